@@ -0,0 +1,176 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/keep-network/keep-common/pkg/persistence"
+
+	"github.com/keep-network/keep-core/config"
+	"github.com/keep-network/keep-core/pkg/bitcoin"
+	"github.com/keep-network/keep-core/pkg/bitcoin/bitcoind"
+	"github.com/keep-network/keep-core/pkg/bitcoin/electrum"
+	"github.com/keep-network/keep-core/pkg/bitcoin/esplora"
+	"github.com/keep-network/keep-core/pkg/bitcoin/quorum"
+	"github.com/keep-network/keep-core/pkg/clientinfo"
+)
+
+// connectBitcoinChain connects to the Bitcoin chain using the backend
+// selected in the given configuration.
+func connectBitcoinChain(
+	ctx context.Context,
+	bitcoinConfig config.BitcoinConfig,
+) (bitcoin.Chain, error) {
+	switch bitcoinConfig.Backend {
+	case config.BitcoinBackendElectrum, "":
+		return electrum.Connect(ctx, bitcoinConfig.Electrum)
+	case config.BitcoinBackendBitcoind:
+		return bitcoind.Connect(ctx, bitcoinConfig.Bitcoind)
+	case config.BitcoinBackendEsplora:
+		return esplora.Connect(ctx, bitcoinConfig.Esplora)
+	case config.BitcoinBackendQuorum:
+		return connectBitcoinQuorum(ctx, bitcoinConfig.Quorum)
+	default:
+		return nil, fmt.Errorf(
+			"unsupported Bitcoin backend [%s]",
+			bitcoinConfig.Backend,
+		)
+	}
+}
+
+// verifyBitcoinChain wraps the given Bitcoin chain backend so block headers,
+// transaction confirmations and merkle proofs returned by the backend are
+// verified locally before they are used. Verified block headers are kept
+// in the given persistence handle; if the handle is nil, they are kept only
+// in memory and fetched again after restart. If syncInBackground is set,
+// block headers are synchronized in the background until the given context
+// is done and the verified chain reports errors until the initial
+// synchronization completes. Otherwise, block headers are synchronized when
+// the verified chain is used.
+func verifyBitcoinChain(
+	ctx context.Context,
+	btcChain bitcoin.Chain,
+	bitcoinConfig config.BitcoinConfig,
+	headersPersistence persistence.BasicHandle,
+	syncInBackground bool,
+) (bitcoin.Chain, error) {
+	headerStore, err := bitcoin.NewHeaderStore(
+		bitcoinConfig.Network,
+		btcChain,
+		headersPersistence,
+		bitcoinConfig.Headers,
+	)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"could not initialize Bitcoin block headers store: [%v]",
+			err,
+		)
+	}
+
+	if syncInBackground {
+		headerStore.StartSync(ctx)
+	}
+
+	return bitcoin.NewVerifiedChain(headerStore), nil
+}
+
+// connectBitcoinQuorum connects to all backends listed in the given
+// configuration and combines them into a single Bitcoin chain handle.
+// Backends are named after their type and position on the list, e.g.
+// `electrum_0`, so they can be identified in logs and metrics.
+func connectBitcoinQuorum(
+	ctx context.Context,
+	quorumConfig config.BitcoinQuorumConfig,
+) (bitcoin.Chain, error) {
+	backends := make([]*quorum.Backend, 0, quorumConfig.BackendsCount())
+
+	connectAll := func(
+		backendType string,
+		count int,
+		connectFn func(index int) (bitcoin.Chain, error),
+	) error {
+		for i := 0; i < count; i++ {
+			name := fmt.Sprintf("%s_%d", backendType, i)
+
+			chain, err := connectFn(i)
+			if err != nil {
+				return fmt.Errorf(
+					"could not connect to backend [%s]: [%v]",
+					name,
+					err,
+				)
+			}
+
+			backends = append(backends, &quorum.Backend{
+				Name:  name,
+				Chain: chain,
+			})
+		}
+
+		return nil
+	}
+
+	if err := connectAll(
+		config.BitcoinBackendElectrum,
+		len(quorumConfig.Electrum),
+		func(index int) (bitcoin.Chain, error) {
+			return electrum.Connect(ctx, quorumConfig.Electrum[index])
+		},
+	); err != nil {
+		return nil, err
+	}
+
+	if err := connectAll(
+		config.BitcoinBackendBitcoind,
+		len(quorumConfig.Bitcoind),
+		func(index int) (bitcoin.Chain, error) {
+			return bitcoind.Connect(ctx, quorumConfig.Bitcoind[index])
+		},
+	); err != nil {
+		return nil, err
+	}
+
+	if err := connectAll(
+		config.BitcoinBackendEsplora,
+		len(quorumConfig.Esplora),
+		func(index int) (bitcoin.Chain, error) {
+			return esplora.Connect(ctx, quorumConfig.Esplora[index])
+		},
+	); err != nil {
+		return nil, err
+	}
+
+	return quorum.Connect(
+		quorum.Config{Threshold: quorumConfig.Threshold},
+		backends,
+	)
+}
+
+// registerBitcoinQuorumSources exposes health of backends combined by the
+// given Bitcoin chain handle through the client info endpoint. It does
+// nothing if the handle does not combine multiple backends.
+func registerBitcoinQuorumSources(
+	clientInfoRegistry *clientinfo.Registry,
+	btcChain bitcoin.Chain,
+) {
+	quorumConnection, ok := btcChain.(*quorum.Connection)
+	if !ok {
+		return
+	}
+
+	sources := make(map[string]clientinfo.Source)
+	for name, source := range quorumConnection.MetricsSources() {
+		sources[name] = source
+	}
+
+	clientInfoRegistry.ObserveApplicationSource("bitcoin_quorum", sources)
+
+	clientInfoRegistry.RegisterApplicationSource(
+		"bitcoin_quorum",
+		func() clientinfo.ApplicationInfo {
+			return clientinfo.ApplicationInfo{
+				"backends": quorumConnection.Health(),
+			}
+		},
+	)
+}
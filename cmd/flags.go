@@ -12,7 +12,9 @@ import (
 	"github.com/keep-network/keep-common/pkg/rate"
 	"github.com/keep-network/keep-core/config"
 	"github.com/keep-network/keep-core/config/network"
+	"github.com/keep-network/keep-core/pkg/bitcoin/bitcoind"
 	"github.com/keep-network/keep-core/pkg/bitcoin/electrum"
+	"github.com/keep-network/keep-core/pkg/bitcoin/esplora"
 	chainEthereum "github.com/keep-network/keep-core/pkg/chain/ethereum"
 	"github.com/keep-network/keep-core/pkg/clientinfo"
 	"github.com/keep-network/keep-core/pkg/maintainer/spv"
@@ -40,7 +42,12 @@ func initFlags(
 		case config.Ethereum:
 			initEthereumFlags(cmd, cfg)
 		case config.BitcoinElectrum:
+			initBitcoinFlags(cmd, cfg)
 			initBitcoinElectrumFlags(cmd, cfg)
+			initBitcoinBitcoindFlags(cmd, cfg)
+			initBitcoinEsploraFlags(cmd, cfg)
+			initBitcoinQuorumFlags(cmd, cfg)
+			initBitcoinHeadersFlags(cmd, cfg)
 		case config.Network:
 			initNetworkFlags(cmd, cfg)
 		case config.Storage:
@@ -156,6 +163,16 @@ func initEthereumFlags(cmd *cobra.Command, cfg *config.Config) {
 	)
 }
 
+// Initialize flags for Bitcoin configuration.
+func initBitcoinFlags(cmd *cobra.Command, cfg *config.Config) {
+	cmd.Flags().StringVar(
+		&cfg.Bitcoin.Backend,
+		"bitcoin.backend",
+		config.BitcoinBackendElectrum,
+		"Backend used to interact with the Bitcoin chain: electrum, bitcoind, esplora or quorum.",
+	)
+}
+
 // Initialize flags for Bitcoin electrum configuration.
 func initBitcoinElectrumFlags(cmd *cobra.Command, cfg *config.Config) {
 	cmd.Flags().StringVar(
@@ -201,6 +218,98 @@ func initBitcoinElectrumFlags(cmd *cobra.Command, cfg *config.Config) {
 	)
 }
 
+// Initialize flags for Bitcoin Core configuration. Credentials of the
+// JSON-RPC server can be set only in the config file.
+func initBitcoinBitcoindFlags(cmd *cobra.Command, cfg *config.Config) {
+	cmd.Flags().StringVar(
+		&cfg.Bitcoin.Bitcoind.URL,
+		"bitcoin.bitcoind.url",
+		"",
+		"URL to the Bitcoin Core JSON-RPC server in format: `scheme://hostname:port`.",
+	)
+
+	cmd.Flags().DurationVar(
+		&cfg.Bitcoin.Bitcoind.RequestTimeout,
+		"bitcoin.bitcoind.requestTimeout",
+		bitcoind.DefaultRequestTimeout,
+		"Timeout for a single attempt of Bitcoin Core JSON-RPC request.",
+	)
+
+	cmd.Flags().DurationVar(
+		&cfg.Bitcoin.Bitcoind.RequestRetryTimeout,
+		"bitcoin.bitcoind.requestRetryTimeout",
+		bitcoind.DefaultRequestRetryTimeout,
+		"Timeout for Bitcoin Core JSON-RPC request retries.",
+	)
+
+	cmd.Flags().UintVar(
+		&cfg.Bitcoin.Bitcoind.ScanStartHeight,
+		"bitcoin.bitcoind.scanStartHeight",
+		0,
+		"Height of the block the Bitcoin Core transaction history scans start from.",
+	)
+}
+
+// Initialize flags for Bitcoin Esplora configuration.
+func initBitcoinEsploraFlags(cmd *cobra.Command, cfg *config.Config) {
+	cmd.Flags().StringVar(
+		&cfg.Bitcoin.Esplora.URL,
+		"bitcoin.esplora.url",
+		"",
+		"URL to the Esplora API in format: `scheme://hostname:port/path`.",
+	)
+
+	cmd.Flags().DurationVar(
+		&cfg.Bitcoin.Esplora.RequestTimeout,
+		"bitcoin.esplora.requestTimeout",
+		esplora.DefaultRequestTimeout,
+		"Timeout for a single attempt of Esplora API request.",
+	)
+
+	cmd.Flags().DurationVar(
+		&cfg.Bitcoin.Esplora.RequestRetryTimeout,
+		"bitcoin.esplora.requestRetryTimeout",
+		esplora.DefaultRequestRetryTimeout,
+		"Timeout for Esplora API request retries.",
+	)
+
+	cmd.Flags().IntVar(
+		&cfg.Bitcoin.Esplora.MaxConnections,
+		"bitcoin.esplora.maxConnections",
+		esplora.DefaultMaxConnections,
+		"Maximum number of connections kept open to the Esplora server.",
+	)
+}
+
+// Initialize flags for Bitcoin quorum configuration. Backends combined by
+// the quorum can be listed only in the config file.
+func initBitcoinQuorumFlags(cmd *cobra.Command, cfg *config.Config) {
+	cmd.Flags().IntVar(
+		&cfg.Bitcoin.Quorum.Threshold,
+		"bitcoin.quorum.threshold",
+		0,
+		"Number of backends that must agree on unspent outputs, confirmations and merkle proofs. Majority of backends if not set.",
+	)
+}
+
+// Initialize flags for the checkpoint of locally verified Bitcoin block
+// headers.
+func initBitcoinHeadersFlags(cmd *cobra.Command, cfg *config.Config) {
+	cmd.Flags().UintVar(
+		&cfg.Bitcoin.Headers.CheckpointHeight,
+		"bitcoin.headers.checkpointHeight",
+		0,
+		"Height of the trusted Bitcoin block header the verification of block headers is anchored at.",
+	)
+
+	cmd.Flags().StringVar(
+		&cfg.Bitcoin.Headers.CheckpointHash,
+		"bitcoin.headers.checkpointHash",
+		"",
+		"Hash of the trusted Bitcoin block header at the checkpoint height, as displayed by block explorers. Required for mainnet. On test networks, the block header 100 blocks below the chain tip is trusted on first use if not set.",
+	)
+}
+
 // Initialize flags for Network configuration.
 func initNetworkFlags(cmd *cobra.Command, cfg *config.Config) {
 	cmd.Flags().BoolVar(
@@ -309,6 +418,48 @@ func initTbtcFlags(cmd *cobra.Command, cfg *config.Config) {
 		tbtc.DefaultKeyGenerationConcurrency,
 		"tECDSA key generation concurrency.",
 	)
+
+	cmd.Flags().DurationVar(
+		&cfg.Tbtc.DepositSweepRefundLocktimeSafetyMargin,
+		"tbtc.depositSweepRefundLocktimeSafetyMargin",
+		tbtc.DepositSweepRefundLocktimeSafetyMargin,
+		"Minimum time that must remain until the refund locktime of deposits proposed for sweeping by the coordination leader; cannot be lower than the default.",
+	)
+
+	cmd.Flags().Int64Var(
+		&cfg.Tbtc.SigningPolicy.MaxTransactionValue,
+		"tbtc.signingPolicy.maxTransactionValue",
+		0,
+		"Maximum total value of a signed wallet transaction's outputs in satoshi, excluding change paid back to the wallet. (0 = no limit)",
+	)
+
+	cmd.Flags().Int64Var(
+		&cfg.Tbtc.SigningPolicy.MaxFeeRate,
+		"tbtc.signingPolicy.maxFeeRate",
+		0,
+		"Maximum fee rate of a signed wallet transaction in satoshi per vbyte. (0 = no limit)",
+	)
+
+	cmd.Flags().StringSliceVar(
+		&cfg.Tbtc.SigningPolicy.AllowedOutputScriptTypes,
+		"tbtc.signingPolicy.allowedOutputScriptTypes",
+		[]string{},
+		"Script types allowed in outputs of signed wallet transactions: P2PKH, P2WPKH, P2SH, P2WSH, P2TR. (empty = all)",
+	)
+
+	cmd.Flags().IntVar(
+		&cfg.Tbtc.SigningPolicy.MaxTransactionsPerWalletPerDay,
+		"tbtc.signingPolicy.maxTransactionsPerWalletPerDay",
+		0,
+		"Maximum number of transactions signed by a single wallet within 24 hours. (0 = no limit)",
+	)
+
+	cmd.Flags().StringSliceVar(
+		&cfg.Tbtc.SigningPolicy.DeniedRedeemerOutputScripts,
+		"tbtc.signingPolicy.deniedRedeemerOutputScripts",
+		[]string{},
+		"Hex-encoded output scripts signed wallet transactions must never pay to.",
+	)
 }
 
 // Initialize flags for Maintainer configuration.
@@ -80,6 +80,13 @@ var cmdFlagsTests = map[string]struct {
 		expectedValueFromFlag: big.NewInt(1250000000000000000),
 		defaultValue:          big.NewInt(500000000000000000),
 	},
+	"bitcoin.backend": {
+		readValueFunc:         func(c *config.Config) interface{} { return c.Bitcoin.Backend },
+		flagName:              "--bitcoin.backend",
+		flagValue:             "bitcoind",
+		expectedValueFromFlag: "bitcoind",
+		defaultValue:          "electrum",
+	},
 	"bitcoin.electrum.url": {
 		readValueFunc:         func(c *config.Config) interface{} { return c.Bitcoin.Electrum.URL },
 		flagName:              "--bitcoin.electrum.url",
@@ -122,6 +129,83 @@ var cmdFlagsTests = map[string]struct {
 		expectedValueFromFlag: 660 * time.Second,
 		defaultValue:          300 * time.Second,
 	},
+	"bitcoin.bitcoind.url": {
+		readValueFunc:         func(c *config.Config) interface{} { return c.Bitcoin.Bitcoind.URL },
+		flagName:              "--bitcoin.bitcoind.url",
+		flagValue:             "http://url.to.bitcoind:18332",
+		expectedValueFromFlag: "http://url.to.bitcoind:18332",
+		defaultValue:          "",
+	},
+	"bitcoin.bitcoind.requestTimeout": {
+		readValueFunc:         func(c *config.Config) interface{} { return c.Bitcoin.Bitcoind.RequestTimeout },
+		flagName:              "--bitcoin.bitcoind.requestTimeout",
+		flagValue:             "4m",
+		expectedValueFromFlag: 240 * time.Second,
+		defaultValue:          180 * time.Second,
+	},
+	"bitcoin.bitcoind.requestRetryTimeout": {
+		readValueFunc:         func(c *config.Config) interface{} { return c.Bitcoin.Bitcoind.RequestRetryTimeout },
+		flagName:              "--bitcoin.bitcoind.requestRetryTimeout",
+		flagValue:             "9m",
+		expectedValueFromFlag: 540 * time.Second,
+		defaultValue:          360 * time.Second,
+	},
+	"bitcoin.bitcoind.scanStartHeight": {
+		readValueFunc:         func(c *config.Config) interface{} { return c.Bitcoin.Bitcoind.ScanStartHeight },
+		flagName:              "--bitcoin.bitcoind.scanStartHeight",
+		flagValue:             "2130000",
+		expectedValueFromFlag: uint(2130000),
+		defaultValue:          uint(0),
+	},
+	"bitcoin.esplora.url": {
+		readValueFunc:         func(c *config.Config) interface{} { return c.Bitcoin.Esplora.URL },
+		flagName:              "--bitcoin.esplora.url",
+		flagValue:             "https://url.to.esplora/testnet/api",
+		expectedValueFromFlag: "https://url.to.esplora/testnet/api",
+		defaultValue:          "",
+	},
+	"bitcoin.esplora.requestTimeout": {
+		readValueFunc:         func(c *config.Config) interface{} { return c.Bitcoin.Esplora.RequestTimeout },
+		flagName:              "--bitcoin.esplora.requestTimeout",
+		flagValue:             "45s",
+		expectedValueFromFlag: 45 * time.Second,
+		defaultValue:          30 * time.Second,
+	},
+	"bitcoin.esplora.requestRetryTimeout": {
+		readValueFunc:         func(c *config.Config) interface{} { return c.Bitcoin.Esplora.RequestRetryTimeout },
+		flagName:              "--bitcoin.esplora.requestRetryTimeout",
+		flagValue:             "3m",
+		expectedValueFromFlag: 180 * time.Second,
+		defaultValue:          120 * time.Second,
+	},
+	"bitcoin.esplora.maxConnections": {
+		readValueFunc:         func(c *config.Config) interface{} { return c.Bitcoin.Esplora.MaxConnections },
+		flagName:              "--bitcoin.esplora.maxConnections",
+		flagValue:             "32",
+		expectedValueFromFlag: 32,
+		defaultValue:          16,
+	},
+	"bitcoin.quorum.threshold": {
+		readValueFunc:         func(c *config.Config) interface{} { return c.Bitcoin.Quorum.Threshold },
+		flagName:              "--bitcoin.quorum.threshold",
+		flagValue:             "2",
+		expectedValueFromFlag: 2,
+		defaultValue:          0,
+	},
+	"bitcoin.headers.checkpointHeight": {
+		readValueFunc:         func(c *config.Config) interface{} { return c.Bitcoin.Headers.CheckpointHeight },
+		flagName:              "--bitcoin.headers.checkpointHeight",
+		flagValue:             "2164152",
+		expectedValueFromFlag: uint(2164152),
+		defaultValue:          uint(0),
+	},
+	"bitcoin.headers.checkpointHash": {
+		readValueFunc:         func(c *config.Config) interface{} { return c.Bitcoin.Headers.CheckpointHash },
+		flagName:              "--bitcoin.headers.checkpointHash",
+		flagValue:             "00000000000013e457bd86d1b6f0b933c2c9500e08dd3eef862ec4e5238b316c",
+		expectedValueFromFlag: "00000000000013e457bd86d1b6f0b933c2c9500e08dd3eef862ec4e5238b316c",
+		defaultValue:          "",
+	},
 	"network.bootstrap": {
 		readValueFunc:         func(c *config.Config) interface{} { return c.LibP2P.Bootstrap },
 		flagName:              "--network.bootstrap",
@@ -225,6 +309,41 @@ var cmdFlagsTests = map[string]struct {
 		expectedValueFromFlag: 101,
 		defaultValue:          runtime.GOMAXPROCS(0),
 	},
+	"tbtc.signingPolicy.maxTransactionValue": {
+		readValueFunc:         func(c *config.Config) interface{} { return c.Tbtc.SigningPolicy.MaxTransactionValue },
+		flagName:              "--tbtc.signingPolicy.maxTransactionValue",
+		flagValue:             "100000000",
+		expectedValueFromFlag: int64(100000000),
+		defaultValue:          int64(0),
+	},
+	"tbtc.signingPolicy.maxFeeRate": {
+		readValueFunc:         func(c *config.Config) interface{} { return c.Tbtc.SigningPolicy.MaxFeeRate },
+		flagName:              "--tbtc.signingPolicy.maxFeeRate",
+		flagValue:             "150",
+		expectedValueFromFlag: int64(150),
+		defaultValue:          int64(0),
+	},
+	"tbtc.signingPolicy.allowedOutputScriptTypes": {
+		readValueFunc:         func(c *config.Config) interface{} { return c.Tbtc.SigningPolicy.AllowedOutputScriptTypes },
+		flagName:              "--tbtc.signingPolicy.allowedOutputScriptTypes",
+		flagValue:             "P2WPKH,P2WSH",
+		expectedValueFromFlag: []string{"P2WPKH", "P2WSH"},
+		defaultValue:          []string{},
+	},
+	"tbtc.signingPolicy.maxTransactionsPerWalletPerDay": {
+		readValueFunc:         func(c *config.Config) interface{} { return c.Tbtc.SigningPolicy.MaxTransactionsPerWalletPerDay },
+		flagName:              "--tbtc.signingPolicy.maxTransactionsPerWalletPerDay",
+		flagValue:             "24",
+		expectedValueFromFlag: 24,
+		defaultValue:          0,
+	},
+	"tbtc.signingPolicy.deniedRedeemerOutputScripts": {
+		readValueFunc:         func(c *config.Config) interface{} { return c.Tbtc.SigningPolicy.DeniedRedeemerOutputScripts },
+		flagName:              "--tbtc.signingPolicy.deniedRedeemerOutputScripts",
+		flagValue:             "0x00148db50eb52063ea9d98b3eac91489a90f738986f6",
+		expectedValueFromFlag: []string{"0x00148db50eb52063ea9d98b3eac91489a90f738986f6"},
+		defaultValue:          []string{},
+	},
 	"maintainer.bitcoinDifficulty": {
 		readValueFunc:         func(c *config.Config) interface{} { return c.Maintainer.BitcoinDifficulty.Enabled },
 		flagName:              "--bitcoinDifficulty",
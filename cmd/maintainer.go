@@ -7,7 +7,6 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/keep-network/keep-core/config"
-	"github.com/keep-network/keep-core/pkg/bitcoin/electrum"
 	"github.com/keep-network/keep-core/pkg/chain/ethereum"
 	"github.com/keep-network/keep-core/pkg/maintainer"
 )
@@ -37,6 +36,14 @@ func init() {
 		clientConfig,
 		config.MaintainerCategories...,
 	)
+
+	MaintainerCommand.Flags().Bool(
+		verifyHeadersFlagName,
+		false,
+		"Verify Bitcoin block headers locally before they are used in SPV "+
+			"proofs. The verification starts from the block header checkpoint "+
+			"set in the Bitcoin headers configuration.",
+	)
 }
 
 // maintainers initializes maintainer tasks specified by flags passed to the
@@ -44,9 +51,29 @@ func init() {
 func maintainers(cmd *cobra.Command, args []string) error {
 	ctx := context.Background()
 
-	btcChain, err := electrum.Connect(ctx, clientConfig.Bitcoin.Electrum)
+	btcChain, err := connectBitcoinChain(ctx, clientConfig.Bitcoin)
+	if err != nil {
+		return fmt.Errorf("could not connect to Bitcoin chain: [%v]", err)
+	}
+
+	verifyHeaders, err := cmd.Flags().GetBool(verifyHeadersFlagName)
 	if err != nil {
-		return fmt.Errorf("could not connect to Electrum chain: [%v]", err)
+		return fmt.Errorf("failed to find verify headers flag: [%v]", err)
+	}
+
+	if verifyHeaders {
+		// The maintainer does not use the storage so the verified block
+		// headers are kept only in memory.
+		btcChain, err = verifyBitcoinChain(
+			ctx,
+			btcChain,
+			clientConfig.Bitcoin,
+			nil,
+			true,
+		)
+		if err != nil {
+			return fmt.Errorf("could not verify Bitcoin chain: [%v]", err)
+		}
 	}
 
 	btcDiffChain, err := ethereum.ConnectBitcoinDifficulty(
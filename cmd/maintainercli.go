@@ -13,10 +13,10 @@ import (
 	"github.com/keep-network/keep-core/config"
 	"github.com/keep-network/keep-core/internal/hexutils"
 	"github.com/keep-network/keep-core/pkg/bitcoin"
-	"github.com/keep-network/keep-core/pkg/bitcoin/electrum"
 	"github.com/keep-network/keep-core/pkg/chain/ethereum"
 	"github.com/keep-network/keep-core/pkg/maintainer/spv"
 	walletmtr "github.com/keep-network/keep-core/pkg/maintainer/wallet"
+	"github.com/keep-network/keep-core/pkg/tbtc"
 )
 
 var (
@@ -46,6 +46,11 @@ var (
 	// submitRedemptionProofCommand:
 	transactionHashFlagName = "transaction-hash"
 	confirmationsFlagName   = "confirmations"
+
+	// submitDepositSweepProofCommand:
+	// submitRedemptionProofCommand:
+	// MaintainerCommand:
+	verifyHeadersFlagName = "verify-headers"
 )
 
 // MaintainerCliCommand contains the definition of tools associated with maintainers
@@ -100,9 +105,9 @@ var listDepositsCommand = cobra.Command{
 			)
 		}
 
-		btcChain, err := electrum.Connect(ctx, clientConfig.Bitcoin.Electrum)
+		btcChain, err := connectBitcoinChain(ctx, clientConfig.Bitcoin)
 		if err != nil {
-			return fmt.Errorf("could not connect to Electrum chain: [%v]", err)
+			return fmt.Errorf("could not connect to Bitcoin chain: [%v]", err)
 		}
 
 		var walletPublicKeyHash [20]byte
@@ -124,6 +129,8 @@ var listDepositsCommand = cobra.Command{
 			head,
 			hideSwept,
 			false,
+			0,
+			nil,
 		)
 		if err != nil {
 			return fmt.Errorf(
@@ -221,9 +228,9 @@ var proposeDepositsSweepCommand = cobra.Command{
 			)
 		}
 
-		btcChain, err := electrum.Connect(ctx, clientConfig.Bitcoin.Electrum)
+		btcChain, err := connectBitcoinChain(ctx, clientConfig.Bitcoin)
 		if err != nil {
-			return fmt.Errorf("could not connect to Electrum chain: [%v]", err)
+			return fmt.Errorf("could not connect to Bitcoin chain: [%v]", err)
 		}
 
 		var walletPublicKeyHash [20]byte
@@ -254,6 +261,8 @@ var proposeDepositsSweepCommand = cobra.Command{
 				btcChain,
 				walletPublicKeyHash,
 				depositSweepMaxSize,
+				tbtc.DepositSweepRefundLocktimeSafetyMargin,
+				nil,
 			)
 			if err != nil {
 				return fmt.Errorf("failed to prepare deposits sweep proposal: %v", err)
@@ -274,6 +283,7 @@ var proposeDepositsSweepCommand = cobra.Command{
 			walletPublicKeyHash,
 			fee,
 			deposits,
+			tbtc.DepositSweepRefundLocktimeSafetyMargin,
 			dryRun,
 		)
 	},
@@ -401,9 +411,9 @@ var proposeRedemptionCommand = cobra.Command{
 			)
 		}
 
-		btcChain, err := electrum.Connect(cmd.Context(), clientConfig.Bitcoin.Electrum)
+		btcChain, err := connectBitcoinChain(cmd.Context(), clientConfig.Bitcoin)
 		if err != nil {
-			return fmt.Errorf("could not connect to Electrum chain: [%v]", err)
+			return fmt.Errorf("could not connect to Bitcoin chain: [%v]", err)
 		}
 
 		var walletPublicKeyHashes [][20]byte
@@ -475,9 +485,9 @@ var estimateDepositsSweepFeeCommand = cobra.Command{
 			)
 		}
 
-		btcChain, err := electrum.Connect(ctx, clientConfig.Bitcoin.Electrum)
+		btcChain, err := connectBitcoinChain(ctx, clientConfig.Bitcoin)
 		if err != nil {
-			return fmt.Errorf("could not connect to Electrum chain: [%v]", err)
+			return fmt.Errorf("could not connect to Bitcoin chain: [%v]", err)
 		}
 
 		fees, err := walletmtr.EstimateDepositsSweepFee(
@@ -590,9 +600,27 @@ var submitDepositSweepProofCommand = cobra.Command{
 			)
 		}
 
-		btcChain, err := electrum.Connect(ctx, clientConfig.Bitcoin.Electrum)
+		btcChain, err := connectBitcoinChain(ctx, clientConfig.Bitcoin)
 		if err != nil {
-			return fmt.Errorf("could not connect to Electrum chain: [%v]", err)
+			return fmt.Errorf("could not connect to Bitcoin chain: [%v]", err)
+		}
+
+		verifyHeaders, err := cmd.Flags().GetBool(verifyHeadersFlagName)
+		if err != nil {
+			return fmt.Errorf("failed to find verify headers flag: [%v]", err)
+		}
+
+		if verifyHeaders {
+			btcChain, err = verifyBitcoinChain(
+				ctx,
+				btcChain,
+				clientConfig.Bitcoin,
+				nil,
+				false,
+			)
+			if err != nil {
+				return fmt.Errorf("could not verify Bitcoin chain: [%v]", err)
+			}
 		}
 
 		transactionHashFlag, err := cmd.Flags().GetString(transactionHashFlagName)
@@ -680,9 +708,27 @@ var submitRedemptionProofCommand = cobra.Command{
 			)
 		}
 
-		btcChain, err := electrum.Connect(ctx, clientConfig.Bitcoin.Electrum)
+		btcChain, err := connectBitcoinChain(ctx, clientConfig.Bitcoin)
 		if err != nil {
-			return fmt.Errorf("could not connect to Electrum chain: [%v]", err)
+			return fmt.Errorf("could not connect to Bitcoin chain: [%v]", err)
+		}
+
+		verifyHeaders, err := cmd.Flags().GetBool(verifyHeadersFlagName)
+		if err != nil {
+			return fmt.Errorf("failed to find verify headers flag: [%v]", err)
+		}
+
+		if verifyHeaders {
+			btcChain, err = verifyBitcoinChain(
+				ctx,
+				btcChain,
+				clientConfig.Bitcoin,
+				nil,
+				false,
+			)
+			if err != nil {
+				return fmt.Errorf("could not verify Bitcoin chain: [%v]", err)
+			}
 		}
 
 		transactionHashFlag, err := cmd.Flags().GetString(transactionHashFlagName)
@@ -868,6 +914,14 @@ func init() {
 			"retrieved from the Bridge will be used.",
 	)
 
+	submitDepositSweepProofCommand.Flags().Bool(
+		verifyHeadersFlagName,
+		false,
+		"(optional) verify Bitcoin block headers locally before the proof is "+
+			"assembled. The verification starts from the block header "+
+			"checkpoint set in the Bitcoin headers configuration.",
+	)
+
 	MaintainerCliCommand.AddCommand(&submitDepositSweepProofCommand)
 
 	// Submit Redemption Proof Subcommand.
@@ -895,6 +949,14 @@ func init() {
 			"retrieved from the Bridge will be used.",
 	)
 
+	submitRedemptionProofCommand.Flags().Bool(
+		verifyHeadersFlagName,
+		false,
+		"(optional) verify Bitcoin block headers locally before the proof is "+
+			"assembled. The verification starts from the block header "+
+			"checkpoint set in the Bitcoin headers configuration.",
+	)
+
 	MaintainerCliCommand.AddCommand(&submitRedemptionProofCommand)
 }
 
@@ -0,0 +1,180 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/keep-network/keep-core/config"
+	"github.com/keep-network/keep-core/internal/hexutils"
+	"github.com/keep-network/keep-core/pkg/bitcoin"
+	"github.com/keep-network/keep-core/pkg/storage"
+	"github.com/keep-network/keep-core/pkg/tbtc"
+)
+
+// SigningAuditCommand contains the definition of tools associated with the
+// tECDSA signing audit log kept by the client.
+var SigningAuditCommand = &cobra.Command{
+	Use:   "signing-audit",
+	Short: "Signing audit log tools",
+	Long: "The tool exposes commands allowing to inspect the audit log of " +
+		"signatures produced by wallets controlled by the client.",
+	TraverseChildren: true,
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		if err := clientConfig.ReadConfig(
+			configFilePath,
+			cmd.Flags(),
+			config.General, config.Storage,
+		); err != nil {
+			logger.Fatalf("error reading config: %v", err)
+		}
+	},
+}
+
+var listSigningAuditCommand = cobra.Command{
+	Use:              "list",
+	Short:            "list signing audit log entries",
+	Long:             "Lists entries of the signing audit log ordered by their sequence numbers.",
+	TraverseChildren: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		wallet, err := cmd.Flags().GetString(walletFlagName)
+		if err != nil {
+			return fmt.Errorf("failed to find wallet flag: %v", err)
+		}
+
+		var walletPublicKeyHash [20]byte
+		if len(wallet) > 0 {
+			walletPublicKeyHash, err = newWalletPublicKeyHash(wallet)
+			if err != nil {
+				return fmt.Errorf(
+					"failed to extract wallet public key hash: %v",
+					err,
+				)
+			}
+		}
+
+		entries, err := loadSigningAuditLog()
+		if err != nil {
+			return err
+		}
+
+		if len(wallet) > 0 {
+			filteredEntries := make([]*tbtc.SigningAuditEntry, 0)
+			for _, entry := range entries {
+				if entry.WalletPublicKeyHash == walletPublicKeyHash {
+					filteredEntries = append(filteredEntries, entry)
+				}
+			}
+			entries = filteredEntries
+		}
+
+		if err := printSigningAuditTable(entries); err != nil {
+			return fmt.Errorf("failed to print signing audit table: %v", err)
+		}
+
+		return nil
+	},
+}
+
+func printSigningAuditTable(entries []*tbtc.SigningAuditEntry) error {
+	w := tabwriter.NewWriter(os.Stdout, 2, 4, 1, ' ', tabwriter.AlignRight)
+	fmt.Fprintf(w, "sequence\ttimestamp\twallet\taction\ttransaction\tmessage\tparticipating members\tsignature\t\n")
+
+	for _, entry := range entries {
+		transaction := "-"
+		if entry.TransactionHash != (bitcoin.Hash{}) {
+			transaction = entry.TransactionHash.Hex(bitcoin.ReversedByteOrder)
+		}
+
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\t0x%064x\t%v\t%s\t\n",
+			entry.Sequence,
+			entry.Timestamp.UTC().Format("2006-01-02 15:04:05"),
+			hexutils.Encode(entry.WalletPublicKeyHash[:]),
+			entry.ActionType,
+			transaction,
+			entry.Message,
+			entry.ParticipatingMembersIndexes,
+			entry.Signature,
+		)
+	}
+
+	if err := w.Flush(); err != nil {
+		return fmt.Errorf("failed to flush the writer: %v", err)
+	}
+
+	return nil
+}
+
+var verifySigningAuditCommand = cobra.Command{
+	Use:              "verify",
+	Short:            "verify signing audit log integrity",
+	Long:             "Verifies the integrity of the signing audit log hash chain.",
+	TraverseChildren: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		entries, err := loadSigningAuditLog()
+		if err != nil {
+			return err
+		}
+
+		if err := tbtc.VerifySigningAuditLog(entries); err != nil {
+			return fmt.Errorf("signing audit log integrity is broken: [%v]", err)
+		}
+
+		logger.Infof(
+			"signing audit log integrity verified; [%v] entries are intact",
+			len(entries),
+		)
+
+		return nil
+	},
+}
+
+// loadSigningAuditLog loads the signing audit log from the tBTC work
+// persistence of the client.
+func loadSigningAuditLog() ([]*tbtc.SigningAuditEntry, error) {
+	storage, err := storage.Initialize(
+		clientConfig.Storage,
+		clientConfig.Ethereum.KeyFilePassword,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("cannot initialize storage: [%w]", err)
+	}
+
+	tbtcDataPersistence, err := storage.InitializeWorkPersistence("tbtc")
+	if err != nil {
+		return nil, fmt.Errorf(
+			"cannot initialize tbtc data persistence: [%w]",
+			err,
+		)
+	}
+
+	entries, err := tbtc.LoadSigningAuditLog(tbtcDataPersistence)
+	if err != nil {
+		return nil, fmt.Errorf("cannot load signing audit log: [%w]", err)
+	}
+
+	return entries, nil
+}
+
+func init() {
+	initFlags(
+		SigningAuditCommand,
+		&configFilePath,
+		clientConfig,
+		config.Storage,
+	)
+
+	// List Subcommand
+	listSigningAuditCommand.Flags().String(
+		walletFlagName,
+		"",
+		"wallet public key hash",
+	)
+
+	SigningAuditCommand.AddCommand(&listSigningAuditCommand)
+
+	// Verify Subcommand
+	SigningAuditCommand.AddCommand(&verifySigningAuditCommand)
+}
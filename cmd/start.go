@@ -6,7 +6,6 @@ import (
 
 	"github.com/keep-network/keep-common/pkg/persistence"
 	"github.com/keep-network/keep-core/build"
-	"github.com/keep-network/keep-core/pkg/bitcoin/electrum"
 	"github.com/keep-network/keep-core/pkg/operator"
 	"github.com/keep-network/keep-core/pkg/storage"
 
@@ -23,6 +22,7 @@ import (
 	"github.com/keep-network/keep-core/pkg/net/libp2p"
 	"github.com/keep-network/keep-core/pkg/net/retransmission"
 	"github.com/keep-network/keep-core/pkg/tbtc"
+	"github.com/keep-network/keep-core/pkg/tbtcpg"
 )
 
 // StartCommand contains the definition of the start command-line subcommand.
@@ -90,19 +90,31 @@ func start(cmd *cobra.Command) error {
 	// Skip initialization for bootstrap nodes as they are only used for network
 	// discovery.
 	if !isBootstrap() {
-		btcChain, err := electrum.Connect(ctx, clientConfig.Bitcoin.Electrum)
+		btcBackend, err := connectBitcoinChain(ctx, clientConfig.Bitcoin)
 		if err != nil {
-			return fmt.Errorf("could not connect to Electrum chain: [%v]", err)
+			return fmt.Errorf("could not connect to Bitcoin chain: [%v]", err)
 		}
 
 		beaconKeyStorePersistence,
 			tbtcKeyStorePersistence,
 			tbtcDataPersistence,
+			bitcoinDataPersistence,
 			err := initializePersistence()
 		if err != nil {
 			return fmt.Errorf("cannot initialize persistence: [%w]", err)
 		}
 
+		btcChain, err := verifyBitcoinChain(
+			ctx,
+			btcBackend,
+			clientConfig.Bitcoin,
+			bitcoinDataPersistence,
+			true,
+		)
+		if err != nil {
+			return fmt.Errorf("could not verify Bitcoin chain: [%v]", err)
+		}
+
 		scheduler := generator.StartScheduler()
 
 		clientInfoRegistry.ObserveBtcConnectivity(
@@ -112,6 +124,10 @@ func start(cmd *cobra.Command) error {
 
 		clientInfoRegistry.RegisterBtcChainInfoSource(btcChain)
 
+		if clientInfoRegistry != nil {
+			registerBitcoinQuorumSources(clientInfoRegistry, btcBackend)
+		}
+
 		err = beacon.Initialize(
 			ctx,
 			beaconChain,
@@ -123,6 +139,15 @@ func start(cmd *cobra.Command) error {
 			return fmt.Errorf("error initializing beacon: [%v]", err)
 		}
 
+		proposalGenerator, err := tbtcpg.NewProposalGenerator(
+			tbtcChain,
+			btcChain,
+			clientConfig.Tbtc.DepositSweepRefundLocktimeSafetyMargin,
+		)
+		if err != nil {
+			return fmt.Errorf("error initializing proposal generator: [%v]", err)
+		}
+
 		err = tbtc.Initialize(
 			ctx,
 			tbtcChain,
@@ -131,6 +156,7 @@ func start(cmd *cobra.Command) error {
 			tbtcKeyStorePersistence,
 			tbtcDataPersistence,
 			scheduler,
+			proposalGenerator,
 			clientConfig.Tbtc,
 			clientInfoRegistry,
 		)
@@ -243,6 +269,7 @@ func initializePersistence() (
 	beaconKeyStorePersistence persistence.ProtectedHandle,
 	tbtcKeyStorePersistence persistence.ProtectedHandle,
 	tbtcDataPersistence persistence.BasicHandle,
+	bitcoinDataPersistence persistence.BasicHandle,
 	err error,
 ) {
 	storage, err := storage.Initialize(
@@ -250,14 +277,14 @@ func initializePersistence() (
 		clientConfig.Ethereum.KeyFilePassword,
 	)
 	if err != nil {
-		return nil, nil, nil, fmt.Errorf("cannot initialize storage: [%w]", err)
+		return nil, nil, nil, nil, fmt.Errorf("cannot initialize storage: [%w]", err)
 	}
 
 	beaconKeyStorePersistence, err = storage.InitializeKeyStorePersistence(
 		"beacon",
 	)
 	if err != nil {
-		return nil, nil, nil, fmt.Errorf(
+		return nil, nil, nil, nil, fmt.Errorf(
 			"cannot initialize beacon keystore persistence: [%w]",
 			err,
 		)
@@ -267,7 +294,7 @@ func initializePersistence() (
 		"tbtc",
 	)
 	if err != nil {
-		return nil, nil, nil, fmt.Errorf(
+		return nil, nil, nil, nil, fmt.Errorf(
 			"cannot initialize tbtc keystore persistence: [%w]",
 			err,
 		)
@@ -275,11 +302,19 @@ func initializePersistence() (
 
 	tbtcDataPersistence, err = storage.InitializeWorkPersistence("tbtc")
 	if err != nil {
-		return nil, nil, nil, fmt.Errorf(
+		return nil, nil, nil, nil, fmt.Errorf(
 			"cannot initialize tbtc data persistence: [%w]",
 			err,
 		)
 	}
 
+	bitcoinDataPersistence, err = storage.InitializeWorkPersistence("bitcoin")
+	if err != nil {
+		return nil, nil, nil, nil, fmt.Errorf(
+			"cannot initialize bitcoin data persistence: [%w]",
+			err,
+		)
+	}
+
 	return
 }
@@ -0,0 +1,184 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/keep-network/keep-common/pkg/persistence"
+
+	"github.com/keep-network/keep-core/config"
+	"github.com/keep-network/keep-core/internal/hexutils"
+	"github.com/keep-network/keep-core/pkg/storage"
+	"github.com/keep-network/keep-core/pkg/tbtc"
+)
+
+// WalletArchiveCommand contains the definition of tools associated with
+// the archive of tECDSA wallets that are closed or terminated on-chain.
+var WalletArchiveCommand = &cobra.Command{
+	Use:   "wallet-archive",
+	Short: "Wallet archive tools",
+	Long: "The tool exposes commands allowing to inspect and restore key " +
+		"shares of wallets archived by the client after they were closed " +
+		"or terminated on-chain.",
+	TraverseChildren: true,
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		if err := clientConfig.ReadConfig(
+			configFilePath,
+			cmd.Flags(),
+			config.General, config.Storage,
+		); err != nil {
+			logger.Fatalf("error reading config: %v", err)
+		}
+	},
+}
+
+var listWalletArchiveCommand = cobra.Command{
+	Use:              "list",
+	Short:            "list archived wallets",
+	Long:             "Lists wallets whose key shares were archived by the client.",
+	TraverseChildren: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		archive, _, err := initializeWalletArchivePersistence()
+		if err != nil {
+			return err
+		}
+
+		wallets, err := tbtc.LoadArchivedWallets(archive)
+		if err != nil {
+			return fmt.Errorf("cannot load archived wallets: [%v]", err)
+		}
+
+		if err := printWalletArchiveTable(wallets); err != nil {
+			return fmt.Errorf("failed to print wallet archive table: %v", err)
+		}
+
+		return nil
+	},
+}
+
+func printWalletArchiveTable(wallets []*tbtc.ArchivedWallet) error {
+	w := tabwriter.NewWriter(os.Stdout, 2, 4, 1, ' ', tabwriter.AlignRight)
+	fmt.Fprintf(w, "wallet\tmembers\t\n")
+
+	for _, wallet := range wallets {
+		fmt.Fprintf(w, "%s\t%v\t\n",
+			hexutils.Encode(wallet.WalletPublicKeyHash[:]),
+			wallet.MembersIndexes,
+		)
+	}
+
+	if err := w.Flush(); err != nil {
+		return fmt.Errorf("failed to flush the writer: %v", err)
+	}
+
+	return nil
+}
+
+var restoreWalletArchiveCommand = cobra.Command{
+	Use:   "restore",
+	Short: "restore archived wallet",
+	Long: "Restores key shares of the given archived wallet so they are " +
+		"loaded on the next client start. The client must not be running " +
+		"while the wallet is restored.",
+	TraverseChildren: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		wallet, err := cmd.Flags().GetString(walletFlagName)
+		if err != nil {
+			return fmt.Errorf("failed to find wallet flag: %v", err)
+		}
+
+		walletPublicKeyHash, err := newWalletPublicKeyHash(wallet)
+		if err != nil {
+			return fmt.Errorf(
+				"failed to extract wallet public key hash: %v",
+				err,
+			)
+		}
+
+		archive, keyStore, err := initializeWalletArchivePersistence()
+		if err != nil {
+			return err
+		}
+
+		restored, err := tbtc.RestoreArchivedWallet(
+			archive,
+			keyStore,
+			walletPublicKeyHash,
+		)
+		if err != nil {
+			return fmt.Errorf("cannot restore archived wallet: [%v]", err)
+		}
+
+		logger.Infof(
+			"restored [%v] key shares of wallet [%s]",
+			restored,
+			hexutils.Encode(walletPublicKeyHash[:]),
+		)
+
+		return nil
+	},
+}
+
+// initializeWalletArchivePersistence initializes the tBTC key store
+// persistence of the client along with the persistence over its archive.
+func initializeWalletArchivePersistence() (
+	persistence.BasicHandle,
+	persistence.ProtectedHandle,
+	error,
+) {
+	storage, err := storage.Initialize(
+		clientConfig.Storage,
+		clientConfig.Ethereum.KeyFilePassword,
+	)
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot initialize storage: [%w]", err)
+	}
+
+	keyStore, err := storage.InitializeKeyStorePersistence("tbtc")
+	if err != nil {
+		return nil, nil, fmt.Errorf(
+			"cannot initialize tbtc keystore persistence: [%w]",
+			err,
+		)
+	}
+
+	archive, err := storage.InitializeKeyStoreArchivePersistence("tbtc")
+	if err != nil {
+		return nil, nil, fmt.Errorf(
+			"cannot initialize tbtc keystore archive persistence: [%w]",
+			err,
+		)
+	}
+
+	return archive, keyStore, nil
+}
+
+func init() {
+	initFlags(
+		WalletArchiveCommand,
+		&configFilePath,
+		clientConfig,
+		config.Storage,
+	)
+
+	// List Subcommand
+	WalletArchiveCommand.AddCommand(&listWalletArchiveCommand)
+
+	// Restore Subcommand
+	restoreWalletArchiveCommand.Flags().String(
+		walletFlagName,
+		"",
+		"wallet public key hash",
+	)
+
+	if err := restoreWalletArchiveCommand.MarkFlagRequired(
+		walletFlagName,
+	); err != nil {
+		logger.Fatalf("failed to mark flag required: [%v]", err)
+	}
+
+	WalletArchiveCommand.AddCommand(&restoreWalletArchiveCommand)
+}
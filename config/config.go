@@ -19,7 +19,9 @@ import (
 	"golang.org/x/term"
 
 	commonEthereum "github.com/keep-network/keep-common/pkg/chain/ethereum"
+	"github.com/keep-network/keep-core/pkg/bitcoin/bitcoind"
 	"github.com/keep-network/keep-core/pkg/bitcoin/electrum"
+	"github.com/keep-network/keep-core/pkg/bitcoin/esplora"
 	"github.com/keep-network/keep-core/pkg/clientinfo"
 	"github.com/keep-network/keep-core/pkg/maintainer"
 	"github.com/keep-network/keep-core/pkg/net/libp2p"
@@ -39,6 +41,21 @@ const (
 	LogLevelEnvVariable = "LOG_LEVEL"
 )
 
+const (
+	// BitcoinBackendElectrum denotes the Bitcoin chain backend connecting to
+	// an Electrum server. It is the default backend.
+	BitcoinBackendElectrum = "electrum"
+	// BitcoinBackendBitcoind denotes the Bitcoin chain backend connecting to
+	// a Bitcoin Core node over JSON-RPC.
+	BitcoinBackendBitcoind = "bitcoind"
+	// BitcoinBackendEsplora denotes the Bitcoin chain backend connecting to
+	// an Esplora REST API.
+	BitcoinBackendEsplora = "esplora"
+	// BitcoinBackendQuorum denotes the Bitcoin chain backend combining
+	// multiple Electrum, Bitcoin Core and Esplora backends.
+	BitcoinBackendQuorum = "quorum"
+)
+
 // Config is the top level config structure.
 type Config struct {
 	Ethereum   commonEthereum.Config
@@ -53,8 +70,43 @@ type Config struct {
 // BitcoinConfig defines the configuration for Bitcoin.
 type BitcoinConfig struct {
 	bitcoin.Network
+	// Backend is the name of the backend used to interact with the Bitcoin
+	// chain. Supported values are `electrum` (default), `bitcoind`,
+	// `esplora` and `quorum`.
+	Backend string
 	// Electrum defines the configuration for the Electrum client.
 	Electrum electrum.Config
+	// Bitcoind defines the configuration for the Bitcoin Core JSON-RPC client.
+	Bitcoind bitcoind.Config
+	// Esplora defines the configuration for the Esplora REST API client.
+	Esplora esplora.Config
+	// Quorum defines the configuration for the backend combining multiple
+	// backends.
+	Quorum BitcoinQuorumConfig
+	// Headers defines the checkpoint the local verification of block
+	// headers is anchored at.
+	Headers bitcoin.HeaderStoreConfig
+}
+
+// BitcoinQuorumConfig defines the configuration for the Bitcoin chain
+// backend combining multiple backends.
+type BitcoinQuorumConfig struct {
+	// Threshold is the number of backends that must agree on the result of
+	// a security-critical query. If not set, the majority of backends is
+	// required.
+	Threshold int
+	// Electrum lists the Electrum servers combined by the backend.
+	Electrum []electrum.Config
+	// Bitcoind lists the Bitcoin Core nodes combined by the backend.
+	Bitcoind []bitcoind.Config
+	// Esplora lists the Esplora REST APIs combined by the backend.
+	Esplora []esplora.Config
+}
+
+// BackendsCount returns the total number of backends combined by the
+// backend.
+func (bqc BitcoinQuorumConfig) BackendsCount() int {
+	return len(bqc.Electrum) + len(bqc.Bitcoind) + len(bqc.Esplora)
 }
 
 // Bind the flags to the viper configuration. Viper reads configuration from
@@ -146,12 +198,18 @@ func (c *Config) ReadConfig(configFilePath string, flagSet *pflag.FlagSet, categ
 		return fmt.Errorf("failed to resolve peers: %w", err)
 	}
 
+	if c.Bitcoin.Backend == "" {
+		c.Bitcoin.Backend = BitcoinBackendElectrum
+	}
+
 	// Resolve Electrum server.
-	// #nosec G404 (insecure random number source (rand))
-	// Picking up an Electrum server does not require secure randomness.
-	err = c.resolveElectrum(rand.New(rand.NewSource(time.Now().UnixNano())))
-	if err != nil {
-		return fmt.Errorf("failed to resolve Electrum: %w", err)
+	if c.Bitcoin.Backend == BitcoinBackendElectrum {
+		// #nosec G404 (insecure random number source (rand))
+		// Picking up an Electrum server does not require secure randomness.
+		err = c.resolveElectrum(rand.New(rand.NewSource(time.Now().UnixNano())))
+		if err != nil {
+			return fmt.Errorf("failed to resolve Electrum: %w", err)
+		}
 	}
 
 	// Validate configuration.
@@ -188,6 +246,52 @@ func (c *Config) ReadConfig(configFilePath string, flagSet *pflag.FlagSet, categ
 	return nil
 }
 
+func validateBitcoinQuorum(config BitcoinQuorumConfig) error {
+	var result *multierror.Error
+
+	backendsCount := config.BackendsCount()
+	if backendsCount == 0 {
+		result = multierror.Append(result, fmt.Errorf(
+			"missing backends for bitcoin.quorum; see bitcoin quorum section in configuration",
+		))
+	}
+
+	if config.Threshold < 0 || config.Threshold > backendsCount {
+		result = multierror.Append(result, fmt.Errorf(
+			"value [%d] for bitcoin.quorum.threshold must be between 1 and the number of backends [%d]; see bitcoin quorum section in configuration",
+			config.Threshold,
+			backendsCount,
+		))
+	}
+
+	for i, electrumConfig := range config.Electrum {
+		if electrumConfig.URL == "" {
+			result = multierror.Append(result, fmt.Errorf(
+				"missing value for bitcoin.quorum.electrum[%d].url; see bitcoin quorum section in configuration",
+				i,
+			))
+		}
+	}
+	for i, bitcoindConfig := range config.Bitcoind {
+		if bitcoindConfig.URL == "" {
+			result = multierror.Append(result, fmt.Errorf(
+				"missing value for bitcoin.quorum.bitcoind[%d].url; see bitcoin quorum section in configuration",
+				i,
+			))
+		}
+	}
+	for i, esploraConfig := range config.Esplora {
+		if esploraConfig.URL == "" {
+			result = multierror.Append(result, fmt.Errorf(
+				"missing value for bitcoin.quorum.esplora[%d].url; see bitcoin quorum section in configuration",
+				i,
+			))
+		}
+	}
+
+	return result.ErrorOrNil()
+}
+
 func validateConfig(config *Config, categories ...Category) error {
 	var result *multierror.Error
 
@@ -206,9 +310,33 @@ func validateConfig(config *Config, categories ...Category) error {
 				))
 			}
 		case BitcoinElectrum:
-			if config.Bitcoin.Electrum.URL == "" {
+			switch config.Bitcoin.Backend {
+			case BitcoinBackendElectrum:
+				if config.Bitcoin.Electrum.URL == "" {
+					result = multierror.Append(result, fmt.Errorf(
+						"missing value for bitcoin.electrum.url; see bitcoin electrum section in configuration",
+					))
+				}
+			case BitcoinBackendBitcoind:
+				if config.Bitcoin.Bitcoind.URL == "" {
+					result = multierror.Append(result, fmt.Errorf(
+						"missing value for bitcoin.bitcoind.url; see bitcoin bitcoind section in configuration",
+					))
+				}
+			case BitcoinBackendEsplora:
+				if config.Bitcoin.Esplora.URL == "" {
+					result = multierror.Append(result, fmt.Errorf(
+						"missing value for bitcoin.esplora.url; see bitcoin esplora section in configuration",
+					))
+				}
+			case BitcoinBackendQuorum:
+				if err := validateBitcoinQuorum(config.Bitcoin.Quorum); err != nil {
+					result = multierror.Append(result, err)
+				}
+			default:
 				result = multierror.Append(result, fmt.Errorf(
-					"missing value for bitcoin.electrum.url; see bitcoin electrum section in configuration",
+					"unsupported value [%s] for bitcoin.backend; see bitcoin section in configuration",
+					config.Bitcoin.Backend,
 				))
 			}
 		case Network:
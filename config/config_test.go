@@ -13,6 +13,9 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"golang.org/x/exp/slices"
 
+	"github.com/keep-network/keep-core/pkg/bitcoin/bitcoind"
+	"github.com/keep-network/keep-core/pkg/bitcoin/electrum"
+	"github.com/keep-network/keep-core/pkg/bitcoin/esplora"
 	"github.com/keep-network/keep-core/pkg/chain/ethereum"
 	ethereumBeacon "github.com/keep-network/keep-core/pkg/chain/ethereum/beacon/gen"
 	ethereumEcdsa "github.com/keep-network/keep-core/pkg/chain/ethereum/ecdsa/gen"
@@ -136,6 +139,10 @@ func TestReadConfigFromFile(t *testing.T) {
 			},
 			expectedValue: "0xfdc315b0e608b7cDE9166D9D69a1506779e3E0CA",
 		},
+		"Bitcoin.Backend": {
+			readValueFunc: func(c *Config) interface{} { return c.Bitcoin.Backend },
+			expectedValue: "electrum",
+		},
 		"Bitcoin.Electrum.URL": {
 			readValueFunc: func(c *Config) interface{} { return c.Bitcoin.Electrum.URL },
 			expectedValue: "ssl://url.to.electrum:18332",
@@ -161,6 +168,88 @@ func TestReadConfigFromFile(t *testing.T) {
 			readValueFunc: func(c *Config) interface{} { return c.Bitcoin.Electrum.KeepAliveInterval },
 			expectedValue: 720 * time.Second,
 		},
+		"Bitcoin.Bitcoind.URL": {
+			readValueFunc: func(c *Config) interface{} { return c.Bitcoin.Bitcoind.URL },
+			expectedValue: "http://url.to.bitcoind:18332",
+		},
+		"Bitcoin.Bitcoind.Username": {
+			readValueFunc: func(c *Config) interface{} { return c.Bitcoin.Bitcoind.Username },
+			expectedValue: "bitcoind-user",
+		},
+		"Bitcoin.Bitcoind.Password": {
+			readValueFunc: func(c *Config) interface{} { return c.Bitcoin.Bitcoind.Password },
+			expectedValue: "bitcoind-password",
+		},
+		"Bitcoin.Bitcoind.RequestTimeout": {
+			readValueFunc: func(c *Config) interface{} { return c.Bitcoin.Bitcoind.RequestTimeout },
+			expectedValue: 137 * time.Second,
+		},
+		"Bitcoin.Bitcoind.RequestRetryTimeout": {
+			readValueFunc: func(c *Config) interface{} { return c.Bitcoin.Bitcoind.RequestRetryTimeout },
+			expectedValue: 420 * time.Second,
+		},
+		"Bitcoin.Bitcoind.ScanStartHeight": {
+			readValueFunc: func(c *Config) interface{} { return c.Bitcoin.Bitcoind.ScanStartHeight },
+			expectedValue: uint(2130000),
+		},
+		"Bitcoin.Esplora.URL": {
+			readValueFunc: func(c *Config) interface{} { return c.Bitcoin.Esplora.URL },
+			expectedValue: "https://url.to.esplora/testnet/api",
+		},
+		"Bitcoin.Esplora.RequestTimeout": {
+			readValueFunc: func(c *Config) interface{} { return c.Bitcoin.Esplora.RequestTimeout },
+			expectedValue: 41 * time.Second,
+		},
+		"Bitcoin.Esplora.RequestRetryTimeout": {
+			readValueFunc: func(c *Config) interface{} { return c.Bitcoin.Esplora.RequestRetryTimeout },
+			expectedValue: 240 * time.Second,
+		},
+		"Bitcoin.Esplora.MaxConnections": {
+			readValueFunc: func(c *Config) interface{} { return c.Bitcoin.Esplora.MaxConnections },
+			expectedValue: 24,
+		},
+		"Bitcoin.Quorum.Threshold": {
+			readValueFunc: func(c *Config) interface{} { return c.Bitcoin.Quorum.Threshold },
+			expectedValue: 2,
+		},
+		"Bitcoin.Quorum.Electrum": {
+			readValueFunc: func(c *Config) interface{} { return c.Bitcoin.Quorum.Electrum },
+			expectedValue: []electrum.Config{
+				{
+					URL:            "ssl://url.to.electrum.quorum:50002",
+					RequestTimeout: 45 * time.Second,
+				},
+			},
+		},
+		"Bitcoin.Quorum.Bitcoind": {
+			readValueFunc: func(c *Config) interface{} { return c.Bitcoin.Quorum.Bitcoind },
+			expectedValue: []bitcoind.Config{
+				{
+					URL:      "http://url.to.bitcoind.quorum:8332",
+					Username: "bitcoind-quorum-user",
+				},
+			},
+		},
+		"Bitcoin.Quorum.Esplora": {
+			readValueFunc: func(c *Config) interface{} { return c.Bitcoin.Quorum.Esplora },
+			expectedValue: []esplora.Config{
+				{
+					URL: "https://url.to.esplora.quorum/api",
+				},
+				{
+					URL:            "https://url.to.other.esplora.quorum/api",
+					MaxConnections: 8,
+				},
+			},
+		},
+		"Bitcoin.Headers.CheckpointHeight": {
+			readValueFunc: func(c *Config) interface{} { return c.Bitcoin.Headers.CheckpointHeight },
+			expectedValue: uint(2164152),
+		},
+		"Bitcoin.Headers.CheckpointHash": {
+			readValueFunc: func(c *Config) interface{} { return c.Bitcoin.Headers.CheckpointHash },
+			expectedValue: "00000000000013e457bd86d1b6f0b933c2c9500e08dd3eef862ec4e5238b316c",
+		},
 		"Network.Port": {
 			readValueFunc: func(c *Config) interface{} { return c.LibP2P.Port },
 			expectedValue: 27001,
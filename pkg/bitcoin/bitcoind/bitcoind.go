@@ -0,0 +1,1024 @@
+package bitcoind
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ipfs/go-log"
+	"go.uber.org/zap"
+
+	"github.com/keep-network/keep-common/pkg/wrappers"
+	"github.com/keep-network/keep-core/pkg/bitcoin"
+)
+
+const (
+	// minSupportedVersion is the minimum version of Bitcoin Core providing
+	// all JSON-RPC methods used by this package, i.e. v25.0.0.
+	minSupportedVersion = 250000
+	// blockFilterIndexName is the name of the block filter index used
+	// to scan the transaction history.
+	blockFilterIndexName = "basic block filter index"
+	// transactionIndexName is the name of the transaction index.
+	transactionIndexName = "txindex"
+	// rpcBatchSize is the maximum number of requests sent in a single
+	// JSON-RPC batch request.
+	rpcBatchSize = 500
+)
+
+var logger = log.Logger("keep-bitcoind")
+
+// Connection is a handle for interactions with Bitcoin Core JSON-RPC server.
+type Connection struct {
+	parentCtx context.Context
+	client    *rpcClient
+	config    Config
+
+	// scanMutex serializes scans of the UTXO set and block filters as
+	// Bitcoin Core runs only one scan of the given kind at a time.
+	scanMutex sync.Mutex
+
+	blockHashesMutex sync.RWMutex
+	// blockHashes holds hashes of blocks including transactions found during
+	// transaction history scans, keyed by transaction hash. Knowing the block
+	// hash allows getting a confirmed transaction from Bitcoin Core running
+	// without the transaction index.
+	blockHashes map[bitcoin.Hash]string
+
+	mempoolMutex sync.Mutex
+	// mempoolTransactions caches transactions fetched during mempool scans,
+	// keyed by transaction ID. Transactions that left the mempool are
+	// evicted on each scan.
+	mempoolTransactions map[string]*mempoolTransaction
+}
+
+// mempoolTransaction is a transaction living in the mempool.
+type mempoolTransaction struct {
+	hash        bitcoin.Hash
+	transaction *bitcoin.Transaction
+	verbose     *verboseTransaction
+}
+
+// Connect initializes handle with provided Config.
+func Connect(parentCtx context.Context, config Config) (bitcoin.Chain, error) {
+	if config.RequestTimeout == 0 {
+		config.RequestTimeout = DefaultRequestTimeout
+	}
+	if config.RequestRetryTimeout == 0 {
+		config.RequestRetryTimeout = DefaultRequestRetryTimeout
+	}
+
+	c := &Connection{
+		parentCtx: parentCtx,
+		client: newRPCClient(
+			config.URL,
+			config.Username,
+			config.Password,
+		),
+		config:              config,
+		blockHashes:         make(map[bitcoin.Hash]string),
+		mempoolTransactions: make(map[string]*mempoolTransaction),
+	}
+
+	if err := c.verifyServer(); err != nil {
+		return nil, fmt.Errorf("failed to verify bitcoind server: [%w]", err)
+	}
+
+	return c, nil
+}
+
+// GetTransaction gets the transaction with the given transaction hash.
+// If the transaction with the given hash was not found on the chain,
+// this function returns an error.
+func (c *Connection) GetTransaction(
+	transactionHash bitcoin.Hash,
+) (*bitcoin.Transaction, error) {
+	txID := transactionHash.Hex(bitcoin.ReversedByteOrder)
+
+	rawTransaction, err := callWithRetry[string](
+		c,
+		"getrawtransaction",
+		c.rawTransactionParams(transactionHash, false)...,
+	)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"failed to get raw transaction with ID [%s]: [%w]",
+			txID,
+			explainNotFound(err),
+		)
+	}
+
+	result, err := decodeTransaction(rawTransaction)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert transaction: [%w]", err)
+	}
+
+	return result, nil
+}
+
+// GetTransactionConfirmations gets the number of confirmations for the
+// transaction with the given transaction hash. If the transaction with the
+// given hash was not found on the chain, this function returns an error.
+func (c *Connection) GetTransactionConfirmations(
+	transactionHash bitcoin.Hash,
+) (uint, error) {
+	txID := transactionHash.Hex(bitcoin.ReversedByteOrder)
+
+	transaction, err := callWithRetry[*verboseTransaction](
+		c,
+		"getrawtransaction",
+		c.rawTransactionParams(transactionHash, true)...,
+	)
+	if err != nil {
+		return 0, fmt.Errorf(
+			"failed to get transaction with ID [%s]: [%w]",
+			txID,
+			explainNotFound(err),
+		)
+	}
+
+	// The confirmations field is omitted for transactions living in the
+	// mempool so, it is zero in that case.
+	return transaction.Confirmations, nil
+}
+
+// BroadcastTransaction broadcasts the given transaction over the
+// network of the Bitcoin chain nodes. If the broadcast action could not be
+// done, this function returns an error. This function does not give any
+// guarantees regarding transaction mining. The transaction may be mined or
+// rejected eventually.
+func (c *Connection) BroadcastTransaction(
+	transaction *bitcoin.Transaction,
+) error {
+	rawTx := hex.EncodeToString(transaction.Serialize())
+
+	rawTxLogger := logger.With(
+		zap.String("rawTx", rawTx),
+	)
+	rawTxLogger.Debugf("broadcasting transaction")
+
+	response, err := callWithRetry[string](c, "sendrawtransaction", rawTx)
+	if err != nil {
+		return fmt.Errorf("failed to broadcast the transaction: [%w]", err)
+	}
+
+	rawTxLogger.Infof("transaction broadcast successful: [%s]", response)
+
+	return nil
+}
+
+// GetLatestBlockHeight gets the height of the latest block (tip). If the
+// latest block was not determined, this function returns an error.
+func (c *Connection) GetLatestBlockHeight() (uint, error) {
+	blockHeight, err := callWithRetry[uint](c, "getblockcount")
+	if err != nil {
+		return 0, fmt.Errorf("failed to get block count: [%w]", err)
+	}
+
+	return blockHeight, nil
+}
+
+// GetBlockHeader gets the block header for the given block height. If the
+// block with the given height was not found on the chain, this function
+// returns an error.
+func (c *Connection) GetBlockHeader(
+	blockHeight uint,
+) (*bitcoin.BlockHeader, error) {
+	blockHash, err := c.getBlockHash(blockHeight)
+	if err != nil {
+		return nil, err
+	}
+
+	rawBlockHeader, err := callWithRetry[string](
+		c,
+		"getblockheader",
+		blockHash,
+		false,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get block header: [%w]", err)
+	}
+
+	blockHeader, err := convertBlockHeader(rawBlockHeader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert block header: [%w]", err)
+	}
+
+	return blockHeader, nil
+}
+
+// GetTransactionMerkleProof gets the Merkle proof for a given transaction.
+// The transaction's hash and the block the transaction was included in the
+// blockchain need to be provided.
+func (c *Connection) GetTransactionMerkleProof(
+	transactionHash bitcoin.Hash,
+	blockHeight uint,
+) (*bitcoin.TransactionMerkleProof, error) {
+	txID := transactionHash.Hex(bitcoin.ReversedByteOrder)
+
+	// Passing the block hash allows getting the proof from Bitcoin Core
+	// running without the transaction index.
+	blockHash, err := c.getBlockHash(blockHeight)
+	if err != nil {
+		return nil, err
+	}
+
+	rawMerkleBlock, err := callWithRetry[string](
+		c,
+		"gettxoutproof",
+		[]string{txID},
+		blockHash,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get merkle proof: [%w]", err)
+	}
+
+	merkleProof, err := convertMerkleBlock(
+		rawMerkleBlock,
+		transactionHash,
+		blockHeight,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert merkle proof: [%w]", err)
+	}
+
+	return merkleProof, nil
+}
+
+// GetTransactionsForPublicKeyHash gets confirmed transactions that pays the
+// given public key hash using either a P2PKH or P2WPKH script. The returned
+// transactions are ordered by block height in the ascending order, i.e.
+// the latest transaction is at the end of the list. The returned list does
+// not contain unconfirmed transactions living in the mempool at the moment
+// of request. The returned transactions list can be limited using the
+// `limit` parameter. For example, if `limit` is set to `5`, only the
+// latest five transactions will be returned. Note that taking an unlimited
+// transaction history may be time-consuming as this function fetches
+// complete transactions with all necessary data.
+func (c *Connection) GetTransactionsForPublicKeyHash(
+	publicKeyHash [20]byte,
+	limit int,
+) ([]*bitcoin.Transaction, error) {
+	txHashes, err := c.GetTxHashesForPublicKeyHash(publicKeyHash)
+	if err != nil {
+		return nil, err
+	}
+
+	var selectedTxHashes []bitcoin.Hash
+	if len(txHashes) > limit {
+		selectedTxHashes = txHashes[len(txHashes)-limit:]
+	} else {
+		selectedTxHashes = txHashes
+	}
+
+	transactions := make([]*bitcoin.Transaction, len(selectedTxHashes))
+	for i, txHash := range selectedTxHashes {
+		transaction, err := c.GetTransaction(txHash)
+		if err != nil {
+			return nil, fmt.Errorf("cannot get transaction: [%v]", err)
+		}
+
+		transactions[i] = transaction
+	}
+
+	return transactions, nil
+}
+
+// GetTxHashesForPublicKeyHash gets hashes of confirmed transactions that pays
+// the given public key hash using either a P2PKH or P2WPKH script. The returned
+// transactions hashes are ordered by block height in the ascending order, i.e.
+// the latest transaction hash is at the end of the list. The returned list does
+// not contain unconfirmed transactions hashes living in the mempool at the
+// moment of request.
+//
+// The transaction history is determined by scanning block filters so,
+// Bitcoin Core must run with the block filter index enabled. Only blocks
+// starting from the configured scan start height are taken into account.
+func (c *Connection) GetTxHashesForPublicKeyHash(
+	publicKeyHash [20]byte,
+) ([]bitcoin.Hash, error) {
+	scripts, err := publicKeyHashScripts(publicKeyHash)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := c.getConfirmedScriptsHistory(scripts)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"cannot get history for public key hash [0x%x]: [%v]",
+			publicKeyHash,
+			err,
+		)
+	}
+
+	txHashes := make([]bitcoin.Hash, len(items))
+	for i, item := range items {
+		txHashes[i] = item.txHash
+	}
+
+	return txHashes, nil
+}
+
+type scriptHistoryItem struct {
+	txHash      bitcoin.Hash
+	blockHeight uint
+}
+
+// getConfirmedScriptsHistory returns a history of confirmed transactions
+// either paying or spending outputs locked using any of the given scripts
+// (P2PKH, P2WPKH, P2SH, P2WSH, etc.) given as hex strings. The returned list
+// is sorted by the block height in the ascending order, i.e. the latest
+// transaction is at the end of the list.
+func (c *Connection) getConfirmedScriptsHistory(
+	scripts []string,
+) ([]*scriptHistoryItem, error) {
+	type scanBlocksResult struct {
+		RelevantBlocks []string `json:"relevant_blocks"`
+	}
+
+	scanResult, err := func() (*scanBlocksResult, error) {
+		c.scanMutex.Lock()
+		defer c.scanMutex.Unlock()
+
+		return callWithRetry[*scanBlocksResult](
+			c,
+			"scanblocks",
+			"start",
+			rawDescriptors(scripts),
+			c.config.ScanStartHeight,
+		)
+	}()
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan blocks: [%w]", err)
+	}
+
+	items := make([]*scriptHistoryItem, 0)
+	for _, blockHash := range scanResult.RelevantBlocks {
+		// Verbosity level 3 includes prevout information for inputs.
+		block, err := callWithRetry[*verboseBlock](c, "getblock", blockHash, 3)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"failed to get block [%s]: [%w]",
+				blockHash,
+				err,
+			)
+		}
+
+		for _, transaction := range block.Transactions {
+			// Block filters are probabilistic and may yield false positives
+			// so, each transaction must be checked explicitly.
+			if !transaction.touchesScripts(scripts) {
+				continue
+			}
+
+			txHash, err := bitcoin.NewHashFromString(
+				transaction.TxID,
+				bitcoin.ReversedByteOrder,
+			)
+			if err != nil {
+				return nil, fmt.Errorf(
+					"cannot parse hash [%s]: [%v]",
+					transaction.TxID,
+					err,
+				)
+			}
+
+			c.setBlockHash(txHash, block.Hash)
+
+			items = append(items, &scriptHistoryItem{
+				txHash:      txHash,
+				blockHeight: block.Height,
+			})
+		}
+	}
+
+	// Relevant blocks are returned in the ascending order though we are
+	// sorting them again just in case (e.g. API contract changes).
+	sort.SliceStable(
+		items,
+		func(i, j int) bool {
+			return items[i].blockHeight < items[j].blockHeight
+		},
+	)
+
+	return items, nil
+}
+
+// GetMempoolForPublicKeyHash gets the unconfirmed mempool transactions
+// that pays the given public key hash using either a P2PKH or P2WPKH script.
+// The returned transactions are in an indefinite order.
+func (c *Connection) GetMempoolForPublicKeyHash(
+	publicKeyHash [20]byte,
+) ([]*bitcoin.Transaction, error) {
+	scripts, err := publicKeyHashScripts(publicKeyHash)
+	if err != nil {
+		return nil, err
+	}
+
+	mempoolTransactions, err := c.scanMempool()
+	if err != nil {
+		return nil, fmt.Errorf("cannot scan mempool: [%v]", err)
+	}
+
+	transactions := make([]*bitcoin.Transaction, 0)
+	for _, mempoolTransaction := range mempoolTransactions {
+		if mempoolTransaction.verbose.touchesScripts(scripts) {
+			transactions = append(
+				transactions,
+				mempoolTransaction.transaction,
+			)
+		}
+	}
+
+	return transactions, nil
+}
+
+// scanMempool returns all transactions currently living in the mempool.
+// Transactions not fetched during previous scans are fetched in batches.
+func (c *Connection) scanMempool() ([]*mempoolTransaction, error) {
+	c.mempoolMutex.Lock()
+	defer c.mempoolMutex.Unlock()
+
+	txIDs, err := callWithRetry[[]string](c, "getrawmempool")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get mempool: [%w]", err)
+	}
+
+	mempoolTransactions := make(map[string]*mempoolTransaction, len(txIDs))
+	missingParamsLists := make([][]interface{}, 0)
+	for _, txID := range txIDs {
+		if cached, ok := c.mempoolTransactions[txID]; ok {
+			mempoolTransactions[txID] = cached
+			continue
+		}
+
+		// Verbosity level 2 includes prevout information for inputs.
+		missingParamsLists = append(
+			missingParamsLists,
+			[]interface{}{txID, 2},
+		)
+	}
+
+	responses, err := batchWithRetry(c, "getrawtransaction", missingParamsLists)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get mempool transactions: [%w]", err)
+	}
+
+	for i, response := range responses {
+		txID := missingParamsLists[i][0].(string)
+
+		if response.Error != nil {
+			// The transaction may have been mined or evicted from the
+			// mempool in the meantime.
+			if response.Error.Code == rpcInvalidAddressOrKeyErrorCode {
+				continue
+			}
+
+			return nil, fmt.Errorf(
+				"failed to get mempool transaction with ID [%s]: [%w]",
+				txID,
+				response.Error,
+			)
+		}
+
+		verbose := new(verboseTransaction)
+		if err := json.Unmarshal(response.Result, verbose); err != nil {
+			return nil, fmt.Errorf(
+				"cannot unmarshal mempool transaction with ID [%s]: [%v]",
+				txID,
+				err,
+			)
+		}
+
+		transaction, err := decodeTransaction(verbose.Hex)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"failed to convert mempool transaction with ID [%s]: [%w]",
+				txID,
+				err,
+			)
+		}
+
+		// The serialized transaction is no longer needed and can be
+		// dropped to reduce the memory footprint of the cache.
+		verbose.Hex = ""
+
+		mempoolTransactions[txID] = &mempoolTransaction{
+			hash:        transaction.Hash(),
+			transaction: transaction,
+			verbose:     verbose,
+		}
+	}
+
+	c.mempoolTransactions = mempoolTransactions
+
+	result := make([]*mempoolTransaction, 0, len(mempoolTransactions))
+	for _, mempoolTransaction := range mempoolTransactions {
+		result = append(result, mempoolTransaction)
+	}
+
+	return result, nil
+}
+
+// GetUtxosForPublicKeyHash gets unspent outputs of confirmed transactions that
+// are controlled by the given public key hash (either a P2PKH or P2WPKH script).
+// The returned UTXOs are ordered by block height in the ascending order, i.e.
+// the latest UTXO is at the end of the list. The returned list does not contain
+// unspent outputs of unconfirmed transactions living in the mempool at the
+// moment of request. Outputs used as inputs of confirmed or mempool
+// transactions are not returned as well because they are no longer UTXOs.
+func (c *Connection) GetUtxosForPublicKeyHash(
+	publicKeyHash [20]byte,
+) ([]*bitcoin.UnspentTransactionOutput, error) {
+	scripts, err := publicKeyHashScripts(publicKeyHash)
+	if err != nil {
+		return nil, err
+	}
+
+	type scanTxOutSetResult struct {
+		Unspents []struct {
+			TxID   string      `json:"txid"`
+			Vout   uint32      `json:"vout"`
+			Amount json.Number `json:"amount"`
+			Height uint        `json:"height"`
+		} `json:"unspents"`
+	}
+
+	scanResult, err := func() (*scanTxOutSetResult, error) {
+		c.scanMutex.Lock()
+		defer c.scanMutex.Unlock()
+
+		return callWithRetry[*scanTxOutSetResult](
+			c,
+			"scantxoutset",
+			"start",
+			rawDescriptors(scripts),
+		)
+	}()
+	if err != nil {
+		return nil, fmt.Errorf(
+			"cannot scan UTXO set for public key hash [0x%x]: [%w]",
+			publicKeyHash,
+			err,
+		)
+	}
+
+	// The UTXO set does not take the mempool into account so, outputs
+	// spent by mempool transactions must be filtered out explicitly.
+	// The gettxout method returns null for such outputs.
+	paramsLists := make([][]interface{}, len(scanResult.Unspents))
+	for i, unspent := range scanResult.Unspents {
+		paramsLists[i] = []interface{}{unspent.TxID, unspent.Vout, true}
+	}
+
+	responses, err := batchWithRetry(c, "gettxout", paramsLists)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transaction outputs: [%w]", err)
+	}
+
+	type utxoItem struct {
+		utxo        *bitcoin.UnspentTransactionOutput
+		blockHeight uint
+	}
+
+	items := make([]*utxoItem, 0)
+	for i, unspent := range scanResult.Unspents {
+		if responses[i].Error != nil {
+			return nil, fmt.Errorf(
+				"failed to get transaction output [%s:%d]: [%w]",
+				unspent.TxID,
+				unspent.Vout,
+				responses[i].Error,
+			)
+		}
+
+		if isNullResult(responses[i].Result) {
+			continue
+		}
+
+		txHash, err := bitcoin.NewHashFromString(
+			unspent.TxID,
+			bitcoin.ReversedByteOrder,
+		)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"cannot parse hash [%s]: [%v]",
+				unspent.TxID,
+				err,
+			)
+		}
+
+		value, err := convertBtcToSatoshi(unspent.Amount)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"cannot convert value of output [%s:%d]: [%v]",
+				unspent.TxID,
+				unspent.Vout,
+				err,
+			)
+		}
+
+		items = append(items, &utxoItem{
+			utxo: &bitcoin.UnspentTransactionOutput{
+				Outpoint: &bitcoin.TransactionOutpoint{
+					TransactionHash: txHash,
+					OutputIndex:     unspent.Vout,
+				},
+				Value: value,
+			},
+			blockHeight: unspent.Height,
+		})
+	}
+
+	sort.SliceStable(
+		items,
+		func(i, j int) bool {
+			return items[i].blockHeight < items[j].blockHeight
+		},
+	)
+
+	utxos := make([]*bitcoin.UnspentTransactionOutput, len(items))
+	for i, item := range items {
+		utxos[i] = item.utxo
+	}
+
+	return utxos, nil
+}
+
+// GetMempoolUtxosForPublicKeyHash gets unspent outputs of unconfirmed transactions
+// that are controlled by the given public key hash (either a P2PKH or P2WPKH script).
+// The returned UTXOs are in an indefinite order. The returned list does not
+// contain unspent outputs of confirmed transactions. Outputs used as inputs of
+// confirmed or mempool transactions are not returned as well because they are
+// no longer UTXOs.
+func (c *Connection) GetMempoolUtxosForPublicKeyHash(
+	publicKeyHash [20]byte,
+) ([]*bitcoin.UnspentTransactionOutput, error) {
+	scripts, err := publicKeyHashScripts(publicKeyHash)
+	if err != nil {
+		return nil, err
+	}
+
+	mempoolTransactions, err := c.scanMempool()
+	if err != nil {
+		return nil, fmt.Errorf("cannot scan mempool: [%v]", err)
+	}
+
+	spentOutpoints := make(map[bitcoin.TransactionOutpoint]bool)
+	for _, mempoolTransaction := range mempoolTransactions {
+		for _, input := range mempoolTransaction.transaction.Inputs {
+			spentOutpoints[*input.Outpoint] = true
+		}
+	}
+
+	utxos := make([]*bitcoin.UnspentTransactionOutput, 0)
+	for _, mempoolTransaction := range mempoolTransactions {
+		for i, output := range mempoolTransaction.transaction.Outputs {
+			script := hex.EncodeToString(output.PublicKeyScript)
+			if !containsScript(scripts, script) {
+				continue
+			}
+
+			outpoint := bitcoin.TransactionOutpoint{
+				TransactionHash: mempoolTransaction.hash,
+				OutputIndex:     uint32(i),
+			}
+
+			if spentOutpoints[outpoint] {
+				continue
+			}
+
+			utxos = append(utxos, &bitcoin.UnspentTransactionOutput{
+				Outpoint: &outpoint,
+				Value:    output.Value,
+			})
+		}
+	}
+
+	return utxos, nil
+}
+
+// EstimateSatPerVByteFee returns the estimated sat/vbyte fee for a
+// transaction to be confirmed within the given number of blocks.
+func (c *Connection) EstimateSatPerVByteFee(blocks uint32) (int64, error) {
+	type estimateSmartFeeResult struct {
+		// FeeRate is expressed in BTC/kvB.
+		FeeRate *float64 `json:"feerate"`
+		Errors  []string `json:"errors"`
+	}
+
+	result, err := callWithRetry[*estimateSmartFeeResult](
+		c,
+		"estimatesmartfee",
+		blocks,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get fee: [%v]", err)
+	}
+
+	// The fee rate is omitted if the node does not have enough information
+	// to make an estimate.
+	if result.FeeRate == nil {
+		return 0, fmt.Errorf(
+			"node cannot make an estimate: [%s]",
+			strings.Join(result.Errors, "; "),
+		)
+	}
+
+	return convertBtcKvbToSatVByte(*result.FeeRate), nil
+}
+
+func convertBtcKvbToSatVByte(btcPerKvbFee float64) int64 {
+	// To convert from BTC/kvB to sat/vbyte, we need to multiply by 1e8/1e3.
+	satPerVByte := (1e8 / 1e3) * btcPerKvbFee
+	// Make sure the minimum returned sat/vbyte fee is always 1.
+	satPerVByte = math.Max(satPerVByte, 1)
+	// Round the returned fee to be an integer.
+	return int64(math.Round(satPerVByte))
+}
+
+func (c *Connection) verifyServer() error {
+	type networkInfo struct {
+		Version    int    `json:"version"`
+		SubVersion string `json:"subversion"`
+	}
+
+	type blockchainInfo struct {
+		Chain  string `json:"chain"`
+		Blocks uint   `json:"blocks"`
+	}
+
+	type indexInfo struct {
+		Synced bool `json:"synced"`
+	}
+
+	network, err := callWithRetry[*networkInfo](c, "getnetworkinfo")
+	if err != nil {
+		return fmt.Errorf("failed to get network info: [%w]", err)
+	}
+
+	blockchain, err := callWithRetry[*blockchainInfo](c, "getblockchaininfo")
+	if err != nil {
+		return fmt.Errorf("failed to get blockchain info: [%w]", err)
+	}
+
+	indexes, err := callWithRetry[map[string]*indexInfo](c, "getindexinfo")
+	if err != nil {
+		return fmt.Errorf("failed to get index info: [%w]", err)
+	}
+
+	logger.Infof(
+		"connected to bitcoind server [version: [%s], chain: [%s], blocks: [%d]]",
+		network.SubVersion,
+		blockchain.Chain,
+		blockchain.Blocks,
+	)
+
+	if network.Version < minSupportedVersion {
+		logger.Warnf(
+			"bitcoind server [%s] runs an unsupported version: [%d]; "+
+				"expected at least: [%d]",
+			c.config.URL,
+			network.Version,
+			minSupportedVersion,
+		)
+	}
+
+	if _, ok := indexes[blockFilterIndexName]; !ok {
+		logger.Warnf(
+			"bitcoind server [%s] does not maintain the block filter index; "+
+				"transaction history lookups will fail; please run it "+
+				"with -blockfilterindex enabled",
+			c.config.URL,
+		)
+	}
+
+	if _, ok := indexes[transactionIndexName]; !ok {
+		logger.Warnf(
+			"bitcoind server [%s] does not maintain the transaction index; "+
+				"only mempool transactions and transactions found during "+
+				"history lookups can be fetched; please run it "+
+				"with -txindex enabled",
+			c.config.URL,
+		)
+	}
+
+	return nil
+}
+
+// getBlockHash gets the hash of the block with the given height.
+func (c *Connection) getBlockHash(blockHeight uint) (string, error) {
+	blockHash, err := callWithRetry[string](c, "getblockhash", blockHeight)
+	if err != nil {
+		return "", fmt.Errorf(
+			"failed to get hash of block [%d]: [%w]",
+			blockHeight,
+			err,
+		)
+	}
+
+	return blockHash, nil
+}
+
+// rawTransactionParams builds parameters of the getrawtransaction request
+// for the given transaction. The hash of the block including the transaction
+// is passed if it is known.
+func (c *Connection) rawTransactionParams(
+	transactionHash bitcoin.Hash,
+	verbose bool,
+) []interface{} {
+	params := []interface{}{
+		transactionHash.Hex(bitcoin.ReversedByteOrder),
+		verbose,
+	}
+
+	if blockHash, ok := c.getBlockHashOf(transactionHash); ok {
+		params = append(params, blockHash)
+	}
+
+	return params
+}
+
+func (c *Connection) setBlockHash(transactionHash bitcoin.Hash, blockHash string) {
+	c.blockHashesMutex.Lock()
+	defer c.blockHashesMutex.Unlock()
+
+	c.blockHashes[transactionHash] = blockHash
+}
+
+func (c *Connection) getBlockHashOf(transactionHash bitcoin.Hash) (string, bool) {
+	c.blockHashesMutex.RLock()
+	defer c.blockHashesMutex.RUnlock()
+
+	blockHash, ok := c.blockHashes[transactionHash]
+	return blockHash, ok
+}
+
+// publicKeyHashScripts returns P2PKH and P2WPKH scripts for the given public
+// key hash, as hex strings.
+func publicKeyHashScripts(publicKeyHash [20]byte) ([]string, error) {
+	p2pkh, err := bitcoin.PayToPublicKeyHash(publicKeyHash)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"cannot build P2PKH for public key hash [0x%x]: [%v]",
+			publicKeyHash,
+			err,
+		)
+	}
+
+	p2wpkh, err := bitcoin.PayToWitnessPublicKeyHash(publicKeyHash)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"cannot build P2WPKH for public key hash [0x%x]: [%v]",
+			publicKeyHash,
+			err,
+		)
+	}
+
+	return []string{hex.EncodeToString(p2pkh), hex.EncodeToString(p2wpkh)}, nil
+}
+
+// rawDescriptors builds output descriptors for the given scripts given as
+// hex strings.
+func rawDescriptors(scripts []string) []string {
+	descriptors := make([]string, len(scripts))
+	for i, script := range scripts {
+		descriptors[i] = fmt.Sprintf("raw(%s)", script)
+	}
+
+	return descriptors
+}
+
+func isNullResult(result json.RawMessage) bool {
+	return len(result) == 0 || string(result) == "null"
+}
+
+// explainNotFound extends the given error with a hint about the transaction
+// index if the error denotes the requested transaction was not found.
+func explainNotFound(err error) error {
+	var rpcErr *rpcError
+	if errors.As(err, &rpcErr) &&
+		rpcErr.Code == rpcInvalidAddressOrKeyErrorCode {
+		return fmt.Errorf(
+			"%w; confirmed transactions can be found only if bitcoind "+
+				"runs with -txindex enabled",
+			err,
+		)
+	}
+
+	return err
+}
+
+func callWithRetry[K interface{}](
+	c *Connection,
+	method string,
+	params ...interface{},
+) (K, error) {
+	return requestWithRetry(
+		c,
+		func(ctx context.Context, client *rpcClient) (K, error) {
+			var result K
+			err := client.call(ctx, method, params, &result)
+			return result, err
+		},
+		method,
+	)
+}
+
+// batchWithRetry executes the given method with each of the given parameters
+// lists using JSON-RPC batch requests of at most rpcBatchSize requests each.
+// The returned responses are in the same order as the parameters lists.
+func batchWithRetry(
+	c *Connection,
+	method string,
+	paramsLists [][]interface{},
+) ([]*rpcResponse, error) {
+	responses := make([]*rpcResponse, 0, len(paramsLists))
+
+	for start := 0; start < len(paramsLists); start += rpcBatchSize {
+		end := start + rpcBatchSize
+		if end > len(paramsLists) {
+			end = len(paramsLists)
+		}
+
+		batchResponses, err := requestWithRetry(
+			c,
+			func(
+				ctx context.Context,
+				client *rpcClient,
+			) ([]*rpcResponse, error) {
+				return client.batchCall(ctx, method, paramsLists[start:end])
+			},
+			method,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		responses = append(responses, batchResponses...)
+	}
+
+	return responses, nil
+}
+
+func requestWithRetry[K interface{}](
+	c *Connection,
+	requestFn func(ctx context.Context, client *rpcClient) (K, error),
+	requestName string,
+) (K, error) {
+	startTime := time.Now()
+	logger.Infof("starting [%s] request to bitcoind server", requestName)
+
+	var result K
+	var rpcErr *rpcError
+
+	err := wrappers.DoWithDefaultRetry(
+		c.parentCtx,
+		c.config.RequestRetryTimeout,
+		func(ctx context.Context) error {
+			requestCtx, requestCancel := context.WithTimeout(ctx, c.config.RequestTimeout)
+			defer requestCancel()
+
+			r, err := requestFn(requestCtx, c.client)
+			if err != nil {
+				// An error returned by bitcoind means the request was
+				// processed and rejected so, retrying it would most likely
+				// produce the same outcome. The only exception is the
+				// server still warming up.
+				if errors.As(err, &rpcErr) && rpcErr.Code != rpcInWarmupErrorCode {
+					return nil
+				}
+
+				rpcErr = nil
+				return fmt.Errorf("request failed: [%w]", err)
+			}
+
+			result = r
+			return nil
+		})
+	if err == nil && rpcErr != nil {
+		err = rpcErr
+	}
+
+	solveRequestOutcome := func(err error) string {
+		if err != nil {
+			return fmt.Sprintf("error: [%v]", err)
+		}
+		return "success"
+	}
+
+	logger.Infof("[%s] request to bitcoind server completed with [%s] after [%s]",
+		requestName,
+		solveRequestOutcome(err),
+		time.Since(startTime),
+	)
+
+	return result, err
+}
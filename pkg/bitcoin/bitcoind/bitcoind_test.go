@@ -0,0 +1,648 @@
+package bitcoind
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-test/deep"
+
+	"github.com/keep-network/keep-core/internal/testutils"
+	"github.com/keep-network/keep-core/pkg/bitcoin"
+
+	testData "github.com/keep-network/keep-core/internal/testdata/bitcoin"
+)
+
+const (
+	stubUsername = "keep"
+	stubPassword = "secret"
+	// stubScanStartHeight is the scan start height the recorded scanblocks
+	// response was captured for.
+	stubScanStartHeight = 2130000
+)
+
+// recordedResponse is a Bitcoin Core response recorded for the given method
+// and parameters.
+type recordedResponse struct {
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params"`
+	Result json.RawMessage `json:"result"`
+	Error  *rpcError       `json:"error"`
+}
+
+// stubServer is a JSON-RPC server replaying responses recorded in
+// testdata/responses.json. Responses follow the format of Bitcoin Core v25
+// and hold testnet data used by other Bitcoin test vectors.
+type stubServer struct {
+	t         *testing.T
+	server    *httptest.Server
+	responses map[string]*recordedResponse
+
+	callsMutex sync.Mutex
+	calls      map[string]int
+}
+
+func newStubServer(t *testing.T) *stubServer {
+	content, err := os.ReadFile("testdata/responses.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var recorded []*recordedResponse
+	if err := json.Unmarshal(content, &recorded); err != nil {
+		t.Fatal(err)
+	}
+
+	ss := &stubServer{
+		t:         t,
+		responses: make(map[string]*recordedResponse),
+		calls:     make(map[string]int),
+	}
+
+	for _, response := range recorded {
+		var params []interface{}
+		if err := json.Unmarshal(response.Params, &params); err != nil {
+			t.Fatal(err)
+		}
+
+		ss.responses[requestKey(response.Method, params)] = response
+	}
+
+	ss.server = httptest.NewServer(http.HandlerFunc(ss.handle))
+	t.Cleanup(ss.server.Close)
+
+	return ss
+}
+
+func requestKey(method string, params []interface{}) string {
+	encodedParams, err := json.Marshal(params)
+	if err != nil {
+		panic(err)
+	}
+
+	return method + string(encodedParams)
+}
+
+func (ss *stubServer) handle(w http.ResponseWriter, r *http.Request) {
+	username, password, ok := r.BasicAuth()
+	if !ok || username != stubUsername || password != stubPassword {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		ss.t.Errorf("cannot read request body: [%v]", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	if strings.HasPrefix(strings.TrimSpace(string(body)), "[") {
+		var requests []*rpcRequest
+		if err := json.Unmarshal(body, &requests); err != nil {
+			ss.t.Errorf("cannot unmarshal batch request: [%v]", err)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		// Respond in the reversed order to make sure the client does not
+		// rely on the order of batch responses.
+		responses := make([]*rpcResponse, len(requests))
+		for i, request := range requests {
+			responses[len(requests)-1-i] = ss.respond(request)
+		}
+
+		_ = json.NewEncoder(w).Encode(responses)
+		return
+	}
+
+	var request rpcRequest
+	if err := json.Unmarshal(body, &request); err != nil {
+		ss.t.Errorf("cannot unmarshal request: [%v]", err)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	response := ss.respond(&request)
+	if response.Error != nil {
+		// Bitcoin Core uses non-200 statuses for failed requests.
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+
+	_ = json.NewEncoder(w).Encode(response)
+}
+
+func (ss *stubServer) respond(request *rpcRequest) *rpcResponse {
+	ss.callsMutex.Lock()
+	ss.calls[request.Method]++
+	ss.callsMutex.Unlock()
+
+	recorded, ok := ss.responses[requestKey(request.Method, request.Params)]
+	if !ok {
+		ss.t.Errorf(
+			"no recorded response for [%s] with params %v",
+			request.Method,
+			request.Params,
+		)
+
+		return &rpcResponse{
+			ID:    request.ID,
+			Error: &rpcError{Code: -32601, Message: "Method not found"},
+		}
+	}
+
+	return &rpcResponse{
+		ID:     request.ID,
+		Result: recorded.Result,
+		Error:  recorded.Error,
+	}
+}
+
+func (ss *stubServer) callsCount(method string) int {
+	ss.callsMutex.Lock()
+	defer ss.callsMutex.Unlock()
+
+	return ss.calls[method]
+}
+
+func connectStub(t *testing.T) (*Connection, *stubServer) {
+	ss := newStubServer(t)
+
+	chain, err := Connect(context.Background(), Config{
+		URL:                 ss.server.URL,
+		Username:            stubUsername,
+		Password:            stubPassword,
+		RequestTimeout:      time.Second,
+		RequestRetryTimeout: 2 * time.Second,
+		ScanStartHeight:     stubScanStartHeight,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return chain.(*Connection), ss
+}
+
+func TestConnect_WrongCredentials(t *testing.T) {
+	ss := newStubServer(t)
+
+	_, err := Connect(context.Background(), Config{
+		URL:                 ss.server.URL,
+		Username:            stubUsername,
+		Password:            "wrong",
+		RequestTimeout:      time.Second,
+		RequestRetryTimeout: 100 * time.Millisecond,
+	})
+	if err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestConnection_GetTransaction(t *testing.T) {
+	connection, _ := connectStub(t)
+
+	for testName, test := range testData.Transactions[bitcoin.Testnet] {
+		t.Run(testName, func(t *testing.T) {
+			transaction, err := connection.GetTransaction(test.TxHash)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := deep.Equal(transaction, &test.BitcoinTx); diff != nil {
+				t.Errorf("compare failed: %v", diff)
+			}
+		})
+	}
+}
+
+func TestConnection_GetTransaction_NotFound(t *testing.T) {
+	connection, ss := connectStub(t)
+
+	transactionHash, err := bitcoin.NewHashFromString(
+		"0000000000000000000000000000000000000000000000000000000000000001",
+		bitcoin.ReversedByteOrder,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = connection.GetTransaction(transactionHash)
+	if err == nil {
+		t.Fatal("expected error")
+	}
+
+	if !strings.Contains(err.Error(), "-txindex") {
+		t.Errorf("expected error to mention the transaction index: [%v]", err)
+	}
+
+	// Errors returned by bitcoind must not be retried.
+	testutils.AssertIntsEqual(
+		t,
+		"getrawtransaction calls count",
+		1,
+		ss.callsCount("getrawtransaction"),
+	)
+}
+
+func TestConnection_GetTransactionConfirmations(t *testing.T) {
+	connection, _ := connectStub(t)
+
+	transaction := testData.Transactions[bitcoin.Testnet]["input: P2WSH, output: P2WPKH"]
+
+	confirmations, err := connection.GetTransactionConfirmations(
+		transaction.TxHash,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The recorded tip is at block 2137782.
+	testutils.AssertUintsEqual(
+		t,
+		"confirmations",
+		uint64(2137782-transaction.BlockHeight+1),
+		uint64(confirmations),
+	)
+}
+
+func TestConnection_BroadcastTransaction(t *testing.T) {
+	connection, ss := connectStub(t)
+
+	transaction := testData.Transactions[bitcoin.Testnet]["input: P2PKH, output: P2SH, P2WPKH"]
+
+	if err := connection.BroadcastTransaction(&transaction.BitcoinTx); err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertIntsEqual(
+		t,
+		"sendrawtransaction calls count",
+		1,
+		ss.callsCount("sendrawtransaction"),
+	)
+}
+
+func TestConnection_GetLatestBlockHeight(t *testing.T) {
+	connection, _ := connectStub(t)
+
+	blockHeight, err := connection.GetLatestBlockHeight()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertUintsEqual(t, "block height", 2137782, uint64(blockHeight))
+}
+
+func TestConnection_GetBlockHeader(t *testing.T) {
+	connection, _ := connectStub(t)
+
+	block := testData.Blocks[bitcoin.Testnet]
+
+	blockHeader, err := connection.GetBlockHeader(block.BlockHeight)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if diff := deep.Equal(blockHeader, block.BlockHeader); diff != nil {
+		t.Errorf("compare failed: %v", diff)
+	}
+}
+
+func TestConnection_GetTransactionMerkleProof(t *testing.T) {
+	connection, _ := connectStub(t)
+
+	merkleProof := testData.TxMerkleProofs[bitcoin.Testnet]
+
+	result, err := connection.GetTransactionMerkleProof(
+		merkleProof.TxHash,
+		merkleProof.BlockHeight,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if diff := deep.Equal(result, merkleProof.MerkleProof); diff != nil {
+		t.Errorf("compare failed: %v", diff)
+	}
+}
+
+func TestConnection_GetTxHashesForPublicKeyHash(t *testing.T) {
+	connection, _ := connectStub(t)
+
+	// The recorded block filter scan returns three blocks. One of them is
+	// a false positive and must be skipped.
+	txHashes, err := connection.GetTxHashesForPublicKeyHash(
+		publicKeyHashFromString(t, "8db50eb52063ea9d98b3eac91489a90f738986f6"),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	transactions := testData.Transactions[bitcoin.Testnet]
+	expectedTxHashes := []bitcoin.Hash{
+		transactions["input: P2SH, output: P2WPKH"].TxHash,
+		transactions["input: P2WSH, output: P2WPKH"].TxHash,
+	}
+
+	if diff := deep.Equal(txHashes, expectedTxHashes); diff != nil {
+		t.Errorf("compare failed: %v", diff)
+	}
+}
+
+func TestConnection_GetTransactionsForPublicKeyHash(t *testing.T) {
+	connection, _ := connectStub(t)
+
+	// The recorded responses do not contain a getrawtransaction response
+	// without the block hash for the expected transaction so, this test
+	// also makes sure the block hash found during the history scan is used.
+	transactions, err := connection.GetTransactionsForPublicKeyHash(
+		publicKeyHashFromString(t, "8db50eb52063ea9d98b3eac91489a90f738986f6"),
+		1,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedTransaction := testData.Transactions[bitcoin.Testnet]["input: P2WSH, output: P2WPKH"]
+
+	testutils.AssertIntsEqual(t, "transactions count", 1, len(transactions))
+
+	if diff := deep.Equal(transactions[0], &expectedTransaction.BitcoinTx); diff != nil {
+		t.Errorf("compare failed: %v", diff)
+	}
+}
+
+func TestConnection_GetUtxosForPublicKeyHash(t *testing.T) {
+	connection, _ := connectStub(t)
+
+	// The recorded UTXO set scan returns three outputs. One of them is
+	// already spent by a mempool transaction and must be skipped.
+	utxos, err := connection.GetUtxosForPublicKeyHash(
+		publicKeyHashFromString(t, "e6f9d74726b19b75f16fe1e9feaec048aa4fa1d0"),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedUtxos := []string{
+		"ea374ab6842723c647c3fc0ab281ca0641eaa768576cf9df695ca5b827140214:0:10000",
+		"f65bc5029251f0042aedb37f90dbb2bfb63a2e81694beef9cae5ec62e954c22e:1:299200",
+	}
+
+	if diff := deep.Equal(formatUtxos(utxos), expectedUtxos); diff != nil {
+		t.Errorf("compare failed: %v", diff)
+	}
+}
+
+func TestConnection_GetMempoolForPublicKeyHash(t *testing.T) {
+	connection, _ := connectStub(t)
+
+	transactions, err := connection.GetMempoolForPublicKeyHash(
+		publicKeyHashFromString(t, "8db50eb52063ea9d98b3eac91489a90f738986f6"),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	txHashes := make([]string, len(transactions))
+	for i, transaction := range transactions {
+		txHashes[i] = transaction.Hash().Hex(bitcoin.ReversedByteOrder)
+	}
+	sort.Strings(txHashes)
+
+	expectedTxHashes := []string{
+		"3a0d1ab3ca2f99dca58b0e995850f795f39bfe868888123da3c9a88c66144bec",
+		"9efc9d555233e12e06378a35a7b988d54f7043b5c3156adc79c7af0a0fd6f1a0",
+	}
+
+	if diff := deep.Equal(txHashes, expectedTxHashes); diff != nil {
+		t.Errorf("compare failed: %v", diff)
+	}
+}
+
+func TestConnection_GetMempoolUtxosForPublicKeyHash(t *testing.T) {
+	connection, ss := connectStub(t)
+
+	publicKeyHash := publicKeyHashFromString(
+		t,
+		"8db50eb52063ea9d98b3eac91489a90f738986f6",
+	)
+
+	// The output of 9efc9d555233e12e06378a35a7b988d54f7043b5c3156adc79c7af0a0fd6f1a0
+	// is spent by another mempool transaction and must be skipped.
+	expectedUtxos := []string{
+		"3a0d1ab3ca2f99dca58b0e995850f795f39bfe868888123da3c9a88c66144bec:0:77000",
+	}
+
+	utxos, err := connection.GetMempoolUtxosForPublicKeyHash(publicKeyHash)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if diff := deep.Equal(formatUtxos(utxos), expectedUtxos); diff != nil {
+		t.Errorf("compare failed: %v", diff)
+	}
+
+	getRawTransactionCalls := ss.callsCount("getrawtransaction")
+
+	utxos, err = connection.GetMempoolUtxosForPublicKeyHash(publicKeyHash)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if diff := deep.Equal(formatUtxos(utxos), expectedUtxos); diff != nil {
+		t.Errorf("compare failed: %v", diff)
+	}
+
+	// Transactions fetched during the first scan should be cached. Only the
+	// transaction that could not be fetched is requested again.
+	testutils.AssertIntsEqual(
+		t,
+		"getrawtransaction calls count",
+		getRawTransactionCalls+1,
+		ss.callsCount("getrawtransaction"),
+	)
+}
+
+func TestConnection_EstimateSatPerVByteFee(t *testing.T) {
+	connection, _ := connectStub(t)
+
+	satPerVByteFee, err := connection.EstimateSatPerVByteFee(6)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertIntsEqual(t, "sat/vbyte fee", 12, int(satPerVByteFee))
+
+	_, err = connection.EstimateSatPerVByteFee(1)
+	if err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestConvertBtcKvbToSatVByte(t *testing.T) {
+	var tests = map[string]struct {
+		btcPerKvbFee           float64
+		expectedSatPerVByteFee int64
+	}{
+		"BTC/kvB is 0": {
+			btcPerKvbFee:           0,
+			expectedSatPerVByteFee: 1,
+		},
+		"BTC/kvB is 0.000001": {
+			btcPerKvbFee:           0.000001,
+			expectedSatPerVByteFee: 1,
+		},
+		"BTC/kvB is 0.00002": {
+			btcPerKvbFee:           0.00002,
+			expectedSatPerVByteFee: 2,
+		},
+		"BTC/kvB is 0.0012350": {
+			btcPerKvbFee:           0.0012350,
+			expectedSatPerVByteFee: 124,
+		},
+		"BTC/kvB is 0.0012349": {
+			btcPerKvbFee:           0.0012349,
+			expectedSatPerVByteFee: 123,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			satPerVByteFee := convertBtcKvbToSatVByte(test.btcPerKvbFee)
+
+			testutils.AssertIntsEqual(
+				t,
+				"sat/vbyte fee",
+				int(test.expectedSatPerVByteFee),
+				int(satPerVByteFee),
+			)
+		})
+	}
+}
+
+func TestConvertBtcToSatoshi(t *testing.T) {
+	var tests = map[string]struct {
+		amount           string
+		expectedSatoshis int64
+		expectedErr      bool
+	}{
+		"whole bitcoins": {
+			amount:           "21",
+			expectedSatoshis: 2100000000,
+		},
+		"fractional amount": {
+			amount:           "0.00299200",
+			expectedSatoshis: 299200,
+		},
+		"shortened fractional amount": {
+			amount:           "1.5",
+			expectedSatoshis: 150000000,
+		},
+		"single satoshi": {
+			amount:           "0.00000001",
+			expectedSatoshis: 1,
+		},
+		"too many decimal places": {
+			amount:      "0.000000001",
+			expectedErr: true,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			satoshis, err := convertBtcToSatoshi(json.Number(test.amount))
+
+			if test.expectedErr {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			testutils.AssertIntsEqual(
+				t,
+				"satoshis",
+				int(test.expectedSatoshis),
+				int(satoshis),
+			)
+		})
+	}
+}
+
+func publicKeyHashFromString(t *testing.T, s string) [20]byte {
+	bytes, err := hex.DecodeString(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var publicKeyHash [20]byte
+	copy(publicKeyHash[:], bytes)
+
+	return publicKeyHash
+}
+
+// formatUtxos formats the given UTXOs as txHash:outputIndex:value strings.
+func formatUtxos(utxos []*bitcoin.UnspentTransactionOutput) []string {
+	result := make([]string, len(utxos))
+	for i, utxo := range utxos {
+		result[i] = fmt.Sprintf(
+			"%s:%d:%d",
+			utxo.Outpoint.TransactionHash.Hex(bitcoin.ReversedByteOrder),
+			utxo.Outpoint.OutputIndex,
+			utxo.Value,
+		)
+	}
+
+	return result
+}
+
+// Make sure the basic authentication header is built the way Bitcoin Core
+// expects.
+func TestRPCClient_BasicAuth(t *testing.T) {
+	var authorization string
+
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			authorization = r.Header.Get("Authorization")
+			_, _ = w.Write([]byte(`{"result":1,"error":null,"id":1}`))
+		},
+	))
+	defer server.Close()
+
+	client := newRPCClient(server.URL, stubUsername, stubPassword)
+
+	var result int
+	if err := client.call(context.Background(), "getblockcount", nil, &result); err != nil {
+		t.Fatal(err)
+	}
+
+	expectedAuthorization := "Basic " + base64.StdEncoding.EncodeToString(
+		[]byte(stubUsername+":"+stubPassword),
+	)
+
+	testutils.AssertStringsEqual(
+		t,
+		"authorization header",
+		expectedAuthorization,
+		authorization,
+	)
+	testutils.AssertIntsEqual(t, "result", 1, result)
+}
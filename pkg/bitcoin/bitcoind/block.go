@@ -0,0 +1,33 @@
+package bitcoind
+
+import (
+	"encoding/hex"
+	"fmt"
+
+	"github.com/keep-network/keep-core/pkg/bitcoin"
+)
+
+// convertBlockHeader transforms a serialized block header returned by Bitcoin
+// Core to the format expected by the bitcoin.Chain interface.
+func convertBlockHeader(rawBlockHeader string) (*bitcoin.BlockHeader, error) {
+	headerBytes, err := hex.DecodeString(rawBlockHeader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode a hex string: [%w]", err)
+	}
+
+	if len(headerBytes) != bitcoin.BlockHeaderByteLength {
+		return nil, fmt.Errorf(
+			"wrong block header length; expected [%d], got [%d]",
+			bitcoin.BlockHeaderByteLength,
+			len(headerBytes),
+		)
+	}
+
+	var serializedHeader [bitcoin.BlockHeaderByteLength]byte
+	copy(serializedHeader[:], headerBytes)
+
+	blockHeader := new(bitcoin.BlockHeader)
+	blockHeader.Deserialize(serializedHeader)
+
+	return blockHeader, nil
+}
@@ -0,0 +1,33 @@
+package bitcoind
+
+import "time"
+
+const (
+	// DefaultRequestTimeout is a default timeout used for a single attempt of
+	// Bitcoin Core JSON-RPC request. It is relatively long as scans of the
+	// UTXO set may take a few minutes on mainnet.
+	DefaultRequestTimeout = 3 * time.Minute
+	// DefaultRequestRetryTimeout is a default timeout used for Bitcoin Core
+	// JSON-RPC request retries.
+	DefaultRequestRetryTimeout = 6 * time.Minute
+)
+
+// Config holds configurable properties.
+type Config struct {
+	// URL to the Bitcoin Core JSON-RPC server in format:
+	// `scheme://hostname:port`.
+	URL string
+	// Username used to authenticate to the Bitcoin Core JSON-RPC server.
+	Username string
+	// Password used to authenticate to the Bitcoin Core JSON-RPC server.
+	Password string
+	// Timeout for a single attempt of Bitcoin Core JSON-RPC request.
+	RequestTimeout time.Duration
+	// Timeout for Bitcoin Core JSON-RPC request retries.
+	RequestRetryTimeout time.Duration
+	// Height of the block the scans of transaction history start from.
+	// Transactions confirmed in earlier blocks are not taken into account.
+	// Setting it to a height preceding creation of the first tBTC wallet
+	// considerably speeds up the scans.
+	ScanStartHeight uint
+}
@@ -0,0 +1,229 @@
+package bitcoind
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"sync/atomic"
+)
+
+const (
+	// rpcInvalidAddressOrKeyErrorCode is the code of the error returned by
+	// Bitcoin Core if the requested transaction or block is not known.
+	rpcInvalidAddressOrKeyErrorCode = -5
+	// rpcInWarmupErrorCode is the code of the error returned by Bitcoin Core
+	// if it is still starting up and cannot handle requests yet.
+	rpcInWarmupErrorCode = -28
+)
+
+// rpcRequest is a single Bitcoin Core JSON-RPC request.
+type rpcRequest struct {
+	JSONRPC string        `json:"jsonrpc"`
+	ID      uint64        `json:"id"`
+	Method  string        `json:"method"`
+	Params  []interface{} `json:"params"`
+}
+
+// rpcResponse is a single Bitcoin Core JSON-RPC response.
+type rpcResponse struct {
+	ID     uint64          `json:"id"`
+	Result json.RawMessage `json:"result"`
+	Error  *rpcError       `json:"error"`
+}
+
+// rpcError is an error returned by Bitcoin Core for a request it received
+// and processed but could not fulfill.
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (re *rpcError) Error() string {
+	return fmt.Sprintf("code [%d]: [%s]", re.Code, re.Message)
+}
+
+// rpcClient is a minimal Bitcoin Core JSON-RPC client working over HTTP.
+type rpcClient struct {
+	url        string
+	username   string
+	password   string
+	httpClient *http.Client
+	lastID     uint64
+}
+
+func newRPCClient(url string, username string, password string) *rpcClient {
+	return &rpcClient{
+		url:        url,
+		username:   username,
+		password:   password,
+		httpClient: &http.Client{},
+	}
+}
+
+// call executes a single JSON-RPC request and unmarshals its result into
+// the given result value. If Bitcoin Core returns an error, this function
+// returns it as *rpcError.
+func (rc *rpcClient) call(
+	ctx context.Context,
+	method string,
+	params []interface{},
+	result interface{},
+) error {
+	request := rc.newRequest(method, params)
+
+	var response rpcResponse
+	if err := rc.post(ctx, request, &response); err != nil {
+		return err
+	}
+
+	if response.Error != nil {
+		return response.Error
+	}
+
+	if result == nil {
+		return nil
+	}
+
+	if err := json.Unmarshal(response.Result, result); err != nil {
+		return fmt.Errorf(
+			"cannot unmarshal result of [%s]: [%v]",
+			method,
+			err,
+		)
+	}
+
+	return nil
+}
+
+// batchCall executes the given method with each of the given parameters
+// lists in a single JSON-RPC batch request. The returned responses are in
+// the same order as the parameters lists. Errors returned by Bitcoin Core
+// for specific requests are not returned by this function but held by the
+// respective responses.
+func (rc *rpcClient) batchCall(
+	ctx context.Context,
+	method string,
+	paramsLists [][]interface{},
+) ([]*rpcResponse, error) {
+	if len(paramsLists) == 0 {
+		return []*rpcResponse{}, nil
+	}
+
+	requests := make([]*rpcRequest, len(paramsLists))
+	requestsIndexes := make(map[uint64]int, len(paramsLists))
+	for i, params := range paramsLists {
+		requests[i] = rc.newRequest(method, params)
+		requestsIndexes[requests[i].ID] = i
+	}
+
+	var responses []*rpcResponse
+	if err := rc.post(ctx, requests, &responses); err != nil {
+		return nil, err
+	}
+
+	if len(responses) != len(requests) {
+		return nil, fmt.Errorf(
+			"unexpected number of batch responses; expected [%d], got [%d]",
+			len(requests),
+			len(responses),
+		)
+	}
+
+	// The JSON-RPC specification does not guarantee responses to be in the
+	// same order as requests so, they must be matched using their IDs.
+	for _, response := range responses {
+		if _, ok := requestsIndexes[response.ID]; !ok {
+			return nil, fmt.Errorf(
+				"unexpected batch response ID [%d]",
+				response.ID,
+			)
+		}
+	}
+
+	sort.Slice(responses, func(i, j int) bool {
+		return requestsIndexes[responses[i].ID] < requestsIndexes[responses[j].ID]
+	})
+
+	return responses, nil
+}
+
+func (rc *rpcClient) newRequest(
+	method string,
+	params []interface{},
+) *rpcRequest {
+	if params == nil {
+		params = []interface{}{}
+	}
+
+	return &rpcRequest{
+		JSONRPC: "1.0",
+		ID:      atomic.AddUint64(&rc.lastID, 1),
+		Method:  method,
+		Params:  params,
+	}
+}
+
+// post sends the given payload to the JSON-RPC server and unmarshals the
+// response body into the given response value.
+func (rc *rpcClient) post(
+	ctx context.Context,
+	payload interface{},
+	response interface{},
+) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("cannot marshal request: [%v]", err)
+	}
+
+	httpRequest, err := http.NewRequestWithContext(
+		ctx,
+		http.MethodPost,
+		rc.url,
+		bytes.NewReader(body),
+	)
+	if err != nil {
+		return fmt.Errorf("cannot create HTTP request: [%v]", err)
+	}
+
+	httpRequest.Header.Set("Content-Type", "application/json")
+	if rc.username != "" || rc.password != "" {
+		httpRequest.SetBasicAuth(rc.username, rc.password)
+	}
+
+	httpResponse, err := rc.httpClient.Do(httpRequest)
+	if err != nil {
+		return fmt.Errorf("cannot execute HTTP request: [%v]", err)
+	}
+	defer httpResponse.Body.Close()
+
+	if httpResponse.StatusCode == http.StatusUnauthorized ||
+		httpResponse.StatusCode == http.StatusForbidden {
+		return fmt.Errorf(
+			"server rejected credentials with HTTP status [%s]",
+			httpResponse.Status,
+		)
+	}
+
+	responseBody, err := io.ReadAll(httpResponse.Body)
+	if err != nil {
+		return fmt.Errorf("cannot read HTTP response body: [%v]", err)
+	}
+
+	// Bitcoin Core responds with non-200 HTTP statuses along with a valid
+	// JSON-RPC response body when the request itself fails, e.g. the
+	// requested transaction does not exist. The body is decoded in the first
+	// place so such errors are surfaced to the caller.
+	if err := json.Unmarshal(responseBody, response); err != nil {
+		return fmt.Errorf(
+			"cannot decode response with HTTP status [%s]: [%v]",
+			httpResponse.Status,
+			err,
+		)
+	}
+
+	return nil
+}
@@ -0,0 +1,303 @@
+package bitcoind
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/btcsuite/btcd/v2/wire"
+
+	"github.com/keep-network/keep-core/pkg/bitcoin"
+)
+
+// satoshisPerBitcoinDecimals is the number of decimal places of a bitcoin
+// amount expressed in BTC.
+const satoshisPerBitcoinDecimals = 8
+
+// scriptPubKey is the locking script representation returned by Bitcoin Core.
+type scriptPubKey struct {
+	Hex string `json:"hex"`
+}
+
+// verboseTransaction is the transaction representation returned by Bitcoin
+// Core for verbose transaction and block queries. Only fields used by this
+// package are decoded.
+type verboseTransaction struct {
+	TxID          string `json:"txid"`
+	Hex           string `json:"hex"`
+	BlockHash     string `json:"blockhash"`
+	Confirmations uint   `json:"confirmations"`
+	Vin           []struct {
+		// Prevout is returned only for verbosity levels including prevout
+		// information and is absent for coinbase inputs.
+		Prevout *struct {
+			ScriptPubKey scriptPubKey `json:"scriptPubKey"`
+		} `json:"prevout"`
+	} `json:"vin"`
+	Vout []struct {
+		ScriptPubKey scriptPubKey `json:"scriptPubKey"`
+	} `json:"vout"`
+}
+
+// touchesScripts returns true if any of the transaction's outputs is locked
+// using one of the given scripts or any of the transaction's inputs spends
+// an output locked using one of the given scripts.
+func (vt *verboseTransaction) touchesScripts(scripts []string) bool {
+	for _, vout := range vt.Vout {
+		if containsScript(scripts, vout.ScriptPubKey.Hex) {
+			return true
+		}
+	}
+
+	for _, vin := range vt.Vin {
+		if vin.Prevout != nil &&
+			containsScript(scripts, vin.Prevout.ScriptPubKey.Hex) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func containsScript(scripts []string, script string) bool {
+	for _, s := range scripts {
+		if strings.EqualFold(s, script) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// verboseBlock is the block representation returned by Bitcoin Core for
+// verbose block queries. Only fields used by this package are decoded.
+type verboseBlock struct {
+	Hash         string                `json:"hash"`
+	Height       uint                  `json:"height"`
+	Transactions []*verboseTransaction `json:"tx"`
+}
+
+// decodeTransaction deserializes a transaction from the hexadecimal serialized
+// string to the format expected by the bitcoin.Chain interface.
+func decodeTransaction(rawTx string) (*bitcoin.Transaction, error) {
+	transactionBytes, err := hex.DecodeString(rawTx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode a hex string: [%w]", err)
+	}
+
+	transaction := new(bitcoin.Transaction)
+	if err := transaction.Deserialize(transactionBytes); err != nil {
+		return nil, fmt.Errorf("failed to deserialize a transaction: [%w]", err)
+	}
+
+	return transaction, nil
+}
+
+// convertBtcToSatoshi converts the given BTC amount returned by Bitcoin Core
+// to satoshis. The conversion is done on the decimal representation of the
+// amount in order to avoid floating point rounding errors.
+func convertBtcToSatoshi(amount json.Number) (int64, error) {
+	integerPart, fractionalPart, _ := strings.Cut(amount.String(), ".")
+
+	if len(fractionalPart) > satoshisPerBitcoinDecimals {
+		return 0, fmt.Errorf("amount [%s] has too many decimal places", amount)
+	}
+
+	fractionalPart += strings.Repeat(
+		"0",
+		satoshisPerBitcoinDecimals-len(fractionalPart),
+	)
+
+	satoshis, err := strconv.ParseInt(integerPart+fractionalPart, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("cannot parse amount [%s]: [%v]", amount, err)
+	}
+
+	return satoshis, nil
+}
+
+// convertMerkleBlock transforms a serialized merkle block returned by Bitcoin
+// Core for the given transaction to the format expected by the bitcoin.Chain
+// interface. The merkle block holds a partial merkle tree, as defined by
+// BIP-0037, whose only matched leaf is expected to be the given transaction.
+// The merkle branch of the transaction is extracted from the partial merkle
+// tree which is verified against the merkle root of the block header.
+func convertMerkleBlock(
+	rawMerkleBlock string,
+	transactionHash bitcoin.Hash,
+	blockHeight uint,
+) (*bitcoin.TransactionMerkleProof, error) {
+	merkleBlockBytes, err := hex.DecodeString(rawMerkleBlock)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode a hex string: [%w]", err)
+	}
+
+	var merkleBlock wire.MsgMerkleBlock
+	err = merkleBlock.BtcDecode(
+		bytes.NewReader(merkleBlockBytes),
+		wire.ProtocolVersion,
+		wire.BaseEncoding,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to deserialize a merkle block: [%w]", err)
+	}
+
+	if merkleBlock.Transactions == 0 {
+		return nil, fmt.Errorf("merkle block has no transactions")
+	}
+
+	tree := &partialMerkleTree{
+		transactionsCount: uint64(merkleBlock.Transactions),
+		flags:             merkleBlock.Flags,
+	}
+	for _, hash := range merkleBlock.Hashes {
+		tree.hashes = append(tree.hashes, bitcoin.Hash(*hash))
+	}
+
+	root, err := tree.extractBranch()
+	if err != nil {
+		return nil, fmt.Errorf("invalid partial merkle tree: [%v]", err)
+	}
+
+	if root != bitcoin.Hash(merkleBlock.Header.MerkleRoot) {
+		return nil, fmt.Errorf(
+			"partial merkle tree root does not match the block header",
+		)
+	}
+
+	if tree.matchedHash != transactionHash {
+		return nil, fmt.Errorf(
+			"partial merkle tree matches transaction [%s] instead of [%s]",
+			tree.matchedHash.Hex(bitcoin.ReversedByteOrder),
+			transactionHash.Hex(bitcoin.ReversedByteOrder),
+		)
+	}
+
+	merkleNodes := make([]string, len(tree.branch))
+	for i, node := range tree.branch {
+		merkleNodes[i] = node.Hex(bitcoin.ReversedByteOrder)
+	}
+
+	return &bitcoin.TransactionMerkleProof{
+		BlockHeight: blockHeight,
+		MerkleNodes: merkleNodes,
+		Position:    tree.matchedPosition,
+	}, nil
+}
+
+// partialMerkleTree is a BIP-0037 partial merkle tree having a single
+// matched leaf.
+type partialMerkleTree struct {
+	transactionsCount uint64
+	hashes            []bitcoin.Hash
+	flags             []byte
+
+	usedHashes int
+	usedFlags  int
+
+	matchedCount    int
+	matchedHash     bitcoin.Hash
+	matchedPosition uint
+	// branch holds hashes the matched leaf is paired with, recursively,
+	// deepest pairing first.
+	branch []bitcoin.Hash
+}
+
+// extractBranch traverses the tree in order to find its matched leaf along
+// with the merkle branch of that leaf. Returns the merkle root computed
+// during the traversal.
+func (pmt *partialMerkleTree) extractBranch() (bitcoin.Hash, error) {
+	height := uint(0)
+	for pmt.width(height) > 1 {
+		height++
+	}
+
+	root, _, err := pmt.traverse(height, 0)
+	if err != nil {
+		return bitcoin.Hash{}, err
+	}
+
+	if pmt.usedHashes != len(pmt.hashes) {
+		return bitcoin.Hash{}, fmt.Errorf("not all hashes were used")
+	}
+
+	if (pmt.usedFlags+7)/8 != len(pmt.flags) {
+		return bitcoin.Hash{}, fmt.Errorf("not all flags were used")
+	}
+
+	if pmt.matchedCount != 1 {
+		return bitcoin.Hash{}, fmt.Errorf(
+			"expected exactly one matched transaction; got [%d]",
+			pmt.matchedCount,
+		)
+	}
+
+	return root, nil
+}
+
+// width returns the number of nodes at the given height of the tree, where
+// height 0 denotes leaves.
+func (pmt *partialMerkleTree) width(height uint) uint64 {
+	return (pmt.transactionsCount + (1 << height) - 1) >> height
+}
+
+// traverse processes the subtree rooted at the node with the given height
+// and position, in the depth-first order. Returns the hash of the node and
+// a flag indicating whether the subtree contains the matched leaf.
+func (pmt *partialMerkleTree) traverse(
+	height uint,
+	position uint64,
+) (bitcoin.Hash, bool, error) {
+	if pmt.usedFlags >= len(pmt.flags)*8 {
+		return bitcoin.Hash{}, false, fmt.Errorf("flags exhausted")
+	}
+
+	flag := pmt.flags[pmt.usedFlags/8]&(1<<(pmt.usedFlags%8)) != 0
+	pmt.usedFlags++
+
+	if height == 0 || !flag {
+		if pmt.usedHashes >= len(pmt.hashes) {
+			return bitcoin.Hash{}, false, fmt.Errorf("hashes exhausted")
+		}
+
+		hash := pmt.hashes[pmt.usedHashes]
+		pmt.usedHashes++
+
+		matched := height == 0 && flag
+		if matched {
+			pmt.matchedCount++
+			pmt.matchedHash = hash
+			pmt.matchedPosition = uint(position)
+		}
+
+		return hash, matched, nil
+	}
+
+	left, leftMatched, err := pmt.traverse(height-1, position*2)
+	if err != nil {
+		return bitcoin.Hash{}, false, err
+	}
+
+	// If the right child does not exist, the left one is paired with itself.
+	right, rightMatched := left, false
+	if position*2+1 < pmt.width(height-1) {
+		right, rightMatched, err = pmt.traverse(height-1, position*2+1)
+		if err != nil {
+			return bitcoin.Hash{}, false, err
+		}
+	}
+
+	if leftMatched {
+		pmt.branch = append(pmt.branch, right)
+	}
+	if rightMatched {
+		pmt.branch = append(pmt.branch, left)
+	}
+
+	hash := bitcoin.ComputeHash(append(left[:], right[:]...))
+
+	return hash, leftMatched || rightMatched, nil
+}
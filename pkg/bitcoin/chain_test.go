@@ -222,3 +222,13 @@ func (lc *localChain) addBlockHeader(
 
 	return nil
 }
+
+func (lc *localChain) setBlockHeader(
+	blockNumber uint,
+	blockHeader *BlockHeader,
+) {
+	lc.blockHeadersMutex.Lock()
+	defer lc.blockHeadersMutex.Unlock()
+
+	lc.blockHeaders[blockNumber] = blockHeader
+}
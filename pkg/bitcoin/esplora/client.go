@@ -0,0 +1,148 @@
+package esplora
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// maxResponseBodySize is the maximum size of the response body read from
+// the Esplora server.
+const maxResponseBodySize = 16 * 1024 * 1024
+
+// httpError is an error returned by the Esplora server for a request it
+// received but could not fulfill.
+type httpError struct {
+	statusCode int
+	message    string
+}
+
+func (he *httpError) Error() string {
+	return fmt.Sprintf(
+		"HTTP status [%d]: [%s]",
+		he.statusCode,
+		he.message,
+	)
+}
+
+// isRetriable returns true if the request may succeed if it is repeated,
+// i.e. the server was overloaded or failed internally. Other errors, like
+// a transaction not found or rejected during broadcast, are final.
+func (he *httpError) isRetriable() bool {
+	return he.statusCode == http.StatusTooManyRequests ||
+		he.statusCode >= http.StatusInternalServerError
+}
+
+// apiClient is a minimal Esplora REST API client. The underlying HTTP client
+// keeps a pool of connections reused by subsequent requests.
+type apiClient struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+func newAPIClient(baseURL string, maxConnections int) *apiClient {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.MaxIdleConns = maxConnections
+	transport.MaxIdleConnsPerHost = maxConnections
+	transport.MaxConnsPerHost = maxConnections
+	transport.IdleConnTimeout = 90 * time.Second
+
+	return &apiClient{
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		httpClient: &http.Client{Transport: transport},
+	}
+}
+
+// getText executes a GET request for the given path and returns the
+// response body as a trimmed string.
+func (ac *apiClient) getText(ctx context.Context, path string) (string, error) {
+	body, err := ac.do(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(string(body)), nil
+}
+
+// getJSON executes a GET request for the given path and unmarshals the
+// response body into the given result value.
+func (ac *apiClient) getJSON(
+	ctx context.Context,
+	path string,
+	result interface{},
+) error {
+	body, err := ac.do(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(body, result); err != nil {
+		return fmt.Errorf("cannot unmarshal response of [%s]: [%v]", path, err)
+	}
+
+	return nil
+}
+
+// postText executes a POST request for the given path with the given text
+// payload and returns the response body as a trimmed string.
+func (ac *apiClient) postText(
+	ctx context.Context,
+	path string,
+	payload string,
+) (string, error) {
+	body, err := ac.do(ctx, http.MethodPost, path, strings.NewReader(payload))
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(string(body)), nil
+}
+
+// do executes the HTTP request and returns the response body. If the server
+// responds with a non-200 status, this function returns *httpError.
+func (ac *apiClient) do(
+	ctx context.Context,
+	method string,
+	path string,
+	payload io.Reader,
+) ([]byte, error) {
+	httpRequest, err := http.NewRequestWithContext(
+		ctx,
+		method,
+		ac.baseURL+path,
+		payload,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create HTTP request: [%v]", err)
+	}
+
+	if payload != nil {
+		httpRequest.Header.Set("Content-Type", "text/plain")
+	}
+
+	httpResponse, err := ac.httpClient.Do(httpRequest)
+	if err != nil {
+		return nil, fmt.Errorf("cannot execute HTTP request: [%v]", err)
+	}
+	// The body must be read until the end and closed so the connection can
+	// be reused by subsequent requests.
+	defer httpResponse.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(httpResponse.Body, maxResponseBodySize))
+	if err != nil {
+		return nil, fmt.Errorf("cannot read HTTP response body: [%v]", err)
+	}
+
+	if httpResponse.StatusCode != http.StatusOK {
+		return nil, &httpError{
+			statusCode: httpResponse.StatusCode,
+			message:    strings.TrimSpace(string(body)),
+		}
+	}
+
+	return body, nil
+}
@@ -0,0 +1,29 @@
+package esplora
+
+import "time"
+
+const (
+	// DefaultRequestTimeout is a default timeout used for a single attempt of
+	// Esplora API request.
+	DefaultRequestTimeout = 30 * time.Second
+	// DefaultRequestRetryTimeout is a default timeout used for Esplora API
+	// request retries.
+	DefaultRequestRetryTimeout = 2 * time.Minute
+	// DefaultMaxConnections is a default maximum number of connections kept
+	// open to the Esplora server.
+	DefaultMaxConnections = 16
+)
+
+// Config holds configurable properties.
+type Config struct {
+	// URL to the Esplora API in format: `scheme://hostname:port/path`,
+	// e.g. `https://blockstream.info/testnet/api`.
+	URL string
+	// Timeout for a single attempt of Esplora API request.
+	RequestTimeout time.Duration
+	// Timeout for Esplora API request retries.
+	RequestRetryTimeout time.Duration
+	// Maximum number of connections kept open to the Esplora server. Idle
+	// connections are reused by subsequent requests.
+	MaxConnections int
+}
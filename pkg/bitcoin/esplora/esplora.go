@@ -0,0 +1,950 @@
+package esplora
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/ipfs/go-log"
+	"go.uber.org/zap"
+
+	"github.com/keep-network/keep-common/pkg/wrappers"
+	"github.com/keep-network/keep-core/pkg/bitcoin"
+)
+
+var logger = log.Logger("keep-esplora")
+
+// Connection is a handle for interactions with Esplora API.
+type Connection struct {
+	parentCtx context.Context
+	client    *apiClient
+	config    Config
+}
+
+// Connect initializes handle with provided Config.
+func Connect(parentCtx context.Context, config Config) (bitcoin.Chain, error) {
+	if config.RequestTimeout == 0 {
+		config.RequestTimeout = DefaultRequestTimeout
+	}
+	if config.RequestRetryTimeout == 0 {
+		config.RequestRetryTimeout = DefaultRequestRetryTimeout
+	}
+	if config.MaxConnections == 0 {
+		config.MaxConnections = DefaultMaxConnections
+	}
+
+	c := &Connection{
+		parentCtx: parentCtx,
+		client:    newAPIClient(config.URL, config.MaxConnections),
+		config:    config,
+	}
+
+	if err := c.verifyServer(); err != nil {
+		return nil, fmt.Errorf("failed to verify esplora server: [%w]", err)
+	}
+
+	return c, nil
+}
+
+// GetTransaction gets the transaction with the given transaction hash.
+// If the transaction with the given hash was not found on the chain,
+// this function returns an error.
+func (c *Connection) GetTransaction(
+	transactionHash bitcoin.Hash,
+) (*bitcoin.Transaction, error) {
+	txID := transactionHash.Hex(bitcoin.ReversedByteOrder)
+
+	rawTransaction, err := requestWithRetry(
+		c,
+		func(ctx context.Context, client *apiClient) (string, error) {
+			return client.getText(ctx, fmt.Sprintf("/tx/%s/hex", txID))
+		},
+		"GetTransactionHex",
+	)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"failed to get raw transaction with ID [%s]: [%w]",
+			txID,
+			err,
+		)
+	}
+
+	result, err := decodeTransaction(rawTransaction)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert transaction: [%w]", err)
+	}
+
+	return result, nil
+}
+
+// GetTransactionConfirmations gets the number of confirmations for the
+// transaction with the given transaction hash. If the transaction with the
+// given hash was not found on the chain, this function returns an error.
+func (c *Connection) GetTransactionConfirmations(
+	transactionHash bitcoin.Hash,
+) (uint, error) {
+	txID := transactionHash.Hex(bitcoin.ReversedByteOrder)
+
+	status, err := requestWithRetry(
+		c,
+		func(
+			ctx context.Context,
+			client *apiClient,
+		) (*transactionStatus, error) {
+			status := new(transactionStatus)
+			err := client.getJSON(
+				ctx,
+				fmt.Sprintf("/tx/%s/status", txID),
+				status,
+			)
+			return status, err
+		},
+		"GetTransactionStatus",
+	)
+	if err != nil {
+		return 0, fmt.Errorf(
+			"failed to get status of transaction with ID [%s]: [%w]",
+			txID,
+			err,
+		)
+	}
+
+	if !status.Confirmed {
+		return 0, nil
+	}
+
+	latestBlockHeight, err := c.GetLatestBlockHeight()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get latest block height: [%w]", err)
+	}
+
+	if latestBlockHeight < status.BlockHeight {
+		return 0, fmt.Errorf(
+			"transaction block height [%d] is greater than "+
+				"latest block height [%d]",
+			status.BlockHeight,
+			latestBlockHeight,
+		)
+	}
+
+	return latestBlockHeight - status.BlockHeight + 1, nil
+}
+
+// BroadcastTransaction broadcasts the given transaction over the
+// network of the Bitcoin chain nodes. If the broadcast action could not be
+// done, this function returns an error. This function does not give any
+// guarantees regarding transaction mining. The transaction may be mined or
+// rejected eventually.
+func (c *Connection) BroadcastTransaction(
+	transaction *bitcoin.Transaction,
+) error {
+	rawTx := hex.EncodeToString(transaction.Serialize())
+
+	rawTxLogger := logger.With(
+		zap.String("rawTx", rawTx),
+	)
+	rawTxLogger.Debugf("broadcasting transaction")
+
+	response, err := requestWithRetry(
+		c,
+		func(ctx context.Context, client *apiClient) (string, error) {
+			return client.postText(ctx, "/tx", rawTx)
+		},
+		"BroadcastTransaction",
+	)
+	if err != nil {
+		return fmt.Errorf("failed to broadcast the transaction: [%w]", err)
+	}
+
+	rawTxLogger.Infof("transaction broadcast successful: [%s]", response)
+
+	return nil
+}
+
+// GetLatestBlockHeight gets the height of the latest block (tip). If the
+// latest block was not determined, this function returns an error.
+func (c *Connection) GetLatestBlockHeight() (uint, error) {
+	blockHeight, err := requestWithRetry(
+		c,
+		func(ctx context.Context, client *apiClient) (uint, error) {
+			response, err := client.getText(ctx, "/blocks/tip/height")
+			if err != nil {
+				return 0, err
+			}
+
+			blockHeight, err := strconv.ParseUint(response, 10, 64)
+			if err != nil {
+				return 0, fmt.Errorf(
+					"cannot parse block height [%s]: [%v]",
+					response,
+					err,
+				)
+			}
+
+			return uint(blockHeight), nil
+		},
+		"GetLatestBlockHeight",
+	)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get latest block height: [%w]", err)
+	}
+
+	return blockHeight, nil
+}
+
+// GetBlockHeader gets the block header for the given block height. If the
+// block with the given height was not found on the chain, this function
+// returns an error.
+func (c *Connection) GetBlockHeader(
+	blockHeight uint,
+) (*bitcoin.BlockHeader, error) {
+	blockHash, err := requestWithRetry(
+		c,
+		func(ctx context.Context, client *apiClient) (string, error) {
+			return client.getText(
+				ctx,
+				fmt.Sprintf("/block-height/%d", blockHeight),
+			)
+		},
+		"GetBlockHash",
+	)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"failed to get hash of block [%d]: [%w]",
+			blockHeight,
+			err,
+		)
+	}
+
+	rawBlockHeader, err := requestWithRetry(
+		c,
+		func(ctx context.Context, client *apiClient) (string, error) {
+			return client.getText(
+				ctx,
+				fmt.Sprintf("/block/%s/header", blockHash),
+			)
+		},
+		"GetBlockHeader",
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get block header: [%w]", err)
+	}
+
+	blockHeader, err := convertBlockHeader(rawBlockHeader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert block header: [%w]", err)
+	}
+
+	return blockHeader, nil
+}
+
+// GetTransactionMerkleProof gets the Merkle proof for a given transaction.
+// The transaction's hash and the block the transaction was included in the
+// blockchain need to be provided.
+func (c *Connection) GetTransactionMerkleProof(
+	transactionHash bitcoin.Hash,
+	blockHeight uint,
+) (*bitcoin.TransactionMerkleProof, error) {
+	txID := transactionHash.Hex(bitcoin.ReversedByteOrder)
+
+	proof, err := requestWithRetry(
+		c,
+		func(ctx context.Context, client *apiClient) (*merkleProof, error) {
+			proof := new(merkleProof)
+			err := client.getJSON(
+				ctx,
+				fmt.Sprintf("/tx/%s/merkle-proof", txID),
+				proof,
+			)
+			return proof, err
+		},
+		"GetMerkleProof",
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get merkle proof: [%w]", err)
+	}
+
+	// The Esplora API determines the block on its own so, it must be
+	// ensured the proof refers to the expected one.
+	if proof.BlockHeight != blockHeight {
+		return nil, fmt.Errorf(
+			"merkle proof refers to block [%d] instead of [%d]",
+			proof.BlockHeight,
+			blockHeight,
+		)
+	}
+
+	return convertMerkleProof(proof), nil
+}
+
+// GetTransactionsForPublicKeyHash gets confirmed transactions that pays the
+// given public key hash using either a P2PKH or P2WPKH script. The returned
+// transactions are ordered by block height in the ascending order, i.e.
+// the latest transaction is at the end of the list. The returned list does
+// not contain unconfirmed transactions living in the mempool at the moment
+// of request. The returned transactions list can be limited using the
+// `limit` parameter. For example, if `limit` is set to `5`, only the
+// latest five transactions will be returned. Note that taking an unlimited
+// transaction history may be time-consuming as this function fetches
+// complete transactions with all necessary data.
+//
+// The Esplora API returns the history in pages, starting from the latest
+// transactions, so only pages needed to satisfy the limit are fetched.
+func (c *Connection) GetTransactionsForPublicKeyHash(
+	publicKeyHash [20]byte,
+	limit int,
+) ([]*bitcoin.Transaction, error) {
+	if limit <= 0 {
+		return []*bitcoin.Transaction{}, nil
+	}
+
+	items, err := c.getConfirmedPublicKeyHashHistory(publicKeyHash, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(items) > limit {
+		items = items[len(items)-limit:]
+	}
+
+	transactions := make([]*bitcoin.Transaction, len(items))
+	for i, item := range items {
+		transactions[i] = item.transaction
+	}
+
+	return transactions, nil
+}
+
+// GetTxHashesForPublicKeyHash gets hashes of confirmed transactions that pays
+// the given public key hash using either a P2PKH or P2WPKH script. The returned
+// transactions hashes are ordered by block height in the ascending order, i.e.
+// the latest transaction hash is at the end of the list. The returned list does
+// not contain unconfirmed transactions hashes living in the mempool at the
+// moment of request.
+func (c *Connection) GetTxHashesForPublicKeyHash(
+	publicKeyHash [20]byte,
+) ([]bitcoin.Hash, error) {
+	items, err := c.getConfirmedPublicKeyHashHistory(publicKeyHash, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	txHashes := make([]bitcoin.Hash, len(items))
+	for i, item := range items {
+		txHashes[i] = item.txHash
+	}
+
+	return txHashes, nil
+}
+
+type scriptHistoryItem struct {
+	txHash      bitcoin.Hash
+	blockHeight uint
+	transaction *bitcoin.Transaction
+}
+
+// getConfirmedPublicKeyHashHistory returns a history of confirmed
+// transactions for P2PKH and P2WPKH scripts of the given public key hash.
+// The returned list is sorted by the block height in the ascending order.
+// If the limit is greater than zero, only pages necessary to get the given
+// number of the latest transactions for each script are fetched.
+func (c *Connection) getConfirmedPublicKeyHashHistory(
+	publicKeyHash [20]byte,
+	limit int,
+) ([]*scriptHistoryItem, error) {
+	p2pkh, err := bitcoin.PayToPublicKeyHash(publicKeyHash)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"cannot build P2PKH for public key hash [0x%x]: [%v]",
+			publicKeyHash,
+			err,
+		)
+	}
+
+	p2wpkh, err := bitcoin.PayToWitnessPublicKeyHash(publicKeyHash)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"cannot build P2WPKH for public key hash [0x%x]: [%v]",
+			publicKeyHash,
+			err,
+		)
+	}
+
+	p2pkhItems, err := c.getConfirmedScriptHistory(p2pkh, limit)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"cannot get P2PKH history for public key hash [0x%x]: [%v]",
+			publicKeyHash,
+			err,
+		)
+	}
+
+	p2wpkhItems, err := c.getConfirmedScriptHistory(p2wpkh, limit)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"cannot get P2WPKH history for public key hash [0x%x]: [%v]",
+			publicKeyHash,
+			err,
+		)
+	}
+
+	// A single transaction may touch both scripts so, it is taken only once.
+	items := make([]*scriptHistoryItem, 0, len(p2pkhItems)+len(p2wpkhItems))
+	seen := make(map[bitcoin.Hash]bool)
+	for _, item := range append(p2pkhItems, p2wpkhItems...) {
+		if seen[item.txHash] {
+			continue
+		}
+		seen[item.txHash] = true
+
+		items = append(items, item)
+	}
+
+	sort.SliceStable(
+		items,
+		func(i, j int) bool {
+			return items[i].blockHeight < items[j].blockHeight
+		},
+	)
+
+	return items, nil
+}
+
+// getConfirmedScriptHistory returns a history of confirmed transactions for
+// the given script (P2PKH, P2WPKH, P2SH, P2WSH, etc.). The returned list
+// is sorted by the block height in the ascending order, i.e. the latest
+// transaction is at the end of the list. If the limit is greater than zero,
+// pages are fetched only until the given number of the latest transactions
+// is collected. The returned list may contain more items than the limit.
+func (c *Connection) getConfirmedScriptHistory(
+	script []byte,
+	limit int,
+) ([]*scriptHistoryItem, error) {
+	scriptHash := computeScriptHash(script)
+
+	items := make([]*scriptHistoryItem, 0)
+	lastSeenTxID := ""
+
+	for {
+		path := fmt.Sprintf("/scripthash/%s/txs/chain", scriptHash)
+		if lastSeenTxID != "" {
+			path = fmt.Sprintf("%s/%s", path, lastSeenTxID)
+		}
+
+		// Transactions are returned starting from the latest ones. Each page
+		// starts after the last transaction seen on the previous page.
+		page, err := requestWithRetry(
+			c,
+			func(
+				ctx context.Context,
+				client *apiClient,
+			) ([]*transaction, error) {
+				var page []*transaction
+				err := client.getJSON(ctx, path, &page)
+				return page, err
+			},
+			"GetScriptHashChainTransactions",
+		)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"failed to get history for script [0x%x]: [%v]",
+				script,
+				err,
+			)
+		}
+
+		if len(page) == 0 {
+			break
+		}
+
+		for _, t := range page {
+			if !t.Status.Confirmed {
+				continue
+			}
+
+			convertedTransaction, err := convertTransaction(t)
+			if err != nil {
+				return nil, fmt.Errorf(
+					"cannot convert transaction [%s]: [%v]",
+					t.TxID,
+					err,
+				)
+			}
+
+			items = append(items, &scriptHistoryItem{
+				txHash:      convertedTransaction.Hash(),
+				blockHeight: t.Status.BlockHeight,
+				transaction: convertedTransaction,
+			})
+		}
+
+		if limit > 0 && len(items) >= limit {
+			break
+		}
+
+		lastSeenTxID = page[len(page)-1].TxID
+	}
+
+	sort.SliceStable(
+		items,
+		func(i, j int) bool {
+			return items[i].blockHeight < items[j].blockHeight
+		},
+	)
+
+	return items, nil
+}
+
+// GetMempoolForPublicKeyHash gets the unconfirmed mempool transactions
+// that pays the given public key hash using either a P2PKH or P2WPKH script.
+// The returned transactions are in an indefinite order.
+//
+// Note that the Esplora API returns at most 50 mempool transactions for
+// a single script.
+func (c *Connection) GetMempoolForPublicKeyHash(
+	publicKeyHash [20]byte,
+) ([]*bitcoin.Transaction, error) {
+	p2pkh, err := bitcoin.PayToPublicKeyHash(publicKeyHash)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"cannot build P2PKH for public key hash [0x%x]: [%v]",
+			publicKeyHash,
+			err,
+		)
+	}
+
+	p2wpkh, err := bitcoin.PayToWitnessPublicKeyHash(publicKeyHash)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"cannot build P2WPKH for public key hash [0x%x]: [%v]",
+			publicKeyHash,
+			err,
+		)
+	}
+
+	p2pkhTransactions, err := c.getScriptMempool(p2pkh)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"cannot get P2PKH mempool items for public key hash [0x%x]: [%v]",
+			publicKeyHash,
+			err,
+		)
+	}
+
+	p2wpkhTransactions, err := c.getScriptMempool(p2wpkh)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"cannot get P2WPKH mempool items for public key hash [0x%x]: [%v]",
+			publicKeyHash,
+			err,
+		)
+	}
+
+	transactions := make([]*bitcoin.Transaction, 0)
+	seen := make(map[bitcoin.Hash]bool)
+	for _, t := range append(p2pkhTransactions, p2wpkhTransactions...) {
+		txHash := t.Hash()
+		if seen[txHash] {
+			continue
+		}
+		seen[txHash] = true
+
+		transactions = append(transactions, t)
+	}
+
+	return transactions, nil
+}
+
+// getScriptMempool returns unconfirmed mempool transactions for
+// the given script (P2PKH, P2WPKH, P2SH, P2WSH, etc.). The returned list
+// is in an indefinite order.
+func (c *Connection) getScriptMempool(
+	script []byte,
+) ([]*bitcoin.Transaction, error) {
+	scriptHash := computeScriptHash(script)
+
+	items, err := requestWithRetry(
+		c,
+		func(ctx context.Context, client *apiClient) ([]*transaction, error) {
+			var items []*transaction
+			err := client.getJSON(
+				ctx,
+				fmt.Sprintf("/scripthash/%s/txs/mempool", scriptHash),
+				&items,
+			)
+			return items, err
+		},
+		"GetScriptHashMempoolTransactions",
+	)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"failed to get mempool for script [0x%x]: [%v]",
+			script,
+			err,
+		)
+	}
+
+	transactions := make([]*bitcoin.Transaction, len(items))
+	for i, item := range items {
+		transaction, err := convertTransaction(item)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"cannot convert transaction [%s]: [%v]",
+				item.TxID,
+				err,
+			)
+		}
+
+		transactions[i] = transaction
+	}
+
+	return transactions, nil
+}
+
+// GetUtxosForPublicKeyHash gets unspent outputs of confirmed transactions that
+// are controlled by the given public key hash (either a P2PKH or P2WPKH script).
+// The returned UTXOs are ordered by block height in the ascending order, i.e.
+// the latest UTXO is at the end of the list. The returned list does not contain
+// unspent outputs of unconfirmed transactions living in the mempool at the
+// moment of request. Outputs used as inputs of confirmed or mempool
+// transactions are not returned as well because they are no longer UTXOs.
+func (c *Connection) GetUtxosForPublicKeyHash(
+	publicKeyHash [20]byte,
+) ([]*bitcoin.UnspentTransactionOutput, error) {
+	return c.getPublicKeyHashUtxos(publicKeyHash, true)
+}
+
+// GetMempoolUtxosForPublicKeyHash gets unspent outputs of unconfirmed transactions
+// that are controlled by the given public key hash (either a P2PKH or P2WPKH script).
+// The returned UTXOs are in an indefinite order. The returned list does not
+// contain unspent outputs of confirmed transactions. Outputs used as inputs of
+// confirmed or mempool transactions are not returned as well because they are
+// no longer UTXOs.
+func (c *Connection) GetMempoolUtxosForPublicKeyHash(
+	publicKeyHash [20]byte,
+) ([]*bitcoin.UnspentTransactionOutput, error) {
+	return c.getPublicKeyHashUtxos(publicKeyHash, false)
+}
+
+// getPublicKeyHashUtxos returns unspent outputs of confirmed/unconfirmed
+// transactions controlled by the given public key hash (either a P2PKH or
+// P2WPKH script). See getScriptUtxos for details about the `confirmed` flag.
+func (c *Connection) getPublicKeyHashUtxos(
+	publicKeyHash [20]byte,
+	confirmed bool,
+) ([]*bitcoin.UnspentTransactionOutput, error) {
+	p2pkh, err := bitcoin.PayToPublicKeyHash(publicKeyHash)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"cannot build P2PKH for public key hash [0x%x]: [%v]",
+			publicKeyHash,
+			err,
+		)
+	}
+
+	p2wpkh, err := bitcoin.PayToWitnessPublicKeyHash(publicKeyHash)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"cannot build P2WPKH for public key hash [0x%x]: [%v]",
+			publicKeyHash,
+			err,
+		)
+	}
+
+	p2pkhItems, err := c.getScriptUtxos(p2pkh, confirmed)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"cannot get P2PKH UTXOs for public key hash [0x%x]: [%v]",
+			publicKeyHash,
+			err,
+		)
+	}
+
+	p2wpkhItems, err := c.getScriptUtxos(p2wpkh, confirmed)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"cannot get P2WPKH UTXOs for public key hash [0x%x]: [%v]",
+			publicKeyHash,
+			err,
+		)
+	}
+
+	items := append(p2pkhItems, p2wpkhItems...)
+
+	if confirmed {
+		sort.SliceStable(
+			items,
+			func(i, j int) bool {
+				return items[i].blockHeight < items[j].blockHeight
+			},
+		)
+	}
+
+	utxos := make([]*bitcoin.UnspentTransactionOutput, len(items))
+	for i, item := range items {
+		utxos[i] = &bitcoin.UnspentTransactionOutput{
+			Outpoint: &bitcoin.TransactionOutpoint{
+				TransactionHash: item.txHash,
+				OutputIndex:     item.outputIndex,
+			},
+			Value: item.value,
+		}
+	}
+
+	return utxos, nil
+}
+
+type scriptUtxoItem struct {
+	txHash      bitcoin.Hash
+	outputIndex uint32
+	value       int64
+	blockHeight uint
+}
+
+// getScriptUtxos returns unspent outputs of confirmed/unconfirmed transactions
+// that are locked using the given script (P2PKH, P2WPKH, P2SH, P2WSH, etc.).
+//
+// If the `confirmed` flag is true, the returned list contains unspent outputs
+// of confirmed transactions, sorted by the block height in the ascending order,
+// i.e. the latest UTXO is at the end of the list. The resulting list does not
+// contain unspent outputs of unconfirmed transactions living in the mempool
+// at the moment of request.
+//
+// If the `confirmed` flag is false, the returned list contains unspent outputs
+// of unconfirmed transactions, in an indefinite order. The resulting list
+// does not contain unspent outputs of confirmed transactions.
+//
+// In both cases, the resulted list DOES NOT CONTAIN outputs already used as
+// inputs of confirmed or mempool transactions because they are no longer UTXOs.
+func (c *Connection) getScriptUtxos(
+	script []byte,
+	confirmed bool,
+) ([]*scriptUtxoItem, error) {
+	scriptHash := computeScriptHash(script)
+
+	items, err := requestWithRetry(
+		c,
+		func(ctx context.Context, client *apiClient) ([]*utxo, error) {
+			var items []*utxo
+			err := client.getJSON(
+				ctx,
+				fmt.Sprintf("/scripthash/%s/utxo", scriptHash),
+				&items,
+			)
+			return items, err
+		},
+		"GetScriptHashUtxos",
+	)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"failed to get UTXOs for script [0x%x]: [%v]",
+			script,
+			err,
+		)
+	}
+
+	// The Esplora API takes mempool transactions into account so, outputs
+	// spent by mempool transactions are not returned while outputs of
+	// mempool transactions are returned as unconfirmed.
+	filteredItems := make([]*scriptUtxoItem, 0)
+	for _, item := range items {
+		if item.Status.Confirmed != confirmed {
+			continue
+		}
+
+		txHash, err := bitcoin.NewHashFromString(
+			item.TxID,
+			bitcoin.ReversedByteOrder,
+		)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"cannot parse hash [%s]: [%v]",
+				item.TxID,
+				err,
+			)
+		}
+
+		filteredItems = append(
+			filteredItems, &scriptUtxoItem{
+				txHash:      txHash,
+				outputIndex: item.Vout,
+				value:       item.Value,
+				blockHeight: item.Status.BlockHeight,
+			},
+		)
+	}
+
+	if confirmed {
+		// The order of UTXOs returned by the Esplora API is not specified
+		// so they must be sorted explicitly. Sorting makes sense only for
+		// confirmed items as unconfirmed ones have no block height.
+		sort.SliceStable(
+			filteredItems,
+			func(i, j int) bool {
+				return filteredItems[i].blockHeight < filteredItems[j].blockHeight
+			},
+		)
+	}
+
+	return filteredItems, nil
+}
+
+// EstimateSatPerVByteFee returns the estimated sat/vbyte fee for a
+// transaction to be confirmed within the given number of blocks.
+func (c *Connection) EstimateSatPerVByteFee(blocks uint32) (int64, error) {
+	// According to Esplora API docs, the returned object maps confirmation
+	// targets, expressed in blocks, to sat/vbyte fees.
+	estimates, err := requestWithRetry(
+		c,
+		func(
+			ctx context.Context,
+			client *apiClient,
+		) (map[string]float64, error) {
+			estimates := make(map[string]float64)
+			err := client.getJSON(ctx, "/fee-estimates", &estimates)
+			return estimates, err
+		},
+		"GetFeeEstimates",
+	)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get fee: [%v]", err)
+	}
+
+	satPerVByteFee, err := selectFeeEstimate(estimates, blocks)
+	if err != nil {
+		return 0, err
+	}
+
+	return convertSatVByteFee(satPerVByteFee), nil
+}
+
+// selectFeeEstimate selects the fee estimate for the greatest available
+// confirmation target not exceeding the given number of blocks. If there is
+// no such target, the estimate for the smallest available one is selected.
+func selectFeeEstimate(
+	estimates map[string]float64,
+	blocks uint32,
+) (float64, error) {
+	selectedTarget := -1
+	lowestTarget := -1
+
+	for key := range estimates {
+		target, err := strconv.Atoi(key)
+		if err != nil {
+			return 0, fmt.Errorf(
+				"cannot parse confirmation target [%s]: [%v]",
+				key,
+				err,
+			)
+		}
+
+		if lowestTarget == -1 || target < lowestTarget {
+			lowestTarget = target
+		}
+
+		if target <= int(blocks) && target > selectedTarget {
+			selectedTarget = target
+		}
+	}
+
+	if lowestTarget == -1 {
+		return 0, fmt.Errorf(
+			"server does not have enough information to make an estimate",
+		)
+	}
+
+	if selectedTarget == -1 {
+		selectedTarget = lowestTarget
+	}
+
+	return estimates[strconv.Itoa(selectedTarget)], nil
+}
+
+func convertSatVByteFee(satPerVByteFee float64) int64 {
+	// Make sure the minimum returned sat/vbyte fee is always 1.
+	satPerVByte := math.Max(satPerVByteFee, 1)
+	// Round the returned fee to be an integer.
+	return int64(math.Round(satPerVByte))
+}
+
+func (c *Connection) verifyServer() error {
+	blockHeight, err := c.GetLatestBlockHeight()
+	if err != nil {
+		return fmt.Errorf("failed to get latest block height: [%w]", err)
+	}
+
+	logger.Infof(
+		"connected to esplora server [url: [%s], blocks: [%d]]",
+		c.config.URL,
+		blockHeight,
+	)
+
+	return nil
+}
+
+// computeScriptHash computes the script hash used by the Esplora API to
+// identify the given script, i.e. the SHA-256 hash of the script. Contrary
+// to the Electrum protocol, the hash is not reversed.
+func computeScriptHash(script []byte) string {
+	scriptHash := sha256.Sum256(script)
+	return hex.EncodeToString(scriptHash[:])
+}
+
+func requestWithRetry[K interface{}](
+	c *Connection,
+	requestFn func(ctx context.Context, client *apiClient) (K, error),
+	requestName string,
+) (K, error) {
+	startTime := time.Now()
+	logger.Infof("starting [%s] request to Esplora server", requestName)
+
+	var result K
+	var finalErr *httpError
+
+	err := wrappers.DoWithDefaultRetry(
+		c.parentCtx,
+		c.config.RequestRetryTimeout,
+		func(ctx context.Context) error {
+			requestCtx, requestCancel := context.WithTimeout(ctx, c.config.RequestTimeout)
+			defer requestCancel()
+
+			r, err := requestFn(requestCtx, c.client)
+			if err != nil {
+				// The server processed and rejected the request so,
+				// retrying it would most likely produce the same outcome.
+				if errors.As(err, &finalErr) && !finalErr.isRetriable() {
+					return nil
+				}
+
+				finalErr = nil
+				return fmt.Errorf("request failed: [%w]", err)
+			}
+
+			result = r
+			return nil
+		})
+	if err == nil && finalErr != nil {
+		err = finalErr
+	}
+
+	solveRequestOutcome := func(err error) string {
+		if err != nil {
+			return fmt.Sprintf("error: [%v]", err)
+		}
+		return "success"
+	}
+
+	logger.Infof("[%s] request to Esplora server completed with [%s] after [%s]",
+		requestName,
+		solveRequestOutcome(err),
+		time.Since(startTime),
+	)
+
+	return result, err
+}
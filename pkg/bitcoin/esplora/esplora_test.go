@@ -0,0 +1,846 @@
+package esplora
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-test/deep"
+
+	"github.com/keep-network/keep-core/internal/testutils"
+	"github.com/keep-network/keep-core/pkg/bitcoin"
+
+	testData "github.com/keep-network/keep-core/internal/testdata/bitcoin"
+)
+
+const stubTipHeight = 2137782
+
+// stubResponse is a response returned by the stub server for the given route.
+type stubResponse struct {
+	status int
+	body   string
+	// failures is the number of times the route responds with
+	// HTTP 503 before returning the actual response.
+	failures int
+	// expectedBody is the request body expected by the route, if set.
+	expectedBody string
+}
+
+// stubServer is an Esplora API stub serving responses registered for routes
+// in format `METHOD path`.
+type stubServer struct {
+	t      *testing.T
+	server *httptest.Server
+
+	mutex  sync.Mutex
+	routes map[string]*stubResponse
+	calls  map[string]int
+}
+
+func newStubServer(t *testing.T) *stubServer {
+	ss := &stubServer{
+		t:      t,
+		routes: make(map[string]*stubResponse),
+		calls:  make(map[string]int),
+	}
+
+	ss.server = httptest.NewServer(http.HandlerFunc(ss.handle))
+	t.Cleanup(ss.server.Close)
+
+	ss.setText("/blocks/tip/height", fmt.Sprintf("%d", stubTipHeight))
+
+	return ss
+}
+
+func (ss *stubServer) handle(w http.ResponseWriter, r *http.Request) {
+	ss.mutex.Lock()
+	defer ss.mutex.Unlock()
+
+	route := r.Method + " " + strings.TrimPrefix(r.URL.Path, "/api")
+	ss.calls[route]++
+
+	response, ok := ss.routes[route]
+	if !ok {
+		ss.t.Errorf("unexpected request [%s]", route)
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	if response.expectedBody != "" {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			ss.t.Errorf("cannot read request body: [%v]", err)
+		}
+
+		if string(body) != response.expectedBody {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte("unexpected request body"))
+			return
+		}
+	}
+
+	if response.failures > 0 {
+		response.failures--
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+
+	w.WriteHeader(response.status)
+	_, _ = w.Write([]byte(response.body))
+}
+
+func (ss *stubServer) set(route string, response *stubResponse) {
+	ss.mutex.Lock()
+	defer ss.mutex.Unlock()
+
+	ss.routes[route] = response
+}
+
+func (ss *stubServer) setText(path string, body string) {
+	ss.set("GET "+path, &stubResponse{status: http.StatusOK, body: body})
+}
+
+func (ss *stubServer) setJSON(path string, value interface{}) {
+	body, err := json.Marshal(value)
+	if err != nil {
+		ss.t.Fatal(err)
+	}
+
+	ss.setText(path, string(body))
+}
+
+func (ss *stubServer) callsCount(route string) int {
+	ss.mutex.Lock()
+	defer ss.mutex.Unlock()
+
+	return ss.calls[route]
+}
+
+func connectStub(t *testing.T, ss *stubServer) *Connection {
+	chain, err := Connect(context.Background(), Config{
+		// Make sure the API path prefix is preserved.
+		URL:                 ss.server.URL + "/api/",
+		RequestTimeout:      time.Second,
+		RequestRetryTimeout: 5 * time.Second,
+		MaxConnections:      2,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return chain.(*Connection)
+}
+
+func TestConnect_RequestRejected(t *testing.T) {
+	ss := newStubServer(t)
+	ss.set(
+		"GET /blocks/tip/height",
+		&stubResponse{status: http.StatusBadRequest, body: "bad request"},
+	)
+
+	_, err := Connect(context.Background(), Config{
+		URL:                 ss.server.URL,
+		RequestTimeout:      time.Second,
+		RequestRetryTimeout: time.Second,
+	})
+	if err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestConnection_GetTransaction(t *testing.T) {
+	ss := newStubServer(t)
+	for _, test := range testData.Transactions[bitcoin.Testnet] {
+		ss.setText(
+			fmt.Sprintf("/tx/%s/hex", txID(test.TxHash)),
+			hex.EncodeToString(test.BitcoinTx.Serialize()),
+		)
+	}
+
+	connection := connectStub(t, ss)
+
+	for testName, test := range testData.Transactions[bitcoin.Testnet] {
+		t.Run(testName, func(t *testing.T) {
+			transaction, err := connection.GetTransaction(test.TxHash)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := deep.Equal(transaction, &test.BitcoinTx); diff != nil {
+				t.Errorf("compare failed: %v", diff)
+			}
+		})
+	}
+}
+
+func TestConnection_GetTransaction_NotFound(t *testing.T) {
+	ss := newStubServer(t)
+	txHash := hashFromString(
+		t,
+		"0000000000000000000000000000000000000000000000000000000000000001",
+	)
+	route := fmt.Sprintf("GET /tx/%s/hex", txID(txHash))
+	ss.set(
+		route,
+		&stubResponse{status: http.StatusNotFound, body: "Transaction not found"},
+	)
+
+	connection := connectStub(t, ss)
+
+	_, err := connection.GetTransaction(txHash)
+	if err == nil {
+		t.Fatal("expected error")
+	}
+
+	// Requests rejected by the server must not be retried.
+	testutils.AssertIntsEqual(t, "calls count", 1, ss.callsCount(route))
+}
+
+func TestConnection_GetTransaction_Retry(t *testing.T) {
+	ss := newStubServer(t)
+	test := testData.Transactions[bitcoin.Testnet]["input: P2SH, output: P2WPKH"]
+	route := fmt.Sprintf("GET /tx/%s/hex", txID(test.TxHash))
+	ss.set(route, &stubResponse{
+		status:   http.StatusOK,
+		body:     hex.EncodeToString(test.BitcoinTx.Serialize()),
+		failures: 1,
+	})
+
+	connection := connectStub(t, ss)
+
+	transaction, err := connection.GetTransaction(test.TxHash)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if diff := deep.Equal(transaction, &test.BitcoinTx); diff != nil {
+		t.Errorf("compare failed: %v", diff)
+	}
+
+	testutils.AssertIntsEqual(t, "calls count", 2, ss.callsCount(route))
+}
+
+func TestConnection_GetTransactionConfirmations(t *testing.T) {
+	ss := newStubServer(t)
+
+	confirmedTxHash := hashFromString(
+		t,
+		"9efc9d555233e12e06378a35a7b988d54f7043b5c3156adc79c7af0a0fd6f1a0",
+	)
+	ss.setJSON(
+		fmt.Sprintf("/tx/%s/status", txID(confirmedTxHash)),
+		map[string]interface{}{"confirmed": true, "block_height": 2137780},
+	)
+
+	unconfirmedTxHash := hashFromString(
+		t,
+		"3a0d1ab3ca2f99dca58b0e995850f795f39bfe868888123da3c9a88c66144bec",
+	)
+	ss.setJSON(
+		fmt.Sprintf("/tx/%s/status", txID(unconfirmedTxHash)),
+		map[string]interface{}{"confirmed": false},
+	)
+
+	connection := connectStub(t, ss)
+
+	confirmations, err := connection.GetTransactionConfirmations(confirmedTxHash)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testutils.AssertUintsEqual(t, "confirmations", 3, uint64(confirmations))
+
+	confirmations, err = connection.GetTransactionConfirmations(unconfirmedTxHash)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testutils.AssertUintsEqual(t, "confirmations", 0, uint64(confirmations))
+}
+
+func TestConnection_BroadcastTransaction(t *testing.T) {
+	ss := newStubServer(t)
+	test := testData.Transactions[bitcoin.Testnet]["input: P2PKH, output: P2SH, P2WPKH"]
+	rawTx := hex.EncodeToString(test.BitcoinTx.Serialize())
+	ss.set("POST /tx", &stubResponse{
+		status:       http.StatusOK,
+		body:         txID(test.TxHash),
+		expectedBody: rawTx,
+	})
+
+	connection := connectStub(t, ss)
+
+	if err := connection.BroadcastTransaction(&test.BitcoinTx); err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertIntsEqual(t, "calls count", 1, ss.callsCount("POST /tx"))
+}
+
+func TestConnection_BroadcastTransaction_Rejected(t *testing.T) {
+	ss := newStubServer(t)
+	test := testData.Transactions[bitcoin.Testnet]["input: P2PKH, output: P2SH, P2WPKH"]
+	ss.set("POST /tx", &stubResponse{
+		status: http.StatusBadRequest,
+		body:   "sendrawtransaction RPC error: {\"code\":-27,\"message\":\"Transaction already in block chain\"}",
+	})
+
+	connection := connectStub(t, ss)
+
+	err := connection.BroadcastTransaction(&test.BitcoinTx)
+	if err == nil {
+		t.Fatal("expected error")
+	}
+
+	if !strings.Contains(err.Error(), "Transaction already in block chain") {
+		t.Errorf("unexpected error: [%v]", err)
+	}
+
+	testutils.AssertIntsEqual(t, "calls count", 1, ss.callsCount("POST /tx"))
+}
+
+func TestConnection_GetLatestBlockHeight(t *testing.T) {
+	connection := connectStub(t, newStubServer(t))
+
+	blockHeight, err := connection.GetLatestBlockHeight()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertUintsEqual(t, "block height", stubTipHeight, uint64(blockHeight))
+}
+
+func TestConnection_GetBlockHeader(t *testing.T) {
+	ss := newStubServer(t)
+	block := testData.Blocks[bitcoin.Testnet]
+	blockHash := fmt.Sprintf("%064x", block.BlockHeight)
+	serializedHeader := block.BlockHeader.Serialize()
+	ss.setText(fmt.Sprintf("/block-height/%d", block.BlockHeight), blockHash)
+	ss.setText(
+		fmt.Sprintf("/block/%s/header", blockHash),
+		hex.EncodeToString(serializedHeader[:]),
+	)
+
+	connection := connectStub(t, ss)
+
+	blockHeader, err := connection.GetBlockHeader(block.BlockHeight)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if diff := deep.Equal(blockHeader, block.BlockHeader); diff != nil {
+		t.Errorf("compare failed: %v", diff)
+	}
+}
+
+func TestConnection_GetTransactionMerkleProof(t *testing.T) {
+	ss := newStubServer(t)
+	proof := testData.TxMerkleProofs[bitcoin.Testnet]
+	ss.setJSON(
+		fmt.Sprintf("/tx/%s/merkle-proof", txID(proof.TxHash)),
+		map[string]interface{}{
+			"block_height": proof.MerkleProof.BlockHeight,
+			"merkle":       proof.MerkleProof.MerkleNodes,
+			"pos":          proof.MerkleProof.Position,
+		},
+	)
+
+	connection := connectStub(t, ss)
+
+	result, err := connection.GetTransactionMerkleProof(
+		proof.TxHash,
+		proof.BlockHeight,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if diff := deep.Equal(result, proof.MerkleProof); diff != nil {
+		t.Errorf("compare failed: %v", diff)
+	}
+
+	_, err = connection.GetTransactionMerkleProof(
+		proof.TxHash,
+		proof.BlockHeight+1,
+	)
+	if err == nil {
+		t.Fatal("expected error for proof referring to another block")
+	}
+}
+
+// historyStub registers the confirmed history of the test public key hash.
+// The P2WPKH history contains all testnet transaction vectors and is split
+// into pages of two transactions. The P2PKH history contains one of the
+// transactions to make sure duplicates are taken only once.
+func historyStub(t *testing.T, ss *stubServer) (
+	publicKeyHash [20]byte,
+	expectedTransactions []bitcoin.Transaction,
+	p2wpkhPageRoutes []string,
+) {
+	publicKeyHash = publicKeyHashFromString(
+		t,
+		"8db50eb52063ea9d98b3eac91489a90f738986f6",
+	)
+
+	type historyItem struct {
+		txHash      bitcoin.Hash
+		blockHeight uint
+		transaction bitcoin.Transaction
+	}
+
+	var items []*historyItem
+	for _, test := range testData.Transactions[bitcoin.Testnet] {
+		items = append(items, &historyItem{
+			txHash:      test.TxHash,
+			blockHeight: test.BlockHeight,
+			transaction: test.BitcoinTx,
+		})
+	}
+
+	// Esplora returns the latest transactions first.
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].blockHeight > items[j].blockHeight
+	})
+
+	p2wpkhPath := fmt.Sprintf(
+		"/scripthash/%s/txs/chain",
+		scriptHash(t, publicKeyHash, bitcoin.PayToWitnessPublicKeyHash),
+	)
+
+	// The history ends with an empty page.
+	path := p2wpkhPath
+	for start := 0; ; start += 2 {
+		if start > len(items) {
+			start = len(items)
+		}
+		end := start + 2
+		if end > len(items) {
+			end = len(items)
+		}
+
+		page := make([]interface{}, 0)
+		for _, item := range items[start:end] {
+			page = append(
+				page,
+				esploraTransaction(&item.transaction, item.blockHeight),
+			)
+		}
+
+		ss.setJSON(path, page)
+		p2wpkhPageRoutes = append(p2wpkhPageRoutes, "GET "+path)
+
+		if len(page) == 0 {
+			break
+		}
+
+		path = fmt.Sprintf("%s/%s", p2wpkhPath, txID(items[end-1].txHash))
+	}
+
+	p2pkhPath := fmt.Sprintf(
+		"/scripthash/%s/txs/chain",
+		scriptHash(t, publicKeyHash, bitcoin.PayToPublicKeyHash),
+	)
+	duplicate := items[len(items)-1]
+	ss.setJSON(
+		p2pkhPath,
+		[]interface{}{
+			esploraTransaction(&duplicate.transaction, duplicate.blockHeight),
+		},
+	)
+	ss.setJSON(
+		fmt.Sprintf("%s/%s", p2pkhPath, txID(duplicate.txHash)),
+		[]interface{}{},
+	)
+
+	for i := len(items) - 1; i >= 0; i-- {
+		expectedTransactions = append(expectedTransactions, items[i].transaction)
+	}
+
+	return publicKeyHash, expectedTransactions, p2wpkhPageRoutes
+}
+
+func TestConnection_GetTxHashesForPublicKeyHash(t *testing.T) {
+	ss := newStubServer(t)
+	publicKeyHash, expectedTransactions, pageRoutes := historyStub(t, ss)
+
+	connection := connectStub(t, ss)
+
+	txHashes, err := connection.GetTxHashesForPublicKeyHash(publicKeyHash)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedTxHashes := make([]bitcoin.Hash, len(expectedTransactions))
+	for i, transaction := range expectedTransactions {
+		expectedTxHashes[i] = transaction.Hash()
+	}
+
+	if diff := deep.Equal(txHashes, expectedTxHashes); diff != nil {
+		t.Errorf("compare failed: %v", diff)
+	}
+
+	for _, route := range pageRoutes {
+		testutils.AssertIntsEqual(
+			t,
+			fmt.Sprintf("calls count of [%s]", route),
+			1,
+			ss.callsCount(route),
+		)
+	}
+}
+
+func TestConnection_GetTransactionsForPublicKeyHash(t *testing.T) {
+	ss := newStubServer(t)
+	publicKeyHash, expectedTransactions, pageRoutes := historyStub(t, ss)
+
+	connection := connectStub(t, ss)
+
+	transactions, err := connection.GetTransactionsForPublicKeyHash(
+		publicKeyHash,
+		2,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertIntsEqual(t, "transactions count", 2, len(transactions))
+
+	latestTransactions := expectedTransactions[len(expectedTransactions)-2:]
+	for i, transaction := range transactions {
+		testutils.AssertBytesEqual(
+			t,
+			latestTransactions[i].Serialize(),
+			transaction.Serialize(),
+		)
+	}
+
+	// Only the first page of the history should be fetched.
+	testutils.AssertIntsEqual(
+		t,
+		"first page calls count",
+		1,
+		ss.callsCount(pageRoutes[0]),
+	)
+	for _, route := range pageRoutes[1:] {
+		testutils.AssertIntsEqual(
+			t,
+			fmt.Sprintf("calls count of [%s]", route),
+			0,
+			ss.callsCount(route),
+		)
+	}
+}
+
+func TestConnection_GetMempoolForPublicKeyHash(t *testing.T) {
+	ss := newStubServer(t)
+	publicKeyHash := publicKeyHashFromString(
+		t,
+		"8db50eb52063ea9d98b3eac91489a90f738986f6",
+	)
+	test := testData.Transactions[bitcoin.Testnet]["input: P2WSH, output: P2WPKH"]
+
+	ss.setJSON(
+		fmt.Sprintf(
+			"/scripthash/%s/txs/mempool",
+			scriptHash(t, publicKeyHash, bitcoin.PayToPublicKeyHash),
+		),
+		[]interface{}{},
+	)
+	ss.setJSON(
+		fmt.Sprintf(
+			"/scripthash/%s/txs/mempool",
+			scriptHash(t, publicKeyHash, bitcoin.PayToWitnessPublicKeyHash),
+		),
+		[]interface{}{esploraTransaction(&test.BitcoinTx, 0)},
+	)
+
+	connection := connectStub(t, ss)
+
+	transactions, err := connection.GetMempoolForPublicKeyHash(publicKeyHash)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertIntsEqual(t, "transactions count", 1, len(transactions))
+	testutils.AssertBytesEqual(
+		t,
+		test.BitcoinTx.Serialize(),
+		transactions[0].Serialize(),
+	)
+}
+
+func utxosStub(t *testing.T, ss *stubServer) [20]byte {
+	publicKeyHash := publicKeyHashFromString(
+		t,
+		"e6f9d74726b19b75f16fe1e9feaec048aa4fa1d0",
+	)
+
+	utxo := func(txID string, vout uint32, value int64, height uint) interface{} {
+		status := map[string]interface{}{"confirmed": height > 0}
+		if height > 0 {
+			status["block_height"] = height
+		}
+
+		return map[string]interface{}{
+			"txid":   txID,
+			"vout":   vout,
+			"value":  value,
+			"status": status,
+		}
+	}
+
+	ss.setJSON(
+		fmt.Sprintf(
+			"/scripthash/%s/utxo",
+			scriptHash(t, publicKeyHash, bitcoin.PayToPublicKeyHash),
+		),
+		[]interface{}{
+			utxo("4f9affc5b418385d5aa61e23caa0b55156bf0682d5fedf2d905446f3f88aec6c", 0, 100000, 2130000),
+		},
+	)
+	ss.setJSON(
+		fmt.Sprintf(
+			"/scripthash/%s/utxo",
+			scriptHash(t, publicKeyHash, bitcoin.PayToWitnessPublicKeyHash),
+		),
+		[]interface{}{
+			utxo("f65bc5029251f0042aedb37f90dbb2bfb63a2e81694beef9cae5ec62e954c22e", 1, 299200, 2135100),
+			utxo("3a0d1ab3ca2f99dca58b0e995850f795f39bfe868888123da3c9a88c66144bec", 0, 77000, 0),
+			utxo("ea374ab6842723c647c3fc0ab281ca0641eaa768576cf9df695ca5b827140214", 0, 10000, 2120000),
+		},
+	)
+
+	return publicKeyHash
+}
+
+func TestConnection_GetUtxosForPublicKeyHash(t *testing.T) {
+	ss := newStubServer(t)
+	publicKeyHash := utxosStub(t, ss)
+
+	connection := connectStub(t, ss)
+
+	utxos, err := connection.GetUtxosForPublicKeyHash(publicKeyHash)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedUtxos := []string{
+		"ea374ab6842723c647c3fc0ab281ca0641eaa768576cf9df695ca5b827140214:0:10000",
+		"4f9affc5b418385d5aa61e23caa0b55156bf0682d5fedf2d905446f3f88aec6c:0:100000",
+		"f65bc5029251f0042aedb37f90dbb2bfb63a2e81694beef9cae5ec62e954c22e:1:299200",
+	}
+
+	if diff := deep.Equal(formatUtxos(utxos), expectedUtxos); diff != nil {
+		t.Errorf("compare failed: %v", diff)
+	}
+}
+
+func TestConnection_GetMempoolUtxosForPublicKeyHash(t *testing.T) {
+	ss := newStubServer(t)
+	publicKeyHash := utxosStub(t, ss)
+
+	connection := connectStub(t, ss)
+
+	utxos, err := connection.GetMempoolUtxosForPublicKeyHash(publicKeyHash)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedUtxos := []string{
+		"3a0d1ab3ca2f99dca58b0e995850f795f39bfe868888123da3c9a88c66144bec:0:77000",
+	}
+
+	if diff := deep.Equal(formatUtxos(utxos), expectedUtxos); diff != nil {
+		t.Errorf("compare failed: %v", diff)
+	}
+}
+
+func TestConnection_EstimateSatPerVByteFee(t *testing.T) {
+	ss := newStubServer(t)
+	ss.setJSON("/fee-estimates", map[string]float64{
+		"1":    87.882,
+		"2":    87.882,
+		"3":    40.1,
+		"6":    12.4,
+		"144":  1.027,
+		"1008": 0.5,
+	})
+
+	connection := connectStub(t, ss)
+
+	var tests = map[string]struct {
+		blocks                 uint32
+		expectedSatPerVByteFee int64
+	}{
+		"exact confirmation target": {
+			blocks:                 6,
+			expectedSatPerVByteFee: 12,
+		},
+		"confirmation target between available ones": {
+			blocks:                 4,
+			expectedSatPerVByteFee: 40,
+		},
+		"confirmation target greater than available ones": {
+			blocks:                 2000,
+			expectedSatPerVByteFee: 1,
+		},
+		"confirmation target lower than available ones": {
+			blocks:                 0,
+			expectedSatPerVByteFee: 88,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			satPerVByteFee, err := connection.EstimateSatPerVByteFee(test.blocks)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			testutils.AssertIntsEqual(
+				t,
+				"sat/vbyte fee",
+				int(test.expectedSatPerVByteFee),
+				int(satPerVByteFee),
+			)
+		})
+	}
+}
+
+func TestConnection_EstimateSatPerVByteFee_NoEstimates(t *testing.T) {
+	ss := newStubServer(t)
+	ss.setJSON("/fee-estimates", map[string]float64{})
+
+	connection := connectStub(t, ss)
+
+	_, err := connection.EstimateSatPerVByteFee(6)
+	if err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestConvertTransaction_HashMismatch(t *testing.T) {
+	test := testData.Transactions[bitcoin.Testnet]["input: P2SH, output: P2WPKH"]
+
+	encoded, err := json.Marshal(esploraTransaction(&test.BitcoinTx, 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	decoded := new(transaction)
+	if err := json.Unmarshal(encoded, decoded); err != nil {
+		t.Fatal(err)
+	}
+
+	decoded.Vout[0].Value++
+
+	_, err = convertTransaction(decoded)
+	if err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+// esploraTransaction builds the Esplora API representation of the given
+// transaction. The transaction is unconfirmed if the block height is zero.
+func esploraTransaction(
+	transaction *bitcoin.Transaction,
+	blockHeight uint,
+) interface{} {
+	vin := make([]interface{}, len(transaction.Inputs))
+	for i, input := range transaction.Inputs {
+		witness := make([]string, len(input.Witness))
+		for j, item := range input.Witness {
+			witness[j] = hex.EncodeToString(item)
+		}
+
+		vin[i] = map[string]interface{}{
+			"txid":      txID(input.Outpoint.TransactionHash),
+			"vout":      input.Outpoint.OutputIndex,
+			"scriptsig": hex.EncodeToString(input.SignatureScript),
+			"witness":   witness,
+			"sequence":  input.Sequence,
+		}
+	}
+
+	vout := make([]interface{}, len(transaction.Outputs))
+	for i, output := range transaction.Outputs {
+		vout[i] = map[string]interface{}{
+			"scriptpubkey": hex.EncodeToString(output.PublicKeyScript),
+			"value":        output.Value,
+		}
+	}
+
+	status := map[string]interface{}{"confirmed": blockHeight > 0}
+	if blockHeight > 0 {
+		status["block_height"] = blockHeight
+	}
+
+	return map[string]interface{}{
+		"txid":     txID(transaction.Hash()),
+		"version":  transaction.Version,
+		"locktime": transaction.Locktime,
+		"vin":      vin,
+		"vout":     vout,
+		"status":   status,
+	}
+}
+
+func scriptHash(
+	t *testing.T,
+	publicKeyHash [20]byte,
+	scriptFn func([20]byte) (bitcoin.Script, error),
+) string {
+	script, err := scriptFn(publicKeyHash)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return computeScriptHash(script)
+}
+
+func txID(hash bitcoin.Hash) string {
+	return hash.Hex(bitcoin.ReversedByteOrder)
+}
+
+func hashFromString(t *testing.T, s string) bitcoin.Hash {
+	hash, err := bitcoin.NewHashFromString(s, bitcoin.ReversedByteOrder)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return hash
+}
+
+func publicKeyHashFromString(t *testing.T, s string) [20]byte {
+	bytes, err := hex.DecodeString(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var publicKeyHash [20]byte
+	copy(publicKeyHash[:], bytes)
+
+	return publicKeyHash
+}
+
+// formatUtxos formats the given UTXOs as txHash:outputIndex:value strings.
+func formatUtxos(utxos []*bitcoin.UnspentTransactionOutput) []string {
+	result := make([]string, len(utxos))
+	for i, utxo := range utxos {
+		result[i] = fmt.Sprintf(
+			"%s:%d:%d",
+			txID(utxo.Outpoint.TransactionHash),
+			utxo.Outpoint.OutputIndex,
+			utxo.Value,
+		)
+	}
+
+	return result
+}
@@ -0,0 +1,165 @@
+package esplora
+
+import (
+	"encoding/hex"
+	"fmt"
+
+	"github.com/keep-network/keep-core/pkg/bitcoin"
+)
+
+// transactionStatus is the confirmation status of a transaction returned by
+// the Esplora API.
+type transactionStatus struct {
+	Confirmed   bool `json:"confirmed"`
+	BlockHeight uint `json:"block_height"`
+}
+
+// transaction is the transaction representation returned by the Esplora API.
+// Only fields used by this package are decoded.
+type transaction struct {
+	TxID     string `json:"txid"`
+	Version  int32  `json:"version"`
+	Locktime uint32 `json:"locktime"`
+	Vin      []struct {
+		TxID      string   `json:"txid"`
+		Vout      uint32   `json:"vout"`
+		ScriptSig string   `json:"scriptsig"`
+		Witness   []string `json:"witness"`
+		Sequence  uint32   `json:"sequence"`
+	} `json:"vin"`
+	Vout []struct {
+		ScriptPubKey string `json:"scriptpubkey"`
+		Value        int64  `json:"value"`
+	} `json:"vout"`
+	Status transactionStatus `json:"status"`
+}
+
+// utxo is the unspent output representation returned by the Esplora API.
+type utxo struct {
+	TxID   string            `json:"txid"`
+	Vout   uint32            `json:"vout"`
+	Value  int64             `json:"value"`
+	Status transactionStatus `json:"status"`
+}
+
+// merkleProof is the merkle proof representation returned by the Esplora API.
+type merkleProof struct {
+	BlockHeight uint     `json:"block_height"`
+	Merkle      []string `json:"merkle"`
+	Position    uint     `json:"pos"`
+}
+
+// decodeTransaction deserializes a transaction from the hexadecimal serialized
+// string to the format expected by the bitcoin.Chain interface.
+func decodeTransaction(rawTx string) (*bitcoin.Transaction, error) {
+	transactionBytes, err := hex.DecodeString(rawTx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode a hex string: [%w]", err)
+	}
+
+	result := new(bitcoin.Transaction)
+	if err := result.Deserialize(transactionBytes); err != nil {
+		return nil, fmt.Errorf("failed to deserialize a transaction: [%w]", err)
+	}
+
+	return result, nil
+}
+
+// convertTransaction transforms a transaction returned by the Esplora API to
+// the format expected by the bitcoin.Chain interface. The hash of the
+// converted transaction is verified against the transaction ID returned
+// by the API so an incomplete conversion cannot go unnoticed.
+func convertTransaction(t *transaction) (*bitcoin.Transaction, error) {
+	result := &bitcoin.Transaction{
+		Version:  t.Version,
+		Locktime: t.Locktime,
+	}
+
+	for i, vin := range t.Vin {
+		// The previous transaction hash of coinbase inputs is zeroed.
+		var previousTxHash bitcoin.Hash
+		if vin.TxID != "" {
+			hash, err := bitcoin.NewHashFromString(
+				vin.TxID,
+				bitcoin.ReversedByteOrder,
+			)
+			if err != nil {
+				return nil, fmt.Errorf(
+					"cannot parse hash of input [%d]: [%v]",
+					i,
+					err,
+				)
+			}
+			previousTxHash = hash
+		}
+
+		signatureScript, err := hex.DecodeString(vin.ScriptSig)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"cannot decode signature script of input [%d]: [%v]",
+				i,
+				err,
+			)
+		}
+
+		var witness [][]byte
+		for _, item := range vin.Witness {
+			witnessItem, err := hex.DecodeString(item)
+			if err != nil {
+				return nil, fmt.Errorf(
+					"cannot decode witness of input [%d]: [%v]",
+					i,
+					err,
+				)
+			}
+			witness = append(witness, witnessItem)
+		}
+
+		result.Inputs = append(result.Inputs, &bitcoin.TransactionInput{
+			Outpoint: &bitcoin.TransactionOutpoint{
+				TransactionHash: previousTxHash,
+				OutputIndex:     vin.Vout,
+			},
+			SignatureScript: signatureScript,
+			Witness:         witness,
+			Sequence:        vin.Sequence,
+		})
+	}
+
+	for i, vout := range t.Vout {
+		publicKeyScript, err := hex.DecodeString(vout.ScriptPubKey)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"cannot decode public key script of output [%d]: [%v]",
+				i,
+				err,
+			)
+		}
+
+		result.Outputs = append(result.Outputs, &bitcoin.TransactionOutput{
+			Value:           vout.Value,
+			PublicKeyScript: publicKeyScript,
+		})
+	}
+
+	if txID := result.Hash().Hex(bitcoin.ReversedByteOrder); txID != t.TxID {
+		return nil, fmt.Errorf(
+			"converted transaction has ID [%s] instead of [%s]",
+			txID,
+			t.TxID,
+		)
+	}
+
+	return result, nil
+}
+
+// convertMerkleProof transforms a merkle proof returned by the Esplora API
+// to the format expected by the bitcoin.Chain interface. The Esplora API
+// returns merkle nodes in the same format as the Electrum protocol.
+func convertMerkleProof(proof *merkleProof) *bitcoin.TransactionMerkleProof {
+	return &bitcoin.TransactionMerkleProof{
+		BlockHeight: proof.BlockHeight,
+		MerkleNodes: proof.Merkle,
+		Position:    proof.Position,
+	}
+}
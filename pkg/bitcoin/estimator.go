@@ -201,6 +201,31 @@ func (tse *TransactionSizeEstimator) AddScriptHashOutputs(
 	return tse
 }
 
+// AddTaprootOutputs adds the provided count of P2TR outputs to the
+// estimation. If the estimator already errored out during previous actions,
+// this method does nothing.
+func (tse *TransactionSizeEstimator) AddTaprootOutputs(
+	count int,
+) *TransactionSizeEstimator {
+	if tse.err != nil {
+		return tse
+	}
+
+	scriptPlaceholder, err := PayToTaproot([32]byte{})
+	if err != nil {
+		tse.err = err
+		return tse
+	}
+
+	for i := 0; i < count; i++ {
+		tse.internal.AddTxOut(
+			wire.NewTxOut(0, scriptPlaceholder),
+		)
+	}
+
+	return tse
+}
+
 // VirtualSize returns the virtual size of the transaction whose shape was
 // provided to the estimator. If any errors occurred while building the
 // transaction shape, the first error will be returned.
@@ -0,0 +1,887 @@
+package bitcoin
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/btcsuite/btcd/blockchain"
+	"github.com/ipfs/go-log"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/keep-network/keep-common/pkg/persistence"
+)
+
+var logger = log.Logger("keep-bitcoin")
+
+const (
+	// trustedHeaderDepth is the depth below the chain tip of the block header
+	// trusted on first use if no checkpoint is configured.
+	trustedHeaderDepth = 100
+	// maxReorgDepth is the maximum depth of a chain reorganization the
+	// HeaderStore follows.
+	maxReorgDepth = 100
+
+	// syncBatchSize is the maximum number of block headers fetched from the
+	// chain backend and verified in one batch during the synchronization.
+	syncBatchSize = 500
+	// syncConcurrency is the maximum number of block headers fetched from
+	// the chain backend concurrently.
+	syncConcurrency = 16
+	// syncInterval is the interval of the background synchronization of
+	// block headers.
+	syncInterval = 1 * time.Minute
+
+	// headersDirectory is the persistence directory the block headers are
+	// stored in.
+	headersDirectory = "headers"
+	// anchorFileName is the name of the file holding the block header the
+	// stored chain is anchored at.
+	anchorFileName = "anchor"
+)
+
+// HeaderStoreConfig holds configurable properties of the HeaderStore.
+type HeaderStoreConfig struct {
+	// CheckpointHeight is the height of the trusted block header the
+	// verification of block headers is anchored at.
+	CheckpointHeight uint
+	// CheckpointHash is the hash of the trusted block header in the reversed
+	// byte order, as displayed by block explorers. It must be set for
+	// mainnet. On test networks, the block header 100 blocks below the chain
+	// tip is trusted on first use if the checkpoint is not set.
+	CheckpointHash string
+}
+
+// headerAnchor identifies the trusted block header the stored chain is
+// anchored at.
+type headerAnchor struct {
+	height uint
+	hash   Hash
+}
+
+// HeaderStore is a light store of Bitcoin block headers verified locally.
+// The store is anchored at a trusted block header. Block headers following
+// the anchor are accepted only if they point to their predecessors, have
+// difficulty required by the difficulty retarget rules, and satisfy the
+// proof of work. Block headers preceding the anchor are accepted only if
+// their hashes match the previous block header hashes of their successors.
+// In case of a chain reorganization, the branch reported by the chain
+// backend is accepted only if it has more accumulated work than the
+// replaced branch. Verified block headers are persisted so they do not
+// have to be fetched again after restart.
+type HeaderStore struct {
+	// syncMutex makes sure only one synchronization runs at a time.
+	syncMutex sync.Mutex
+	mutex     sync.Mutex
+
+	network     Network
+	chain       Chain
+	persistence persistence.BasicHandle
+
+	anchor *headerAnchor
+	// headers holds the verified block headers; the header with index i
+	// is at height firstHeight + i.
+	firstHeight uint
+	headers     []*BlockHeader
+	// synced indicates whether block headers have been synchronized up to
+	// the chain backend's tip at least once.
+	synced bool
+}
+
+// NewHeaderStore creates a new HeaderStore verifying block headers of the
+// given network fetched from the given chain backend. Block headers
+// verified in previous runs are read from the given persistence handle.
+// The persistence handle is optional; if it is nil, block headers are kept
+// only in memory. The checkpoint must be configured for mainnet. The store
+// is not synchronized on creation; use Sync or StartSync to fetch block
+// headers following the anchor.
+func NewHeaderStore(
+	network Network,
+	chain Chain,
+	persistence persistence.BasicHandle,
+	config HeaderStoreConfig,
+) (*HeaderStore, error) {
+	if _, err := consensusParams(network); err != nil {
+		return nil, err
+	}
+
+	var checkpoint *headerAnchor
+	if config.CheckpointHash != "" {
+		hash, err := NewHashFromString(config.CheckpointHash, ReversedByteOrder)
+		if err != nil {
+			return nil, fmt.Errorf("invalid checkpoint hash: [%v]", err)
+		}
+
+		checkpoint = &headerAnchor{
+			height: config.CheckpointHeight,
+			hash:   hash,
+		}
+	}
+
+	// Trusting a block header returned by the chain backend gives no
+	// protection against a malicious backend so, it is acceptable only on
+	// test networks.
+	if checkpoint == nil && network == Mainnet {
+		return nil, fmt.Errorf(
+			"block header checkpoint must be configured for mainnet",
+		)
+	}
+
+	hs := &HeaderStore{
+		network:     network,
+		chain:       chain,
+		persistence: persistence,
+	}
+
+	if err := hs.load(checkpoint); err != nil {
+		logger.Warnf("discarding persisted block headers: [%v]", err)
+		hs.anchor, hs.headers = nil, nil
+		hs.deletePersisted()
+	}
+
+	if hs.anchor == nil {
+		if err := hs.initialize(checkpoint); err != nil {
+			return nil, err
+		}
+	}
+
+	return hs, nil
+}
+
+// initialize anchors the store at the given checkpoint or, if the
+// checkpoint is not set, at the block header trusted on first use.
+func (hs *HeaderStore) initialize(checkpoint *headerAnchor) error {
+	if checkpoint == nil {
+		tipHeight, err := hs.chain.GetLatestBlockHeight()
+		if err != nil {
+			return fmt.Errorf("cannot get latest block height: [%v]", err)
+		}
+
+		height := uint(0)
+		if tipHeight > trustedHeaderDepth {
+			height = tipHeight - trustedHeaderDepth
+		}
+
+		header, err := hs.chain.GetBlockHeader(height)
+		if err != nil {
+			return fmt.Errorf(
+				"cannot get block header at height [%d]: [%v]",
+				height,
+				err,
+			)
+		}
+
+		logger.Warnf(
+			"block header checkpoint not configured; trusting block "+
+				"header [%s] at height [%d] returned by the chain backend",
+			header.Hash().Hex(ReversedByteOrder),
+			height,
+		)
+
+		hs.anchorAt(&headerAnchor{height: height, hash: header.Hash()}, header)
+
+		return nil
+	}
+
+	header, err := hs.chain.GetBlockHeader(checkpoint.height)
+	if err != nil {
+		return fmt.Errorf(
+			"cannot get checkpoint block header at height [%d]: [%v]",
+			checkpoint.height,
+			err,
+		)
+	}
+
+	if header.Hash() != checkpoint.hash {
+		return fmt.Errorf(
+			"chain backend returned block header [%s] at checkpoint "+
+				"height [%d] while [%s] is expected",
+			header.Hash().Hex(ReversedByteOrder),
+			checkpoint.height,
+			checkpoint.hash.Hex(ReversedByteOrder),
+		)
+	}
+
+	hs.anchorAt(checkpoint, header)
+
+	return nil
+}
+
+// anchorAt resets the store so it holds only the given anchor block header.
+func (hs *HeaderStore) anchorAt(anchor *headerAnchor, header *BlockHeader) {
+	hs.anchor = anchor
+	hs.firstHeight = anchor.height
+	hs.headers = []*BlockHeader{header}
+
+	hs.save(anchor.height, anchor.height)
+}
+
+// StartSync synchronizes block headers in the background, right away and
+// then periodically, until the given context is done. Failed
+// synchronizations are logged and retried in the next period.
+func (hs *HeaderStore) StartSync(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(syncInterval)
+		defer ticker.Stop()
+
+		for {
+			if err := hs.Sync(); err != nil {
+				logger.Errorf("cannot synchronize block headers: [%v]", err)
+			}
+
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+// Sync fetches block headers from the chain backend up to the backend's
+// chain tip and verifies them. Block headers are fetched and verified in
+// batches so the verified ones are available before the whole
+// synchronization completes. If the chain backend reports a chain
+// reorganization, the store follows it as long as the reported branch has
+// more accumulated work than the stored one. If another synchronization is
+// in progress, this function waits until it completes.
+func (hs *HeaderStore) Sync() error {
+	hs.syncMutex.Lock()
+	defer hs.syncMutex.Unlock()
+
+	return hs.sync()
+}
+
+// syncIfIdle synchronizes block headers unless another synchronization,
+// e.g. the background one, is in progress. In the latter case, this
+// function returns an error if block headers have not been synchronized up
+// to the chain backend's tip at least once. This way, the verified tip is
+// not used while it may be arbitrarily far behind the chain tip.
+func (hs *HeaderStore) syncIfIdle() error {
+	if hs.syncMutex.TryLock() {
+		defer hs.syncMutex.Unlock()
+
+		return hs.sync()
+	}
+
+	hs.mutex.Lock()
+	defer hs.mutex.Unlock()
+
+	if !hs.synced {
+		return fmt.Errorf(
+			"block headers are being synchronized; verified tip is at "+
+				"height [%d]",
+			hs.tipHeight(),
+		)
+	}
+
+	return nil
+}
+
+func (hs *HeaderStore) sync() error {
+	backendTipHeight, err := hs.chain.GetLatestBlockHeight()
+	if err != nil {
+		return fmt.Errorf("cannot get latest block height: [%v]", err)
+	}
+
+	for {
+		hs.mutex.Lock()
+		tipHeight := hs.tipHeight()
+		tipHash := hs.headers[len(hs.headers)-1].Hash()
+		hs.mutex.Unlock()
+
+		if backendTipHeight <= tipHeight {
+			hs.mutex.Lock()
+			hs.synced = true
+			hs.mutex.Unlock()
+
+			return nil
+		}
+
+		toHeight := tipHeight + syncBatchSize
+		if toHeight > backendTipHeight {
+			toHeight = backendTipHeight
+		}
+
+		batch, err := hs.fetchHeaders(tipHeight+1, toHeight)
+		if err != nil {
+			return err
+		}
+
+		// Only the synchronization modifies the verified tip so, the tip
+		// could not change while the batch was being fetched.
+		hs.mutex.Lock()
+		if batch[0].PreviousBlockHeaderHash != tipHash {
+			err = hs.reorganize(backendTipHeight)
+		} else {
+			err = hs.extend(batch)
+		}
+		hs.mutex.Unlock()
+
+		if err != nil {
+			return err
+		}
+
+		if toHeight < backendTipHeight {
+			logger.Infof(
+				"synchronized block headers up to height [%d]; chain tip "+
+					"is at height [%d]",
+				toHeight,
+				backendTipHeight,
+			)
+		}
+	}
+}
+
+// fetchHeaders fetches block headers in the given height range from the
+// chain backend. Block headers are fetched concurrently.
+func (hs *HeaderStore) fetchHeaders(
+	fromHeight uint,
+	toHeight uint,
+) ([]*BlockHeader, error) {
+	headers := make([]*BlockHeader, toHeight-fromHeight+1)
+
+	errGroup := &errgroup.Group{}
+	errGroup.SetLimit(syncConcurrency)
+
+	for height := fromHeight; height <= toHeight; height++ {
+		height := height
+
+		errGroup.Go(func() error {
+			header, err := hs.chain.GetBlockHeader(height)
+			if err != nil {
+				return fmt.Errorf(
+					"cannot get block header at height [%d]: [%v]",
+					height,
+					err,
+				)
+			}
+
+			headers[height-fromHeight] = header
+
+			return nil
+		})
+	}
+
+	if err := errGroup.Wait(); err != nil {
+		return nil, err
+	}
+
+	return headers, nil
+}
+
+// extend verifies the given block headers following the verified tip and
+// appends them to the store. Block headers preceding the first invalid one
+// are appended as well.
+func (hs *HeaderStore) extend(headers []*BlockHeader) error {
+	fromHeight := hs.tipHeight() + 1
+
+	defer hs.save(fromHeight, fromHeight+uint(len(headers))-1)
+
+	for i, header := range headers {
+		if err := verifyHeader(
+			hs.network,
+			fromHeight+uint(i),
+			header,
+			hs.headers[len(hs.headers)-1],
+			hs.headerAt,
+		); err != nil {
+			return err
+		}
+
+		hs.headers = append(hs.headers, header)
+	}
+
+	return nil
+}
+
+// reorganize replaces the stored branch diverging from the chain backend's
+// branch with the latter, provided the backend's branch has more
+// accumulated work. At most one batch of block headers following the fork
+// is fetched; the remaining ones are fetched by the further synchronization.
+func (hs *HeaderStore) reorganize(backendTipHeight uint) error {
+	tipHeight := hs.tipHeight()
+
+	forkHeight, found := uint(0), false
+	for height := tipHeight; height+maxReorgDepth > tipHeight &&
+		height >= hs.anchor.height; height-- {
+		header, err := hs.chain.GetBlockHeader(height)
+		if err != nil {
+			return fmt.Errorf(
+				"cannot get block header at height [%d]: [%v]",
+				height,
+				err,
+			)
+		}
+
+		if header.Hash() == hs.headers[height-hs.firstHeight].Hash() {
+			forkHeight, found = height, true
+			break
+		}
+
+		if height == 0 {
+			break
+		}
+	}
+
+	if !found {
+		return fmt.Errorf(
+			"chain backend reports a chain diverging more than [%d] blocks "+
+				"below the tip or below the anchor at height [%d]",
+			maxReorgDepth,
+			hs.anchor.height,
+		)
+	}
+
+	branchTipHeight := forkHeight + syncBatchSize
+	if branchTipHeight > backendTipHeight {
+		branchTipHeight = backendTipHeight
+	}
+
+	fetched, err := hs.fetchHeaders(forkHeight+1, branchTipHeight)
+	if err != nil {
+		return err
+	}
+
+	branch := make([]*BlockHeader, 0, len(fetched))
+	branchHeaderAt := func(height uint) (*BlockHeader, error) {
+		if height > forkHeight {
+			return branch[height-forkHeight-1], nil
+		}
+		return hs.headerAt(height)
+	}
+
+	for i, header := range fetched {
+		height := forkHeight + uint(i) + 1
+
+		parent, err := branchHeaderAt(height - 1)
+		if err != nil {
+			return err
+		}
+
+		if err := verifyHeader(
+			hs.network,
+			height,
+			header,
+			parent,
+			branchHeaderAt,
+		); err != nil {
+			return fmt.Errorf("invalid reorganized branch: [%w]", err)
+		}
+
+		branch = append(branch, header)
+	}
+
+	replaced := hs.headers[forkHeight-hs.firstHeight+1:]
+	if work(branch).Cmp(work(replaced)) <= 0 {
+		return fmt.Errorf(
+			"chain backend reports a branch diverging at height [%d] "+
+				"that does not have more work than the verified branch",
+			forkHeight,
+		)
+	}
+
+	logger.Warnf(
+		"following chain reorganization at height [%d]; replacing [%d] "+
+			"block headers with [%d] block headers",
+		forkHeight,
+		len(replaced),
+		len(branch),
+	)
+
+	hs.headers = append(hs.headers[:forkHeight-hs.firstHeight+1], branch...)
+
+	// Save up to the previous tip as well, so files holding block headers
+	// of the replaced branch above the new tip are removed.
+	saveToHeight := hs.tipHeight()
+	if tipHeight > saveToHeight {
+		saveToHeight = tipHeight
+	}
+	hs.save(forkHeight+1, saveToHeight)
+
+	return nil
+}
+
+// work returns the total work accumulated by the given block headers.
+func work(headers []*BlockHeader) *big.Int {
+	total := new(big.Int)
+	for _, header := range headers {
+		total.Add(total, blockchain.CalcWork(header.Bits))
+	}
+	return total
+}
+
+// TipHeight returns the height of the latest verified block header.
+func (hs *HeaderStore) TipHeight() uint {
+	hs.mutex.Lock()
+	defer hs.mutex.Unlock()
+
+	return hs.tipHeight()
+}
+
+func (hs *HeaderStore) tipHeight() uint {
+	return hs.firstHeight + uint(len(hs.headers)) - 1
+}
+
+// HeaderAt returns the verified block header at the given height. Block
+// headers preceding the stored ones are fetched from the chain backend
+// and verified against their successors. This function returns an error
+// if the given height is above the latest verified block header.
+func (hs *HeaderStore) HeaderAt(height uint) (*BlockHeader, error) {
+	hs.mutex.Lock()
+	defer hs.mutex.Unlock()
+
+	return hs.headerAt(height)
+}
+
+func (hs *HeaderStore) headerAt(height uint) (*BlockHeader, error) {
+	if height > hs.tipHeight() {
+		return nil, fmt.Errorf(
+			"block header at height [%d] is above the verified tip [%d]",
+			height,
+			hs.tipHeight(),
+		)
+	}
+
+	if height < hs.firstHeight {
+		if err := hs.extendBackward(height); err != nil {
+			return nil, err
+		}
+	}
+
+	return hs.headers[height-hs.firstHeight], nil
+}
+
+// extendBackward fetches block headers preceding the stored ones down to
+// the given height. Each fetched block header must hash to the previous
+// block header hash of its successor.
+func (hs *HeaderStore) extendBackward(height uint) error {
+	previousFirstHeight := hs.firstHeight
+
+	extension := make([]*BlockHeader, previousFirstHeight-height)
+	successor := hs.headers[0]
+
+	for h := previousFirstHeight - 1; ; h-- {
+		header, err := hs.chain.GetBlockHeader(h)
+		if err != nil {
+			return fmt.Errorf(
+				"cannot get block header at height [%d]: [%v]",
+				h,
+				err,
+			)
+		}
+
+		if header.Hash() != successor.PreviousBlockHeaderHash {
+			return fmt.Errorf(
+				"chain backend returned block header [%s] at height [%d] "+
+					"while [%s] is expected",
+				header.Hash().Hex(ReversedByteOrder),
+				h,
+				successor.PreviousBlockHeaderHash.Hex(ReversedByteOrder),
+			)
+		}
+
+		extension[h-height] = header
+		successor = header
+
+		if h == height {
+			break
+		}
+	}
+
+	hs.headers = append(extension, hs.headers...)
+	hs.firstHeight = height
+
+	hs.save(height, previousFirstHeight-1)
+
+	return nil
+}
+
+// save persists block headers in the given height range. Block headers are
+// persisted in files holding block headers of a single difficulty period.
+// Persisted files holding block headers above the tip are removed.
+func (hs *HeaderStore) save(fromHeight uint, toHeight uint) {
+	if hs.persistence == nil {
+		return
+	}
+
+	if err := hs.persistence.Save(
+		encodeAnchor(hs.anchor),
+		headersDirectory,
+		anchorFileName,
+	); err != nil {
+		logger.Errorf("cannot persist block headers anchor: [%v]", err)
+		return
+	}
+
+	tipHeight := hs.tipHeight()
+
+	fromPeriod := fromHeight / difficultyAdjustmentInterval
+	toPeriod := toHeight / difficultyAdjustmentInterval
+
+	for period := fromPeriod; period <= toPeriod; period++ {
+		periodFirstHeight := period * difficultyAdjustmentInterval
+		if periodFirstHeight < hs.firstHeight {
+			periodFirstHeight = hs.firstHeight
+		}
+
+		periodLastHeight := (period+1)*difficultyAdjustmentInterval - 1
+		if periodLastHeight > tipHeight {
+			periodLastHeight = tipHeight
+		}
+
+		if periodFirstHeight > periodLastHeight {
+			if err := hs.persistence.Delete(
+				headersDirectory,
+				periodFileName(period),
+			); err != nil {
+				logger.Debugf(
+					"cannot delete block headers of period [%d]: [%v]",
+					period,
+					err,
+				)
+			}
+			continue
+		}
+
+		if err := hs.persistence.Save(
+			encodeHeaders(
+				periodFirstHeight,
+				hs.headers[periodFirstHeight-hs.firstHeight:periodLastHeight-hs.firstHeight+1],
+			),
+			headersDirectory,
+			periodFileName(period),
+		); err != nil {
+			logger.Errorf(
+				"cannot persist block headers of period [%d]: [%v]",
+				period,
+				err,
+			)
+		}
+	}
+}
+
+// load reads the persisted block headers. The persisted block headers are
+// used only if they are anchored at the given checkpoint or the checkpoint
+// is not set.
+func (hs *HeaderStore) load(checkpoint *headerAnchor) error {
+	if hs.persistence == nil {
+		return nil
+	}
+
+	descriptors, errs := hs.persistence.ReadAll()
+
+	var anchor *headerAnchor
+	chunks := make(map[uint][]*BlockHeader)
+
+	for descriptors != nil || errs != nil {
+		select {
+		case descriptor, ok := <-descriptors:
+			if !ok {
+				descriptors = nil
+				continue
+			}
+
+			if descriptor.Directory() != headersDirectory {
+				continue
+			}
+
+			content, err := descriptor.Content()
+			if err != nil {
+				return fmt.Errorf(
+					"cannot read file [%s]: [%v]",
+					descriptor.Name(),
+					err,
+				)
+			}
+
+			if descriptor.Name() == anchorFileName {
+				anchor, err = decodeAnchor(content)
+			} else {
+				var firstHeight uint
+				var headers []*BlockHeader
+				firstHeight, headers, err = decodeHeaders(content)
+				chunks[firstHeight] = headers
+			}
+			if err != nil {
+				return fmt.Errorf(
+					"cannot decode file [%s]: [%v]",
+					descriptor.Name(),
+					err,
+				)
+			}
+		case err, ok := <-errs:
+			if !ok {
+				errs = nil
+				continue
+			}
+
+			return fmt.Errorf("cannot read persisted block headers: [%v]", err)
+		}
+	}
+
+	if anchor == nil {
+		if len(chunks) > 0 {
+			return fmt.Errorf("anchor not found")
+		}
+		return nil
+	}
+
+	if checkpoint != nil && *checkpoint != *anchor {
+		return fmt.Errorf(
+			"persisted block headers are anchored at [%s] at height [%d] "+
+				"instead of the configured checkpoint",
+			anchor.hash.Hex(ReversedByteOrder),
+			anchor.height,
+		)
+	}
+
+	firstHeights := make([]uint, 0, len(chunks))
+	for firstHeight := range chunks {
+		firstHeights = append(firstHeights, firstHeight)
+	}
+	sort.Slice(firstHeights, func(i, j int) bool {
+		return firstHeights[i] < firstHeights[j]
+	})
+
+	if len(firstHeights) == 0 {
+		return fmt.Errorf("no block headers found")
+	}
+
+	headers := make([]*BlockHeader, 0)
+	for _, firstHeight := range firstHeights {
+		if firstHeight != firstHeights[0]+uint(len(headers)) {
+			return fmt.Errorf("gap before height [%d]", firstHeight)
+		}
+		headers = append(headers, chunks[firstHeight]...)
+	}
+
+	for i := 1; i < len(headers); i++ {
+		if headers[i].PreviousBlockHeaderHash != headers[i-1].Hash() {
+			return fmt.Errorf(
+				"block header at height [%d] does not point to its predecessor",
+				firstHeights[0]+uint(i),
+			)
+		}
+	}
+
+	if anchor.height < firstHeights[0] ||
+		anchor.height >= firstHeights[0]+uint(len(headers)) ||
+		headers[anchor.height-firstHeights[0]].Hash() != anchor.hash {
+		return fmt.Errorf("anchor block header not found")
+	}
+
+	hs.anchor = anchor
+	hs.firstHeight = firstHeights[0]
+	hs.headers = headers
+
+	logger.Infof(
+		"loaded [%d] persisted block headers from height [%d] to [%d]",
+		len(headers),
+		hs.firstHeight,
+		hs.tipHeight(),
+	)
+
+	return nil
+}
+
+// deletePersisted removes all persisted block headers.
+func (hs *HeaderStore) deletePersisted() {
+	if hs.persistence == nil {
+		return
+	}
+
+	descriptors, errs := hs.persistence.ReadAll()
+
+	for descriptors != nil || errs != nil {
+		select {
+		case descriptor, ok := <-descriptors:
+			if !ok {
+				descriptors = nil
+				continue
+			}
+
+			if descriptor.Directory() != headersDirectory {
+				continue
+			}
+
+			if err := hs.persistence.Delete(
+				descriptor.Directory(),
+				descriptor.Name(),
+			); err != nil {
+				logger.Errorf(
+					"cannot delete persisted file [%s]: [%v]",
+					descriptor.Name(),
+					err,
+				)
+			}
+		case _, ok := <-errs:
+			if !ok {
+				errs = nil
+			}
+		}
+	}
+}
+
+func periodFileName(period uint) string {
+	return strconv.FormatUint(uint64(period), 10)
+}
+
+// encodeAnchor encodes the anchor as [height][hash].
+func encodeAnchor(anchor *headerAnchor) []byte {
+	result := make([]byte, 8+HashByteLength)
+	binary.LittleEndian.PutUint64(result, uint64(anchor.height))
+	copy(result[8:], anchor.hash[:])
+	return result
+}
+
+func decodeAnchor(data []byte) (*headerAnchor, error) {
+	if len(data) != 8+HashByteLength {
+		return nil, fmt.Errorf("wrong anchor length [%d]", len(data))
+	}
+
+	anchor := &headerAnchor{
+		height: uint(binary.LittleEndian.Uint64(data)),
+	}
+	copy(anchor.hash[:], data[8:])
+
+	return anchor, nil
+}
+
+// encodeHeaders encodes block headers as [first height][header]...[header].
+func encodeHeaders(firstHeight uint, headers []*BlockHeader) []byte {
+	result := make([]byte, 8, 8+len(headers)*BlockHeaderByteLength)
+	binary.LittleEndian.PutUint64(result, uint64(firstHeight))
+
+	for _, header := range headers {
+		serialized := header.Serialize()
+		result = append(result, serialized[:]...)
+	}
+
+	return result
+}
+
+func decodeHeaders(data []byte) (uint, []*BlockHeader, error) {
+	if len(data) < 8 || (len(data)-8)%BlockHeaderByteLength != 0 {
+		return 0, nil, fmt.Errorf("wrong block headers length [%d]", len(data))
+	}
+
+	firstHeight := uint(binary.LittleEndian.Uint64(data))
+
+	headers := make([]*BlockHeader, 0, (len(data)-8)/BlockHeaderByteLength)
+	for offset := 8; offset < len(data); offset += BlockHeaderByteLength {
+		var serialized [BlockHeaderByteLength]byte
+		copy(serialized[:], data[offset:offset+BlockHeaderByteLength])
+
+		header := &BlockHeader{}
+		header.Deserialize(serialized)
+		headers = append(headers, header)
+	}
+
+	return firstHeight, headers, nil
+}
@@ -0,0 +1,624 @@
+package bitcoin
+
+import (
+	"context"
+	"encoding/binary"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/chaincfg"
+
+	"github.com/keep-network/keep-common/pkg/persistence"
+
+	"github.com/keep-network/keep-core/internal/testutils"
+)
+
+const (
+	// regtestBits are the difficulty bits of regtest block headers.
+	regtestBits = 0x207fffff
+	// testChainFirstHeight is the height of the first block header of
+	// test chains.
+	testChainFirstHeight = 1000
+	// testChainTipHeight is the height of the tip of test chains.
+	testChainTipHeight = 1200
+)
+
+// mineHeaders mines the given number of regtest block headers extending the
+// given parent block header. The seed differentiates block headers of
+// competing branches.
+func mineHeaders(
+	parent *BlockHeader,
+	count int,
+	seed uint32,
+) []*BlockHeader {
+	headers := make([]*BlockHeader, 0, count)
+
+	for i := 0; i < count; i++ {
+		header := &BlockHeader{
+			Version:                 4,
+			PreviousBlockHeaderHash: parent.Hash(),
+			Time:                    parent.Time + 600,
+			Bits:                    regtestBits,
+		}
+		binary.LittleEndian.PutUint32(header.MerkleRootHash[:], seed)
+		binary.LittleEndian.PutUint32(header.MerkleRootHash[4:], uint32(i))
+
+		for !satisfiesTarget(header) {
+			header.Nonce++
+		}
+
+		headers = append(headers, header)
+		parent = header
+	}
+
+	return headers
+}
+
+func satisfiesTarget(header *BlockHeader) bool {
+	return verifyProofOfWork(&chaincfg.RegressionNetParams, header) == nil
+}
+
+// newTestHeaderChain returns a local chain holding regtest block headers from
+// testChainFirstHeight to testChainTipHeight.
+func newTestHeaderChain() (*localChain, map[uint]*BlockHeader) {
+	chain := newLocalChain()
+	headers := make(map[uint]*BlockHeader)
+
+	genesis := &BlockHeader{Time: 1600000000}
+	for i, header := range mineHeaders(
+		genesis,
+		testChainTipHeight-testChainFirstHeight+1,
+		0,
+	) {
+		height := uint(testChainFirstHeight + i)
+		headers[height] = header
+		chain.setBlockHeader(height, header)
+	}
+
+	return chain, headers
+}
+
+// extendTestHeaderChain mines block headers extending the header at the
+// given height and puts them into the local chain.
+func extendTestHeaderChain(
+	chain *localChain,
+	headers map[uint]*BlockHeader,
+	fromHeight uint,
+	count int,
+	seed uint32,
+) {
+	for i, header := range mineHeaders(headers[fromHeight], count, seed) {
+		height := fromHeight + uint(i) + 1
+		headers[height] = header
+		chain.setBlockHeader(height, header)
+	}
+}
+
+func TestNewHeaderStore_TrustOnFirstUse(t *testing.T) {
+	chain, headers := newTestHeaderChain()
+
+	headerStore, err := NewHeaderStore(Regtest, chain, nil, HeaderStoreConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The store is not synchronized on creation.
+	testutils.AssertUintsEqual(
+		t,
+		"tip height before synchronization",
+		testChainTipHeight-trustedHeaderDepth,
+		uint64(headerStore.TipHeight()),
+	)
+
+	if err := headerStore.Sync(); err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertUintsEqual(
+		t,
+		"anchor height",
+		testChainTipHeight-trustedHeaderDepth,
+		uint64(headerStore.anchor.height),
+	)
+	testutils.AssertUintsEqual(
+		t,
+		"tip height",
+		testChainTipHeight,
+		uint64(headerStore.TipHeight()),
+	)
+
+	for _, height := range []uint{testChainFirstHeight, 1150, testChainTipHeight} {
+		header, err := headerStore.HeaderAt(height)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if header.Hash() != headers[height].Hash() {
+			t.Errorf("unexpected block header at height [%d]", height)
+		}
+	}
+
+	_, err = headerStore.HeaderAt(testChainTipHeight + 1)
+	if err == nil {
+		t.Fatal("expected error for block header above the tip")
+	}
+}
+
+func TestNewHeaderStore_Checkpoint(t *testing.T) {
+	chain, headers := newTestHeaderChain()
+
+	headerStore, err := NewHeaderStore(
+		Regtest,
+		chain,
+		nil,
+		HeaderStoreConfig{
+			CheckpointHeight: 1150,
+			CheckpointHash:   headers[1150].Hash().Hex(ReversedByteOrder),
+		},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := headerStore.Sync(); err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertUintsEqual(
+		t,
+		"anchor height",
+		1150,
+		uint64(headerStore.anchor.height),
+	)
+	testutils.AssertUintsEqual(
+		t,
+		"tip height",
+		testChainTipHeight,
+		uint64(headerStore.TipHeight()),
+	)
+
+	_, err = NewHeaderStore(
+		Regtest,
+		chain,
+		nil,
+		HeaderStoreConfig{
+			CheckpointHeight: 1150,
+			CheckpointHash:   headers[1151].Hash().Hex(ReversedByteOrder),
+		},
+	)
+	if err == nil || !strings.Contains(err.Error(), "at checkpoint height [1150]") {
+		t.Fatalf("unexpected error: [%v]", err)
+	}
+}
+
+func TestNewHeaderStore_MainnetWithoutCheckpoint(t *testing.T) {
+	chain, _ := newTestHeaderChain()
+
+	_, err := NewHeaderStore(Mainnet, chain, nil, HeaderStoreConfig{})
+	if err == nil || !strings.Contains(err.Error(), "must be configured") {
+		t.Fatalf("unexpected error: [%v]", err)
+	}
+}
+
+func TestHeaderStore_Sync(t *testing.T) {
+	chain, headers := newTestHeaderChain()
+
+	headerStore, err := NewHeaderStore(Regtest, chain, nil, HeaderStoreConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := headerStore.Sync(); err != nil {
+		t.Fatal(err)
+	}
+
+	extendTestHeaderChain(chain, headers, testChainTipHeight, 10, 0)
+
+	if err := headerStore.Sync(); err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertUintsEqual(
+		t,
+		"tip height",
+		testChainTipHeight+10,
+		uint64(headerStore.TipHeight()),
+	)
+}
+
+func TestHeaderStore_Sync_MultipleBatches(t *testing.T) {
+	chain, headers := newTestHeaderChain()
+
+	headerStore, err := NewHeaderStore(Regtest, chain, nil, HeaderStoreConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	extendTestHeaderChain(chain, headers, testChainTipHeight, 2*syncBatchSize, 0)
+
+	if err := headerStore.Sync(); err != nil {
+		t.Fatal(err)
+	}
+
+	tipHeight := uint(testChainTipHeight + 2*syncBatchSize)
+
+	testutils.AssertUintsEqual(
+		t,
+		"tip height",
+		uint64(tipHeight),
+		uint64(headerStore.TipHeight()),
+	)
+
+	header, err := headerStore.HeaderAt(tipHeight)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if header.Hash() != headers[tipHeight].Hash() {
+		t.Errorf("unexpected block header at height [%d]", tipHeight)
+	}
+}
+
+func TestHeaderStore_SyncIfIdle(t *testing.T) {
+	chain, _ := newTestHeaderChain()
+
+	headerStore, err := NewHeaderStore(Regtest, chain, nil, HeaderStoreConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Simulate the initial synchronization running in the background.
+	headerStore.syncMutex.Lock()
+	err = headerStore.syncIfIdle()
+	headerStore.syncMutex.Unlock()
+	if err == nil || !strings.Contains(err.Error(), "being synchronized") {
+		t.Fatalf("unexpected error: [%v]", err)
+	}
+
+	if err := headerStore.syncIfIdle(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Once synchronized, the verified tip can be used while another
+	// synchronization is running.
+	headerStore.syncMutex.Lock()
+	err = headerStore.syncIfIdle()
+	headerStore.syncMutex.Unlock()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertUintsEqual(
+		t,
+		"tip height",
+		testChainTipHeight,
+		uint64(headerStore.TipHeight()),
+	)
+}
+
+func TestHeaderStore_StartSync(t *testing.T) {
+	chain, _ := newTestHeaderChain()
+
+	headerStore, err := NewHeaderStore(Regtest, chain, nil, HeaderStoreConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	defer cancelCtx()
+
+	headerStore.StartSync(ctx)
+
+	deadline := time.Now().Add(5 * time.Second)
+	for headerStore.TipHeight() < testChainTipHeight {
+		if time.Now().After(deadline) {
+			t.Fatal("block headers not synchronized in the background")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestHeaderStore_Sync_InvalidHeader(t *testing.T) {
+	var tests = map[string]struct {
+		modifyFn      func(header *BlockHeader)
+		expectedError string
+	}{
+		"wrong difficulty bits": {
+			modifyFn: func(header *BlockHeader) {
+				header.Bits = 0x1d00ffff
+			},
+			expectedError: "block header at height [1202] has difficulty bits",
+		},
+		"wrong proof of work": {
+			modifyFn: func(header *BlockHeader) {
+				for satisfiesTarget(header) {
+					header.Nonce++
+				}
+			},
+			expectedError: "block header at height [1202] has invalid proof of work",
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			chain, headers := newTestHeaderChain()
+
+			headerStore, err := NewHeaderStore(
+				Regtest,
+				chain,
+				nil,
+				HeaderStoreConfig{},
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if err := headerStore.Sync(); err != nil {
+				t.Fatal(err)
+			}
+
+			extendTestHeaderChain(chain, headers, testChainTipHeight, 5, 0)
+
+			invalid := *headers[testChainTipHeight+2]
+			test.modifyFn(&invalid)
+			chain.setBlockHeader(testChainTipHeight+2, &invalid)
+
+			err = headerStore.Sync()
+			if err == nil || !strings.Contains(err.Error(), test.expectedError) {
+				t.Fatalf("unexpected error: [%v]", err)
+			}
+
+			testutils.AssertUintsEqual(
+				t,
+				"tip height",
+				testChainTipHeight+1,
+				uint64(headerStore.TipHeight()),
+			)
+		})
+	}
+}
+
+func TestHeaderStore_Sync_Reorganization(t *testing.T) {
+	chain, headers := newTestHeaderChain()
+
+	headerStore, err := NewHeaderStore(Regtest, chain, nil, HeaderStoreConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := headerStore.Sync(); err != nil {
+		t.Fatal(err)
+	}
+
+	forkHeight := uint(testChainTipHeight - 5)
+	extendTestHeaderChain(chain, headers, forkHeight, 10, 1)
+
+	if err := headerStore.Sync(); err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertUintsEqual(
+		t,
+		"tip height",
+		uint64(forkHeight+10),
+		uint64(headerStore.TipHeight()),
+	)
+
+	for height := forkHeight; height <= forkHeight+10; height++ {
+		header, err := headerStore.HeaderAt(height)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if header.Hash() != headers[height].Hash() {
+			t.Errorf("unexpected block header at height [%d]", height)
+		}
+	}
+}
+
+func TestHeaderStore_Sync_ReorganizationTooDeep(t *testing.T) {
+	chain, headers := newTestHeaderChain()
+
+	headerStore, err := NewHeaderStore(Regtest, chain, nil, HeaderStoreConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := headerStore.Sync(); err != nil {
+		t.Fatal(err)
+	}
+
+	tipHash := headers[testChainTipHeight].Hash()
+
+	extendTestHeaderChain(
+		chain,
+		headers,
+		testChainTipHeight-maxReorgDepth-1,
+		maxReorgDepth+10,
+		1,
+	)
+
+	err = headerStore.Sync()
+	if err == nil || !strings.Contains(err.Error(), "diverging more than") {
+		t.Fatalf("unexpected error: [%v]", err)
+	}
+
+	header, err := headerStore.HeaderAt(testChainTipHeight)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if header.Hash() != tipHash {
+		t.Errorf("verified chain should not be reorganized")
+	}
+}
+
+func TestHeaderStore_HeaderAt_InvalidPrecedingHeader(t *testing.T) {
+	chain, headers := newTestHeaderChain()
+
+	headerStore, err := NewHeaderStore(Regtest, chain, nil, HeaderStoreConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	anchorHeight := uint(testChainTipHeight - trustedHeaderDepth)
+
+	// Replace the block header preceding the anchor with a valid block
+	// header of a different branch.
+	extendTestHeaderChain(chain, headers, anchorHeight-2, 1, 1)
+
+	_, err = headerStore.HeaderAt(anchorHeight - 1)
+	if err == nil || !strings.Contains(err.Error(), "while") {
+		t.Fatalf("unexpected error: [%v]", err)
+	}
+}
+
+func TestNewHeaderStore_Persistence(t *testing.T) {
+	chain, headers := newTestHeaderChain()
+	persistenceHandle := newHeaderPersistenceMock()
+
+	headerStore, err := NewHeaderStore(
+		Regtest,
+		chain,
+		persistenceHandle,
+		HeaderStoreConfig{},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := headerStore.Sync(); err != nil {
+		t.Fatal(err)
+	}
+
+	// The new chain backend holds only the tip so the store can be created
+	// only from the persisted block headers.
+	tipOnlyChain := newLocalChain()
+	tipOnlyChain.setBlockHeader(testChainTipHeight, headers[testChainTipHeight])
+
+	headerStore, err = NewHeaderStore(
+		Regtest,
+		tipOnlyChain,
+		persistenceHandle,
+		HeaderStoreConfig{},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertUintsEqual(
+		t,
+		"anchor height",
+		testChainTipHeight-trustedHeaderDepth,
+		uint64(headerStore.anchor.height),
+	)
+	testutils.AssertUintsEqual(
+		t,
+		"tip height",
+		testChainTipHeight,
+		uint64(headerStore.TipHeight()),
+	)
+
+	// Configuring a different checkpoint discards the persisted block
+	// headers.
+	headerStore, err = NewHeaderStore(
+		Regtest,
+		chain,
+		persistenceHandle,
+		HeaderStoreConfig{
+			CheckpointHeight: 1150,
+			CheckpointHash:   headers[1150].Hash().Hex(ReversedByteOrder),
+		},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertUintsEqual(
+		t,
+		"first height",
+		1150,
+		uint64(headerStore.firstHeight),
+	)
+
+	anchor, err := decodeAnchor(
+		persistenceHandle.files[headersDirectory+"/"+anchorFileName],
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertUintsEqual(t, "persisted anchor height", 1150, uint64(anchor.height))
+}
+
+type headerPersistenceMock struct {
+	mutex sync.Mutex
+	files map[string][]byte
+}
+
+func newHeaderPersistenceMock() *headerPersistenceMock {
+	return &headerPersistenceMock{
+		files: make(map[string][]byte),
+	}
+}
+
+func (hpm *headerPersistenceMock) Save(
+	data []byte,
+	directory string,
+	name string,
+) error {
+	hpm.mutex.Lock()
+	defer hpm.mutex.Unlock()
+
+	hpm.files[directory+"/"+name] = data
+	return nil
+}
+
+func (hpm *headerPersistenceMock) ReadAll() (
+	<-chan persistence.DataDescriptor,
+	<-chan error,
+) {
+	hpm.mutex.Lock()
+	defer hpm.mutex.Unlock()
+
+	outputData := make(chan persistence.DataDescriptor, len(hpm.files))
+	outputErrors := make(chan error)
+
+	for path, content := range hpm.files {
+		parts := strings.SplitN(path, "/", 2)
+		outputData <- &testDataDescriptor{parts[1], parts[0], content}
+	}
+
+	close(outputData)
+	close(outputErrors)
+
+	return outputData, outputErrors
+}
+
+func (hpm *headerPersistenceMock) Delete(directory string, name string) error {
+	hpm.mutex.Lock()
+	defer hpm.mutex.Unlock()
+
+	delete(hpm.files, directory+"/"+name)
+	return nil
+}
+
+type testDataDescriptor struct {
+	name      string
+	directory string
+	content   []byte
+}
+
+func (tdd *testDataDescriptor) Name() string {
+	return tdd.name
+}
+
+func (tdd *testDataDescriptor) Directory() string {
+	return tdd.directory
+}
+
+func (tdd *testDataDescriptor) Content() ([]byte, error) {
+	return tdd.content, nil
+}
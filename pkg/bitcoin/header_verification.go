@@ -0,0 +1,250 @@
+package bitcoin
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/btcsuite/btcd/blockchain"
+	"github.com/btcsuite/btcd/chaincfg"
+
+	"github.com/keep-network/keep-core/pkg/internal/byteutils"
+)
+
+// difficultyAdjustmentInterval is the number of blocks between difficulty
+// retargets.
+const difficultyAdjustmentInterval = 2016
+
+// headerSource returns the block header at the given height. It is used to
+// reach block headers preceding the verified one when determining the
+// required difficulty.
+type headerSource func(height uint) (*BlockHeader, error)
+
+// consensusParams returns the consensus parameters of the given network
+// required to verify block headers.
+func consensusParams(network Network) (*chaincfg.Params, error) {
+	switch network {
+	case Mainnet:
+		return &chaincfg.MainNetParams, nil
+	case Testnet:
+		return &chaincfg.TestNet3Params, nil
+	case Regtest:
+		return &chaincfg.RegressionNetParams, nil
+	default:
+		return nil, fmt.Errorf("unsupported network [%v]", network)
+	}
+}
+
+// verifyHeader verifies the block header at the given height extends the
+// given parent block header. That is, the block header must point to the
+// parent, have difficulty bits required by the difficulty retarget rules of
+// the network, and its hash must satisfy the target determined by these
+// bits.
+func verifyHeader(
+	network Network,
+	height uint,
+	header *BlockHeader,
+	parent *BlockHeader,
+	headerAt headerSource,
+) error {
+	params, err := consensusParams(network)
+	if err != nil {
+		return err
+	}
+
+	if header.PreviousBlockHeaderHash != parent.Hash() {
+		return fmt.Errorf(
+			"block header at height [%d] does not point to the "+
+				"previous block header [%s]",
+			height,
+			parent.Hash().Hex(ReversedByteOrder),
+		)
+	}
+
+	expectedBits, err := requiredBits(
+		params,
+		network,
+		height,
+		header,
+		parent,
+		headerAt,
+	)
+	if err != nil {
+		return fmt.Errorf(
+			"cannot determine required difficulty of block header "+
+				"at height [%d]: [%v]",
+			height,
+			err,
+		)
+	}
+
+	if header.Bits != expectedBits {
+		return fmt.Errorf(
+			"block header at height [%d] has difficulty bits [0x%08x] "+
+				"while [0x%08x] are required",
+			height,
+			header.Bits,
+			expectedBits,
+		)
+	}
+
+	if err := verifyProofOfWork(params, header); err != nil {
+		return fmt.Errorf(
+			"block header at height [%d] has invalid proof of work: [%v]",
+			height,
+			err,
+		)
+	}
+
+	return nil
+}
+
+// verifyProofOfWork verifies the hash of the given block header satisfies
+// the target determined by the header's difficulty bits and the target is
+// within the proof of work limit of the network.
+func verifyProofOfWork(params *chaincfg.Params, header *BlockHeader) error {
+	target := header.Target()
+	if target.Sign() <= 0 || target.Cmp(params.PowLimit) > 0 {
+		return fmt.Errorf(
+			"target of difficulty bits [0x%08x] is out of range",
+			header.Bits,
+		)
+	}
+
+	hash := header.Hash()
+	// The hash is compared with the target as a big-endian number.
+	hashValue := new(big.Int).SetBytes(byteutils.Reverse(hash[:]))
+
+	if hashValue.Cmp(target) > 0 {
+		return fmt.Errorf(
+			"hash [%s] is above the target",
+			hash.Hex(ReversedByteOrder),
+		)
+	}
+
+	return nil
+}
+
+// requiredBits determines the difficulty bits the block header at the given
+// height must have according to the difficulty retarget rules of the
+// network.
+func requiredBits(
+	params *chaincfg.Params,
+	network Network,
+	height uint,
+	header *BlockHeader,
+	parent *BlockHeader,
+	headerAt headerSource,
+) (uint32, error) {
+	// Regtest does not retarget the difficulty.
+	if network == Regtest {
+		return parent.Bits, nil
+	}
+
+	if height%difficultyAdjustmentInterval != 0 {
+		if !params.ReduceMinDifficulty {
+			return parent.Bits, nil
+		}
+
+		// Test networks allow mining a block with the minimum difficulty
+		// if no block was mined for the given time.
+		minDifficultyTime := int64(parent.Time) +
+			int64(params.MinDiffReductionTime/time.Second)
+		if int64(header.Time) > minDifficultyTime {
+			return params.PowLimitBits, nil
+		}
+
+		// Otherwise, the difficulty of the last block that did not have
+		// the minimum difficulty rule applied is required.
+		lastHeight, last := height-1, parent
+		for lastHeight%difficultyAdjustmentInterval != 0 &&
+			last.Bits == params.PowLimitBits {
+			lastHeight--
+
+			var err error
+			last, err = headerAt(lastHeight)
+			if err != nil {
+				return 0, err
+			}
+		}
+
+		return last.Bits, nil
+	}
+
+	if height < difficultyAdjustmentInterval {
+		return 0, fmt.Errorf("no previous difficulty period")
+	}
+
+	first, err := headerAt(height - difficultyAdjustmentInterval)
+	if err != nil {
+		return 0, err
+	}
+
+	targetTimespan := int64(params.TargetTimespan / time.Second)
+
+	timespan := int64(parent.Time) - int64(first.Time)
+	if minTimespan := targetTimespan / params.RetargetAdjustmentFactor; timespan < minTimespan {
+		timespan = minTimespan
+	}
+	if maxTimespan := targetTimespan * params.RetargetAdjustmentFactor; timespan > maxTimespan {
+		timespan = maxTimespan
+	}
+
+	target := blockchain.CompactToBig(parent.Bits)
+	target.Mul(target, big.NewInt(timespan))
+	target.Div(target, big.NewInt(targetTimespan))
+
+	if target.Cmp(params.PowLimit) > 0 {
+		target.Set(params.PowLimit)
+	}
+
+	return blockchain.BigToCompact(target), nil
+}
+
+// verifyMerkleProof verifies the given merkle proof proves inclusion of the
+// transaction with the given hash in the block with the given merkle root.
+func verifyMerkleProof(
+	transactionHash Hash,
+	proof *TransactionMerkleProof,
+	merkleRoot Hash,
+) error {
+	current := transactionHash
+	position := proof.Position
+
+	for _, node := range proof.MerkleNodes {
+		nodeHash, err := NewHashFromString(node, ReversedByteOrder)
+		if err != nil {
+			return fmt.Errorf("invalid merkle node [%s]: [%v]", node, err)
+		}
+
+		var pair [2 * HashByteLength]byte
+		if position%2 == 0 {
+			copy(pair[:HashByteLength], current[:])
+			copy(pair[HashByteLength:], nodeHash[:])
+		} else {
+			copy(pair[:HashByteLength], nodeHash[:])
+			copy(pair[HashByteLength:], current[:])
+		}
+
+		current = ComputeHash(pair[:])
+		position /= 2
+	}
+
+	if position != 0 {
+		return fmt.Errorf(
+			"position [%d] exceeds the merkle tree depth [%d]",
+			proof.Position,
+			len(proof.MerkleNodes),
+		)
+	}
+
+	if current != merkleRoot {
+		return fmt.Errorf(
+			"merkle proof leads to root [%s] instead of [%s]",
+			current.Hex(ReversedByteOrder),
+			merkleRoot.Hex(ReversedByteOrder),
+		)
+	}
+
+	return nil
+}
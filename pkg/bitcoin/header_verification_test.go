@@ -0,0 +1,228 @@
+package bitcoin
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/chaincfg"
+
+	"github.com/keep-network/keep-core/internal/testutils"
+)
+
+// testnetHeaders returns consecutive Bitcoin testnet block headers from
+// heights 2164152 to 2164160 used by the SPV proof test vectors.
+func testnetHeaders() map[uint]*BlockHeader {
+	headers := make(map[uint]*BlockHeader)
+	for _, test := range SpvProofData {
+		for height, header := range test.BitcoinChainData.HeadersChain {
+			headers[height] = header
+		}
+	}
+	return headers
+}
+
+func TestVerifyHeader_Testnet(t *testing.T) {
+	headers := testnetHeaders()
+
+	headerAt := func(height uint) (*BlockHeader, error) {
+		return headers[height], nil
+	}
+
+	// Block headers at 2164158-2164160 are mined with the minimum
+	// difficulty allowed 20 minutes after the previous block. Block
+	// headers at 2164154-2164157 must have the difficulty of the last
+	// block without the minimum difficulty.
+	for height := uint(2164154); height <= 2164160; height++ {
+		err := verifyHeader(
+			Testnet,
+			height,
+			headers[height],
+			headers[height-1],
+			headerAt,
+		)
+		if err != nil {
+			t.Errorf("unexpected error for height [%d]: [%v]", height, err)
+		}
+	}
+}
+
+func TestVerifyHeader_Testnet_Invalid(t *testing.T) {
+	headers := testnetHeaders()
+
+	headerAt := func(height uint) (*BlockHeader, error) {
+		return headers[height], nil
+	}
+
+	var tests = map[string]struct {
+		height        uint
+		modifyFn      func(header *BlockHeader)
+		expectedError string
+	}{
+		"wrong previous block header": {
+			height: 2164156,
+			modifyFn: func(header *BlockHeader) {
+				header.PreviousBlockHeaderHash = headers[2164154].Hash()
+			},
+			expectedError: "block header at height [2164156] does not " +
+				"point to the previous block header",
+		},
+		"minimum difficulty before 20 minutes elapsed": {
+			height: 2164157,
+			modifyFn: func(header *BlockHeader) {
+				header.Bits = chaincfg.TestNet3Params.PowLimitBits
+			},
+			expectedError: "block header at height [2164157] has " +
+				"difficulty bits [0x1d00ffff] while [0x1a033eed] are required",
+		},
+		"wrong nonce": {
+			height: 2164157,
+			modifyFn: func(header *BlockHeader) {
+				header.Nonce++
+			},
+			expectedError: "block header at height [2164157] has invalid " +
+				"proof of work: [hash",
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			header := *headers[test.height]
+			test.modifyFn(&header)
+
+			err := verifyHeader(
+				Testnet,
+				test.height,
+				&header,
+				headers[test.height-1],
+				headerAt,
+			)
+			if err == nil || !strings.HasPrefix(err.Error(), test.expectedError) {
+				t.Fatalf(
+					"unexpected error\nexpected prefix: [%s]\nactual:          [%v]",
+					test.expectedError,
+					err,
+				)
+			}
+		})
+	}
+}
+
+func TestRequiredBits_Retarget(t *testing.T) {
+	targetTimespan := uint32(chaincfg.MainNetParams.TargetTimespan / time.Second)
+
+	var tests = map[string]struct {
+		parentBits   uint32
+		timespan     uint32
+		expectedBits uint32
+	}{
+		"target timespan": {
+			parentBits:   0x17053894,
+			timespan:     targetTimespan,
+			expectedBits: 0x17053894,
+		},
+		"half of target timespan": {
+			parentBits:   0x17053894,
+			timespan:     targetTimespan / 2,
+			expectedBits: 0x17029c4a,
+		},
+		"double target timespan": {
+			parentBits:   0x17053894,
+			timespan:     targetTimespan * 2,
+			expectedBits: 0x170a7128,
+		},
+		"timespan limited to four times target timespan": {
+			parentBits:   0x17053894,
+			timespan:     targetTimespan * 10,
+			expectedBits: 0x1714e250,
+		},
+		"timespan limited to quarter of target timespan": {
+			parentBits:   0x17053894,
+			timespan:     targetTimespan / 10,
+			expectedBits: 0x17014e25,
+		},
+		"target limited to proof of work limit": {
+			parentBits:   0x1d00ffff,
+			timespan:     targetTimespan * 2,
+			expectedBits: 0x1d00ffff,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			height := uint(100 * difficultyAdjustmentInterval)
+
+			first := &BlockHeader{Time: 1600000000, Bits: test.parentBits}
+			parent := &BlockHeader{
+				Time: first.Time + test.timespan,
+				Bits: test.parentBits,
+			}
+
+			headerAt := func(h uint) (*BlockHeader, error) {
+				if h != height-difficultyAdjustmentInterval {
+					t.Fatalf("unexpected height [%d]", h)
+				}
+				return first, nil
+			}
+
+			bits, err := requiredBits(
+				&chaincfg.MainNetParams,
+				Mainnet,
+				height,
+				&BlockHeader{Time: parent.Time + 600},
+				parent,
+				headerAt,
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			testutils.AssertUintsEqual(
+				t,
+				"bits",
+				uint64(test.expectedBits),
+				uint64(bits),
+			)
+		})
+	}
+}
+
+func TestVerifyMerkleProof(t *testing.T) {
+	for testName, test := range SpvProofData {
+		t.Run(testName, func(t *testing.T) {
+			transaction := transactionFrom(t, test.BitcoinChainData.TransactionHex)
+			proof := test.BitcoinChainData.TransactionMerkleProof
+			header := test.BitcoinChainData.HeadersChain[proof.BlockHeight]
+
+			err := verifyMerkleProof(
+				transaction.Hash(),
+				proof,
+				header.MerkleRootHash,
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			wrongPosition := *proof
+			wrongPosition.Position++
+
+			err = verifyMerkleProof(
+				transaction.Hash(),
+				&wrongPosition,
+				header.MerkleRootHash,
+			)
+			if err == nil {
+				t.Fatal("expected error for wrong position")
+			}
+
+			err = verifyMerkleProof(
+				transaction.Hash(),
+				proof,
+				header.PreviousBlockHeaderHash,
+			)
+			if err == nil {
+				t.Fatal("expected error for wrong merkle root")
+			}
+		})
+	}
+}
@@ -0,0 +1,10 @@
+package quorum
+
+// Config holds configurable properties.
+type Config struct {
+	// Threshold is the number of backends that must agree on the result of
+	// a security-critical query, i.e. a query about unspent outputs,
+	// transaction confirmations or transaction merkle proofs. If not set,
+	// the majority of backends is required.
+	Threshold int
+}
@@ -0,0 +1,90 @@
+package quorum
+
+import (
+	"sync"
+	"time"
+)
+
+// BackendHealth is a snapshot of the health of a single backend.
+type BackendHealth struct {
+	Name                string        `json:"name"`
+	RequestsCount       uint64        `json:"requests_count"`
+	FailuresCount       uint64        `json:"failures_count"`
+	DisagreementsCount  uint64        `json:"disagreements_count"`
+	ConsecutiveFailures uint64        `json:"consecutive_failures"`
+	LastLatency         time.Duration `json:"last_latency"`
+	LastError           string        `json:"last_error,omitempty"`
+	LastErrorTime       time.Time     `json:"last_error_time,omitempty"`
+}
+
+// IsHealthy returns true if the latest request to the backend succeeded.
+func (bh BackendHealth) IsHealthy() bool {
+	return bh.ConsecutiveFailures == 0
+}
+
+// health tracks outcomes of requests sent to a single backend.
+type health struct {
+	mutex sync.Mutex
+
+	requestsCount       uint64
+	failuresCount       uint64
+	disagreementsCount  uint64
+	consecutiveFailures uint64
+	lastLatency         time.Duration
+	lastError           string
+	lastErrorTime       time.Time
+}
+
+func (h *health) recordSuccess(latency time.Duration) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	h.requestsCount++
+	h.consecutiveFailures = 0
+	h.lastLatency = latency
+}
+
+func (h *health) recordFailure(latency time.Duration, err error) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	h.requestsCount++
+	h.failuresCount++
+	h.consecutiveFailures++
+	h.lastLatency = latency
+	h.lastError = err.Error()
+	h.lastErrorTime = time.Now()
+}
+
+// recordDisagreement records the backend returned a result different from
+// the one other backends agreed on.
+func (h *health) recordDisagreement() {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	h.disagreementsCount++
+}
+
+// failures returns the number of consecutive failed requests.
+func (h *health) failures() uint64 {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	return h.consecutiveFailures
+}
+
+func (h *health) snapshot(name string) BackendHealth {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	return BackendHealth{
+		Name:                name,
+		RequestsCount:       h.requestsCount,
+		FailuresCount:       h.failuresCount,
+		DisagreementsCount:  h.disagreementsCount,
+		ConsecutiveFailures: h.consecutiveFailures,
+		LastLatency:         h.lastLatency,
+		LastError:           h.lastError,
+		LastErrorTime:       h.lastErrorTime,
+	}
+}
@@ -0,0 +1,684 @@
+package quorum
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ipfs/go-log"
+
+	"github.com/keep-network/keep-core/pkg/bitcoin"
+)
+
+var logger = log.Logger("keep-bitcoin-quorum")
+
+// Backend is a named Bitcoin chain backend combined by the Connection.
+type Backend struct {
+	// Name identifies the backend in logs and metrics.
+	Name string
+	// Chain is the handle used to interact with the backend.
+	Chain bitcoin.Chain
+}
+
+// backend wraps a Backend with its health tracking.
+type backend struct {
+	name   string
+	chain  bitcoin.Chain
+	health *health
+}
+
+// Connection is a bitcoin.Chain implementation combining multiple backends.
+// Queries whose results are used to determine the wallet state and to build
+// security-critical proofs and transactions, i.e. queries about wallet
+// transactions, unspent outputs, block headers, the chain tip, transaction
+// confirmations and transaction merkle proofs, are sent to all backends and
+// succeed only if the configured threshold of backends agrees on the
+// result. Remaining queries are sent to the healthiest backend and fail
+// over to the next ones in case of an error. Transactions are broadcast
+// through all backends.
+type Connection struct {
+	config   Config
+	backends []*backend
+}
+
+// Connect initializes handle combining the provided backends.
+func Connect(config Config, backends []*Backend) (*Connection, error) {
+	if len(backends) == 0 {
+		return nil, fmt.Errorf("at least one backend must be provided")
+	}
+
+	if config.Threshold == 0 {
+		config.Threshold = len(backends)/2 + 1
+	}
+	if config.Threshold < 0 || config.Threshold > len(backends) {
+		return nil, fmt.Errorf(
+			"threshold [%d] must be between 1 and the number of backends [%d]",
+			config.Threshold,
+			len(backends),
+		)
+	}
+
+	names := make(map[string]bool)
+	wrapped := make([]*backend, len(backends))
+	for i, b := range backends {
+		if names[b.Name] {
+			return nil, fmt.Errorf("duplicate backend name [%s]", b.Name)
+		}
+		names[b.Name] = true
+
+		wrapped[i] = &backend{
+			name:   b.Name,
+			chain:  b.Chain,
+			health: &health{},
+		}
+	}
+
+	logger.Infof(
+		"combined [%d] Bitcoin chain backends with agreement threshold [%d]",
+		len(wrapped),
+		config.Threshold,
+	)
+
+	return &Connection{
+		config:   config,
+		backends: wrapped,
+	}, nil
+}
+
+// GetTransaction gets the transaction with the given transaction hash.
+// If the transaction with the given hash was not found on the chain,
+// this function returns an error. The transaction returned by a backend
+// is accepted only if its hash matches the requested one.
+func (c *Connection) GetTransaction(
+	transactionHash bitcoin.Hash,
+) (*bitcoin.Transaction, error) {
+	return failover(
+		c,
+		"GetTransaction",
+		func(chain bitcoin.Chain) (*bitcoin.Transaction, error) {
+			transaction, err := chain.GetTransaction(transactionHash)
+			if err != nil {
+				return nil, err
+			}
+
+			if transaction.Hash() != transactionHash {
+				return nil, fmt.Errorf(
+					"returned transaction has unexpected hash [%s]",
+					transaction.Hash().Hex(bitcoin.ReversedByteOrder),
+				)
+			}
+
+			return transaction, nil
+		},
+	)
+}
+
+// GetTransactionConfirmations gets the number of confirmations for the
+// transaction with the given transaction hash. If the transaction with the
+// given hash was not found on the chain, this function returns an error.
+// As backends may observe the chain tip at slightly different moments, the
+// result is the lowest number of confirmations among the first responses
+// of threshold backends. This way, no group of backends smaller than the
+// threshold can inflate the number of confirmations.
+func (c *Connection) GetTransactionConfirmations(
+	transactionHash bitcoin.Hash,
+) (uint, error) {
+	return lowest(
+		c,
+		"GetTransactionConfirmations",
+		func(chain bitcoin.Chain) (uint, error) {
+			return chain.GetTransactionConfirmations(transactionHash)
+		},
+	)
+}
+
+// BroadcastTransaction broadcasts the given transaction over the
+// network of the Bitcoin chain nodes through all backends. The broadcast
+// is considered successful if at least one backend accepted the
+// transaction. This function does not give any guarantees regarding
+// transaction mining. The transaction may be mined or rejected eventually.
+func (c *Connection) BroadcastTransaction(
+	transaction *bitcoin.Transaction,
+) error {
+	errs := make([]error, len(c.backends))
+
+	wg := sync.WaitGroup{}
+	wg.Add(len(c.backends))
+
+	for i, b := range c.backends {
+		go func(i int, b *backend) {
+			defer wg.Done()
+
+			_, errs[i] = invoke(b, func(chain bitcoin.Chain) (struct{}, error) {
+				return struct{}{}, chain.BroadcastTransaction(transaction)
+			})
+		}(i, b)
+	}
+
+	wg.Wait()
+
+	failures := make([]string, 0)
+	for i, err := range errs {
+		if err != nil {
+			logger.Warnf(
+				"backend [%s] failed to broadcast transaction [%s]: [%v]",
+				c.backends[i].name,
+				transaction.Hash().Hex(bitcoin.ReversedByteOrder),
+				err,
+			)
+			failures = append(
+				failures,
+				fmt.Sprintf("%s: %v", c.backends[i].name, err),
+			)
+		}
+	}
+
+	if len(failures) == len(c.backends) {
+		return fmt.Errorf(
+			"all backends failed to broadcast the transaction: [%s]",
+			strings.Join(failures, "; "),
+		)
+	}
+
+	return nil
+}
+
+// GetLatestBlockHeight gets the height of the latest block (tip). If the
+// latest block was not determined, this function returns an error. As
+// backends may observe the chain tip at slightly different moments, the
+// result is the lowest height among the first responses of threshold
+// backends. This way, no group of backends smaller than the threshold can
+// move the tip ahead.
+func (c *Connection) GetLatestBlockHeight() (uint, error) {
+	return lowest(
+		c,
+		"GetLatestBlockHeight",
+		func(chain bitcoin.Chain) (uint, error) {
+			return chain.GetLatestBlockHeight()
+		},
+	)
+}
+
+// GetBlockHeader gets the block header for the given block height. If the
+// block with the given height was not found on the chain, this function
+// returns an error. The block header is returned only if the threshold of
+// backends returned the same header.
+func (c *Connection) GetBlockHeader(
+	blockHeight uint,
+) (*bitcoin.BlockHeader, error) {
+	return agree(
+		c,
+		"GetBlockHeader",
+		func(chain bitcoin.Chain) (*bitcoin.BlockHeader, error) {
+			return chain.GetBlockHeader(blockHeight)
+		},
+		func(header *bitcoin.BlockHeader) string {
+			return header.Hash().Hex(bitcoin.InternalByteOrder)
+		},
+	)
+}
+
+// GetTransactionMerkleProof gets the Merkle proof for a given transaction.
+// The transaction's hash and the block the transaction was included in the
+// blockchain need to be provided. The proof is returned only if the
+// threshold of backends returned the same proof.
+func (c *Connection) GetTransactionMerkleProof(
+	transactionHash bitcoin.Hash,
+	blockHeight uint,
+) (*bitcoin.TransactionMerkleProof, error) {
+	return agree(
+		c,
+		"GetTransactionMerkleProof",
+		func(chain bitcoin.Chain) (*bitcoin.TransactionMerkleProof, error) {
+			return chain.GetTransactionMerkleProof(transactionHash, blockHeight)
+		},
+		func(proof *bitcoin.TransactionMerkleProof) string {
+			return fmt.Sprintf(
+				"%d:%d:%s",
+				proof.BlockHeight,
+				proof.Position,
+				strings.Join(proof.MerkleNodes, ","),
+			)
+		},
+	)
+}
+
+// GetTransactionsForPublicKeyHash gets the confirmed transactions that pays the
+// given public key hash using either a P2PKH or P2WPKH script. The returned
+// transactions are ordered by block height in the ascending order, i.e.
+// the latest transaction is at the end of the list. The returned list does
+// not contain unconfirmed transactions living in the mempool at the moment
+// of request. The returned transactions list can be limited using the
+// `limit` parameter. For example, if `limit` is set to `5`, only the
+// latest five transactions will be returned. The transactions are returned
+// only if the threshold of backends returned the same transactions in the
+// same order.
+func (c *Connection) GetTransactionsForPublicKeyHash(
+	publicKeyHash [20]byte,
+	limit int,
+) ([]*bitcoin.Transaction, error) {
+	return agree(
+		c,
+		"GetTransactionsForPublicKeyHash",
+		func(chain bitcoin.Chain) ([]*bitcoin.Transaction, error) {
+			return chain.GetTransactionsForPublicKeyHash(publicKeyHash, limit)
+		},
+		func(transactions []*bitcoin.Transaction) string {
+			return transactionsKey(transactions, false)
+		},
+	)
+}
+
+// GetTxHashesForPublicKeyHash gets hashes of confirmed transactions that pays
+// the given public key hash using either a P2PKH or P2WPKH script. The returned
+// transactions hashes are ordered by block height in the ascending order, i.e.
+// the latest transaction hash is at the end of the list. The returned list does
+// not contain unconfirmed transactions hashes living in the mempool at the
+// moment of request. The hashes are returned only if the threshold of
+// backends returned the same hashes in the same order.
+func (c *Connection) GetTxHashesForPublicKeyHash(
+	publicKeyHash [20]byte,
+) ([]bitcoin.Hash, error) {
+	return agree(
+		c,
+		"GetTxHashesForPublicKeyHash",
+		func(chain bitcoin.Chain) ([]bitcoin.Hash, error) {
+			return chain.GetTxHashesForPublicKeyHash(publicKeyHash)
+		},
+		func(hashes []bitcoin.Hash) string {
+			return hashesKey(hashes, false)
+		},
+	)
+}
+
+// GetMempoolForPublicKeyHash gets the unconfirmed mempool transactions
+// that pays the given public key hash using either a P2PKH or P2WPKH script.
+// The returned transactions are in an indefinite order. The transactions are
+// returned only if the threshold of backends returned the same set of
+// transactions.
+func (c *Connection) GetMempoolForPublicKeyHash(
+	publicKeyHash [20]byte,
+) ([]*bitcoin.Transaction, error) {
+	return agree(
+		c,
+		"GetMempoolForPublicKeyHash",
+		func(chain bitcoin.Chain) ([]*bitcoin.Transaction, error) {
+			return chain.GetMempoolForPublicKeyHash(publicKeyHash)
+		},
+		func(transactions []*bitcoin.Transaction) string {
+			return transactionsKey(transactions, true)
+		},
+	)
+}
+
+// GetUtxosForPublicKeyHash gets unspent outputs of confirmed transactions that
+// are controlled by the given public key hash (either a P2PKH or P2WPKH script).
+// The returned UTXOs are ordered by block height in the ascending order, i.e.
+// the latest UTXO is at the end of the list. The returned list does not contain
+// unspent outputs of unconfirmed transactions living in the mempool at the
+// moment of request. Outputs used as inputs of confirmed or mempool
+// transactions are not returned as well because they are no longer UTXOs.
+// The outputs are returned only if the threshold of backends returned the
+// same set of outputs.
+func (c *Connection) GetUtxosForPublicKeyHash(
+	publicKeyHash [20]byte,
+) ([]*bitcoin.UnspentTransactionOutput, error) {
+	return agree(
+		c,
+		"GetUtxosForPublicKeyHash",
+		func(chain bitcoin.Chain) ([]*bitcoin.UnspentTransactionOutput, error) {
+			return chain.GetUtxosForPublicKeyHash(publicKeyHash)
+		},
+		utxosKey,
+	)
+}
+
+// GetMempoolUtxosForPublicKeyHash gets unspent outputs of unconfirmed transactions
+// that are controlled by the given public key hash (either a P2PKH or P2WPKH script).
+// The returned UTXOs are in an indefinite order. The returned list does not
+// contain unspent outputs of confirmed transactions. Outputs used as inputs of
+// confirmed or mempool transactions are not returned as well because they are
+// no longer UTXOs. The outputs are returned only if the threshold of backends
+// returned the same set of outputs.
+func (c *Connection) GetMempoolUtxosForPublicKeyHash(
+	publicKeyHash [20]byte,
+) ([]*bitcoin.UnspentTransactionOutput, error) {
+	return agree(
+		c,
+		"GetMempoolUtxosForPublicKeyHash",
+		func(chain bitcoin.Chain) ([]*bitcoin.UnspentTransactionOutput, error) {
+			return chain.GetMempoolUtxosForPublicKeyHash(publicKeyHash)
+		},
+		utxosKey,
+	)
+}
+
+// EstimateSatPerVByteFee returns the estimated sat/vbyte fee for a
+// transaction to be confirmed within the given number of blocks.
+func (c *Connection) EstimateSatPerVByteFee(blocks uint32) (int64, error) {
+	return failover(
+		c,
+		"EstimateSatPerVByteFee",
+		func(chain bitcoin.Chain) (int64, error) {
+			return chain.EstimateSatPerVByteFee(blocks)
+		},
+	)
+}
+
+// Health returns health snapshots of all backends, in the configured order.
+func (c *Connection) Health() []BackendHealth {
+	snapshots := make([]BackendHealth, len(c.backends))
+	for i, b := range c.backends {
+		snapshots[i] = b.health.snapshot(b.name)
+	}
+	return snapshots
+}
+
+// MetricsSources returns functions exposing health metrics of all backends.
+// Metric names are prefixed with backend names.
+func (c *Connection) MetricsSources() map[string]func() float64 {
+	sources := make(map[string]func() float64)
+
+	for _, b := range c.backends {
+		b := b
+
+		snapshot := func() BackendHealth {
+			return b.health.snapshot(b.name)
+		}
+
+		sources[b.name+"_requests_count"] = func() float64 {
+			return float64(snapshot().RequestsCount)
+		}
+		sources[b.name+"_failures_count"] = func() float64 {
+			return float64(snapshot().FailuresCount)
+		}
+		sources[b.name+"_disagreements_count"] = func() float64 {
+			return float64(snapshot().DisagreementsCount)
+		}
+		sources[b.name+"_consecutive_failures"] = func() float64 {
+			return float64(snapshot().ConsecutiveFailures)
+		}
+		sources[b.name+"_last_latency_seconds"] = func() float64 {
+			return snapshot().LastLatency.Seconds()
+		}
+		sources[b.name+"_healthy"] = func() float64 {
+			if snapshot().IsHealthy() {
+				return 1
+			}
+			return 0
+		}
+	}
+
+	return sources
+}
+
+// orderedBackends returns backends ordered by the number of consecutive
+// failures, so healthy backends are asked first. The configured order is
+// preserved among backends with the same number of failures.
+func (c *Connection) orderedBackends() []*backend {
+	ordered := make([]*backend, len(c.backends))
+	copy(ordered, c.backends)
+
+	failures := make(map[*backend]uint64, len(ordered))
+	for _, b := range ordered {
+		failures[b] = b.health.failures()
+	}
+
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return failures[ordered[i]] < failures[ordered[j]]
+	})
+
+	return ordered
+}
+
+// invoke executes the request against the given backend and records the
+// outcome in the backend health.
+func invoke[K any](
+	b *backend,
+	requestFn func(chain bitcoin.Chain) (K, error),
+) (K, error) {
+	startTime := time.Now()
+	result, err := requestFn(b.chain)
+	latency := time.Since(startTime)
+
+	if err != nil {
+		b.health.recordFailure(latency, err)
+		return result, err
+	}
+
+	b.health.recordSuccess(latency)
+	return result, nil
+}
+
+// failover executes the request against backends one by one, starting from
+// the healthiest one, and returns the first successful result.
+func failover[K any](
+	c *Connection,
+	requestName string,
+	requestFn func(chain bitcoin.Chain) (K, error),
+) (K, error) {
+	failures := make([]string, 0)
+
+	for _, b := range c.orderedBackends() {
+		result, err := invoke(b, requestFn)
+		if err == nil {
+			return result, nil
+		}
+
+		logger.Warnf(
+			"[%s] request to backend [%s] failed: [%v]",
+			requestName,
+			b.name,
+			err,
+		)
+		failures = append(failures, fmt.Sprintf("%s: %v", b.name, err))
+	}
+
+	var zero K
+	return zero, fmt.Errorf(
+		"[%s] request failed on all backends: [%s]",
+		requestName,
+		strings.Join(failures, "; "),
+	)
+}
+
+// agree executes the request against all backends concurrently and returns
+// the result as soon as the threshold of backends returned results with the
+// same key.
+func agree[K any](
+	c *Connection,
+	requestName string,
+	requestFn func(chain bitcoin.Chain) (K, error),
+	keyFn func(result K) string,
+) (K, error) {
+	return collect(
+		c,
+		requestName,
+		requestFn,
+		func(results []K) (K, func(K) bool, bool) {
+			groups := make(map[string]int)
+			for _, result := range results {
+				key := keyFn(result)
+				groups[key]++
+
+				if groups[key] >= c.config.Threshold {
+					return result, func(other K) bool {
+						return keyFn(other) == key
+					}, true
+				}
+			}
+
+			var zero K
+			return zero, nil, false
+		},
+	)
+}
+
+// lowest executes the request against all backends concurrently and returns
+// the lowest of the first results returned by threshold backends. It is meant
+// for requests whose results naturally differ a bit between backends, e.g.
+// because backends observe new blocks at slightly different moments, and
+// for which a lower result is the conservative one.
+func lowest(
+	c *Connection,
+	requestName string,
+	requestFn func(chain bitcoin.Chain) (uint, error),
+) (uint, error) {
+	return collect(
+		c,
+		requestName,
+		requestFn,
+		func(results []uint) (uint, func(uint) bool, bool) {
+			if len(results) < c.config.Threshold {
+				return 0, nil, false
+			}
+
+			lowest := results[0]
+			for _, result := range results[1:] {
+				if result < lowest {
+					lowest = result
+				}
+			}
+
+			return lowest, func(uint) bool { return true }, true
+		},
+	)
+}
+
+// outcome is a result of a request executed against a single backend.
+type outcome[K any] struct {
+	backend *backend
+	result  K
+	err     error
+}
+
+// collect executes the request against all backends concurrently and
+// passes successful results to the resolve function each time a new result
+// arrives. Once the resolve function reports a resolved result, the result
+// is returned. Backends whose results are not accepted by the function
+// returned along with the resolved result, including backends responding
+// later, are recorded as disagreeing.
+func collect[K any](
+	c *Connection,
+	requestName string,
+	requestFn func(chain bitcoin.Chain) (K, error),
+	resolveFn func(results []K) (K, func(K) bool, bool),
+) (K, error) {
+	outcomes := make(chan *outcome[K], len(c.backends))
+
+	for _, b := range c.backends {
+		go func(b *backend) {
+			result, err := invoke(b, requestFn)
+			outcomes <- &outcome[K]{b, result, err}
+		}(b)
+	}
+
+	checkAgreement := func(o *outcome[K], accepts func(K) bool) {
+		if o.err != nil || accepts(o.result) {
+			return
+		}
+
+		logger.Warnf(
+			"[%s] result returned by backend [%s] disagrees with results "+
+				"of other backends",
+			requestName,
+			o.backend.name,
+		)
+		o.backend.health.recordDisagreement()
+	}
+
+	received := make([]*outcome[K], 0)
+	results := make([]K, 0)
+	failures := make([]string, 0)
+
+	for i := 0; i < len(c.backends); i++ {
+		o := <-outcomes
+		received = append(received, o)
+
+		if o.err != nil {
+			logger.Warnf(
+				"[%s] request to backend [%s] failed: [%v]",
+				requestName,
+				o.backend.name,
+				o.err,
+			)
+			failures = append(
+				failures,
+				fmt.Sprintf("%s: %v", o.backend.name, o.err),
+			)
+			continue
+		}
+
+		results = append(results, o.result)
+
+		resolved, accepts, ok := resolveFn(results)
+		if !ok {
+			continue
+		}
+
+		for _, r := range received {
+			checkAgreement(r, accepts)
+		}
+
+		remaining := len(c.backends) - len(received)
+		go func() {
+			for j := 0; j < remaining; j++ {
+				checkAgreement(<-outcomes, accepts)
+			}
+		}()
+
+		return resolved, nil
+	}
+
+	var zero K
+	return zero, fmt.Errorf(
+		"[%s] request did not reach agreement of [%d] backends; "+
+			"[%d] backends succeeded; failures: [%s]",
+		requestName,
+		c.config.Threshold,
+		len(results),
+		strings.Join(failures, "; "),
+	)
+}
+
+// utxosKey returns a key identifying the set of unspent outputs regardless
+// of the order of outputs.
+func utxosKey(utxos []*bitcoin.UnspentTransactionOutput) string {
+	keys := make([]string, len(utxos))
+	for i, utxo := range utxos {
+		keys[i] = fmt.Sprintf(
+			"%s:%d:%d",
+			utxo.Outpoint.TransactionHash.Hex(bitcoin.InternalByteOrder),
+			utxo.Outpoint.OutputIndex,
+			utxo.Value,
+		)
+	}
+	sort.Strings(keys)
+	return strings.Join(keys, ",")
+}
+
+// transactionsKey returns a key identifying the list of transactions by their
+// hashes. If unordered is set, the key does not depend on the order of
+// transactions.
+func transactionsKey(transactions []*bitcoin.Transaction, unordered bool) string {
+	hashes := make([]bitcoin.Hash, len(transactions))
+	for i, transaction := range transactions {
+		hashes[i] = transaction.Hash()
+	}
+	return hashesKey(hashes, unordered)
+}
+
+// hashesKey returns a key identifying the list of hashes. If unordered is set,
+// the key does not depend on the order of hashes.
+func hashesKey(hashes []bitcoin.Hash, unordered bool) string {
+	keys := make([]string, len(hashes))
+	for i, hash := range hashes {
+		keys[i] = hash.Hex(bitcoin.InternalByteOrder)
+	}
+	if unordered {
+		sort.Strings(keys)
+	}
+	return strings.Join(keys, ",")
+}
@@ -0,0 +1,1036 @@
+package quorum
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-test/deep"
+
+	"github.com/keep-network/keep-core/internal/testutils"
+	"github.com/keep-network/keep-core/pkg/bitcoin"
+
+	testData "github.com/keep-network/keep-core/internal/testdata/bitcoin"
+)
+
+var errUnavailable = fmt.Errorf("backend unavailable")
+
+const (
+	testTransaction      = "input: P2PKH, output: P2SH, P2WPKH"
+	otherTestTransaction = "input: P2SH, output: P2WPKH"
+)
+
+// fakeChain is an in-memory bitcoin.Chain returning preconfigured results.
+// If err is set, all requests fail with it.
+type fakeChain struct {
+	mutex sync.Mutex
+	calls map[string]int
+
+	err   error
+	delay time.Duration
+
+	transaction   *bitcoin.Transaction
+	confirmations uint
+	blockHeight   uint
+	blockHeader   *bitcoin.BlockHeader
+	merkleProof   *bitcoin.TransactionMerkleProof
+	transactions  []*bitcoin.Transaction
+	txHashes      []bitcoin.Hash
+	mempool       []*bitcoin.Transaction
+	utxos         []*bitcoin.UnspentTransactionOutput
+	mempoolUtxos  []*bitcoin.UnspentTransactionOutput
+	broadcastErr  error
+	broadcasted   []*bitcoin.Transaction
+}
+
+func (fc *fakeChain) call(method string) error {
+	time.Sleep(fc.delay)
+
+	fc.mutex.Lock()
+	defer fc.mutex.Unlock()
+
+	if fc.calls == nil {
+		fc.calls = make(map[string]int)
+	}
+	fc.calls[method]++
+
+	return fc.err
+}
+
+func (fc *fakeChain) callsCount(method string) int {
+	fc.mutex.Lock()
+	defer fc.mutex.Unlock()
+
+	return fc.calls[method]
+}
+
+func (fc *fakeChain) GetTransaction(
+	transactionHash bitcoin.Hash,
+) (*bitcoin.Transaction, error) {
+	if err := fc.call("GetTransaction"); err != nil {
+		return nil, err
+	}
+	return fc.transaction, nil
+}
+
+func (fc *fakeChain) GetTransactionConfirmations(
+	transactionHash bitcoin.Hash,
+) (uint, error) {
+	if err := fc.call("GetTransactionConfirmations"); err != nil {
+		return 0, err
+	}
+	return fc.confirmations, nil
+}
+
+func (fc *fakeChain) BroadcastTransaction(
+	transaction *bitcoin.Transaction,
+) error {
+	if err := fc.call("BroadcastTransaction"); err != nil {
+		return err
+	}
+
+	fc.mutex.Lock()
+	defer fc.mutex.Unlock()
+
+	if fc.broadcastErr != nil {
+		return fc.broadcastErr
+	}
+	fc.broadcasted = append(fc.broadcasted, transaction)
+	return nil
+}
+
+func (fc *fakeChain) GetLatestBlockHeight() (uint, error) {
+	if err := fc.call("GetLatestBlockHeight"); err != nil {
+		return 0, err
+	}
+	return fc.blockHeight, nil
+}
+
+func (fc *fakeChain) GetBlockHeader(
+	blockHeight uint,
+) (*bitcoin.BlockHeader, error) {
+	if err := fc.call("GetBlockHeader"); err != nil {
+		return nil, err
+	}
+	return fc.blockHeader, nil
+}
+
+func (fc *fakeChain) GetTransactionMerkleProof(
+	transactionHash bitcoin.Hash,
+	blockHeight uint,
+) (*bitcoin.TransactionMerkleProof, error) {
+	if err := fc.call("GetTransactionMerkleProof"); err != nil {
+		return nil, err
+	}
+	return fc.merkleProof, nil
+}
+
+func (fc *fakeChain) GetTransactionsForPublicKeyHash(
+	publicKeyHash [20]byte,
+	limit int,
+) ([]*bitcoin.Transaction, error) {
+	if err := fc.call("GetTransactionsForPublicKeyHash"); err != nil {
+		return nil, err
+	}
+	return fc.transactions, nil
+}
+
+func (fc *fakeChain) GetTxHashesForPublicKeyHash(
+	publicKeyHash [20]byte,
+) ([]bitcoin.Hash, error) {
+	if err := fc.call("GetTxHashesForPublicKeyHash"); err != nil {
+		return nil, err
+	}
+	return fc.txHashes, nil
+}
+
+func (fc *fakeChain) GetMempoolForPublicKeyHash(
+	publicKeyHash [20]byte,
+) ([]*bitcoin.Transaction, error) {
+	if err := fc.call("GetMempoolForPublicKeyHash"); err != nil {
+		return nil, err
+	}
+	return fc.mempool, nil
+}
+
+func (fc *fakeChain) GetUtxosForPublicKeyHash(
+	publicKeyHash [20]byte,
+) ([]*bitcoin.UnspentTransactionOutput, error) {
+	if err := fc.call("GetUtxosForPublicKeyHash"); err != nil {
+		return nil, err
+	}
+	return fc.utxos, nil
+}
+
+func (fc *fakeChain) GetMempoolUtxosForPublicKeyHash(
+	publicKeyHash [20]byte,
+) ([]*bitcoin.UnspentTransactionOutput, error) {
+	if err := fc.call("GetMempoolUtxosForPublicKeyHash"); err != nil {
+		return nil, err
+	}
+	return fc.mempoolUtxos, nil
+}
+
+func (fc *fakeChain) EstimateSatPerVByteFee(blocks uint32) (int64, error) {
+	return 0, fmt.Errorf("not implemented")
+}
+
+func connect(
+	t *testing.T,
+	threshold int,
+	chains ...*fakeChain,
+) *Connection {
+	backends := make([]*Backend, len(chains))
+	for i, chain := range chains {
+		backends[i] = &Backend{
+			Name:  fmt.Sprintf("backend_%d", i),
+			Chain: chain,
+		}
+	}
+
+	connection, err := Connect(Config{Threshold: threshold}, backends)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return connection
+}
+
+func TestConnect(t *testing.T) {
+	chain := &fakeChain{}
+
+	var tests = map[string]struct {
+		threshold         int
+		backends          []*Backend
+		expectedThreshold int
+		expectedError     string
+	}{
+		"default threshold": {
+			backends: []*Backend{
+				{Name: "a", Chain: chain},
+				{Name: "b", Chain: chain},
+				{Name: "c", Chain: chain},
+				{Name: "d", Chain: chain},
+			},
+			expectedThreshold: 3,
+		},
+		"explicit threshold": {
+			threshold: 1,
+			backends: []*Backend{
+				{Name: "a", Chain: chain},
+				{Name: "b", Chain: chain},
+			},
+			expectedThreshold: 1,
+		},
+		"no backends": {
+			expectedError: "at least one backend must be provided",
+		},
+		"threshold above the number of backends": {
+			threshold: 3,
+			backends: []*Backend{
+				{Name: "a", Chain: chain},
+				{Name: "b", Chain: chain},
+			},
+			expectedError: "threshold [3] must be between 1 and the number " +
+				"of backends [2]",
+		},
+		"negative threshold": {
+			threshold: -1,
+			backends: []*Backend{
+				{Name: "a", Chain: chain},
+			},
+			expectedError: "threshold [-1] must be between 1 and the number " +
+				"of backends [1]",
+		},
+		"duplicate names": {
+			backends: []*Backend{
+				{Name: "a", Chain: chain},
+				{Name: "a", Chain: chain},
+			},
+			expectedError: "duplicate backend name [a]",
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			connection, err := Connect(
+				Config{Threshold: test.threshold},
+				test.backends,
+			)
+
+			if test.expectedError != "" {
+				if err == nil || err.Error() != test.expectedError {
+					t.Fatalf(
+						"unexpected error\nexpected: [%s]\nactual:   [%v]",
+						test.expectedError,
+						err,
+					)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			testutils.AssertIntsEqual(
+				t,
+				"threshold",
+				test.expectedThreshold,
+				connection.config.Threshold,
+			)
+		})
+	}
+}
+
+func TestGetTransaction_Failover(t *testing.T) {
+	transactions := testData.Transactions[bitcoin.Testnet]
+	expected := transactions[testTransaction]
+	other := transactions[otherTestTransaction]
+
+	unavailable := &fakeChain{err: errUnavailable}
+	lying := &fakeChain{transaction: &other.BitcoinTx}
+	honest := &fakeChain{transaction: &expected.BitcoinTx}
+
+	connection := connect(t, 1, unavailable, lying, honest)
+
+	transaction, err := connection.GetTransaction(expected.TxHash)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if diff := deep.Equal(&expected.BitcoinTx, transaction); diff != nil {
+		t.Errorf("unexpected transaction: %v", diff)
+	}
+
+	health := connection.Health()
+	testutils.AssertUintsEqual(
+		t,
+		"unavailable backend consecutive failures",
+		1,
+		health[0].ConsecutiveFailures,
+	)
+	testutils.AssertUintsEqual(
+		t,
+		"lying backend consecutive failures",
+		1,
+		health[1].ConsecutiveFailures,
+	)
+	testutils.AssertUintsEqual(
+		t,
+		"honest backend consecutive failures",
+		0,
+		health[2].ConsecutiveFailures,
+	)
+	testutils.AssertUintsEqual(
+		t,
+		"honest backend requests",
+		1,
+		health[2].RequestsCount,
+	)
+}
+
+func TestGetTransaction_AllBackendsFailed(t *testing.T) {
+	connection := connect(
+		t,
+		1,
+		&fakeChain{err: errUnavailable},
+		&fakeChain{err: errUnavailable},
+	)
+
+	_, err := connection.GetTransaction(
+		testData.Transactions[bitcoin.Testnet][testTransaction].TxHash,
+	)
+
+	expectedError := "[GetTransaction] request failed on all backends: " +
+		"[backend_0: backend unavailable; backend_1: backend unavailable]"
+	if err == nil || err.Error() != expectedError {
+		t.Fatalf(
+			"unexpected error\nexpected: [%s]\nactual:   [%v]",
+			expectedError,
+			err,
+		)
+	}
+}
+
+func TestFailover_PrefersHealthyBackends(t *testing.T) {
+	expected := testData.Transactions[bitcoin.Testnet][testTransaction]
+
+	first := &fakeChain{transaction: &expected.BitcoinTx}
+	second := &fakeChain{transaction: &expected.BitcoinTx}
+
+	connection := connect(t, 1, first, second)
+
+	first.err = errUnavailable
+	if _, err := connection.GetTransaction(expected.TxHash); err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertIntsEqual(
+		t,
+		"first backend calls",
+		1,
+		first.callsCount("GetTransaction"),
+	)
+	testutils.AssertIntsEqual(
+		t,
+		"second backend calls",
+		1,
+		second.callsCount("GetTransaction"),
+	)
+
+	// The first backend failed recently so the second one should be asked
+	// first, even though the first one has recovered in the meantime.
+	first.err = nil
+	if _, err := connection.GetTransaction(expected.TxHash); err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertIntsEqual(
+		t,
+		"first backend calls",
+		1,
+		first.callsCount("GetTransaction"),
+	)
+	testutils.AssertIntsEqual(
+		t,
+		"second backend calls",
+		2,
+		second.callsCount("GetTransaction"),
+	)
+}
+
+func TestGetUtxosForPublicKeyHash(t *testing.T) {
+	utxo := func(txHash string, outputIndex uint32, value int64) *bitcoin.UnspentTransactionOutput {
+		hash, err := bitcoin.NewHashFromString(txHash, bitcoin.ReversedByteOrder)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return &bitcoin.UnspentTransactionOutput{
+			Outpoint: &bitcoin.TransactionOutpoint{
+				TransactionHash: hash,
+				OutputIndex:     outputIndex,
+			},
+			Value: value,
+		}
+	}
+
+	utxo1 := utxo(
+		"f65bc5029251f0042aedb37f90dbb2bfb63a2e81694beef9cae5ec62e954c22e",
+		0,
+		100000,
+	)
+	utxo2 := utxo(
+		"2724545276df61f43f1e92c4b9f1dd3c9109595c022dbd9dc003efbad8ded38b",
+		1,
+		200000,
+	)
+	forged := utxo(
+		"44863a79ce2b8fec9792403d5048506e50ffa7338191db0e6c30d3d3358ea2f6",
+		0,
+		1000000000,
+	)
+
+	var tests = map[string]struct {
+		threshold             int
+		chains                []*fakeChain
+		expectedUtxos         []*bitcoin.UnspentTransactionOutput
+		expectedDisagreements []uint64
+		expectedError         string
+	}{
+		"all backends agree in different order": {
+			threshold: 3,
+			chains: []*fakeChain{
+				{utxos: []*bitcoin.UnspentTransactionOutput{utxo1, utxo2}},
+				{utxos: []*bitcoin.UnspentTransactionOutput{utxo2, utxo1}},
+				{utxos: []*bitcoin.UnspentTransactionOutput{utxo1, utxo2}},
+			},
+			expectedUtxos:         []*bitcoin.UnspentTransactionOutput{utxo1, utxo2},
+			expectedDisagreements: []uint64{0, 0, 0},
+		},
+		"threshold reached despite lying backend": {
+			threshold: 2,
+			chains: []*fakeChain{
+				{utxos: []*bitcoin.UnspentTransactionOutput{utxo1, forged}},
+				{utxos: []*bitcoin.UnspentTransactionOutput{utxo1, utxo2}},
+				{utxos: []*bitcoin.UnspentTransactionOutput{utxo1, utxo2}},
+			},
+			expectedUtxos:         []*bitcoin.UnspentTransactionOutput{utxo1, utxo2},
+			expectedDisagreements: []uint64{1, 0, 0},
+		},
+		"threshold reached despite unavailable backend": {
+			threshold: 2,
+			chains: []*fakeChain{
+				{utxos: []*bitcoin.UnspentTransactionOutput{utxo1}},
+				{err: errUnavailable},
+				{utxos: []*bitcoin.UnspentTransactionOutput{utxo1}},
+			},
+			expectedUtxos:         []*bitcoin.UnspentTransactionOutput{utxo1},
+			expectedDisagreements: []uint64{0, 0, 0},
+		},
+		"threshold not reached": {
+			threshold: 2,
+			chains: []*fakeChain{
+				{utxos: []*bitcoin.UnspentTransactionOutput{utxo1, forged}},
+				{err: errUnavailable},
+				{utxos: []*bitcoin.UnspentTransactionOutput{utxo1, utxo2}},
+			},
+			expectedError: "[GetUtxosForPublicKeyHash] request did not reach " +
+				"agreement of [2] backends; [2] backends succeeded; " +
+				"failures: [backend_1: backend unavailable]",
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			connection := connect(t, test.threshold, test.chains...)
+
+			utxos, err := connection.GetUtxosForPublicKeyHash([20]byte{})
+
+			if test.expectedError != "" {
+				if err == nil || err.Error() != test.expectedError {
+					t.Fatalf(
+						"unexpected error\nexpected: [%s]\nactual:   [%v]",
+						test.expectedError,
+						err,
+					)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if utxosKey(test.expectedUtxos) != utxosKey(utxos) {
+				t.Errorf("unexpected UTXOs")
+			}
+
+			assertDisagreements(t, connection, test.expectedDisagreements)
+		})
+	}
+}
+
+func TestGetMempoolUtxosForPublicKeyHash(t *testing.T) {
+	utxo := func(txHash string, value int64) *bitcoin.UnspentTransactionOutput {
+		hash, err := bitcoin.NewHashFromString(txHash, bitcoin.ReversedByteOrder)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return &bitcoin.UnspentTransactionOutput{
+			Outpoint: &bitcoin.TransactionOutpoint{
+				TransactionHash: hash,
+				OutputIndex:     0,
+			},
+			Value: value,
+		}
+	}
+
+	expected := []*bitcoin.UnspentTransactionOutput{
+		utxo(
+			"f65bc5029251f0042aedb37f90dbb2bfb63a2e81694beef9cae5ec62e954c22e",
+			100000,
+		),
+	}
+	forged := []*bitcoin.UnspentTransactionOutput{
+		utxo(
+			"44863a79ce2b8fec9792403d5048506e50ffa7338191db0e6c30d3d3358ea2f6",
+			1000000000,
+		),
+	}
+
+	connection := connect(
+		t,
+		2,
+		&fakeChain{mempoolUtxos: forged},
+		&fakeChain{mempoolUtxos: expected, delay: 10 * time.Millisecond},
+		&fakeChain{mempoolUtxos: expected, delay: 10 * time.Millisecond},
+	)
+
+	utxos, err := connection.GetMempoolUtxosForPublicKeyHash([20]byte{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if utxosKey(expected) != utxosKey(utxos) {
+		t.Errorf("unexpected UTXOs")
+	}
+
+	assertDisagreements(t, connection, []uint64{1, 0, 0})
+}
+
+func TestGetTransactionsForPublicKeyHash(t *testing.T) {
+	transactions := testData.Transactions[bitcoin.Testnet]
+	firstTransaction := transactions[testTransaction].BitcoinTx
+	secondTransaction := transactions[otherTestTransaction].BitcoinTx
+	first, second := &firstTransaction, &secondTransaction
+
+	var tests = map[string]struct {
+		threshold             int
+		chains                []*fakeChain
+		expectedTransactions  []*bitcoin.Transaction
+		expectedDisagreements []uint64
+		expectedError         string
+	}{
+		"threshold reached despite backend hiding a transaction": {
+			threshold: 2,
+			chains: []*fakeChain{
+				{transactions: []*bitcoin.Transaction{first}},
+				{
+					transactions: []*bitcoin.Transaction{first, second},
+					delay:        10 * time.Millisecond,
+				},
+				{
+					transactions: []*bitcoin.Transaction{first, second},
+					delay:        10 * time.Millisecond,
+				},
+			},
+			expectedTransactions:  []*bitcoin.Transaction{first, second},
+			expectedDisagreements: []uint64{1, 0, 0},
+		},
+		"threshold not reached due to different order": {
+			threshold: 2,
+			chains: []*fakeChain{
+				{transactions: []*bitcoin.Transaction{second, first}},
+				{err: errUnavailable},
+				{transactions: []*bitcoin.Transaction{first, second}},
+			},
+			expectedError: "[GetTransactionsForPublicKeyHash] request did not " +
+				"reach agreement of [2] backends; [2] backends succeeded; " +
+				"failures: [backend_1: backend unavailable]",
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			connection := connect(t, test.threshold, test.chains...)
+
+			actual, err := connection.GetTransactionsForPublicKeyHash(
+				[20]byte{},
+				5,
+			)
+
+			if test.expectedError != "" {
+				if err == nil || err.Error() != test.expectedError {
+					t.Fatalf(
+						"unexpected error\nexpected: [%s]\nactual:   [%v]",
+						test.expectedError,
+						err,
+					)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := deep.Equal(test.expectedTransactions, actual); diff != nil {
+				t.Errorf("unexpected transactions: %v", diff)
+			}
+
+			assertDisagreements(t, connection, test.expectedDisagreements)
+		})
+	}
+}
+
+func TestGetTxHashesForPublicKeyHash(t *testing.T) {
+	expected := testData.TransactionsForPublicKeyHash[bitcoin.Testnet].Transactions
+
+	connection := connect(
+		t,
+		2,
+		&fakeChain{txHashes: expected[:len(expected)-1]},
+		&fakeChain{txHashes: expected, delay: 10 * time.Millisecond},
+		&fakeChain{txHashes: expected, delay: 10 * time.Millisecond},
+	)
+
+	hashes, err := connection.GetTxHashesForPublicKeyHash([20]byte{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(expected, hashes) {
+		t.Errorf(
+			"unexpected hashes\nexpected: [%v]\nactual:   [%v]",
+			expected,
+			hashes,
+		)
+	}
+
+	assertDisagreements(t, connection, []uint64{1, 0, 0})
+}
+
+func TestGetMempoolForPublicKeyHash(t *testing.T) {
+	transactions := testData.Transactions[bitcoin.Testnet]
+	firstTransaction := transactions[testTransaction].BitcoinTx
+	secondTransaction := transactions[otherTestTransaction].BitcoinTx
+	first, second := &firstTransaction, &secondTransaction
+
+	connection := connect(
+		t,
+		2,
+		&fakeChain{mempool: []*bitcoin.Transaction{first}},
+		&fakeChain{
+			mempool: []*bitcoin.Transaction{second, first},
+			delay:   10 * time.Millisecond,
+		},
+		&fakeChain{
+			mempool: []*bitcoin.Transaction{first, second},
+			delay:   10 * time.Millisecond,
+		},
+	)
+
+	mempool, err := connection.GetMempoolForPublicKeyHash([20]byte{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertStringsEqual(
+		t,
+		"mempool transactions",
+		transactionsKey([]*bitcoin.Transaction{first, second}, true),
+		transactionsKey(mempool, true),
+	)
+
+	assertDisagreements(t, connection, []uint64{1, 0, 0})
+}
+
+func TestGetBlockHeader(t *testing.T) {
+	expected := testData.Blocks[bitcoin.Testnet].BlockHeader
+
+	forged := *expected
+	forged.MerkleRootHash = bitcoin.Hash{1}
+
+	connection := connect(
+		t,
+		2,
+		&fakeChain{blockHeader: &forged},
+		&fakeChain{blockHeader: expected, delay: 10 * time.Millisecond},
+		&fakeChain{blockHeader: expected, delay: 10 * time.Millisecond},
+	)
+
+	header, err := connection.GetBlockHeader(
+		testData.Blocks[bitcoin.Testnet].BlockHeight,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if diff := deep.Equal(expected, header); diff != nil {
+		t.Errorf("unexpected block header: %v", diff)
+	}
+
+	assertDisagreements(t, connection, []uint64{1, 0, 0})
+}
+
+func TestGetLatestBlockHeight(t *testing.T) {
+	var tests = map[string]struct {
+		threshold           int
+		chains              []*fakeChain
+		expectedBlockHeight uint
+		expectedError       string
+	}{
+		"backends lagging behind": {
+			threshold: 3,
+			chains: []*fakeChain{
+				{blockHeight: 101},
+				{blockHeight: 100},
+				{blockHeight: 102},
+			},
+			expectedBlockHeight: 100,
+		},
+		"moved ahead by a single backend": {
+			threshold: 2,
+			chains: []*fakeChain{
+				{blockHeight: 1000},
+				{blockHeight: 100, delay: 10 * time.Millisecond},
+				{blockHeight: 100, delay: 10 * time.Millisecond},
+			},
+			expectedBlockHeight: 100,
+		},
+		"threshold not reached": {
+			threshold: 2,
+			chains: []*fakeChain{
+				{blockHeight: 100},
+				{err: errUnavailable},
+			},
+			expectedError: "[GetLatestBlockHeight] request did not " +
+				"reach agreement of [2] backends; [1] backends succeeded; " +
+				"failures: [backend_1: backend unavailable]",
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			connection := connect(t, test.threshold, test.chains...)
+
+			blockHeight, err := connection.GetLatestBlockHeight()
+
+			if test.expectedError != "" {
+				if err == nil || err.Error() != test.expectedError {
+					t.Fatalf(
+						"unexpected error\nexpected: [%s]\nactual:   [%v]",
+						test.expectedError,
+						err,
+					)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			testutils.AssertUintsEqual(
+				t,
+				"block height",
+				uint64(test.expectedBlockHeight),
+				uint64(blockHeight),
+			)
+		})
+	}
+}
+
+func TestGetTransactionMerkleProof(t *testing.T) {
+	expected := testData.TxMerkleProofs[bitcoin.Testnet].MerkleProof
+
+	forged := &bitcoin.TransactionMerkleProof{
+		BlockHeight: expected.BlockHeight,
+		MerkleNodes: expected.MerkleNodes[1:],
+		Position:    expected.Position,
+	}
+
+	connection := connect(
+		t,
+		2,
+		&fakeChain{merkleProof: forged},
+		&fakeChain{merkleProof: expected},
+		&fakeChain{merkleProof: expected},
+	)
+
+	proof, err := connection.GetTransactionMerkleProof(
+		testData.TxMerkleProofs[bitcoin.Testnet].TxHash,
+		testData.TxMerkleProofs[bitcoin.Testnet].BlockHeight,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if diff := deep.Equal(expected, proof); diff != nil {
+		t.Errorf("unexpected merkle proof: %v", diff)
+	}
+
+	assertDisagreements(t, connection, []uint64{1, 0, 0})
+}
+
+func TestGetTransactionConfirmations(t *testing.T) {
+	var tests = map[string]struct {
+		threshold             int
+		chains                []*fakeChain
+		expectedConfirmations uint
+		expectedError         string
+	}{
+		"backends lagging behind": {
+			threshold: 3,
+			chains: []*fakeChain{
+				{confirmations: 11},
+				{confirmations: 10},
+				{confirmations: 12},
+			},
+			expectedConfirmations: 10,
+		},
+		"inflated by a single backend": {
+			threshold: 2,
+			chains: []*fakeChain{
+				{confirmations: 1000},
+				{confirmations: 10, delay: 10 * time.Millisecond},
+				{confirmations: 10, delay: 10 * time.Millisecond},
+			},
+			expectedConfirmations: 10,
+		},
+		"threshold not reached": {
+			threshold: 2,
+			chains: []*fakeChain{
+				{confirmations: 10},
+				{err: errUnavailable},
+			},
+			expectedError: "[GetTransactionConfirmations] request did not " +
+				"reach agreement of [2] backends; [1] backends succeeded; " +
+				"failures: [backend_1: backend unavailable]",
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			connection := connect(t, test.threshold, test.chains...)
+
+			confirmations, err := connection.GetTransactionConfirmations(
+				bitcoin.Hash{},
+			)
+
+			if test.expectedError != "" {
+				if err == nil || err.Error() != test.expectedError {
+					t.Fatalf(
+						"unexpected error\nexpected: [%s]\nactual:   [%v]",
+						test.expectedError,
+						err,
+					)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			testutils.AssertUintsEqual(
+				t,
+				"confirmations",
+				uint64(test.expectedConfirmations),
+				uint64(confirmations),
+			)
+		})
+	}
+}
+
+func TestBroadcastTransaction(t *testing.T) {
+	transaction := testData.Transactions[bitcoin.Testnet][testTransaction].BitcoinTx
+
+	t.Run("partially accepted", func(t *testing.T) {
+		rejecting := &fakeChain{broadcastErr: fmt.Errorf("rejected")}
+		unavailable := &fakeChain{err: errUnavailable}
+		accepting := &fakeChain{}
+
+		connection := connect(t, 1, rejecting, unavailable, accepting)
+
+		if err := connection.BroadcastTransaction(&transaction); err != nil {
+			t.Fatal(err)
+		}
+
+		for i, chain := range []*fakeChain{rejecting, unavailable, accepting} {
+			testutils.AssertIntsEqual(
+				t,
+				fmt.Sprintf("backend %d broadcast calls", i),
+				1,
+				chain.callsCount("BroadcastTransaction"),
+			)
+		}
+
+		if !reflect.DeepEqual(
+			[]*bitcoin.Transaction{&transaction},
+			accepting.broadcasted,
+		) {
+			t.Errorf("transaction not broadcast through accepting backend")
+		}
+	})
+
+	t.Run("rejected by all backends", func(t *testing.T) {
+		connection := connect(
+			t,
+			1,
+			&fakeChain{broadcastErr: fmt.Errorf("rejected")},
+			&fakeChain{err: errUnavailable},
+		)
+
+		err := connection.BroadcastTransaction(&transaction)
+
+		expectedError := "all backends failed to broadcast the transaction: " +
+			"[backend_0: rejected; backend_1: backend unavailable]"
+		if err == nil || err.Error() != expectedError {
+			t.Fatalf(
+				"unexpected error\nexpected: [%s]\nactual:   [%v]",
+				expectedError,
+				err,
+			)
+		}
+	})
+}
+
+func TestMetricsSources(t *testing.T) {
+	connection := connect(
+		t,
+		1,
+		&fakeChain{blockHeight: 100},
+		&fakeChain{err: errUnavailable},
+	)
+
+	transaction := testData.Transactions[bitcoin.Testnet][testTransaction].BitcoinTx
+	if err := connection.BroadcastTransaction(&transaction); err != nil {
+		t.Fatal(err)
+	}
+
+	sources := connection.MetricsSources()
+
+	expected := map[string]float64{
+		"backend_0_requests_count":       1,
+		"backend_0_failures_count":       0,
+		"backend_0_disagreements_count":  0,
+		"backend_0_consecutive_failures": 0,
+		"backend_0_healthy":              1,
+		"backend_1_requests_count":       1,
+		"backend_1_failures_count":       1,
+		"backend_1_disagreements_count":  0,
+		"backend_1_consecutive_failures": 1,
+		"backend_1_healthy":              0,
+	}
+
+	for name, value := range expected {
+		source, ok := sources[name]
+		if !ok {
+			t.Errorf("missing metric [%s]", name)
+			continue
+		}
+
+		if actual := source(); actual != value {
+			t.Errorf(
+				"unexpected value of metric [%s]\nexpected: [%v]\nactual:   [%v]",
+				name,
+				value,
+				actual,
+			)
+		}
+	}
+
+	for name := range sources {
+		if !strings.HasPrefix(name, "backend_0_") &&
+			!strings.HasPrefix(name, "backend_1_") {
+			t.Errorf("unexpected metric [%s]", name)
+		}
+	}
+}
+
+// assertDisagreements waits until disagreements of all backends are
+// recorded, as results of backends responding after the agreement was
+// reached are checked asynchronously.
+func assertDisagreements(
+	t *testing.T,
+	connection *Connection,
+	expected []uint64,
+) {
+	var actual []uint64
+
+	for i := 0; i < 100; i++ {
+		actual = make([]uint64, 0)
+		for _, health := range connection.Health() {
+			actual = append(actual, health.DisagreementsCount)
+		}
+
+		if reflect.DeepEqual(expected, actual) {
+			return
+		}
+
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	t.Errorf(
+		"unexpected disagreements\nexpected: [%v]\nactual:   [%v]",
+		expected,
+		actual,
+	)
+}
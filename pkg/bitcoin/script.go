@@ -19,6 +19,7 @@ const (
 	P2WPKHScript
 	P2SHScript
 	P2WSHScript
+	P2TRScript
 )
 
 func (st ScriptType) String() string {
@@ -31,6 +32,8 @@ func (st ScriptType) String() string {
 		return "P2SH"
 	case P2WSHScript:
 		return "P2WSH"
+	case P2TRScript:
+		return "P2TR"
 	default:
 		return "NonStandard"
 	}
@@ -147,8 +150,23 @@ func PayToScriptHash(scriptHash [20]byte) (Script, error) {
 		Script()
 }
 
+// PayToTaproot constructs a P2TR script for the provided 32-byte x-only
+// taproot output key. The function assumes the provided output key is valid.
+func PayToTaproot(outputKey [32]byte) (Script, error) {
+	return txscript.NewScriptBuilder().
+		AddOp(txscript.OP_1).
+		AddData(outputKey[:]).
+		Script()
+}
+
 // GetScriptType gets the ScriptType of the given Script.
 func GetScriptType(script Script) ScriptType {
+	// Witness version 1 scripts are not recognized by the underlying
+	// txscript package so, they must be checked explicitly.
+	if isPayToTaproot(script) {
+		return P2TRScript
+	}
+
 	switch txscript.GetScriptClass(script) {
 	case txscript.PubKeyHashTy:
 		return P2PKHScript
@@ -163,6 +181,28 @@ func GetScriptType(script Script) ScriptType {
 	}
 }
 
+// isPayToTaproot checks whether the given script is a P2TR script, i.e.
+// a witness version 1 script with a 32-byte witness program.
+func isPayToTaproot(script Script) bool {
+	return len(script) == 34 &&
+		script[0] == txscript.OP_1 &&
+		script[1] == txscript.OP_DATA_32
+}
+
+// ExtractTaprootOutputKey extracts the 32-byte x-only taproot output key
+// from a P2TR script.
+func ExtractTaprootOutputKey(script Script) ([32]byte, error) {
+	if !isPayToTaproot(script) {
+		return [32]byte{}, fmt.Errorf("not a P2TR script")
+	}
+
+	var outputKey [32]byte
+	// Omit the first two 0x5120 bytes.
+	copy(outputKey[:], script[2:])
+
+	return outputKey, nil
+}
+
 // ExtractPublicKeyHash extracts the public key hash from a P2WPKH or P2PKH
 // script.
 func ExtractPublicKeyHash(script Script) ([20]byte, error) {
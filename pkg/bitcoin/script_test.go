@@ -334,6 +334,33 @@ func TestPayToScriptHash(t *testing.T) {
 	testutils.AssertBytesEqual(t, expectedResult, result[:])
 }
 
+func TestPayToTaproot(t *testing.T) {
+	// The 32-byte x-only output key taken from the BIP-86 test vectors.
+	outputKeyBytes, err := hex.DecodeString(
+		"a60869f0dbcf1dc659c9cecbaf8050135ea9e8cdc487053f1dc6880949dc684c",
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var outputKey [32]byte
+	copy(outputKey[:], outputKeyBytes)
+
+	result, err := PayToTaproot(outputKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedResult, err := hex.DecodeString(
+		"5120a60869f0dbcf1dc659c9cecbaf8050135ea9e8cdc487053f1dc6880949dc684c",
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertBytesEqual(t, expectedResult, result[:])
+}
+
 func TestGetScriptType(t *testing.T) {
 	fromHex := func(hexString string) []byte {
 		bytes, err := hex.DecodeString(hexString)
@@ -363,6 +390,14 @@ func TestGetScriptType(t *testing.T) {
 			script:       fromHex("002086a303cdd2e2eab1d1679f1a813835dc5a1b65321077cdccaf08f98cbf04ca96"),
 			expectedType: P2WSHScript,
 		},
+		"p2tr script": {
+			script:       fromHex("5120a60869f0dbcf1dc659c9cecbaf8050135ea9e8cdc487053f1dc6880949dc684c"),
+			expectedType: P2TRScript,
+		},
+		"witness v1 script with non-standard program length": {
+			script:       fromHex("51148db50eb52063ea9d98b3eac91489a90f738986f6"),
+			expectedType: NonStandardScript,
+		},
 		"non-standard script": {
 			script: fromHex(
 				"14934b98637ca318a4d6e7ca6ffd1690b8e77df6377508f9f0c90d0003" +
@@ -440,3 +475,56 @@ func TestExtractPublicKeyHash(t *testing.T) {
 		})
 	}
 }
+
+func TestExtractTaprootOutputKey(t *testing.T) {
+	fromHex := func(hexString string) []byte {
+		bytes, err := hex.DecodeString(hexString)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return bytes
+	}
+
+	var outputKey [32]byte
+	copy(
+		outputKey[:],
+		fromHex("a60869f0dbcf1dc659c9cecbaf8050135ea9e8cdc487053f1dc6880949dc684c"),
+	)
+
+	var tests = map[string]struct {
+		script            Script
+		expectedOutputKey [32]byte
+		expectedErr       error
+	}{
+		"P2TR script": {
+			script:            fromHex("5120a60869f0dbcf1dc659c9cecbaf8050135ea9e8cdc487053f1dc6880949dc684c"),
+			expectedOutputKey: outputKey,
+		},
+		"P2WSH script": {
+			script:      fromHex("002086a303cdd2e2eab1d1679f1a813835dc5a1b65321077cdccaf08f98cbf04ca96"),
+			expectedErr: fmt.Errorf("not a P2TR script"),
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			actualOutputKey, err := ExtractTaprootOutputKey(test.script)
+
+			if !reflect.DeepEqual(test.expectedErr, err) {
+				t.Errorf(
+					"unexpected error\nexpected: %+v\nactual:   %+v\n",
+					test.expectedErr,
+					err,
+				)
+			}
+
+			if test.expectedOutputKey != actualOutputKey {
+				t.Errorf(
+					"unexpected output key\nexpected: 0x%x\nactual:   0x%x\n",
+					test.expectedOutputKey,
+					actualOutputKey,
+				)
+			}
+		})
+	}
+}
@@ -3,8 +3,12 @@ package bitcoin
 import (
 	"bytes"
 	"encoding/binary"
+	"fmt"
 
+	"github.com/btcsuite/btcd/mempool"
+	"github.com/btcsuite/btcd/txscript"
 	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcutil"
 )
 
 // TransactionSerializationFormat represents the Bitcoin transaction
@@ -183,6 +187,180 @@ func (t *Transaction) WitnessHash() Hash {
 	return ComputeHash(t.Serialize(Witness))
 }
 
+// VirtualSize returns the virtual size of the transaction in vbytes, as
+// defined by BIP-0141. For reference, see:
+// https://github.com/bitcoin/bips/blob/master/bip-0141.mediawiki#transaction-size-calculations
+func (t *Transaction) VirtualSize() int64 {
+	internal := newInternalTransaction()
+	internal.fromTransaction(t)
+
+	return mempool.GetTxVirtualSize(btcutil.NewTx(internal.MsgTx))
+}
+
+// SignatureHashPreimage computes the preimage of the SIGHASH_ALL signature
+// hash of the transaction input with the given index. The previousOutput
+// argument must be the output spent by that input. The double SHA-256 of the
+// returned preimage is the signature hash that was signed to unlock the
+// input. The returned witness flag denotes whether the preimage was built
+// according to BIP-0143 (witness inputs) or the legacy algorithm
+// (non-witness inputs). Only P2PKH, P2WPKH, P2SH, and P2WSH outputs are
+// supported. For reference, see:
+// https://github.com/bitcoin/bips/blob/master/bip-0143.mediawiki#specification
+func (t *Transaction) SignatureHashPreimage(
+	inputIndex int,
+	previousOutput *TransactionOutput,
+) ([]byte, bool, error) {
+	if inputIndex < 0 || inputIndex >= len(t.Inputs) {
+		return nil, false, fmt.Errorf(
+			"input index [%v] out of range",
+			inputIndex,
+		)
+	}
+
+	input := t.Inputs[inputIndex]
+
+	var scriptCode Script
+	var witness bool
+
+	switch GetScriptType(previousOutput.PublicKeyScript) {
+	case P2PKHScript:
+		scriptCode = previousOutput.PublicKeyScript
+	case P2WPKHScript:
+		// According to BIP-0143, the scriptCode of a P2WPKH input is the
+		// P2PKH script built using the same public key hash.
+		publicKeyHash, err := ExtractPublicKeyHash(
+			previousOutput.PublicKeyScript,
+		)
+		if err != nil {
+			return nil, false, fmt.Errorf(
+				"cannot extract public key hash: [%v]",
+				err,
+			)
+		}
+
+		scriptCode, err = PayToPublicKeyHash(publicKeyHash)
+		if err != nil {
+			return nil, false, fmt.Errorf(
+				"cannot build P2PKH script: [%v]",
+				err,
+			)
+		}
+		witness = true
+	case P2SHScript:
+		// The redeem script is the last item pushed by the signature script.
+		pushes, err := txscript.PushedData(input.SignatureScript)
+		if err != nil || len(pushes) == 0 {
+			return nil, false, fmt.Errorf(
+				"cannot extract redeem script from signature script",
+			)
+		}
+		scriptCode = pushes[len(pushes)-1]
+	case P2WSHScript:
+		// The witness script is the last item of the witness stack.
+		if len(input.Witness) == 0 {
+			return nil, false, fmt.Errorf(
+				"cannot extract witness script from empty witness",
+			)
+		}
+		scriptCode = input.Witness[len(input.Witness)-1]
+		witness = true
+	default:
+		return nil, false, fmt.Errorf(
+			"unsupported previous output script type",
+		)
+	}
+
+	if witness {
+		return t.witnessSignatureHashPreimage(
+			inputIndex,
+			scriptCode,
+			previousOutput.Value,
+		), true, nil
+	}
+
+	return t.legacySignatureHashPreimage(inputIndex, scriptCode), false, nil
+}
+
+// legacySignatureHashPreimage computes the SIGHASH_ALL signature hash
+// preimage of the given non-witness input. The preimage is the Standard
+// serialization of the transaction whose signature scripts are cleared,
+// except the signed input's one that is replaced by the scriptCode,
+// followed by the 4-byte little-endian sighash type.
+func (t *Transaction) legacySignatureHashPreimage(
+	inputIndex int,
+	scriptCode Script,
+) []byte {
+	internal := newInternalTransaction()
+	internal.fromTransaction(t)
+
+	for i, input := range internal.TxIn {
+		input.Witness = nil
+
+		if i == inputIndex {
+			input.SignatureScript = scriptCode
+		} else {
+			input.SignatureScript = nil
+		}
+	}
+
+	buffer := bytes.NewBuffer(
+		make([]byte, 0, internal.SerializeSizeStripped()+4),
+	)
+	// Writing to a bytes.Buffer never returns an error.
+	_ = internal.SerializeNoWitness(buffer)
+	_ = binary.Write(buffer, binary.LittleEndian, uint32(txscript.SigHashAll))
+
+	return buffer.Bytes()
+}
+
+// witnessSignatureHashPreimage computes the SIGHASH_ALL signature hash
+// preimage of the given witness input, as defined by BIP-0143.
+func (t *Transaction) witnessSignatureHashPreimage(
+	inputIndex int,
+	scriptCode Script,
+	value int64,
+) []byte {
+	var prevouts, sequences bytes.Buffer
+	for _, input := range t.Inputs {
+		prevouts.Write(input.Outpoint.TransactionHash[:])
+		_ = binary.Write(
+			&prevouts,
+			binary.LittleEndian,
+			input.Outpoint.OutputIndex,
+		)
+		_ = binary.Write(&sequences, binary.LittleEndian, input.Sequence)
+	}
+
+	var outputs bytes.Buffer
+	for _, output := range t.Outputs {
+		_ = wire.WriteTxOut(&outputs, 0, 0, &wire.TxOut{
+			Value:    output.Value,
+			PkScript: output.PublicKeyScript,
+		})
+	}
+
+	hashPrevouts := ComputeHash(prevouts.Bytes())
+	hashSequence := ComputeHash(sequences.Bytes())
+	hashOutputs := ComputeHash(outputs.Bytes())
+
+	input := t.Inputs[inputIndex]
+
+	var buffer bytes.Buffer
+	_ = binary.Write(&buffer, binary.LittleEndian, t.Version)
+	buffer.Write(hashPrevouts[:])
+	buffer.Write(hashSequence[:])
+	buffer.Write(input.Outpoint.TransactionHash[:])
+	_ = binary.Write(&buffer, binary.LittleEndian, input.Outpoint.OutputIndex)
+	_ = wire.WriteVarBytes(&buffer, 0, scriptCode)
+	_ = binary.Write(&buffer, binary.LittleEndian, value)
+	_ = binary.Write(&buffer, binary.LittleEndian, input.Sequence)
+	buffer.Write(hashOutputs[:])
+	_ = binary.Write(&buffer, binary.LittleEndian, t.Locktime)
+	_ = binary.Write(&buffer, binary.LittleEndian, uint32(txscript.SigHashAll))
+
+	return buffer.Bytes()
+}
+
 // TransactionOutpoint represents a Bitcoin transaction outpoint.
 // For reference, see:
 // https://developer.bitcoin.org/reference/transactions.html#outpoint-the-specific-part-of-a-specific-output
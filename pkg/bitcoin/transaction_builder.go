@@ -7,10 +7,19 @@ import (
 
 	"github.com/btcsuite/btcd/btcec"
 	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/mempool"
 	"github.com/btcsuite/btcd/txscript"
 	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcutil"
 )
 
+// replaceByFeeSequence is the input sequence number signaling that the
+// transaction is replaceable according to BIP-0125. Any sequence number
+// lower than 0xfffffffe signals replaceability. The 0xfffffffd value is
+// the highest one that also keeps the transaction's locktime enforced and
+// does not enable relative locktime defined by BIP-0068.
+const replaceByFeeSequence = wire.MaxTxInSequenceNum - 2
+
 // TransactionBuilder is a component that is responsible for the whole
 // transaction creation process. It assembles an unsigned transaction,
 // prepares it for signing, and applies the given signatures in order to
@@ -133,6 +142,17 @@ func (tb *TransactionBuilder) AddScriptHashInput(
 	return nil
 }
 
+// SignalReplaceByFee makes all inputs added so far signal that the
+// transaction can be replaced by a transaction paying a higher fee, as
+// described in BIP-0125. This function must be called after all inputs
+// are added and before ComputeSignatureHashes. For reference, see:
+// https://github.com/bitcoin/bips/blob/master/bip-0125.mediawiki
+func (tb *TransactionBuilder) SignalReplaceByFee() {
+	for _, input := range tb.internal.TxIn {
+		input.Sequence = replaceByFeeSequence
+	}
+}
+
 // getScript gets the locking script (PublicKeyScript) for the given unspent
 // transaction output.
 func (tb *TransactionBuilder) getScript(
@@ -309,6 +329,77 @@ func (tb *TransactionBuilder) TotalInputsValue() int64 {
 	return totalInputsValue
 }
 
+// TotalOutputsValue returns the total value of transaction outputs.
+func (tb *TransactionBuilder) TotalOutputsValue() int64 {
+	totalOutputsValue := int64(0)
+
+	for _, output := range tb.internal.TxOut {
+		totalOutputsValue += output.Value
+	}
+
+	return totalOutputsValue
+}
+
+// Outputs returns the outputs added to the transaction so far.
+func (tb *TransactionBuilder) Outputs() []*TransactionOutput {
+	outputs := make([]*TransactionOutput, len(tb.internal.TxOut))
+	for i, output := range tb.internal.TxOut {
+		outputs[i] = &TransactionOutput{
+			Value:           output.Value,
+			PublicKeyScript: output.PkScript,
+		}
+	}
+
+	return outputs
+}
+
+// VirtualSize estimates the virtual size of the transaction once all its
+// inputs are signed. This function must be called before AddSignatures.
+// Just as TransactionSizeEstimator, the builder assumes the greatest possible
+// byte size of signatures so, the estimation can slightly overshoot the
+// virtual size of the actual signed transaction.
+func (tb *TransactionBuilder) VirtualSize() (int64, error) {
+	msgTx := tb.internal.Copy()
+
+	for i, input := range msgTx.TxIn {
+		if tb.sigHashArgs[i].witness {
+			witness := wire.TxWitness{
+				signaturePlaceholder,
+				publicKeyPlaceholder,
+			}
+
+			// Keep the pre-filled witness script of P2WSH inputs.
+			if len(input.Witness) == 1 {
+				witness = append(witness, input.Witness[0])
+			}
+
+			input.Witness = witness
+		} else {
+			builder := txscript.NewScriptBuilder().
+				AddData(signaturePlaceholder).
+				AddData(publicKeyPlaceholder)
+
+			// Keep the pre-filled redeem script of P2SH inputs.
+			if len(input.SignatureScript) > 0 {
+				builder.AddData(input.SignatureScript)
+			}
+
+			script, err := builder.Script()
+			if err != nil {
+				return 0, fmt.Errorf(
+					"cannot build signature script for input [%v]: [%v]",
+					i,
+					err,
+				)
+			}
+
+			input.SignatureScript = script
+		}
+	}
+
+	return mempool.GetTxVirtualSize(btcutil.NewTx(msgTx)), nil
+}
+
 // inputSigHashArgs is a helper structure holding some arguments required to
 // compute a sighash for the given input.
 type inputSigHashArgs struct {
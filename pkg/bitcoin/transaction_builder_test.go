@@ -6,6 +6,8 @@ import (
 	"reflect"
 	"testing"
 
+	"github.com/btcsuite/btcd/wire"
+
 	"github.com/keep-network/keep-core/internal/testutils"
 )
 
@@ -213,6 +215,63 @@ func TestTransactionBuilder_AddOutput(t *testing.T) {
 	builder.AddOutput(output)
 
 	assertInternalOutput(t, builder, 0, output)
+
+	testutils.AssertIntsEqual(
+		t,
+		"total outputs value",
+		10000,
+		int(builder.TotalOutputsValue()),
+	)
+
+	outputs := builder.Outputs()
+	testutils.AssertIntsEqual(t, "outputs count", 1, len(outputs))
+	if !reflect.DeepEqual(output, outputs[0]) {
+		t.Errorf(
+			"unexpected output\nexpected: %v\nactual:   %v",
+			output,
+			outputs[0],
+		)
+	}
+}
+
+func TestTransactionBuilder_SignalReplaceByFee(t *testing.T) {
+	localChain := newLocalChain()
+
+	// https://live.blockcypher.com/btc-testnet/tx/f8eaf242a55ea15e602f9f990e33f67f99dfbe25d1802bbde63cc1caabf99668
+	inputTransaction := transactionFrom(t, "01000000000102bc187be612bc3db8cfcdec56b75e9bc0262ab6eacfe27cc1a699bacd53e3d07400000000c948304502210089a89aaf3fec97ac9ffa91cdff59829f0cb3ef852a468153e2c0e2b473466d2e022072902bb923ef016ac52e941ced78f816bf27991c2b73211e227db27ec200bc0a012103989d253b17a6a0f41838b84ff0d20e8898f9d7b1a98f2564da4cc29dcf8581d94c5c14934b98637ca318a4d6e7ca6ffd1690b8e77df6377508f9f0c90d000395237576a9148db50eb52063ea9d98b3eac91489a90f738986f68763ac6776a914e257eccafbc07c381642ce6e7e55120fb077fbed8804e0250162b175ac68ffffffffdc557e737b6688c5712649b86f7757a722dc3d42786f23b2fa826394dfec545c0000000000ffffffff01488a0000000000001600148db50eb52063ea9d98b3eac91489a90f738986f6000347304402203747f5ee31334b11ebac6a2a156b1584605de8d91a654cd703f9c8438634997402202059d680211776f93c25636266b02e059ed9fcc6209f7d3d9926c49a0d8750ed012103989d253b17a6a0f41838b84ff0d20e8898f9d7b1a98f2564da4cc29dcf8581d95c14934b98637ca318a4d6e7ca6ffd1690b8e77df6377508f9f0c90d000395237576a9148db50eb52063ea9d98b3eac91489a90f738986f68763ac6776a914e257eccafbc07c381642ce6e7e55120fb077fbed8804e0250162b175ac6800000000")
+	err := localChain.addTransaction(inputTransaction)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	builder := NewTransactionBuilder(localChain)
+
+	err = builder.AddPublicKeyHashInput(&UnspentTransactionOutput{
+		Outpoint: &TransactionOutpoint{
+			TransactionHash: inputTransaction.Hash(),
+			OutputIndex:     0,
+		},
+		Value: 35400,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertUintsEqual(
+		t,
+		"input sequence before signaling",
+		uint64(wire.MaxTxInSequenceNum),
+		uint64(builder.internal.TxIn[0].Sequence),
+	)
+
+	builder.SignalReplaceByFee()
+
+	testutils.AssertUintsEqual(
+		t,
+		"input sequence after signaling",
+		0xfffffffd,
+		uint64(builder.internal.TxIn[0].Sequence),
+	)
 }
 
 // The goal of this test is making sure that the TransactionBuilder can
@@ -415,6 +474,11 @@ func TestTransactionBuilder_Signing(t *testing.T) {
 				len(builder.sigHashes),
 			)
 
+			estimatedVirtualSize, err := builder.VirtualSize()
+			if err != nil {
+				t.Fatal(err)
+			}
+
 			transaction, err := builder.AddSignatures(test.signatures)
 			if err != nil {
 				t.Fatal(err)
@@ -425,6 +489,21 @@ func TestTransactionBuilder_Signing(t *testing.T) {
 				transaction.Serialize(),
 				hexToSlice(t, test.expectedSignedTransactionHex),
 			)
+
+			// The estimation assumes 72-byte signatures while actual
+			// signatures can be one byte shorter. A shorter signature
+			// reduces the virtual size by at most one vbyte.
+			actualVirtualSize := transaction.VirtualSize()
+			if estimatedVirtualSize < actualVirtualSize ||
+				estimatedVirtualSize > actualVirtualSize+int64(len(test.inputs)) {
+				t.Errorf(
+					"unexpected virtual size estimation\n"+
+						"actual virtual size: %v\n"+
+						"estimated:           %v",
+					actualVirtualSize,
+					estimatedVirtualSize,
+				)
+			}
 		})
 	}
 }
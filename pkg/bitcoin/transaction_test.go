@@ -4,6 +4,7 @@ import (
 	"crypto/ecdsa"
 	"crypto/elliptic"
 	"encoding/hex"
+	"fmt"
 	"reflect"
 	"testing"
 
@@ -165,6 +166,24 @@ func TestTransaction_WitnessHash(t *testing.T) {
 	)
 }
 
+func TestTransaction_VirtualSize(t *testing.T) {
+	transaction := transactionFixture(t)
+
+	// Virtual size is the transaction weight divided by 4 and rounded up.
+	// The weight is the Standard serialization size multiplied by 3 plus
+	// the Witness serialization size.
+	weight := 3*len(transaction.Serialize(Standard)) +
+		len(transaction.Serialize(Witness))
+	expectedVirtualSize := (weight + 3) / 4
+
+	testutils.AssertIntsEqual(
+		t,
+		"virtual size",
+		expectedVirtualSize,
+		int(transaction.VirtualSize()),
+	)
+}
+
 // transactionFixture returns a real testnet transaction:
 // https://live.blockcypher.com/btc-testnet/tx/435d4aff6d4bc34134877bd3213c17970142fdd04d4113d534120033b9eecb2e.
 //
@@ -172,6 +191,111 @@ func TestTransaction_WitnessHash(t *testing.T) {
 // so, those hashes can be checked in block explorers as is. Based on them, hexToHash
 // constructs proper instances of bitcoin.Hash and converts them to
 // bitcoin.InternalByteOrder for serialization.
+func TestTransaction_SignatureHashPreimage(t *testing.T) {
+	type previousOutput struct {
+		transactionHex string
+		outputIndex    uint32
+	}
+
+	var tests = map[string]struct {
+		transactionHex         string
+		previousOutputs        []previousOutput
+		expectedWitnesses      []bool
+		expectedSigHashesHexes []string
+	}{
+		// https://live.blockcypher.com/btc-testnet/tx/435d4aff6d4bc34134877bd3213c17970142fdd04d4113d534120033b9eecb2e
+		"P2WPKH, P2SH and P2WSH inputs with one P2WPKH output": {
+			transactionHex: "010000000001036896f9abcac13ce6bd2b80d125bedf997ff6330e999f2f605ea15ea542f2eaf80000000000ffffffffed0ae94da996c6f3b89dfe967675d4808251db93e81022ae9e038d06f92efed400000000c948304502210092327ddff69a2b8c7ae787c5d590a2f14586089e6339e942d56e82aa42052cd902204c0d1700ba1ac617da27fee032a57937c9607f0187199ed3c46954df845643d7012103989d253b17a6a0f41838b84ff0d20e8898f9d7b1a98f2564da4cc29dcf8581d94c5c14934b98637ca318a4d6e7ca6ffd1690b8e77df6377508f9f0c90d000395237576a9148db50eb52063ea9d98b3eac91489a90f738986f68763ac6776a914e257eccafbc07c381642ce6e7e55120fb077fbed8804e0250162b175ac68ffffffffe37f552fc23fa0032bfd00c8eef5f5c22bf85fe4c6e735857719ff8a4ff66eb80000000000ffffffff0180ed0000000000001600148db50eb52063ea9d98b3eac91489a90f738986f602483045022100baf754252d0d6a49aceba7eb0ec40b4cc568e8c659e168b96598a11cf56dc078022051117466ee998a3fc72221006817e8cfe9c2e71ad622ff811a0bf100d888d49c012103989d253b17a6a0f41838b84ff0d20e8898f9d7b1a98f2564da4cc29dcf8581d90003473044022014a535eb334656665ac69a678dbf7c019c4f13262e9ea4d195c61a00cd5f698d022023c0062913c4614bdff07f94475ceb4c585df53f71611776c3521ed8f8785913012103989d253b17a6a0f41838b84ff0d20e8898f9d7b1a98f2564da4cc29dcf8581d95c14934b98637ca318a4d6e7ca6ffd1690b8e77df6377508f9f0c90d000395237576a9148db50eb52063ea9d98b3eac91489a90f738986f68763ac6776a914e257eccafbc07c381642ce6e7e55120fb077fbed8804e0250162b175ac6800000000",
+			previousOutputs: []previousOutput{
+				{
+					transactionHex: "01000000000102bc187be612bc3db8cfcdec56b75e9bc0262ab6eacfe27cc1a699bacd53e3d07400000000c948304502210089a89aaf3fec97ac9ffa91cdff59829f0cb3ef852a468153e2c0e2b473466d2e022072902bb923ef016ac52e941ced78f816bf27991c2b73211e227db27ec200bc0a012103989d253b17a6a0f41838b84ff0d20e8898f9d7b1a98f2564da4cc29dcf8581d94c5c14934b98637ca318a4d6e7ca6ffd1690b8e77df6377508f9f0c90d000395237576a9148db50eb52063ea9d98b3eac91489a90f738986f68763ac6776a914e257eccafbc07c381642ce6e7e55120fb077fbed8804e0250162b175ac68ffffffffdc557e737b6688c5712649b86f7757a722dc3d42786f23b2fa826394dfec545c0000000000ffffffff01488a0000000000001600148db50eb52063ea9d98b3eac91489a90f738986f6000347304402203747f5ee31334b11ebac6a2a156b1584605de8d91a654cd703f9c8438634997402202059d680211776f93c25636266b02e059ed9fcc6209f7d3d9926c49a0d8750ed012103989d253b17a6a0f41838b84ff0d20e8898f9d7b1a98f2564da4cc29dcf8581d95c14934b98637ca318a4d6e7ca6ffd1690b8e77df6377508f9f0c90d000395237576a9148db50eb52063ea9d98b3eac91489a90f738986f68763ac6776a914e257eccafbc07c381642ce6e7e55120fb077fbed8804e0250162b175ac6800000000",
+					outputIndex:    0,
+				},
+				{
+					transactionHex: "01000000000101e37f552fc23fa0032bfd00c8eef5f5c22bf85fe4c6e735857719ff8a4ff66eb80100000000ffffffff02684200000000000017a9143ec459d0f3c29286ae5df5fcc421e2786024277e8742b7100000000000160014e257eccafbc07c381642ce6e7e55120fb077fbed0248304502210084eb60347b9aa48d9a53c6ab0fc2c2357a0df430d193507facfb2238e46f034502202a29d11e128dba3ff3a8ad9a1e820a3b58e89e37fa90d1cc2b3f05207599fef00121039d61d62dcd048d3f8550d22eb90b4af908db60231d117aeede04e7bc11907bfa00000000",
+					outputIndex:    0,
+				},
+				{
+					transactionHex: "01000000000101dc557e737b6688c5712649b86f7757a722dc3d42786f23b2fa826394dfec545c0100000000ffffffff02102700000000000022002086a303cdd2e2eab1d1679f1a813835dc5a1b65321077cdccaf08f98cbf04ca962cff100000000000160014e257eccafbc07c381642ce6e7e55120fb077fbed02473044022050759dde2c84bccf3c1502b0e33a6acb570117fd27a982c0c2991c9f9737508e02201fcba5d6f6c0ab780042138a9110418b3f589d8d09a900f20ee28cfcdb14d2970121039d61d62dcd048d3f8550d22eb90b4af908db60231d117aeede04e7bc11907bfa00000000",
+					outputIndex:    0,
+				},
+			},
+			expectedWitnesses: []bool{true, false, true},
+			expectedSigHashesHexes: []string{
+				"db0e8c898d3a59a23a70b3d910db720b5942445a24bce2dd96e0488a9de660a9",
+				"0730c379a7c60686255d4730afdf7ce321e83f5e4956346c19956b764a237831",
+				"126b2edd1b3c28dbff6cd48a9eb666558cb59d1008db60bb5f7bbf1a0d45e588",
+			},
+		},
+		// https://live.blockcypher.com/btc-testnet/tx/7831d0dfde7e160f3b9bb66c433710f0d3110d73ea78b9db65e81c091a6718a0
+		"P2WSH and P2PKH inputs with one P2WPKH output": {
+			transactionHex: "01000000000102173a201f597a2c8ccd7842303a6653bb87437fb08dae671731a075403b32a2fd0000000000ffffffffe19612be756bf7e740b47bec0e24845089ace48c78d473cb34949b3007c4a2c8000000006a47304402204382deb051f9f3e2b539e4bac2d1a50faf8d66bc7a3a3f3d286dabd96d92b58b02207c74c6aaf48e25d07e02bb4039606d77ecfd80c492c050ab2486af6027fc2d5a012103989d253b17a6a0f41838b84ff0d20e8898f9d7b1a98f2564da4cc29dcf8581d9ffffffff0108840000000000001600148db50eb52063ea9d98b3eac91489a90f738986f603483045022100c52bc876cdee80a3061ace3ffbce5e860942d444cd38e00e5f63fd8e818d7e7c022040a7017bb8213991697705e7092c481526c788a4731d06e582dc1c57bed7243b012103989d253b17a6a0f41838b84ff0d20e8898f9d7b1a98f2564da4cc29dcf8581d95c14934b98637ca318a4d6e7ca6ffd1690b8e77df6377508f9f0c90d000395237576a9148db50eb52063ea9d98b3eac91489a90f738986f68763ac6776a914e257eccafbc07c381642ce6e7e55120fb077fbed880448f2b262b175ac680000000000",
+			previousOutputs: []previousOutput{
+				{
+					transactionHex: "010000000001012d4e0b1ef0bf21eed32f6e2f11353b78534dcf21852d506f6f53b64bb5c6b4c50100000000ffffffff02384a000000000000220020b1f83e226979dc9fe74e87f6d303dbb08a27a1c7ce91664033f34c7f2d214cd76c45110000000000160014e257eccafbc07c381642ce6e7e55120fb077fbed02473044022072109558ed0ad905e3853df8a987bb1353c0b3935b30c568763820c711600657022051ebcb9f03897f9c508d66d1c587cd81d888994e3b0bf819a9ef3b2df934328c0121039d61d62dcd048d3f8550d22eb90b4af908db60231d117aeede04e7bc11907bfa00000000",
+					outputIndex:    0,
+				},
+				{
+					transactionHex: "01000000012d4e0b1ef0bf21eed32f6e2f11353b78534dcf21852d506f6f53b64bb5c6b4c500000000c84730440220590e998a5c28965fd442e700445a60c494124fdbb8aa39cc20c04f2aedadb1a602206acb2f852cd7adea65fe9209024e18d2d6ccac0b1e45c61d80c9bcd62f3e5a12012103989d253b17a6a0f41838b84ff0d20e8898f9d7b1a98f2564da4cc29dcf8581d94c5c14934b98637ca318a4d6e7ca6ffd1690b8e77df6377508f9f0c90d000395237576a9148db50eb52063ea9d98b3eac91489a90f738986f68763ac6776a914e257eccafbc07c381642ce6e7e55120fb077fbed880448f2b262b175ac68ffffffff0110400000000000001976a9148db50eb52063ea9d98b3eac91489a90f738986f688ac00000000",
+					outputIndex:    0,
+				},
+			},
+			expectedWitnesses: []bool{true, false},
+			expectedSigHashesHexes: []string{
+				"5c83f28b996fedb35ffb1e02e885599d6a1fe9ed7671e849e81ecc50a3020ea5",
+				"f75ee5a069404db9a8684159589c59b01c913135a47d36828b433019e46733f1",
+			},
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			transaction := transactionFrom(t, test.transactionHex)
+
+			for i, previous := range test.previousOutputs {
+				previousTransaction := transactionFrom(
+					t,
+					previous.transactionHex,
+				)
+
+				preimage, witness, err := transaction.SignatureHashPreimage(
+					i,
+					previousTransaction.Outputs[previous.outputIndex],
+				)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				testutils.AssertBoolsEqual(
+					t,
+					fmt.Sprintf("witness flag for input [%v]", i),
+					test.expectedWitnesses[i],
+					witness,
+				)
+
+				sigHash := ComputeHash(preimage)
+				testutils.AssertBytesEqual(
+					t,
+					hexToSlice(t, test.expectedSigHashesHexes[i]),
+					sigHash[:],
+				)
+			}
+		})
+	}
+}
+
+func TestTransaction_SignatureHashPreimage_InputIndexOutOfRange(t *testing.T) {
+	transaction := transactionFixture(t)
+
+	_, _, err := transaction.SignatureHashPreimage(
+		len(transaction.Inputs),
+		transaction.Outputs[0],
+	)
+	if err == nil {
+		t.Fatal("expected error")
+	}
+}
+
 func transactionFixture(t *testing.T) *Transaction {
 	tx := new(Transaction)
 
@@ -0,0 +1,183 @@
+package bitcoin
+
+import (
+	"fmt"
+)
+
+// VerifiedChain is a Chain implementation that does not trust block
+// headers, transaction confirmations and transaction merkle proofs
+// returned by the chain backend. Block headers and the chain tip are served
+// from the HeaderStore holding locally verified block headers. Merkle
+// proofs are verified against merkle roots of the verified block headers
+// and the confirmations are counted on the verified chain. This way, a
+// chain backend cannot make the client assemble a bogus SPV proof or
+// consider an unconfirmed transaction as confirmed. Remaining calls are
+// passed to the chain backend.
+type VerifiedChain struct {
+	Chain
+
+	headerStore *HeaderStore
+}
+
+// NewVerifiedChain creates a new VerifiedChain relying on block headers
+// verified by the given HeaderStore. The chain backend of the HeaderStore
+// is used for the remaining calls.
+func NewVerifiedChain(headerStore *HeaderStore) *VerifiedChain {
+	return &VerifiedChain{
+		Chain:       headerStore.chain,
+		headerStore: headerStore,
+	}
+}
+
+// GetLatestBlockHeight gets the height of the latest verified block (tip).
+// If the latest block was not determined, this function returns an error.
+// This is also the case while block headers are being synchronized for the
+// first time.
+func (vc *VerifiedChain) GetLatestBlockHeight() (uint, error) {
+	if err := vc.headerStore.syncIfIdle(); err != nil {
+		return 0, fmt.Errorf("cannot synchronize block headers: [%w]", err)
+	}
+
+	return vc.headerStore.TipHeight(), nil
+}
+
+// GetBlockHeader gets the verified block header for the given block height.
+// If the block with the given height was not found on the verified chain,
+// this function returns an error.
+func (vc *VerifiedChain) GetBlockHeader(
+	blockHeight uint,
+) (*BlockHeader, error) {
+	if blockHeight > vc.headerStore.TipHeight() {
+		if err := vc.headerStore.syncIfIdle(); err != nil {
+			return nil, fmt.Errorf(
+				"cannot synchronize block headers: [%w]",
+				err,
+			)
+		}
+	}
+
+	return vc.headerStore.HeaderAt(blockHeight)
+}
+
+// GetTransactionMerkleProof gets the Merkle proof for a given transaction.
+// The transaction's hash and the block the transaction was included in the
+// blockchain need to be provided. The proof is returned only if it proves
+// inclusion of the transaction in the verified block at the given height.
+func (vc *VerifiedChain) GetTransactionMerkleProof(
+	transactionHash Hash,
+	blockHeight uint,
+) (*TransactionMerkleProof, error) {
+	proof, err := vc.Chain.GetTransactionMerkleProof(
+		transactionHash,
+		blockHeight,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if proof.BlockHeight != blockHeight {
+		return nil, fmt.Errorf(
+			"merkle proof is for block at height [%d] instead of [%d]",
+			proof.BlockHeight,
+			blockHeight,
+		)
+	}
+
+	header, err := vc.GetBlockHeader(blockHeight)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := verifyMerkleProof(
+		transactionHash,
+		proof,
+		header.MerkleRootHash,
+	); err != nil {
+		return nil, fmt.Errorf(
+			"invalid merkle proof of transaction [%s] in block at "+
+				"height [%d]: [%v]",
+			transactionHash.Hex(ReversedByteOrder),
+			blockHeight,
+			err,
+		)
+	}
+
+	return proof, nil
+}
+
+// GetTransactionConfirmations gets the number of confirmations for the
+// transaction with the given transaction hash. If the transaction with the
+// given hash was not found on the chain, this function returns an error.
+// The block including the transaction is determined using the
+// confirmations reported by the chain backend. The inclusion is then
+// verified with a merkle proof against the verified block header and the
+// confirmations are counted on the verified chain.
+func (vc *VerifiedChain) GetTransactionConfirmations(
+	transactionHash Hash,
+) (uint, error) {
+	backendConfirmations, err := vc.Chain.GetTransactionConfirmations(
+		transactionHash,
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	if backendConfirmations == 0 {
+		return 0, nil
+	}
+
+	backendTipHeight, err := vc.Chain.GetLatestBlockHeight()
+	if err != nil {
+		return 0, err
+	}
+
+	if backendConfirmations > backendTipHeight+1 {
+		return 0, fmt.Errorf(
+			"chain backend reports [%d] confirmations with tip at height [%d]",
+			backendConfirmations,
+			backendTipHeight,
+		)
+	}
+
+	tipHeight, err := vc.GetLatestBlockHeight()
+	if err != nil {
+		return 0, err
+	}
+
+	// The chain backend may have observed a new block between the calls
+	// so the transaction may be included one block lower.
+	blockHeight := backendTipHeight - backendConfirmations + 1
+	candidateHeights := []uint{blockHeight}
+	if blockHeight > 0 {
+		candidateHeights = append(candidateHeights, blockHeight-1)
+	}
+
+	var lastErr error
+	for _, candidateHeight := range candidateHeights {
+		if candidateHeight > tipHeight {
+			lastErr = fmt.Errorf(
+				"block at height [%d] is above the verified tip [%d]",
+				candidateHeight,
+				tipHeight,
+			)
+			continue
+		}
+
+		if _, err := vc.GetTransactionMerkleProof(
+			transactionHash,
+			candidateHeight,
+		); err != nil {
+			lastErr = err
+			continue
+		}
+
+		return tipHeight - candidateHeight + 1, nil
+	}
+
+	return 0, fmt.Errorf(
+		"cannot verify inclusion of transaction [%s] in the verified "+
+			"chain: [%w]",
+		transactionHash.Hex(ReversedByteOrder),
+		lastErr,
+	)
+}
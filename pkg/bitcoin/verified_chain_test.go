@@ -0,0 +1,141 @@
+package bitcoin
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// newVerifiedTestChain returns a VerifiedChain on top of a local chain
+// reporting the given transaction data. The verified chain is anchored two
+// blocks above the block including the transaction so the testnet difficulty
+// of the following blocks can be determined from the test vectors and the
+// block including the transaction is verified against its successors.
+func newVerifiedTestChain(
+	t *testing.T,
+	transaction *Transaction,
+	confirmations uint,
+	merkleProof *TransactionMerkleProof,
+	tipHeight uint,
+) *VerifiedChain {
+	bitcoinChain := newLocalChain()
+	bitcoinChain.addTransaction(transaction)
+	bitcoinChain.addTransactionConfirmations(transaction.Hash(), confirmations)
+	bitcoinChain.addTransactionMerkleProof(transaction.Hash(), merkleProof)
+
+	// Use headers of all test vectors to provide headers preceding the
+	// checkpoint required to determine the testnet difficulty.
+	headers := testnetHeaders()
+	for height, header := range headers {
+		if height <= tipHeight {
+			bitcoinChain.addBlockHeader(height, header)
+		}
+	}
+
+	headerStore, err := NewHeaderStore(
+		Testnet,
+		bitcoinChain,
+		nil,
+		HeaderStoreConfig{
+			CheckpointHeight: merkleProof.BlockHeight + 2,
+			CheckpointHash: headers[merkleProof.BlockHeight+2].Hash().Hex(
+				ReversedByteOrder,
+			),
+		},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return NewVerifiedChain(headerStore)
+}
+
+func TestVerifiedChain_AssembleSpvProof(t *testing.T) {
+	for testName, test := range SpvProofData {
+		t.Run(testName, func(t *testing.T) {
+			transaction := transactionFrom(t, test.BitcoinChainData.TransactionHex)
+			confirmations := test.BitcoinChainData.AccumulatedTxConfirmations
+			merkleProof := test.BitcoinChainData.TransactionMerkleProof
+
+			verifiedChain := newVerifiedTestChain(
+				t,
+				transaction,
+				confirmations,
+				merkleProof,
+				merkleProof.BlockHeight+confirmations-1,
+			)
+
+			tx, proof, err := AssembleSpvProof(
+				transaction.Hash(),
+				test.RequiredConfirmations,
+				verifiedChain,
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(test.ExpectedProof, proof) {
+				t.Errorf(
+					"unexpected proof\nexpected: %v\nactual:   %v\n",
+					test.ExpectedProof,
+					proof,
+				)
+			}
+			if !reflect.DeepEqual(transaction, tx) {
+				t.Errorf(
+					"unexpected transaction\nexpected: %v\nactual:   %v\n",
+					transaction,
+					tx,
+				)
+			}
+		})
+	}
+}
+
+func TestVerifiedChain_GetTransactionMerkleProof_Forged(t *testing.T) {
+	test := SpvProofData["single input"]
+	transaction := transactionFrom(t, test.BitcoinChainData.TransactionHex)
+	confirmations := test.BitcoinChainData.AccumulatedTxConfirmations
+
+	forgedProof := *test.BitcoinChainData.TransactionMerkleProof
+	forgedProof.MerkleNodes = append([]string{}, forgedProof.MerkleNodes...)
+	forgedProof.MerkleNodes[0] = transaction.Hash().Hex(ReversedByteOrder)
+
+	verifiedChain := newVerifiedTestChain(
+		t,
+		transaction,
+		confirmations,
+		&forgedProof,
+		forgedProof.BlockHeight+confirmations-1,
+	)
+
+	_, err := verifiedChain.GetTransactionMerkleProof(
+		transaction.Hash(),
+		forgedProof.BlockHeight,
+	)
+	if err == nil || !strings.Contains(err.Error(), "invalid merkle proof") {
+		t.Fatalf("unexpected error: [%v]", err)
+	}
+}
+
+func TestVerifiedChain_GetTransactionConfirmations_Inflated(t *testing.T) {
+	test := SpvProofData["multiple inputs"]
+	transaction := transactionFrom(t, test.BitcoinChainData.TransactionHex)
+	confirmations := test.BitcoinChainData.AccumulatedTxConfirmations
+	merkleProof := test.BitcoinChainData.TransactionMerkleProof
+
+	// The chain backend claims the transaction was included two blocks
+	// before the block it was actually included in.
+	verifiedChain := newVerifiedTestChain(
+		t,
+		transaction,
+		confirmations+2,
+		merkleProof,
+		merkleProof.BlockHeight+confirmations-1,
+	)
+
+	_, err := verifiedChain.GetTransactionConfirmations(transaction.Hash())
+	if err == nil || !strings.Contains(err.Error(), "cannot verify inclusion") {
+		t.Fatalf("unexpected error: [%v]", err)
+	}
+}
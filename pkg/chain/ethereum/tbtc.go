@@ -957,6 +957,169 @@ func (tc *TbtcChain) DKGParameters() (*tbtc.DKGParameters, error) {
 	}, nil
 }
 
+func (tc *TbtcChain) GetInactivityClaimNonce(walletID [32]byte) (*big.Int, error) {
+	nonce, err := tc.walletRegistry.InactivityClaimNonce(walletID)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"cannot get inactivity claim nonce for wallet [0x%x]: [%v]",
+			walletID,
+			err,
+		)
+	}
+
+	return nonce, nil
+}
+
+// CalculateInactivityClaimHash calculates a 32-byte hash that is used
+// to produce a signature supporting the inactivity claim of the wallet
+// with the given public key. The inactiveMembersIndexes parameter must
+// contain indexes of members considered inactive. The heartbeatFailed
+// parameter indicates whether the claim is raised due to a wallet-wide
+// heartbeat failure. The nonce is the current inactivity claim nonce
+// of the wallet.
+func (tc *TbtcChain) CalculateInactivityClaimHash(
+	walletPublicKey *ecdsa.PublicKey,
+	nonce *big.Int,
+	inactiveMembersIndexes []group.MemberIndex,
+	heartbeatFailed bool,
+) (tbtc.InactivityClaimHash, error) {
+	walletPublicKeyBytes := elliptic.Marshal(
+		walletPublicKey.Curve,
+		walletPublicKey.X,
+		walletPublicKey.Y,
+	)
+	// Crop the 04 prefix as the contract expects an unprefixed 64-byte
+	// public key.
+	unprefixedWalletPublicKeyBytes := walletPublicKeyBytes[1:]
+
+	return calculateInactivityClaimHash(
+		tc.chainID,
+		nonce,
+		unprefixedWalletPublicKeyBytes,
+		sortMembersIndexes(inactiveMembersIndexes),
+		heartbeatFailed,
+	)
+}
+
+// calculateInactivityClaimHash computes the keccak256 hash for the given
+// inactivity claim parameters. It expects that the walletPublicKey is a
+// 64-byte uncompressed public key without the 04 prefix and
+// inactiveMembersIndexes slice is sorted in ascending order. Those
+// expectations are forced by the contract.
+func calculateInactivityClaimHash(
+	chainID *big.Int,
+	nonce *big.Int,
+	walletPublicKey []byte,
+	inactiveMembersIndexes []group.MemberIndex,
+	heartbeatFailed bool,
+) (tbtc.InactivityClaimHash, error) {
+	publicKeySize := 64
+
+	if len(walletPublicKey) != publicKeySize {
+		return tbtc.InactivityClaimHash{}, fmt.Errorf(
+			"wrong wallet public key length",
+		)
+	}
+
+	uint256Type, err := abi.NewType("uint256", "uint256", nil)
+	if err != nil {
+		return tbtc.InactivityClaimHash{}, err
+	}
+	bytesType, err := abi.NewType("bytes", "bytes", nil)
+	if err != nil {
+		return tbtc.InactivityClaimHash{}, err
+	}
+	uint256SliceType, err := abi.NewType("uint256[]", "uint256[]", nil)
+	if err != nil {
+		return tbtc.InactivityClaimHash{}, err
+	}
+	boolType, err := abi.NewType("bool", "bool", nil)
+	if err != nil {
+		return tbtc.InactivityClaimHash{}, err
+	}
+
+	bytes, err := abi.Arguments{
+		{Type: uint256Type},
+		{Type: uint256Type},
+		{Type: bytesType},
+		{Type: uint256SliceType},
+		{Type: boolType},
+	}.Pack(
+		chainID,
+		nonce,
+		walletPublicKey,
+		convertMembersIndexesToChainFormat(inactiveMembersIndexes),
+		heartbeatFailed,
+	)
+	if err != nil {
+		return tbtc.InactivityClaimHash{}, err
+	}
+
+	return tbtc.InactivityClaimHash(crypto.Keccak256Hash(bytes)), nil
+}
+
+func (tc *TbtcChain) SubmitInactivityClaim(
+	claim *tbtc.InactivityChainClaim,
+	nonce *big.Int,
+	groupMembers chain.OperatorIDs,
+) error {
+	signingMembersIndexes, signatures, err := convertSignaturesToChainFormat(
+		claim.Signatures,
+	)
+	if err != nil {
+		return fmt.Errorf(
+			"could not convert signatures to chain format: [%v]",
+			err,
+		)
+	}
+
+	_, err = tc.walletRegistry.NotifyOperatorInactivity(
+		ecdsaabi.EcdsaInactivityClaim{
+			WalletID: claim.WalletID,
+			InactiveMembersIndices: convertMembersIndexesToChainFormat(
+				sortMembersIndexes(claim.InactiveMembersIndexes),
+			),
+			HeartbeatFailed: claim.HeartbeatFailed,
+			Signatures:      signatures,
+			SigningMembersIndices: convertMembersIndexesToChainFormat(
+				signingMembersIndexes,
+			),
+		},
+		nonce,
+		groupMembers,
+	)
+
+	return err
+}
+
+// sortMembersIndexes returns a copy of the given members indexes slice,
+// sorted in ascending order.
+func sortMembersIndexes(
+	membersIndexes []group.MemberIndex,
+) []group.MemberIndex {
+	sorted := make([]group.MemberIndex, len(membersIndexes))
+	copy(sorted, membersIndexes)
+
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i] < sorted[j]
+	})
+
+	return sorted
+}
+
+// convertMembersIndexesToChainFormat converts the given members indexes
+// to the uint256 format expected by the chain.
+func convertMembersIndexesToChainFormat(
+	membersIndexes []group.MemberIndex,
+) []*big.Int {
+	converted := make([]*big.Int, len(membersIndexes))
+	for i, memberIndex := range membersIndexes {
+		converted[i] = big.NewInt(int64(memberIndex))
+	}
+
+	return converted
+}
+
 func (tc *TbtcChain) PastDepositRevealedEvents(
 	filter *tbtc.DepositRevealedEventFilter,
 ) ([]*tbtc.DepositRevealedEvent, error) {
@@ -1249,6 +1412,275 @@ func (tc *TbtcChain) GetDepositRequest(
 	}, true, nil
 }
 
+func (tc *TbtcChain) GetMovedFundsSweepRequest(
+	movingFundsTxHash bitcoin.Hash,
+	movingFundsTxOutputIndex uint32,
+) (*tbtc.MovedFundsSweepRequest, bool, error) {
+	// The moved funds sweep request key is built the same way as the
+	// deposit key, i.e. keccak256(movingFundsTxHash | movingFundsTxOutputIndex).
+	requestKey := buildDepositKey(movingFundsTxHash, movingFundsTxOutputIndex)
+
+	request, err := tc.bridge.MovedFundsSweepRequests(requestKey)
+	if err != nil {
+		return nil, false, fmt.Errorf(
+			"cannot get moved funds sweep request for key [0x%x]: [%v]",
+			requestKey.Text(16),
+			err,
+		)
+	}
+
+	// Request not found.
+	if request.CreatedAt == 0 {
+		return nil, false, nil
+	}
+
+	requestState, err := parseMovedFundsSweepRequestState(request.State)
+	if err != nil {
+		return nil, false, fmt.Errorf(
+			"cannot parse moved funds sweep request state: [%v]",
+			err,
+		)
+	}
+
+	return &tbtc.MovedFundsSweepRequest{
+		WalletPublicKeyHash: request.WalletPubKeyHash,
+		Value:               request.Value,
+		CreatedAt:           time.Unix(int64(request.CreatedAt), 0),
+		State:               requestState,
+	}, true, nil
+}
+
+func parseMovedFundsSweepRequestState(
+	value uint8,
+) (tbtc.MovedFundsSweepRequestState, error) {
+	switch value {
+	case 0:
+		return tbtc.MovedFundsSweepRequestStateUnknown, nil
+	case 1:
+		return tbtc.MovedFundsSweepRequestStatePending, nil
+	case 2:
+		return tbtc.MovedFundsSweepRequestStateProcessed, nil
+	case 3:
+		return tbtc.MovedFundsSweepRequestStateTimedOut, nil
+	default:
+		return 0, fmt.Errorf(
+			"unexpected moved funds sweep request state value: [%v]",
+			value,
+		)
+	}
+}
+
+func (tc *TbtcChain) OnFraudChallengeSubmitted(
+	handler func(event *tbtc.FraudChallengeSubmittedEvent),
+) subscription.EventSubscription {
+	onEvent := func(
+		walletPublicKeyHash [20]byte,
+		sighash [32]byte,
+		v uint8,
+		r [32]byte,
+		s [32]byte,
+		blockNumber uint64,
+	) {
+		handler(&tbtc.FraudChallengeSubmittedEvent{
+			WalletPublicKeyHash: walletPublicKeyHash,
+			Sighash:             sighash,
+			V:                   v,
+			R:                   r,
+			S:                   s,
+			BlockNumber:         blockNumber,
+		})
+	}
+
+	return tc.bridge.FraudChallengeSubmittedEvent(nil, nil).OnEvent(onEvent)
+}
+
+func (tc *TbtcChain) PastFraudChallengeSubmittedEvents(
+	filter *tbtc.FraudChallengeSubmittedEventFilter,
+) ([]*tbtc.FraudChallengeSubmittedEvent, error) {
+	var startBlock uint64
+	var endBlock *uint64
+	var walletPublicKeyHash [][20]byte
+
+	if filter != nil {
+		startBlock = filter.StartBlock
+		endBlock = filter.EndBlock
+		walletPublicKeyHash = filter.WalletPublicKeyHash
+	}
+
+	events, err := tc.bridge.PastFraudChallengeSubmittedEvents(
+		startBlock,
+		endBlock,
+		walletPublicKeyHash,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	convertedEvents := make([]*tbtc.FraudChallengeSubmittedEvent, 0)
+	for _, event := range events {
+		convertedEvent := &tbtc.FraudChallengeSubmittedEvent{
+			WalletPublicKeyHash: event.WalletPubKeyHash,
+			Sighash:             event.Sighash,
+			V:                   event.V,
+			R:                   event.R,
+			S:                   event.S,
+			BlockNumber:         event.Raw.BlockNumber,
+		}
+
+		convertedEvents = append(convertedEvents, convertedEvent)
+	}
+
+	sort.SliceStable(
+		convertedEvents,
+		func(i, j int) bool {
+			return convertedEvents[i].BlockNumber < convertedEvents[j].BlockNumber
+		},
+	)
+
+	return convertedEvents, err
+}
+
+func (tc *TbtcChain) PastMovingFundsCommitmentSubmittedEvents(
+	filter *tbtc.MovingFundsCommitmentSubmittedEventFilter,
+) ([]*tbtc.MovingFundsCommitmentSubmittedEvent, error) {
+	var startBlock uint64
+	var endBlock *uint64
+	var walletPublicKeyHash [][20]byte
+
+	if filter != nil {
+		startBlock = filter.StartBlock
+		endBlock = filter.EndBlock
+		walletPublicKeyHash = filter.WalletPublicKeyHash
+	}
+
+	events, err := tc.bridge.PastMovingFundsCommitmentSubmittedEvents(
+		startBlock,
+		endBlock,
+		walletPublicKeyHash,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	convertedEvents := make([]*tbtc.MovingFundsCommitmentSubmittedEvent, 0)
+	for _, event := range events {
+		convertedEvent := &tbtc.MovingFundsCommitmentSubmittedEvent{
+			WalletPublicKeyHash: event.WalletPubKeyHash,
+			TargetWallets:       event.TargetWallets,
+			Submitter:           chain.Address(event.Submitter.Hex()),
+			BlockNumber:         event.Raw.BlockNumber,
+		}
+
+		convertedEvents = append(convertedEvents, convertedEvent)
+	}
+
+	sort.SliceStable(
+		convertedEvents,
+		func(i, j int) bool {
+			return convertedEvents[i].BlockNumber < convertedEvents[j].BlockNumber
+		},
+	)
+
+	return convertedEvents, err
+}
+
+func (tc *TbtcChain) GetFraudChallenge(
+	walletPublicKey *ecdsa.PublicKey,
+	sighash [32]byte,
+) (*tbtc.FraudChallenge, bool, error) {
+	challengeKey := buildFraudChallengeKey(
+		unprefixedPublicKeyBytes(walletPublicKey),
+		sighash,
+	)
+
+	challenge, err := tc.bridge.FraudChallenges(challengeKey)
+	if err != nil {
+		return nil, false, fmt.Errorf(
+			"cannot get fraud challenge for key [0x%x]: [%v]",
+			challengeKey.Text(16),
+			err,
+		)
+	}
+
+	// Fraud challenge not found.
+	if challenge.ReportedAt == 0 {
+		return nil, false, nil
+	}
+
+	return &tbtc.FraudChallenge{
+		Challenger:    chain.Address(challenge.Challenger.Hex()),
+		DepositAmount: challenge.DepositAmount,
+		ReportedAt:    time.Unix(int64(challenge.ReportedAt), 0),
+		Resolved:      challenge.Resolved,
+	}, true, nil
+}
+
+func (tc *TbtcChain) GetFraudChallengeDefeatTimeout() (time.Duration, error) {
+	parameters, err := tc.bridge.FraudParameters()
+	if err != nil {
+		return 0, fmt.Errorf("cannot get fraud parameters: [%v]", err)
+	}
+
+	return time.Duration(parameters.FraudChallengeDefeatTimeout) * time.Second,
+		nil
+}
+
+func (tc *TbtcChain) DefeatFraudChallenge(
+	walletPublicKey *ecdsa.PublicKey,
+	preimage []byte,
+	witness bool,
+) error {
+	_, err := tc.bridge.DefeatFraudChallenge(
+		unprefixedPublicKeyBytes(walletPublicKey),
+		preimage,
+		witness,
+	)
+
+	return err
+}
+
+func (tc *TbtcChain) DefeatFraudChallengeWithHeartbeat(
+	walletPublicKey *ecdsa.PublicKey,
+	heartbeatMessage []byte,
+) error {
+	_, err := tc.bridge.DefeatFraudChallengeWithHeartbeat(
+		unprefixedPublicKeyBytes(walletPublicKey),
+		heartbeatMessage,
+	)
+
+	return err
+}
+
+// unprefixedPublicKeyBytes converts the given public key to the uncompressed
+// and unprefixed 64-byte form expected by the Bridge contract.
+func unprefixedPublicKeyBytes(publicKey *ecdsa.PublicKey) []byte {
+	publicKeyBytes := elliptic.Marshal(
+		publicKey.Curve,
+		publicKey.X,
+		publicKey.Y,
+	)
+	// Crop the 04 prefix.
+	return publicKeyBytes[1:]
+}
+
+// buildFraudChallengeKey builds the key of the fraud challenge submitted
+// against the wallet with the given unprefixed 64-byte public key for
+// the given signature hash. The key is computed as
+// keccak256(walletPublicKey | sighash).
+func buildFraudChallengeKey(
+	unprefixedWalletPublicKey []byte,
+	sighash [32]byte,
+) *big.Int {
+	challengeKey := crypto.Keccak256Hash(
+		append(
+			append([]byte{}, unprefixedWalletPublicKey...),
+			sighash[:]...,
+		),
+	)
+
+	return challengeKey.Big()
+}
+
 func (tc *TbtcChain) PastNewWalletRegisteredEvents(
 	filter *tbtc.NewWalletRegisteredEventFilter,
 ) ([]*tbtc.NewWalletRegisteredEvent, error) {
@@ -1372,6 +1804,46 @@ func (tc *TbtcChain) BuildRedemptionKey(
 	return buildRedemptionKey(walletPublicKeyHash, redeemerOutputScript)
 }
 
+func (tc *TbtcChain) OnDepositRevealed(
+	handler func(event *tbtc.DepositRevealedEvent),
+) subscription.EventSubscription {
+	onEvent := func(
+		fundingTxHash [32]byte,
+		fundingOutputIndex uint32,
+		depositor common.Address,
+		amount uint64,
+		blindingFactor [8]byte,
+		walletPublicKeyHash [20]byte,
+		refundPublicKeyHash [20]byte,
+		refundLocktime [4]byte,
+		vault common.Address,
+		blockNumber uint64,
+	) {
+		var vaultAddress *chain.Address
+		if vault != [20]byte{} {
+			v := chain.Address(vault.Hex())
+			vaultAddress = &v
+		}
+
+		handler(&tbtc.DepositRevealedEvent{
+			// The fundingTxHash is in the bitcoin.InternalByteOrder,
+			// just as bitcoin.Hash assumes.
+			FundingTxHash:       fundingTxHash,
+			FundingOutputIndex:  fundingOutputIndex,
+			Depositor:           chain.Address(depositor.Hex()),
+			Amount:              amount,
+			BlindingFactor:      blindingFactor,
+			WalletPublicKeyHash: walletPublicKeyHash,
+			RefundPublicKeyHash: refundPublicKeyHash,
+			RefundLocktime:      refundLocktime,
+			Vault:               vaultAddress,
+			BlockNumber:         blockNumber,
+		})
+	}
+
+	return tc.bridge.DepositRevealedEvent(nil, nil, nil).OnEvent(onEvent)
+}
+
 func (tc *TbtcChain) GetDepositParameters() (
 	dustThreshold uint64,
 	treasuryFeeDivisor uint64,
@@ -1595,46 +2067,6 @@ func buildDepositKey(
 	return depositKey.Big()
 }
 
-func (tc *TbtcChain) OnHeartbeatRequestSubmitted(
-	handler func(event *tbtc.HeartbeatRequestSubmittedEvent),
-) subscription.EventSubscription {
-	onEvent := func(
-		walletPubKeyHash [20]byte,
-		message []byte,
-		coordinator common.Address,
-		blockNumber uint64,
-	) {
-		handler(&tbtc.HeartbeatRequestSubmittedEvent{
-			WalletPublicKeyHash: walletPubKeyHash,
-			Message:             message,
-			Coordinator:         chain.Address(coordinator.Hex()),
-			BlockNumber:         blockNumber,
-		})
-	}
-
-	return tc.walletCoordinator.HeartbeatRequestSubmittedEvent(nil, nil).OnEvent(onEvent)
-}
-
-func (tc *TbtcChain) OnDepositSweepProposalSubmitted(
-	handler func(event *tbtc.DepositSweepProposalSubmittedEvent),
-) subscription.EventSubscription {
-	onEvent := func(
-		proposal tbtcabi.WalletCoordinatorDepositSweepProposal,
-		coordinator common.Address,
-		blockNumber uint64,
-	) {
-		handler(&tbtc.DepositSweepProposalSubmittedEvent{
-			Proposal:    convertDepositSweepProposalFromAbiType(proposal),
-			Coordinator: chain.Address(coordinator.Hex()),
-			BlockNumber: blockNumber,
-		})
-	}
-
-	return tc.walletCoordinator.
-		DepositSweepProposalSubmittedEvent(nil, nil).
-		OnEvent(onEvent)
-}
-
 func convertDepositSweepProposalFromAbiType(
 	proposal tbtcabi.WalletCoordinatorDepositSweepProposal,
 ) *tbtc.DepositSweepProposal {
@@ -1852,35 +2284,6 @@ func (tc *TbtcChain) GetDepositSweepMaxSize() (uint16, error) {
 	return tc.walletCoordinator.DepositSweepMaxSize()
 }
 
-func (tc *TbtcChain) OnRedemptionProposalSubmitted(
-	handler func(event *tbtc.RedemptionProposalSubmittedEvent),
-) subscription.EventSubscription {
-	onEvent := func(
-		proposal tbtcabi.WalletCoordinatorRedemptionProposal,
-		coordinator common.Address,
-		blockNumber uint64,
-	) {
-		tbtcProposal, err := convertRedemptionProposalFromAbiType(proposal)
-		if err != nil {
-			logger.Errorf(
-				"unexpected proposal in RedemptionProposalSubmitted event: [%v]",
-				err,
-			)
-			return
-		}
-
-		handler(&tbtc.RedemptionProposalSubmittedEvent{
-			Proposal:    tbtcProposal,
-			Coordinator: chain.Address(coordinator.Hex()),
-			BlockNumber: blockNumber,
-		})
-	}
-
-	return tc.walletCoordinator.
-		RedemptionProposalSubmittedEvent(nil, nil).
-		OnEvent(onEvent)
-}
-
 func (tc *TbtcChain) ValidateRedemptionProposal(
 	proposal *tbtc.RedemptionProposal,
 ) error {
@@ -1962,6 +2365,172 @@ func convertRedemptionProposalToAbiType(
 	}, nil
 }
 
+func (tc *TbtcChain) ValidateMovingFundsProposal(
+	walletMainUtxo *bitcoin.UnspentTransactionOutput,
+	proposal *tbtc.MovingFundsProposal,
+) error {
+	// The WalletCoordinator contract does not expose a validation function
+	// for moving funds proposals. The proposal is validated against the
+	// Bridge state according to the rules enforced by the Bridge upon the
+	// moving funds proof submission.
+	wallet, err := tc.GetWallet(proposal.WalletPublicKeyHash)
+	if err != nil {
+		return fmt.Errorf("cannot get source wallet's data: [%v]", err)
+	}
+
+	if wallet.State != tbtc.StateMovingFunds {
+		return fmt.Errorf(
+			"source wallet is in state [%v] instead of [%v]",
+			wallet.State,
+			tbtc.StateMovingFunds,
+		)
+	}
+
+	if wallet.PendingRedemptionsValue > 0 {
+		return fmt.Errorf(
+			"source wallet must handle all pending redemptions first",
+		)
+	}
+
+	if walletMainUtxo == nil {
+		return fmt.Errorf("source wallet main UTXO is required")
+	}
+
+	if wallet.MainUtxoHash != computeMainUtxoHash(walletMainUtxo) {
+		return fmt.Errorf("invalid source wallet main UTXO data")
+	}
+
+	if wallet.MovingFundsTargetWalletsCommitmentHash == [32]byte{} {
+		return fmt.Errorf("target wallets commitment not submitted yet")
+	}
+
+	if len(proposal.TargetWallets) == 0 {
+		return fmt.Errorf("target wallets list is empty")
+	}
+
+	targetWalletsCommitmentHash := computeMovingFundsTargetWalletsCommitmentHash(
+		proposal.TargetWallets,
+	)
+	if wallet.MovingFundsTargetWalletsCommitmentHash != targetWalletsCommitmentHash {
+		return fmt.Errorf("target wallets do not match the on-chain commitment")
+	}
+
+	parameters, err := tc.bridge.MovingFundsParameters()
+	if err != nil {
+		return fmt.Errorf("cannot get moving funds parameters: [%v]", err)
+	}
+
+	if uint64(walletMainUtxo.Value) < parameters.MovingFundsDustThreshold {
+		return fmt.Errorf(
+			"source wallet main UTXO value is below the moving funds " +
+				"dust threshold",
+		)
+	}
+
+	if proposal.MovingFundsTxFee == nil ||
+		proposal.MovingFundsTxFee.Sign() <= 0 {
+		return fmt.Errorf("proposed transaction fee must be greater than zero")
+	}
+
+	maxTotalFee := new(big.Int).SetUint64(parameters.MovingFundsTxMaxTotalFee)
+	if proposal.MovingFundsTxFee.Cmp(maxTotalFee) > 0 {
+		return fmt.Errorf("proposed transaction fee is too high")
+	}
+
+	return nil
+}
+
+// computeMovingFundsTargetWalletsCommitmentHash computes the hash of the
+// given target wallets list according to the on-chain Bridge rules, i.e.
+// keccak256(abi.encodePacked(targetWallets)). Elements of packed arrays
+// are padded to 32 bytes so each 20-byte wallet public key hash is
+// right-padded with zeros.
+func computeMovingFundsTargetWalletsCommitmentHash(
+	targetWallets [][20]byte,
+) [32]byte {
+	packed := make([]byte, 0, 32*len(targetWallets))
+	for _, targetWallet := range targetWallets {
+		paddedTargetWallet := make([]byte, 32)
+		copy(paddedTargetWallet, targetWallet[:])
+		packed = append(packed, paddedTargetWallet...)
+	}
+
+	return crypto.Keccak256Hash(packed)
+}
+
+func (tc *TbtcChain) ValidateMovedFundsSweepProposal(
+	walletMainUtxo *bitcoin.UnspentTransactionOutput,
+	proposal *tbtc.MovedFundsSweepProposal,
+) error {
+	// The WalletCoordinator contract does not expose a validation function
+	// for moved funds sweep proposals. The proposal is validated against the
+	// Bridge state according to the rules enforced by the Bridge upon the
+	// moved funds sweep proof submission.
+	wallet, err := tc.GetWallet(proposal.WalletPublicKeyHash)
+	if err != nil {
+		return fmt.Errorf("cannot get sweeping wallet's data: [%v]", err)
+	}
+
+	if wallet.State != tbtc.StateLive && wallet.State != tbtc.StateMovingFunds {
+		return fmt.Errorf(
+			"sweeping wallet is in state [%v] instead of [%v] or [%v]",
+			wallet.State,
+			tbtc.StateLive,
+			tbtc.StateMovingFunds,
+		)
+	}
+
+	if walletMainUtxo != nil {
+		if wallet.MainUtxoHash != computeMainUtxoHash(walletMainUtxo) {
+			return fmt.Errorf("invalid sweeping wallet main UTXO data")
+		}
+	} else if wallet.MainUtxoHash != [32]byte{} {
+		return fmt.Errorf("sweeping wallet main UTXO data must be provided")
+	}
+
+	request, found, err := tc.GetMovedFundsSweepRequest(
+		proposal.MovingFundsTxHash,
+		proposal.MovingFundsTxOutputIndex,
+	)
+	if err != nil {
+		return fmt.Errorf("cannot get moved funds sweep request: [%v]", err)
+	}
+
+	if !found {
+		return fmt.Errorf("moved funds sweep request does not exist")
+	}
+
+	if request.WalletPublicKeyHash != proposal.WalletPublicKeyHash {
+		return fmt.Errorf(
+			"moved funds sweep request belongs to another wallet",
+		)
+	}
+
+	if request.State != tbtc.MovedFundsSweepRequestStatePending {
+		return fmt.Errorf(
+			"moved funds sweep request is in state [%v] instead of [%v]",
+			request.State,
+			tbtc.MovedFundsSweepRequestStatePending,
+		)
+	}
+
+	if proposal.SweepTxFee == nil || proposal.SweepTxFee.Sign() <= 0 {
+		return fmt.Errorf("proposed transaction fee must be greater than zero")
+	}
+
+	parameters, err := tc.bridge.MovingFundsParameters()
+	if err != nil {
+		return fmt.Errorf("cannot get moving funds parameters: [%v]", err)
+	}
+
+	maxTotalFee := new(big.Int).SetUint64(parameters.MovedFundsSweepTxMaxTotalFee)
+	if proposal.SweepTxFee.Cmp(maxTotalFee) > 0 {
+		return fmt.Errorf("proposed transaction fee is too high")
+	}
+
+	return nil
+}
+
 func (tc *TbtcChain) GetRedemptionMaxSize() (uint16, error) {
 	return tc.walletCoordinator.RedemptionMaxSize()
 }
@@ -240,6 +240,41 @@ func TestCalculateDKGResultSignatureHash(t *testing.T) {
 	)
 }
 
+func TestCalculateInactivityClaimHash(t *testing.T) {
+	chainID := big.NewInt(1)
+	nonce := big.NewInt(7)
+
+	walletPublicKey, err := hex.DecodeString(
+		"989d253b17a6a0f41838b84ff0d20e8898f9d7b1a98f2564da4cc29dcf8581d9d" +
+			"218b65e7d91c752f7b22eaceb771a9af3a6f3d3f010a5d471a1aeef7d7713af",
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	inactiveMembersIndexes := []group.MemberIndex{2, 55}
+
+	hash, err := calculateInactivityClaimHash(
+		chainID,
+		nonce,
+		walletPublicKey,
+		inactiveMembersIndexes,
+		true,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedHash := "bdc9ecc65a4fe82ed9a986a988c9aff8cd01774af08e231b1f1525b5106c8157"
+
+	testutils.AssertStringsEqual(
+		t,
+		"hash",
+		expectedHash,
+		hex.EncodeToString(hash[:]),
+	)
+}
+
 func TestParseDkgResultValidationOutcome(t *testing.T) {
 	isValid, err := parseDkgResultValidationOutcome(
 		&struct {
@@ -338,6 +373,37 @@ func TestComputeMainUtxoHash(t *testing.T) {
 	testutils.AssertBytesEqual(t, expectedMainUtxoHash, mainUtxoHash[:])
 }
 
+func TestComputeMovingFundsTargetWalletsCommitmentHash(t *testing.T) {
+	fromHex := func(hexString string) [20]byte {
+		bytes, err := hex.DecodeString(hexString)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var result [20]byte
+		copy(result[:], bytes)
+
+		return result
+	}
+
+	targetWallets := [][20]byte{
+		fromHex("8db50eb52063ea9d98b3eac91489a90f738986f6"),
+		fromHex("2cd680318747b720d67bf4246eb7403b476adb34"),
+	}
+
+	commitmentHash := computeMovingFundsTargetWalletsCommitmentHash(
+		targetWallets,
+	)
+
+	expectedCommitmentHash, err := hex.DecodeString(
+		"dce1a45cd7cd7d1dfa4a806dc4a129e6766fd5d89959a566154c2832e18eb6cb",
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testutils.AssertBytesEqual(t, expectedCommitmentHash, commitmentHash[:])
+}
+
 // Test data based on: https://etherscan.io/tx/0x97c7a293127a604da77f7ef8daf4b19da2bf04327dd891b6d717eaef89bd8bca
 func TestBuildDepositKey(t *testing.T) {
 	fundingTxHash, err := bitcoin.NewHashFromString(
@@ -361,6 +427,36 @@ func TestBuildDepositKey(t *testing.T) {
 	)
 }
 
+func TestBuildFraudChallengeKey(t *testing.T) {
+	walletPublicKey, err := hex.DecodeString(
+		"989d253b17a6a0f41838b84ff0d20e8898f9d7b1a98f2564da4cc29dcf8581d9d" +
+			"218b65e7d91c752f7b22eaceb771a9af3a6f3d3f010a5d471a1aeef7d7713af",
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sighashBytes, err := hex.DecodeString(
+		"5c83f28b996fedb35ffb1e02e885599d6a1fe9ed7671e849e81ecc50a3020ea5",
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var sighash [32]byte
+	copy(sighash[:], sighashBytes)
+
+	challengeKey := buildFraudChallengeKey(walletPublicKey, sighash)
+
+	expectedChallengeKey := "644009b05b24dd9532928c75ea558dfdbb2da098cb855dfb93823a62d80b3970"
+	testutils.AssertStringsEqual(
+		t,
+		"fraud challenge key",
+		expectedChallengeKey,
+		challengeKey.Text(16),
+	)
+}
+
 func TestBuildRedemptionKey(t *testing.T) {
 	fromHex := func(hexString string) []byte {
 		b, err := hex.DecodeString(hexString)
@@ -6,6 +6,7 @@ import (
 	"math"
 	"math/big"
 	"sort"
+	"time"
 
 	"github.com/keep-network/keep-core/internal/hexutils"
 	"github.com/keep-network/keep-core/pkg/bitcoin"
@@ -25,6 +26,8 @@ func (wm *walletMaintainer) runDepositSweepTask(ctx context.Context) error {
 		wm.btcChain,
 		[20]byte{},
 		depositSweepMaxSize,
+		tbtc.DepositSweepRefundLocktimeSafetyMargin,
+		nil,
 	)
 	if err != nil {
 		return fmt.Errorf("failed to prepare deposits sweep proposal: [%w]", err)
@@ -46,6 +49,7 @@ func (wm *walletMaintainer) runDepositSweepTask(ctx context.Context) error {
 				walletPublicKeyHash,
 				0,
 				deposits,
+				tbtc.DepositSweepRefundLocktimeSafetyMargin,
 				false,
 			)
 		},
@@ -71,7 +75,11 @@ type Deposit struct {
 	Confirmations       uint
 }
 
-// FindDeposits finds deposits according to the given criteria.
+// FindDeposits finds deposits according to the given criteria. If
+// refundLocktimeSafetyMargin is non-zero, unswept deposits whose refund
+// locktime elapses earlier than the margin from now are skipped, as they can
+// be reclaimed by the depositor while the sweep is in flight. Deposits
+// matching any of the excludedDeposits references are skipped as well.
 func FindDeposits(
 	chain Chain,
 	btcChain bitcoin.Chain,
@@ -79,6 +87,8 @@ func FindDeposits(
 	maxNumberOfDeposits int,
 	skipSwept bool,
 	skipUnconfirmed bool,
+	refundLocktimeSafetyMargin time.Duration,
+	excludedDeposits []*DepositReference,
 ) ([]*Deposit, error) {
 	logger.Infof("reading revealed deposits from chain...")
 
@@ -102,6 +112,19 @@ func FindDeposits(
 		return depositRevealedEvents[i].BlockNumber < depositRevealedEvents[j].BlockNumber
 	})
 
+	type outpoint struct {
+		fundingTxHash      bitcoin.Hash
+		fundingOutputIndex uint32
+	}
+
+	excluded := make(map[outpoint]bool, len(excludedDeposits))
+	for _, excludedDeposit := range excludedDeposits {
+		excluded[outpoint{
+			fundingTxHash:      excludedDeposit.FundingTxHash,
+			fundingOutputIndex: excludedDeposit.FundingOutputIndex,
+		}] = true
+	}
+
 	logger.Infof("getting deposits details...")
 
 	resultSliceCapacity := len(depositRevealedEvents)
@@ -119,6 +142,18 @@ func FindDeposits(
 
 		depositKey := chain.BuildDepositKey(event.FundingTxHash, event.FundingOutputIndex)
 
+		if excluded[outpoint{
+			fundingTxHash:      event.FundingTxHash,
+			fundingOutputIndex: event.FundingOutputIndex,
+		}] {
+			logger.Warnf(
+				"deposit %d/%d with key [%s] is excluded; skipping it",
+				i+1, len(depositRevealedEvents),
+				hexutils.Encode(depositKey.Bytes()),
+			)
+			continue
+		}
+
 		depositRequest, found, err := chain.GetDepositRequest(
 			event.FundingTxHash,
 			event.FundingOutputIndex,
@@ -143,6 +178,32 @@ func FindDeposits(
 			continue
 		}
 
+		if !isSwept && refundLocktimeSafetyMargin > 0 {
+			refundLocktime := tbtc.RefundLocktimeTime(event.RefundLocktime)
+			timeToRefundLocktime := time.Until(refundLocktime)
+
+			if timeToRefundLocktime < refundLocktimeSafetyMargin {
+				logger.Warnf(
+					"deposit %d/%d with key [%s] will expire unswept; "+
+						"its refund locktime [%s] elapses in [%v] which "+
+						"is less than the safety margin [%v] so, the "+
+						"depositor can reclaim it",
+					i+1, len(depositRevealedEvents),
+					hexutils.Encode(depositKey.Bytes()),
+					refundLocktime.UTC().Format(time.RFC3339),
+					timeToRefundLocktime.Round(time.Second),
+					refundLocktimeSafetyMargin,
+				)
+				continue
+			}
+
+			logger.Debugf(
+				"deposit %d/%d refund locktime elapses in [%v]",
+				i+1, len(depositRevealedEvents),
+				timeToRefundLocktime.Round(time.Second),
+			)
+		}
+
 		confirmations, err := btcChain.GetTransactionConfirmations(event.FundingTxHash)
 		if err != nil {
 			logger.Errorf(
@@ -206,6 +267,13 @@ func FindDeposits(
 // the wallet that can be swept.
 // Deposits with insufficient number of funding transaction confirmations will
 // not be taken into consideration for sweeping.
+// Deposits whose refund locktime elapses earlier than refundLocktimeSafetyMargin
+// from now will not be taken into consideration either. A warning is logged
+// for each of them as they will likely expire unswept. A zero margin disables
+// this check.
+// Deposits matching any of the excludedDeposits references will not be taken
+// into consideration, e.g. deposits that failed validation against the
+// Bitcoin chain.
 // The result will not mix deposits for different wallets.
 //
 // TODO: Cache immutable data
@@ -214,6 +282,8 @@ func FindDepositsToSweep(
 	btcChain bitcoin.Chain,
 	walletPublicKeyHash [20]byte,
 	maxNumberOfDeposits uint16,
+	refundLocktimeSafetyMargin time.Duration,
+	excludedDeposits []*DepositReference,
 ) ([20]byte, []*DepositReference, error) {
 	logger.Infof("deposit sweep max size: %d", maxNumberOfDeposits)
 
@@ -225,6 +295,8 @@ func FindDepositsToSweep(
 			int(maxNumberOfDeposits),
 			true,
 			true,
+			refundLocktimeSafetyMargin,
+			excludedDeposits,
 		)
 		if err != nil {
 			return nil,
@@ -329,12 +401,15 @@ func FindDepositsToSweep(
 }
 
 // ProposeDepositsSweep handles deposit sweep proposal request submission.
+// The refundLocktimeSafetyMargin is used to validate the proposal, see
+// tbtc.ValidateDepositSweepProposal for details.
 func ProposeDepositsSweep(
 	chain Chain,
 	btcChain bitcoin.Chain,
 	walletPublicKeyHash [20]byte,
 	fee int64,
 	deposits []*DepositReference,
+	refundLocktimeSafetyMargin time.Duration,
 	dryRun bool,
 ) error {
 	if len(deposits) == 0 {
@@ -396,6 +471,7 @@ func ProposeDepositsSweep(
 		logger,
 		proposal,
 		tbtc.DepositSweepRequiredFundingTxConfirmations,
+		refundLocktimeSafetyMargin,
 		chain,
 		btcChain,
 	); err != nil {
@@ -1,8 +1,10 @@
 package wallet_test
 
 import (
+	"encoding/binary"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/go-test/deep"
 	"github.com/ipfs/go-log"
@@ -77,6 +79,8 @@ func TestFindDepositsToSweep(t *testing.T) {
 				btcChain,
 				scenario.WalletPublicKeyHash,
 				scenario.MaxNumberOfDeposits,
+				0,
+				nil,
 			)
 
 			if err != nil {
@@ -98,6 +102,182 @@ func TestFindDepositsToSweep(t *testing.T) {
 	}
 }
 
+func TestFindDepositsToSweep_RefundLocktimeSafetyMargin(t *testing.T) {
+	tbtcChain := walletmtr.NewLocalChain()
+	btcChain := walletmtr.NewLocalBitcoinChain()
+
+	walletPublicKeyHash := [20]byte{1, 2, 3}
+	refundLocktimeSafetyMargin := 24 * time.Hour
+
+	refundLocktimeIn := func(duration time.Duration) [4]byte {
+		var refundLocktime [4]byte
+		binary.LittleEndian.PutUint32(
+			refundLocktime[:],
+			uint32(time.Now().Add(duration).Unix()),
+		)
+		return refundLocktime
+	}
+
+	deposits := []struct {
+		fundingTxHash  bitcoin.Hash
+		revealBlock    uint64
+		refundLocktime [4]byte
+	}{
+		// Refund locktime already elapsed.
+		{bitcoin.Hash{1}, 100, refundLocktimeIn(-time.Hour)},
+		// Refund locktime within the safety margin.
+		{bitcoin.Hash{2}, 200, refundLocktimeIn(refundLocktimeSafetyMargin / 2)},
+		// Refund locktime beyond the safety margin.
+		{bitcoin.Hash{3}, 300, refundLocktimeIn(2 * refundLocktimeSafetyMargin)},
+	}
+
+	for _, deposit := range deposits {
+		tbtcChain.SetDepositRequest(
+			deposit.fundingTxHash,
+			0,
+			&tbtc.DepositChainRequest{SweptAt: time.Unix(0, 0)},
+		)
+		btcChain.SetTransaction(deposit.fundingTxHash, &bitcoin.Transaction{
+			Outputs: []*bitcoin.TransactionOutput{{}},
+		})
+		btcChain.SetTransactionConfirmations(
+			deposit.fundingTxHash,
+			tbtc.DepositSweepRequiredFundingTxConfirmations,
+		)
+
+		err := tbtcChain.AddPastDepositRevealedEvent(
+			&tbtc.DepositRevealedEventFilter{
+				WalletPublicKeyHash: [][20]byte{walletPublicKeyHash},
+			},
+			&tbtc.DepositRevealedEvent{
+				BlockNumber:         deposit.revealBlock,
+				WalletPublicKeyHash: walletPublicKeyHash,
+				FundingTxHash:       deposit.fundingTxHash,
+				FundingOutputIndex:  0,
+				RefundLocktime:      deposit.refundLocktime,
+			},
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	actualWallet, actualDeposits, err := walletmtr.FindDepositsToSweep(
+		tbtcChain,
+		btcChain,
+		walletPublicKeyHash,
+		10,
+		refundLocktimeSafetyMargin,
+		nil,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if actualWallet != walletPublicKeyHash {
+		t.Errorf(
+			"invalid wallet public key hash\nexpected: %s\nactual:   %s",
+			hexutils.Encode(walletPublicKeyHash[:]),
+			hexutils.Encode(actualWallet[:]),
+		)
+	}
+
+	expectedDeposits := []*walletmtr.DepositReference{
+		{
+			FundingTxHash:      bitcoin.Hash{3},
+			FundingOutputIndex: 0,
+			RevealBlock:        300,
+		},
+	}
+
+	if diff := deep.Equal(actualDeposits, expectedDeposits); diff != nil {
+		t.Errorf("invalid deposits: %v", diff)
+	}
+}
+
+func TestFindDepositsToSweep_ExcludedDeposits(t *testing.T) {
+	tbtcChain := walletmtr.NewLocalChain()
+	btcChain := walletmtr.NewLocalBitcoinChain()
+
+	walletPublicKeyHash := [20]byte{1, 2, 3}
+
+	deposits := []struct {
+		fundingTxHash bitcoin.Hash
+		revealBlock   uint64
+	}{
+		{bitcoin.Hash{1}, 100},
+		{bitcoin.Hash{2}, 200},
+		{bitcoin.Hash{3}, 300},
+	}
+
+	for _, deposit := range deposits {
+		tbtcChain.SetDepositRequest(
+			deposit.fundingTxHash,
+			0,
+			&tbtc.DepositChainRequest{SweptAt: time.Unix(0, 0)},
+		)
+		btcChain.SetTransaction(deposit.fundingTxHash, &bitcoin.Transaction{
+			Outputs: []*bitcoin.TransactionOutput{{}},
+		})
+		btcChain.SetTransactionConfirmations(
+			deposit.fundingTxHash,
+			tbtc.DepositSweepRequiredFundingTxConfirmations,
+		)
+
+		err := tbtcChain.AddPastDepositRevealedEvent(
+			&tbtc.DepositRevealedEventFilter{
+				WalletPublicKeyHash: [][20]byte{walletPublicKeyHash},
+			},
+			&tbtc.DepositRevealedEvent{
+				BlockNumber:         deposit.revealBlock,
+				WalletPublicKeyHash: walletPublicKeyHash,
+				FundingTxHash:       deposit.fundingTxHash,
+				FundingOutputIndex:  0,
+			},
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Excluded deposits must not take the slots of the remaining ones so,
+	// the max number of deposits is set to the number of non-excluded ones.
+	_, actualDeposits, err := walletmtr.FindDepositsToSweep(
+		tbtcChain,
+		btcChain,
+		walletPublicKeyHash,
+		2,
+		0,
+		[]*walletmtr.DepositReference{
+			{
+				FundingTxHash:      bitcoin.Hash{1},
+				FundingOutputIndex: 0,
+				RevealBlock:        100,
+			},
+		},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedDeposits := []*walletmtr.DepositReference{
+		{
+			FundingTxHash:      bitcoin.Hash{2},
+			FundingOutputIndex: 0,
+			RevealBlock:        200,
+		},
+		{
+			FundingTxHash:      bitcoin.Hash{3},
+			FundingOutputIndex: 0,
+			RevealBlock:        300,
+		},
+	}
+
+	if diff := deep.Equal(actualDeposits, expectedDeposits); diff != nil {
+		t.Errorf("invalid deposits: %v", diff)
+	}
+}
+
 func TestProposeDepositsSweep(t *testing.T) {
 	err := log.SetLogLevel("*", "DEBUG")
 	if err != nil {
@@ -158,6 +338,7 @@ func TestProposeDepositsSweep(t *testing.T) {
 				scenario.WalletPublicKeyHash,
 				scenario.SweepTxFee,
 				scenario.DepositsReferences(),
+				0,
 				false,
 			)
 
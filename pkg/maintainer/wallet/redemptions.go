@@ -548,6 +548,8 @@ func EstimateRedemptionFee(
 			sizeEstimator.AddScriptHashOutputs(1, false)
 		case bitcoin.P2WSHScript:
 			sizeEstimator.AddScriptHashOutputs(1, true)
+		case bitcoin.P2TRScript:
+			sizeEstimator.AddTaprootOutputs(1)
 		default:
 			return 0, fmt.Errorf("non-standard redeemer output script type")
 		}
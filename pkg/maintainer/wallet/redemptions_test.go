@@ -42,6 +42,46 @@ func TestEstimateRedemptionFee(t *testing.T) {
 	testutils.AssertIntsEqual(t, "fee", expectedFee, int(actualFee))
 }
 
+func TestEstimateRedemptionFee_Taproot(t *testing.T) {
+	btcChain := walletmtr.NewLocalBitcoinChain()
+	btcChain.SetEstimateSatPerVByteFee(1, 16)
+
+	redeemerOutputScript, err := bitcoin.PayToTaproot([32]byte{0x01})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	actualFee, err := walletmtr.EstimateRedemptionFee(
+		btcChain,
+		[]bitcoin.Script{redeemerOutputScript},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedFee := 2448 // transactionVirtualSize * satPerVByteFee = 153 * 16 = 2448
+	testutils.AssertIntsEqual(t, "fee", expectedFee, int(actualFee))
+}
+
+func TestEstimateRedemptionFee_NonStandardScript(t *testing.T) {
+	btcChain := walletmtr.NewLocalBitcoinChain()
+	btcChain.SetEstimateSatPerVByteFee(1, 16)
+
+	// OP_RETURN outputs cannot be used as redeemer output scripts.
+	redeemerOutputScript, err := hex.DecodeString("6a0401020304")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = walletmtr.EstimateRedemptionFee(
+		btcChain,
+		[]bitcoin.Script{redeemerOutputScript},
+	)
+	if err == nil {
+		t.Fatal("expected error")
+	}
+}
+
 func TestFindPendingRedemptions(t *testing.T) {
 	scenarios, err := test.LoadFindPendingRedemptionsTestScenario()
 	if err != nil {
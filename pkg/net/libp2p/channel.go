@@ -54,6 +54,10 @@ type channel struct {
 
 	name string
 
+	// cancelCtx cancels the context of channel's workers and is used
+	// when the channel is closed.
+	cancelCtx context.CancelFunc
+
 	clientIdentity *identity
 	peerStore      peerstore.Peerstore
 
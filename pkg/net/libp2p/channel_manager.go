@@ -118,8 +118,11 @@ func (cm *channelManager) newChannel(name string) (*channel, error) {
 		)
 	}
 
+	ctx, cancelCtx := context.WithCancel(cm.ctx)
+
 	channel := &channel{
 		name:                 name,
+		cancelCtx:            cancelCtx,
 		clientIdentity:       cm.identity,
 		peerStore:            cm.peerStore,
 		validator:            cm.pubsub,
@@ -131,11 +134,67 @@ func (cm *channelManager) newChannel(name string) (*channel, error) {
 		retransmissionTicker: cm.retransmissionTicker,
 	}
 
-	go channel.handleMessages(cm.ctx)
+	go channel.handleMessages(ctx)
 
 	return channel, nil
 }
 
+func (cm *channelManager) closeChannel(name string) error {
+	cm.channelsMutex.Lock()
+	channel, exists := cm.channels[name]
+	delete(cm.channels, name)
+	cm.channelsMutex.Unlock()
+
+	if !exists {
+		return nil
+	}
+
+	// Stop the channel's workers and cancel the topic subscription. The
+	// cancellation is processed by the pubsub event loop before any
+	// subsequent request so, the topic has no active subscription once
+	// Cancel returns.
+	channel.cancelCtx()
+	channel.subscription.Cancel()
+
+	channel.validatorMutex.Lock()
+	err := channel.validator.UnregisterTopicValidator(name)
+	channel.validatorMutex.Unlock()
+	if err != nil {
+		// That error can occur when no filter was set for the channel.
+		logger.Debugf(
+			"could not unregister topic validator for channel [%v]: [%v]",
+			name,
+			err,
+		)
+	}
+
+	cm.forwardersMutex.Lock()
+	_, forwarded := cm.forwarders[name]
+	cm.forwardersMutex.Unlock()
+
+	// The topic is still used by the forwarder so, it must stay open.
+	// It will be reused if the channel is opened again.
+	if forwarded {
+		return nil
+	}
+
+	cm.topicsMutex.Lock()
+	defer cm.topicsMutex.Unlock()
+
+	topic, exists := cm.topics[name]
+	if !exists {
+		return nil
+	}
+
+	if err := topic.Close(); err != nil {
+		return fmt.Errorf("could not close topic [%v]: [%v]", name, err)
+	}
+
+	delete(cm.topics, name)
+
+	return nil
+}
+
 func (cm *channelManager) newForwarder(name string, ttl time.Duration) error {
 	cm.forwardersMutex.Lock()
 	defer cm.forwardersMutex.Unlock()
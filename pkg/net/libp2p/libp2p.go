@@ -97,6 +97,12 @@ func (p *provider) BroadcastChannelFor(name string) (net.BroadcastChannel, error
 	return p.broadcastChannelManager.getChannel(name)
 }
 
+func (p *provider) CloseBroadcastChannel(name string) error {
+	p.channelManagerMutex.Lock()
+	defer p.channelManagerMutex.Unlock()
+	return p.broadcastChannelManager.closeChannel(name)
+}
+
 func (p *provider) Type() string {
 	return "libp2p"
 }
@@ -66,3 +66,33 @@ func broadcastMessage(name string, message net.Message) error {
 
 	return nil
 }
+
+// closeBroadcastChannels removes all channels with the given name that were
+// opened using the given operator public key. Removed channels no longer
+// receive messages and all their handlers are unregistered.
+func closeBroadcastChannels(
+	name string,
+	operatorPublicKey *operator.PublicKey,
+) {
+	broadcastChannelsMutex.Lock()
+	defer broadcastChannelsMutex.Unlock()
+
+	remainingChannels := make([]*localChannel, 0)
+	for _, channel := range broadcastChannels[name] {
+		if channel.operatorPublicKey == operatorPublicKey {
+			channel.messageHandlersMutex.Lock()
+			channel.messageHandlers = make([]*messageHandler, 0)
+			channel.messageHandlersMutex.Unlock()
+			continue
+		}
+
+		remainingChannels = append(remainingChannels, channel)
+	}
+
+	if len(remainingChannels) == 0 {
+		delete(broadcastChannels, name)
+		return
+	}
+
+	broadcastChannels[name] = remainingChannels
+}
@@ -233,6 +233,66 @@ loop:
 	}
 }
 
+func TestCloseBroadcastChannel(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	channelName := "channel name"
+
+	_, operatorPublicKey1, err := operator.GenerateKeyPair(DefaultCurve)
+	if err != nil {
+		t.Fatal(err)
+	}
+	provider1 := ConnectWithKey(operatorPublicKey1)
+	localChannel1, err := provider1.BroadcastChannelFor(channelName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	localChannel1.SetUnmarshaler(func() net.TaggedUnmarshaler {
+		return &mockNetMessage{}
+	})
+
+	_, localChannel2, err := initTestChannel(channelName)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	channel1Messages := make(chan net.Message, 1)
+	localChannel1.Recv(ctx, func(msg net.Message) {
+		channel1Messages <- msg
+	})
+
+	channel2Messages := make(chan net.Message, 1)
+	localChannel2.Recv(ctx, func(msg net.Message) {
+		channel2Messages <- msg
+	})
+
+	if err := provider1.CloseBroadcastChannel(channelName); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := localChannel2.Send(ctx, &mockNetMessage{}); err != nil {
+		t.Fatalf("failed to send message: [%v]", err)
+	}
+
+	select {
+	case <-channel2Messages:
+	case <-ctx.Done():
+		t.Fatal("message should be delivered to the open channel")
+	}
+
+	select {
+	case <-channel1Messages:
+		t.Fatal("message should not be delivered to the closed channel")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	// Closing a channel that does not exist should be a no-op.
+	if err := provider1.CloseBroadcastChannel("other channel"); err != nil {
+		t.Fatal(err)
+	}
+}
+
 func initTestChannel(channelName string) (*operator.PublicKey, net.BroadcastChannel, error) {
 	_, operatorPublicKey, err := operator.GenerateKeyPair(DefaultCurve)
 	if err != nil {
@@ -38,6 +38,11 @@ func (lp *localProvider) BroadcastChannelFor(name string) (net.BroadcastChannel,
 	return getBroadcastChannel(name, lp.operatorPublicKey), nil
 }
 
+func (lp *localProvider) CloseBroadcastChannel(name string) error {
+	closeBroadcastChannels(name, lp.operatorPublicKey)
+	return nil
+}
+
 func (lp *localProvider) Type() string {
 	return "local"
 }
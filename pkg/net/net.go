@@ -62,6 +62,13 @@ type Provider interface {
 	// channel name.
 	BroadcastChannelFor(name string) (BroadcastChannel, error)
 
+	// CloseBroadcastChannel closes the broadcast channel with the given name
+	// and cancels the underlying subscription. Handlers installed on the
+	// channel receive no more messages. A subsequent BroadcastChannelFor call
+	// for the same name opens a new channel. Closing a channel that does not
+	// exist is a no-op.
+	CloseBroadcastChannel(name string) error
+
 	// ConnectionManager returns the connection manager used by the provider.
 	ConnectionManager() ConnectionManager
 
@@ -25,6 +25,10 @@ const (
 	// lead to losing rewards as a result of inactivity but is not
 	// a protocol violation.
 	workDirName = "work"
+	// The key store archive directory is a place where the key store
+	// persistence moves archived data. It must match the directory used
+	// by the protected disk persistence handle.
+	keyStoreArchiveDirName = "archive"
 )
 
 // Storage is a disk persistent storage for the client.
@@ -77,6 +81,24 @@ func (s *Storage) InitializeKeyStorePersistence(dir string) (
 	return s.initializeKeyStorePersistence(s.keystoreDir, dir)
 }
 
+// InitializeKeyStoreArchivePersistence initializes a disk persistence over
+// the archive of the keystore persistence with the given name. The returned
+// handle reads data archived by the keystore persistence and allows removing
+// them, e.g. once they are restored.
+func (s *Storage) InitializeKeyStoreArchivePersistence(dir string) (
+	persistence.BasicHandle,
+	error,
+) {
+	if _, err := s.InitializeKeyStorePersistence(dir); err != nil {
+		return nil, err
+	}
+
+	return s.initializeWorkPersistence(
+		filepath.Join(s.keystoreDir, dir),
+		keyStoreArchiveDirName,
+	)
+}
+
 // InitializeWorkPersistence initializes a disk persistence under work parent.
 func (s *Storage) InitializeWorkPersistence(dir string) (
 	persistence.BasicHandle,
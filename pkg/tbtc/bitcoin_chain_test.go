@@ -35,6 +35,15 @@ func (lbc *localBitcoinChain) GetTransaction(
 		}
 	}
 
+	lbc.mempoolMutex.Lock()
+	defer lbc.mempoolMutex.Unlock()
+
+	for _, transaction := range lbc.mempool {
+		if transaction.Hash() == transactionHash {
+			return transaction, nil
+		}
+	}
+
 	return nil, fmt.Errorf("transaction not found")
 }
 
@@ -48,6 +57,15 @@ func (lbc *localBitcoinChain) GetTransactionConfirmations(
 		}
 	}
 
+	lbc.mempoolMutex.Lock()
+	defer lbc.mempoolMutex.Unlock()
+
+	for _, transaction := range lbc.mempool {
+		if transaction.Hash() == transactionHash {
+			return 0, nil
+		}
+	}
+
 	return 0, fmt.Errorf("transaction not found")
 }
 
@@ -71,7 +89,12 @@ func (lbc *localBitcoinChain) BroadcastTransaction(
 }
 
 func (lbc *localBitcoinChain) GetLatestBlockHeight() (uint, error) {
-	panic("not implemented")
+	lbc.transactionsMutex.Lock()
+	defer lbc.transactionsMutex.Unlock()
+
+	// Each confirmed transaction is assumed to be mined in a separate block,
+	// consistently with GetTransactionConfirmations.
+	return uint(len(lbc.transactions)), nil
 }
 
 func (lbc *localBitcoinChain) GetBlockHeader(
@@ -227,6 +250,13 @@ func (lbc *localBitcoinChain) GetMempoolUtxosForPublicKeyHash(
 	return nil, nil
 }
 
+func (lbc *localBitcoinChain) addToMempool(transaction *bitcoin.Transaction) {
+	lbc.mempoolMutex.Lock()
+	defer lbc.mempoolMutex.Unlock()
+
+	lbc.mempool = append(lbc.mempool, transaction)
+}
+
 func (lbc *localBitcoinChain) EstimateSatPerVByteFee(
 	blocks uint32,
 ) (int64, error) {
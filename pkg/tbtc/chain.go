@@ -181,6 +181,54 @@ type DKGParameters struct {
 	ApprovePrecedencePeriodBlocks uint64
 }
 
+// InactivityClaimChain defines the subset of the TBTC chain interface that
+// pertains to the inactivity claims raised by wallet signing groups.
+type InactivityClaimChain interface {
+	// GetInactivityClaimNonce returns the current nonce of inactivity claims
+	// of the wallet with the given ECDSA wallet ID. The nonce is incremented
+	// each time an inactivity claim of the wallet is accepted by the chain.
+	GetInactivityClaimNonce(walletID [32]byte) (*big.Int, error)
+
+	// CalculateInactivityClaimHash calculates a 32-byte hash that is used
+	// to produce a signature supporting the inactivity claim of the wallet
+	// with the given public key. The inactiveMembersIndexes parameter must
+	// contain indexes of members considered inactive. The heartbeatFailed
+	// parameter indicates whether the claim is raised due to a wallet-wide
+	// heartbeat failure. The nonce is the current inactivity claim nonce
+	// of the wallet.
+	CalculateInactivityClaimHash(
+		walletPublicKey *ecdsa.PublicKey,
+		nonce *big.Int,
+		inactiveMembersIndexes []group.MemberIndex,
+		heartbeatFailed bool,
+	) (InactivityClaimHash, error)
+
+	// SubmitInactivityClaim submits the inactivity claim to the chain. The
+	// nonce must be the nonce the claim was signed with and groupMembers
+	// must be the identifiers of all wallet signing group members, in the
+	// order of their member indexes.
+	SubmitInactivityClaim(
+		claim *InactivityChainClaim,
+		nonce *big.Int,
+		groupMembers chain.OperatorIDs,
+	) error
+}
+
+// InactivityClaimHash represents a hash of the inactivity claim signed by
+// signing group members supporting the claim. The algorithm used is specific
+// to the chain.
+type InactivityClaimHash [32]byte
+
+// InactivityChainClaim represents an inactivity claim submitted to the chain.
+type InactivityChainClaim struct {
+	WalletID               [32]byte
+	InactiveMembersIndexes []group.MemberIndex
+	HeartbeatFailed        bool
+	// Signatures holds signatures supporting the claim, keyed by indexes
+	// of signing group members who produced them.
+	Signatures map[group.MemberIndex][]byte
+}
+
 // BridgeChain defines the subset of the TBTC chain interface that pertains
 // specifically to the tBTC Bridge operations.
 type BridgeChain interface {
@@ -221,6 +269,144 @@ type BridgeChain interface {
 		fundingTxHash bitcoin.Hash,
 		fundingOutputIndex uint32,
 	) (*DepositChainRequest, bool, error)
+
+	// GetMovedFundsSweepRequest gets the on-chain moved funds sweep request
+	// for the given moving funds transaction hash and output index.
+	// The returned bool value indicates whether the request was found or not.
+	GetMovedFundsSweepRequest(
+		movingFundsTxHash bitcoin.Hash,
+		movingFundsTxOutputIndex uint32,
+	) (*MovedFundsSweepRequest, bool, error)
+
+	// PastMovingFundsCommitmentSubmittedEvents fetches past moving funds
+	// target wallets commitment events according to the provided filter or
+	// unfiltered if the filter is nil. Returned events are sorted by the block
+	// number in the ascending order, i.e. the latest event is at the end of
+	// the slice.
+	PastMovingFundsCommitmentSubmittedEvents(
+		filter *MovingFundsCommitmentSubmittedEventFilter,
+	) ([]*MovingFundsCommitmentSubmittedEvent, error)
+}
+
+// DepositRevealChain defines the subset of the TBTC chain interface that
+// pertains to the validation of deposits revealed to the tBTC Bridge.
+type DepositRevealChain interface {
+	// OnDepositRevealed registers a callback that is invoked when an
+	// on-chain notification of the deposit reveal is seen.
+	OnDepositRevealed(
+		func(event *DepositRevealedEvent),
+	) subscription.EventSubscription
+
+	// GetDepositParameters gets the current value of parameters relevant
+	// for the depositing process.
+	GetDepositParameters() (
+		dustThreshold uint64,
+		treasuryFeeDivisor uint64,
+		txMaxFee uint64,
+		revealAheadPeriod uint32,
+		err error,
+	)
+}
+
+// RedemptionMonitoringChain defines the subset of the TBTC chain interface
+// that pertains to the monitoring of pending redemption requests.
+type RedemptionMonitoringChain interface {
+	// PastRedemptionRequestedEvents fetches past redemption requested events
+	// according to the provided filter or unfiltered if the filter is nil.
+	// Returned events are sorted by the block number in the ascending order,
+	// i.e. the latest event is at the end of the slice.
+	PastRedemptionRequestedEvents(
+		filter *RedemptionRequestedEventFilter,
+	) ([]*RedemptionRequestedEvent, error)
+
+	// GetRedemptionParameters gets the current value of parameters relevant
+	// for the redemption process.
+	GetRedemptionParameters() (
+		dustThreshold uint64,
+		treasuryFeeDivisor uint64,
+		txMaxFee uint64,
+		txMaxTotalFee uint64,
+		timeout uint32,
+		timeoutSlashingAmount *big.Int,
+		timeoutNotifierRewardMultiplier uint32,
+		err error,
+	)
+}
+
+// FraudChain defines the subset of the TBTC chain interface that pertains
+// specifically to the defense against fraud challenges submitted to the
+// tBTC Bridge.
+type FraudChain interface {
+	// OnFraudChallengeSubmitted registers a callback that is invoked when
+	// an on-chain notification of the fraud challenge submission is seen.
+	OnFraudChallengeSubmitted(
+		func(event *FraudChallengeSubmittedEvent),
+	) subscription.EventSubscription
+
+	// PastFraudChallengeSubmittedEvents fetches past fraud challenge
+	// submission events according to the provided filter or unfiltered if
+	// the filter is nil. Returned events are sorted by the block number in
+	// the ascending order, i.e. the latest event is at the end of the slice.
+	PastFraudChallengeSubmittedEvents(
+		filter *FraudChallengeSubmittedEventFilter,
+	) ([]*FraudChallengeSubmittedEvent, error)
+
+	// GetFraudChallenge gets the on-chain fraud challenge submitted against
+	// the given wallet for the given signature hash. The returned bool value
+	// indicates whether the challenge was found or not.
+	GetFraudChallenge(
+		walletPublicKey *ecdsa.PublicKey,
+		sighash [32]byte,
+	) (*FraudChallenge, bool, error)
+
+	// GetFraudChallengeDefeatTimeout gets the time the wallet has to defeat
+	// a fraud challenge, counted from the moment the challenge was reported.
+	GetFraudChallengeDefeatTimeout() (time.Duration, error)
+
+	// DefeatFraudChallenge defeats the fraud challenge submitted against
+	// the given wallet by revealing the preimage of the challenged signature
+	// hash. The preimage must be the one of a Bitcoin transaction input
+	// spending a wallet UTXO. The witness flag denotes whether the preimage
+	// was built for a witness input.
+	DefeatFraudChallenge(
+		walletPublicKey *ecdsa.PublicKey,
+		preimage []byte,
+		witness bool,
+	) error
+
+	// DefeatFraudChallengeWithHeartbeat defeats the fraud challenge submitted
+	// against the given wallet by revealing the heartbeat message whose
+	// signature hash was challenged.
+	DefeatFraudChallengeWithHeartbeat(
+		walletPublicKey *ecdsa.PublicKey,
+		heartbeatMessage []byte,
+	) error
+}
+
+// FraudChallengeSubmittedEvent represents a fraud challenge submission event.
+type FraudChallengeSubmittedEvent struct {
+	WalletPublicKeyHash [20]byte
+	Sighash             [32]byte
+	V                   uint8
+	R                   [32]byte
+	S                   [32]byte
+	BlockNumber         uint64
+}
+
+// FraudChallengeSubmittedEventFilter is a component allowing to filter
+// FraudChallengeSubmittedEvent.
+type FraudChallengeSubmittedEventFilter struct {
+	StartBlock          uint64
+	EndBlock            *uint64
+	WalletPublicKeyHash [][20]byte
+}
+
+// FraudChallenge represents a fraud challenge stored on-chain.
+type FraudChallenge struct {
+	Challenger    chain.Address
+	DepositAmount *big.Int
+	ReportedAt    time.Time
+	Resolved      bool
 }
 
 // NewWalletRegisteredEvent represents a new wallet registered event.
@@ -317,32 +503,6 @@ type WalletChainData struct {
 // WalletCoordinatorChain defines the subset of the TBTC chain interface that
 // pertains specifically to the tBTC wallet coordination.
 type WalletCoordinatorChain interface {
-	// OnHeartbeatRequestSubmitted registers a callback that is invoked when
-	// an on-chain notification of the wallet heartbeat request is seen.
-	OnHeartbeatRequestSubmitted(
-		handler func(event *HeartbeatRequestSubmittedEvent),
-	) subscription.EventSubscription
-
-	// OnDepositSweepProposalSubmitted registers a callback that is invoked when
-	// an on-chain notification of the deposit sweep proposal submission is seen.
-	OnDepositSweepProposalSubmitted(
-		func(event *DepositSweepProposalSubmittedEvent),
-	) subscription.EventSubscription
-
-	// GetWalletLock gets the current wallet lock for the given wallet.
-	// Returned values represent the expiration time and the cause of the lock.
-	// The expiration time can be UNIX timestamp 0 which means there is no lock
-	// on the wallet at the given moment.
-	GetWalletLock(
-		walletPublicKeyHash [20]byte,
-	) (time.Time, WalletActionType, error)
-
-	// OnRedemptionProposalSubmitted registers a callback that is invoked when
-	// an on-chain notification of the redemption proposal submission is seen.
-	OnRedemptionProposalSubmitted(
-		func(event *RedemptionProposalSubmittedEvent),
-	) subscription.EventSubscription
-
 	// ValidateDepositSweepProposal validates the given deposit sweep proposal
 	// against the chain. It requires some additional data about the deposits
 	// that must be fetched externally. Returns an error if the proposal is
@@ -359,17 +519,25 @@ type WalletCoordinatorChain interface {
 	// against the chain. Returns an error if the proposal is not valid or
 	// nil otherwise.
 	ValidateRedemptionProposal(proposal *RedemptionProposal) error
-}
 
-// HeartbeatRequestSubmittedEvent represents a wallet heartbeat request
-// submitted to the chain.
-//
-// TODO: Remove this type and all related code.
-type HeartbeatRequestSubmittedEvent struct {
-	WalletPublicKeyHash [20]byte
-	Message             []byte
-	Coordinator         chain.Address
-	BlockNumber         uint64
+	// ValidateMovingFundsProposal validates the given moving funds proposal
+	// against the chain. The wallet's main UTXO must be provided as it
+	// is the only input of the moving funds transaction. Returns an error
+	// if the proposal is not valid or nil otherwise.
+	ValidateMovingFundsProposal(
+		walletMainUtxo *bitcoin.UnspentTransactionOutput,
+		proposal *MovingFundsProposal,
+	) error
+
+	// ValidateMovedFundsSweepProposal validates the given moved funds sweep
+	// proposal against the chain. The wallet's main UTXO must be provided
+	// if the wallet has one as it becomes an input of the moved funds sweep
+	// transaction. Returns an error if the proposal is not valid or nil
+	// otherwise.
+	ValidateMovedFundsSweepProposal(
+		walletMainUtxo *bitcoin.UnspentTransactionOutput,
+		proposal *MovedFundsSweepProposal,
+	) error
 }
 
 // DepositSweepProposalSubmittedEvent represents a deposit sweep proposal
@@ -429,6 +597,23 @@ type RedemptionRequestedEvent struct {
 	BlockNumber          uint64
 }
 
+// MovingFundsCommitmentSubmittedEvent represents a moving funds target
+// wallets commitment submission event.
+type MovingFundsCommitmentSubmittedEvent struct {
+	WalletPublicKeyHash [20]byte
+	TargetWallets       [][20]byte
+	Submitter           chain.Address
+	BlockNumber         uint64
+}
+
+// MovingFundsCommitmentSubmittedEventFilter is a component allowing to
+// filter MovingFundsCommitmentSubmittedEvent.
+type MovingFundsCommitmentSubmittedEventFilter struct {
+	StartBlock          uint64
+	EndBlock            *uint64
+	WalletPublicKeyHash [][20]byte
+}
+
 // RedemptionRequestedEventFilter is a component allowing to filter RedemptionRequestedEvent.
 type RedemptionRequestedEventFilter struct {
 	StartBlock          uint64
@@ -458,6 +643,10 @@ type Chain interface {
 	sortition.Chain
 	GroupSelectionChain
 	DistributedKeyGenerationChain
+	InactivityClaimChain
 	BridgeChain
+	DepositRevealChain
+	RedemptionMonitoringChain
+	FraudChain
 	WalletCoordinatorChain
 }
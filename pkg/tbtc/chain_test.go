@@ -15,6 +15,7 @@ import (
 	"time"
 
 	"golang.org/x/crypto/sha3"
+	"golang.org/x/exp/slices"
 
 	"github.com/keep-network/keep-core/pkg/bitcoin"
 	"github.com/keep-network/keep-core/pkg/chain"
@@ -35,6 +36,7 @@ type localChain struct {
 	dkgResultApprovalHandlers      map[int]func(submission *DKGResultApprovedEvent)
 
 	dkgResultApprovalGuard func() bool
+	dkgResultValidityGuard func(dkgResult *DKGChainResult) bool
 
 	dkgResultChallengeHandlersMutex sync.Mutex
 	dkgResultChallengeHandlers      map[int]func(submission *DKGResultChallengedEvent)
@@ -47,6 +49,9 @@ type localChain struct {
 	walletsMutex sync.Mutex
 	wallets      map[[20]byte]*WalletChainData
 
+	operatorsIDsMutex sync.Mutex
+	operatorsIDs      map[chain.Address]chain.OperatorID
+
 	blocksByTimestampMutex sync.Mutex
 	blocksByTimestamp      map[uint64]uint64
 
@@ -65,6 +70,38 @@ type localChain struct {
 	redemptionProposalValidationsMutex sync.Mutex
 	redemptionProposalValidations      map[[32]byte]bool
 
+	movingFundsProposalValidationsMutex sync.Mutex
+	movingFundsProposalValidations      map[[32]byte]bool
+
+	movedFundsSweepRequestsMutex sync.Mutex
+	movedFundsSweepRequests      map[[32]byte]*MovedFundsSweepRequest
+
+	movedFundsSweepProposalValidationsMutex sync.Mutex
+	movedFundsSweepProposalValidations      map[[32]byte]bool
+
+	inactivityClaimsMutex  sync.Mutex
+	inactivityClaimNonces  map[[32]byte]*big.Int
+	inactivityClaims       []*InactivityChainClaim
+	inactivityClaimMembers []chain.OperatorIDs
+
+	pastRedemptionRequestedEventsMutex sync.Mutex
+	pastRedemptionRequestedEvents      []*RedemptionRequestedEvent
+
+	redemptionParametersMutex sync.Mutex
+	redemptionTimeout         uint32
+
+	depositParametersMutex sync.Mutex
+	depositDustThreshold   uint64
+
+	fraudChallengesMutex        sync.Mutex
+	fraudChallenges             map[[32]byte]*FraudChallenge
+	fraudChallengeDefeatTimeout time.Duration
+	fraudChallengeDefenses      []*fraudChallengeDefense
+	fraudChallengeDefenseErrors int
+
+	pastFraudChallengeSubmittedEventsMutex sync.Mutex
+	pastFraudChallengeSubmittedEvents      []*FraudChallengeSubmittedEvent
+
 	blockCounter       chain.BlockCounter
 	operatorPrivateKey *operator.PrivateKey
 }
@@ -197,11 +234,31 @@ func (lc *localChain) GetOperatorID(
 		return 0, err
 	}
 
-	if thisOperatorAddress != operatorAddress {
-		return 0, fmt.Errorf("local chain allows for one operator only")
+	if thisOperatorAddress == operatorAddress {
+		return localChainOperatorID, nil
+	}
+
+	lc.operatorsIDsMutex.Lock()
+	defer lc.operatorsIDsMutex.Unlock()
+
+	operatorID, ok := lc.operatorsIDs[operatorAddress]
+	if !ok {
+		return 0, fmt.Errorf("unknown operator")
 	}
 
-	return localChainOperatorID, nil
+	return operatorID, nil
+}
+
+// setOperatorID registers an operator other than the operator of the local
+// chain so the local chain can resolve its ID.
+func (lc *localChain) setOperatorID(
+	operatorAddress chain.Address,
+	operatorID chain.OperatorID,
+) {
+	lc.operatorsIDsMutex.Lock()
+	defer lc.operatorsIDsMutex.Unlock()
+
+	lc.operatorsIDs[operatorAddress] = operatorID
 }
 
 func (lc *localChain) SelectGroup() (*GroupSelectionResult, error) {
@@ -392,7 +449,18 @@ func (lc *localChain) IsDKGResultValid(dkgResult *DKGChainResult) (bool, error)
 	lc.dkgMutex.Lock()
 	defer lc.dkgMutex.Unlock()
 
-	return lc.dkgResultValid, nil
+	return lc.isDKGResultValid(dkgResult), nil
+}
+
+// isDKGResultValid determines the validity of the given DKG result using
+// the validity guard, if set, or the validity set with setDKGResultValidity
+// otherwise. Must be called with the DKG mutex held.
+func (lc *localChain) isDKGResultValid(dkgResult *DKGChainResult) bool {
+	if lc.dkgResultValidityGuard != nil {
+		return lc.dkgResultValidityGuard(dkgResult)
+	}
+
+	return lc.dkgResultValid
 }
 
 func (lc *localChain) setDKGResultValidity(
@@ -421,7 +489,7 @@ func (lc *localChain) ChallengeDKGResult(dkgResult *DKGChainResult) error {
 		return fmt.Errorf("result does not match the submitted one")
 	}
 
-	if lc.dkgResultValid {
+	if lc.isDKGResultValid(dkgResult) {
 		return fmt.Errorf("submitted result is valid")
 	}
 
@@ -460,7 +528,7 @@ func (lc *localChain) ApproveDKGResult(dkgResult *DKGChainResult) error {
 		return fmt.Errorf("result does not match the submitted one")
 	}
 
-	if !lc.dkgResultValid {
+	if !lc.isDKGResultValid(dkgResult) {
 		return fmt.Errorf("submitted result is invalid")
 	}
 
@@ -495,6 +563,67 @@ func (lc *localChain) DKGParameters() (*DKGParameters, error) {
 	}, nil
 }
 
+func (lc *localChain) GetInactivityClaimNonce(
+	walletID [32]byte,
+) (*big.Int, error) {
+	lc.inactivityClaimsMutex.Lock()
+	defer lc.inactivityClaimsMutex.Unlock()
+
+	nonce, ok := lc.inactivityClaimNonces[walletID]
+	if !ok {
+		return big.NewInt(0), nil
+	}
+
+	return new(big.Int).Set(nonce), nil
+}
+
+func (lc *localChain) CalculateInactivityClaimHash(
+	walletPublicKey *ecdsa.PublicKey,
+	nonce *big.Int,
+	inactiveMembersIndexes []group.MemberIndex,
+	heartbeatFailed bool,
+) (InactivityClaimHash, error) {
+	if walletPublicKey == nil {
+		return InactivityClaimHash{}, fmt.Errorf("wallet public key is nil")
+	}
+
+	encoded := fmt.Sprint(
+		walletPublicKey,
+		nonce,
+		inactiveMembersIndexes,
+		heartbeatFailed,
+	)
+
+	return sha3.Sum256([]byte(encoded)), nil
+}
+
+func (lc *localChain) SubmitInactivityClaim(
+	claim *InactivityChainClaim,
+	nonce *big.Int,
+	groupMembers chain.OperatorIDs,
+) error {
+	lc.inactivityClaimsMutex.Lock()
+	defer lc.inactivityClaimsMutex.Unlock()
+
+	currentNonce, ok := lc.inactivityClaimNonces[claim.WalletID]
+	if !ok {
+		currentNonce = big.NewInt(0)
+	}
+
+	if currentNonce.Cmp(nonce) != 0 {
+		return fmt.Errorf("invalid nonce")
+	}
+
+	lc.inactivityClaimNonces[claim.WalletID] = new(big.Int).Add(
+		currentNonce,
+		big.NewInt(1),
+	)
+	lc.inactivityClaims = append(lc.inactivityClaims, claim)
+	lc.inactivityClaimMembers = append(lc.inactivityClaimMembers, groupMembers)
+
+	return nil
+}
+
 func (lc *localChain) PastDepositRevealedEvents(
 	filter *DepositRevealedEventFilter,
 ) ([]*DepositRevealedEvent, error) {
@@ -663,26 +792,6 @@ func (lc *localChain) operatorAddress() (chain.Address, error) {
 	return lc.Signing().PublicKeyToAddress(operatorPublicKey)
 }
 
-func (lc *localChain) OnHeartbeatRequestSubmitted(
-	handler func(event *HeartbeatRequestSubmittedEvent),
-) subscription.EventSubscription {
-	panic("unsupported")
-}
-
-func (lc *localChain) OnDepositSweepProposalSubmitted(
-	handler func(event *DepositSweepProposalSubmittedEvent),
-) subscription.EventSubscription {
-	panic("unsupported")
-}
-
-func (lc *localChain) GetWalletLock(walletPublicKeyHash [20]byte) (
-	time.Time,
-	WalletActionType,
-	error,
-) {
-	panic("unsupported")
-}
-
 func (lc *localChain) ValidateDepositSweepProposal(
 	proposal *DepositSweepProposal,
 	depositsExtraInfo []struct {
@@ -767,12 +876,6 @@ func buildDepositSweepProposalValidationKey(
 	return sha256.Sum256(buffer.Bytes()), nil
 }
 
-func (lc *localChain) OnRedemptionProposalSubmitted(
-	func(event *RedemptionProposalSubmittedEvent),
-) subscription.EventSubscription {
-	panic("unsupported")
-}
-
 func (lc *localChain) ValidateRedemptionProposal(
 	proposal *RedemptionProposal,
 ) error {
@@ -829,6 +932,459 @@ func buildRedemptionProposalValidationKey(
 	return sha256.Sum256(buffer.Bytes()), nil
 }
 
+func (lc *localChain) ValidateMovingFundsProposal(
+	walletMainUtxo *bitcoin.UnspentTransactionOutput,
+	proposal *MovingFundsProposal,
+) error {
+	lc.movingFundsProposalValidationsMutex.Lock()
+	defer lc.movingFundsProposalValidationsMutex.Unlock()
+
+	key, err := buildMovingFundsProposalValidationKey(walletMainUtxo, proposal)
+	if err != nil {
+		return err
+	}
+
+	result, ok := lc.movingFundsProposalValidations[key]
+	if !ok {
+		return fmt.Errorf("validation result unknown")
+	}
+
+	if !result {
+		return fmt.Errorf("validation failed")
+	}
+
+	return nil
+}
+
+func (lc *localChain) setMovingFundsProposalValidationResult(
+	walletMainUtxo *bitcoin.UnspentTransactionOutput,
+	proposal *MovingFundsProposal,
+	result bool,
+) error {
+	lc.movingFundsProposalValidationsMutex.Lock()
+	defer lc.movingFundsProposalValidationsMutex.Unlock()
+
+	key, err := buildMovingFundsProposalValidationKey(walletMainUtxo, proposal)
+	if err != nil {
+		return err
+	}
+
+	lc.movingFundsProposalValidations[key] = result
+
+	return nil
+}
+
+func buildMovingFundsProposalValidationKey(
+	walletMainUtxo *bitcoin.UnspentTransactionOutput,
+	proposal *MovingFundsProposal,
+) ([32]byte, error) {
+	var buffer bytes.Buffer
+
+	buffer.Write(proposal.WalletPublicKeyHash[:])
+
+	buffer.Write(walletMainUtxo.Outpoint.TransactionHash[:])
+	outputIndex := make([]byte, 4)
+	binary.BigEndian.PutUint32(outputIndex, walletMainUtxo.Outpoint.OutputIndex)
+	buffer.Write(outputIndex)
+	value := make([]byte, 8)
+	binary.BigEndian.PutUint64(value, uint64(walletMainUtxo.Value))
+	buffer.Write(value)
+
+	for _, targetWallet := range proposal.TargetWallets {
+		buffer.Write(targetWallet[:])
+	}
+
+	buffer.Write(proposal.MovingFundsTxFee.Bytes())
+
+	return sha256.Sum256(buffer.Bytes()), nil
+}
+
+func (lc *localChain) GetMovedFundsSweepRequest(
+	movingFundsTxHash bitcoin.Hash,
+	movingFundsTxOutputIndex uint32,
+) (*MovedFundsSweepRequest, bool, error) {
+	lc.movedFundsSweepRequestsMutex.Lock()
+	defer lc.movedFundsSweepRequestsMutex.Unlock()
+
+	requestKey := buildMovedFundsSweepRequestKey(
+		movingFundsTxHash,
+		movingFundsTxOutputIndex,
+	)
+
+	request, ok := lc.movedFundsSweepRequests[requestKey]
+	if !ok {
+		return nil, false, nil
+	}
+
+	return request, true, nil
+}
+
+func (lc *localChain) setMovedFundsSweepRequest(
+	movingFundsTxHash bitcoin.Hash,
+	movingFundsTxOutputIndex uint32,
+	request *MovedFundsSweepRequest,
+) {
+	lc.movedFundsSweepRequestsMutex.Lock()
+	defer lc.movedFundsSweepRequestsMutex.Unlock()
+
+	requestKey := buildMovedFundsSweepRequestKey(
+		movingFundsTxHash,
+		movingFundsTxOutputIndex,
+	)
+
+	lc.movedFundsSweepRequests[requestKey] = request
+}
+
+func buildMovedFundsSweepRequestKey(
+	movingFundsTxHash bitcoin.Hash,
+	movingFundsTxOutputIndex uint32,
+) [32]byte {
+	outputIndexBytes := make([]byte, 4)
+	binary.BigEndian.PutUint32(outputIndexBytes, movingFundsTxOutputIndex)
+
+	return sha256.Sum256(append(movingFundsTxHash[:], outputIndexBytes...))
+}
+
+func (lc *localChain) PastRedemptionRequestedEvents(
+	filter *RedemptionRequestedEventFilter,
+) ([]*RedemptionRequestedEvent, error) {
+	lc.pastRedemptionRequestedEventsMutex.Lock()
+	defer lc.pastRedemptionRequestedEventsMutex.Unlock()
+
+	result := make([]*RedemptionRequestedEvent, 0)
+	for _, event := range lc.pastRedemptionRequestedEvents {
+		if filter != nil {
+			if event.BlockNumber < filter.StartBlock {
+				continue
+			}
+
+			if filter.EndBlock != nil && event.BlockNumber > *filter.EndBlock {
+				continue
+			}
+
+			if len(filter.WalletPublicKeyHash) > 0 && !slices.Contains(
+				filter.WalletPublicKeyHash,
+				event.WalletPublicKeyHash,
+			) {
+				continue
+			}
+		}
+
+		result = append(result, event)
+	}
+
+	return result, nil
+}
+
+func (lc *localChain) addPastRedemptionRequestedEvent(
+	event *RedemptionRequestedEvent,
+) {
+	lc.pastRedemptionRequestedEventsMutex.Lock()
+	defer lc.pastRedemptionRequestedEventsMutex.Unlock()
+
+	lc.pastRedemptionRequestedEvents = append(
+		lc.pastRedemptionRequestedEvents,
+		event,
+	)
+}
+
+func (lc *localChain) GetRedemptionParameters() (
+	dustThreshold uint64,
+	treasuryFeeDivisor uint64,
+	txMaxFee uint64,
+	txMaxTotalFee uint64,
+	timeout uint32,
+	timeoutSlashingAmount *big.Int,
+	timeoutNotifierRewardMultiplier uint32,
+	err error,
+) {
+	lc.redemptionParametersMutex.Lock()
+	defer lc.redemptionParametersMutex.Unlock()
+
+	return 0, 0, 0, 0, lc.redemptionTimeout, big.NewInt(0), 0, nil
+}
+
+func (lc *localChain) setRedemptionTimeout(timeout uint32) {
+	lc.redemptionParametersMutex.Lock()
+	defer lc.redemptionParametersMutex.Unlock()
+
+	lc.redemptionTimeout = timeout
+}
+
+func (lc *localChain) OnDepositRevealed(
+	handler func(event *DepositRevealedEvent),
+) subscription.EventSubscription {
+	panic("unsupported")
+}
+
+func (lc *localChain) GetDepositParameters() (
+	dustThreshold uint64,
+	treasuryFeeDivisor uint64,
+	txMaxFee uint64,
+	revealAheadPeriod uint32,
+	err error,
+) {
+	lc.depositParametersMutex.Lock()
+	defer lc.depositParametersMutex.Unlock()
+
+	return lc.depositDustThreshold, 0, 0, 0, nil
+}
+
+func (lc *localChain) setDepositDustThreshold(dustThreshold uint64) {
+	lc.depositParametersMutex.Lock()
+	defer lc.depositParametersMutex.Unlock()
+
+	lc.depositDustThreshold = dustThreshold
+}
+
+func (lc *localChain) OnFraudChallengeSubmitted(
+	handler func(event *FraudChallengeSubmittedEvent),
+) subscription.EventSubscription {
+	panic("unsupported")
+}
+
+func (lc *localChain) PastFraudChallengeSubmittedEvents(
+	filter *FraudChallengeSubmittedEventFilter,
+) ([]*FraudChallengeSubmittedEvent, error) {
+	lc.pastFraudChallengeSubmittedEventsMutex.Lock()
+	defer lc.pastFraudChallengeSubmittedEventsMutex.Unlock()
+
+	result := make([]*FraudChallengeSubmittedEvent, 0)
+	for _, event := range lc.pastFraudChallengeSubmittedEvents {
+		if filter != nil {
+			if event.BlockNumber < filter.StartBlock {
+				continue
+			}
+
+			if filter.EndBlock != nil && event.BlockNumber > *filter.EndBlock {
+				continue
+			}
+
+			if len(filter.WalletPublicKeyHash) > 0 && !slices.Contains(
+				filter.WalletPublicKeyHash,
+				event.WalletPublicKeyHash,
+			) {
+				continue
+			}
+		}
+
+		result = append(result, event)
+	}
+
+	return result, nil
+}
+
+func (lc *localChain) addPastFraudChallengeSubmittedEvent(
+	event *FraudChallengeSubmittedEvent,
+) {
+	lc.pastFraudChallengeSubmittedEventsMutex.Lock()
+	defer lc.pastFraudChallengeSubmittedEventsMutex.Unlock()
+
+	lc.pastFraudChallengeSubmittedEvents = append(
+		lc.pastFraudChallengeSubmittedEvents,
+		event,
+	)
+}
+
+func (lc *localChain) PastMovingFundsCommitmentSubmittedEvents(
+	filter *MovingFundsCommitmentSubmittedEventFilter,
+) ([]*MovingFundsCommitmentSubmittedEvent, error) {
+	panic("unsupported")
+}
+
+func (lc *localChain) GetFraudChallenge(
+	walletPublicKey *ecdsa.PublicKey,
+	sighash [32]byte,
+) (*FraudChallenge, bool, error) {
+	lc.fraudChallengesMutex.Lock()
+	defer lc.fraudChallengesMutex.Unlock()
+
+	challenge, ok := lc.fraudChallenges[buildFraudChallengeKey(
+		walletPublicKey,
+		sighash,
+	)]
+	if !ok {
+		return nil, false, nil
+	}
+
+	return challenge, true, nil
+}
+
+func (lc *localChain) setFraudChallenge(
+	walletPublicKey *ecdsa.PublicKey,
+	sighash [32]byte,
+	challenge *FraudChallenge,
+) {
+	lc.fraudChallengesMutex.Lock()
+	defer lc.fraudChallengesMutex.Unlock()
+
+	lc.fraudChallenges[buildFraudChallengeKey(
+		walletPublicKey,
+		sighash,
+	)] = challenge
+}
+
+func (lc *localChain) GetFraudChallengeDefeatTimeout() (time.Duration, error) {
+	lc.fraudChallengesMutex.Lock()
+	defer lc.fraudChallengesMutex.Unlock()
+
+	return lc.fraudChallengeDefeatTimeout, nil
+}
+
+func (lc *localChain) DefeatFraudChallenge(
+	walletPublicKey *ecdsa.PublicKey,
+	preimage []byte,
+	witness bool,
+) error {
+	return lc.defeatFraudChallenge(walletPublicKey, &fraudChallengeDefense{
+		preimage: preimage,
+		witness:  witness,
+	})
+}
+
+func (lc *localChain) DefeatFraudChallengeWithHeartbeat(
+	walletPublicKey *ecdsa.PublicKey,
+	heartbeatMessage []byte,
+) error {
+	return lc.defeatFraudChallenge(walletPublicKey, &fraudChallengeDefense{
+		heartbeatMessage: heartbeatMessage,
+	})
+}
+
+func (lc *localChain) defeatFraudChallenge(
+	walletPublicKey *ecdsa.PublicKey,
+	defense *fraudChallengeDefense,
+) error {
+	lc.fraudChallengesMutex.Lock()
+	defer lc.fraudChallengesMutex.Unlock()
+
+	preimage := defense.preimage
+	if defense.heartbeatMessage != nil {
+		preimage = defense.heartbeatMessage
+	}
+
+	challenge, ok := lc.fraudChallenges[buildFraudChallengeKey(
+		walletPublicKey,
+		bitcoin.ComputeHash(preimage),
+	)]
+	if !ok {
+		return fmt.Errorf("fraud challenge does not exist")
+	}
+
+	if challenge.Resolved {
+		return fmt.Errorf("fraud challenge already resolved")
+	}
+
+	if lc.fraudChallengeDefenseErrors > 0 {
+		lc.fraudChallengeDefenseErrors--
+		return fmt.Errorf("fraud challenge defense transaction failed")
+	}
+
+	challenge.Resolved = true
+	lc.fraudChallengeDefenses = append(lc.fraudChallengeDefenses, defense)
+
+	return nil
+}
+
+func (lc *localChain) setFraudChallengeDefenseErrors(count int) {
+	lc.fraudChallengesMutex.Lock()
+	defer lc.fraudChallengesMutex.Unlock()
+
+	lc.fraudChallengeDefenseErrors = count
+}
+
+func buildFraudChallengeKey(
+	walletPublicKey *ecdsa.PublicKey,
+	sighash [32]byte,
+) [32]byte {
+	walletPublicKeyBytes := elliptic.Marshal(
+		walletPublicKey.Curve,
+		walletPublicKey.X,
+		walletPublicKey.Y,
+	)
+
+	return sha256.Sum256(append(walletPublicKeyBytes, sighash[:]...))
+}
+
+func (lc *localChain) ValidateMovedFundsSweepProposal(
+	walletMainUtxo *bitcoin.UnspentTransactionOutput,
+	proposal *MovedFundsSweepProposal,
+) error {
+	lc.movedFundsSweepProposalValidationsMutex.Lock()
+	defer lc.movedFundsSweepProposalValidationsMutex.Unlock()
+
+	key, err := buildMovedFundsSweepProposalValidationKey(
+		walletMainUtxo,
+		proposal,
+	)
+	if err != nil {
+		return err
+	}
+
+	result, ok := lc.movedFundsSweepProposalValidations[key]
+	if !ok {
+		return fmt.Errorf("validation result unknown")
+	}
+
+	if !result {
+		return fmt.Errorf("validation failed")
+	}
+
+	return nil
+}
+
+func (lc *localChain) setMovedFundsSweepProposalValidationResult(
+	walletMainUtxo *bitcoin.UnspentTransactionOutput,
+	proposal *MovedFundsSweepProposal,
+	result bool,
+) error {
+	lc.movedFundsSweepProposalValidationsMutex.Lock()
+	defer lc.movedFundsSweepProposalValidationsMutex.Unlock()
+
+	key, err := buildMovedFundsSweepProposalValidationKey(
+		walletMainUtxo,
+		proposal,
+	)
+	if err != nil {
+		return err
+	}
+
+	lc.movedFundsSweepProposalValidations[key] = result
+
+	return nil
+}
+
+func buildMovedFundsSweepProposalValidationKey(
+	walletMainUtxo *bitcoin.UnspentTransactionOutput,
+	proposal *MovedFundsSweepProposal,
+) ([32]byte, error) {
+	var buffer bytes.Buffer
+
+	buffer.Write(proposal.WalletPublicKeyHash[:])
+
+	if walletMainUtxo != nil {
+		buffer.Write(walletMainUtxo.Outpoint.TransactionHash[:])
+		outputIndex := make([]byte, 4)
+		binary.BigEndian.PutUint32(outputIndex, walletMainUtxo.Outpoint.OutputIndex)
+		buffer.Write(outputIndex)
+		value := make([]byte, 8)
+		binary.BigEndian.PutUint64(value, uint64(walletMainUtxo.Value))
+		buffer.Write(value)
+	}
+
+	buffer.Write(proposal.MovingFundsTxHash[:])
+	movingFundsTxOutputIndex := make([]byte, 4)
+	binary.BigEndian.PutUint32(
+		movingFundsTxOutputIndex,
+		proposal.MovingFundsTxOutputIndex,
+	)
+	buffer.Write(movingFundsTxOutputIndex)
+
+	buffer.Write(proposal.SweepTxFee.Bytes())
+
+	return sha256.Sum256(buffer.Bytes()), nil
+}
+
 // Connect sets up the local chain.
 func Connect(blockTime ...time.Duration) *localChain {
 	operatorPrivateKey, _, err := operator.GenerateKeyPair(local_v1.DefaultCurve)
@@ -857,15 +1413,22 @@ func ConnectWithKey(
 		dkgResultChallengeHandlers: make(
 			map[int]func(submission *DKGResultChallengedEvent),
 		),
-		wallets:                         make(map[[20]byte]*WalletChainData),
-		blocksByTimestamp:               make(map[uint64]uint64),
-		blocksHashesByNumber:            make(map[uint64][32]byte),
-		pastDepositRevealedEvents:       make(map[[32]byte][]*DepositRevealedEvent),
-		depositSweepProposalValidations: make(map[[32]byte]bool),
-		pendingRedemptionRequests:       make(map[[32]byte]*RedemptionRequest),
-		redemptionProposalValidations:   make(map[[32]byte]bool),
-		blockCounter:                    blockCounter,
-		operatorPrivateKey:              operatorPrivateKey,
+		wallets:                            make(map[[20]byte]*WalletChainData),
+		operatorsIDs:                       make(map[chain.Address]chain.OperatorID),
+		blocksByTimestamp:                  make(map[uint64]uint64),
+		blocksHashesByNumber:               make(map[uint64][32]byte),
+		pastDepositRevealedEvents:          make(map[[32]byte][]*DepositRevealedEvent),
+		depositSweepProposalValidations:    make(map[[32]byte]bool),
+		pendingRedemptionRequests:          make(map[[32]byte]*RedemptionRequest),
+		redemptionProposalValidations:      make(map[[32]byte]bool),
+		movingFundsProposalValidations:     make(map[[32]byte]bool),
+		movedFundsSweepRequests:            make(map[[32]byte]*MovedFundsSweepRequest),
+		movedFundsSweepProposalValidations: make(map[[32]byte]bool),
+		inactivityClaimNonces:              make(map[[32]byte]*big.Int),
+		fraudChallenges:                    make(map[[32]byte]*FraudChallenge),
+		fraudChallengeDefeatTimeout:        7 * 24 * time.Hour,
+		blockCounter:                       blockCounter,
+		operatorPrivateKey:                 operatorPrivateKey,
 	}
 
 	return localChain
@@ -10,6 +10,9 @@ import (
 	"golang.org/x/exp/slices"
 	"math/rand"
 	"sort"
+	"time"
+
+	"github.com/ipfs/go-log/v2"
 
 	"github.com/keep-network/keep-core/pkg/bitcoin"
 	"github.com/keep-network/keep-core/pkg/chain"
@@ -38,6 +41,17 @@ const (
 	// coordination window.
 	coordinationDurationBlocks = coordinationActivePhaseDurationBlocks +
 		coordinationPassivePhaseDurationBlocks
+	// coordinationLeaderTurnDurationBlocks is the number of blocks in a single
+	// leader's turn within the active phase of the coordination window.
+	// The primary leader can propose from the very beginning of the active
+	// phase. Each subsequent backup leader can propose once the turn of the
+	// preceding leader ends and no valid proposal was received so far.
+	coordinationLeaderTurnDurationBlocks = 20
+	// coordinationLeadersCount is the maximum number of leaders, i.e. the
+	// primary leader and the backup leaders, taking their turns during the
+	// active phase of a single coordination window.
+	coordinationLeadersCount = coordinationActivePhaseDurationBlocks /
+		coordinationLeaderTurnDurationBlocks
 	// coordinationSafeBlockShift is the number of blocks by which the
 	// coordination block is shifted to obtain a safe block whose 32-byte
 	// hash can be used as an ingredient for the coordination seed, computed
@@ -55,12 +69,22 @@ const (
 	// and before they are filtered out as not interesting for the follower,
 	// they are buffered in the channel.
 	coordinationMessageReceiveBuffer = 512
+	// coordinationAverageBlockTime is the assumed average block time of the
+	// host chain. It is used to estimate the point in time at which a
+	// proposal agreed during the coordination procedure expires, given that
+	// proposal validity is expressed in blocks.
+	coordinationAverageBlockTime = 12 * time.Second
 )
 
 // errCoordinationExecutorBusy is an error returned when the coordination
 // executor cannot execute the requested coordination due to an ongoing one.
 var errCoordinationExecutorBusy = fmt.Errorf("coordination executor is busy")
 
+// errCoordinationLeaderTurnEnded is an error returned by the leader's routine
+// when the leader's turn ended before the proposal was delivered. Followers
+// do not accept proposals of leaders whose turn has already ended.
+var errCoordinationLeaderTurnEnded = fmt.Errorf("leader's turn ended")
+
 // coordinationWindow represents a single coordination window. The coordination
 // block is the first block of the window.
 type coordinationWindow struct {
@@ -82,6 +106,14 @@ func (cw *coordinationWindow) activePhaseEndBlock() uint64 {
 	return cw.coordinationBlock + coordinationActivePhaseDurationBlocks
 }
 
+// LeaderTurnStartBlock returns the block number at which the turn of the
+// leader with the given rank starts. The primary leader has rank 0 and their
+// turn starts along with the coordination window.
+func (cw *coordinationWindow) leaderTurnStartBlock(rank int) uint64 {
+	return cw.coordinationBlock +
+		uint64(rank)*coordinationLeaderTurnDurationBlocks
+}
+
 // EndBlock returns the block number at which the coordination window ends.
 func (cw *coordinationWindow) endBlock() uint64 {
 	return cw.coordinationBlock + coordinationDurationBlocks
@@ -201,14 +233,47 @@ func (cf *coordinationFault) String() string {
 // coordination window. The generator is expected to return a proposal
 // for the first action from the checklist that is valid for the given
 // wallet's state. If none of the actions are valid, the generator
-// should return a noopProposal.
+// should return a NoopProposal.
 type coordinationProposalGenerator func(
 	walletPublicKeyHash [20]byte,
 	actionsChecklist []WalletActionType,
-) (coordinationProposal, error)
+) (CoordinationProposal, error)
+
+// CoordinationProposalGenerator is a component responsible for generating
+// coordination proposals for wallets whose coordination leader is
+// controlled by the node.
+type CoordinationProposalGenerator interface {
+	// Generate generates a coordination proposal based on the checklist
+	// of possible wallet actions held by the given request. The checklist
+	// is a list of actions that should be checked for the given coordination
+	// window. Deposits quarantined by the node are passed along so the
+	// generator can skip them. The generator is expected to return a proposal
+	// for the first action from the checklist that is valid for the given
+	// wallet's state. If none of the actions are valid, the generator should
+	// return a NoopProposal.
+	Generate(request *CoordinationProposalRequest) (CoordinationProposal, error)
+}
+
+// CoordinationProposalRequest represents a request for a coordination
+// proposal passed to the CoordinationProposalGenerator.
+type CoordinationProposalRequest struct {
+	// WalletPublicKeyHash is the 20-byte public key hash of the wallet
+	// the proposal is generated for.
+	WalletPublicKeyHash [20]byte
+	// ActionsChecklist is the list of wallet actions that should be checked
+	// for the given coordination window.
+	ActionsChecklist []WalletActionType
+	// QuarantinedDeposits holds deposits of the wallet that failed validation
+	// against the Bitcoin chain and must not be included in the proposal.
+	QuarantinedDeposits []*QuarantinedDeposit
+	// PendingTransaction is the latest transaction signed by the wallet that
+	// is not confirmed on the Bitcoin chain yet. Nil if there is no such
+	// transaction.
+	PendingTransaction *PendingWalletTransaction
+}
 
-// coordinationProposal represents a single action proposal for the given wallet.
-type coordinationProposal interface {
+// CoordinationProposal represents a single action proposal for the given wallet.
+type CoordinationProposal interface {
 	pb.Marshaler
 	pb.Unmarshaler
 
@@ -220,14 +285,24 @@ type coordinationProposal interface {
 	validityBlocks() uint64
 }
 
-// noopProposal is a proposal that does not propose any action.
-type noopProposal struct{}
+// walletPublicKeyHashSetter is implemented by coordination proposals that
+// still carry the WalletPublicKeyHash field.
+//
+// TODO: Remove once the WalletPublicKeyHash field is removed from proposals.
+type walletPublicKeyHashSetter interface {
+	// setWalletPublicKeyHash sets the public key hash of the wallet
+	// the proposal is addressed to.
+	setWalletPublicKeyHash(walletPublicKeyHash [20]byte)
+}
+
+// NoopProposal is a proposal that does not propose any action.
+type NoopProposal struct{}
 
-func (np *noopProposal) actionType() WalletActionType {
+func (np *NoopProposal) actionType() WalletActionType {
 	return ActionNoop
 }
 
-func (np *noopProposal) validityBlocks() uint64 {
+func (np *NoopProposal) validityBlocks() uint64 {
 	// Panic to make sure that the proposal is not processed by the node.
 	panic("noop proposal does not have validity blocks")
 }
@@ -238,7 +313,7 @@ type coordinationResult struct {
 	wallet   wallet
 	window   *coordinationWindow
 	leader   chain.Address
-	proposal coordinationProposal
+	proposal CoordinationProposal
 	faults   []*coordinationFault
 }
 
@@ -259,7 +334,7 @@ type coordinationMessage struct {
 	senderID            group.MemberIndex
 	coordinationBlock   uint64
 	walletPublicKeyHash [20]byte
-	proposal            coordinationProposal
+	proposal            CoordinationProposal
 }
 
 func (cm *coordinationMessage) Type() string {
@@ -283,7 +358,8 @@ type coordinationExecutor struct {
 	membershipValidator *group.MembershipValidator
 	protocolLatch       *generator.ProtocolLatch
 
-	waitForBlockFn waitForBlockFn
+	getCurrentBlockFn getCurrentBlockFn
+	waitForBlockFn    waitForBlockFn
 }
 
 // newCoordinationExecutor creates a new coordination executor for the
@@ -297,6 +373,7 @@ func newCoordinationExecutor(
 	broadcastChannel net.BroadcastChannel,
 	membershipValidator *group.MembershipValidator,
 	protocolLatch *generator.ProtocolLatch,
+	getCurrentBlockFn getCurrentBlockFn,
 	waitForBlockFn waitForBlockFn,
 ) *coordinationExecutor {
 	return &coordinationExecutor{
@@ -309,6 +386,7 @@ func newCoordinationExecutor(
 		broadcastChannel:    broadcastChannel,
 		membershipValidator: membershipValidator,
 		protocolLatch:       protocolLatch,
+		getCurrentBlockFn:   getCurrentBlockFn,
 		waitForBlockFn:      waitForBlockFn,
 	}
 }
@@ -359,9 +437,9 @@ func (ce *coordinationExecutor) coordinate(
 
 	execLogger.Info("coordination seed is: [0x%x]", seed)
 
-	leader := ce.getLeader(seed)
+	leaders := ce.getLeaders(seed)
 
-	execLogger.Info("coordination leader is: [%s]", leader)
+	execLogger.Info("coordination leaders are: [%v]", leaders)
 
 	actionsChecklist := ce.getActionsChecklist(window.index(), seed)
 
@@ -376,51 +454,137 @@ func (ce *coordinationExecutor) coordinate(
 	)
 	defer cancelCtx()
 
-	var proposal coordinationProposal
+	// Determine the rank of the executing operator on the leaders list.
+	// The rank is -1 if the executing operator is not one of the leaders.
+	rank := slices.Index(leaders, ce.operatorAddress)
+
+	// The executing operator follows all leaders preceding them on the
+	// leaders list. If the executing operator is not one of the leaders,
+	// they follow all leaders.
+	precedingLeaders := leaders
+	if rank >= 0 {
+		precedingLeaders = leaders[:rank]
+	}
+
+	var proposal CoordinationProposal
+	var leader chain.Address
 	var faults []*coordinationFault
 
-	if leader == ce.operatorAddress {
-		execLogger.Info("executing leader's routine")
+	if len(precedingLeaders) > 0 {
+		execLogger.Info("executing follower's routine")
 
-		proposal, err = ce.executeLeaderRoutine(
-			ctx,
-			window.coordinationBlock,
-			actionsChecklist,
+		followerCtx := ctx
+		if rank > 0 {
+			// A backup leader follows the preceding leaders only until
+			// their own turn starts.
+			var cancelFollowerCtx context.CancelFunc
+			followerCtx, cancelFollowerCtx = withCancelOnBlock(
+				ctx,
+				window.leaderTurnStartBlock(rank),
+				ce.waitForBlockFn,
+			)
+			defer cancelFollowerCtx()
+		}
+
+		proposal, leader, faults, err = ce.executeFollowerRoutine(
+			followerCtx,
+			precedingLeaders,
+			window,
+			append(actionsChecklist, ActionNoop),
 		)
 		if err != nil {
-			return nil, fmt.Errorf(
-				"failed to execute leader's routine: [%v]",
-				err,
+			if rank < 0 {
+				// None of the leaders proposed on time. Do not fail the
+				// coordination procedure as the result must carry the
+				// idleness faults of the leaders. The proposal remains
+				// unset and is turned into a noop below.
+				execLogger.Warnf(
+					"proposal not received from leaders; "+
+						"observed faults: [%v]: [%v]",
+					faults,
+					err,
+				)
+			} else {
+				execLogger.Infof(
+					"proposal not received from preceding leaders; "+
+						"taking over as backup leader with rank [%v]: [%v]",
+					rank,
+					err,
+				)
+			}
+		} else {
+			execLogger.Info(
+				"received proposal: [%s] from leader [%s]; "+
+					"observed faults: [%v]",
+				proposal.actionType(),
+				leader,
+				faults,
 			)
 		}
+	}
 
-		execLogger.Info("broadcasted proposal: [%s]", proposal.actionType())
-	} else {
-		execLogger.Info("executing follower's routine")
+	if proposal == nil && rank >= 0 {
+		execLogger.Info("executing leader's routine")
 
-		proposal, faults, err = ce.executeFollowerRoutine(
-			ctx,
-			leader,
-			window.coordinationBlock,
-			append(actionsChecklist, ActionNoop),
+		// The coordination message should be retransmitted until the end
+		// of the active phase, even though the coordination procedure of
+		// the leader completes right after the proposal is broadcast.
+		// Otherwise, followers that are late may miss the message. The
+		// broadcast context is cancelled once the active phase ends or
+		// right away if the leader's routine fails.
+		broadcastCtx, cancelBroadcastCtx := withCancelOnBlock(
+			context.Background(),
+			window.activePhaseEndBlock(),
+			ce.waitForBlockFn,
 		)
-		if err != nil {
+
+		proposal, err = ce.executeLeaderRoutine(
+			broadcastCtx,
+			window,
+			rank,
+			actionsChecklist,
+		)
+		switch {
+		case err == errCoordinationLeaderTurnEnded:
+			cancelBroadcastCtx()
+
+			// Followers do not accept the proposal of a leader whose turn
+			// has already ended and take the proposal of the next leader
+			// instead. Follow the leaders whose turn may still be in
+			// progress, just like any other follower would do.
+			execLogger.Warnf(
+				"turn of leader with rank [%v] ended before the proposal "+
+					"was delivered; executing follower's routine",
+				rank,
+			)
+
+			proposal, leader, faults = ce.executeLateLeaderRoutine(
+				ctx,
+				execLogger,
+				leaders,
+				window,
+				append(actionsChecklist, ActionNoop),
+				faults,
+			)
+		case err != nil:
+			cancelBroadcastCtx()
 			return nil, fmt.Errorf(
-				"failed to execute follower's routine: [%v]",
+				"failed to execute leader's routine: [%v]",
 				err,
 			)
-		}
+		default:
+			leader = ce.operatorAddress
 
-		execLogger.Info(
-			"received proposal: [%s]; observed faults: [%v]",
-			proposal.actionType(),
-			faults,
-		)
+			execLogger.Info(
+				"broadcasted proposal: [%s]",
+				proposal.actionType(),
+			)
+		}
 	}
 
 	// Just in case, if the proposal is nil, set it to noop.
 	if proposal == nil {
-		proposal = &noopProposal{}
+		proposal = &NoopProposal{}
 	}
 
 	result := &coordinationResult{
@@ -459,9 +623,12 @@ func (ce *coordinationExecutor) getSeed(
 	), nil
 }
 
-// getLeader returns the address of the coordination leader for the given
-// coordination seed.
-func (ce *coordinationExecutor) getLeader(seed [32]byte) chain.Address {
+// getLeaders returns the ordered list of coordination leaders for the given
+// coordination seed. The first leader on the list is the primary leader.
+// Subsequent leaders are backup leaders that can take over, one by one,
+// if the preceding leaders do not deliver a valid proposal during their
+// turns. The list contains up to coordinationLeadersCount unique operators.
+func (ce *coordinationExecutor) getLeaders(seed [32]byte) []chain.Address {
 	// First, take all operators backing the wallet.
 	allOperators := chain.Addresses(ce.coordinatedWallet.signingGroupOperators)
 
@@ -493,8 +660,13 @@ func (ce *coordinationExecutor) getLeader(seed [32]byte) chain.Address {
 		},
 	)
 
-	// The first operator in the shuffled list is the leader.
-	return uniqueOperators[0]
+	// The first operators in the shuffled list are the leaders, ordered
+	// by their rank.
+	if len(uniqueOperators) > coordinationLeadersCount {
+		return uniqueOperators[:coordinationLeadersCount]
+	}
+
+	return uniqueOperators
 }
 
 // getActionsChecklist returns a list of wallet actions that should be checked
@@ -511,6 +683,12 @@ func (ce *coordinationExecutor) getActionsChecklist(
 
 	var actions []WalletActionType
 
+	// Fee bump action should be checked first on every coordination window.
+	// No other transaction of the wallet can be made until the wallet's
+	// pending transaction is confirmed so, a stuck transaction blocks all
+	// other actions.
+	actions = append(actions, ActionFeeBump)
+
 	// Redemption action is a priority action and should be checked on every
 	// coordination window.
 	actions = append(actions, ActionRedemption)
@@ -546,12 +724,18 @@ func (ce *coordinationExecutor) getActionsChecklist(
 
 // executeLeaderRoutine executes the leader's routine for the given coordination
 // window. The routine generates a proposal and broadcasts it to the followers.
-// It returns the generated proposal or an error if the routine failed.
+// The leader has the given rank on the leaders list. As followers accept only
+// proposals of the leader whose turn is in progress, the proposal is not
+// broadcast if the leader's turn ended before the proposal was generated and
+// errCoordinationLeaderTurnEnded is returned. The same error is returned if
+// the turn ended while the proposal was being broadcast. It returns the
+// generated proposal or an error if the routine failed.
 func (ce *coordinationExecutor) executeLeaderRoutine(
 	ctx context.Context,
-	coordinationBlock uint64,
+	window *coordinationWindow,
+	rank int,
 	actionsChecklist []WalletActionType,
-) (coordinationProposal, error) {
+) (CoordinationProposal, error) {
 	walletPublicKeyHash := ce.walletPublicKeyHash()
 
 	proposal, err := ce.proposalGenerator(walletPublicKeyHash, actionsChecklist)
@@ -559,6 +743,13 @@ func (ce *coordinationExecutor) executeLeaderRoutine(
 		return nil, fmt.Errorf("failed to generate proposal: [%v]", err)
 	}
 
+	// The turn of the leader ends once the turn of the next leader starts.
+	turnEndBlock := window.leaderTurnStartBlock(rank + 1)
+
+	if err := ce.checkLeaderTurn(turnEndBlock); err != nil {
+		return nil, err
+	}
+
 	// Sort members indexes in ascending order, just in case. Choose the first
 	// member as the sender of the coordination message.
 	membersIndexes := append([]group.MemberIndex{}, ce.membersIndexes...)
@@ -567,7 +758,7 @@ func (ce *coordinationExecutor) executeLeaderRoutine(
 
 	message := &coordinationMessage{
 		senderID:            senderID,
-		coordinationBlock:   coordinationBlock,
+		coordinationBlock:   window.coordinationBlock,
 		walletPublicKeyHash: walletPublicKeyHash,
 		proposal:            proposal,
 	}
@@ -581,28 +772,131 @@ func (ce *coordinationExecutor) executeLeaderRoutine(
 		return nil, fmt.Errorf("failed to send coordination message: [%v]", err)
 	}
 
+	if err := ce.checkLeaderTurn(turnEndBlock); err != nil {
+		return nil, err
+	}
+
 	return proposal, nil
 }
 
-// executeFollowerRoutine executes the follower's routine for the given coordination
-// window. The routine listens for the coordination message from the leader and
-// validates it. If the leader's proposal is valid, it returns the received
-// proposal. Returns an error if the routine failed.
+// checkLeaderTurn returns errCoordinationLeaderTurnEnded if the leader's turn
+// ending at the given block has already ended.
+func (ce *coordinationExecutor) checkLeaderTurn(turnEndBlock uint64) error {
+	currentBlock, err := ce.getCurrentBlockFn()
+	if err != nil {
+		return fmt.Errorf("failed to get current block: [%v]", err)
+	}
+
+	if currentBlock >= turnEndBlock {
+		return errCoordinationLeaderTurnEnded
+	}
+
+	return nil
+}
+
+// executeLateLeaderRoutine executes the follower's routine for the leader
+// whose turn ended before their proposal was delivered. The late leader
+// follows all leaders, including themselves, so they accept the same
+// proposal as other followers. The idleness of leaders is observed by the
+// follower's routine again so, only other faults from the given faults
+// observed before are kept. If no proposal is received, the returned
+// proposal is nil.
+func (ce *coordinationExecutor) executeLateLeaderRoutine(
+	ctx context.Context,
+	execLogger log.StandardLogger,
+	leaders []chain.Address,
+	window *coordinationWindow,
+	actionsAllowed []WalletActionType,
+	faults []*coordinationFault,
+) (CoordinationProposal, chain.Address, []*coordinationFault) {
+	var observedFaults []*coordinationFault
+	for _, fault := range faults {
+		if fault.faultType != FaultLeaderIdleness {
+			observedFaults = append(observedFaults, fault)
+		}
+	}
+
+	proposal, leader, followerFaults, err := ce.executeFollowerRoutine(
+		ctx,
+		leaders,
+		window,
+		actionsAllowed,
+	)
+
+	observedFaults = append(observedFaults, followerFaults...)
+
+	if err != nil {
+		execLogger.Warnf(
+			"proposal not received from leaders; observed faults: [%v]: [%v]",
+			observedFaults,
+			err,
+		)
+
+		return nil, "", observedFaults
+	}
+
+	execLogger.Infof(
+		"received proposal: [%s] from leader [%s]; observed faults: [%v]",
+		proposal.actionType(),
+		leader,
+		observedFaults,
+	)
+
+	return proposal, leader, observedFaults
+}
+
+// executeFollowerRoutine executes the follower's routine for the given
+// coordination window. The routine listens for coordination messages from
+// the given leaders, ordered by their rank, and validates them. The primary
+// leader's turn starts along with the coordination window while each backup
+// leader's turn starts once their turn start block is reached. A leader's
+// turn ends once the turn of the next leader starts. The routine accepts the
+// first valid proposal of the leader whose turn is in progress. Proposals of
+// leaders whose turn has already ended are not accepted as the next leader
+// considers them idle and takes over. This is the same rule the backup
+// leaders follow before taking over. The routine returns the accepted
+// proposal along with the leader who sent it. Returns an error if the
+// routine failed.
 func (ce *coordinationExecutor) executeFollowerRoutine(
 	ctx context.Context,
-	leader chain.Address,
-	coordinationBlock uint64,
+	leaders []chain.Address,
+	window *coordinationWindow,
 	actionsAllowed []WalletActionType,
-) (coordinationProposal, []*coordinationFault, error) {
+) (CoordinationProposal, chain.Address, []*coordinationFault, error) {
 	// Cache wallet public key hash to not compute it on every message.
 	walletPublicKeyHash := ce.walletPublicKeyHash()
 	// Leader ID is the index of the first (index-wise) member controlled by
-	// the leader operator. The membersByOperator function returns a list of
-	// members controlled by the leader operator in the ascending order.
-	// It is enough to take the first member from the list. No need
-	// to check for list length as it is guaranteed that the leader operator
+	// the given leader operator. The membersByOperator function returns a
+	// list of members controlled by the leader operator in the ascending
+	// order. It is enough to take the first member from the list. No need
+	// to check for list length as it is guaranteed that each leader operator
 	// is one of the operators backing the wallet.
-	leaderID := ce.coordinatedWallet.membersByOperator(leader)[0]
+	leadersIDs := make([]group.MemberIndex, len(leaders))
+	for i, leader := range leaders {
+		leadersIDs[i] = ce.coordinatedWallet.membersByOperator(leader)[0]
+	}
+
+	// Notify about the start of each backup leader's turn. The channel is
+	// buffered so, the notifying goroutines never block.
+	turnsChan := make(chan int, len(leaders))
+	for rank := 1; rank < len(leaders); rank++ {
+		go func(rank int) {
+			err := ce.waitForBlockFn(ctx, window.leaderTurnStartBlock(rank))
+			if err != nil || ctx.Err() != nil {
+				return
+			}
+
+			turnsChan <- rank
+		}(rank)
+	}
+
+	// The rank of the leader whose turn is in progress.
+	currentRank := 0
+	// Valid proposals received from the leaders, indexed by the leader's rank.
+	// A proposal from a backup leader whose turn has not started yet is held
+	// as it is possible that the follower observes the turn start a bit later
+	// than the backup leader.
+	proposals := make([]CoordinationProposal, len(leaders))
 
 	var faults []*coordinationFault
 
@@ -636,7 +930,7 @@ loop:
 			}
 
 			// Filter out messages with wrong coordination block.
-			if coordinationBlock != message.coordinationBlock {
+			if window.coordinationBlock != message.coordinationBlock {
 				continue
 			}
 
@@ -645,8 +939,9 @@ loop:
 				continue
 			}
 
-			// Filter out messages from leader's impersonators.
-			if leaderID != message.senderID {
+			// Filter out messages from leaders' impersonators.
+			senderRank := slices.Index(leadersIDs, message.senderID)
+			if senderRank < 0 {
 				sender := ce.chain.Signing().PublicKeyBytesToAddress(
 					netMessage.SenderPublicKey(),
 				)
@@ -664,25 +959,52 @@ loop:
 			if !slices.Contains(actionsAllowed, message.proposal.actionType()) {
 				faults = append(
 					faults, &coordinationFault{
-						culprit:   leader,
+						culprit:   leaders[senderRank],
 						faultType: FaultLeaderMistake,
 					},
 				)
 				continue
 			}
 
-			return message.proposal, faults, nil
+			// Take only the first valid proposal of the given leader.
+			// Proposals of leaders whose turn has already ended are
+			// not taken.
+			if senderRank >= currentRank && proposals[senderRank] == nil {
+				proposals[senderRank] = message.proposal
+			}
+		case rank := <-turnsChan:
+			if rank > currentRank {
+				currentRank = rank
+			}
 		case <-ctx.Done():
 			break loop
 		}
+
+		// Accept the proposal of the leader whose turn is in progress.
+		// All preceding leaders did not propose during their turns so,
+		// they were idle.
+		if proposal := proposals[currentRank]; proposal != nil {
+			for _, idleLeader := range leaders[:currentRank] {
+				faults = append(
+					faults, &coordinationFault{
+						culprit:   idleLeader,
+						faultType: FaultLeaderIdleness,
+					},
+				)
+			}
+
+			return proposal, leaders[currentRank], faults, nil
+		}
 	}
 
-	faults = append(
-		faults, &coordinationFault{
-			culprit:   leader,
-			faultType: FaultLeaderIdleness,
-		},
-	)
+	for _, idleLeader := range leaders {
+		faults = append(
+			faults, &coordinationFault{
+				culprit:   idleLeader,
+				faultType: FaultLeaderIdleness,
+			},
+		)
+	}
 
-	return nil, faults, fmt.Errorf("coordination message not received on time")
+	return nil, "", faults, fmt.Errorf("coordination message not received on time")
 }
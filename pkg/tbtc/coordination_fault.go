@@ -0,0 +1,268 @@
+package tbtc
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/keep-network/keep-common/pkg/persistence"
+
+	"github.com/keep-network/keep-core/pkg/chain"
+)
+
+// coordinationFaultsDirectory is the name of the work persistence directory
+// holding per-operator coordination fault counters.
+const coordinationFaultsDirectory = "coordination_fault_counters"
+
+// coordinationFaultCounter represents the number of coordination faults of
+// the given type committed by the given culprit operator. The counter is
+// persisted as a single file that is overwritten every time the counter
+// changes so, the storage does not grow with the number of recorded faults.
+type coordinationFaultCounter struct {
+	culprit   chain.Address
+	faultType CoordinationFaultType
+	count     uint64
+	// lastWindowIndex is the index of the coordination window in which
+	// the last counted fault occurred.
+	lastWindowIndex uint64
+	// lastWalletPublicKeyHash is the public key hash of the wallet for
+	// which the last counted fault occurred.
+	lastWalletPublicKeyHash [20]byte
+}
+
+// name returns a unique name of the counter that can be used as the name
+// of the file in the persistence layer.
+func (cfc *coordinationFaultCounter) name() string {
+	return fmt.Sprintf("%s_%d", cfc.culprit, cfc.faultType)
+}
+
+// coordinationFaultKey uniquely identifies a coordination fault that
+// occurred for the given wallet in the given coordination window.
+type coordinationFaultKey struct {
+	windowIndex         uint64
+	walletPublicKeyHash [20]byte
+	culprit             chain.Address
+	faultType           CoordinationFaultType
+}
+
+// coordinationFaultRegistry is the component that keeps aggregated
+// per-operator coordination fault counters and persists them using the
+// underlying persistence layer. All functions of the registry are safe for
+// concurrent use.
+type coordinationFaultRegistry struct {
+	// mutex is a single struct-wide lock that ensures all functions
+	// of the registry are thread-safe.
+	mutex sync.Mutex
+
+	// persistence is the handle to the underlying work persistence layer.
+	persistence persistence.BasicHandle
+
+	// recordedFaults holds faults already counted in the most recent
+	// coordination window seen by the registry. It is used to avoid counting
+	// the same fault twice and is reset once a newer window is seen so, it
+	// does not grow over time.
+	recordedFaults map[coordinationFaultKey]bool
+
+	// recordedFaultsWindowIndex is the index of the most recent coordination
+	// window seen by the registry.
+	recordedFaultsWindowIndex uint64
+
+	// counters holds fault counters per fault type, for each culprit
+	// operator.
+	counters map[chain.Address]map[CoordinationFaultType]*coordinationFaultCounter
+}
+
+// newCoordinationFaultRegistry creates a new instance of the
+// coordinationFaultRegistry. The per-operator counters are restored from
+// the persistence layer.
+func newCoordinationFaultRegistry(
+	persistence persistence.BasicHandle,
+) *coordinationFaultRegistry {
+	cfr := &coordinationFaultRegistry{
+		persistence:    persistence,
+		recordedFaults: make(map[coordinationFaultKey]bool),
+		counters: make(
+			map[chain.Address]map[CoordinationFaultType]*coordinationFaultCounter,
+		),
+	}
+
+	counters := cfr.loadCounters()
+	for _, counter := range counters {
+		if _, ok := cfr.counters[counter.culprit]; !ok {
+			cfr.counters[counter.culprit] =
+				make(map[CoordinationFaultType]*coordinationFaultCounter)
+		}
+
+		cfr.counters[counter.culprit][counter.faultType] = counter
+	}
+
+	if len(counters) > 0 {
+		logger.Infof(
+			"[%v] coordination fault counters loaded from storage",
+			len(counters),
+		)
+	}
+
+	return cfr
+}
+
+// recordFaults counts the given coordination faults that occurred for the
+// given wallet in the coordination window with the given index and persists
+// the updated per-operator counters.
+func (cfr *coordinationFaultRegistry) recordFaults(
+	windowIndex uint64,
+	walletPublicKeyHash [20]byte,
+	faults []*coordinationFault,
+) error {
+	cfr.mutex.Lock()
+	defer cfr.mutex.Unlock()
+
+	if windowIndex > cfr.recordedFaultsWindowIndex {
+		cfr.recordedFaults = make(map[coordinationFaultKey]bool)
+		cfr.recordedFaultsWindowIndex = windowIndex
+	}
+
+	for _, fault := range faults {
+		key := coordinationFaultKey{
+			windowIndex:         windowIndex,
+			walletPublicKeyHash: walletPublicKeyHash,
+			culprit:             fault.culprit,
+			faultType:           fault.faultType,
+		}
+
+		if cfr.recordedFaults[key] {
+			continue
+		}
+
+		counter := &coordinationFaultCounter{
+			culprit:   fault.culprit,
+			faultType: fault.faultType,
+		}
+		if existing, ok := cfr.counters[fault.culprit][fault.faultType]; ok {
+			*counter = *existing
+		}
+
+		counter.count++
+		counter.lastWindowIndex = windowIndex
+		counter.lastWalletPublicKeyHash = walletPublicKeyHash
+
+		bytes, err := counter.Marshal()
+		if err != nil {
+			return fmt.Errorf(
+				"cannot marshal counter of coordination fault [%s]: [%v]",
+				fault,
+				err,
+			)
+		}
+
+		err = cfr.persistence.Save(
+			bytes,
+			coordinationFaultsDirectory,
+			counter.name(),
+		)
+		if err != nil {
+			return fmt.Errorf(
+				"cannot save counter of coordination fault [%s]: [%v]",
+				fault,
+				err,
+			)
+		}
+
+		if _, ok := cfr.counters[fault.culprit]; !ok {
+			cfr.counters[fault.culprit] =
+				make(map[CoordinationFaultType]*coordinationFaultCounter)
+		}
+
+		cfr.counters[fault.culprit][fault.faultType] = counter
+		cfr.recordedFaults[key] = true
+	}
+
+	return nil
+}
+
+// operatorsFaultsCounts returns a snapshot of the aggregated per-operator
+// fault counters. The returned map is keyed by the culprit operator address
+// and holds counters keyed by the fault type name.
+func (cfr *coordinationFaultRegistry) operatorsFaultsCounts() map[chain.Address]map[string]uint64 {
+	cfr.mutex.Lock()
+	defer cfr.mutex.Unlock()
+
+	result := make(map[chain.Address]map[string]uint64)
+	for operator, counters := range cfr.counters {
+		result[operator] = make(map[string]uint64)
+		for faultType, counter := range counters {
+			result[operator][faultType.String()] = counter.count
+		}
+	}
+
+	return result
+}
+
+// loadCounters loads all coordination fault counters stored using the
+// underlying persistence layer.
+func (cfr *coordinationFaultRegistry) loadCounters() []*coordinationFaultCounter {
+	counters := make([]*coordinationFaultCounter, 0)
+
+	descriptorsChan, errorsChan := cfr.persistence.ReadAll()
+
+	// Two goroutines read from descriptors and errors channels and either
+	// add the counter to the result slice or outputs a log error.
+	// The reason for using two goroutines at the same time - one for
+	// descriptors and one for errors - is that channels do not have to be
+	// buffered, and we do not know in what order the information is written to
+	// channels.
+	var wg sync.WaitGroup
+	wg.Add(2)
+
+	go func() {
+		for descriptor := range descriptorsChan {
+			// Read only the files located in the coordination faults
+			// directory as the work persistence is shared.
+			if descriptor.Directory() != coordinationFaultsDirectory {
+				continue
+			}
+
+			content, err := descriptor.Content()
+			if err != nil {
+				logger.Errorf(
+					"could not get content from file [%v] "+
+						"in directory [%v]: [%v]",
+					descriptor.Name(),
+					descriptor.Directory(),
+					err,
+				)
+				continue
+			}
+
+			counter := &coordinationFaultCounter{}
+			if err := counter.Unmarshal(content); err != nil {
+				logger.Errorf(
+					"could not unmarshal coordination fault counter "+
+						"from file [%v] in directory [%v]: [%v]",
+					descriptor.Name(),
+					descriptor.Directory(),
+					err,
+				)
+				continue
+			}
+
+			counters = append(counters, counter)
+		}
+
+		wg.Done()
+	}()
+
+	go func() {
+		for err := range errorsChan {
+			logger.Errorf(
+				"could not load coordination fault counter from disk: [%v]",
+				err,
+			)
+		}
+
+		wg.Done()
+	}()
+
+	wg.Wait()
+
+	return counters
+}
@@ -0,0 +1,121 @@
+package tbtc
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/keep-network/keep-core/internal/testutils"
+	"github.com/keep-network/keep-core/pkg/chain"
+)
+
+func TestCoordinationFaultRegistry_RecordFaults(t *testing.T) {
+	persistenceHandle := &mockPersistenceHandle{}
+
+	registry := newCoordinationFaultRegistry(persistenceHandle)
+
+	walletPublicKeyHash1 := [20]byte{1}
+	walletPublicKeyHash2 := [20]byte{2}
+
+	operator1 := chain.Address("0x01")
+	operator2 := chain.Address("0x02")
+
+	err := registry.recordFaults(
+		1,
+		walletPublicKeyHash1,
+		[]*coordinationFault{
+			{culprit: operator1, faultType: FaultLeaderIdleness},
+			{culprit: operator2, faultType: FaultLeaderImpersonation},
+		},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = registry.recordFaults(
+		2,
+		walletPublicKeyHash2,
+		[]*coordinationFault{
+			{culprit: operator1, faultType: FaultLeaderIdleness},
+			{culprit: operator1, faultType: FaultLeaderMistake},
+		},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Record a duplicate of an already recorded fault. It should not be
+	// counted again.
+	err = registry.recordFaults(
+		2,
+		walletPublicKeyHash2,
+		[]*coordinationFault{
+			{culprit: operator1, faultType: FaultLeaderMistake},
+		},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = registry.recordFaults(
+		3,
+		walletPublicKeyHash1,
+		[]*coordinationFault{
+			{culprit: operator1, faultType: FaultLeaderIdleness},
+		},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A single counter is persisted per operator and fault type, no
+	// matter how many faults were recorded.
+	testutils.AssertIntsEqual(
+		t,
+		"persisted counters count",
+		3,
+		len(persistenceHandle.saved),
+	)
+
+	for _, descriptor := range persistenceHandle.saved {
+		testutils.AssertStringsEqual(
+			t,
+			"persisted counter directory",
+			coordinationFaultsDirectory,
+			descriptor.Directory(),
+		)
+	}
+
+	expectedCounts := map[chain.Address]map[string]uint64{
+		operator1: {
+			FaultLeaderIdleness.String(): 3,
+			FaultLeaderMistake.String():  1,
+		},
+		operator2: {
+			FaultLeaderImpersonation.String(): 1,
+		},
+	}
+
+	if !reflect.DeepEqual(expectedCounts, registry.operatorsFaultsCounts()) {
+		t.Errorf(
+			"unexpected faults counts\n"+
+				"expected: [%v]\n"+
+				"actual:   [%v]",
+			expectedCounts,
+			registry.operatorsFaultsCounts(),
+		)
+	}
+
+	// Create a new registry instance using the same persistence handle
+	// and make sure the counters are restored from the storage.
+	restoredRegistry := newCoordinationFaultRegistry(persistenceHandle)
+
+	if !reflect.DeepEqual(expectedCounts, restoredRegistry.operatorsFaultsCounts()) {
+		t.Errorf(
+			"unexpected restored faults counts\n"+
+				"expected: [%v]\n"+
+				"actual:   [%v]",
+			expectedCounts,
+			restoredRegistry.operatorsFaultsCounts(),
+		)
+	}
+}
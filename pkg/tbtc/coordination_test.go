@@ -48,6 +48,19 @@ func TestCoordinationWindow_EndBlock(t *testing.T) {
 	)
 }
 
+func TestCoordinationWindow_LeaderTurnStartBlock(t *testing.T) {
+	window := newCoordinationWindow(900)
+
+	for rank, expectedStartBlock := range []int{900, 920, 940, 960} {
+		testutils.AssertIntsEqual(
+			t,
+			fmt.Sprintf("turn start block for leader with rank [%v]", rank),
+			expectedStartBlock,
+			int(window.leaderTurnStartBlock(rank)),
+		)
+	}
+}
+
 func TestCoordinationWindow_IsAfter(t *testing.T) {
 	window := newCoordinationWindow(1800)
 
@@ -210,6 +223,7 @@ func TestCoordinationExecutor_Coordinate(t *testing.T) {
 		chain              Chain
 		address            chain.Address
 		channel            net.BroadcastChannel
+		currentBlock       func() (uint64, error)
 		waitForBlockHeight func(ctx context.Context, blockHeight uint64) error
 	}
 
@@ -282,10 +296,20 @@ func TestCoordinationExecutor_Coordinate(t *testing.T) {
 			return nil
 		}
 
+		currentBlock := func() (uint64, error) {
+			blockCounter, err := localChain.BlockCounter()
+			if err != nil {
+				return 0, err
+			}
+
+			return blockCounter.CurrentBlock()
+		}
+
 		return &operatorFixture{
 			chain:              localChain,
 			address:            operatorAddress,
 			channel:            broadcastChannel,
+			currentBlock:       currentBlock,
 			waitForBlockHeight: waitForBlockHeight,
 		}
 	}
@@ -313,7 +337,7 @@ func TestCoordinationExecutor_Coordinate(t *testing.T) {
 	proposalGenerator := func(
 		walletPublicKeyHash [20]byte,
 		actionsChecklist []WalletActionType,
-	) (coordinationProposal, error) {
+	) (CoordinationProposal, error) {
 		for _, action := range actionsChecklist {
 			if walletPublicKeyHash == publicKeyHash && action == ActionRedemption {
 				return &RedemptionProposal{
@@ -326,7 +350,7 @@ func TestCoordinationExecutor_Coordinate(t *testing.T) {
 			}
 		}
 
-		return &noopProposal{}, nil
+		return &NoopProposal{}, nil
 	}
 
 	membershipValidator := group.NewMembershipValidator(
@@ -347,6 +371,7 @@ func TestCoordinationExecutor_Coordinate(t *testing.T) {
 			operator.channel,
 			membershipValidator,
 			protocolLatch,
+			operator.currentBlock,
 			operator.waitForBlockHeight,
 		)
 	}
@@ -395,56 +420,708 @@ loop:
 		return i.operatorIndex < j.operatorIndex
 	})
 
-	testutils.AssertIntsEqual(t, "reports count", 3, len(reports))
+	testutils.AssertIntsEqual(t, "reports count", 3, len(reports))
+
+	expectedResult := &coordinationResult{
+		wallet: coordinatedWallet,
+		window: window,
+		leader: operator3.address,
+		proposal: &RedemptionProposal{
+			RedeemersOutputScripts: []bitcoin.Script{
+				parseScript("00148db50eb52063ea9d98b3eac91489a90f738986f6"),
+				parseScript("76a9148db50eb52063ea9d98b3eac91489a90f738986f688ac"),
+			},
+			RedemptionTxFee: big.NewInt(10000),
+		},
+		faults: nil,
+	}
+
+	expectedReports := []*report{
+		{
+			operatorIndex: 1,
+			result:        expectedResult,
+			err:           nil,
+		},
+		{
+			operatorIndex: 2,
+			result:        expectedResult,
+			err:           nil,
+		},
+		{
+			operatorIndex: 3,
+			result:        expectedResult,
+			err:           nil,
+		},
+	}
+	if !reflect.DeepEqual(expectedReports, reports) {
+		t.Errorf(
+			"unexpected reports:\n"+
+				"expected: %v\n"+
+				"actual:   %v",
+			expectedReports,
+			reports,
+		)
+
+	}
+
+	testutils.AssertBoolsEqual(
+		t,
+		"protocol latch state",
+		false,
+		protocolLatch.IsExecuting(),
+	)
+}
+
+func TestCoordinationExecutor_Coordinate_WithBackupLeaders(t *testing.T) {
+	// Uncompressed public key corresponding to the 20-byte public key hash:
+	// aa768412ceed10bd423c025542ca90071f9fb62d.
+	publicKeyHex, err := hex.DecodeString(
+		"0471e30bca60f6548d7b42582a478ea37ada63b402af7b3ddd57f0c95bb6843175" +
+			"aa0d2053a91a050a6797d85c38f2909cb7027f2344a01986aa2f9f8ca7a0c289",
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 20-byte public key hash corresponding to the public key above.
+	buffer, err := hex.DecodeString("aa768412ceed10bd423c025542ca90071f9fb62d")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var publicKeyHash [20]byte
+	copy(publicKeyHash[:], buffer)
+
+	parseScript := func(script string) bitcoin.Script {
+		parsed, err := hex.DecodeString(script)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return parsed
+	}
+
+	coordinationBlock := uint64(900)
+
+	type operatorFixture struct {
+		chain              Chain
+		address            chain.Address
+		channel            net.BroadcastChannel
+		currentBlock       func() (uint64, error)
+		waitForBlockHeight func(ctx context.Context, blockHeight uint64) error
+	}
+
+	generateOperator := func(seed int64, channelName string) *operatorFixture {
+		// #nosec G404 (insecure random number source (rand))
+		rng := rand.New(rand.NewSource(seed))
+		// Generate operators with deterministic addresses that don't change
+		// between test runs.
+		generated, err := ecdsa.GenerateKey(
+			local_v1.DefaultCurve,
+			rng,
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		localChain := ConnectWithKey(
+			&operator.PrivateKey{
+				PublicKey: operator.PublicKey{
+					Curve: operator.Secp256k1,
+					X:     generated.X,
+					Y:     generated.Y,
+				},
+				D: generated.D,
+			},
+			100*time.Millisecond,
+		)
+
+		localChain.setBlockHashByNumber(
+			coordinationBlock-32,
+			"1422996cbcbc38fc924a46f4df5f9064279d3ab43396e58386dac9b87440d64f",
+		)
+
+		operatorAddress, err := localChain.operatorAddress()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, operatorPublicKey, err := localChain.OperatorKeyPair()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		broadcastChannel, err := netlocal.ConnectWithKey(operatorPublicKey).
+			BroadcastChannelFor(channelName)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		broadcastChannel.SetUnmarshaler(func() net.TaggedUnmarshaler {
+			return &coordinationMessage{}
+		})
+
+		waitForBlockHeight := func(ctx context.Context, blockHeight uint64) error {
+			blockCounter, err := localChain.BlockCounter()
+			if err != nil {
+				return err
+			}
+
+			// The local chain starts from block 0 so, shift the awaited
+			// block height by the coordination block to make the
+			// coordination window start right away.
+			wait, err := blockCounter.BlockHeightWaiter(
+				blockHeight - coordinationBlock,
+			)
+			if err != nil {
+				return err
+			}
+
+			select {
+			case <-wait:
+			case <-ctx.Done():
+			}
+
+			return nil
+		}
+
+		currentBlock := func() (uint64, error) {
+			blockCounter, err := localChain.BlockCounter()
+			if err != nil {
+				return 0, err
+			}
+
+			// Shift the current block the same way the awaited block
+			// height is shifted.
+			block, err := blockCounter.CurrentBlock()
+			if err != nil {
+				return 0, err
+			}
+
+			return block + coordinationBlock, nil
+		}
+
+		return &operatorFixture{
+			chain:              localChain,
+			address:            operatorAddress,
+			channel:            broadcastChannel,
+			currentBlock:       currentBlock,
+			waitForBlockHeight: waitForBlockHeight,
+		}
+	}
+
+	proposalGenerator := func(
+		walletPublicKeyHash [20]byte,
+		actionsChecklist []WalletActionType,
+	) (CoordinationProposal, error) {
+		for _, action := range actionsChecklist {
+			if walletPublicKeyHash == publicKeyHash && action == ActionRedemption {
+				return &RedemptionProposal{
+					RedeemersOutputScripts: []bitcoin.Script{
+						parseScript("00148db50eb52063ea9d98b3eac91489a90f738986f6"),
+						parseScript("76a9148db50eb52063ea9d98b3eac91489a90f738986f688ac"),
+					},
+					RedemptionTxFee: big.NewInt(10000),
+				}, nil
+			}
+		}
+
+		return &NoopProposal{}, nil
+	}
+
+	window := newCoordinationWindow(coordinationBlock)
+
+	type report struct {
+		operatorIndex int
+		result        *coordinationResult
+		err           error
+	}
+
+	var tests = map[string]struct {
+		// Ranks of leaders that are offline during the coordination.
+		offlineLeaders []int
+		// Ranks of leaders that generate their proposals only once their
+		// turns end.
+		lateLeaders []int
+		// Rank of the leader whose proposal is expected to be accepted.
+		expectedLeader int
+	}{
+		"all leaders online": {
+			offlineLeaders: nil,
+			expectedLeader: 0,
+		},
+		"primary leader offline": {
+			offlineLeaders: []int{0},
+			expectedLeader: 1,
+		},
+		"primary leader and first backup leader offline": {
+			offlineLeaders: []int{0, 1},
+			expectedLeader: 2,
+		},
+		"primary leader late": {
+			lateLeaders:    []int{0},
+			expectedLeader: 1,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			// Use a separate broadcast channel for each test case to not
+			// receive retransmissions from other test cases.
+			channelName := fmt.Sprintf("test-%s", testName)
+
+			operators := []*operatorFixture{
+				generateOperator(1, channelName),
+				generateOperator(2, channelName),
+				generateOperator(3, channelName),
+			}
+
+			coordinatedWallet := wallet{
+				publicKey: unmarshalPublicKey(publicKeyHex),
+				signingGroupOperators: []chain.Address{
+					operators[1].address,
+					operators[2].address,
+					operators[0].address,
+					operators[0].address,
+					operators[2].address,
+					operators[1].address,
+					operators[1].address,
+					operators[2].address,
+					operators[0].address,
+					operators[0].address,
+				},
+			}
+
+			membershipValidator := group.NewMembershipValidator(
+				&testutils.MockLogger{},
+				coordinatedWallet.signingGroupOperators,
+				Connect().Signing(),
+			)
+
+			protocolLatch := generator.NewProtocolLatch()
+
+			// Ranks of late leaders, by their addresses.
+			lateLeadersRanks := make(map[chain.Address]int)
+
+			generateExecutor := func(operator *operatorFixture) *coordinationExecutor {
+				operatorProposalGenerator := proposalGenerator
+
+				if rank, isLate := lateLeadersRanks[operator.address]; isLate {
+					// The late leader's proposal differs from proposals of
+					// other leaders to make sure it is not accepted by
+					// anyone, including the late leader.
+					operatorProposalGenerator = func(
+						walletPublicKeyHash [20]byte,
+						actionsChecklist []WalletActionType,
+					) (CoordinationProposal, error) {
+						err := operator.waitForBlockHeight(
+							context.Background(),
+							window.leaderTurnStartBlock(rank+1),
+						)
+						if err != nil {
+							return nil, err
+						}
+
+						return &RedemptionProposal{
+							RedeemersOutputScripts: []bitcoin.Script{
+								parseScript("00148db50eb52063ea9d98b3eac91489a90f738986f6"),
+							},
+							RedemptionTxFee: big.NewInt(20000),
+						}, nil
+					}
+				}
+
+				return newCoordinationExecutor(
+					operator.chain,
+					coordinatedWallet,
+					coordinatedWallet.membersByOperator(operator.address),
+					operator.address,
+					operatorProposalGenerator,
+					operator.channel,
+					membershipValidator,
+					protocolLatch,
+					operator.currentBlock,
+					operator.waitForBlockHeight,
+				)
+			}
+
+			// Determine the leaders upfront in order to decide which
+			// operators should be offline. The leaders selection itself
+			// is covered by a separate test.
+			seed, err := generateExecutor(operators[0]).getSeed(coordinationBlock)
+			if err != nil {
+				t.Fatal(err)
+			}
+			leaders := generateExecutor(operators[0]).getLeaders(seed)
+
+			testutils.AssertIntsEqual(t, "leaders count", 3, len(leaders))
+
+			for _, rank := range test.lateLeaders {
+				lateLeadersRanks[leaders[rank]] = rank
+			}
+
+			isOffline := func(operator *operatorFixture) bool {
+				for _, rank := range test.offlineLeaders {
+					if leaders[rank] == operator.address {
+						return true
+					}
+				}
+
+				return false
+			}
+
+			reportChan := make(chan *report, len(operators))
+
+			onlineOperatorsCount := 0
+			for i, currentOperator := range operators {
+				if isOffline(currentOperator) {
+					continue
+				}
+
+				onlineOperatorsCount++
+
+				go func(operatorIndex int, operator *operatorFixture) {
+					result, err := generateExecutor(operator).coordinate(window)
+
+					reportChan <- &report{
+						operatorIndex: operatorIndex,
+						result:        result,
+						err:           err,
+					}
+				}(i+1, currentOperator)
+			}
+
+			reports := make([]*report, 0)
+		loop:
+			//lint:ignore S1000 for-select is used as the channel is not closed by senders.
+			for {
+				select {
+				case r := <-reportChan:
+					reports = append(reports, r)
+
+					if len(reports) == onlineOperatorsCount {
+						break loop
+					}
+				}
+			}
+
+			slices.SortFunc(reports, func(i, j *report) bool {
+				return i.operatorIndex < j.operatorIndex
+			})
+
+			testutils.AssertIntsEqual(
+				t,
+				"reports count",
+				onlineOperatorsCount,
+				len(reports),
+			)
+
+			// All leaders preceding the expected leader are expected to be
+			// reported as idle.
+			var expectedFaults []*coordinationFault
+			for _, idleLeader := range leaders[:test.expectedLeader] {
+				expectedFaults = append(
+					expectedFaults, &coordinationFault{
+						culprit:   idleLeader,
+						faultType: FaultLeaderIdleness,
+					},
+				)
+			}
+
+			expectedReports := make([]*report, 0)
+			for i, currentOperator := range operators {
+				if isOffline(currentOperator) {
+					continue
+				}
+
+				expectedResult := &coordinationResult{
+					wallet: coordinatedWallet,
+					window: window,
+					leader: leaders[test.expectedLeader],
+					proposal: &RedemptionProposal{
+						RedeemersOutputScripts: []bitcoin.Script{
+							parseScript("00148db50eb52063ea9d98b3eac91489a90f738986f6"),
+							parseScript("76a9148db50eb52063ea9d98b3eac91489a90f738986f688ac"),
+						},
+						RedemptionTxFee: big.NewInt(10000),
+					},
+					faults: expectedFaults,
+				}
+
+				expectedReports = append(expectedReports, &report{
+					operatorIndex: i + 1,
+					result:        expectedResult,
+					err:           nil,
+				})
+			}
+
+			if !reflect.DeepEqual(expectedReports, reports) {
+				t.Errorf(
+					"unexpected reports:\n"+
+						"expected: %v\n"+
+						"actual:   %v",
+					expectedReports,
+					reports,
+				)
+			}
+
+			testutils.AssertBoolsEqual(
+				t,
+				"protocol latch state",
+				false,
+				protocolLatch.IsExecuting(),
+			)
+		})
+	}
+}
+
+func TestCoordinationExecutor_Coordinate_NoProposal(t *testing.T) {
+	// Uncompressed public key corresponding to the 20-byte public key hash:
+	// aa768412ceed10bd423c025542ca90071f9fb62d.
+	publicKeyHex, err := hex.DecodeString(
+		"0471e30bca60f6548d7b42582a478ea37ada63b402af7b3ddd57f0c95bb6843175" +
+			"aa0d2053a91a050a6797d85c38f2909cb7027f2344a01986aa2f9f8ca7a0c289",
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	coordinationBlock := uint64(900)
+
+	type operatorFixture struct {
+		chain              Chain
+		address            chain.Address
+		channel            net.BroadcastChannel
+		currentBlock       func() (uint64, error)
+		waitForBlockHeight func(ctx context.Context, blockHeight uint64) error
+	}
+
+	generateOperator := func(seed int64) *operatorFixture {
+		// #nosec G404 (insecure random number source (rand))
+		rng := rand.New(rand.NewSource(seed))
+		generated, err := ecdsa.GenerateKey(
+			local_v1.DefaultCurve,
+			rng,
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		localChain := ConnectWithKey(
+			&operator.PrivateKey{
+				PublicKey: operator.PublicKey{
+					Curve: operator.Secp256k1,
+					X:     generated.X,
+					Y:     generated.Y,
+				},
+				D: generated.D,
+			},
+			10*time.Millisecond,
+		)
+
+		localChain.setBlockHashByNumber(
+			coordinationBlock-32,
+			"1422996cbcbc38fc924a46f4df5f9064279d3ab43396e58386dac9b87440d64f",
+		)
+
+		operatorAddress, err := localChain.operatorAddress()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, operatorPublicKey, err := localChain.OperatorKeyPair()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		broadcastChannel, err := netlocal.ConnectWithKey(operatorPublicKey).
+			BroadcastChannelFor("test-no-proposal")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		broadcastChannel.SetUnmarshaler(func() net.TaggedUnmarshaler {
+			return &coordinationMessage{}
+		})
+
+		waitForBlockHeight := func(ctx context.Context, blockHeight uint64) error {
+			blockCounter, err := localChain.BlockCounter()
+			if err != nil {
+				return err
+			}
+
+			// The local chain starts from block 0 so, shift the awaited
+			// block height by the coordination block to make the
+			// coordination window start right away.
+			wait, err := blockCounter.BlockHeightWaiter(
+				blockHeight - coordinationBlock,
+			)
+			if err != nil {
+				return err
+			}
+
+			select {
+			case <-wait:
+			case <-ctx.Done():
+			}
+
+			return nil
+		}
+
+		currentBlock := func() (uint64, error) {
+			blockCounter, err := localChain.BlockCounter()
+			if err != nil {
+				return 0, err
+			}
+
+			// Shift the current block the same way the awaited block
+			// height is shifted.
+			block, err := blockCounter.CurrentBlock()
+			if err != nil {
+				return 0, err
+			}
+
+			return block + coordinationBlock, nil
+		}
+
+		return &operatorFixture{
+			chain:              localChain,
+			address:            operatorAddress,
+			channel:            broadcastChannel,
+			currentBlock:       currentBlock,
+			waitForBlockHeight: waitForBlockHeight,
+		}
+	}
+
+	// Use more operators than the leaders count so, at least one of
+	// them is a pure follower.
+	operators := make([]*operatorFixture, coordinationLeadersCount+1)
+	signingGroupOperators := make([]chain.Address, 0)
+	for i := range operators {
+		operators[i] = generateOperator(int64(i + 1))
+		signingGroupOperators = append(
+			signingGroupOperators,
+			operators[i].address,
+			operators[i].address,
+		)
+	}
+
+	coordinatedWallet := wallet{
+		publicKey:             unmarshalPublicKey(publicKeyHex),
+		signingGroupOperators: signingGroupOperators,
+	}
+
+	membershipValidator := group.NewMembershipValidator(
+		&testutils.MockLogger{},
+		coordinatedWallet.signingGroupOperators,
+		Connect().Signing(),
+	)
+
+	proposalGenerator := func(
+		walletPublicKeyHash [20]byte,
+		actionsChecklist []WalletActionType,
+	) (CoordinationProposal, error) {
+		return &NoopProposal{}, nil
+	}
+
+	generateExecutor := func(operator *operatorFixture) *coordinationExecutor {
+		return newCoordinationExecutor(
+			operator.chain,
+			coordinatedWallet,
+			coordinatedWallet.membersByOperator(operator.address),
+			operator.address,
+			proposalGenerator,
+			operator.channel,
+			membershipValidator,
+			generator.NewProtocolLatch(),
+			operator.currentBlock,
+			operator.waitForBlockHeight,
+		)
+	}
+
+	seed, err := generateExecutor(operators[0]).getSeed(coordinationBlock)
+	if err != nil {
+		t.Fatal(err)
+	}
+	leaders := generateExecutor(operators[0]).getLeaders(seed)
+
+	testutils.AssertIntsEqual(
+		t,
+		"leaders count",
+		coordinationLeadersCount,
+		len(leaders),
+	)
+
+	// Only the operator that is not a leader runs the coordination
+	// procedure. All leaders are offline.
+	var follower *operatorFixture
+	for _, operator := range operators {
+		if !slices.Contains(leaders, operator.address) {
+			follower = operator
+			break
+		}
+	}
+
+	window := newCoordinationWindow(coordinationBlock)
+
+	result, err := generateExecutor(follower).coordinate(window)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertIntsEqual(
+		t,
+		"proposal action type",
+		int(ActionNoop),
+		int(result.proposal.actionType()),
+	)
 
-	expectedResult := &coordinationResult{
-		wallet: coordinatedWallet,
-		window: window,
-		leader: operator3.address,
-		proposal: &RedemptionProposal{
-			RedeemersOutputScripts: []bitcoin.Script{
-				parseScript("00148db50eb52063ea9d98b3eac91489a90f738986f6"),
-				parseScript("76a9148db50eb52063ea9d98b3eac91489a90f738986f688ac"),
+	var expectedFaults []*coordinationFault
+	for _, idleLeader := range leaders {
+		expectedFaults = append(
+			expectedFaults, &coordinationFault{
+				culprit:   idleLeader,
+				faultType: FaultLeaderIdleness,
 			},
-			RedemptionTxFee: big.NewInt(10000),
-		},
-		faults: nil,
+		)
 	}
 
-	expectedReports := []*report{
-		{
-			operatorIndex: 1,
-			result:        expectedResult,
-			err:           nil,
-		},
-		{
-			operatorIndex: 2,
-			result:        expectedResult,
-			err:           nil,
-		},
-		{
-			operatorIndex: 3,
-			result:        expectedResult,
-			err:           nil,
-		},
-	}
-	if !reflect.DeepEqual(expectedReports, reports) {
+	if !reflect.DeepEqual(expectedFaults, result.faults) {
 		t.Errorf(
-			"unexpected reports:\n"+
-				"expected: %v\n"+
-				"actual:   %v",
-			expectedReports,
-			reports,
+			"unexpected faults\n"+
+				"expected: [%v]\n"+
+				"actual:   [%v]",
+			expectedFaults,
+			result.faults,
 		)
+	}
 
+	// Make sure the idleness faults are recorded once the result is
+	// processed.
+	node := &node{
+		coordinationFaultRegistry: newCoordinationFaultRegistry(
+			&mockPersistenceHandle{},
+		),
 	}
 
-	testutils.AssertBoolsEqual(
-		t,
-		"protocol latch state",
-		false,
-		protocolLatch.IsExecuting(),
-	)
+	processCoordinationResult(node, result)
+
+	expectedCounts := make(map[chain.Address]map[string]uint64)
+	for _, leader := range leaders {
+		expectedCounts[leader] = map[string]uint64{
+			FaultLeaderIdleness.String(): 1,
+		}
+	}
+
+	actualCounts := node.coordinationFaultRegistry.operatorsFaultsCounts()
+	if !reflect.DeepEqual(expectedCounts, actualCounts) {
+		t.Errorf(
+			"unexpected faults counts\n"+
+				"expected: [%v]\n"+
+				"actual:   [%v]",
+			expectedCounts,
+			actualCounts,
+		)
+	}
 }
 
 func TestCoordinationExecutor_GetSeed(t *testing.T) {
@@ -526,7 +1203,8 @@ func TestCoordinationExecutor_GetLeader(t *testing.T) {
 		coordinatedWallet: coordinatedWallet,
 	}
 
-	leader := executor.getLeader(seed)
+	// The first leader is the primary leader.
+	leader := executor.getLeaders(seed)[0]
 
 	testutils.AssertStringsEqual(
 		t,
@@ -536,6 +1214,60 @@ func TestCoordinationExecutor_GetLeader(t *testing.T) {
 	)
 }
 
+func TestCoordinationExecutor_GetLeaders(t *testing.T) {
+	seedBytes, err := hex.DecodeString(
+		"9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08",
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var seed [32]byte
+	copy(seed[:], seedBytes)
+
+	coordinatedWallet := wallet{
+		// Set only relevant fields.
+		signingGroupOperators: []chain.Address{
+			"957ECF59507a6A74b8d98747f07a74De270D3CC3", // member 1
+			"5E14c0f27612fbfB7A6FE40b5A6Ec997fA62fc04", // member 2
+			"D2662604f8b4540336fBd3c1F48d7e9cdFbD079c", // member 3
+			"7CBD87ABC182216A7Aa0E8d19aA21abFA2511383", // member 4
+			"FAc73b03884d94a08a5c6c7BB12Ac0b20571F162", // member 5
+			"705C76445651530fe0D25eeE287b6164cE2c7216", // member 6
+			"7CBD87ABC182216A7Aa0E8d19aA21abFA2511383", // member 7  (same operator as member 4)
+			"405ad1f632b49A0617fbdc1fD427aF54BA9Bb3dd", // member 8
+			"7CBD87ABC182216A7Aa0E8d19aA21abFA2511383", // member 9  (same operator as member 4)
+			"5E14c0f27612fbfB7A6FE40b5A6Ec997fA62fc04", // member 10 (same operator as member 2)
+		},
+	}
+
+	executor := &coordinationExecutor{
+		// Set only relevant fields.
+		coordinatedWallet: coordinatedWallet,
+	}
+
+	leaders := executor.getLeaders(seed)
+
+	// The first leader is the primary leader. The rest are backup leaders
+	// ordered by their rank.
+	expectedLeaders := []chain.Address{
+		"D2662604f8b4540336fBd3c1F48d7e9cdFbD079c", // member 3
+		"405ad1f632b49A0617fbdc1fD427aF54BA9Bb3dd", // member 8
+		"705C76445651530fe0D25eeE287b6164cE2c7216", // member 6
+		"5E14c0f27612fbfB7A6FE40b5A6Ec997fA62fc04", // member 2
+	}
+
+	if !reflect.DeepEqual(expectedLeaders, leaders) {
+		t.Errorf(
+			"unexpected coordination leaders\n"+
+				"expected: [%v]\n"+
+				"actual:   [%v]",
+			expectedLeaders,
+			leaders,
+		)
+	}
+}
+
 func TestCoordinationExecutor_GetActionsChecklist(t *testing.T) {
 	tests := map[string]struct {
 		coordinationBlock uint64
@@ -548,7 +1280,7 @@ func TestCoordinationExecutor_GetActionsChecklist(t *testing.T) {
 		},
 		"block 900": {
 			coordinationBlock: 900,
-			expectedChecklist: []WalletActionType{ActionRedemption},
+			expectedChecklist: []WalletActionType{ActionFeeBump, ActionRedemption},
 		},
 		// Incorrect coordination window.
 		"block 901": {
@@ -557,72 +1289,75 @@ func TestCoordinationExecutor_GetActionsChecklist(t *testing.T) {
 		},
 		"block 1800": {
 			coordinationBlock: 1800,
-			expectedChecklist: []WalletActionType{ActionRedemption},
+			expectedChecklist: []WalletActionType{ActionFeeBump, ActionRedemption},
 		},
 		"block 2700": {
 			coordinationBlock: 2700,
-			expectedChecklist: []WalletActionType{ActionRedemption},
+			expectedChecklist: []WalletActionType{ActionFeeBump, ActionRedemption},
 		},
 		"block 3600": {
 			coordinationBlock: 3600,
-			expectedChecklist: []WalletActionType{ActionRedemption},
+			expectedChecklist: []WalletActionType{ActionFeeBump, ActionRedemption},
 		},
 		"block 4500": {
 			coordinationBlock: 4500,
-			expectedChecklist: []WalletActionType{ActionRedemption},
+			expectedChecklist: []WalletActionType{ActionFeeBump, ActionRedemption},
 		},
 		// Heartbeat randomly selected for the 6th coordination window.
 		"block 5400": {
 			coordinationBlock: 5400,
 			expectedChecklist: []WalletActionType{
+				ActionFeeBump,
 				ActionRedemption,
 				ActionHeartbeat,
 			},
 		},
 		"block 6300": {
 			coordinationBlock: 6300,
-			expectedChecklist: []WalletActionType{ActionRedemption},
+			expectedChecklist: []WalletActionType{ActionFeeBump, ActionRedemption},
 		},
 		"block 7200": {
 			coordinationBlock: 7200,
-			expectedChecklist: []WalletActionType{ActionRedemption},
+			expectedChecklist: []WalletActionType{ActionFeeBump, ActionRedemption},
 		},
 		"block 8100": {
 			coordinationBlock: 8100,
-			expectedChecklist: []WalletActionType{ActionRedemption},
+			expectedChecklist: []WalletActionType{ActionFeeBump, ActionRedemption},
 		},
 		"block 9000": {
 			coordinationBlock: 9000,
-			expectedChecklist: []WalletActionType{ActionRedemption},
+			expectedChecklist: []WalletActionType{ActionFeeBump, ActionRedemption},
 		},
 		"block 9900": {
 			coordinationBlock: 9900,
-			expectedChecklist: []WalletActionType{ActionRedemption},
+			expectedChecklist: []WalletActionType{ActionFeeBump, ActionRedemption},
 		},
 		"block 10800": {
 			coordinationBlock: 10800,
-			expectedChecklist: []WalletActionType{ActionRedemption},
+			expectedChecklist: []WalletActionType{ActionFeeBump, ActionRedemption},
 		},
 		"block 11700": {
 			coordinationBlock: 11700,
-			expectedChecklist: []WalletActionType{ActionRedemption},
+			expectedChecklist: []WalletActionType{ActionFeeBump, ActionRedemption},
 		},
 		// Heartbeat randomly selected for the 14th coordination window.
 		"block 12600": {
 			coordinationBlock: 12600,
 			expectedChecklist: []WalletActionType{
+				ActionFeeBump,
 				ActionRedemption,
 				ActionHeartbeat,
 			},
 		},
 		"block 13500": {
 			coordinationBlock: 13500,
-			expectedChecklist: []WalletActionType{ActionRedemption},
+			expectedChecklist: []WalletActionType{ActionFeeBump, ActionRedemption},
 		},
 		// 16th coordination window so, all actions should be on the checklist.
 		"block 14400": {
 			coordinationBlock: 14400,
 			expectedChecklist: []WalletActionType{
+				ActionFeeBump,
 				ActionRedemption,
 				ActionDepositSweep,
 				ActionMovedFundsSweep,
@@ -694,7 +1429,7 @@ func TestCoordinationExecutor_ExecuteLeaderRoutine(t *testing.T) {
 		walletPublicKeyHash [20]byte,
 		actionsChecklist []WalletActionType,
 	) (
-		coordinationProposal,
+		CoordinationProposal,
 		error,
 	) {
 		for _, action := range actionsChecklist {
@@ -705,7 +1440,7 @@ func TestCoordinationExecutor_ExecuteLeaderRoutine(t *testing.T) {
 			}
 		}
 
-		return &noopProposal{}, nil
+		return &NoopProposal{}, nil
 	}
 
 	provider := netlocal.Connect()
@@ -725,6 +1460,9 @@ func TestCoordinationExecutor_ExecuteLeaderRoutine(t *testing.T) {
 		membersIndexes:    membersIndexes,
 		proposalGenerator: proposalGenerator,
 		broadcastChannel:  broadcastChannel,
+		getCurrentBlockFn: func() (uint64, error) {
+			return 910, nil
+		},
 	}
 
 	actionsChecklist := []WalletActionType{
@@ -752,7 +1490,12 @@ func TestCoordinationExecutor_ExecuteLeaderRoutine(t *testing.T) {
 		cancelCtx()
 	})
 
-	proposal, err := executor.executeLeaderRoutine(ctx, 900, actionsChecklist)
+	proposal, err := executor.executeLeaderRoutine(
+		ctx,
+		newCoordinationWindow(900),
+		0,
+		actionsChecklist,
+	)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -791,6 +1534,153 @@ func TestCoordinationExecutor_ExecuteLeaderRoutine(t *testing.T) {
 	}
 }
 
+func TestCoordinationExecutor_ExecuteLeaderRoutine_TurnEnded(t *testing.T) {
+	// Uncompressed public key corresponding to the 20-byte public key hash:
+	// aa768412ceed10bd423c025542ca90071f9fb62d.
+	publicKeyHex, err := hex.DecodeString(
+		"0471e30bca60f6548d7b42582a478ea37ada63b402af7b3ddd57f0c95bb6843175" +
+			"aa0d2053a91a050a6797d85c38f2909cb7027f2344a01986aa2f9f8ca7a0c289",
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	coordinatedWallet := wallet{
+		// Set only relevant fields.
+		publicKey: unmarshalPublicKey(publicKeyHex),
+	}
+
+	proposalGenerator := func(
+		walletPublicKeyHash [20]byte,
+		actionsChecklist []WalletActionType,
+	) (
+		CoordinationProposal,
+		error,
+	) {
+		return &HeartbeatProposal{
+			Message: []byte("heartbeat message"),
+		}, nil
+	}
+
+	window := newCoordinationWindow(900)
+
+	var tests = map[string]struct {
+		rank int
+		// Current blocks observed by the leader, one by one. The last block
+		// is observed once all preceding blocks were observed.
+		currentBlocks   []uint64
+		expectedMessage bool
+		expectedErr     error
+	}{
+		"backup leader's turn in progress": {
+			rank:            1,
+			currentBlocks:   []uint64{925, 935},
+			expectedMessage: true,
+			expectedErr:     nil,
+		},
+		"turn ended before broadcast": {
+			rank:            0,
+			currentBlocks:   []uint64{920},
+			expectedMessage: false,
+			expectedErr:     errCoordinationLeaderTurnEnded,
+		},
+		"backup leader's turn ended before broadcast": {
+			rank:            1,
+			currentBlocks:   []uint64{945},
+			expectedMessage: false,
+			expectedErr:     errCoordinationLeaderTurnEnded,
+		},
+		"turn ended during broadcast": {
+			rank:            0,
+			currentBlocks:   []uint64{919, 920},
+			expectedMessage: true,
+			expectedErr:     errCoordinationLeaderTurnEnded,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			broadcastChannel, err := netlocal.Connect().BroadcastChannelFor(
+				fmt.Sprintf("test-%s", testName),
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			broadcastChannel.SetUnmarshaler(func() net.TaggedUnmarshaler {
+				return &coordinationMessage{}
+			})
+
+			observedBlocks := 0
+			getCurrentBlockFn := func() (uint64, error) {
+				index := observedBlocks
+				if index >= len(test.currentBlocks) {
+					index = len(test.currentBlocks) - 1
+				}
+				observedBlocks++
+
+				return test.currentBlocks[index], nil
+			}
+
+			executor := &coordinationExecutor{
+				// Set only relevant fields.
+				coordinatedWallet: coordinatedWallet,
+				membersIndexes:    []group.MemberIndex{1},
+				proposalGenerator: proposalGenerator,
+				broadcastChannel:  broadcastChannel,
+				getCurrentBlockFn: getCurrentBlockFn,
+			}
+
+			ctx, cancelCtx := context.WithTimeout(
+				context.Background(),
+				time.Second,
+			)
+			defer cancelCtx()
+
+			messageChan := make(chan *coordinationMessage, 1)
+			broadcastChannel.Recv(ctx, func(m net.Message) {
+				if cm, ok := m.Payload().(*coordinationMessage); ok {
+					select {
+					case messageChan <- cm:
+					default:
+					}
+				}
+			})
+
+			proposal, err := executor.executeLeaderRoutine(
+				ctx,
+				window,
+				test.rank,
+				[]WalletActionType{ActionHeartbeat},
+			)
+			if err != test.expectedErr {
+				t.Fatalf(
+					"unexpected error\n"+
+						"expected: [%v]\n"+
+						"actual:   [%v]",
+					test.expectedErr,
+					err,
+				)
+			}
+
+			if test.expectedErr != nil && proposal != nil {
+				t.Errorf("unexpected proposal: [%v]", proposal)
+			}
+
+			select {
+			case <-messageChan:
+				if !test.expectedMessage {
+					t.Errorf("unexpected coordination message")
+				}
+			case <-ctx.Done():
+				if test.expectedMessage {
+					t.Errorf("expected coordination message")
+				}
+			}
+		})
+	}
+}
+
 func TestCoordinationExecutor_ExecuteFollowerRoutine(t *testing.T) {
 	// Uncompressed public key corresponding to the 20-byte public key hash:
 	// aa768412ceed10bd423c025542ca90071f9fb62d.
@@ -922,7 +1812,7 @@ func TestCoordinationExecutor_ExecuteFollowerRoutine(t *testing.T) {
 			senderID:            coordinatedWallet.membersByOperator(follower1.address)[0],
 			coordinationBlock:   900,
 			walletPublicKeyHash: executor.walletPublicKeyHash(),
-			proposal:            &noopProposal{},
+			proposal:            &NoopProposal{},
 		})
 		if err != nil {
 			t.Error(err)
@@ -935,7 +1825,7 @@ func TestCoordinationExecutor_ExecuteFollowerRoutine(t *testing.T) {
 			senderID:            coordinatedWallet.membersByOperator(follower2.address)[0],
 			coordinationBlock:   900,
 			walletPublicKeyHash: executor.walletPublicKeyHash(),
-			proposal:            &noopProposal{},
+			proposal:            &NoopProposal{},
 		})
 		if err != nil {
 			t.Error(err)
@@ -948,7 +1838,7 @@ func TestCoordinationExecutor_ExecuteFollowerRoutine(t *testing.T) {
 			senderID:            leaderID,
 			coordinationBlock:   901,
 			walletPublicKeyHash: executor.walletPublicKeyHash(),
-			proposal:            &noopProposal{},
+			proposal:            &NoopProposal{},
 		})
 		if err != nil {
 			t.Error(err)
@@ -960,7 +1850,7 @@ func TestCoordinationExecutor_ExecuteFollowerRoutine(t *testing.T) {
 			senderID:            leaderID,
 			coordinationBlock:   900,
 			walletPublicKeyHash: [20]byte{0x01},
-			proposal:            &noopProposal{},
+			proposal:            &NoopProposal{},
 		})
 		if err != nil {
 			t.Error(err)
@@ -972,7 +1862,7 @@ func TestCoordinationExecutor_ExecuteFollowerRoutine(t *testing.T) {
 			senderID:            coordinatedWallet.membersByOperator(follower2.address)[0],
 			coordinationBlock:   900,
 			walletPublicKeyHash: executor.walletPublicKeyHash(),
-			proposal:            &noopProposal{},
+			proposal:            &NoopProposal{},
 		})
 		if err != nil {
 			t.Error(err)
@@ -1013,16 +1903,23 @@ func TestCoordinationExecutor_ExecuteFollowerRoutine(t *testing.T) {
 		}
 	}()
 
-	proposal, faults, err := executor.executeFollowerRoutine(
+	proposal, proposalLeader, faults, err := executor.executeFollowerRoutine(
 		ctx,
-		leader.address,
-		900,
+		[]chain.Address{leader.address},
+		newCoordinationWindow(900),
 		[]WalletActionType{ActionRedemption, ActionNoop},
 	)
 	if err != nil {
 		t.Fatal(err)
 	}
 
+	testutils.AssertStringsEqual(
+		t,
+		"proposal leader",
+		leader.address.String(),
+		proposalLeader.String(),
+	)
+
 	expectedProposal := &RedemptionProposal{
 		RedeemersOutputScripts: []bitcoin.Script{
 			parseScript("00148db50eb52063ea9d98b3eac91489a90f738986f6"),
@@ -1127,10 +2024,10 @@ func TestCoordinationExecutor_ExecuteFollowerRoutine_WithIdleLeader(t *testing.T
 	ctx, cancelCtx := context.WithTimeout(context.Background(), 1*time.Second)
 	defer cancelCtx()
 
-	_, faults, err := executor.executeFollowerRoutine(
+	_, _, faults, err := executor.executeFollowerRoutine(
 		ctx,
-		leader,
-		900,
+		[]chain.Address{leader},
+		newCoordinationWindow(900),
 		[]WalletActionType{ActionRedemption, ActionNoop},
 	)
 
@@ -1161,3 +2058,243 @@ func TestCoordinationExecutor_ExecuteFollowerRoutine_WithIdleLeader(t *testing.T
 		)
 	}
 }
+
+func TestCoordinationExecutor_ExecuteFollowerRoutine_WithBackupLeader(t *testing.T) {
+	// Uncompressed public key corresponding to the 20-byte public key hash:
+	// aa768412ceed10bd423c025542ca90071f9fb62d.
+	publicKeyHex, err := hex.DecodeString(
+		"0471e30bca60f6548d7b42582a478ea37ada63b402af7b3ddd57f0c95bb6843175" +
+			"aa0d2053a91a050a6797d85c38f2909cb7027f2344a01986aa2f9f8ca7a0c289",
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	type operatorFixture struct {
+		address chain.Address
+		channel net.BroadcastChannel
+	}
+
+	generateOperator := func(channelName string) *operatorFixture {
+		localChain := Connect()
+
+		operatorAddress, err := localChain.operatorAddress()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, operatorPublicKey, err := localChain.OperatorKeyPair()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		broadcastChannel, err := netlocal.ConnectWithKey(operatorPublicKey).
+			BroadcastChannelFor(channelName)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		broadcastChannel.SetUnmarshaler(func() net.TaggedUnmarshaler {
+			return &coordinationMessage{}
+		})
+
+		return &operatorFixture{
+			address: operatorAddress,
+			channel: broadcastChannel,
+		}
+	}
+
+	backupProposal := &HeartbeatProposal{
+		Message: []byte("backup leader's heartbeat"),
+	}
+
+	primaryProposal := &HeartbeatProposal{
+		Message: []byte("primary leader's heartbeat"),
+	}
+
+	// Events happening during the follower routine, in order.
+	const (
+		primaryLeaderProposes = "primary leader proposes"
+		backupLeaderProposes  = "backup leader proposes"
+		backupLeaderTurns     = "backup leader's turn starts"
+	)
+
+	var tests = map[string]struct {
+		events           []string
+		expectedLeader   int
+		expectedProposal CoordinationProposal
+		expectedFaults   int
+	}{
+		"primary leader proposes after the backup leader": {
+			events: []string{
+				backupLeaderProposes,
+				primaryLeaderProposes,
+			},
+			expectedLeader:   0,
+			expectedProposal: primaryProposal,
+			expectedFaults:   0,
+		},
+		"primary leader is idle": {
+			events: []string{
+				backupLeaderProposes,
+				backupLeaderTurns,
+			},
+			expectedLeader:   1,
+			expectedProposal: backupProposal,
+			expectedFaults:   1,
+		},
+		"primary leader proposes after their turn ended": {
+			events: []string{
+				backupLeaderTurns,
+				primaryLeaderProposes,
+				backupLeaderProposes,
+			},
+			expectedLeader:   1,
+			expectedProposal: backupProposal,
+			expectedFaults:   1,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			channelName := fmt.Sprintf("test-%s", testName)
+
+			primaryLeader := generateOperator(channelName)
+			backupLeader := generateOperator(channelName)
+			follower := generateOperator(channelName)
+
+			coordinatedWallet := wallet{
+				publicKey: unmarshalPublicKey(publicKeyHex),
+				signingGroupOperators: []chain.Address{
+					follower.address,
+					backupLeader.address,
+					primaryLeader.address,
+					primaryLeader.address,
+					backupLeader.address,
+					follower.address,
+				},
+			}
+
+			leaders := []chain.Address{
+				primaryLeader.address,
+				backupLeader.address,
+			}
+
+			localChain := Connect()
+
+			membershipValidator := group.NewMembershipValidator(
+				&testutils.MockLogger{},
+				coordinatedWallet.signingGroupOperators,
+				localChain.Signing(),
+			)
+
+			// The backup leader's turn starts only when the test allows it.
+			backupLeaderTurnChan := make(chan struct{})
+
+			executor := &coordinationExecutor{
+				// Set only relevant fields.
+				chain:               localChain,
+				coordinatedWallet:   coordinatedWallet,
+				membersIndexes:      coordinatedWallet.membersByOperator(follower.address),
+				operatorAddress:     follower.address,
+				broadcastChannel:    follower.channel,
+				membershipValidator: membershipValidator,
+				waitForBlockFn: func(ctx context.Context, block uint64) error {
+					select {
+					case <-backupLeaderTurnChan:
+					case <-ctx.Done():
+					}
+					return nil
+				},
+			}
+
+			ctx, cancelCtx := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancelCtx()
+
+			window := newCoordinationWindow(900)
+
+			go func() {
+				// Give the follower routine some time to start and set up the
+				// broadcast channel handler.
+				time.Sleep(1 * time.Second)
+
+				for _, event := range test.events {
+					var err error
+
+					switch event {
+					case primaryLeaderProposes:
+						err = primaryLeader.channel.Send(ctx, &coordinationMessage{
+							senderID:            coordinatedWallet.membersByOperator(primaryLeader.address)[0],
+							coordinationBlock:   900,
+							walletPublicKeyHash: executor.walletPublicKeyHash(),
+							proposal:            primaryProposal,
+						})
+					case backupLeaderProposes:
+						// If the backup leader's turn has not started yet,
+						// the proposal should be held by the follower.
+						err = backupLeader.channel.Send(ctx, &coordinationMessage{
+							senderID:            coordinatedWallet.membersByOperator(backupLeader.address)[0],
+							coordinationBlock:   900,
+							walletPublicKeyHash: executor.walletPublicKeyHash(),
+							proposal:            backupProposal,
+						})
+					case backupLeaderTurns:
+						close(backupLeaderTurnChan)
+					}
+					if err != nil {
+						t.Error(err)
+						return
+					}
+
+					time.Sleep(500 * time.Millisecond)
+				}
+			}()
+
+			proposal, proposalLeader, faults, err := executor.executeFollowerRoutine(
+				ctx,
+				leaders,
+				window,
+				[]WalletActionType{ActionHeartbeat, ActionNoop},
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(test.expectedProposal, proposal) {
+				t.Errorf(
+					"unexpected proposal: \n"+
+						"expected: %v\n"+
+						"actual:   %v",
+					test.expectedProposal,
+					proposal,
+				)
+			}
+
+			testutils.AssertStringsEqual(
+				t,
+				"proposal leader",
+				leaders[test.expectedLeader].String(),
+				proposalLeader.String(),
+			)
+
+			var expectedFaults []*coordinationFault
+			for _, idleLeader := range leaders[:test.expectedFaults] {
+				expectedFaults = append(
+					expectedFaults, &coordinationFault{
+						culprit:   idleLeader,
+						faultType: FaultLeaderIdleness,
+					},
+				)
+			}
+			if !reflect.DeepEqual(expectedFaults, faults) {
+				t.Errorf(
+					"unexpected faults: \n"+
+						"expected: %v\n"+
+						"actual:   %v",
+					expectedFaults,
+					faults,
+				)
+			}
+		})
+	}
+}
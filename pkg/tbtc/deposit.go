@@ -1,11 +1,13 @@
 package tbtc
 
 import (
+	"encoding/binary"
 	"encoding/hex"
 	"fmt"
 	"github.com/keep-network/keep-core/pkg/bitcoin"
 	"github.com/keep-network/keep-core/pkg/chain"
 	"strings"
+	"time"
 )
 
 // depositScriptFormat is the format of the deposit P2(W)SH Bitcoin script
@@ -59,3 +61,24 @@ func (d *Deposit) Script() ([]byte, error) {
 
 	return hex.DecodeString(script)
 }
+
+// RefundLocktimeTime returns the moment the deposit's refund locktime
+// elapses. Since then, the depositor can reclaim the deposit on the Bitcoin
+// chain. The refund locktime is encoded as a 4-byte little-endian UNIX
+// timestamp.
+func (d *Deposit) RefundLocktimeTime() time.Time {
+	return RefundLocktimeTime(d.RefundLocktime)
+}
+
+// TimeToRefundLocktime returns the time remaining, counted from the given
+// moment, until the deposit's refund locktime elapses. The returned duration
+// is negative if the refund locktime already elapsed.
+func (d *Deposit) TimeToRefundLocktime(now time.Time) time.Duration {
+	return d.RefundLocktimeTime().Sub(now)
+}
+
+// RefundLocktimeTime converts the given 4-byte little-endian deposit refund
+// locktime to the moment the locktime elapses.
+func RefundLocktimeTime(refundLocktime [4]byte) time.Time {
+	return time.Unix(int64(binary.LittleEndian.Uint32(refundLocktime[:])), 0)
+}
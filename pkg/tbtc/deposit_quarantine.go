@@ -0,0 +1,342 @@
+package tbtc
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/keep-network/keep-common/pkg/persistence"
+
+	"github.com/keep-network/keep-core/pkg/bitcoin"
+)
+
+// depositQuarantineDirectory is the name of the work persistence directory
+// holding quarantined deposits.
+const depositQuarantineDirectory = "deposit_quarantine"
+
+// DepositQuarantineReason represents the reason a revealed deposit was
+// quarantined.
+type DepositQuarantineReason uint8
+
+const (
+	// DepositFundingTxMissing is used when the funding transaction of the
+	// deposit could not be found on the Bitcoin chain.
+	DepositFundingTxMissing DepositQuarantineReason = iota + 1
+	// DepositFundingTxUnconfirmed is used when the funding transaction of
+	// the deposit does not have enough confirmations yet.
+	DepositFundingTxUnconfirmed
+	// DepositFundingOutputMismatch is used when the funding output of the
+	// deposit does not exist or does not match the revealed deposit script.
+	DepositFundingOutputMismatch
+	// DepositAmountDust is used when the funding output value is below
+	// the deposit dust threshold.
+	DepositAmountDust
+)
+
+func (dqr DepositQuarantineReason) String() string {
+	switch dqr {
+	case DepositFundingTxMissing:
+		return "FundingTxMissing"
+	case DepositFundingTxUnconfirmed:
+		return "FundingTxUnconfirmed"
+	case DepositFundingOutputMismatch:
+		return "FundingOutputMismatch"
+	case DepositAmountDust:
+		return "AmountDust"
+	default:
+		panic("unknown deposit quarantine reason")
+	}
+}
+
+// transient returns true if the reason may cease to apply over time, for
+// example once the funding transaction gets mined or confirmed. Deposits
+// quarantined for a transient reason are periodically re-validated.
+func (dqr DepositQuarantineReason) transient() bool {
+	return dqr == DepositFundingTxMissing || dqr == DepositFundingTxUnconfirmed
+}
+
+// QuarantinedDeposit represents a revealed deposit that did not pass
+// validation against the Bitcoin chain and must not be included in deposit
+// sweep proposals.
+type QuarantinedDeposit struct {
+	WalletPublicKeyHash [20]byte
+	FundingTxHash       bitcoin.Hash
+	FundingOutputIndex  uint32
+	RevealBlock         uint64
+	Reason              DepositQuarantineReason
+	QuarantinedAt       time.Time
+}
+
+// name returns a unique name of the quarantined deposit that can be used
+// as the name of the file in the persistence layer.
+func (qd *QuarantinedDeposit) name() string {
+	return depositQuarantineName(qd.FundingTxHash, qd.FundingOutputIndex)
+}
+
+func depositQuarantineName(
+	fundingTxHash bitcoin.Hash,
+	fundingOutputIndex uint32,
+) string {
+	return fmt.Sprintf(
+		"%s_%d",
+		fundingTxHash.Hex(bitcoin.InternalByteOrder),
+		fundingOutputIndex,
+	)
+}
+
+// depositQuarantine is the component that keeps the list of quarantined
+// deposits using the underlying persistence layer. All functions of the
+// quarantine are safe for concurrent use.
+type depositQuarantine struct {
+	// mutex is a single struct-wide lock that ensures all functions
+	// of the quarantine are thread-safe.
+	mutex sync.Mutex
+
+	// persistence is the handle to the underlying work persistence layer.
+	persistence persistence.BasicHandle
+
+	// deposits holds all quarantined deposits, keyed by their names.
+	deposits map[string]*QuarantinedDeposit
+}
+
+// newDepositQuarantine creates a new instance of the depositQuarantine.
+// The quarantine is pre-populated using the deposits already stored in
+// the persistence layer.
+func newDepositQuarantine(
+	persistence persistence.BasicHandle,
+) *depositQuarantine {
+	dq := &depositQuarantine{
+		persistence: persistence,
+		deposits:    make(map[string]*QuarantinedDeposit),
+	}
+
+	deposits := dq.loadDeposits()
+	for _, deposit := range deposits {
+		dq.deposits[deposit.name()] = deposit
+	}
+
+	if len(deposits) > 0 {
+		logger.Infof(
+			"[%v] quarantined deposits loaded from storage",
+			len(deposits),
+		)
+	}
+
+	return dq
+}
+
+// quarantine persists the given deposit in the quarantine. If the deposit
+// is already quarantined, its entry is overwritten.
+func (dq *depositQuarantine) quarantine(deposit *QuarantinedDeposit) error {
+	dq.mutex.Lock()
+	defer dq.mutex.Unlock()
+
+	bytes, err := deposit.Marshal()
+	if err != nil {
+		return fmt.Errorf("cannot marshal quarantined deposit: [%v]", err)
+	}
+
+	err = dq.persistence.Save(bytes, depositQuarantineDirectory, deposit.name())
+	if err != nil {
+		return fmt.Errorf("cannot save quarantined deposit: [%v]", err)
+	}
+
+	dq.deposits[deposit.name()] = deposit
+
+	return nil
+}
+
+// release removes the deposit identified by the given funding outpoint from
+// the quarantine. It is a no-op if the deposit is not quarantined.
+func (dq *depositQuarantine) release(
+	fundingTxHash bitcoin.Hash,
+	fundingOutputIndex uint32,
+) error {
+	dq.mutex.Lock()
+	defer dq.mutex.Unlock()
+
+	name := depositQuarantineName(fundingTxHash, fundingOutputIndex)
+
+	if _, ok := dq.deposits[name]; !ok {
+		return nil
+	}
+
+	err := dq.persistence.Delete(depositQuarantineDirectory, name)
+	if err != nil {
+		return fmt.Errorf("cannot delete quarantined deposit: [%v]", err)
+	}
+
+	delete(dq.deposits, name)
+
+	return nil
+}
+
+// get returns a copy of the quarantined deposit identified by the given
+// funding outpoint. The returned bool value indicates whether the deposit
+// is quarantined or not.
+func (dq *depositQuarantine) get(
+	fundingTxHash bitcoin.Hash,
+	fundingOutputIndex uint32,
+) (*QuarantinedDeposit, bool) {
+	dq.mutex.Lock()
+	defer dq.mutex.Unlock()
+
+	deposit, ok := dq.deposits[depositQuarantineName(
+		fundingTxHash,
+		fundingOutputIndex,
+	)]
+	if !ok {
+		return nil, false
+	}
+
+	depositCopy := *deposit
+	return &depositCopy, true
+}
+
+// walletDeposits returns copies of all quarantined deposits of the given
+// wallet. The returned deposits are sorted by the reveal block.
+func (dq *depositQuarantine) walletDeposits(
+	walletPublicKeyHash [20]byte,
+) []*QuarantinedDeposit {
+	return dq.filter(func(deposit *QuarantinedDeposit) bool {
+		return deposit.WalletPublicKeyHash == walletPublicKeyHash
+	})
+}
+
+// transientDeposits returns copies of all deposits quarantined for
+// a transient reason. The returned deposits are sorted by the reveal block.
+func (dq *depositQuarantine) transientDeposits() []*QuarantinedDeposit {
+	return dq.filter(func(deposit *QuarantinedDeposit) bool {
+		return deposit.Reason.transient()
+	})
+}
+
+// all returns copies of all quarantined deposits. The returned deposits
+// are sorted by the reveal block.
+func (dq *depositQuarantine) all() []*QuarantinedDeposit {
+	return dq.filter(func(*QuarantinedDeposit) bool {
+		return true
+	})
+}
+
+// count returns the number of quarantined deposits.
+func (dq *depositQuarantine) count() int {
+	dq.mutex.Lock()
+	defer dq.mutex.Unlock()
+
+	return len(dq.deposits)
+}
+
+// filter returns copies of quarantined deposits matching the given predicate.
+// The returned deposits are sorted by the reveal block.
+func (dq *depositQuarantine) filter(
+	predicate func(deposit *QuarantinedDeposit) bool,
+) []*QuarantinedDeposit {
+	dq.mutex.Lock()
+	defer dq.mutex.Unlock()
+
+	result := make([]*QuarantinedDeposit, 0)
+	for _, deposit := range dq.deposits {
+		if predicate(deposit) {
+			depositCopy := *deposit
+			result = append(result, &depositCopy)
+		}
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].RevealBlock < result[j].RevealBlock
+	})
+
+	return result
+}
+
+// diagnostics returns a human-readable snapshot of all quarantined deposits
+// that can be exposed by the diagnostics endpoint.
+func (dq *depositQuarantine) diagnostics() []map[string]interface{} {
+	deposits := dq.all()
+
+	result := make([]map[string]interface{}, len(deposits))
+	for i, deposit := range deposits {
+		result[i] = map[string]interface{}{
+			"walletPublicKeyHash": fmt.Sprintf("0x%x", deposit.WalletPublicKeyHash),
+			"fundingTxHash":       deposit.FundingTxHash.Hex(bitcoin.ReversedByteOrder),
+			"fundingOutputIndex":  deposit.FundingOutputIndex,
+			"revealBlock":         deposit.RevealBlock,
+			"reason":              deposit.Reason.String(),
+			"quarantinedAt":       deposit.QuarantinedAt.UTC().Format(time.RFC3339),
+		}
+	}
+
+	return result
+}
+
+// loadDeposits loads all quarantined deposits stored using the underlying
+// persistence layer.
+func (dq *depositQuarantine) loadDeposits() []*QuarantinedDeposit {
+	deposits := make([]*QuarantinedDeposit, 0)
+
+	descriptorsChan, errorsChan := dq.persistence.ReadAll()
+
+	// Two goroutines read from descriptors and errors channels and either
+	// add the deposit to the result slice or outputs a log error.
+	// The reason for using two goroutines at the same time - one for
+	// descriptors and one for errors - is that channels do not have to be
+	// buffered, and we do not know in what order the information is written to
+	// channels.
+	var wg sync.WaitGroup
+	wg.Add(2)
+
+	go func() {
+		for descriptor := range descriptorsChan {
+			// Read only the files located in the deposit quarantine
+			// directory as the work persistence is shared.
+			if descriptor.Directory() != depositQuarantineDirectory {
+				continue
+			}
+
+			content, err := descriptor.Content()
+			if err != nil {
+				logger.Errorf(
+					"could not get content from file [%v] "+
+						"in directory [%v]: [%v]",
+					descriptor.Name(),
+					descriptor.Directory(),
+					err,
+				)
+				continue
+			}
+
+			deposit := &QuarantinedDeposit{}
+			if err := deposit.Unmarshal(content); err != nil {
+				logger.Errorf(
+					"could not unmarshal quarantined deposit from file [%v] "+
+						"in directory [%v]: [%v]",
+					descriptor.Name(),
+					descriptor.Directory(),
+					err,
+				)
+				continue
+			}
+
+			deposits = append(deposits, deposit)
+		}
+
+		wg.Done()
+	}()
+
+	go func() {
+		for err := range errorsChan {
+			logger.Errorf(
+				"could not load quarantined deposit from disk: [%v]",
+				err,
+			)
+		}
+
+		wg.Done()
+	}()
+
+	wg.Wait()
+
+	return deposits
+}
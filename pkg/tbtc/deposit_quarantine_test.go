@@ -0,0 +1,123 @@
+package tbtc
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/keep-network/keep-core/internal/testutils"
+	"github.com/keep-network/keep-core/pkg/bitcoin"
+)
+
+func TestDepositQuarantine(t *testing.T) {
+	persistenceHandle := &mockPersistenceHandle{}
+
+	quarantine := newDepositQuarantine(persistenceHandle)
+
+	walletPublicKeyHash1 := [20]byte{1}
+	walletPublicKeyHash2 := [20]byte{2}
+
+	quarantinedAt := time.Unix(1700000000, 0)
+
+	deposit1 := &QuarantinedDeposit{
+		WalletPublicKeyHash: walletPublicKeyHash1,
+		FundingTxHash:       bitcoin.Hash{1},
+		FundingOutputIndex:  0,
+		RevealBlock:         200,
+		Reason:              DepositFundingOutputMismatch,
+		QuarantinedAt:       quarantinedAt,
+	}
+	deposit2 := &QuarantinedDeposit{
+		WalletPublicKeyHash: walletPublicKeyHash1,
+		FundingTxHash:       bitcoin.Hash{2},
+		FundingOutputIndex:  1,
+		RevealBlock:         100,
+		Reason:              DepositFundingTxUnconfirmed,
+		QuarantinedAt:       quarantinedAt,
+	}
+	deposit3 := &QuarantinedDeposit{
+		WalletPublicKeyHash: walletPublicKeyHash2,
+		FundingTxHash:       bitcoin.Hash{3},
+		FundingOutputIndex:  2,
+		RevealBlock:         300,
+		Reason:              DepositFundingTxMissing,
+		QuarantinedAt:       quarantinedAt,
+	}
+
+	for _, deposit := range []*QuarantinedDeposit{deposit1, deposit2, deposit3} {
+		if err := quarantine.quarantine(deposit); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	testutils.AssertIntsEqual(t, "quarantined deposits count", 3, quarantine.count())
+	testutils.AssertIntsEqual(
+		t,
+		"persisted deposits count",
+		3,
+		len(persistenceHandle.saved),
+	)
+
+	for _, descriptor := range persistenceHandle.saved {
+		testutils.AssertStringsEqual(
+			t,
+			"persisted deposit directory",
+			depositQuarantineDirectory,
+			descriptor.Directory(),
+		)
+	}
+
+	expectedWalletDeposits := []*QuarantinedDeposit{deposit2, deposit1}
+	if !reflect.DeepEqual(
+		expectedWalletDeposits,
+		quarantine.walletDeposits(walletPublicKeyHash1),
+	) {
+		t.Errorf("unexpected wallet deposits")
+	}
+
+	expectedTransientDeposits := []*QuarantinedDeposit{deposit2, deposit3}
+	if !reflect.DeepEqual(
+		expectedTransientDeposits,
+		quarantine.transientDeposits(),
+	) {
+		t.Errorf("unexpected transient deposits")
+	}
+
+	err := quarantine.release(deposit2.FundingTxHash, deposit2.FundingOutputIndex)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Releasing a deposit that is not quarantined should be a no-op.
+	err = quarantine.release(bitcoin.Hash{4}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok := quarantine.get(
+		deposit2.FundingTxHash,
+		deposit2.FundingOutputIndex,
+	); ok {
+		t.Errorf("released deposit should not be quarantined")
+	}
+
+	testutils.AssertIntsEqual(t, "quarantined deposits count", 2, quarantine.count())
+	testutils.AssertIntsEqual(
+		t,
+		"persisted deposits count",
+		2,
+		len(persistenceHandle.saved),
+	)
+
+	// Make sure the quarantine is properly restored from the persistence.
+	restoredQuarantine := newDepositQuarantine(persistenceHandle)
+
+	expectedDeposits := []*QuarantinedDeposit{deposit1, deposit3}
+	if !reflect.DeepEqual(expectedDeposits, restoredQuarantine.all()) {
+		t.Errorf(
+			"unexpected restored deposits\nexpected: %v\nactual:   %v",
+			expectedDeposits,
+			restoredQuarantine.all(),
+		)
+	}
+}
@@ -0,0 +1,234 @@
+package tbtc
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/keep-network/keep-core/pkg/bitcoin"
+)
+
+// depositRevealRecheckInterval determines how often deposits quarantined
+// for a transient reason are re-validated.
+const depositRevealRecheckInterval = 30 * time.Minute
+
+// depositRevealValidator is the component that validates revealed deposits
+// against the Bitcoin chain and puts invalid ones into the deposit
+// quarantine. Quarantined deposits are skipped during deposit sweep proposal
+// generation so a single invalid reveal cannot cause the whole sweep to fail.
+type depositRevealValidator struct {
+	chain                          Chain
+	btcChain                       bitcoin.Chain
+	quarantine                     *depositQuarantine
+	requiredFundingTxConfirmations uint
+}
+
+func newDepositRevealValidator(
+	chain Chain,
+	btcChain bitcoin.Chain,
+	quarantine *depositQuarantine,
+) *depositRevealValidator {
+	return &depositRevealValidator{
+		chain:                          chain,
+		btcChain:                       btcChain,
+		quarantine:                     quarantine,
+		requiredFundingTxConfirmations: DepositSweepRequiredFundingTxConfirmations,
+	}
+}
+
+// validate validates the revealed deposit against the Bitcoin chain. If the
+// deposit is invalid, it is put into the quarantine. If the deposit is valid
+// but was quarantined before, it is released from the quarantine.
+func (drv *depositRevealValidator) validate(event *DepositRevealedEvent) error {
+	dustThreshold, _, _, _, err := drv.chain.GetDepositParameters()
+	if err != nil {
+		return fmt.Errorf("cannot get deposit parameters: [%v]", err)
+	}
+
+	reason, valid := validateRevealedDeposit(
+		event.unpack(),
+		dustThreshold,
+		drv.requiredFundingTxConfirmations,
+		drv.btcChain,
+	)
+
+	existing, quarantined := drv.quarantine.get(
+		event.FundingTxHash,
+		event.FundingOutputIndex,
+	)
+
+	if valid {
+		if !quarantined {
+			return nil
+		}
+
+		logger.Infof(
+			"deposit [%s:%d] of wallet PKH [0x%x] is now valid; "+
+				"releasing it from the quarantine",
+			event.FundingTxHash.Hex(bitcoin.ReversedByteOrder),
+			event.FundingOutputIndex,
+			event.WalletPublicKeyHash,
+		)
+
+		return drv.quarantine.release(
+			event.FundingTxHash,
+			event.FundingOutputIndex,
+		)
+	}
+
+	if quarantined && existing.Reason == reason {
+		return nil
+	}
+
+	logger.Warnf(
+		"quarantining deposit [%s:%d] of wallet PKH [0x%x] "+
+			"revealed at block [%v]; reason: [%s]",
+		event.FundingTxHash.Hex(bitcoin.ReversedByteOrder),
+		event.FundingOutputIndex,
+		event.WalletPublicKeyHash,
+		event.BlockNumber,
+		reason,
+	)
+
+	return drv.quarantine.quarantine(&QuarantinedDeposit{
+		WalletPublicKeyHash: event.WalletPublicKeyHash,
+		FundingTxHash:       event.FundingTxHash,
+		FundingOutputIndex:  event.FundingOutputIndex,
+		RevealBlock:         event.BlockNumber,
+		Reason:              reason,
+		QuarantinedAt:       time.Now(),
+	})
+}
+
+// recheck re-validates all deposits quarantined for a transient reason.
+// Deposits that turned valid are released from the quarantine.
+func (drv *depositRevealValidator) recheck() {
+	for _, deposit := range drv.quarantine.transientDeposits() {
+		fundingTxHashHex := deposit.FundingTxHash.Hex(bitcoin.ReversedByteOrder)
+
+		events, err := drv.chain.PastDepositRevealedEvents(
+			&DepositRevealedEventFilter{
+				StartBlock:          deposit.RevealBlock,
+				EndBlock:            &deposit.RevealBlock,
+				WalletPublicKeyHash: [][20]byte{deposit.WalletPublicKeyHash},
+			},
+		)
+		if err != nil {
+			logger.Errorf(
+				"cannot get DepositRevealed event for quarantined "+
+					"deposit [%s:%d]: [%v]",
+				fundingTxHashHex,
+				deposit.FundingOutputIndex,
+				err,
+			)
+			continue
+		}
+
+		var matchingEvent *DepositRevealedEvent
+		for _, event := range events {
+			if event.FundingTxHash == deposit.FundingTxHash &&
+				event.FundingOutputIndex == deposit.FundingOutputIndex {
+				matchingEvent = event
+				break
+			}
+		}
+
+		if matchingEvent == nil {
+			logger.Errorf(
+				"no matching DepositRevealed event for quarantined "+
+					"deposit [%s:%d]",
+				fundingTxHashHex,
+				deposit.FundingOutputIndex,
+			)
+			continue
+		}
+
+		if err := drv.validate(matchingEvent); err != nil {
+			logger.Errorf(
+				"cannot re-validate quarantined deposit [%s:%d]: [%v]",
+				fundingTxHashHex,
+				deposit.FundingOutputIndex,
+				err,
+			)
+		}
+	}
+}
+
+// runRecheckLoop periodically re-validates deposits quarantined for
+// a transient reason, until the given context is done.
+func (drv *depositRevealValidator) runRecheckLoop(ctx context.Context) {
+	ticker := time.NewTicker(depositRevealRecheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			drv.recheck()
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// validateRevealedDeposit validates the revealed deposit against the Bitcoin
+// chain. It checks that the funding transaction exists, its funding output
+// matches the deposit script, the funding output value is not below the
+// given dust threshold, and the funding transaction has the required number
+// of confirmations. The returned bool value is true if the deposit is valid.
+// Otherwise, the returned reason describes why the deposit is invalid.
+func validateRevealedDeposit(
+	deposit *Deposit,
+	dustThreshold uint64,
+	requiredFundingTxConfirmations uint,
+	btcChain bitcoin.Chain,
+) (DepositQuarantineReason, bool) {
+	fundingTxHash := deposit.Utxo.Outpoint.TransactionHash
+	fundingOutputIndex := deposit.Utxo.Outpoint.OutputIndex
+
+	fundingTx, err := btcChain.GetTransaction(fundingTxHash)
+	if err != nil {
+		return DepositFundingTxMissing, false
+	}
+
+	if int(fundingOutputIndex) >= len(fundingTx.Outputs) {
+		return DepositFundingOutputMismatch, false
+	}
+
+	fundingOutput := fundingTx.Outputs[fundingOutputIndex]
+
+	depositScript, err := deposit.Script()
+	if err != nil {
+		return DepositFundingOutputMismatch, false
+	}
+
+	p2shScript, err := bitcoin.PayToScriptHash(
+		bitcoin.ScriptHash(depositScript),
+	)
+	if err != nil {
+		return DepositFundingOutputMismatch, false
+	}
+
+	p2wshScript, err := bitcoin.PayToWitnessScriptHash(
+		bitcoin.WitnessScriptHash(depositScript),
+	)
+	if err != nil {
+		return DepositFundingOutputMismatch, false
+	}
+
+	if !bytes.Equal(fundingOutput.PublicKeyScript, p2shScript) &&
+		!bytes.Equal(fundingOutput.PublicKeyScript, p2wshScript) {
+		return DepositFundingOutputMismatch, false
+	}
+
+	if fundingOutput.Value < 0 || uint64(fundingOutput.Value) < dustThreshold {
+		return DepositAmountDust, false
+	}
+
+	confirmations, err := btcChain.GetTransactionConfirmations(fundingTxHash)
+	if err != nil || confirmations < requiredFundingTxConfirmations {
+		return DepositFundingTxUnconfirmed, false
+	}
+
+	return 0, true
+}
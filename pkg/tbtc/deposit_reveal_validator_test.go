@@ -0,0 +1,250 @@
+package tbtc
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/keep-network/keep-core/internal/testutils"
+	"github.com/keep-network/keep-core/pkg/bitcoin"
+	"github.com/keep-network/keep-core/pkg/chain"
+)
+
+func TestValidateRevealedDeposit(t *testing.T) {
+	dustThreshold := uint64(1000000)
+
+	var tests = map[string]struct {
+		fundingTxHash      bitcoin.Hash
+		fundingOutputIndex uint32
+		amount             uint64
+		fundingTxConfirmed bool
+		expectedValid      bool
+		expectedReason     DepositQuarantineReason
+	}{
+		"valid deposit": {
+			fundingTxConfirmed: true,
+			expectedValid:      true,
+		},
+		"funding tx missing": {
+			fundingTxHash:  bitcoin.Hash{1},
+			expectedReason: DepositFundingTxMissing,
+		},
+		"funding output index out of range": {
+			fundingOutputIndex: 2,
+			fundingTxConfirmed: true,
+			expectedReason:     DepositFundingOutputMismatch,
+		},
+		"funding output script mismatch": {
+			fundingOutputIndex: 1,
+			fundingTxConfirmed: true,
+			expectedReason:     DepositFundingOutputMismatch,
+		},
+		"dust amount": {
+			amount:             500000,
+			fundingTxConfirmed: true,
+			expectedReason:     DepositAmountDust,
+		},
+		"funding tx unconfirmed": {
+			fundingTxConfirmed: false,
+			expectedReason:     DepositFundingTxUnconfirmed,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			btcChain := newLocalBitcoinChain()
+
+			amount := uint64(2000000)
+			if test.amount != 0 {
+				amount = test.amount
+			}
+
+			testEvent, testFundingTx := newTestDepositReveal(t, amount)
+			if test.fundingTxConfirmed {
+				confirmTestTransaction(btcChain, testFundingTx)
+			} else {
+				btcChain.addToMempool(testFundingTx)
+			}
+
+			if test.fundingTxHash != (bitcoin.Hash{}) {
+				testEvent.FundingTxHash = test.fundingTxHash
+			}
+			testEvent.FundingOutputIndex = test.fundingOutputIndex
+
+			reason, valid := validateRevealedDeposit(
+				testEvent.unpack(),
+				dustThreshold,
+				DepositSweepRequiredFundingTxConfirmations,
+				btcChain,
+			)
+
+			testutils.AssertBoolsEqual(t, "validity", test.expectedValid, valid)
+
+			if !test.expectedValid {
+				testutils.AssertStringsEqual(
+					t,
+					"quarantine reason",
+					test.expectedReason.String(),
+					reason.String(),
+				)
+			}
+		})
+	}
+}
+
+func TestDepositRevealValidator_QuarantineAndRelease(t *testing.T) {
+	localChain := Connect()
+	localChain.setDepositDustThreshold(1000000)
+
+	btcChain := newLocalBitcoinChain()
+
+	quarantine := newDepositQuarantine(&mockPersistenceHandle{})
+
+	validator := newDepositRevealValidator(localChain, btcChain, quarantine)
+
+	event, fundingTx := newTestDepositReveal(t, 2000000)
+
+	// The funding transaction is in the mempool so, the deposit should be
+	// quarantined as unconfirmed.
+	btcChain.addToMempool(fundingTx)
+
+	if err := validator.validate(event); err != nil {
+		t.Fatal(err)
+	}
+
+	quarantinedDeposit, ok := quarantine.get(
+		event.FundingTxHash,
+		event.FundingOutputIndex,
+	)
+	if !ok {
+		t.Fatal("deposit should be quarantined")
+	}
+
+	testutils.AssertStringsEqual(
+		t,
+		"quarantine reason",
+		DepositFundingTxUnconfirmed.String(),
+		quarantinedDeposit.Reason.String(),
+	)
+	testutils.AssertUintsEqual(
+		t,
+		"reveal block",
+		event.BlockNumber,
+		quarantinedDeposit.RevealBlock,
+	)
+
+	// Confirm the funding transaction and re-check the quarantine. The
+	// deposit should be released.
+	btcChain.mempool = make([]*bitcoin.Transaction, 0)
+	confirmTestTransaction(btcChain, fundingTx)
+
+	err := localChain.setPastDepositRevealedEvents(
+		&DepositRevealedEventFilter{
+			StartBlock:          event.BlockNumber,
+			EndBlock:            &event.BlockNumber,
+			WalletPublicKeyHash: [][20]byte{event.WalletPublicKeyHash},
+		},
+		[]*DepositRevealedEvent{event},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	validator.recheck()
+
+	if _, ok := quarantine.get(
+		event.FundingTxHash,
+		event.FundingOutputIndex,
+	); ok {
+		t.Fatal("deposit should be released from the quarantine")
+	}
+
+	testutils.AssertIntsEqual(t, "quarantined deposits count", 0, quarantine.count())
+}
+
+// newTestDepositReveal creates a deposit reveal event along with a matching
+// funding transaction paying the given amount to the P2WSH deposit script
+// at output 0. Output 1 pays to an unrelated script.
+func newTestDepositReveal(
+	t *testing.T,
+	amount uint64,
+) (*DepositRevealedEvent, *bitcoin.Transaction) {
+	hexToSlice := func(hexString string) []byte {
+		bytes, err := hex.DecodeString(hexString)
+		if err != nil {
+			t.Fatalf("error while converting [%v]: [%v]", hexString, err)
+		}
+		return bytes
+	}
+
+	event := &DepositRevealedEvent{
+		FundingOutputIndex: 0,
+		Depositor:          chain.Address("934b98637ca318a4d6e7ca6ffd1690b8e77df637"),
+		Amount:             amount,
+		BlockNumber:        100,
+	}
+	copy(event.BlindingFactor[:], hexToSlice("f9f0c90d00039523"))
+	copy(event.WalletPublicKeyHash[:], hexToSlice("8db50eb52063ea9d98b3eac91489a90f738986f6"))
+	copy(event.RefundPublicKeyHash[:], hexToSlice("28e081f285138ccbe389c1eb8985716230129f89"))
+	copy(event.RefundLocktime[:], hexToSlice("60bcea61"))
+
+	depositScript, err := event.unpack().Script()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	depositOutputScript, err := bitcoin.PayToWitnessScriptHash(
+		bitcoin.WitnessScriptHash(depositScript),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	otherOutputScript, err := bitcoin.PayToPublicKeyHash([20]byte{1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fundingTx := &bitcoin.Transaction{
+		Version: 1,
+		Inputs: []*bitcoin.TransactionInput{
+			{
+				Outpoint: &bitcoin.TransactionOutpoint{
+					TransactionHash: bitcoin.Hash{2},
+					OutputIndex:     0,
+				},
+				Sequence: 0xffffffff,
+			},
+		},
+		Outputs: []*bitcoin.TransactionOutput{
+			{
+				Value:           int64(amount),
+				PublicKeyScript: depositOutputScript,
+			},
+			{
+				Value:           int64(amount),
+				PublicKeyScript: otherOutputScript,
+			},
+		},
+	}
+
+	event.FundingTxHash = fundingTx.Hash()
+
+	return event, fundingTx
+}
+
+// confirmTestTransaction adds the given transaction to the local Bitcoin
+// chain and mines enough subsequent transactions so the given one has the
+// number of confirmations required for deposit sweeps.
+func confirmTestTransaction(
+	btcChain *localBitcoinChain,
+	transaction *bitcoin.Transaction,
+) {
+	btcChain.transactions = append(btcChain.transactions, transaction)
+
+	for i := uint(1); i < DepositSweepRequiredFundingTxConfirmations; i++ {
+		btcChain.transactions = append(
+			btcChain.transactions,
+			&bitcoin.Transaction{Version: 1, Locktime: uint32(i)},
+		)
+	}
+}
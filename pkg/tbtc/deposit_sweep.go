@@ -19,15 +19,24 @@ const (
 	// another actions. The value of 1200 blocks is roughly 4 hours, assuming
 	// 12 seconds per block.
 	depositSweepProposalValidityBlocks = 1200
-	// depositSweepProposalConfirmationBlocks determines the block length of the
-	// confirmation period on the host chain that is preserved after a deposit
-	// sweep proposal submission.
-	depositSweepProposalConfirmationBlocks = 20
 	// DepositSweepRequiredFundingTxConfirmations determines the minimum
 	// number of confirmations that are needed for a deposit funding Bitcoin
 	// transaction in order to consider it a valid part of the deposit sweep
 	// proposal.
 	DepositSweepRequiredFundingTxConfirmations = 6
+	// DepositSweepRefundLocktimeSafetyMargin determines the minimum time
+	// that must remain until the refund locktime of a deposit in order to
+	// consider it a valid part of the deposit sweep proposal. Once the
+	// refund locktime elapses, the depositor can reclaim the deposit and
+	// make the sweep transaction invalid. The margin must exceed the
+	// worst-case duration of the deposit sweep action, i.e. roughly 4 hours
+	// determined by depositSweepProposalValidityBlocks. It also absorbs the
+	// median time past lag of the Bitcoin chain, as the refund locktime
+	// is checked against the median time of the last 11 blocks. This is
+	// a protocol constant all signing group members use to validate deposit
+	// sweep proposals. The coordination leader may use a larger margin when
+	// generating proposals but never a smaller one.
+	DepositSweepRefundLocktimeSafetyMargin = 24 * time.Hour
 	// depositSweepSigningTimeoutSafetyMargin determines the duration of the
 	// safety margin that must be preserved between the signing timeout
 	// and the timeout of the entire deposit sweep action. This safety
@@ -75,6 +84,20 @@ func (dsp *DepositSweepProposal) validityBlocks() uint64 {
 	return depositSweepProposalValidityBlocks
 }
 
+func (dsp *DepositSweepProposal) setWalletPublicKeyHash(walletPublicKeyHash [20]byte) {
+	dsp.WalletPublicKeyHash = walletPublicKeyHash
+}
+
+func (dsp *DepositSweepProposal) transactionFee() int64 {
+	return dsp.SweepTxFee.Int64()
+}
+
+func (dsp *DepositSweepProposal) withTransactionFee(fee int64) walletTransactionProposal {
+	proposal := *dsp
+	proposal.SweepTxFee = big.NewInt(fee)
+	return &proposal
+}
+
 // depositSweepAction is a deposit sweep walletAction.
 type depositSweepAction struct {
 	logger   *zap.SugaredLogger
@@ -89,6 +112,7 @@ type depositSweepAction struct {
 	proposalExpiresAt            time.Time
 
 	requiredFundingTxConfirmations uint
+	refundLocktimeSafetyMargin     time.Duration
 	signingTimeoutSafetyMargin     time.Duration
 	broadcastTimeout               time.Duration
 	broadcastCheckDelay            time.Duration
@@ -100,6 +124,7 @@ func newDepositSweepAction(
 	btcChain bitcoin.Chain,
 	sweepingWallet wallet,
 	signingExecutor walletSigningExecutor,
+	pendingTransactions *pendingWalletTransactions,
 	proposal *DepositSweepProposal,
 	proposalProcessingStartBlock uint64,
 	proposalExpiresAt time.Time,
@@ -108,6 +133,8 @@ func newDepositSweepAction(
 		btcChain,
 		sweepingWallet,
 		signingExecutor,
+		ActionDepositSweep,
+		pendingTransactions,
 	)
 
 	return &depositSweepAction{
@@ -120,6 +147,7 @@ func newDepositSweepAction(
 		proposalProcessingStartBlock:   proposalProcessingStartBlock,
 		proposalExpiresAt:              proposalExpiresAt,
 		requiredFundingTxConfirmations: DepositSweepRequiredFundingTxConfirmations,
+		refundLocktimeSafetyMargin:     DepositSweepRefundLocktimeSafetyMargin,
 		signingTimeoutSafetyMargin:     depositSweepSigningTimeoutSafetyMargin,
 		broadcastTimeout:               depositSweepBroadcastTimeout,
 		broadcastCheckDelay:            depositSweepBroadcastCheckDelay,
@@ -135,6 +163,7 @@ func (dsa *depositSweepAction) execute() error {
 		validateProposalLogger,
 		dsa.proposal,
 		dsa.requiredFundingTxConfirmations,
+		dsa.refundLocktimeSafetyMargin,
 		dsa.chain,
 		dsa.btcChain,
 	)
@@ -188,16 +217,24 @@ func (dsa *depositSweepAction) execute() error {
 		zap.String("step", "signTransaction"),
 	)
 
+	signingTimesOutAt := dsa.proposalExpiresAt.Add(-dsa.signingTimeoutSafetyMargin)
+
 	sweepTx, err := dsa.transactionExecutor.signTransaction(
 		signTxLogger,
 		unsignedSweepTx,
 		dsa.proposalProcessingStartBlock,
-		dsa.proposalExpiresAt.Add(-dsa.signingTimeoutSafetyMargin),
+		signingTimesOutAt,
 	)
 	if err != nil {
 		return fmt.Errorf("sign transaction step failed: [%v]", err)
 	}
 
+	dsa.transactionExecutor.recordPendingTransaction(
+		sweepTx,
+		walletMainUtxo,
+		dsa.proposal,
+	)
+
 	broadcastTxLogger := dsa.logger.With(
 		zap.String("step", "broadcastTransaction"),
 		zap.String("sweepTxHash", sweepTx.Hash().Hex(bitcoin.ReversedByteOrder)),
@@ -217,11 +254,15 @@ func (dsa *depositSweepAction) execute() error {
 }
 
 // ValidateDepositSweepProposal checks the deposit sweep proposal with on-chain
-// validation rules and verifies transactions on the Bitcoin chain.
+// validation rules and verifies transactions on the Bitcoin chain. Deposits
+// whose refund locktime elapses earlier than refundLocktimeSafetyMargin from
+// now are rejected as the depositor could reclaim them while the sweep is
+// in flight. A zero refundLocktimeSafetyMargin disables this check.
 func ValidateDepositSweepProposal(
 	validateProposalLogger log.StandardLogger,
 	proposal *DepositSweepProposal,
 	requiredFundingTxConfirmations uint,
+	refundLocktimeSafetyMargin time.Duration,
 	chain interface {
 		// PastDepositRevealedEvents fetches past deposit reveal events according
 		// to the provided filter or unfiltered if the filter is nil. Returned
@@ -347,11 +388,33 @@ func ValidateDepositSweepProposal(
 			)
 		}
 
+		deposit := matchingEvent.unpack()
+
+		if refundLocktimeSafetyMargin > 0 {
+			timeToRefundLocktime := deposit.TimeToRefundLocktime(time.Now())
+
+			validateProposalLogger.Infof(
+				"deposit [%v] - refund locktime elapses in [%v]",
+				depositDisplayIndex,
+				timeToRefundLocktime,
+			)
+
+			if timeToRefundLocktime < refundLocktimeSafetyMargin {
+				return nil, fmt.Errorf(
+					"refund locktime of deposit [%v] elapses in [%v] "+
+						"which is less than the safety margin [%v]",
+					depositDisplayIndex,
+					timeToRefundLocktime,
+					refundLocktimeSafetyMargin,
+				)
+			}
+		}
+
 		depositExtraInfo[i] = struct {
 			*Deposit
 			FundingTx *bitcoin.Transaction
 		}{
-			Deposit:   matchingEvent.unpack(),
+			Deposit:   deposit,
 			FundingTx: fundingTx,
 		}
 	}
@@ -383,6 +446,14 @@ func (dsa *depositSweepAction) actionType() WalletActionType {
 	return ActionDepositSweep
 }
 
+func (dsa *depositSweepAction) expiresAt() time.Time {
+	return dsa.proposalExpiresAt
+}
+
+func (dsa *depositSweepAction) signingTimeoutBlock() uint64 {
+	return signingTimeoutBlock(dsa.proposalProcessingStartBlock)
+}
+
 // assembleDepositSweepTransaction constructs an unsigned deposit sweep Bitcoin
 // transaction.
 //
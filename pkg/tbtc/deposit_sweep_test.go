@@ -187,10 +187,16 @@ func TestDepositSweepAction_Execute(t *testing.T) {
 				bitcoinChain,
 				wallet,
 				signingExecutor,
+				// Scenario transactions do not signal replace-by-fee so,
+				// they must not be recorded for fee bumping.
+				nil,
 				proposal,
 				proposalProcessingStartBlock,
 				proposalExpiresAt,
 			)
+			// Scenario deposits have refund locktimes in the past so,
+			// the refund locktime check must be disabled.
+			action.refundLocktimeSafetyMargin = 0
 
 			// Modify the default parameters of the action to make
 			// it possible to execute in the current test environment.
@@ -3,6 +3,7 @@ package tbtc
 import (
 	"encoding/hex"
 	"testing"
+	"time"
 
 	"github.com/keep-network/keep-core/internal/testutils"
 )
@@ -37,3 +38,51 @@ func TestDeposit_Script(t *testing.T) {
 
 	testutils.AssertBytesEqual(t, expectedScript, script)
 }
+
+func TestDeposit_TimeToRefundLocktime(t *testing.T) {
+	refundLocktime, err := hex.DecodeString("60bcea61")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Fill only the fields relevant for refund locktime computation.
+	d := new(Deposit)
+	copy(d.RefundLocktime[:], refundLocktime)
+
+	expectedRefundLocktimeTime := time.Date(2022, 1, 21, 14, 0, 0, 0, time.UTC)
+
+	if !expectedRefundLocktimeTime.Equal(d.RefundLocktimeTime()) {
+		t.Errorf(
+			"unexpected refund locktime time\n"+
+				"expected: [%v]\n"+
+				"actual:   [%v]",
+			expectedRefundLocktimeTime,
+			d.RefundLocktimeTime(),
+		)
+	}
+
+	var tests = map[string]struct {
+		now                          time.Time
+		expectedTimeToRefundLocktime time.Duration
+	}{
+		"refund locktime ahead": {
+			now:                          expectedRefundLocktimeTime.Add(-2 * time.Hour),
+			expectedTimeToRefundLocktime: 2 * time.Hour,
+		},
+		"refund locktime elapsed": {
+			now:                          expectedRefundLocktimeTime.Add(time.Hour),
+			expectedTimeToRefundLocktime: -time.Hour,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			testutils.AssertIntsEqual(
+				t,
+				"time to refund locktime",
+				int(test.expectedTimeToRefundLocktime),
+				int(d.TimeToRefundLocktime(test.now)),
+			)
+		})
+	}
+}
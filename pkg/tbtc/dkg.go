@@ -39,6 +39,10 @@ const (
 	// submission. Once the period elapses, the DKG state is checked to confirm
 	// the challenge was accepted successfully.
 	dkgResultChallengeConfirmationBlocks = 20
+	// dkgResultEventsBufferSize determines the capacity of buffers holding
+	// DKG result submission and challenge events received by a member
+	// while the result is published and monitored.
+	dkgResultEventsBufferSize = 10
 )
 
 // dkgExecutor is a component responsible for the full execution of ECDSA
@@ -274,7 +278,16 @@ func (de *dkgExecutor) generateSigningGroup(
 
 		go func() {
 			de.protocolLatch.Lock()
-			defer de.protocolLatch.Unlock()
+			// The latch is released once the result is published as
+			// monitoring the result challenges may take a long time.
+			latchReleased := false
+			releaseLatch := func() {
+				if !latchReleased {
+					latchReleased = true
+					de.protocolLatch.Unlock()
+				}
+			}
+			defer releaseLatch()
 
 			ctx, cancelCtx := withCancelOnBlock(
 				context.Background(),
@@ -283,9 +296,17 @@ func (de *dkgExecutor) generateSigningGroup(
 			)
 			defer cancelCtx()
 
-			// TODO: This subscription has to be updated once we implement
-			//       re-submitting DKG result to the chain after a challenge.
-			//       See https://github.com/keep-network/keep-core/issues/3450
+			// Submitted and challenged results are tracked so the result
+			// can be re-submitted if another result is challenged successfully.
+			submittedEvents := make(
+				chan *DKGResultSubmittedEvent,
+				dkgResultEventsBufferSize,
+			)
+			challengedEvents := make(
+				chan *DKGResultChallengedEvent,
+				dkgResultEventsBufferSize,
+			)
+
 			subscription := de.chain.OnDKGResultSubmitted(
 				func(event *DKGResultSubmittedEvent) {
 					defer cancelCtx()
@@ -300,9 +321,31 @@ func (de *dkgExecutor) generateSigningGroup(
 						event.BlockNumber,
 						event.Result.SubmitterMemberIndex,
 					)
+
+					select {
+					case submittedEvents <- event:
+					default:
+						dkgLogger.Warnf(
+							"[member:%v] DKG result submission event dropped",
+							memberIndex,
+						)
+					}
 				})
 			defer subscription.Unsubscribe()
 
+			challengeSubscription := de.chain.OnDKGResultChallenged(
+				func(event *DKGResultChallengedEvent) {
+					select {
+					case challengedEvents <- event:
+					default:
+						dkgLogger.Warnf(
+							"[member:%v] DKG result challenge event dropped",
+							memberIndex,
+						)
+					}
+				})
+			defer challengeSubscription.Unsubscribe()
+
 			announcer := announcer.New(
 				fmt.Sprintf("%v-%v", ProtocolName, "dkg"),
 				broadcastChannel,
@@ -409,7 +452,7 @@ func (de *dkgExecutor) generateSigningGroup(
 
 			dkgLogger.Infof("registered %s", signer)
 
-			err = de.publishDkgResult(
+			de.publishDkgResultAndResubmitOnChallenge(
 				ctx,
 				dkgLogger,
 				seed,
@@ -419,24 +462,12 @@ func (de *dkgExecutor) generateSigningGroup(
 				result,
 				groupSelectionResult,
 				startBlock,
+				dkgParameters,
+				dkgTimeoutBlock,
+				submittedEvents,
+				challengedEvents,
+				releaseLatch,
 			)
-			if err != nil {
-				if errors.Is(err, context.Canceled) {
-					dkgLogger.Infof(
-						"[member:%v] DKG is no longer awaiting the result; "+
-							"aborting DKG result publication",
-						memberIndex,
-					)
-					return
-				}
-
-				dkgLogger.Errorf(
-					"[member:%v] DKG result publication failed [%v]",
-					memberIndex,
-					err,
-				)
-				return
-			}
 		}()
 	}
 }
@@ -496,6 +527,91 @@ func (de *dkgExecutor) registerSigner(
 	return signer, nil
 }
 
+// publishDkgResultAndResubmitOnChallenge publishes the DKG result and, once
+// the result signatures are collected, monitors the submitted results to
+// re-submit the given result if another one is challenged successfully.
+// The given context is cancelled by a result submission of any member so,
+// the context cancellation does not mean the publication failed if the
+// signatures were already collected. The releaseLatch function is called once the
+// publication is done and before the potentially long monitoring starts.
+func (de *dkgExecutor) publishDkgResultAndResubmitOnChallenge(
+	ctx context.Context,
+	dkgLogger log.StandardLogger,
+	seed *big.Int,
+	memberIndex group.MemberIndex,
+	broadcastChannel net.BroadcastChannel,
+	membershipValidator *group.MembershipValidator,
+	result *dkg.Result,
+	groupSelectionResult *GroupSelectionResult,
+	startBlock uint64,
+	dkgParameters *DKGParameters,
+	dkgTimeoutBlock uint64,
+	submittedEvents <-chan *DKGResultSubmittedEvent,
+	challengedEvents <-chan *DKGResultChallengedEvent,
+	releaseLatch func(),
+) {
+	resultSubmitter := newDkgResultSubmitter(
+		dkgLogger,
+		de.chain,
+		de.groupParameters,
+		groupSelectionResult,
+		de.waitForBlockFn,
+	)
+
+	err := de.publishDkgResult(
+		ctx,
+		dkgLogger,
+		seed,
+		memberIndex,
+		broadcastChannel,
+		membershipValidator,
+		result,
+		startBlock,
+		resultSubmitter,
+	)
+	if err != nil {
+		if !errors.Is(err, context.Canceled) {
+			dkgLogger.Errorf(
+				"[member:%v] DKG result publication failed [%v]",
+				memberIndex,
+				err,
+			)
+			return
+		}
+
+		if len(resultSubmitter.collectedSignatures()) == 0 {
+			dkgLogger.Infof(
+				"[member:%v] DKG is no longer awaiting the result; "+
+					"aborting DKG result publication",
+				memberIndex,
+			)
+			return
+		}
+
+		// Another member submitted a result after the signatures were
+		// collected. That result may still be challenged so, the monitoring
+		// must not be skipped.
+		dkgLogger.Infof(
+			"[member:%v] DKG result submitted by another member; "+
+				"monitoring the submitted result",
+			memberIndex,
+		)
+	}
+
+	releaseLatch()
+
+	de.resubmitDkgResultOnChallenge(
+		dkgLogger,
+		memberIndex,
+		result,
+		resultSubmitter,
+		dkgParameters,
+		dkgTimeoutBlock,
+		submittedEvents,
+		challengedEvents,
+	)
+}
+
 // publishDkgResult performs the DKG result publication process.
 func (de *dkgExecutor) publishDkgResult(
 	ctx context.Context,
@@ -505,8 +621,8 @@ func (de *dkgExecutor) publishDkgResult(
 	broadcastChannel net.BroadcastChannel,
 	membershipValidator *group.MembershipValidator,
 	dkgResult *dkg.Result,
-	groupSelectionResult *GroupSelectionResult,
 	startBlock uint64,
+	resultSubmitter *dkgResultSubmitter,
 ) error {
 	return dkg.Publish(
 		ctx,
@@ -516,17 +632,166 @@ func (de *dkgExecutor) publishDkgResult(
 		broadcastChannel,
 		membershipValidator,
 		newDkgResultSigner(de.chain, startBlock),
-		newDkgResultSubmitter(
-			dkgLogger,
-			de.chain,
-			de.groupParameters,
-			groupSelectionResult,
-			de.waitForBlockFn,
-		),
+		resultSubmitter,
 		dkgResult,
 	)
 }
 
+// resubmitDkgResultOnChallenge monitors the DKG results submitted to the
+// chain and re-submits the given result, along with the supporting member
+// signatures collected during the publication, if a submitted result is
+// challenged successfully. The result signatures remain valid after
+// a challenge as they are computed for the DKG start block. Members
+// re-submit the result in the same staggered order as during the original
+// submission. The function returns once a submitted result survives the
+// challenge period or no result is submitted before the submission timeout.
+func (de *dkgExecutor) resubmitDkgResultOnChallenge(
+	dkgLogger log.StandardLogger,
+	memberIndex group.MemberIndex,
+	result *dkg.Result,
+	resultSubmitter *dkgResultSubmitter,
+	dkgParameters *DKGParameters,
+	dkgTimeoutBlock uint64,
+	submittedEvents <-chan *DKGResultSubmittedEvent,
+	challengedEvents <-chan *DKGResultChallengedEvent,
+) {
+	signatures := resultSubmitter.collectedSignatures()
+	if len(signatures) == 0 {
+		// The member never collected the signatures required to submit
+		// the result so, it cannot re-submit it either.
+		return
+	}
+
+	submission := waitForDkgResultSubmission(
+		dkgTimeoutBlock,
+		de.waitForBlockFn,
+		submittedEvents,
+	)
+
+	for submission != nil {
+		challengeTimeoutBlock := submission.BlockNumber +
+			dkgParameters.ChallengePeriodBlocks +
+			dkgResultChallengeConfirmationBlocks
+
+		challenge := waitForDkgResultChallenge(
+			submission.ResultHash,
+			challengeTimeoutBlock,
+			de.waitForBlockFn,
+			challengedEvents,
+		)
+		if challenge == nil {
+			dkgLogger.Infof(
+				"[member:%v] DKG result with hash [0x%x] was not "+
+					"challenged; result re-submission is not needed",
+				memberIndex,
+				submission.ResultHash,
+			)
+			return
+		}
+
+		dkgLogger.Infof(
+			"[member:%v] DKG result with hash [0x%x] challenged at "+
+				"block [%v]; re-submitting DKG result",
+			memberIndex,
+			challenge.ResultHash,
+			challenge.BlockNumber,
+		)
+
+		// A successful challenge restarts the result submission period.
+		submissionTimeoutBlock := challenge.BlockNumber +
+			dkgParameters.SubmissionTimeoutBlocks
+
+		ctx, cancelCtx := withCancelOnBlock(
+			context.Background(),
+			submissionTimeoutBlock,
+			de.waitForBlockFn,
+		)
+
+		// Wait for the next submission in the background. The submission
+		// of another member cancels the context so, the result is not
+		// submitted twice.
+		nextSubmissionChan := make(chan *DKGResultSubmittedEvent, 1)
+		go func() {
+			select {
+			case event := <-submittedEvents:
+				cancelCtx()
+				nextSubmissionChan <- event
+			case <-ctx.Done():
+				nextSubmissionChan <- nil
+			}
+		}()
+
+		err := resultSubmitter.SubmitResult(ctx, memberIndex, result, signatures)
+		if err != nil {
+			dkgLogger.Errorf(
+				"[member:%v] DKG result re-submission failed [%v]",
+				memberIndex,
+				err,
+			)
+			cancelCtx()
+			return
+		}
+
+		submission = <-nextSubmissionChan
+		cancelCtx()
+	}
+
+	dkgLogger.Infof(
+		"[member:%v] no DKG result submitted before the submission timeout",
+		memberIndex,
+	)
+}
+
+// waitForDkgResultSubmission waits for the DKG result submission event until
+// the given timeout block. Returns nil if no result was submitted on time.
+func waitForDkgResultSubmission(
+	timeoutBlock uint64,
+	waitForBlockFn waitForBlockFn,
+	submittedEvents <-chan *DKGResultSubmittedEvent,
+) *DKGResultSubmittedEvent {
+	ctx, cancelCtx := withCancelOnBlock(
+		context.Background(),
+		timeoutBlock,
+		waitForBlockFn,
+	)
+	defer cancelCtx()
+
+	select {
+	case event := <-submittedEvents:
+		return event
+	case <-ctx.Done():
+		return nil
+	}
+}
+
+// waitForDkgResultChallenge waits for the challenge of the DKG result with
+// the given hash until the given timeout block. Challenges of other results
+// are ignored. Returns nil if the result was not challenged on time.
+func waitForDkgResultChallenge(
+	resultHash DKGChainResultHash,
+	timeoutBlock uint64,
+	waitForBlockFn waitForBlockFn,
+	challengedEvents <-chan *DKGResultChallengedEvent,
+) *DKGResultChallengedEvent {
+	ctx, cancelCtx := withCancelOnBlock(
+		context.Background(),
+		timeoutBlock,
+		waitForBlockFn,
+	)
+	defer cancelCtx()
+
+	for {
+		select {
+		case event := <-challengedEvents:
+			if event.ResultHash == resultHash {
+				return event
+			}
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
 // executeDkgValidation performs the submitted DKG result validation process.
 // If the result is not valid, this function submits an on-chain result
 // challenge. If the result is valid and the given node was involved in the DKG,
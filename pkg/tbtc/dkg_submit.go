@@ -3,6 +3,7 @@ package tbtc
 import (
 	"context"
 	"fmt"
+	"sync"
 
 	"github.com/ipfs/go-log/v2"
 	"github.com/keep-network/keep-core/pkg/protocol/group"
@@ -83,6 +84,12 @@ type dkgResultSubmitter struct {
 	groupSelectionResult *GroupSelectionResult
 
 	waitForBlockFn waitForBlockFn
+
+	// signatures holds the supporting member signatures of the last result
+	// passed for submission. They are retained so the same result can be
+	// re-submitted if another result is successfully challenged.
+	signaturesMutex sync.Mutex
+	signatures      map[group.MemberIndex][]byte
 }
 
 func newDkgResultSubmitter(
@@ -120,6 +127,10 @@ func (drs *dkgResultSubmitter) SubmitResult(
 		)
 	}
 
+	drs.signaturesMutex.Lock()
+	drs.signatures = signatures
+	drs.signaturesMutex.Unlock()
+
 	dkgState, err := drs.chain.GetDKGState()
 	if err != nil {
 		return fmt.Errorf("could not check DKG state: [%w]", err)
@@ -212,3 +223,12 @@ func (drs *dkgResultSubmitter) SubmitResult(
 
 	return drs.chain.SubmitDKGResult(dkgResult)
 }
+
+// collectedSignatures returns the supporting member signatures of the last
+// result passed for submission or nil if no result was passed yet.
+func (drs *dkgResultSubmitter) collectedSignatures() map[group.MemberIndex][]byte {
+	drs.signaturesMutex.Lock()
+	defer drs.signaturesMutex.Unlock()
+
+	return drs.signatures
+}
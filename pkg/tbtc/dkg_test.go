@@ -1,9 +1,12 @@
 package tbtc
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"math/big"
 	"reflect"
+	"sync"
 	"testing"
 	"time"
 
@@ -14,6 +17,7 @@ import (
 	"github.com/keep-network/keep-core/internal/testutils"
 	"github.com/keep-network/keep-core/pkg/chain"
 	"github.com/keep-network/keep-core/pkg/internal/tecdsatest"
+	netlocal "github.com/keep-network/keep-core/pkg/net/local"
 	"github.com/keep-network/keep-core/pkg/protocol/group"
 	"github.com/keep-network/keep-core/pkg/tecdsa"
 	"github.com/keep-network/keep-core/pkg/tecdsa/dkg"
@@ -378,6 +382,471 @@ func TestDkgExecutor_ExecuteDkgValidation(t *testing.T) {
 	}
 }
 
+func TestDkgExecutor_ResubmitDkgResultOnChallenge(t *testing.T) {
+	testData, err := tecdsatest.LoadPrivateKeyShareTestFixtures(1)
+	if err != nil {
+		t.Fatalf("failed to load test data: [%v]", err)
+	}
+
+	groupParameters := &GroupParameters{
+		GroupSize:       5,
+		GroupQuorum:     4,
+		HonestThreshold: 3,
+	}
+
+	localChain := Connect()
+
+	operatorAddress, err := localChain.operatorAddress()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	operatorID, err := localChain.GetOperatorID(operatorAddress)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	signatures := make(map[group.MemberIndex][]byte)
+	operatorsIDs := make(chain.OperatorIDs, groupParameters.GroupSize)
+	operatorsAddresses := make(chain.Addresses, groupParameters.GroupSize)
+
+	for memberIndex := uint8(1); int(memberIndex) <= groupParameters.GroupSize; memberIndex++ {
+		signatures[memberIndex] = []byte{memberIndex}
+		operatorsIDs[memberIndex-1] = operatorID
+		operatorsAddresses[memberIndex-1] = operatorAddress
+	}
+
+	groupSelectionResult := &GroupSelectionResult{
+		OperatorsIDs:       operatorsIDs,
+		OperatorsAddresses: operatorsAddresses,
+	}
+
+	submittedEvents := make(chan *DKGResultSubmittedEvent, 10)
+	_ = localChain.OnDKGResultSubmitted(
+		func(event *DKGResultSubmittedEvent) {
+			submittedEvents <- event
+		},
+	)
+
+	challengedEvents := make(chan *DKGResultChallengedEvent, 10)
+	_ = localChain.OnDKGResultChallenged(
+		func(event *DKGResultChallengedEvent) {
+			challengedEvents <- event
+		},
+	)
+
+	err = localChain.startDKG()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A malicious member submits an invalid result first.
+	maliciousResult := &DKGChainResult{
+		SubmitterMemberIndex: group.MemberIndex(2),
+		GroupPublicKey:       []byte{0x01, 0x02, 0x03},
+	}
+
+	err = localChain.setDKGResultValidity(false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = localChain.SubmitDKGResult(maliciousResult)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The honest member attempts to submit its result but the DKG is no
+	// longer awaiting the result. The signatures should be retained anyway.
+	resultSubmitter := newDkgResultSubmitter(
+		&testutils.MockLogger{},
+		localChain,
+		groupParameters,
+		groupSelectionResult,
+		testWaitForBlockFn(localChain),
+	)
+
+	result := &dkg.Result{
+		Group:           group.NewGroup(groupParameters.DishonestThreshold(), groupParameters.GroupSize),
+		PrivateKeyShare: tecdsa.NewPrivateKeyShare(testData[0]),
+	}
+
+	memberIndex := group.MemberIndex(1)
+
+	err = resultSubmitter.SubmitResult(
+		context.Background(),
+		memberIndex,
+		result,
+		signatures,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = localChain.ChallengeDKGResult(maliciousResult)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = localChain.setDKGResultValidity(true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dkgParameters, err := localChain.DKGParameters()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Setting only the fields really needed for this test.
+	dkgExecutor := &dkgExecutor{
+		groupParameters: groupParameters,
+		chain:           localChain,
+		waitForBlockFn:  testWaitForBlockFn(localChain),
+	}
+
+	dkgExecutor.resubmitDkgResultOnChallenge(
+		&testutils.MockLogger{},
+		memberIndex,
+		result,
+		resultSubmitter,
+		dkgParameters,
+		dkgParameters.SubmissionTimeoutBlocks,
+		submittedEvents,
+		challengedEvents,
+	)
+
+	expectedGroupPublicKey, err := result.GroupPublicKeyBytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resubmittedResult := localChain.dkgResult
+
+	testutils.AssertIntsEqual(
+		t,
+		"submitter member index",
+		int(memberIndex),
+		int(resubmittedResult.SubmitterMemberIndex),
+	)
+	testutils.AssertBytesEqual(
+		t,
+		expectedGroupPublicKey,
+		resubmittedResult.GroupPublicKey,
+	)
+
+	// The local chain packs signatures in the map iteration order so,
+	// the order is not deterministic.
+	actualSignatures := append([]byte{}, resubmittedResult.Signatures...)
+	slices.Sort(actualSignatures)
+	testutils.AssertBytesEqual(t, []byte{1, 2, 3, 4, 5}, actualSignatures)
+
+	dkgState, err := localChain.GetDKGState()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertIntsEqual(
+		t,
+		"DKG state",
+		int(Challenge),
+		int(dkgState),
+	)
+}
+
+// TestDkgExecutor_PublishDkgResultAndResubmitOnChallenge covers the scenario
+// in which a malicious member submits an invalid result right after the
+// result signatures are collected. The submission cancels the publication
+// of honest members who must still re-submit their result once the invalid
+// one is challenged.
+func TestDkgExecutor_PublishDkgResultAndResubmitOnChallenge(t *testing.T) {
+	testData, err := tecdsatest.LoadPrivateKeyShareTestFixtures(1)
+	if err != nil {
+		t.Fatalf("failed to load test data: [%v]", err)
+	}
+
+	groupParameters := &GroupParameters{
+		GroupSize:       5,
+		GroupQuorum:     4,
+		HonestThreshold: 3,
+	}
+
+	localChain := Connect(100 * time.Millisecond)
+
+	operatorAddress, err := localChain.operatorAddress()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	operatorID, err := localChain.GetOperatorID(operatorAddress)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, operatorPublicKey, err := localChain.OperatorKeyPair()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	operatorsIDs := make(chain.OperatorIDs, groupParameters.GroupSize)
+	operatorsAddresses := make(chain.Addresses, groupParameters.GroupSize)
+	for i := 0; i < groupParameters.GroupSize; i++ {
+		operatorsIDs[i] = operatorID
+		operatorsAddresses[i] = operatorAddress
+	}
+
+	groupSelectionResult := &GroupSelectionResult{
+		OperatorsIDs:       operatorsIDs,
+		OperatorsAddresses: operatorsAddresses,
+	}
+
+	result := &dkg.Result{
+		Group:           group.NewGroup(groupParameters.DishonestThreshold(), groupParameters.GroupSize),
+		PrivateKeyShare: tecdsa.NewPrivateKeyShare(testData[0]),
+	}
+
+	groupPublicKey, err := result.GroupPublicKeyBytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	maliciousGroupPublicKey := []byte{0x01, 0x02, 0x03}
+
+	// Only the result submitted by the malicious member is invalid.
+	localChain.dkgResultValidityGuard = func(dkgResult *DKGChainResult) bool {
+		return !bytes.Equal(maliciousGroupPublicKey, dkgResult.GroupPublicKey)
+	}
+
+	dkgExecutor := &dkgExecutor{
+		groupParameters: groupParameters,
+		chain:           localChain,
+		netProvider:     netlocal.ConnectWithKey(operatorPublicKey),
+		waitForBlockFn:  testWaitForBlockFn(localChain),
+	}
+
+	seed := big.NewInt(100)
+
+	membershipValidator := group.NewMembershipValidator(
+		&testutils.MockLogger{},
+		operatorsAddresses,
+		localChain.Signing(),
+	)
+
+	broadcastChannel, err := dkgExecutor.setupBroadcastChannel(
+		seed,
+		membershipValidator,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dkgParameters := &DKGParameters{
+		SubmissionTimeoutBlocks: 100,
+		ChallengePeriodBlocks:   15,
+	}
+
+	dkgTimeoutBlock := dkgParameters.SubmissionTimeoutBlocks
+
+	submissions := make(chan *DKGResultSubmittedEvent, 10)
+	_ = localChain.OnDKGResultSubmitted(
+		func(event *DKGResultSubmittedEvent) {
+			submissions <- event
+		},
+	)
+
+	err = localChain.startDKG()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wg := sync.WaitGroup{}
+
+	// Honest members publish the result just like generateSigningGroup does.
+	for memberIndex := group.MemberIndex(2); int(memberIndex) <= groupParameters.GroupSize; memberIndex++ {
+		ctx, cancelCtx := withCancelOnBlock(
+			context.Background(),
+			dkgTimeoutBlock,
+			dkgExecutor.waitForBlockFn,
+		)
+
+		submittedEvents := make(
+			chan *DKGResultSubmittedEvent,
+			dkgResultEventsBufferSize,
+		)
+		challengedEvents := make(
+			chan *DKGResultChallengedEvent,
+			dkgResultEventsBufferSize,
+		)
+
+		_ = localChain.OnDKGResultSubmitted(
+			func(event *DKGResultSubmittedEvent) {
+				defer cancelCtx()
+				submittedEvents <- event
+			},
+		)
+		_ = localChain.OnDKGResultChallenged(
+			func(event *DKGResultChallengedEvent) {
+				challengedEvents <- event
+			},
+		)
+
+		wg.Add(1)
+		go func(memberIndex group.MemberIndex) {
+			defer wg.Done()
+			defer cancelCtx()
+
+			dkgExecutor.publishDkgResultAndResubmitOnChallenge(
+				ctx,
+				&testutils.MockLogger{},
+				seed,
+				memberIndex,
+				broadcastChannel,
+				membershipValidator,
+				result,
+				groupSelectionResult,
+				0,
+				dkgParameters,
+				dkgTimeoutBlock,
+				submittedEvents,
+				challengedEvents,
+				func() {},
+			)
+		}(memberIndex)
+	}
+
+	// The malicious member takes part in the result signing but submits
+	// a different result using the collected signatures. The submission is
+	// slightly delayed to let honest members collect the signatures as well.
+	go func() {
+		_ = dkg.Publish(
+			context.Background(),
+			&testutils.MockLogger{},
+			seed.Text(16),
+			group.MemberIndex(1),
+			broadcastChannel,
+			membershipValidator,
+			newDkgResultSigner(localChain, 0),
+			&maliciousDkgResultSubmitter{
+				chain:                localChain,
+				waitForBlockFn:       dkgExecutor.waitForBlockFn,
+				groupSelectionResult: groupSelectionResult,
+				groupPublicKey:       maliciousGroupPublicKey,
+			},
+			result,
+		)
+	}()
+
+	awaitSubmission := func() *DKGResultSubmittedEvent {
+		select {
+		case event := <-submissions:
+			return event
+		case <-time.After(1 * time.Minute):
+			t.Fatal("timeout while waiting for the DKG result submission")
+			return nil
+		}
+	}
+
+	maliciousSubmission := awaitSubmission()
+
+	testutils.AssertBytesEqual(
+		t,
+		maliciousGroupPublicKey,
+		maliciousSubmission.Result.GroupPublicKey,
+	)
+
+	err = localChain.ChallengeDKGResult(maliciousSubmission.Result)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resubmission := awaitSubmission()
+
+	if resubmission.Result.SubmitterMemberIndex == 1 {
+		t.Errorf("DKG result re-submitted by the malicious member")
+	}
+	testutils.AssertBytesEqual(
+		t,
+		groupPublicKey,
+		resubmission.Result.GroupPublicKey,
+	)
+
+	wg.Wait()
+
+	select {
+	case event := <-submissions:
+		t.Errorf(
+			"unexpected DKG result submission by member [%v]",
+			event.Result.SubmitterMemberIndex,
+		)
+	default:
+	}
+
+	dkgState, err := localChain.GetDKGState()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertIntsEqual(
+		t,
+		"DKG state",
+		int(Challenge),
+		int(dkgState),
+	)
+}
+
+// maliciousDkgResultSubmitter submits a DKG result with the given group
+// public key instead of the one agreed upon during the result publication.
+// The result is submitted two blocks after the signatures are collected.
+type maliciousDkgResultSubmitter struct {
+	chain                *localChain
+	waitForBlockFn       waitForBlockFn
+	groupSelectionResult *GroupSelectionResult
+	groupPublicKey       []byte
+}
+
+func (mdrs *maliciousDkgResultSubmitter) SubmitResult(
+	ctx context.Context,
+	memberIndex group.MemberIndex,
+	result *dkg.Result,
+	signatures map[group.MemberIndex][]byte,
+) error {
+	groupPublicKey, err := result.GroupPublicKey()
+	if err != nil {
+		return err
+	}
+
+	dkgResult, err := mdrs.chain.AssembleDKGResult(
+		memberIndex,
+		groupPublicKey,
+		result.Group.OperatingMemberIndexes(),
+		result.MisbehavedMembersIndexes(),
+		signatures,
+		mdrs.groupSelectionResult,
+	)
+	if err != nil {
+		return err
+	}
+
+	dkgResult.GroupPublicKey = mdrs.groupPublicKey
+
+	blockCounter, err := mdrs.chain.BlockCounter()
+	if err != nil {
+		return err
+	}
+
+	currentBlock, err := blockCounter.CurrentBlock()
+	if err != nil {
+		return err
+	}
+
+	err = mdrs.waitForBlockFn(ctx, currentBlock+2)
+	if err != nil {
+		return err
+	}
+
+	return mdrs.chain.SubmitDKGResult(dkgResult)
+}
+
 func TestFinalSigningGroup(t *testing.T) {
 	groupParameters := &GroupParameters{
 		GroupSize:       5,
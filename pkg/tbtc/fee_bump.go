@@ -0,0 +1,751 @@
+package tbtc
+
+import (
+	"crypto/ecdsa"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ipfs/go-log/v2"
+	"go.uber.org/zap"
+
+	"github.com/keep-network/keep-common/pkg/persistence"
+
+	"github.com/keep-network/keep-core/pkg/bitcoin"
+)
+
+const (
+	// feeBumpProposalValidityBlocks determines the fee bump proposal validity
+	// time expressed in blocks. In other words, this is the worst-case time
+	// for a fee bump during which the wallet is busy and cannot take another
+	// actions. The replaced transaction can be a deposit sweep transaction
+	// with many inputs so, the value is the same as for the deposit sweep
+	// action. The value of 1200 blocks is roughly 4 hours, assuming 12 seconds
+	// per block.
+	feeBumpProposalValidityBlocks = 1200
+	// feeBumpSigningTimeoutSafetyMargin determines the duration of the
+	// safety margin that must be preserved between the signing timeout
+	// and the timeout of the entire fee bump action. This safety margin
+	// prevents against the case where signing completes late and there is
+	// not enough time to broadcast the replacement transaction properly.
+	// In such a case, wallet signatures may leak and make the wallet subject
+	// of fraud accusations. Usage of the safety margin ensures there is enough
+	// time to perform post-signing steps of the fee bump action.
+	feeBumpSigningTimeoutSafetyMargin = 1 * time.Hour
+	// feeBumpBroadcastTimeout determines the time window for replacement
+	// transaction broadcast. It is guaranteed that at least
+	// feeBumpSigningTimeoutSafetyMargin is preserved for the broadcast
+	// step. However, the happy path for the broadcast step is usually quick
+	// and few retries are needed to recover from temporary problems. That
+	// said, if the broadcast step does not succeed in a tight timeframe,
+	// there is no point to retry for the entire possible time window.
+	// Hence, the timeout for broadcast step is set as 25% of the entire
+	// time widow determined by feeBumpSigningTimeoutSafetyMargin.
+	feeBumpBroadcastTimeout = feeBumpSigningTimeoutSafetyMargin / 4
+	// feeBumpBroadcastCheckDelay determines the delay that must
+	// be preserved between transaction broadcast and the check that ensures
+	// the transaction is known on the Bitcoin chain. This delay is needed
+	// as spreading the transaction over the Bitcoin network takes time.
+	feeBumpBroadcastCheckDelay = 1 * time.Minute
+)
+
+// FeeBumpProposal represents a fee bump proposal issued by a wallet's
+// coordination leader. The proposal replaces the wallet's pending transaction
+// with a transaction paying a higher fee, as described in BIP-0125.
+type FeeBumpProposal struct {
+	// TransactionHash is the hash of the wallet's pending transaction that
+	// should be replaced.
+	TransactionHash bitcoin.Hash
+	// TransactionFee is the fee of the replacement transaction.
+	TransactionFee *big.Int
+}
+
+func (fbp *FeeBumpProposal) actionType() WalletActionType {
+	return ActionFeeBump
+}
+
+func (fbp *FeeBumpProposal) validityBlocks() uint64 {
+	return feeBumpProposalValidityBlocks
+}
+
+// MinimumReplacementFee returns the minimum fee the transaction replacing
+// the given transaction paying the given fee must pay. According to BIP-0125,
+// the replacement must pay for its own bandwidth at the minimum relay fee
+// rate of 1 satoshi per vbyte on top of the fee paid by the replaced
+// transaction. The replacement has the same inputs and outputs as the
+// replaced transaction so, the virtual size of the replaced transaction
+// is used.
+func MinimumReplacementFee(transaction *bitcoin.Transaction, fee int64) int64 {
+	return fee + transaction.VirtualSize()
+}
+
+// PendingWalletTransaction represents a wallet transaction that was signed by
+// the wallet but is not confirmed on the Bitcoin chain yet.
+type PendingWalletTransaction struct {
+	// Transaction is the latest signed version of the transaction.
+	Transaction *bitcoin.Transaction
+	// Fee is the fee paid by the latest signed version of the transaction.
+	Fee int64
+	// SignedAtBlockHeight is the Bitcoin chain height at the moment the
+	// latest version of the transaction was signed and handed over for
+	// broadcast.
+	SignedAtBlockHeight uint
+}
+
+// walletTransactionProposal is a coordination proposal of a wallet action
+// that submits a Bitcoin transaction whose fee can be bumped.
+type walletTransactionProposal interface {
+	CoordinationProposal
+	walletPublicKeyHashSetter
+
+	// transactionFee returns the fee of the proposed transaction.
+	transactionFee() int64
+	// withTransactionFee returns a copy of the proposal with the given
+	// transaction fee.
+	withTransactionFee(fee int64) walletTransactionProposal
+}
+
+// pendingWalletTransactionsDirectory is the name of the work persistence
+// directory holding pending wallet transactions.
+const pendingWalletTransactionsDirectory = "pending_wallet_transactions"
+
+// pendingWalletTransaction is a record of the pendingWalletTransactions
+// registry.
+type pendingWalletTransaction struct {
+	PendingWalletTransaction
+
+	// replacedTransactionsHashes holds hashes of all previous versions of
+	// the transaction that were replaced by the latest one. Any of them
+	// can still get confirmed on the Bitcoin chain.
+	replacedTransactionsHashes []bitcoin.Hash
+	// walletPublicKey is the public key of the wallet that signed the
+	// transaction.
+	walletPublicKey *ecdsa.PublicKey
+	// walletMainUtxo is the wallet's main UTXO the transaction was assembled
+	// with. It is nil if the wallet had no main UTXO at that time.
+	walletMainUtxo *bitcoin.UnspentTransactionOutput
+	// proposal is the proposal of the wallet action the transaction
+	// belongs to. Replacement transactions are assembled from this proposal
+	// with the replacement fee.
+	proposal walletTransactionProposal
+}
+
+// name returns the name of the file holding the pending transaction in the
+// persistence layer. A wallet has at most one pending transaction so, the
+// wallet public key hash is used.
+func (pwt *pendingWalletTransaction) name() string {
+	return fmt.Sprintf("%x", bitcoin.PublicKeyHash(pwt.walletPublicKey))
+}
+
+// pendingWalletTransactions is a registry of the latest transactions signed by
+// wallets controlled by the node. The registry lets the wallet's coordination
+// leader propose a fee bump of a transaction that got stuck and lets the
+// followers validate and assemble the replacement transaction. A wallet has
+// at most one pending transaction at a time as a new transaction always
+// spends the outcome of the previous one. The registry is stored using the
+// underlying persistence layer so, transactions signed before a node restart
+// can still be bumped.
+type pendingWalletTransactions struct {
+	mutex sync.Mutex
+
+	// persistence is the handle to the underlying work persistence layer.
+	persistence persistence.BasicHandle
+
+	// transactions holds the pending transactions by the 20-byte public key
+	// hash of the wallet.
+	transactions map[[20]byte]*pendingWalletTransaction
+}
+
+// newPendingWalletTransactions creates a new instance of the
+// pendingWalletTransactions registry. The registry is pre-populated using
+// the pending transactions already stored in the persistence layer.
+func newPendingWalletTransactions(
+	persistence persistence.BasicHandle,
+) *pendingWalletTransactions {
+	pwt := &pendingWalletTransactions{
+		persistence:  persistence,
+		transactions: make(map[[20]byte]*pendingWalletTransaction),
+	}
+
+	transactions := pwt.loadTransactions()
+	for _, transaction := range transactions {
+		walletPublicKeyHash := bitcoin.PublicKeyHash(transaction.walletPublicKey)
+		pwt.transactions[walletPublicKeyHash] = transaction
+	}
+
+	if len(transactions) > 0 {
+		logger.Infof(
+			"[%v] pending wallet transactions loaded from storage",
+			len(transactions),
+		)
+	}
+
+	return pwt
+}
+
+// record records the given transaction as the pending transaction of the
+// wallet with the given public key. Any transaction recorded previously for
+// the wallet is forgotten. The wallet main UTXO and the proposal the
+// transaction was assembled from are used to assemble replacement
+// transactions.
+func (pwt *pendingWalletTransactions) record(
+	walletPublicKey *ecdsa.PublicKey,
+	transaction *bitcoin.Transaction,
+	signedAtBlockHeight uint,
+	walletMainUtxo *bitcoin.UnspentTransactionOutput,
+	proposal walletTransactionProposal,
+) {
+	pwt.mutex.Lock()
+	defer pwt.mutex.Unlock()
+
+	pwt.store(&pendingWalletTransaction{
+		PendingWalletTransaction: PendingWalletTransaction{
+			Transaction:         transaction,
+			Fee:                 proposal.transactionFee(),
+			SignedAtBlockHeight: signedAtBlockHeight,
+		},
+		walletPublicKey: walletPublicKey,
+		walletMainUtxo:  walletMainUtxo,
+		proposal:        proposal,
+	})
+}
+
+// replace records the given transaction as the latest version of the pending
+// transaction of the given wallet. Returns an error if the latest version of
+// the wallet's pending transaction is not the replaced one.
+func (pwt *pendingWalletTransactions) replace(
+	walletPublicKeyHash [20]byte,
+	replacedTransactionHash bitcoin.Hash,
+	transaction *bitcoin.Transaction,
+	fee int64,
+	signedAtBlockHeight uint,
+) error {
+	pwt.mutex.Lock()
+	defer pwt.mutex.Unlock()
+
+	current, ok := pwt.transactions[walletPublicKeyHash]
+	if !ok {
+		return fmt.Errorf("wallet has no pending transaction")
+	}
+
+	if current.Transaction.Hash() != replacedTransactionHash {
+		return fmt.Errorf(
+			"pending transaction of the wallet is [%s]",
+			current.Transaction.Hash().Hex(bitcoin.ReversedByteOrder),
+		)
+	}
+
+	pwt.store(&pendingWalletTransaction{
+		PendingWalletTransaction: PendingWalletTransaction{
+			Transaction:         transaction,
+			Fee:                 fee,
+			SignedAtBlockHeight: signedAtBlockHeight,
+		},
+		replacedTransactionsHashes: append(
+			append([]bitcoin.Hash{}, current.replacedTransactionsHashes...),
+			replacedTransactionHash,
+		),
+		walletPublicKey: current.walletPublicKey,
+		walletMainUtxo:  current.walletMainUtxo,
+		proposal:        current.proposal,
+	})
+
+	return nil
+}
+
+// store puts the given pending transaction into the registry and persists
+// it, overwriting the previous pending transaction of the wallet. A failure
+// to persist the transaction is only logged as the transaction can still
+// be bumped as long as the node is not restarted. This function must be
+// called with the registry's mutex held.
+func (pwt *pendingWalletTransactions) store(pending *pendingWalletTransaction) {
+	pwt.transactions[bitcoin.PublicKeyHash(pending.walletPublicKey)] = pending
+
+	bytes, err := pending.Marshal()
+	if err != nil {
+		logger.Errorf(
+			"cannot marshal pending transaction [%s]: [%v]",
+			pending.Transaction.Hash().Hex(bitcoin.ReversedByteOrder),
+			err,
+		)
+		return
+	}
+
+	err = pwt.persistence.Save(
+		bytes,
+		pendingWalletTransactionsDirectory,
+		pending.name(),
+	)
+	if err != nil {
+		logger.Errorf(
+			"cannot save pending transaction [%s]: [%v]",
+			pending.Transaction.Hash().Hex(bitcoin.ReversedByteOrder),
+			err,
+		)
+	}
+}
+
+// unconfirmed returns the pending transaction of the given wallet if none of
+// its versions is confirmed on the Bitcoin chain. The pending transaction is
+// removed from the registry once any of its versions gets confirmed.
+// The latest version is looked up first. If its confirmations cannot be
+// determined, e.g. because of a Bitcoin backend failure, the transaction is
+// not considered unconfirmed as it may not be stuck at all. Otherwise, the
+// latest version is known to the Bitcoin chain so, previous versions whose
+// confirmations cannot be determined are considered evicted from the mempool
+// by the replacement. The returned bool value is false if the wallet has no
+// unconfirmed pending transaction.
+func (pwt *pendingWalletTransactions) unconfirmed(
+	walletPublicKeyHash [20]byte,
+	btcChain bitcoin.Chain,
+) (*pendingWalletTransaction, bool) {
+	pwt.mutex.Lock()
+	pending, ok := pwt.transactions[walletPublicKeyHash]
+	pwt.mutex.Unlock()
+
+	if !ok {
+		return nil, false
+	}
+
+	hashes := append(
+		[]bitcoin.Hash{pending.Transaction.Hash()},
+		pending.replacedTransactionsHashes...,
+	)
+
+	for i, hash := range hashes {
+		confirmations, err := btcChain.GetTransactionConfirmations(hash)
+		if err != nil {
+			logger.Warnf(
+				"cannot get confirmations of transaction [%s] of wallet "+
+					"PKH [0x%x]: [%v]",
+				hash.Hex(bitcoin.ReversedByteOrder),
+				walletPublicKeyHash,
+				err,
+			)
+
+			// The latest version must be known to the Bitcoin chain.
+			if i == 0 {
+				return nil, false
+			}
+
+			continue
+		}
+
+		if confirmations > 0 {
+			pwt.mutex.Lock()
+			// Remove the record only if it was not replaced in the meantime.
+			if pwt.transactions[walletPublicKeyHash] == pending {
+				delete(pwt.transactions, walletPublicKeyHash)
+
+				err := pwt.persistence.Delete(
+					pendingWalletTransactionsDirectory,
+					pending.name(),
+				)
+				if err != nil {
+					logger.Errorf(
+						"cannot delete confirmed pending transaction of "+
+							"wallet PKH [0x%x]: [%v]",
+						walletPublicKeyHash,
+						err,
+					)
+				}
+			}
+			pwt.mutex.Unlock()
+
+			return nil, false
+		}
+	}
+
+	return pending, true
+}
+
+// loadTransactions loads all pending transactions stored using the underlying
+// persistence layer.
+func (pwt *pendingWalletTransactions) loadTransactions() []*pendingWalletTransaction {
+	transactions := make([]*pendingWalletTransaction, 0)
+
+	descriptorsChan, errorsChan := pwt.persistence.ReadAll()
+
+	// Two goroutines read from descriptors and errors channels and either
+	// add the transaction to the result slice or outputs a log error.
+	// The reason for using two goroutines at the same time - one for
+	// descriptors and one for errors - is that channels do not have to be
+	// buffered, and we do not know in what order the information is written to
+	// channels.
+	var wg sync.WaitGroup
+	wg.Add(2)
+
+	go func() {
+		for descriptor := range descriptorsChan {
+			// Read only the files located in the pending wallet transactions
+			// directory as the work persistence is shared.
+			if descriptor.Directory() != pendingWalletTransactionsDirectory {
+				continue
+			}
+
+			content, err := descriptor.Content()
+			if err != nil {
+				logger.Errorf(
+					"could not get content from file [%v] "+
+						"in directory [%v]: [%v]",
+					descriptor.Name(),
+					descriptor.Directory(),
+					err,
+				)
+				continue
+			}
+
+			transaction := &pendingWalletTransaction{}
+			if err := transaction.Unmarshal(content); err != nil {
+				logger.Errorf(
+					"could not unmarshal pending wallet transaction from "+
+						"file [%v] in directory [%v]: [%v]",
+					descriptor.Name(),
+					descriptor.Directory(),
+					err,
+				)
+				continue
+			}
+
+			transactions = append(transactions, transaction)
+		}
+
+		wg.Done()
+	}()
+
+	go func() {
+		for err := range errorsChan {
+			logger.Errorf(
+				"could not load pending wallet transaction from disk: [%v]",
+				err,
+			)
+		}
+
+		wg.Done()
+	}()
+
+	wg.Wait()
+
+	return transactions
+}
+
+// assembleReplacementTransaction assembles an unsigned transaction replacing
+// the given pending transaction and paying the given fee. The replacement
+// is assembled from the proposal of the wallet action the pending transaction
+// belongs to. The proposal is validated with the replacement fee first to
+// make sure the replacement does not violate the on-chain rules of the
+// action, e.g. its fee does not exceed the maximum allowed fee.
+func assembleReplacementTransaction(
+	assembleLogger log.StandardLogger,
+	chain Chain,
+	btcChain bitcoin.Chain,
+	pending *pendingWalletTransaction,
+	fee int64,
+) (*bitcoin.TransactionBuilder, error) {
+	switch proposal := pending.proposal.withTransactionFee(fee).(type) {
+	case *DepositSweepProposal:
+		deposits, err := ValidateDepositSweepProposal(
+			assembleLogger,
+			proposal,
+			DepositSweepRequiredFundingTxConfirmations,
+			DepositSweepRefundLocktimeSafetyMargin,
+			chain,
+			btcChain,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		return assembleDepositSweepTransaction(
+			btcChain,
+			pending.walletPublicKey,
+			pending.walletMainUtxo,
+			deposits,
+			fee,
+		)
+	case *RedemptionProposal:
+		requests, err := ValidateRedemptionProposal(
+			assembleLogger,
+			proposal,
+			chain,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		return assembleRedemptionTransaction(
+			btcChain,
+			pending.walletPublicKey,
+			pending.walletMainUtxo,
+			requests,
+			withRedemptionTotalFee(fee),
+			RedemptionChangeFirst,
+		)
+	case *MovingFundsProposal:
+		err := ValidateMovingFundsProposal(
+			assembleLogger,
+			pending.walletMainUtxo,
+			proposal,
+			chain,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		return assembleMovingFundsTransaction(
+			btcChain,
+			pending.walletPublicKey,
+			pending.walletMainUtxo,
+			proposal.TargetWallets,
+			fee,
+		)
+	case *MovedFundsSweepProposal:
+		request, err := ValidateMovedFundsSweepProposal(
+			assembleLogger,
+			pending.walletMainUtxo,
+			proposal,
+			chain,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		movedFundsUtxo := &bitcoin.UnspentTransactionOutput{
+			Outpoint: &bitcoin.TransactionOutpoint{
+				TransactionHash: proposal.MovingFundsTxHash,
+				OutputIndex:     proposal.MovingFundsTxOutputIndex,
+			},
+			Value: int64(request.Value),
+		}
+
+		return assembleMovedFundsSweepTransaction(
+			btcChain,
+			pending.walletPublicKey,
+			pending.walletMainUtxo,
+			movedFundsUtxo,
+			fee,
+		)
+	default:
+		return nil, fmt.Errorf(
+			"transactions of [%s] action cannot be replaced",
+			proposal.actionType(),
+		)
+	}
+}
+
+// feeBumpAction is a fee bump walletAction.
+type feeBumpAction struct {
+	logger   *zap.SugaredLogger
+	chain    Chain
+	btcChain bitcoin.Chain
+
+	bumpingWallet       wallet
+	transactionExecutor *walletTransactionExecutor
+	pendingTransactions *pendingWalletTransactions
+
+	proposal                     *FeeBumpProposal
+	proposalProcessingStartBlock uint64
+	proposalExpiresAt            time.Time
+
+	signingTimeoutSafetyMargin time.Duration
+	broadcastTimeout           time.Duration
+	broadcastCheckDelay        time.Duration
+}
+
+func newFeeBumpAction(
+	logger *zap.SugaredLogger,
+	chain Chain,
+	btcChain bitcoin.Chain,
+	bumpingWallet wallet,
+	signingExecutor walletSigningExecutor,
+	pendingTransactions *pendingWalletTransactions,
+	proposal *FeeBumpProposal,
+	proposalProcessingStartBlock uint64,
+	proposalExpiresAt time.Time,
+) *feeBumpAction {
+	transactionExecutor := newWalletTransactionExecutor(
+		btcChain,
+		bumpingWallet,
+		signingExecutor,
+		ActionFeeBump,
+		pendingTransactions,
+	)
+
+	return &feeBumpAction{
+		logger:                       logger,
+		chain:                        chain,
+		btcChain:                     btcChain,
+		bumpingWallet:                bumpingWallet,
+		transactionExecutor:          transactionExecutor,
+		pendingTransactions:          pendingTransactions,
+		proposal:                     proposal,
+		proposalProcessingStartBlock: proposalProcessingStartBlock,
+		proposalExpiresAt:            proposalExpiresAt,
+		signingTimeoutSafetyMargin:   feeBumpSigningTimeoutSafetyMargin,
+		broadcastTimeout:             feeBumpBroadcastTimeout,
+		broadcastCheckDelay:          feeBumpBroadcastCheckDelay,
+	}
+}
+
+func (fba *feeBumpAction) execute() error {
+	walletPublicKeyHash := bitcoin.PublicKeyHash(fba.wallet().publicKey)
+
+	validateProposalLogger := fba.logger.With(
+		zap.String("step", "validateProposal"),
+	)
+
+	unsignedReplacementTx, err := validateFeeBumpProposal(
+		validateProposalLogger,
+		walletPublicKeyHash,
+		fba.proposal,
+		fba.pendingTransactions,
+		fba.chain,
+		fba.btcChain,
+	)
+	if err != nil {
+		return fmt.Errorf("validate proposal step failed: [%v]", err)
+	}
+
+	signTxLogger := fba.logger.With(
+		zap.String("step", "signTransaction"),
+	)
+
+	signingTimesOutAt := fba.proposalExpiresAt.Add(-fba.signingTimeoutSafetyMargin)
+
+	replacementTx, err := fba.transactionExecutor.signTransaction(
+		signTxLogger,
+		unsignedReplacementTx,
+		fba.proposalProcessingStartBlock,
+		signingTimesOutAt,
+	)
+	if err != nil {
+		return fmt.Errorf("sign transaction step failed: [%v]", err)
+	}
+
+	signedAtBlockHeight, err := fba.btcChain.GetLatestBlockHeight()
+	if err != nil {
+		return fmt.Errorf("cannot get latest Bitcoin block height: [%v]", err)
+	}
+
+	// Record the replacement before broadcasting it. All signers have the
+	// replacement at this point, regardless of whether their broadcast
+	// succeeds, so the replacement can be bumped again later.
+	err = fba.pendingTransactions.replace(
+		walletPublicKeyHash,
+		fba.proposal.TransactionHash,
+		replacementTx,
+		fba.proposal.TransactionFee.Int64(),
+		signedAtBlockHeight,
+	)
+	if err != nil {
+		return fmt.Errorf("cannot record replacement transaction: [%v]", err)
+	}
+
+	broadcastTxLogger := fba.logger.With(
+		zap.String("step", "broadcastTransaction"),
+		zap.String(
+			"replacementTxHash",
+			replacementTx.Hash().Hex(bitcoin.ReversedByteOrder),
+		),
+	)
+
+	err = fba.transactionExecutor.broadcastTransaction(
+		broadcastTxLogger,
+		replacementTx,
+		fba.broadcastTimeout,
+		fba.broadcastCheckDelay,
+	)
+	if err != nil {
+		return fmt.Errorf("broadcast transaction step failed: [%v]", err)
+	}
+
+	return nil
+}
+
+// validateFeeBumpProposal checks the fee bump proposal against the pending
+// transaction of the given wallet. The proposal must target the latest
+// version of the wallet's pending transaction, none of the transaction
+// versions can be confirmed, and the proposed fee must satisfy the BIP-0125
+// replacement rules. If so, the replacement transaction is assembled from the
+// proposal of the wallet action the pending transaction belongs to, which also
+// checks the replacement against the action's on-chain rules. Returns the unsigned
+// replacement transaction if the proposal is valid or an error otherwise.
+func validateFeeBumpProposal(
+	validateProposalLogger log.StandardLogger,
+	walletPublicKeyHash [20]byte,
+	proposal *FeeBumpProposal,
+	pendingTransactions *pendingWalletTransactions,
+	chain Chain,
+	btcChain bitcoin.Chain,
+) (*bitcoin.TransactionBuilder, error) {
+	validateProposalLogger.Infof("looking up the wallet's pending transaction")
+
+	pending, ok := pendingTransactions.unconfirmed(walletPublicKeyHash, btcChain)
+	if !ok {
+		return nil, fmt.Errorf("wallet has no unconfirmed pending transaction")
+	}
+
+	pendingTxHash := pending.Transaction.Hash()
+	if pendingTxHash != proposal.TransactionHash {
+		return nil, fmt.Errorf(
+			"proposed transaction [%s] is not the wallet's pending "+
+				"transaction [%s]",
+			proposal.TransactionHash.Hex(bitcoin.ReversedByteOrder),
+			pendingTxHash.Hex(bitcoin.ReversedByteOrder),
+		)
+	}
+
+	fee := proposal.TransactionFee.Int64()
+	minimumFee := MinimumReplacementFee(pending.Transaction, pending.Fee)
+	if fee < minimumFee {
+		return nil, fmt.Errorf(
+			"proposed fee [%v] is lower than the minimum replacement fee [%v]",
+			fee,
+			minimumFee,
+		)
+	}
+
+	validateProposalLogger.Infof(
+		"assembling [%s] transaction replacing transaction [%s] "+
+			"with fee [%v] instead of [%v]",
+		pending.proposal.actionType(),
+		pendingTxHash.Hex(bitcoin.ReversedByteOrder),
+		fee,
+		pending.Fee,
+	)
+
+	unsignedReplacementTx, err := assembleReplacementTransaction(
+		validateProposalLogger,
+		chain,
+		btcChain,
+		pending,
+		fee,
+	)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"cannot assemble replacement transaction: [%v]",
+			err,
+		)
+	}
+
+	validateProposalLogger.Infof("fee bump proposal is valid")
+
+	return unsignedReplacementTx, nil
+}
+
+func (fba *feeBumpAction) wallet() wallet {
+	return fba.bumpingWallet
+}
+
+func (fba *feeBumpAction) actionType() WalletActionType {
+	return ActionFeeBump
+}
+
+func (fba *feeBumpAction) expiresAt() time.Time {
+	return fba.proposalExpiresAt
+}
+
+func (fba *feeBumpAction) signingTimeoutBlock() uint64 {
+	return signingTimeoutBlock(fba.proposalProcessingStartBlock)
+}
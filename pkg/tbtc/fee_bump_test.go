@@ -0,0 +1,575 @@
+package tbtc
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"math/big"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/keep-network/keep-core/internal/testutils"
+	"github.com/keep-network/keep-core/pkg/bitcoin"
+	"github.com/keep-network/keep-core/pkg/tecdsa"
+)
+
+func TestFeeBumpAction_Execute(t *testing.T) {
+	const initialFee = 1000
+
+	var tests = map[string]struct {
+		noPendingTransaction bool
+		pendingConfirmed     bool
+		differentTransaction bool
+		nodeRestarted        bool
+		feeDelta             int64
+		replacementRejected  bool
+		expectedErr          string
+	}{
+		"valid proposal": {
+			feeDelta: 500,
+		},
+		"minimum replacement fee": {
+			feeDelta: 0,
+		},
+		"pending transaction restored after node restart": {
+			nodeRestarted: true,
+			feeDelta:      500,
+		},
+		"no pending transaction": {
+			noPendingTransaction: true,
+			expectedErr:          "wallet has no unconfirmed pending transaction",
+		},
+		"pending transaction confirmed": {
+			pendingConfirmed: true,
+			expectedErr:      "wallet has no unconfirmed pending transaction",
+		},
+		"different transaction proposed": {
+			differentTransaction: true,
+			expectedErr:          "is not the wallet's pending transaction",
+		},
+		"fee lower than minimum replacement fee": {
+			feeDelta:    -1,
+			expectedErr: "is lower than the minimum replacement fee",
+		},
+		"replacement violates on-chain rules": {
+			feeDelta:            500,
+			replacementRejected: true,
+			expectedErr:         "moved funds sweep proposal is invalid",
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			fixture := newFeeBumpTestFixture(t)
+
+			persistenceHandle := &mockPersistenceHandle{}
+			pendingTransactions := newPendingWalletTransactions(persistenceHandle)
+
+			transactionExecutor := newWalletTransactionExecutor(
+				fixture.btcChain,
+				fixture.wallet,
+				fixture.signingExecutor,
+				ActionMovedFundsSweep,
+				pendingTransactions,
+			)
+
+			unsignedTx, err := fixture.assemble(initialFee)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			tx, err := transactionExecutor.signTransaction(
+				logger,
+				unsignedTx,
+				0,
+				time.Now().Add(time.Minute),
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !test.noPendingTransaction {
+				transactionExecutor.recordPendingTransaction(
+					tx,
+					nil,
+					fixture.proposal(initialFee),
+				)
+			}
+
+			if test.nodeRestarted {
+				pendingTransactions = newPendingWalletTransactions(
+					persistenceHandle,
+				)
+			}
+
+			if test.pendingConfirmed {
+				err = fixture.btcChain.BroadcastTransaction(tx)
+				if err != nil {
+					t.Fatal(err)
+				}
+			} else {
+				fixture.btcChain.addToMempool(tx)
+			}
+
+			proposal := &FeeBumpProposal{
+				TransactionHash: tx.Hash(),
+				TransactionFee: big.NewInt(
+					MinimumReplacementFee(tx, initialFee) + test.feeDelta,
+				),
+			}
+			if test.differentTransaction {
+				proposal.TransactionHash = bitcoin.Hash{1}
+			}
+
+			err = fixture.chain.setMovedFundsSweepProposalValidationResult(
+				nil,
+				fixture.proposal(proposal.TransactionFee.Int64()),
+				!test.replacementRejected,
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			action := newFeeBumpAction(
+				logger.With(),
+				fixture.chain,
+				fixture.btcChain,
+				fixture.wallet,
+				fixture.signingExecutor,
+				pendingTransactions,
+				proposal,
+				0,
+				time.Now().Add(feeBumpSigningTimeoutSafetyMargin+time.Minute),
+			)
+
+			// Modify the default parameters of the action to make
+			// it possible to execute in the current test environment.
+			action.broadcastTimeout = time.Second
+			action.broadcastCheckDelay = 10 * time.Millisecond
+
+			// The replacement is signed at the current block height. The local
+			// chain moves it forward once the replacement gets broadcasted.
+			signingBlockHeight, err := fixture.btcChain.GetLatestBlockHeight()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = action.execute()
+
+			if test.expectedErr != "" {
+				if err == nil || !strings.Contains(err.Error(), test.expectedErr) {
+					t.Fatalf(
+						"unexpected error\nexpected: [%v]\nactual:   [%v]",
+						test.expectedErr,
+						err,
+					)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			pending, ok := pendingTransactions.transactions[fixture.walletPublicKeyHash]
+			if !ok {
+				t.Fatal("replacement transaction should be recorded")
+			}
+
+			replacementTx := pending.Transaction
+
+			_, err = fixture.btcChain.GetTransaction(replacementTx.Hash())
+			if err != nil {
+				t.Fatalf("replacement transaction not broadcasted: [%v]", err)
+			}
+
+			testutils.AssertIntsEqual(
+				t,
+				"inputs count",
+				1,
+				len(replacementTx.Inputs),
+			)
+			testutils.AssertBytesEqual(
+				t,
+				tx.Inputs[0].Outpoint.TransactionHash[:],
+				replacementTx.Inputs[0].Outpoint.TransactionHash[:],
+			)
+			testutils.AssertUintsEqual(
+				t,
+				"input sequence",
+				0xfffffffd,
+				uint64(replacementTx.Inputs[0].Sequence),
+			)
+			testutils.AssertIntsEqual(
+				t,
+				"replacement fee",
+				int(proposal.TransactionFee.Int64()),
+				int(feeBumpTestFundingValue-replacementTx.Outputs[0].Value),
+			)
+			testutils.AssertIntsEqual(
+				t,
+				"recorded fee",
+				int(proposal.TransactionFee.Int64()),
+				int(pending.Fee),
+			)
+			testutils.AssertUintsEqual(
+				t,
+				"recorded signing block height",
+				uint64(signingBlockHeight),
+				uint64(pending.SignedAtBlockHeight),
+			)
+			if !reflect.DeepEqual(
+				[]bitcoin.Hash{tx.Hash()},
+				pending.replacedTransactionsHashes,
+			) {
+				t.Errorf(
+					"unexpected replaced transactions hashes\n"+
+						"expected: [%v]\n"+
+						"actual:   [%v]",
+					[]bitcoin.Hash{tx.Hash()},
+					pending.replacedTransactionsHashes,
+				)
+			}
+			testutils.AssertStringsEqual(
+				t,
+				"action type",
+				ActionMovedFundsSweep.String(),
+				pending.proposal.actionType().String(),
+			)
+
+			restoredPending, ok := newPendingWalletTransactions(
+				persistenceHandle,
+			).transactions[fixture.walletPublicKeyHash]
+			if !ok {
+				t.Fatal("replacement transaction should be persisted")
+			}
+
+			restoredTxHash := restoredPending.Transaction.Hash()
+			replacementTxHash := replacementTx.Hash()
+			testutils.AssertBytesEqual(
+				t,
+				replacementTxHash[:],
+				restoredTxHash[:],
+			)
+		})
+	}
+}
+
+func TestPendingWalletTransactions_Unconfirmed(t *testing.T) {
+	walletPublicKey := generateWallet(big.NewInt(1)).publicKey
+	walletPublicKeyHash := bitcoin.PublicKeyHash(walletPublicKey)
+
+	originalTx := &bitcoin.Transaction{Version: 1, Locktime: 1}
+	replacementTx := &bitcoin.Transaction{Version: 1, Locktime: 2}
+
+	var tests = map[string]struct {
+		confirmedTx         *bitcoin.Transaction
+		unknownTx           *bitcoin.Transaction
+		expectedUnconfirmed bool
+		expectedRecorded    bool
+	}{
+		"no version confirmed": {
+			confirmedTx:         nil,
+			expectedUnconfirmed: true,
+			expectedRecorded:    true,
+		},
+		"replaced version confirmed": {
+			confirmedTx:         originalTx,
+			expectedUnconfirmed: false,
+			expectedRecorded:    false,
+		},
+		"latest version confirmed": {
+			confirmedTx:         replacementTx,
+			expectedUnconfirmed: false,
+			expectedRecorded:    false,
+		},
+		"replaced version unknown": {
+			unknownTx:           originalTx,
+			expectedUnconfirmed: true,
+			expectedRecorded:    true,
+		},
+		"latest version unknown": {
+			unknownTx:           replacementTx,
+			expectedUnconfirmed: false,
+			expectedRecorded:    true,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			btcChain := newLocalBitcoinChain()
+			for _, tx := range []*bitcoin.Transaction{originalTx, replacementTx} {
+				if tx != test.unknownTx {
+					btcChain.addToMempool(tx)
+				}
+			}
+
+			if test.confirmedTx != nil {
+				err := btcChain.BroadcastTransaction(test.confirmedTx)
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			persistenceHandle := &mockPersistenceHandle{}
+			pendingTransactions := newPendingWalletTransactions(persistenceHandle)
+			pendingTransactions.record(
+				walletPublicKey,
+				originalTx,
+				100,
+				nil,
+				&RedemptionProposal{RedemptionTxFee: big.NewInt(1000)},
+			)
+
+			err := pendingTransactions.replace(
+				walletPublicKeyHash,
+				originalTx.Hash(),
+				replacementTx,
+				1500,
+				101,
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			pending, ok := pendingTransactions.unconfirmed(
+				walletPublicKeyHash,
+				btcChain,
+			)
+
+			testutils.AssertBoolsEqual(
+				t,
+				"unconfirmed",
+				test.expectedUnconfirmed,
+				ok,
+			)
+
+			if ok {
+				replacementTxHash := replacementTx.Hash()
+				pendingTxHash := pending.Transaction.Hash()
+
+				testutils.AssertBytesEqual(
+					t,
+					replacementTxHash[:],
+					pendingTxHash[:],
+				)
+			}
+
+			_, recorded := pendingTransactions.transactions[walletPublicKeyHash]
+			testutils.AssertBoolsEqual(
+				t,
+				"recorded",
+				test.expectedRecorded,
+				recorded,
+			)
+			testutils.AssertBoolsEqual(
+				t,
+				"persisted",
+				test.expectedRecorded,
+				len(persistenceHandle.saved) == 1,
+			)
+		})
+	}
+}
+
+func TestPendingWalletTransactions_Replace_NotLatest(t *testing.T) {
+	walletPublicKey := generateWallet(big.NewInt(1)).publicKey
+	walletPublicKeyHash := bitcoin.PublicKeyHash(walletPublicKey)
+
+	originalTx := &bitcoin.Transaction{Version: 1, Locktime: 1}
+	replacementTx := &bitcoin.Transaction{Version: 1, Locktime: 2}
+
+	pendingTransactions := newPendingWalletTransactions(&mockPersistenceHandle{})
+
+	err := pendingTransactions.replace(
+		walletPublicKeyHash,
+		originalTx.Hash(),
+		replacementTx,
+		1500,
+		101,
+	)
+	if err == nil {
+		t.Fatal("expected error for wallet without pending transaction")
+	}
+
+	pendingTransactions.record(
+		walletPublicKey,
+		originalTx,
+		100,
+		nil,
+		&RedemptionProposal{RedemptionTxFee: big.NewInt(1000)},
+	)
+
+	err = pendingTransactions.replace(
+		walletPublicKeyHash,
+		replacementTx.Hash(),
+		replacementTx,
+		1500,
+		101,
+	)
+	if err == nil {
+		t.Fatal("expected error for transaction that is not the latest one")
+	}
+}
+
+// feeBumpTestFundingValue is the value of the output funding the wallet
+// transactions used by fee bump tests.
+const feeBumpTestFundingValue = 100000
+
+// feeBumpTestFixture holds a wallet controlled by a single private key,
+// a Bitcoin chain holding a moving funds transaction funding the wallet, and
+// a host chain holding the moved funds sweep request of that transaction.
+type feeBumpTestFixture struct {
+	wallet              wallet
+	walletPublicKeyHash [20]byte
+	walletScript        bitcoin.Script
+	signingExecutor     *keyWalletSigningExecutor
+	chain               *localChain
+	btcChain            *localBitcoinChain
+	fundingTx           *bitcoin.Transaction
+}
+
+func newFeeBumpTestFixture(t *testing.T) *feeBumpTestFixture {
+	privateKey, err := ecdsa.GenerateKey(tecdsa.Curve, rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	executingWallet := generateWallet(privateKey.D)
+	walletPublicKeyHash := bitcoin.PublicKeyHash(executingWallet.publicKey)
+	walletScript, err := bitcoin.PayToWitnessPublicKeyHash(walletPublicKeyHash)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	btcChain := newLocalBitcoinChain()
+
+	fundingTx := &bitcoin.Transaction{
+		Version: 1,
+		Inputs: []*bitcoin.TransactionInput{
+			{
+				Outpoint: &bitcoin.TransactionOutpoint{
+					TransactionHash: bitcoin.Hash{1},
+					OutputIndex:     0,
+				},
+				Sequence: 0xffffffff,
+			},
+		},
+		Outputs: []*bitcoin.TransactionOutput{
+			{
+				Value:           feeBumpTestFundingValue,
+				PublicKeyScript: walletScript,
+			},
+		},
+	}
+
+	err = btcChain.BroadcastTransaction(fundingTx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	chain := Connect()
+	chain.setMovedFundsSweepRequest(
+		fundingTx.Hash(),
+		0,
+		&MovedFundsSweepRequest{
+			WalletPublicKeyHash: walletPublicKeyHash,
+			Value:               feeBumpTestFundingValue,
+			State:               MovedFundsSweepRequestStatePending,
+		},
+	)
+
+	return &feeBumpTestFixture{
+		wallet:              executingWallet,
+		walletPublicKeyHash: walletPublicKeyHash,
+		walletScript:        walletScript,
+		signingExecutor:     &keyWalletSigningExecutor{privateKey: privateKey},
+		chain:               chain,
+		btcChain:            btcChain,
+		fundingTx:           fundingTx,
+	}
+}
+
+// proposal returns the moved funds sweep proposal sweeping the funding output
+// and paying the given fee.
+func (fbtf *feeBumpTestFixture) proposal(fee int64) *MovedFundsSweepProposal {
+	return &MovedFundsSweepProposal{
+		WalletPublicKeyHash:      fbtf.walletPublicKeyHash,
+		MovingFundsTxHash:        fbtf.fundingTx.Hash(),
+		MovingFundsTxOutputIndex: 0,
+		SweepTxFee:               big.NewInt(fee),
+	}
+}
+
+// assemble assembles an unsigned moved funds sweep transaction spending the
+// funding output back to the wallet and paying the given fee.
+func (fbtf *feeBumpTestFixture) assemble(
+	fee int64,
+) (*bitcoin.TransactionBuilder, error) {
+	return assembleMovedFundsSweepTransaction(
+		fbtf.btcChain,
+		fbtf.wallet.publicKey,
+		nil,
+		&bitcoin.UnspentTransactionOutput{
+			Outpoint: &bitcoin.TransactionOutpoint{
+				TransactionHash: fbtf.fundingTx.Hash(),
+				OutputIndex:     0,
+			},
+			Value: feeBumpTestFundingValue,
+		},
+		fee,
+	)
+}
+
+// keyWalletSigningExecutor is a wallet signing executor that signs messages
+// using the given private key. Signing ends one block after it starts.
+type keyWalletSigningExecutor struct {
+	privateKey *ecdsa.PrivateKey
+}
+
+func (kwse *keyWalletSigningExecutor) signBatch(
+	ctx context.Context,
+	messages []*big.Int,
+	startBlock uint64,
+) ([]*signingResult, error) {
+	results := make([]*signingResult, len(messages))
+	for i, message := range messages {
+		r, s, err := ecdsa.Sign(rand.Reader, kwse.privateKey, message.Bytes())
+		if err != nil {
+			return nil, err
+		}
+
+		results[i] = &signingResult{
+			signature: &tecdsa.Signature{R: r, S: s},
+			endBlock:  startBlock + 1,
+		}
+	}
+
+	return results, nil
+}
+
+func (kwse *keyWalletSigningExecutor) recordSignatures(
+	actionType WalletActionType,
+	transactionHash bitcoin.Hash,
+	messages []*big.Int,
+	results []*signingResult,
+) error {
+	return nil
+}
+
+func (kwse *keyWalletSigningExecutor) evaluateSigningPolicy(
+	actionType WalletActionType,
+	unsignedTx *bitcoin.TransactionBuilder,
+) error {
+	return nil
+}
+
+func (kwse *keyWalletSigningExecutor) countSignedTransaction(
+	transactionHash bitcoin.Hash,
+) {
+}
+
+func (kwse *keyWalletSigningExecutor) releaseSigningPolicyReservation() {
+}
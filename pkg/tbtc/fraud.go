@@ -0,0 +1,519 @@
+package tbtc
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ipfs/go-log/v2"
+	"go.uber.org/zap"
+
+	"github.com/keep-network/keep-core/pkg/bitcoin"
+	"github.com/keep-network/keep-core/pkg/protocol/group"
+)
+
+const (
+	// fraudChallengeConfirmationBlocks determines the period used to confirm
+	// the fraud challenge submission before attempting to defeat it.
+	fraudChallengeConfirmationBlocks = 20
+	// fraudChallengeDefenseDelayStepBlocks determines the delay between
+	// defense submissions of subsequent signing group members. The first
+	// member submits the defense right after the confirmation period and
+	// others step in only if the challenge is still not defeated.
+	fraudChallengeDefenseDelayStepBlocks = 5
+	// fraudChallengeDefenseTransactionsLimit determines the number of the
+	// most recent confirmed transactions of the wallet that are searched for
+	// the challenged signature if the signing audit log does not point to
+	// the transaction directly.
+	fraudChallengeDefenseTransactionsLimit = 20
+	// fraudChallengeHeartbeatLookBackBlocks determines the number of blocks
+	// preceding the reference block whose heartbeat messages are checked
+	// against the challenged signature hash. The value of 50400 blocks is
+	// roughly 1 week, assuming 12 seconds per block.
+	fraudChallengeHeartbeatLookBackBlocks = 50400
+	// fraudChallengeDefenseRetryDelay determines the delay between subsequent
+	// attempts to defeat the fraud challenge. Failed attempts are repeated
+	// until the challenge is resolved or the defeat deadline passes. This is
+	// needed as the defense may not be possible right away, e.g. because the
+	// challenged transaction is not confirmed yet, or the defense submission
+	// may fail temporarily.
+	fraudChallengeDefenseRetryDelay = 10 * time.Minute
+	// fraudChallengeLookBackMarginBlocks is the number of blocks added to the
+	// fraud challenge defeat timeout, expressed in blocks, when looking for
+	// past fraud challenges that can still be defeated. This margin accounts
+	// for the block time being different from the assumed average.
+	fraudChallengeLookBackMarginBlocks = 1000
+)
+
+// fraudChallengeDefense holds the data required to defeat a fraud challenge.
+// Either the preimage or the heartbeat message is set.
+type fraudChallengeDefense struct {
+	// preimage is the preimage of the challenged signature hash built for
+	// an input of a Bitcoin transaction spending a wallet UTXO.
+	preimage []byte
+	// witness denotes whether the preimage was built for a witness input.
+	witness bool
+	// heartbeatMessage is the heartbeat message whose hash was challenged.
+	heartbeatMessage []byte
+}
+
+// fraudChallengeDefender is the component responsible for defeating fraud
+// challenges submitted against wallets controlled by the node. A fraud
+// challenge points to a signature hash signed by the wallet. The defender
+// finds the heartbeat message or the Bitcoin transaction input the signature
+// hash was computed for and reveals the signature hash preimage on-chain.
+type fraudChallengeDefender struct {
+	chain          Chain
+	btcChain       bitcoin.Chain
+	auditLog       *signingAuditLog
+	waitForBlockFn waitForBlockFn
+	retryDelay     time.Duration
+}
+
+func newFraudChallengeDefender(
+	chain Chain,
+	btcChain bitcoin.Chain,
+	auditLog *signingAuditLog,
+	waitForBlockFn waitForBlockFn,
+) *fraudChallengeDefender {
+	return &fraudChallengeDefender{
+		chain:          chain,
+		btcChain:       btcChain,
+		auditLog:       auditLog,
+		waitForBlockFn: waitForBlockFn,
+		retryDelay:     fraudChallengeDefenseRetryDelay,
+	}
+}
+
+// defend attempts to defeat the fraud challenge described by the given event.
+// The challenged wallet must be controlled by the node and the submitter
+// member index must be the lowest index of the wallet's signing group
+// members controlled by the node. Members submit their defenses one after
+// another, according to their indexes, until the challenge is defeated.
+// A failed defense submission is retried until the challenge is resolved
+// or its defeat deadline passes.
+func (fcd *fraudChallengeDefender) defend(
+	ctx context.Context,
+	walletPublicKey *ecdsa.PublicKey,
+	submitterMemberIndex group.MemberIndex,
+	event *FraudChallengeSubmittedEvent,
+) error {
+	defenseLogger := logger.With(
+		zap.String("walletPKH", fmt.Sprintf("0x%x", event.WalletPublicKeyHash)),
+		zap.String("sighash", fmt.Sprintf("0x%x", event.Sighash)),
+		zap.Uint8("member", uint8(submitterMemberIndex)),
+	)
+
+	submissionBlock := event.BlockNumber + fraudChallengeConfirmationBlocks +
+		uint64(submitterMemberIndex-1)*fraudChallengeDefenseDelayStepBlocks
+
+	defenseLogger.Infof(
+		"waiting for block [%v] to submit fraud challenge defense",
+		submissionBlock,
+	)
+
+	err := fcd.waitForBlockFn(ctx, submissionBlock)
+	if err != nil {
+		return fmt.Errorf(
+			"failed to wait for the defense submission block: [%v]",
+			err,
+		)
+	}
+
+	defeatTimeout, err := fcd.chain.GetFraudChallengeDefeatTimeout()
+	if err != nil {
+		return fmt.Errorf(
+			"cannot get fraud challenge defeat timeout: [%v]",
+			err,
+		)
+	}
+
+	for attempt := 1; ; attempt++ {
+		challenge, found, err := fcd.chain.GetFraudChallenge(
+			walletPublicKey,
+			event.Sighash,
+		)
+		if err != nil {
+			return fmt.Errorf("cannot get fraud challenge: [%v]", err)
+		}
+
+		if !found {
+			return fmt.Errorf("fraud challenge not found on-chain")
+		}
+
+		if challenge.Resolved {
+			defenseLogger.Infof("fraud challenge resolved")
+			return nil
+		}
+
+		deadline := challenge.ReportedAt.Add(defeatTimeout)
+		if time.Now().After(deadline) {
+			return fmt.Errorf(
+				"fraud challenge defeat deadline [%v] already passed",
+				deadline,
+			)
+		}
+
+		err = fcd.submitDefense(defenseLogger, walletPublicKey, event)
+		if err == nil {
+			defenseLogger.Infof(
+				"fraud challenge defense submitted in attempt [%v]",
+				attempt,
+			)
+			return nil
+		}
+
+		defenseLogger.Warnf(
+			"attempt [%v] to defeat fraud challenge failed; "+
+				"retrying in [%v]: [%v]",
+			attempt,
+			fcd.retryDelay,
+			err,
+		)
+
+		select {
+		case <-time.After(fcd.retryDelay):
+		case <-ctx.Done():
+			return fmt.Errorf(
+				"fraud challenge defense cancelled: [%v]",
+				ctx.Err(),
+			)
+		}
+	}
+}
+
+// submitDefense finds the defense against the fraud challenge described by
+// the given event and submits it on-chain.
+func (fcd *fraudChallengeDefender) submitDefense(
+	defenseLogger log.StandardLogger,
+	walletPublicKey *ecdsa.PublicKey,
+	event *FraudChallengeSubmittedEvent,
+) error {
+	defense, err := fcd.findDefense(defenseLogger, event)
+	if err != nil {
+		return fmt.Errorf("cannot find fraud challenge defense: [%v]", err)
+	}
+
+	if defense.heartbeatMessage != nil {
+		err = fcd.chain.DefeatFraudChallengeWithHeartbeat(
+			walletPublicKey,
+			defense.heartbeatMessage,
+		)
+	} else {
+		err = fcd.chain.DefeatFraudChallenge(
+			walletPublicKey,
+			defense.preimage,
+			defense.witness,
+		)
+	}
+	if err != nil {
+		return fmt.Errorf("cannot submit fraud challenge defense: [%v]", err)
+	}
+
+	return nil
+}
+
+// findDefense finds the defense against the fraud challenge described by
+// the given event. First, the signing audit log is consulted to determine
+// whether the challenged signature hash belongs to a heartbeat or to
+// a known Bitcoin transaction. If the audit log does not help, heartbeat
+// messages of blocks preceding the challenge and the wallet's recent
+// confirmed transactions are searched. Transactions without confirmations
+// are rejected as the Bridge accepts the defense only for transactions
+// spending UTXOs it knows to be spent. A confirmed transaction may still be
+// not proven to the Bridge; the defense submission fails then and is
+// retried by the caller.
+func (fcd *fraudChallengeDefender) findDefense(
+	defenseLogger log.StandardLogger,
+	event *FraudChallengeSubmittedEvent,
+) (*fraudChallengeDefense, error) {
+	entry, found, err := fcd.auditLog.find(
+		event.WalletPublicKeyHash,
+		new(big.Int).SetBytes(event.Sighash[:]),
+	)
+	if err != nil {
+		defenseLogger.Warnf("cannot search signing audit log: [%v]", err)
+	}
+
+	if found {
+		if entry.ActionType == ActionHeartbeat {
+			// The heartbeat message was built before the signature was
+			// recorded so, the block at the entry's timestamp is a good
+			// upper bound of the heartbeat message block.
+			referenceBlock, err := fcd.chain.GetBlockNumberByTimestamp(
+				uint64(entry.Timestamp.Unix()),
+			)
+			if err != nil {
+				defenseLogger.Warnf(
+					"cannot get block of the signing audit log entry: [%v]",
+					err,
+				)
+			} else if message, ok := findHeartbeatMessage(
+				event.Sighash,
+				referenceBlock,
+			); ok {
+				return &fraudChallengeDefense{heartbeatMessage: message}, nil
+			}
+		}
+
+		if entry.TransactionHash != (bitcoin.Hash{}) {
+			confirmations, err := fcd.btcChain.GetTransactionConfirmations(
+				entry.TransactionHash,
+			)
+			if err != nil {
+				defenseLogger.Warnf(
+					"cannot get confirmations of transaction [%s] of the "+
+						"signing audit log entry: [%v]",
+					entry.TransactionHash.Hex(bitcoin.ReversedByteOrder),
+					err,
+				)
+			} else if confirmations == 0 {
+				return nil, fmt.Errorf(
+					"transaction [%s] of the signing audit log entry "+
+						"is not confirmed yet",
+					entry.TransactionHash.Hex(bitcoin.ReversedByteOrder),
+				)
+			}
+
+			transaction, err := fcd.btcChain.GetTransaction(
+				entry.TransactionHash,
+			)
+			if err != nil {
+				defenseLogger.Warnf(
+					"cannot get transaction [%s] of the signing "+
+						"audit log entry: [%v]",
+					entry.TransactionHash.Hex(bitcoin.ReversedByteOrder),
+					err,
+				)
+			} else {
+				defense, ok, err := fcd.findTransactionDefense(
+					transaction,
+					event.Sighash,
+				)
+				if err != nil {
+					defenseLogger.Warnf(
+						"cannot check transaction [%s] of the signing "+
+							"audit log entry: [%v]",
+						entry.TransactionHash.Hex(bitcoin.ReversedByteOrder),
+						err,
+					)
+				} else if ok {
+					return defense, nil
+				}
+			}
+		}
+	}
+
+	if message, ok := findHeartbeatMessage(
+		event.Sighash,
+		event.BlockNumber,
+	); ok {
+		return &fraudChallengeDefense{heartbeatMessage: message}, nil
+	}
+
+	transactions, err := fcd.btcChain.GetTransactionsForPublicKeyHash(
+		event.WalletPublicKeyHash,
+		fraudChallengeDefenseTransactionsLimit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get wallet transactions: [%v]", err)
+	}
+
+	// Most recent transactions are the most likely to be challenged so,
+	// start from the end.
+	for i := len(transactions) - 1; i >= 0; i-- {
+		defense, ok, err := fcd.findTransactionDefense(
+			transactions[i],
+			event.Sighash,
+		)
+		if err != nil {
+			defenseLogger.Warnf(
+				"cannot check transaction [%s]: [%v]",
+				transactions[i].Hash().Hex(bitcoin.ReversedByteOrder),
+				err,
+			)
+			continue
+		}
+		if ok {
+			return defense, nil
+		}
+	}
+
+	return nil, fmt.Errorf(
+		"challenged signature hash does not match any known heartbeat " +
+			"message or wallet transaction",
+	)
+}
+
+// findTransactionDefense checks whether the challenged signature hash was
+// computed for any input of the given transaction. If so, the preimage of
+// the signature hash is returned as the defense. The returned bool value
+// indicates whether the defense was found.
+func (fcd *fraudChallengeDefender) findTransactionDefense(
+	transaction *bitcoin.Transaction,
+	sighash [32]byte,
+) (*fraudChallengeDefense, bool, error) {
+	for i, input := range transaction.Inputs {
+		previousTransaction, err := fcd.btcChain.GetTransaction(
+			input.Outpoint.TransactionHash,
+		)
+		if err != nil {
+			return nil, false, fmt.Errorf(
+				"cannot get transaction [%s] spent by input [%v]: [%v]",
+				input.Outpoint.TransactionHash.Hex(bitcoin.ReversedByteOrder),
+				i,
+				err,
+			)
+		}
+
+		outputIndex := int(input.Outpoint.OutputIndex)
+		if outputIndex >= len(previousTransaction.Outputs) {
+			return nil, false, fmt.Errorf(
+				"output spent by input [%v] does not exist",
+				i,
+			)
+		}
+
+		preimage, witness, err := transaction.SignatureHashPreimage(
+			i,
+			previousTransaction.Outputs[outputIndex],
+		)
+		if err != nil {
+			// The input may spend an output the wallet does not control
+			// and whose script is not supported.
+			continue
+		}
+
+		if bitcoin.ComputeHash(preimage) == sighash {
+			return &fraudChallengeDefense{
+				preimage: preimage,
+				witness:  witness,
+			}, true, nil
+		}
+	}
+
+	return nil, false, nil
+}
+
+// findHeartbeatMessage searches heartbeat messages built for blocks preceding
+// the reference block, including the reference block itself, for the one
+// whose hash is the given signature hash. The returned bool value indicates
+// whether the heartbeat message was found.
+func findHeartbeatMessage(
+	sighash [32]byte,
+	referenceBlock uint64,
+) ([]byte, bool) {
+	lowestBlock := uint64(0)
+	if referenceBlock > fraudChallengeHeartbeatLookBackBlocks {
+		lowestBlock = referenceBlock - fraudChallengeHeartbeatLookBackBlocks
+	}
+
+	for block := referenceBlock; ; block-- {
+		message := NewHeartbeatMessage(block)
+		if bitcoin.ComputeHash(message) == sighash {
+			return message, true
+		}
+
+		if block == lowestBlock {
+			return nil, false
+		}
+	}
+}
+
+// findPendingFraudChallenges finds fraud challenges submitted against the
+// given wallets that are not resolved yet and whose defeat deadline has not
+// passed. This allows the node to defend challenges that were submitted
+// while it was offline. Returned events are sorted by the block number in
+// the ascending order.
+func findPendingFraudChallenges(
+	chain Chain,
+	walletsPublicKeys []*ecdsa.PublicKey,
+	now time.Time,
+) ([]*FraudChallengeSubmittedEvent, error) {
+	if len(walletsPublicKeys) == 0 {
+		return []*FraudChallengeSubmittedEvent{}, nil
+	}
+
+	walletsPublicKeysByHash := make(map[[20]byte]*ecdsa.PublicKey)
+	walletsPublicKeyHashes := make([][20]byte, 0, len(walletsPublicKeys))
+	for _, walletPublicKey := range walletsPublicKeys {
+		walletPublicKeyHash := bitcoin.PublicKeyHash(walletPublicKey)
+		walletsPublicKeysByHash[walletPublicKeyHash] = walletPublicKey
+		walletsPublicKeyHashes = append(
+			walletsPublicKeyHashes,
+			walletPublicKeyHash,
+		)
+	}
+
+	defeatTimeout, err := chain.GetFraudChallengeDefeatTimeout()
+	if err != nil {
+		return nil, fmt.Errorf(
+			"cannot get fraud challenge defeat timeout: [%v]",
+			err,
+		)
+	}
+
+	blockCounter, err := chain.BlockCounter()
+	if err != nil {
+		return nil, fmt.Errorf("cannot get block counter: [%v]", err)
+	}
+
+	currentBlock, err := blockCounter.CurrentBlock()
+	if err != nil {
+		return nil, fmt.Errorf("cannot get current block: [%v]", err)
+	}
+
+	// Challenges older than the defeat timeout can no longer be defeated
+	// so, there is no need to look further back. The lookback is estimated
+	// in blocks using the assumed average block time.
+	lookbackBlocks := uint64(defeatTimeout/coordinationAverageBlockTime) +
+		fraudChallengeLookBackMarginBlocks
+
+	startBlock := uint64(0)
+	if currentBlock > lookbackBlocks {
+		startBlock = currentBlock - lookbackBlocks
+	}
+
+	events, err := chain.PastFraudChallengeSubmittedEvents(
+		&FraudChallengeSubmittedEventFilter{
+			StartBlock:          startBlock,
+			WalletPublicKeyHash: walletsPublicKeyHashes,
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"cannot get past fraud challenge submitted events: [%v]",
+			err,
+		)
+	}
+
+	result := make([]*FraudChallengeSubmittedEvent, 0)
+	for _, event := range events {
+		walletPublicKey, ok := walletsPublicKeysByHash[event.WalletPublicKeyHash]
+		if !ok {
+			continue
+		}
+
+		challenge, found, err := chain.GetFraudChallenge(
+			walletPublicKey,
+			event.Sighash,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("cannot get fraud challenge: [%v]", err)
+		}
+
+		if !found || challenge.Resolved {
+			continue
+		}
+
+		if now.After(challenge.ReportedAt.Add(defeatTimeout)) {
+			continue
+		}
+
+		result = append(result, event)
+	}
+
+	return result, nil
+}
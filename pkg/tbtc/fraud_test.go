@@ -0,0 +1,527 @@
+package tbtc
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/keep-network/keep-core/internal/testutils"
+	"github.com/keep-network/keep-core/pkg/bitcoin"
+	"github.com/keep-network/keep-core/pkg/protocol/group"
+)
+
+func TestFraudChallengeDefender_Defend_Transaction(t *testing.T) {
+	defender, localChain, btcChain := setupFraudChallengeDefender()
+
+	wallet := generateWallet(big.NewInt(100))
+	walletPublicKeyHash := bitcoin.PublicKeyHash(wallet.publicKey)
+
+	transaction, preimage := fraudChallengeTransactionFixture(
+		t,
+		btcChain,
+		walletPublicKeyHash,
+		walletPublicKeyHash,
+	)
+
+	err := btcChain.BroadcastTransaction(transaction)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sighash := bitcoin.ComputeHash(preimage)
+	localChain.setFraudChallenge(wallet.publicKey, sighash, &FraudChallenge{
+		ReportedAt: time.Now(),
+	})
+
+	err = defender.defend(
+		context.Background(),
+		wallet.publicKey,
+		group.MemberIndex(1),
+		&FraudChallengeSubmittedEvent{
+			WalletPublicKeyHash: walletPublicKeyHash,
+			Sighash:             sighash,
+			BlockNumber:         100,
+		},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertIntsEqual(
+		t,
+		"defenses count",
+		1,
+		len(localChain.fraudChallengeDefenses),
+	)
+
+	defense := localChain.fraudChallengeDefenses[0]
+	testutils.AssertBytesEqual(t, preimage, defense.preimage)
+	testutils.AssertBoolsEqual(t, "witness flag", true, defense.witness)
+
+	challenge, _, err := localChain.GetFraudChallenge(wallet.publicKey, sighash)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertBoolsEqual(t, "resolved flag", true, challenge.Resolved)
+}
+
+func TestFraudChallengeDefender_Defend_SigningAuditLogTransaction(t *testing.T) {
+	defender, localChain, btcChain := setupFraudChallengeDefender()
+
+	wallet := generateWallet(big.NewInt(100))
+	walletPublicKeyHash := bitcoin.PublicKeyHash(wallet.publicKey)
+
+	// The transaction moves all funds to another wallet so, it cannot be
+	// found by looking at the challenged wallet's transactions. It can be
+	// found only using the signing audit log.
+	transaction, preimage := fraudChallengeTransactionFixture(
+		t,
+		btcChain,
+		walletPublicKeyHash,
+		[20]byte{0xaa},
+	)
+
+	err := btcChain.BroadcastTransaction(transaction)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sighash := bitcoin.ComputeHash(preimage)
+
+	err = defender.auditLog.record(
+		walletPublicKeyHash,
+		ActionMovingFunds,
+		transaction.Hash(),
+		[]*big.Int{new(big.Int).SetBytes(sighash[:])},
+		[]*signingResult{
+			newTestSigningResult(1, []group.MemberIndex{1, 2, 3}),
+		},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	localChain.setFraudChallenge(wallet.publicKey, sighash, &FraudChallenge{
+		ReportedAt: time.Now(),
+	})
+
+	err = defender.defend(
+		context.Background(),
+		wallet.publicKey,
+		group.MemberIndex(1),
+		&FraudChallengeSubmittedEvent{
+			WalletPublicKeyHash: walletPublicKeyHash,
+			Sighash:             sighash,
+			BlockNumber:         100,
+		},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertIntsEqual(
+		t,
+		"defenses count",
+		1,
+		len(localChain.fraudChallengeDefenses),
+	)
+	testutils.AssertBytesEqual(
+		t,
+		preimage,
+		localChain.fraudChallengeDefenses[0].preimage,
+	)
+}
+
+func TestFraudChallengeDefender_Defend_Heartbeat(t *testing.T) {
+	defender, localChain, _ := setupFraudChallengeDefender()
+
+	wallet := generateWallet(big.NewInt(100))
+	walletPublicKeyHash := bitcoin.PublicKeyHash(wallet.publicKey)
+
+	heartbeatMessage := NewHeartbeatMessage(1000)
+	sighash := bitcoin.ComputeHash(heartbeatMessage)
+
+	localChain.setFraudChallenge(wallet.publicKey, sighash, &FraudChallenge{
+		ReportedAt: time.Now(),
+	})
+
+	err := defender.defend(
+		context.Background(),
+		wallet.publicKey,
+		group.MemberIndex(1),
+		&FraudChallengeSubmittedEvent{
+			WalletPublicKeyHash: walletPublicKeyHash,
+			Sighash:             sighash,
+			BlockNumber:         1500,
+		},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertIntsEqual(
+		t,
+		"defenses count",
+		1,
+		len(localChain.fraudChallengeDefenses),
+	)
+	testutils.AssertBytesEqual(
+		t,
+		heartbeatMessage,
+		localChain.fraudChallengeDefenses[0].heartbeatMessage,
+	)
+}
+
+func TestFraudChallengeDefender_Defend_AlreadyResolved(t *testing.T) {
+	defender, localChain, _ := setupFraudChallengeDefender()
+
+	wallet := generateWallet(big.NewInt(100))
+	walletPublicKeyHash := bitcoin.PublicKeyHash(wallet.publicKey)
+
+	sighash := bitcoin.ComputeHash(NewHeartbeatMessage(1000))
+
+	localChain.setFraudChallenge(wallet.publicKey, sighash, &FraudChallenge{
+		ReportedAt: time.Now(),
+		Resolved:   true,
+	})
+
+	err := defender.defend(
+		context.Background(),
+		wallet.publicKey,
+		group.MemberIndex(1),
+		&FraudChallengeSubmittedEvent{
+			WalletPublicKeyHash: walletPublicKeyHash,
+			Sighash:             sighash,
+			BlockNumber:         1500,
+		},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertIntsEqual(
+		t,
+		"defenses count",
+		0,
+		len(localChain.fraudChallengeDefenses),
+	)
+}
+
+func TestFraudChallengeDefender_Defend_DeadlinePassed(t *testing.T) {
+	defender, localChain, _ := setupFraudChallengeDefender()
+
+	wallet := generateWallet(big.NewInt(100))
+	walletPublicKeyHash := bitcoin.PublicKeyHash(wallet.publicKey)
+
+	sighash := bitcoin.ComputeHash(NewHeartbeatMessage(1000))
+
+	localChain.setFraudChallenge(wallet.publicKey, sighash, &FraudChallenge{
+		ReportedAt: time.Now().Add(
+			-localChain.fraudChallengeDefeatTimeout - time.Minute,
+		),
+	})
+
+	err := defender.defend(
+		context.Background(),
+		wallet.publicKey,
+		group.MemberIndex(1),
+		&FraudChallengeSubmittedEvent{
+			WalletPublicKeyHash: walletPublicKeyHash,
+			Sighash:             sighash,
+			BlockNumber:         1500,
+		},
+	)
+	if err == nil {
+		t.Fatal("expected error")
+	}
+
+	testutils.AssertIntsEqual(
+		t,
+		"defenses count",
+		0,
+		len(localChain.fraudChallengeDefenses),
+	)
+}
+
+func TestFraudChallengeDefender_Defend_UnknownSighash(t *testing.T) {
+	defender, localChain, _ := setupFraudChallengeDefender()
+
+	wallet := generateWallet(big.NewInt(100))
+	walletPublicKeyHash := bitcoin.PublicKeyHash(wallet.publicKey)
+
+	sighash := [32]byte{1, 2, 3}
+
+	localChain.setFraudChallenge(wallet.publicKey, sighash, &FraudChallenge{
+		ReportedAt: time.Now(),
+	})
+
+	// The defense cannot be found so, it is retried until the context
+	// is done.
+	ctx, cancelCtx := context.WithTimeout(
+		context.Background(),
+		100*time.Millisecond,
+	)
+	defer cancelCtx()
+
+	err := defender.defend(
+		ctx,
+		wallet.publicKey,
+		group.MemberIndex(1),
+		&FraudChallengeSubmittedEvent{
+			WalletPublicKeyHash: walletPublicKeyHash,
+			Sighash:             sighash,
+			BlockNumber:         1500,
+		},
+	)
+	if err == nil {
+		t.Fatal("expected error")
+	}
+
+	testutils.AssertIntsEqual(
+		t,
+		"defenses count",
+		0,
+		len(localChain.fraudChallengeDefenses),
+	)
+}
+
+func TestFraudChallengeDefender_Defend_RetryFailedSubmission(t *testing.T) {
+	defender, localChain, _ := setupFraudChallengeDefender()
+
+	wallet := generateWallet(big.NewInt(100))
+	walletPublicKeyHash := bitcoin.PublicKeyHash(wallet.publicKey)
+
+	heartbeatMessage := NewHeartbeatMessage(1000)
+	sighash := bitcoin.ComputeHash(heartbeatMessage)
+
+	localChain.setFraudChallenge(wallet.publicKey, sighash, &FraudChallenge{
+		ReportedAt: time.Now(),
+	})
+	// The first two defense submissions fail.
+	localChain.setFraudChallengeDefenseErrors(2)
+
+	err := defender.defend(
+		context.Background(),
+		wallet.publicKey,
+		group.MemberIndex(1),
+		&FraudChallengeSubmittedEvent{
+			WalletPublicKeyHash: walletPublicKeyHash,
+			Sighash:             sighash,
+			BlockNumber:         1005,
+		},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertIntsEqual(
+		t,
+		"defenses count",
+		1,
+		len(localChain.fraudChallengeDefenses),
+	)
+
+	challenge, _, err := localChain.GetFraudChallenge(wallet.publicKey, sighash)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertBoolsEqual(t, "resolved flag", true, challenge.Resolved)
+}
+
+func TestFindPendingFraudChallenges(t *testing.T) {
+	localChain := Connect()
+
+	wallet1 := generateWallet(big.NewInt(100))
+	wallet1PublicKeyHash := bitcoin.PublicKeyHash(wallet1.publicKey)
+	wallet2 := generateWallet(big.NewInt(200))
+	wallet2PublicKeyHash := bitcoin.PublicKeyHash(wallet2.publicKey)
+	// Wallet not controlled by the node.
+	wallet3 := generateWallet(big.NewInt(300))
+	wallet3PublicKeyHash := bitcoin.PublicKeyHash(wallet3.publicKey)
+
+	now := time.Now()
+
+	pendingSighash := [32]byte{0x01}
+	resolvedSighash := [32]byte{0x02}
+	timedOutSighash := [32]byte{0x03}
+	otherWalletSighash := [32]byte{0x04}
+
+	localChain.setFraudChallenge(
+		wallet1.publicKey,
+		pendingSighash,
+		&FraudChallenge{ReportedAt: now.Add(-time.Hour)},
+	)
+	localChain.setFraudChallenge(
+		wallet1.publicKey,
+		resolvedSighash,
+		&FraudChallenge{ReportedAt: now.Add(-time.Hour), Resolved: true},
+	)
+	localChain.setFraudChallenge(
+		wallet2.publicKey,
+		timedOutSighash,
+		&FraudChallenge{
+			ReportedAt: now.Add(
+				-localChain.fraudChallengeDefeatTimeout - time.Minute,
+			),
+		},
+	)
+	localChain.setFraudChallenge(
+		wallet3.publicKey,
+		otherWalletSighash,
+		&FraudChallenge{ReportedAt: now.Add(-time.Hour)},
+	)
+
+	pendingEvent := &FraudChallengeSubmittedEvent{
+		WalletPublicKeyHash: wallet1PublicKeyHash,
+		Sighash:             pendingSighash,
+	}
+
+	for _, event := range []*FraudChallengeSubmittedEvent{
+		pendingEvent,
+		{
+			WalletPublicKeyHash: wallet1PublicKeyHash,
+			Sighash:             resolvedSighash,
+		},
+		{
+			WalletPublicKeyHash: wallet2PublicKeyHash,
+			Sighash:             timedOutSighash,
+		},
+		{
+			WalletPublicKeyHash: wallet3PublicKeyHash,
+			Sighash:             otherWalletSighash,
+		},
+	} {
+		localChain.addPastFraudChallengeSubmittedEvent(event)
+	}
+
+	events, err := findPendingFraudChallenges(
+		localChain,
+		[]*ecdsa.PublicKey{wallet1.publicKey, wallet2.publicKey},
+		now,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertIntsEqual(t, "pending challenges count", 1, len(events))
+
+	if events[0] != pendingEvent {
+		t.Errorf("unexpected pending challenge: [%+v]", events[0])
+	}
+}
+
+func TestFindPendingFraudChallenges_NoWallets(t *testing.T) {
+	localChain := Connect()
+
+	localChain.addPastFraudChallengeSubmittedEvent(
+		&FraudChallengeSubmittedEvent{Sighash: [32]byte{0x01}},
+	)
+
+	events, err := findPendingFraudChallenges(localChain, nil, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertIntsEqual(t, "pending challenges count", 0, len(events))
+}
+
+func setupFraudChallengeDefender() (
+	*fraudChallengeDefender,
+	*localChain,
+	*localBitcoinChain,
+) {
+	localChain := Connect()
+	btcChain := newLocalBitcoinChain()
+
+	defender := newFraudChallengeDefender(
+		localChain,
+		btcChain,
+		newSigningAuditLog(&mockPersistenceHandle{}),
+		func(ctx context.Context, block uint64) error {
+			return nil
+		},
+	)
+	defender.retryDelay = 10 * time.Millisecond
+
+	return defender, localChain, btcChain
+}
+
+// fraudChallengeTransactionFixture builds a transaction spending a P2WPKH
+// output locked by the wallet public key hash and paying to the given
+// target public key hash. The transaction holding the spent output is
+// broadcast to the given Bitcoin chain. Returns the spending transaction
+// and the signature hash preimage of its only input.
+func fraudChallengeTransactionFixture(
+	t *testing.T,
+	btcChain *localBitcoinChain,
+	walletPublicKeyHash [20]byte,
+	targetPublicKeyHash [20]byte,
+) (*bitcoin.Transaction, []byte) {
+	walletScript, err := bitcoin.PayToWitnessPublicKeyHash(walletPublicKeyHash)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	targetScript, err := bitcoin.PayToWitnessPublicKeyHash(targetPublicKeyHash)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	previousTransaction := &bitcoin.Transaction{
+		Version: 1,
+		Inputs: []*bitcoin.TransactionInput{
+			{
+				Outpoint: &bitcoin.TransactionOutpoint{
+					TransactionHash: bitcoin.Hash{0xff},
+					OutputIndex:     0,
+				},
+				Sequence: 0xffffffff,
+			},
+		},
+		Outputs: []*bitcoin.TransactionOutput{
+			{
+				Value:           100000,
+				PublicKeyScript: walletScript,
+			},
+		},
+	}
+
+	err = btcChain.BroadcastTransaction(previousTransaction)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	transaction := &bitcoin.Transaction{
+		Version: 1,
+		Inputs: []*bitcoin.TransactionInput{
+			{
+				Outpoint: &bitcoin.TransactionOutpoint{
+					TransactionHash: previousTransaction.Hash(),
+					OutputIndex:     0,
+				},
+				Witness:  [][]byte{{0x01}, {0x02}},
+				Sequence: 0xffffffff,
+			},
+		},
+		Outputs: []*bitcoin.TransactionOutput{
+			{
+				Value:           90000,
+				PublicKeyScript: targetScript,
+			},
+		},
+	}
+
+	preimage, _, err := transaction.SignatureHashPreimage(
+		0,
+		previousTransaction.Outputs[0],
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return transaction, preimage
+}
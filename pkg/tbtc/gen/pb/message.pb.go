@@ -99,6 +99,69 @@ func (x *SigningDoneMessage) GetEndBlock() uint64 {
 	return 0
 }
 
+type InactivityClaimSignatureMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SenderID  uint32 `protobuf:"varint,1,opt,name=senderID,proto3" json:"senderID,omitempty"`
+	ClaimHash []byte `protobuf:"bytes,2,opt,name=claimHash,proto3" json:"claimHash,omitempty"`
+	Signature []byte `protobuf:"bytes,3,opt,name=signature,proto3" json:"signature,omitempty"`
+}
+
+func (x *InactivityClaimSignatureMessage) Reset() {
+	*x = InactivityClaimSignatureMessage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_tbtc_gen_pb_message_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *InactivityClaimSignatureMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InactivityClaimSignatureMessage) ProtoMessage() {}
+
+func (x *InactivityClaimSignatureMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_tbtc_gen_pb_message_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InactivityClaimSignatureMessage.ProtoReflect.Descriptor instead.
+func (*InactivityClaimSignatureMessage) Descriptor() ([]byte, []int) {
+	return file_pkg_tbtc_gen_pb_message_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *InactivityClaimSignatureMessage) GetSenderID() uint32 {
+	if x != nil {
+		return x.SenderID
+	}
+	return 0
+}
+
+func (x *InactivityClaimSignatureMessage) GetClaimHash() []byte {
+	if x != nil {
+		return x.ClaimHash
+	}
+	return nil
+}
+
+func (x *InactivityClaimSignatureMessage) GetSignature() []byte {
+	if x != nil {
+		return x.Signature
+	}
+	return nil
+}
+
 type CoordinationProposal struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -111,7 +174,7 @@ type CoordinationProposal struct {
 func (x *CoordinationProposal) Reset() {
 	*x = CoordinationProposal{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_tbtc_gen_pb_message_proto_msgTypes[1]
+		mi := &file_pkg_tbtc_gen_pb_message_proto_msgTypes[2]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -124,7 +187,7 @@ func (x *CoordinationProposal) String() string {
 func (*CoordinationProposal) ProtoMessage() {}
 
 func (x *CoordinationProposal) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_tbtc_gen_pb_message_proto_msgTypes[1]
+	mi := &file_pkg_tbtc_gen_pb_message_proto_msgTypes[2]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -137,7 +200,7 @@ func (x *CoordinationProposal) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CoordinationProposal.ProtoReflect.Descriptor instead.
 func (*CoordinationProposal) Descriptor() ([]byte, []int) {
-	return file_pkg_tbtc_gen_pb_message_proto_rawDescGZIP(), []int{1}
+	return file_pkg_tbtc_gen_pb_message_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *CoordinationProposal) GetActionType() uint32 {
@@ -168,7 +231,7 @@ type CoordinationMessage struct {
 func (x *CoordinationMessage) Reset() {
 	*x = CoordinationMessage{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_tbtc_gen_pb_message_proto_msgTypes[2]
+		mi := &file_pkg_tbtc_gen_pb_message_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -181,7 +244,7 @@ func (x *CoordinationMessage) String() string {
 func (*CoordinationMessage) ProtoMessage() {}
 
 func (x *CoordinationMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_tbtc_gen_pb_message_proto_msgTypes[2]
+	mi := &file_pkg_tbtc_gen_pb_message_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -194,7 +257,7 @@ func (x *CoordinationMessage) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CoordinationMessage.ProtoReflect.Descriptor instead.
 func (*CoordinationMessage) Descriptor() ([]byte, []int) {
-	return file_pkg_tbtc_gen_pb_message_proto_rawDescGZIP(), []int{2}
+	return file_pkg_tbtc_gen_pb_message_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *CoordinationMessage) GetSenderID() uint32 {
@@ -236,7 +299,7 @@ type HeartbeatProposal struct {
 func (x *HeartbeatProposal) Reset() {
 	*x = HeartbeatProposal{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_tbtc_gen_pb_message_proto_msgTypes[3]
+		mi := &file_pkg_tbtc_gen_pb_message_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -249,7 +312,7 @@ func (x *HeartbeatProposal) String() string {
 func (*HeartbeatProposal) ProtoMessage() {}
 
 func (x *HeartbeatProposal) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_tbtc_gen_pb_message_proto_msgTypes[3]
+	mi := &file_pkg_tbtc_gen_pb_message_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -262,7 +325,7 @@ func (x *HeartbeatProposal) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HeartbeatProposal.ProtoReflect.Descriptor instead.
 func (*HeartbeatProposal) Descriptor() ([]byte, []int) {
-	return file_pkg_tbtc_gen_pb_message_proto_rawDescGZIP(), []int{3}
+	return file_pkg_tbtc_gen_pb_message_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *HeartbeatProposal) GetMessage() []byte {
@@ -285,7 +348,7 @@ type DepositSweepProposal struct {
 func (x *DepositSweepProposal) Reset() {
 	*x = DepositSweepProposal{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_tbtc_gen_pb_message_proto_msgTypes[4]
+		mi := &file_pkg_tbtc_gen_pb_message_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -298,7 +361,7 @@ func (x *DepositSweepProposal) String() string {
 func (*DepositSweepProposal) ProtoMessage() {}
 
 func (x *DepositSweepProposal) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_tbtc_gen_pb_message_proto_msgTypes[4]
+	mi := &file_pkg_tbtc_gen_pb_message_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -311,7 +374,7 @@ func (x *DepositSweepProposal) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DepositSweepProposal.ProtoReflect.Descriptor instead.
 func (*DepositSweepProposal) Descriptor() ([]byte, []int) {
-	return file_pkg_tbtc_gen_pb_message_proto_rawDescGZIP(), []int{4}
+	return file_pkg_tbtc_gen_pb_message_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *DepositSweepProposal) GetDepositsKeys() []*DepositSweepProposal_DepositKey {
@@ -347,7 +410,7 @@ type RedemptionProposal struct {
 func (x *RedemptionProposal) Reset() {
 	*x = RedemptionProposal{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_tbtc_gen_pb_message_proto_msgTypes[5]
+		mi := &file_pkg_tbtc_gen_pb_message_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -360,7 +423,7 @@ func (x *RedemptionProposal) String() string {
 func (*RedemptionProposal) ProtoMessage() {}
 
 func (x *RedemptionProposal) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_tbtc_gen_pb_message_proto_msgTypes[5]
+	mi := &file_pkg_tbtc_gen_pb_message_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -373,7 +436,7 @@ func (x *RedemptionProposal) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RedemptionProposal.ProtoReflect.Descriptor instead.
 func (*RedemptionProposal) Descriptor() ([]byte, []int) {
-	return file_pkg_tbtc_gen_pb_message_proto_rawDescGZIP(), []int{5}
+	return file_pkg_tbtc_gen_pb_message_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *RedemptionProposal) GetRedeemersOutputScripts() [][]byte {
@@ -390,6 +453,179 @@ func (x *RedemptionProposal) GetRedemptionTxFee() []byte {
 	return nil
 }
 
+type MovingFundsProposal struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TargetWallets    [][]byte `protobuf:"bytes,1,rep,name=targetWallets,proto3" json:"targetWallets,omitempty"`
+	MovingFundsTxFee []byte   `protobuf:"bytes,2,opt,name=movingFundsTxFee,proto3" json:"movingFundsTxFee,omitempty"`
+}
+
+func (x *MovingFundsProposal) Reset() {
+	*x = MovingFundsProposal{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_tbtc_gen_pb_message_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MovingFundsProposal) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MovingFundsProposal) ProtoMessage() {}
+
+func (x *MovingFundsProposal) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_tbtc_gen_pb_message_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MovingFundsProposal.ProtoReflect.Descriptor instead.
+func (*MovingFundsProposal) Descriptor() ([]byte, []int) {
+	return file_pkg_tbtc_gen_pb_message_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *MovingFundsProposal) GetTargetWallets() [][]byte {
+	if x != nil {
+		return x.TargetWallets
+	}
+	return nil
+}
+
+func (x *MovingFundsProposal) GetMovingFundsTxFee() []byte {
+	if x != nil {
+		return x.MovingFundsTxFee
+	}
+	return nil
+}
+
+type MovedFundsSweepProposal struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MovingFundsTxHash        []byte `protobuf:"bytes,1,opt,name=movingFundsTxHash,proto3" json:"movingFundsTxHash,omitempty"`
+	MovingFundsTxOutputIndex uint32 `protobuf:"varint,2,opt,name=movingFundsTxOutputIndex,proto3" json:"movingFundsTxOutputIndex,omitempty"`
+	SweepTxFee               []byte `protobuf:"bytes,3,opt,name=sweepTxFee,proto3" json:"sweepTxFee,omitempty"`
+}
+
+func (x *MovedFundsSweepProposal) Reset() {
+	*x = MovedFundsSweepProposal{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_tbtc_gen_pb_message_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MovedFundsSweepProposal) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MovedFundsSweepProposal) ProtoMessage() {}
+
+func (x *MovedFundsSweepProposal) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_tbtc_gen_pb_message_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MovedFundsSweepProposal.ProtoReflect.Descriptor instead.
+func (*MovedFundsSweepProposal) Descriptor() ([]byte, []int) {
+	return file_pkg_tbtc_gen_pb_message_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *MovedFundsSweepProposal) GetMovingFundsTxHash() []byte {
+	if x != nil {
+		return x.MovingFundsTxHash
+	}
+	return nil
+}
+
+func (x *MovedFundsSweepProposal) GetMovingFundsTxOutputIndex() uint32 {
+	if x != nil {
+		return x.MovingFundsTxOutputIndex
+	}
+	return 0
+}
+
+func (x *MovedFundsSweepProposal) GetSweepTxFee() []byte {
+	if x != nil {
+		return x.SweepTxFee
+	}
+	return nil
+}
+
+type FeeBumpProposal struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TransactionHash []byte `protobuf:"bytes,1,opt,name=transactionHash,proto3" json:"transactionHash,omitempty"`
+	TransactionFee  []byte `protobuf:"bytes,2,opt,name=transactionFee,proto3" json:"transactionFee,omitempty"`
+}
+
+func (x *FeeBumpProposal) Reset() {
+	*x = FeeBumpProposal{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_tbtc_gen_pb_message_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FeeBumpProposal) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FeeBumpProposal) ProtoMessage() {}
+
+func (x *FeeBumpProposal) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_tbtc_gen_pb_message_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FeeBumpProposal.ProtoReflect.Descriptor instead.
+func (*FeeBumpProposal) Descriptor() ([]byte, []int) {
+	return file_pkg_tbtc_gen_pb_message_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *FeeBumpProposal) GetTransactionHash() []byte {
+	if x != nil {
+		return x.TransactionHash
+	}
+	return nil
+}
+
+func (x *FeeBumpProposal) GetTransactionFee() []byte {
+	if x != nil {
+		return x.TransactionFee
+	}
+	return nil
+}
+
 type DepositSweepProposal_DepositKey struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -402,7 +638,7 @@ type DepositSweepProposal_DepositKey struct {
 func (x *DepositSweepProposal_DepositKey) Reset() {
 	*x = DepositSweepProposal_DepositKey{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_tbtc_gen_pb_message_proto_msgTypes[6]
+		mi := &file_pkg_tbtc_gen_pb_message_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -415,7 +651,7 @@ func (x *DepositSweepProposal_DepositKey) String() string {
 func (*DepositSweepProposal_DepositKey) ProtoMessage() {}
 
 func (x *DepositSweepProposal_DepositKey) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_tbtc_gen_pb_message_proto_msgTypes[6]
+	mi := &file_pkg_tbtc_gen_pb_message_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -428,7 +664,7 @@ func (x *DepositSweepProposal_DepositKey) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DepositSweepProposal_DepositKey.ProtoReflect.Descriptor instead.
 func (*DepositSweepProposal_DepositKey) Descriptor() ([]byte, []int) {
-	return file_pkg_tbtc_gen_pb_message_proto_rawDescGZIP(), []int{4, 0}
+	return file_pkg_tbtc_gen_pb_message_proto_rawDescGZIP(), []int{5, 0}
 }
 
 func (x *DepositSweepProposal_DepositKey) GetFundingTxHash() []byte {
@@ -461,54 +697,85 @@ var file_pkg_tbtc_gen_pb_message_proto_rawDesc = []byte{
 	0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x73, 0x69, 0x67,
 	0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x65, 0x6e, 0x64, 0x42, 0x6c, 0x6f,
 	0x63, 0x6b, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x65, 0x6e, 0x64, 0x42, 0x6c, 0x6f,
-	0x63, 0x6b, 0x22, 0x50, 0x0a, 0x14, 0x43, 0x6f, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x61, 0x74, 0x69,
-	0x6f, 0x6e, 0x50, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61, 0x6c, 0x12, 0x1e, 0x0a, 0x0a, 0x61, 0x63,
-	0x74, 0x69, 0x6f, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a,
-	0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x61,
-	0x79, 0x6c, 0x6f, 0x61, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x70, 0x61, 0x79,
-	0x6c, 0x6f, 0x61, 0x64, 0x22, 0xc9, 0x01, 0x0a, 0x13, 0x43, 0x6f, 0x6f, 0x72, 0x64, 0x69, 0x6e,
-	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1a, 0x0a, 0x08,
-	0x73, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08,
-	0x73, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x49, 0x44, 0x12, 0x2c, 0x0a, 0x11, 0x63, 0x6f, 0x6f, 0x72,
-	0x64, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x04, 0x52, 0x11, 0x63, 0x6f, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f,
-	0x6e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x30, 0x0a, 0x13, 0x77, 0x61, 0x6c, 0x6c, 0x65, 0x74,
-	0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79, 0x48, 0x61, 0x73, 0x68, 0x18, 0x03, 0x20,
-	0x01, 0x28, 0x0c, 0x52, 0x13, 0x77, 0x61, 0x6c, 0x6c, 0x65, 0x74, 0x50, 0x75, 0x62, 0x6c, 0x69,
-	0x63, 0x4b, 0x65, 0x79, 0x48, 0x61, 0x73, 0x68, 0x12, 0x36, 0x0a, 0x08, 0x70, 0x72, 0x6f, 0x70,
-	0x6f, 0x73, 0x61, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x74, 0x62, 0x74,
-	0x63, 0x2e, 0x43, 0x6f, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x72,
-	0x6f, 0x70, 0x6f, 0x73, 0x61, 0x6c, 0x52, 0x08, 0x70, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61, 0x6c,
-	0x22, 0x2d, 0x0a, 0x11, 0x48, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x50, 0x72, 0x6f,
-	0x70, 0x6f, 0x73, 0x61, 0x6c, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22,
-	0x99, 0x02, 0x0a, 0x14, 0x44, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x53, 0x77, 0x65, 0x65, 0x70,
-	0x50, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61, 0x6c, 0x12, 0x49, 0x0a, 0x0c, 0x64, 0x65, 0x70, 0x6f,
-	0x73, 0x69, 0x74, 0x73, 0x4b, 0x65, 0x79, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x25,
-	0x2e, 0x74, 0x62, 0x74, 0x63, 0x2e, 0x44, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x53, 0x77, 0x65,
-	0x65, 0x70, 0x50, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61, 0x6c, 0x2e, 0x44, 0x65, 0x70, 0x6f, 0x73,
-	0x69, 0x74, 0x4b, 0x65, 0x79, 0x52, 0x0c, 0x64, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x73, 0x4b,
-	0x65, 0x79, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x73, 0x77, 0x65, 0x65, 0x70, 0x54, 0x78, 0x46, 0x65,
-	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0a, 0x73, 0x77, 0x65, 0x65, 0x70, 0x54, 0x78,
-	0x46, 0x65, 0x65, 0x12, 0x32, 0x0a, 0x14, 0x64, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x73, 0x52,
-	0x65, 0x76, 0x65, 0x61, 0x6c, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28,
-	0x04, 0x52, 0x14, 0x64, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x73, 0x52, 0x65, 0x76, 0x65, 0x61,
-	0x6c, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x1a, 0x62, 0x0a, 0x0a, 0x44, 0x65, 0x70, 0x6f, 0x73,
-	0x69, 0x74, 0x4b, 0x65, 0x79, 0x12, 0x24, 0x0a, 0x0d, 0x66, 0x75, 0x6e, 0x64, 0x69, 0x6e, 0x67,
-	0x54, 0x78, 0x48, 0x61, 0x73, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0d, 0x66, 0x75,
-	0x6e, 0x64, 0x69, 0x6e, 0x67, 0x54, 0x78, 0x48, 0x61, 0x73, 0x68, 0x12, 0x2e, 0x0a, 0x12, 0x66,
-	0x75, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x49, 0x6e, 0x64, 0x65,
-	0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x12, 0x66, 0x75, 0x6e, 0x64, 0x69, 0x6e, 0x67,
-	0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x22, 0x76, 0x0a, 0x12, 0x52,
-	0x65, 0x64, 0x65, 0x6d, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61,
-	0x6c, 0x12, 0x36, 0x0a, 0x16, 0x72, 0x65, 0x64, 0x65, 0x65, 0x6d, 0x65, 0x72, 0x73, 0x4f, 0x75,
-	0x74, 0x70, 0x75, 0x74, 0x53, 0x63, 0x72, 0x69, 0x70, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
-	0x0c, 0x52, 0x16, 0x72, 0x65, 0x64, 0x65, 0x65, 0x6d, 0x65, 0x72, 0x73, 0x4f, 0x75, 0x74, 0x70,
-	0x75, 0x74, 0x53, 0x63, 0x72, 0x69, 0x70, 0x74, 0x73, 0x12, 0x28, 0x0a, 0x0f, 0x72, 0x65, 0x64,
-	0x65, 0x6d, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x78, 0x46, 0x65, 0x65, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x0c, 0x52, 0x0f, 0x72, 0x65, 0x64, 0x65, 0x6d, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x78,
-	0x46, 0x65, 0x65, 0x42, 0x06, 0x5a, 0x04, 0x2e, 0x2f, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x33,
+	0x63, 0x6b, 0x22, 0x79, 0x0a, 0x1f, 0x49, 0x6e, 0x61, 0x63, 0x74, 0x69, 0x76, 0x69, 0x74, 0x79,
+	0x43, 0x6c, 0x61, 0x69, 0x6d, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x4d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x49,
+	0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x73, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x49,
+	0x44, 0x12, 0x1c, 0x0a, 0x09, 0x63, 0x6c, 0x61, 0x69, 0x6d, 0x48, 0x61, 0x73, 0x68, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x63, 0x6c, 0x61, 0x69, 0x6d, 0x48, 0x61, 0x73, 0x68, 0x12,
+	0x1c, 0x0a, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x22, 0x50, 0x0a,
+	0x14, 0x43, 0x6f, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x72, 0x6f,
+	0x70, 0x6f, 0x73, 0x61, 0x6c, 0x12, 0x1e, 0x0a, 0x0a, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x54,
+	0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a, 0x61, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x54, 0x79, 0x70, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x22,
+	0xc9, 0x01, 0x0a, 0x13, 0x43, 0x6f, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x65, 0x6e, 0x64, 0x65,
+	0x72, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x73, 0x65, 0x6e, 0x64, 0x65,
+	0x72, 0x49, 0x44, 0x12, 0x2c, 0x0a, 0x11, 0x63, 0x6f, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x11,
+	0x63, 0x6f, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x42, 0x6c, 0x6f, 0x63,
+	0x6b, 0x12, 0x30, 0x0a, 0x13, 0x77, 0x61, 0x6c, 0x6c, 0x65, 0x74, 0x50, 0x75, 0x62, 0x6c, 0x69,
+	0x63, 0x4b, 0x65, 0x79, 0x48, 0x61, 0x73, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x13,
+	0x77, 0x61, 0x6c, 0x6c, 0x65, 0x74, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79, 0x48,
+	0x61, 0x73, 0x68, 0x12, 0x36, 0x0a, 0x08, 0x70, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61, 0x6c, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x74, 0x62, 0x74, 0x63, 0x2e, 0x43, 0x6f, 0x6f,
+	0x72, 0x64, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61,
+	0x6c, 0x52, 0x08, 0x70, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61, 0x6c, 0x22, 0x2d, 0x0a, 0x11, 0x48,
+	0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x50, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61, 0x6c,
+	0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x99, 0x02, 0x0a, 0x14, 0x44,
+	0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x53, 0x77, 0x65, 0x65, 0x70, 0x50, 0x72, 0x6f, 0x70, 0x6f,
+	0x73, 0x61, 0x6c, 0x12, 0x49, 0x0a, 0x0c, 0x64, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x73, 0x4b,
+	0x65, 0x79, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x25, 0x2e, 0x74, 0x62, 0x74, 0x63,
+	0x2e, 0x44, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x53, 0x77, 0x65, 0x65, 0x70, 0x50, 0x72, 0x6f,
+	0x70, 0x6f, 0x73, 0x61, 0x6c, 0x2e, 0x44, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x4b, 0x65, 0x79,
+	0x52, 0x0c, 0x64, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x73, 0x4b, 0x65, 0x79, 0x73, 0x12, 0x1e,
+	0x0a, 0x0a, 0x73, 0x77, 0x65, 0x65, 0x70, 0x54, 0x78, 0x46, 0x65, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x0a, 0x73, 0x77, 0x65, 0x65, 0x70, 0x54, 0x78, 0x46, 0x65, 0x65, 0x12, 0x32,
+	0x0a, 0x14, 0x64, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x73, 0x52, 0x65, 0x76, 0x65, 0x61, 0x6c,
+	0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x04, 0x52, 0x14, 0x64, 0x65,
+	0x70, 0x6f, 0x73, 0x69, 0x74, 0x73, 0x52, 0x65, 0x76, 0x65, 0x61, 0x6c, 0x42, 0x6c, 0x6f, 0x63,
+	0x6b, 0x73, 0x1a, 0x62, 0x0a, 0x0a, 0x44, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x4b, 0x65, 0x79,
+	0x12, 0x24, 0x0a, 0x0d, 0x66, 0x75, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x54, 0x78, 0x48, 0x61, 0x73,
+	0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0d, 0x66, 0x75, 0x6e, 0x64, 0x69, 0x6e, 0x67,
+	0x54, 0x78, 0x48, 0x61, 0x73, 0x68, 0x12, 0x2e, 0x0a, 0x12, 0x66, 0x75, 0x6e, 0x64, 0x69, 0x6e,
+	0x67, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x12, 0x66, 0x75, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x4f, 0x75, 0x74, 0x70, 0x75,
+	0x74, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x22, 0x76, 0x0a, 0x12, 0x52, 0x65, 0x64, 0x65, 0x6d, 0x70,
+	0x74, 0x69, 0x6f, 0x6e, 0x50, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61, 0x6c, 0x12, 0x36, 0x0a, 0x16,
+	0x72, 0x65, 0x64, 0x65, 0x65, 0x6d, 0x65, 0x72, 0x73, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x53,
+	0x63, 0x72, 0x69, 0x70, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x16, 0x72, 0x65,
+	0x64, 0x65, 0x65, 0x6d, 0x65, 0x72, 0x73, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x53, 0x63, 0x72,
+	0x69, 0x70, 0x74, 0x73, 0x12, 0x28, 0x0a, 0x0f, 0x72, 0x65, 0x64, 0x65, 0x6d, 0x70, 0x74, 0x69,
+	0x6f, 0x6e, 0x54, 0x78, 0x46, 0x65, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0f, 0x72,
+	0x65, 0x64, 0x65, 0x6d, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x78, 0x46, 0x65, 0x65, 0x22, 0x67,
+	0x0a, 0x13, 0x4d, 0x6f, 0x76, 0x69, 0x6e, 0x67, 0x46, 0x75, 0x6e, 0x64, 0x73, 0x50, 0x72, 0x6f,
+	0x70, 0x6f, 0x73, 0x61, 0x6c, 0x12, 0x24, 0x0a, 0x0d, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x57,
+	0x61, 0x6c, 0x6c, 0x65, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x0d, 0x74, 0x61,
+	0x72, 0x67, 0x65, 0x74, 0x57, 0x61, 0x6c, 0x6c, 0x65, 0x74, 0x73, 0x12, 0x2a, 0x0a, 0x10, 0x6d,
+	0x6f, 0x76, 0x69, 0x6e, 0x67, 0x46, 0x75, 0x6e, 0x64, 0x73, 0x54, 0x78, 0x46, 0x65, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x10, 0x6d, 0x6f, 0x76, 0x69, 0x6e, 0x67, 0x46, 0x75, 0x6e,
+	0x64, 0x73, 0x54, 0x78, 0x46, 0x65, 0x65, 0x22, 0xa3, 0x01, 0x0a, 0x17, 0x4d, 0x6f, 0x76, 0x65,
+	0x64, 0x46, 0x75, 0x6e, 0x64, 0x73, 0x53, 0x77, 0x65, 0x65, 0x70, 0x50, 0x72, 0x6f, 0x70, 0x6f,
+	0x73, 0x61, 0x6c, 0x12, 0x2c, 0x0a, 0x11, 0x6d, 0x6f, 0x76, 0x69, 0x6e, 0x67, 0x46, 0x75, 0x6e,
+	0x64, 0x73, 0x54, 0x78, 0x48, 0x61, 0x73, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x11,
+	0x6d, 0x6f, 0x76, 0x69, 0x6e, 0x67, 0x46, 0x75, 0x6e, 0x64, 0x73, 0x54, 0x78, 0x48, 0x61, 0x73,
+	0x68, 0x12, 0x3a, 0x0a, 0x18, 0x6d, 0x6f, 0x76, 0x69, 0x6e, 0x67, 0x46, 0x75, 0x6e, 0x64, 0x73,
+	0x54, 0x78, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x18, 0x6d, 0x6f, 0x76, 0x69, 0x6e, 0x67, 0x46, 0x75, 0x6e, 0x64, 0x73,
+	0x54, 0x78, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x1e, 0x0a,
+	0x0a, 0x73, 0x77, 0x65, 0x65, 0x70, 0x54, 0x78, 0x46, 0x65, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x0a, 0x73, 0x77, 0x65, 0x65, 0x70, 0x54, 0x78, 0x46, 0x65, 0x65, 0x22, 0x63, 0x0a,
+	0x0f, 0x46, 0x65, 0x65, 0x42, 0x75, 0x6d, 0x70, 0x50, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61, 0x6c,
+	0x12, 0x28, 0x0a, 0x0f, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x48,
+	0x61, 0x73, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0f, 0x74, 0x72, 0x61, 0x6e, 0x73,
+	0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x61, 0x73, 0x68, 0x12, 0x26, 0x0a, 0x0e, 0x74, 0x72,
+	0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x46, 0x65, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x0e, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x46,
+	0x65, 0x65, 0x42, 0x06, 0x5a, 0x04, 0x2e, 0x2f, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x33,
 }
 
 var (
@@ -523,24 +790,28 @@ func file_pkg_tbtc_gen_pb_message_proto_rawDescGZIP() []byte {
 	return file_pkg_tbtc_gen_pb_message_proto_rawDescData
 }
 
-var file_pkg_tbtc_gen_pb_message_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_pkg_tbtc_gen_pb_message_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
 var file_pkg_tbtc_gen_pb_message_proto_goTypes = []interface{}{
 	(*SigningDoneMessage)(nil),              // 0: tbtc.SigningDoneMessage
-	(*CoordinationProposal)(nil),            // 1: tbtc.CoordinationProposal
-	(*CoordinationMessage)(nil),             // 2: tbtc.CoordinationMessage
-	(*HeartbeatProposal)(nil),               // 3: tbtc.HeartbeatProposal
-	(*DepositSweepProposal)(nil),            // 4: tbtc.DepositSweepProposal
-	(*RedemptionProposal)(nil),              // 5: tbtc.RedemptionProposal
-	(*DepositSweepProposal_DepositKey)(nil), // 6: tbtc.DepositSweepProposal.DepositKey
+	(*InactivityClaimSignatureMessage)(nil), // 1: tbtc.InactivityClaimSignatureMessage
+	(*CoordinationProposal)(nil),            // 2: tbtc.CoordinationProposal
+	(*CoordinationMessage)(nil),             // 3: tbtc.CoordinationMessage
+	(*HeartbeatProposal)(nil),               // 4: tbtc.HeartbeatProposal
+	(*DepositSweepProposal)(nil),            // 5: tbtc.DepositSweepProposal
+	(*RedemptionProposal)(nil),              // 6: tbtc.RedemptionProposal
+	(*MovingFundsProposal)(nil),             // 7: tbtc.MovingFundsProposal
+	(*MovedFundsSweepProposal)(nil),         // 8: tbtc.MovedFundsSweepProposal
+	(*FeeBumpProposal)(nil),                 // 9: tbtc.FeeBumpProposal
+	(*DepositSweepProposal_DepositKey)(nil), // 10: tbtc.DepositSweepProposal.DepositKey
 }
 var file_pkg_tbtc_gen_pb_message_proto_depIdxs = []int32{
-	1, // 0: tbtc.CoordinationMessage.proposal:type_name -> tbtc.CoordinationProposal
-	6, // 1: tbtc.DepositSweepProposal.depositsKeys:type_name -> tbtc.DepositSweepProposal.DepositKey
-	2, // [2:2] is the sub-list for method output_type
-	2, // [2:2] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	2,  // 0: tbtc.CoordinationMessage.proposal:type_name -> tbtc.CoordinationProposal
+	10, // 1: tbtc.DepositSweepProposal.depositsKeys:type_name -> tbtc.DepositSweepProposal.DepositKey
+	2,  // [2:2] is the sub-list for method output_type
+	2,  // [2:2] is the sub-list for method input_type
+	2,  // [2:2] is the sub-list for extension type_name
+	2,  // [2:2] is the sub-list for extension extendee
+	0,  // [0:2] is the sub-list for field type_name
 }
 
 func init() { file_pkg_tbtc_gen_pb_message_proto_init() }
@@ -562,7 +833,7 @@ func file_pkg_tbtc_gen_pb_message_proto_init() {
 			}
 		}
 		file_pkg_tbtc_gen_pb_message_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CoordinationProposal); i {
+			switch v := v.(*InactivityClaimSignatureMessage); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -574,7 +845,7 @@ func file_pkg_tbtc_gen_pb_message_proto_init() {
 			}
 		}
 		file_pkg_tbtc_gen_pb_message_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CoordinationMessage); i {
+			switch v := v.(*CoordinationProposal); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -586,7 +857,7 @@ func file_pkg_tbtc_gen_pb_message_proto_init() {
 			}
 		}
 		file_pkg_tbtc_gen_pb_message_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*HeartbeatProposal); i {
+			switch v := v.(*CoordinationMessage); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -598,7 +869,7 @@ func file_pkg_tbtc_gen_pb_message_proto_init() {
 			}
 		}
 		file_pkg_tbtc_gen_pb_message_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DepositSweepProposal); i {
+			switch v := v.(*HeartbeatProposal); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -610,7 +881,7 @@ func file_pkg_tbtc_gen_pb_message_proto_init() {
 			}
 		}
 		file_pkg_tbtc_gen_pb_message_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RedemptionProposal); i {
+			switch v := v.(*DepositSweepProposal); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -622,6 +893,54 @@ func file_pkg_tbtc_gen_pb_message_proto_init() {
 			}
 		}
 		file_pkg_tbtc_gen_pb_message_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RedemptionProposal); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_tbtc_gen_pb_message_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MovingFundsProposal); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_tbtc_gen_pb_message_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MovedFundsSweepProposal); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_tbtc_gen_pb_message_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FeeBumpProposal); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_tbtc_gen_pb_message_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*DepositSweepProposal_DepositKey); i {
 			case 0:
 				return &v.state
@@ -640,7 +959,7 @@ func file_pkg_tbtc_gen_pb_message_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_pkg_tbtc_gen_pb_message_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   7,
+			NumMessages:   11,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -2,13 +2,15 @@ package tbtc
 
 import (
 	"context"
+	"encoding/binary"
+	"errors"
 	"fmt"
 	"math/big"
+	"sync"
 	"time"
 
 	"github.com/ipfs/go-log/v2"
 	"github.com/keep-network/keep-core/pkg/bitcoin"
-	"github.com/keep-network/keep-core/pkg/tecdsa"
 )
 
 const (
@@ -18,18 +20,49 @@ const (
 	// take another actions. The value of 300 blocks is roughly 1 hour, assuming
 	// 12 seconds per block.
 	heartbeatProposalValidityBlocks = 300
-	// heartbeatRequestConfirmationBlocks determines the block length of the
-	// confirmation period on the host chain that is preserved after a heartbeat
-	// request submission.
-	heartbeatRequestConfirmationBlocks = 3
 	// heartbeatRequestTimeoutSafetyMargin determines the duration of the
 	// safety margin that must be preserved between the signing timeout
 	// and the timeout of the entire heartbeat action. This safety
 	// margin prevents against the case where signing completes too late and
 	// another action has been already requested by the coordinator.
 	heartbeatRequestTimeoutSafetyMargin = 5 * time.Minute
+	// heartbeatConsecutiveFailuresThreshold determines the number of
+	// consecutive heartbeat failures of a wallet after which the wallet's
+	// signing group raises an inactivity claim against its inactive members.
+	heartbeatConsecutiveFailuresThreshold = 3
+	// heartbeatInactivityClaimDelayBlocks determines the delay that is
+	// preserved between the heartbeat signing timeout and the start of the
+	// inactivity claim. This delay gives all signing group members time to
+	// realize the heartbeat failed.
+	heartbeatInactivityClaimDelayBlocks = 5
 )
 
+// heartbeatInactivityClaimStartBlock returns the block at which the
+// inactivity claim should be started once the heartbeat started at the
+// given block failed. The claim starts after the heartbeat signing times out
+// for all signing group members.
+func heartbeatInactivityClaimStartBlock(heartbeatStartBlock uint64) uint64 {
+	return signingTimeoutBlock(heartbeatStartBlock) +
+		heartbeatInactivityClaimDelayBlocks
+}
+
+// HeartbeatMessagePrefix is the prefix of each heartbeat message. The
+// prefix ensures heartbeat messages cannot be confused with Bitcoin
+// transaction signature hashes.
+var HeartbeatMessagePrefix = [8]byte{
+	0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+}
+
+// NewHeartbeatMessage builds the heartbeat message for the given block
+// number. The heartbeat message consists of the 8-byte heartbeat message
+// prefix followed by the 8-byte big-endian block number.
+func NewHeartbeatMessage(blockNumber uint64) []byte {
+	message := make([]byte, 16)
+	copy(message[:8], HeartbeatMessagePrefix[:])
+	binary.BigEndian.PutUint64(message[8:], blockNumber)
+	return message
+}
+
 type HeartbeatProposal struct {
 	Message []byte
 }
@@ -49,7 +82,65 @@ type heartbeatSigningExecutor interface {
 		ctx context.Context,
 		message *big.Int,
 		startBlock uint64,
-	) (*tecdsa.Signature, uint64, error)
+	) (*signingResult, error)
+
+	recordSignatures(
+		actionType WalletActionType,
+		transactionHash bitcoin.Hash,
+		messages []*big.Int,
+		results []*signingResult,
+	) error
+}
+
+// heartbeatInactivityClaimExecutor is an interface meant to decouple the
+// specific implementation of the inactivity claim executor from the heartbeat
+// action.
+type heartbeatInactivityClaimExecutor interface {
+	claimInactivity(
+		ctx context.Context,
+		heartbeatFailed bool,
+		startBlock uint64,
+	) error
+
+	announceActivity(
+		ctx context.Context,
+		startBlock uint64,
+	) error
+}
+
+// heartbeatFailureCounter holds the numbers of consecutive heartbeat failures
+// of wallets. All functions of the counter are safe for concurrent use.
+type heartbeatFailureCounter struct {
+	mutex sync.Mutex
+	// counts holds the number of consecutive heartbeat failures of each
+	// wallet. The map key is the 20-byte wallet public key hash.
+	counts map[[20]byte]uint
+}
+
+func newHeartbeatFailureCounter() *heartbeatFailureCounter {
+	return &heartbeatFailureCounter{
+		counts: make(map[[20]byte]uint),
+	}
+}
+
+// increment increments the number of consecutive heartbeat failures of the
+// given wallet and returns the incremented value.
+func (hfc *heartbeatFailureCounter) increment(walletPublicKeyHash [20]byte) uint {
+	hfc.mutex.Lock()
+	defer hfc.mutex.Unlock()
+
+	hfc.counts[walletPublicKeyHash]++
+
+	return hfc.counts[walletPublicKeyHash]
+}
+
+// reset resets the number of consecutive heartbeat failures of the given
+// wallet.
+func (hfc *heartbeatFailureCounter) reset(walletPublicKeyHash [20]byte) {
+	hfc.mutex.Lock()
+	defer hfc.mutex.Unlock()
+
+	delete(hfc.counts, walletPublicKeyHash)
 }
 
 // heartbeatAction is a walletAction implementation handling heartbeat requests
@@ -61,6 +152,9 @@ type heartbeatAction struct {
 	message          []byte
 	startBlock       uint64
 	requestExpiresAt time.Time
+
+	failureCounter          *heartbeatFailureCounter
+	inactivityClaimExecutor heartbeatInactivityClaimExecutor
 }
 
 func newHeartbeatAction(
@@ -70,14 +164,18 @@ func newHeartbeatAction(
 	message []byte,
 	startBlock uint64,
 	requestExpiresAt time.Time,
+	failureCounter *heartbeatFailureCounter,
+	inactivityClaimExecutor heartbeatInactivityClaimExecutor,
 ) *heartbeatAction {
 	return &heartbeatAction{
-		logger:           logger,
-		executingWallet:  executingWallet,
-		signingExecutor:  signingExecutor,
-		message:          message,
-		startBlock:       startBlock,
-		requestExpiresAt: requestExpiresAt,
+		logger:                  logger,
+		executingWallet:         executingWallet,
+		signingExecutor:         signingExecutor,
+		message:                 message,
+		startBlock:              startBlock,
+		requestExpiresAt:        requestExpiresAt,
+		failureCounter:          failureCounter,
+		inactivityClaimExecutor: inactivityClaimExecutor,
 	}
 }
 
@@ -94,20 +192,97 @@ func (ha *heartbeatAction) execute() error {
 	)
 	defer cancelHeartbeatCtx()
 
-	signature, _, err := ha.signingExecutor.sign(heartbeatCtx, messageToSign, ha.startBlock)
+	walletPublicKeyHash := bitcoin.PublicKeyHash(ha.executingWallet.publicKey)
+
+	result, err := ha.signingExecutor.sign(heartbeatCtx, messageToSign, ha.startBlock)
 	if err != nil {
+		// A busy signing executor means the wallet is occupied with another
+		// signing so, this is not a heartbeat failure.
+		if !errors.Is(err, errSigningExecutorBusy) {
+			ha.handleFailure(walletPublicKeyHash)
+		}
+
 		return fmt.Errorf("cannot sign heartbeat message: [%v]", err)
 	}
 
+	ha.failureCounter.reset(walletPublicKeyHash)
+
 	logger.Infof(
 		"generated signature [%s] for heartbeat message [0x%x]",
-		signature,
+		result.signature,
 		ha.message,
 	)
 
+	// Heartbeat does not produce any Bitcoin transaction so, the zero
+	// transaction hash is recorded.
+	err = ha.signingExecutor.recordSignatures(
+		ActionHeartbeat,
+		bitcoin.Hash{},
+		[]*big.Int{messageToSign},
+		[]*signingResult{result},
+	)
+	if err != nil {
+		logger.Errorf(
+			"cannot record heartbeat signature in audit log: [%v]",
+			err,
+		)
+	}
+
 	return nil
 }
 
+// handleFailure records the heartbeat failure of the wallet with the given
+// public key hash. Once the number of consecutive heartbeat failures reaches
+// the threshold, the inactivity claim is raised against inactive members of
+// the wallet's signing group. Below the threshold, the node only announces
+// its members are active in the claim other members may raise. Signing group
+// members may observe different numbers of consecutive failures, e.g. after
+// a restart, and must not claim each other inactive because of that.
+// The counter is reset once the claim is settled. If the claim fails, e.g.
+// because not enough members reached the threshold yet, the counter keeps
+// growing so the next failure raises the claim again, together with members
+// that reach the threshold later.
+func (ha *heartbeatAction) handleFailure(walletPublicKeyHash [20]byte) {
+	failuresCount := ha.failureCounter.increment(walletPublicKeyHash)
+
+	ha.logger.Warnf(
+		"heartbeat failed [%v] consecutive time(s)",
+		failuresCount,
+	)
+
+	claimCtx, cancelClaimCtx := context.WithDeadline(
+		context.Background(),
+		ha.requestExpiresAt,
+	)
+	defer cancelClaimCtx()
+
+	claimStartBlock := heartbeatInactivityClaimStartBlock(ha.startBlock)
+
+	if failuresCount < heartbeatConsecutiveFailuresThreshold {
+		err := ha.inactivityClaimExecutor.announceActivity(
+			claimCtx,
+			claimStartBlock,
+		)
+		if err != nil {
+			ha.logger.Errorf("cannot announce activity: [%v]", err)
+		}
+
+		return
+	}
+
+	err := ha.inactivityClaimExecutor.claimInactivity(
+		claimCtx,
+		true,
+		claimStartBlock,
+	)
+	if err != nil {
+		ha.logger.Errorf("cannot claim inactivity: [%v]", err)
+		return
+	}
+
+	ha.failureCounter.reset(walletPublicKeyHash)
+}
+
 func (ha *heartbeatAction) wallet() wallet {
 	return ha.executingWallet
 }
@@ -115,3 +290,11 @@ func (ha *heartbeatAction) wallet() wallet {
 func (ha *heartbeatAction) actionType() WalletActionType {
 	return ActionHeartbeat
 }
+
+func (ha *heartbeatAction) expiresAt() time.Time {
+	return ha.requestExpiresAt
+}
+
+func (ha *heartbeatAction) signingTimeoutBlock() uint64 {
+	return signingTimeoutBlock(ha.startBlock)
+}
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/keep-network/keep-core/internal/testutils"
+	"github.com/keep-network/keep-core/pkg/bitcoin"
 	"github.com/keep-network/keep-core/pkg/tecdsa"
 )
 
@@ -24,14 +25,25 @@ func TestHeartbeatAction_HappyPath(t *testing.T) {
 		t.Fatal(err)
 	}
 
+	executingWallet := generateWallet(big.NewInt(100))
+	walletPublicKeyHash := bitcoin.PublicKeyHash(executingWallet.publicKey)
+
+	// Simulate a previous heartbeat failure. It should be forgotten once
+	// the heartbeat succeeds.
+	failureCounter := newHeartbeatFailureCounter()
+	failureCounter.increment(walletPublicKeyHash)
+
 	mockExecutor := &mockHeartbeatSigningExecutor{}
+	mockClaimExecutor := &mockHeartbeatInactivityClaimExecutor{}
 	action := newHeartbeatAction(
 		logger,
-		wallet{},
+		executingWallet,
 		mockExecutor,
 		messageToSign,
 		startBlock,
 		time.Now(),
+		failureCounter,
+		mockClaimExecutor,
 	)
 
 	err = action.execute()
@@ -51,6 +63,36 @@ func TestHeartbeatAction_HappyPath(t *testing.T) {
 		startBlock,
 		mockExecutor.requestedStartBlock,
 	)
+	testutils.AssertIntsEqual(
+		t,
+		"recorded signatures count",
+		1,
+		len(mockExecutor.recordedMessages),
+	)
+	testutils.AssertBigIntsEqual(
+		t,
+		"recorded message",
+		new(big.Int).SetBytes(sha256d),
+		mockExecutor.recordedMessages[0],
+	)
+	testutils.AssertStringsEqual(
+		t,
+		"recorded action type",
+		ActionHeartbeat.String(),
+		mockExecutor.recordedActionType.String(),
+	)
+	testutils.AssertUintsEqual(
+		t,
+		"consecutive failures count",
+		0,
+		uint64(failureCounter.counts[walletPublicKeyHash]),
+	)
+	testutils.AssertIntsEqual(
+		t,
+		"inactivity claims count",
+		0,
+		len(mockClaimExecutor.requestedStartBlocks),
+	)
 }
 
 func TestHeartbeatAction_SigningError(t *testing.T) {
@@ -60,16 +102,25 @@ func TestHeartbeatAction_SigningError(t *testing.T) {
 		t.Fatal(err)
 	}
 
+	executingWallet := generateWallet(big.NewInt(100))
+	walletPublicKeyHash := bitcoin.PublicKeyHash(executingWallet.publicKey)
+
+	failureCounter := newHeartbeatFailureCounter()
+
 	mockExecutor := &mockHeartbeatSigningExecutor{}
 	mockExecutor.shouldFail = true
 
+	mockClaimExecutor := &mockHeartbeatInactivityClaimExecutor{}
+
 	action := newHeartbeatAction(
 		logger,
-		wallet{},
+		executingWallet,
 		mockExecutor,
 		messageToSign,
 		startBlock,
 		time.Now(),
+		failureCounter,
+		mockClaimExecutor,
 	)
 
 	err = action.execute()
@@ -82,26 +133,253 @@ func TestHeartbeatAction_SigningError(t *testing.T) {
 		"cannot sign heartbeat message: [oofta]",
 		err.Error(),
 	)
+	testutils.AssertUintsEqual(
+		t,
+		"consecutive failures count",
+		1,
+		uint64(failureCounter.counts[walletPublicKeyHash]),
+	)
+	testutils.AssertIntsEqual(
+		t,
+		"inactivity claims count",
+		0,
+		len(mockClaimExecutor.requestedStartBlocks),
+	)
+}
+
+func TestHeartbeatAction_SigningExecutorBusy(t *testing.T) {
+	executingWallet := generateWallet(big.NewInt(100))
+	walletPublicKeyHash := bitcoin.PublicKeyHash(executingWallet.publicKey)
+
+	failureCounter := newHeartbeatFailureCounter()
+
+	mockExecutor := &mockHeartbeatSigningExecutor{}
+	mockExecutor.signingError = errSigningExecutorBusy
+
+	action := newHeartbeatAction(
+		logger,
+		executingWallet,
+		mockExecutor,
+		[]byte{1},
+		10,
+		time.Now(),
+		failureCounter,
+		&mockHeartbeatInactivityClaimExecutor{},
+	)
+
+	err := action.execute()
+	if err == nil {
+		t.Fatal("expected error to be returned")
+	}
+
+	// A busy signing executor is not a heartbeat failure.
+	testutils.AssertUintsEqual(
+		t,
+		"consecutive failures count",
+		0,
+		uint64(failureCounter.counts[walletPublicKeyHash]),
+	)
+}
+
+func TestHeartbeatAction_ConsecutiveFailures(t *testing.T) {
+	executingWallet := generateWallet(big.NewInt(100))
+	walletPublicKeyHash := bitcoin.PublicKeyHash(executingWallet.publicKey)
+
+	failureCounter := newHeartbeatFailureCounter()
+
+	mockExecutor := &mockHeartbeatSigningExecutor{}
+	mockExecutor.shouldFail = true
+
+	mockClaimExecutor := &mockHeartbeatInactivityClaimExecutor{}
+
+	for i := 0; i < heartbeatConsecutiveFailuresThreshold; i++ {
+		startBlock := uint64(1000 * (i + 1))
+
+		action := newHeartbeatAction(
+			logger,
+			executingWallet,
+			mockExecutor,
+			[]byte{1},
+			startBlock,
+			time.Now().Add(time.Hour),
+			failureCounter,
+			mockClaimExecutor,
+		)
+
+		err := action.execute()
+		if err == nil {
+			t.Fatal("expected error to be returned")
+		}
+	}
+
+	// Failures below the threshold only announce activity so, members
+	// claiming inactivity do not consider the node's members inactive.
+	testutils.AssertIntsEqual(
+		t,
+		"activity announcements count",
+		heartbeatConsecutiveFailuresThreshold-1,
+		len(mockClaimExecutor.announcedStartBlocks),
+	)
+	for i, announcedStartBlock := range mockClaimExecutor.announcedStartBlocks {
+		testutils.AssertUintsEqual(
+			t,
+			fmt.Sprintf("activity announcement [%v] start block", i),
+			heartbeatInactivityClaimStartBlock(uint64(1000*(i+1))),
+			announcedStartBlock,
+		)
+	}
+	testutils.AssertIntsEqual(
+		t,
+		"inactivity claims count",
+		1,
+		len(mockClaimExecutor.requestedStartBlocks),
+	)
+	testutils.AssertUintsEqual(
+		t,
+		"inactivity claim start block",
+		heartbeatInactivityClaimStartBlock(3000),
+		mockClaimExecutor.requestedStartBlocks[0],
+	)
+	testutils.AssertBoolsEqual(
+		t,
+		"heartbeat failed flag",
+		true,
+		mockClaimExecutor.requestedHeartbeatFailed[0],
+	)
+	// The counter is reset once the inactivity claim is settled.
+	testutils.AssertUintsEqual(
+		t,
+		"consecutive failures count",
+		0,
+		uint64(failureCounter.counts[walletPublicKeyHash]),
+	)
+}
+
+func TestHeartbeatAction_ConsecutiveFailures_ClaimFailed(t *testing.T) {
+	executingWallet := generateWallet(big.NewInt(100))
+	walletPublicKeyHash := bitcoin.PublicKeyHash(executingWallet.publicKey)
+
+	failureCounter := newHeartbeatFailureCounter()
+
+	mockExecutor := &mockHeartbeatSigningExecutor{}
+	mockExecutor.shouldFail = true
+
+	mockClaimExecutor := &mockHeartbeatInactivityClaimExecutor{}
+	mockClaimExecutor.claimErr = fmt.Errorf("not enough supporting members")
+
+	for i := 0; i < heartbeatConsecutiveFailuresThreshold+1; i++ {
+		action := newHeartbeatAction(
+			logger,
+			executingWallet,
+			mockExecutor,
+			[]byte{1},
+			uint64(1000*(i+1)),
+			time.Now().Add(time.Hour),
+			failureCounter,
+			mockClaimExecutor,
+		)
+
+		err := action.execute()
+		if err == nil {
+			t.Fatal("expected error to be returned")
+		}
+	}
+
+	// The failed claim is raised again on the next failure, when more
+	// members may have reached the threshold.
+	testutils.AssertIntsEqual(
+		t,
+		"inactivity claims count",
+		2,
+		len(mockClaimExecutor.requestedStartBlocks),
+	)
+	testutils.AssertUintsEqual(
+		t,
+		"consecutive failures count",
+		heartbeatConsecutiveFailuresThreshold+1,
+		uint64(failureCounter.counts[walletPublicKeyHash]),
+	)
 }
 
 type mockHeartbeatSigningExecutor struct {
-	shouldFail bool
+	shouldFail   bool
+	signingError error
 
 	requestedMessage    *big.Int
 	requestedStartBlock uint64
+
+	recordedActionType WalletActionType
+	recordedMessages   []*big.Int
 }
 
 func (mhse *mockHeartbeatSigningExecutor) sign(
 	ctx context.Context,
 	message *big.Int,
 	startBlock uint64,
-) (*tecdsa.Signature, uint64, error) {
+) (*signingResult, error) {
 	mhse.requestedMessage = message
 	mhse.requestedStartBlock = startBlock
 
+	if mhse.signingError != nil {
+		return nil, mhse.signingError
+	}
+
 	if mhse.shouldFail {
-		return nil, 0, fmt.Errorf("oofta")
+		return nil, fmt.Errorf("oofta")
 	}
 
-	return &tecdsa.Signature{}, startBlock + 1, nil
+	return &signingResult{
+		signature: &tecdsa.Signature{},
+		endBlock:  startBlock + 1,
+	}, nil
+}
+
+func (mhse *mockHeartbeatSigningExecutor) recordSignatures(
+	actionType WalletActionType,
+	transactionHash bitcoin.Hash,
+	messages []*big.Int,
+	results []*signingResult,
+) error {
+	mhse.recordedActionType = actionType
+	mhse.recordedMessages = messages
+
+	return nil
+}
+
+type mockHeartbeatInactivityClaimExecutor struct {
+	claimErr error
+
+	requestedHeartbeatFailed []bool
+	requestedStartBlocks     []uint64
+
+	announcedStartBlocks []uint64
+}
+
+func (mhice *mockHeartbeatInactivityClaimExecutor) claimInactivity(
+	ctx context.Context,
+	heartbeatFailed bool,
+	startBlock uint64,
+) error {
+	mhice.requestedHeartbeatFailed = append(
+		mhice.requestedHeartbeatFailed,
+		heartbeatFailed,
+	)
+	mhice.requestedStartBlocks = append(
+		mhice.requestedStartBlocks,
+		startBlock,
+	)
+
+	return mhice.claimErr
+}
+
+func (mhice *mockHeartbeatInactivityClaimExecutor) announceActivity(
+	ctx context.Context,
+	startBlock uint64,
+) error {
+	mhice.announcedStartBlocks = append(
+		mhice.announcedStartBlocks,
+		startBlock,
+	)
+
+	return nil
 }
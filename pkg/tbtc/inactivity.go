@@ -0,0 +1,548 @@
+package tbtc
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+
+	"github.com/ipfs/go-log/v2"
+	"github.com/keep-network/keep-core/pkg/bitcoin"
+	"github.com/keep-network/keep-core/pkg/chain"
+	"github.com/keep-network/keep-core/pkg/net"
+	"github.com/keep-network/keep-core/pkg/protocol/announcer"
+	"github.com/keep-network/keep-core/pkg/protocol/group"
+	"go.uber.org/zap"
+	"golang.org/x/exp/slices"
+)
+
+const (
+	// inactivityClaimAnnouncementActiveBlocks determines the duration of the
+	// announcement phase that is performed at the beginning of the inactivity
+	// claim. Signing group members who do not announce their readiness
+	// during this phase are considered inactive.
+	inactivityClaimAnnouncementActiveBlocks = 5
+	// inactivityClaimSignaturesExchangeBlocks determines the duration of the
+	// phase during which signing group members exchange signatures supporting
+	// the inactivity claim.
+	inactivityClaimSignaturesExchangeBlocks = 10
+	// inactivityClaimSubmissionDelayStepBlocks determines the delay step in
+	// blocks that is used to calculate the submission delay period that should
+	// be respected by the given member to avoid all members submitting the
+	// same inactivity claim at the same time.
+	inactivityClaimSubmissionDelayStepBlocks = 3
+	// inactivityClaimReceiveBuffer is a buffer for messages received from
+	// the broadcast channel needed when the signatures exchange consumer is
+	// temporarily too slow to handle them.
+	inactivityClaimReceiveBuffer = 512
+)
+
+// inactivityClaimSignatureMessage is a message used to exchange signatures
+// supporting the inactivity claim across signing group members.
+type inactivityClaimSignatureMessage struct {
+	senderID  group.MemberIndex
+	claimHash InactivityClaimHash
+	signature []byte
+}
+
+func (icsm *inactivityClaimSignatureMessage) Type() string {
+	return "tbtc/inactivity_claim_signature_message"
+}
+
+// inactivityClaimExecutor is a component responsible for executing the
+// inactivity claim protocol of a specific wallet whose part is controlled
+// by this node. The protocol lets the signing group agree on the set of
+// inactive members, collect signatures of members supporting the claim and
+// submit the claim to the chain.
+type inactivityClaimExecutor struct {
+	chain               Chain
+	signers             []*signer
+	broadcastChannel    net.BroadcastChannel
+	membershipValidator *group.MembershipValidator
+	groupParameters     *GroupParameters
+
+	// waitForBlockFn is a function used to wait for the given block.
+	waitForBlockFn waitForBlockFn
+}
+
+func newInactivityClaimExecutor(
+	chain Chain,
+	signers []*signer,
+	broadcastChannel net.BroadcastChannel,
+	membershipValidator *group.MembershipValidator,
+	groupParameters *GroupParameters,
+	waitForBlockFn waitForBlockFn,
+) *inactivityClaimExecutor {
+	return &inactivityClaimExecutor{
+		chain:               chain,
+		signers:             signers,
+		broadcastChannel:    broadcastChannel,
+		membershipValidator: membershipValidator,
+		groupParameters:     groupParameters,
+		waitForBlockFn:      waitForBlockFn,
+	}
+}
+
+// claimInactivity executes the inactivity claim protocol starting at the
+// given block. Members who do not announce their readiness at the beginning
+// of the protocol are considered inactive. The remaining members sign the
+// claim accusing inactive members and exchange their signatures. If at least
+// the honest threshold of members supports the claim, the claim is submitted
+// to the chain. Members who do not support the claim yet still announce their
+// readiness using announceActivity so, members are claimed inactive only if
+// they did not announce and at least the honest threshold of members agreed
+// on the claim. The heartbeatFailed parameter indicates whether the claim is
+// raised due to a wallet-wide heartbeat failure. This function returns nil
+// without submitting anything if all members turned out to be active.
+func (ice *inactivityClaimExecutor) claimInactivity(
+	ctx context.Context,
+	heartbeatFailed bool,
+	startBlock uint64,
+) error {
+	wallet := ice.wallet()
+
+	walletPublicKeyBytes, err := marshalPublicKey(wallet.publicKey)
+	if err != nil {
+		return fmt.Errorf("cannot marshal wallet public key: [%v]", err)
+	}
+
+	announcementEndBlock := startBlock + inactivityClaimAnnouncementActiveBlocks
+	exchangeEndBlock := announcementEndBlock +
+		inactivityClaimSignaturesExchangeBlocks
+
+	claimLogger := logger.With(
+		zap.String("wallet", fmt.Sprintf("0x%x", walletPublicKeyBytes)),
+		zap.Uint64("claimStartBlock", startBlock),
+		zap.Bool("heartbeatFailed", heartbeatFailed),
+	)
+
+	err = ice.waitForBlockFn(ctx, startBlock)
+	if err != nil {
+		return fmt.Errorf(
+			"failed to wait for the inactivity claim start block: [%v]",
+			err,
+		)
+	}
+
+	readyMembersIndexes, err := ice.announceReadiness(
+		ctx,
+		startBlock,
+		announcementEndBlock,
+	)
+	if err != nil {
+		return fmt.Errorf("cannot announce readiness: [%v]", err)
+	}
+
+	inactiveMembersIndexes := make([]group.MemberIndex, 0)
+	for i := 1; i <= wallet.groupSize(); i++ {
+		memberIndex := group.MemberIndex(i)
+		if !slices.Contains(readyMembersIndexes, memberIndex) {
+			inactiveMembersIndexes = append(inactiveMembersIndexes, memberIndex)
+		}
+	}
+
+	if len(inactiveMembersIndexes) == 0 {
+		claimLogger.Infof(
+			"all signing group members are active; " +
+				"there is no inactivity to claim",
+		)
+		return nil
+	}
+
+	if len(readyMembersIndexes) < ice.groupParameters.HonestThreshold {
+		return fmt.Errorf(
+			"only [%v] members are ready while at least [%v] members "+
+				"must support the inactivity claim",
+			len(readyMembersIndexes),
+			ice.groupParameters.HonestThreshold,
+		)
+	}
+
+	claimLogger.Infof(
+		"claiming inactivity of members [%v]",
+		inactiveMembersIndexes,
+	)
+
+	walletChainData, err := ice.chain.GetWallet(
+		bitcoin.PublicKeyHash(wallet.publicKey),
+	)
+	if err != nil {
+		return fmt.Errorf("cannot get wallet's chain data: [%v]", err)
+	}
+
+	nonce, err := ice.chain.GetInactivityClaimNonce(
+		walletChainData.EcdsaWalletID,
+	)
+	if err != nil {
+		return fmt.Errorf("cannot get inactivity claim nonce: [%v]", err)
+	}
+
+	claimHash, err := ice.chain.CalculateInactivityClaimHash(
+		wallet.publicKey,
+		nonce,
+		inactiveMembersIndexes,
+		heartbeatFailed,
+	)
+	if err != nil {
+		return fmt.Errorf("cannot calculate inactivity claim hash: [%v]", err)
+	}
+
+	signature, err := ice.chain.Signing().Sign(claimHash[:])
+	if err != nil {
+		return fmt.Errorf("cannot sign inactivity claim hash: [%v]", err)
+	}
+
+	signatures, err := ice.exchangeSignatures(
+		ctx,
+		claimHash,
+		signature,
+		readyMembersIndexes,
+		exchangeEndBlock,
+	)
+	if err != nil {
+		return fmt.Errorf("cannot exchange signatures: [%v]", err)
+	}
+
+	if len(signatures) < ice.groupParameters.HonestThreshold {
+		return fmt.Errorf(
+			"inactivity claim is supported by [%v] members while at least "+
+				"[%v] supporting members are required",
+			len(signatures),
+			ice.groupParameters.HonestThreshold,
+		)
+	}
+
+	claim := &InactivityChainClaim{
+		WalletID:               walletChainData.EcdsaWalletID,
+		InactiveMembersIndexes: inactiveMembersIndexes,
+		HeartbeatFailed:        heartbeatFailed,
+		Signatures:             signatures,
+	}
+
+	return ice.submitClaim(ctx, claimLogger, claim, nonce, exchangeEndBlock)
+}
+
+// announceActivity announces the readiness of all signers controlled by the
+// executor at the beginning of the inactivity claim started at the given
+// block, without supporting the claim. Signing group members may observe
+// different numbers of consecutive heartbeat failures, e.g. after a node
+// restart. Members that do not claim inactivity yet announce their readiness
+// this way so, members that do claim it do not consider them inactive.
+func (ice *inactivityClaimExecutor) announceActivity(
+	ctx context.Context,
+	startBlock uint64,
+) error {
+	err := ice.waitForBlockFn(ctx, startBlock)
+	if err != nil {
+		return fmt.Errorf(
+			"failed to wait for the inactivity claim start block: [%v]",
+			err,
+		)
+	}
+
+	_, err = ice.announceReadiness(
+		ctx,
+		startBlock,
+		startBlock+inactivityClaimAnnouncementActiveBlocks,
+	)
+	if err != nil {
+		return fmt.Errorf("cannot announce readiness: [%v]", err)
+	}
+
+	return nil
+}
+
+// announceReadiness announces the readiness of all signers controlled by
+// the executor to take part in the inactivity claim started at the given
+// block. Announcements are exchanged until the given end block. The returned
+// slice holds indexes of all members who announced their readiness, sorted
+// in ascending order.
+func (ice *inactivityClaimExecutor) announceReadiness(
+	ctx context.Context,
+	startBlock uint64,
+	announcementEndBlock uint64,
+) ([]group.MemberIndex, error) {
+	announcementCtx, cancelAnnouncementCtx := withCancelOnBlock(
+		ctx,
+		announcementEndBlock,
+		ice.waitForBlockFn,
+	)
+	defer cancelAnnouncementCtx()
+
+	claimAnnouncer := announcer.New(
+		fmt.Sprintf("%v-%v", ProtocolName, "inactivity"),
+		ice.broadcastChannel,
+		ice.membershipValidator,
+	)
+
+	sessionID := fmt.Sprintf("%v", startBlock)
+
+	wg := sync.WaitGroup{}
+	wg.Add(len(ice.signers))
+
+	readyMembersIndexesSet := make(map[group.MemberIndex]bool)
+	readyMembersIndexesMutex := sync.Mutex{}
+	errorsChan := make(chan error, len(ice.signers))
+
+	for _, currentSigner := range ice.signers {
+		go func(signer *signer) {
+			defer wg.Done()
+
+			readyMembersIndexes, err := claimAnnouncer.Announce(
+				announcementCtx,
+				signer.signingGroupMemberIndex,
+				sessionID,
+			)
+			if err != nil {
+				errorsChan <- err
+				return
+			}
+
+			readyMembersIndexesMutex.Lock()
+			for _, memberIndex := range readyMembersIndexes {
+				readyMembersIndexesSet[memberIndex] = true
+			}
+			readyMembersIndexesMutex.Unlock()
+		}(currentSigner)
+	}
+
+	wg.Wait()
+
+	select {
+	case err := <-errorsChan:
+		return nil, err
+	default:
+	}
+
+	// The announcement context is done once the announcement end block is
+	// reached. If the parent context is done, the announcement was cut
+	// short and its outcome cannot be trusted.
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	readyMembersIndexes := make([]group.MemberIndex, 0)
+	for memberIndex := range readyMembersIndexesSet {
+		readyMembersIndexes = append(readyMembersIndexes, memberIndex)
+	}
+
+	sort.Slice(readyMembersIndexes, func(i, j int) bool {
+		return readyMembersIndexes[i] < readyMembersIndexes[j]
+	})
+
+	return readyMembersIndexes, nil
+}
+
+// exchangeSignatures broadcasts the given signature of the given claim hash
+// on behalf of all signers controlled by the executor and collects
+// signatures of other ready members until the given end block. Only valid
+// signatures of the same claim hash are collected. The returned map holds
+// signatures keyed by indexes of members who produced them, including
+// members controlled by the executor.
+func (ice *inactivityClaimExecutor) exchangeSignatures(
+	ctx context.Context,
+	claimHash InactivityClaimHash,
+	signature []byte,
+	readyMembersIndexes []group.MemberIndex,
+	exchangeEndBlock uint64,
+) (map[group.MemberIndex][]byte, error) {
+	exchangeCtx, cancelExchangeCtx := withCancelOnBlock(
+		ctx,
+		exchangeEndBlock,
+		ice.waitForBlockFn,
+	)
+	defer cancelExchangeCtx()
+
+	messagesChan := make(chan net.Message, inactivityClaimReceiveBuffer)
+	ice.broadcastChannel.Recv(exchangeCtx, func(message net.Message) {
+		messagesChan <- message
+	})
+
+	signatures := make(map[group.MemberIndex][]byte)
+
+	for _, signer := range ice.signers {
+		signatures[signer.signingGroupMemberIndex] = signature
+
+		err := ice.broadcastChannel.Send(
+			exchangeCtx,
+			&inactivityClaimSignatureMessage{
+				senderID:  signer.signingGroupMemberIndex,
+				claimHash: claimHash,
+				signature: signature,
+			},
+			net.BackoffRetransmissionStrategy,
+		)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"cannot send signature message: [%v]",
+				err,
+			)
+		}
+	}
+
+	for {
+		select {
+		case netMessage := <-messagesChan:
+			signatureMessage, ok := netMessage.Payload().(*inactivityClaimSignatureMessage)
+			if !ok {
+				continue
+			}
+
+			if _, exists := signatures[signatureMessage.senderID]; exists {
+				continue
+			}
+
+			if !ice.isValidSignatureMessage(
+				signatureMessage,
+				netMessage.SenderPublicKey(),
+				claimHash,
+				readyMembersIndexes,
+			) {
+				continue
+			}
+
+			signatures[signatureMessage.senderID] = signatureMessage.signature
+
+		case <-exchangeCtx.Done():
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+
+			return signatures, nil
+		}
+	}
+}
+
+// isValidSignatureMessage validates the given inactivityClaimSignatureMessage
+// in the context of the given claim hash.
+func (ice *inactivityClaimExecutor) isValidSignatureMessage(
+	signatureMessage *inactivityClaimSignatureMessage,
+	senderPublicKey []byte,
+	claimHash InactivityClaimHash,
+	readyMembersIndexes []group.MemberIndex,
+) bool {
+	if !ice.membershipValidator.IsValidMembership(
+		signatureMessage.senderID,
+		senderPublicKey,
+	) {
+		return false
+	}
+
+	if !slices.Contains(readyMembersIndexes, signatureMessage.senderID) {
+		return false
+	}
+
+	if signatureMessage.claimHash != claimHash {
+		return false
+	}
+
+	isValid, err := ice.chain.Signing().VerifyWithPublicKey(
+		claimHash[:],
+		signatureMessage.signature,
+		senderPublicKey,
+	)
+	if err != nil || !isValid {
+		return false
+	}
+
+	return true
+}
+
+// submitClaim submits the given inactivity claim signed with the given nonce.
+// Members supporting the claim submit it one after another, with a delay
+// determined by their position among supporting members, counted from the
+// given block. Before submitting, the member checks whether the claim nonce
+// is still the same. If it is not, another member already submitted the
+// claim and this function returns nil.
+func (ice *inactivityClaimExecutor) submitClaim(
+	ctx context.Context,
+	claimLogger log.StandardLogger,
+	claim *InactivityChainClaim,
+	nonce *big.Int,
+	submissionStartBlock uint64,
+) error {
+	signingMembersIndexes := make([]group.MemberIndex, 0)
+	for memberIndex := range claim.Signatures {
+		signingMembersIndexes = append(signingMembersIndexes, memberIndex)
+	}
+
+	sort.Slice(signingMembersIndexes, func(i, j int) bool {
+		return signingMembersIndexes[i] < signingMembersIndexes[j]
+	})
+
+	// All controlled signers support the claim so, it is enough to take
+	// the position of the first one.
+	submitterMemberIndex := ice.signers[0].signingGroupMemberIndex
+	for _, signer := range ice.signers {
+		if signer.signingGroupMemberIndex < submitterMemberIndex {
+			submitterMemberIndex = signer.signingGroupMemberIndex
+		}
+	}
+
+	submissionBlock := submissionStartBlock + uint64(slices.Index(
+		signingMembersIndexes,
+		submitterMemberIndex,
+	))*inactivityClaimSubmissionDelayStepBlocks
+
+	claimLogger.Infof(
+		"[member:%v] waiting for block [%v] to submit inactivity claim",
+		submitterMemberIndex,
+		submissionBlock,
+	)
+
+	err := ice.waitForBlockFn(ctx, submissionBlock)
+	if err != nil {
+		return fmt.Errorf(
+			"failed to wait for the inactivity claim submission block: [%v]",
+			err,
+		)
+	}
+
+	currentNonce, err := ice.chain.GetInactivityClaimNonce(claim.WalletID)
+	if err != nil {
+		return fmt.Errorf("cannot get inactivity claim nonce: [%v]", err)
+	}
+
+	if currentNonce.Cmp(nonce) != 0 {
+		claimLogger.Infof(
+			"[member:%v] inactivity claim already submitted by another member",
+			submitterMemberIndex,
+		)
+		return nil
+	}
+
+	wallet := ice.wallet()
+
+	groupMembers := make(chain.OperatorIDs, len(wallet.signingGroupOperators))
+	for i, operatorAddress := range wallet.signingGroupOperators {
+		operatorID, err := ice.chain.GetOperatorID(operatorAddress)
+		if err != nil {
+			return fmt.Errorf(
+				"cannot get ID of operator [%v]: [%v]",
+				operatorAddress,
+				err,
+			)
+		}
+
+		groupMembers[i] = operatorID
+	}
+
+	err = ice.chain.SubmitInactivityClaim(claim, nonce, groupMembers)
+	if err != nil {
+		return fmt.Errorf("cannot submit inactivity claim: [%v]", err)
+	}
+
+	claimLogger.Infof(
+		"[member:%v] inactivity claim submitted",
+		submitterMemberIndex,
+	)
+
+	return nil
+}
+
+func (ice *inactivityClaimExecutor) wallet() wallet {
+	// All signers belong to one wallet. Take that wallet from the
+	// first signer.
+	return ice.signers[0].wallet
+}
@@ -0,0 +1,344 @@
+package tbtc
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/keep-network/keep-core/internal/testutils"
+	"github.com/keep-network/keep-core/pkg/bitcoin"
+	"github.com/keep-network/keep-core/pkg/chain"
+	"github.com/keep-network/keep-core/pkg/chain/local_v1"
+	"github.com/keep-network/keep-core/pkg/generator"
+	"github.com/keep-network/keep-core/pkg/internal/tecdsatest"
+	"github.com/keep-network/keep-core/pkg/net/local"
+	"github.com/keep-network/keep-core/pkg/operator"
+	"github.com/keep-network/keep-core/pkg/protocol/group"
+	"github.com/keep-network/keep-core/pkg/tecdsa"
+)
+
+func TestInactivityClaimExecutor_ClaimInactivity(t *testing.T) {
+	// The node controls members 1-4 of the 5-member signing group. Member 5
+	// does not announce its readiness and should be claimed inactive.
+	executor, localChain := setupInactivityClaimExecutor(t, 4)
+
+	walletPublicKeyHash := bitcoin.PublicKeyHash(executor.wallet().publicKey)
+	walletID := [32]byte{1, 2, 3}
+	localChain.setWallet(walletPublicKeyHash, &WalletChainData{
+		EcdsaWalletID: walletID,
+	})
+
+	ctx, cancelCtx := context.WithTimeout(context.Background(), time.Minute)
+	defer cancelCtx()
+
+	err := executor.claimInactivity(ctx, true, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertIntsEqual(
+		t,
+		"submitted claims count",
+		1,
+		len(localChain.inactivityClaims),
+	)
+
+	claim := localChain.inactivityClaims[0]
+
+	testutils.AssertBytesEqual(t, walletID[:], claim.WalletID[:])
+	testutils.AssertBoolsEqual(
+		t,
+		"heartbeat failed flag",
+		true,
+		claim.HeartbeatFailed,
+	)
+	testutils.AssertIntsEqual(
+		t,
+		"inactive members count",
+		1,
+		len(claim.InactiveMembersIndexes),
+	)
+	testutils.AssertIntsEqual(
+		t,
+		"inactive member index",
+		5,
+		int(claim.InactiveMembersIndexes[0]),
+	)
+	testutils.AssertIntsEqual(
+		t,
+		"signatures count",
+		4,
+		len(claim.Signatures),
+	)
+
+	groupMembers := localChain.inactivityClaimMembers[0]
+	testutils.AssertIntsEqual(t, "group members count", 5, len(groupMembers))
+	for _, operatorID := range groupMembers {
+		testutils.AssertUintsEqual(
+			t,
+			"operator ID",
+			uint64(localChainOperatorID),
+			uint64(operatorID),
+		)
+	}
+
+	nonce, err := localChain.GetInactivityClaimNonce(walletID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertBigIntsEqual(
+		t,
+		"inactivity claim nonce",
+		big.NewInt(1),
+		nonce,
+	)
+}
+
+func TestInactivityClaimExecutor_ClaimInactivity_AllMembersActive(t *testing.T) {
+	executor, localChain := setupInactivityClaimExecutor(t, 5)
+
+	ctx, cancelCtx := context.WithTimeout(context.Background(), time.Minute)
+	defer cancelCtx()
+
+	err := executor.claimInactivity(ctx, true, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertIntsEqual(
+		t,
+		"submitted claims count",
+		0,
+		len(localChain.inactivityClaims),
+	)
+}
+
+func TestInactivityClaimExecutor_ClaimInactivity_MembersAnnouncingActivity(t *testing.T) {
+	// Two nodes take part in the announcement phase so, use longer blocks
+	// to give both of them enough time to exchange their announcements.
+	blockTime := 500 * time.Millisecond
+
+	localChain := Connect(blockTime)
+
+	claimingOperatorAddress, err := localChain.operatorAddress()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The announcing node runs on behalf of another operator so it needs its
+	// own chain handle. Both chains produce blocks at the same pace.
+	announcingOperatorPrivateKey, _, err := operator.GenerateKeyPair(
+		local_v1.DefaultCurve,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	announcingChain := ConnectWithKey(
+		announcingOperatorPrivateKey,
+		blockTime,
+	)
+	announcingOperatorAddress, err := announcingChain.operatorAddress()
+	if err != nil {
+		t.Fatal(err)
+	}
+	localChain.setOperatorID(announcingOperatorAddress, chain.OperatorID(2))
+
+	operators := []chain.Address{
+		claimingOperatorAddress,
+		claimingOperatorAddress,
+		claimingOperatorAddress,
+		announcingOperatorAddress,
+		announcingOperatorAddress,
+	}
+
+	// The first node controls members 1-3 and claims inactivity. The second
+	// node controls member 4 that has not reached the heartbeat failures
+	// threshold yet and only announces its activity. Member 5 does not
+	// announce its readiness and should be the only member claimed inactive.
+	claimingExecutor := setupInactivityClaimExecutorOnChain(
+		t,
+		localChain,
+		operators,
+		1, 2, 3,
+	)
+	announcingExecutor := setupInactivityClaimExecutorOnChain(
+		t,
+		announcingChain,
+		operators,
+		4,
+	)
+
+	walletPublicKeyHash := bitcoin.PublicKeyHash(
+		claimingExecutor.wallet().publicKey,
+	)
+	localChain.setWallet(walletPublicKeyHash, &WalletChainData{
+		EcdsaWalletID: [32]byte{1, 2, 3},
+	})
+
+	ctx, cancelCtx := context.WithTimeout(context.Background(), time.Minute)
+	defer cancelCtx()
+
+	// Setting up the nodes takes time so, start the claim a few blocks
+	// ahead to let both nodes take part in the announcement phase.
+	blockCounter, err := localChain.BlockCounter()
+	if err != nil {
+		t.Fatal(err)
+	}
+	currentBlock, err := blockCounter.CurrentBlock()
+	if err != nil {
+		t.Fatal(err)
+	}
+	startBlock := currentBlock + 2
+
+	announceErrChan := make(chan error, 1)
+	go func() {
+		announceErrChan <- announcingExecutor.announceActivity(ctx, startBlock)
+	}()
+
+	err = claimingExecutor.claimInactivity(ctx, true, startBlock)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = <-announceErrChan
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertIntsEqual(
+		t,
+		"submitted claims count",
+		1,
+		len(localChain.inactivityClaims),
+	)
+
+	claim := localChain.inactivityClaims[0]
+
+	testutils.AssertIntsEqual(
+		t,
+		"inactive members count",
+		1,
+		len(claim.InactiveMembersIndexes),
+	)
+	testutils.AssertIntsEqual(
+		t,
+		"inactive member index",
+		5,
+		int(claim.InactiveMembersIndexes[0]),
+	)
+	testutils.AssertIntsEqual(
+		t,
+		"signatures count",
+		3,
+		len(claim.Signatures),
+	)
+}
+
+// setupInactivityClaimExecutor sets up the inactivity claim executor of
+// a node controlling the given number of members of a 5-member signing group.
+func setupInactivityClaimExecutor(
+	t *testing.T,
+	controlledMembersCount int,
+) (*inactivityClaimExecutor, *localChain) {
+	localChain := Connect(100 * time.Millisecond)
+
+	controlledMembersIndexes := make([]group.MemberIndex, controlledMembersCount)
+	for i := range controlledMembersIndexes {
+		controlledMembersIndexes[i] = group.MemberIndex(i + 1)
+	}
+
+	operatorAddress, err := localChain.operatorAddress()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	operators := make([]chain.Address, 5)
+	for i := range operators {
+		operators[i] = operatorAddress
+	}
+
+	executor := setupInactivityClaimExecutorOnChain(
+		t,
+		localChain,
+		operators,
+		controlledMembersIndexes...,
+	)
+
+	return executor, localChain
+}
+
+// setupInactivityClaimExecutorOnChain sets up the inactivity claim executor
+// of a node controlling the given members of a 5-member signing group. The
+// node runs on behalf of the operator of the given local chain while the
+// signing group members belong to the given operators.
+func setupInactivityClaimExecutorOnChain(
+	t *testing.T,
+	localChain *localChain,
+	operators []chain.Address,
+	controlledMembersIndexes ...group.MemberIndex,
+) *inactivityClaimExecutor {
+	groupParameters := &GroupParameters{
+		GroupSize:       5,
+		GroupQuorum:     4,
+		HonestThreshold: 3,
+	}
+
+	_, operatorPublicKey, err := localChain.OperatorKeyPair()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	localProvider := local.ConnectWithKey(operatorPublicKey)
+
+	testData, err := tecdsatest.LoadPrivateKeyShareTestFixtures(
+		groupParameters.GroupSize,
+	)
+	if err != nil {
+		t.Fatalf("failed to load test data: [%v]", err)
+	}
+
+	signers := make([]*signer, len(controlledMembersIndexes))
+	for i, memberIndex := range controlledMembersIndexes {
+		privateKeyShare := tecdsa.NewPrivateKeyShare(testData[memberIndex-1])
+
+		signers[i] = &signer{
+			wallet: wallet{
+				publicKey:             privateKeyShare.PublicKey(),
+				signingGroupOperators: operators,
+			},
+			signingGroupMemberIndex: memberIndex,
+			privateKeyShare:         privateKeyShare,
+		}
+	}
+
+	keyStorePersistence := createMockKeyStorePersistence(t, signers...)
+
+	node, err := newNode(
+		groupParameters,
+		localChain,
+		newLocalBitcoinChain(),
+		localProvider,
+		keyStorePersistence,
+		&mockPersistenceHandle{},
+		generator.StartScheduler(),
+		&mockCoordinationProposalGenerator{},
+		Config{},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	executor, ok, err := node.getInactivityClaimExecutor(
+		signers[0].wallet.publicKey,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ok {
+		t.Fatal("node is supposed to control wallet signers")
+	}
+
+	return executor
+}
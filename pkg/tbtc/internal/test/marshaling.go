@@ -264,6 +264,219 @@ func (rts *RedemptionTestScenario) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// UnmarshalJSON implements a custom JSON unmarshaling logic to produce a
+// proper MovingFundsTestScenario.
+func (mfts *MovingFundsTestScenario) UnmarshalJSON(data []byte) error {
+	type movingFundsTestScenario struct {
+		Title                                     string
+		WalletPublicKey                           string
+		WalletPrivateKey                          string
+		WalletMainUtxo                            *utxo
+		TargetWallets                             []string
+		InputTransaction                          string
+		Fee                                       int64
+		Signature                                 signature
+		ExpectedSigHash                           string
+		ExpectedMovingFundsTransaction            string
+		ExpectedMovingFundsTransactionHash        string
+		ExpectedMovingFundsTransactionWitnessHash string
+	}
+
+	var unmarshaled movingFundsTestScenario
+
+	err := json.Unmarshal(data, &unmarshaled)
+	if err != nil {
+		return err
+	}
+
+	// Unmarshal title.
+	mfts.Title = unmarshaled.Title
+
+	// Unmarshal wallet public key.
+	x, y := elliptic.Unmarshal(
+		tecdsa.Curve,
+		hexToSlice(unmarshaled.WalletPublicKey),
+	)
+	mfts.WalletPublicKey = &ecdsa.PublicKey{
+		Curve: tecdsa.Curve,
+		X:     x,
+		Y:     y,
+	}
+
+	// Unmarshal wallet private key.
+	mfts.WalletPrivateKey = new(big.Int).SetBytes(
+		hexToSlice(unmarshaled.WalletPrivateKey),
+	)
+
+	// Unmarshal wallet main UTXO.
+	mfts.WalletMainUtxo = unmarshaled.WalletMainUtxo.convert()
+
+	// Unmarshal target wallets.
+	for _, targetWallet := range unmarshaled.TargetWallets {
+		var targetWalletPublicKeyHash [20]byte
+		copy(targetWalletPublicKeyHash[:], hexToSlice(targetWallet))
+
+		mfts.TargetWallets = append(mfts.TargetWallets, targetWalletPublicKeyHash)
+	}
+
+	// Unmarshal input transaction.
+	mfts.InputTransaction = new(bitcoin.Transaction)
+	err = mfts.InputTransaction.Deserialize(hexToSlice(unmarshaled.InputTransaction))
+	if err != nil {
+		return err
+	}
+
+	// Unmarshal fee.
+	mfts.Fee = unmarshaled.Fee
+
+	// Unmarshal signature.
+	mfts.Signature = unmarshaled.Signature.convert(mfts.WalletPublicKey)
+
+	// Unmarshal expected signature hash.
+	mfts.ExpectedSigHash = new(big.Int).SetBytes(hexToSlice(unmarshaled.ExpectedSigHash))
+
+	// Unmarshal expected moving funds transaction.
+	mfts.ExpectedMovingFundsTransaction = new(bitcoin.Transaction)
+	err = mfts.ExpectedMovingFundsTransaction.Deserialize(
+		hexToSlice(unmarshaled.ExpectedMovingFundsTransaction),
+	)
+	if err != nil {
+		return err
+	}
+
+	// Unmarshal expected moving funds transaction hash.
+	mfts.ExpectedMovingFundsTransactionHash, err = bitcoin.NewHashFromString(
+		unmarshaled.ExpectedMovingFundsTransactionHash,
+		bitcoin.ReversedByteOrder,
+	)
+	if err != nil {
+		return err
+	}
+
+	// Unmarshal expected moving funds transaction witness hash.
+	mfts.ExpectedMovingFundsTransactionWitnessHash, err = bitcoin.NewHashFromString(
+		unmarshaled.ExpectedMovingFundsTransactionWitnessHash,
+		bitcoin.ReversedByteOrder,
+	)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// UnmarshalJSON implements a custom JSON unmarshaling logic to produce a
+// proper MovedFundsSweepTestScenario.
+func (mfsts *MovedFundsSweepTestScenario) UnmarshalJSON(data []byte) error {
+	type movedFundsSweepTestScenario struct {
+		Title                               string
+		WalletPublicKey                     string
+		WalletPrivateKey                    string
+		WalletMainUtxo                      *utxo
+		MovedFundsUtxo                      *utxo
+		InputTransactions                   []string
+		Fee                                 int64
+		Signatures                          []signature
+		ExpectedSigHashes                   []string
+		ExpectedSweepTransaction            string
+		ExpectedSweepTransactionHash        string
+		ExpectedSweepTransactionWitnessHash string
+	}
+
+	var unmarshaled movedFundsSweepTestScenario
+
+	err := json.Unmarshal(data, &unmarshaled)
+	if err != nil {
+		return err
+	}
+
+	// Unmarshal title.
+	mfsts.Title = unmarshaled.Title
+
+	// Unmarshal wallet public key.
+	x, y := elliptic.Unmarshal(
+		tecdsa.Curve,
+		hexToSlice(unmarshaled.WalletPublicKey),
+	)
+	mfsts.WalletPublicKey = &ecdsa.PublicKey{
+		Curve: tecdsa.Curve,
+		X:     x,
+		Y:     y,
+	}
+
+	// Unmarshal wallet private key.
+	mfsts.WalletPrivateKey = new(big.Int).SetBytes(
+		hexToSlice(unmarshaled.WalletPrivateKey),
+	)
+
+	// Unmarshal optional wallet main UTXO.
+	if walletMainUtxo := unmarshaled.WalletMainUtxo; walletMainUtxo != nil {
+		mfsts.WalletMainUtxo = walletMainUtxo.convert()
+	}
+
+	// Unmarshal moved funds UTXO.
+	mfsts.MovedFundsUtxo = unmarshaled.MovedFundsUtxo.convert()
+
+	// Unmarshal input transactions.
+	for _, inputTransaction := range unmarshaled.InputTransactions {
+		transaction := new(bitcoin.Transaction)
+		err = transaction.Deserialize(hexToSlice(inputTransaction))
+		if err != nil {
+			return err
+		}
+
+		mfsts.InputTransactions = append(mfsts.InputTransactions, transaction)
+	}
+
+	// Unmarshal fee.
+	mfsts.Fee = unmarshaled.Fee
+
+	// Unmarshal signatures.
+	for _, s := range unmarshaled.Signatures {
+		mfsts.Signatures = append(
+			mfsts.Signatures,
+			s.convert(mfsts.WalletPublicKey),
+		)
+	}
+
+	// Unmarshal expected signature hashes.
+	for _, expectedSigHash := range unmarshaled.ExpectedSigHashes {
+		mfsts.ExpectedSigHashes = append(
+			mfsts.ExpectedSigHashes,
+			new(big.Int).SetBytes(hexToSlice(expectedSigHash)),
+		)
+	}
+
+	// Unmarshal expected sweep transaction.
+	mfsts.ExpectedSweepTransaction = new(bitcoin.Transaction)
+	err = mfsts.ExpectedSweepTransaction.Deserialize(
+		hexToSlice(unmarshaled.ExpectedSweepTransaction),
+	)
+	if err != nil {
+		return err
+	}
+
+	// Unmarshal expected sweep transaction hash.
+	mfsts.ExpectedSweepTransactionHash, err = bitcoin.NewHashFromString(
+		unmarshaled.ExpectedSweepTransactionHash,
+		bitcoin.ReversedByteOrder,
+	)
+	if err != nil {
+		return err
+	}
+
+	// Unmarshal expected sweep transaction witness hash.
+	mfsts.ExpectedSweepTransactionWitnessHash, err = bitcoin.NewHashFromString(
+		unmarshaled.ExpectedSweepTransactionWitnessHash,
+		bitcoin.ReversedByteOrder,
+	)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
 // utxo is a helper type used for unmarshal UTXO encoded as JSON.
 type utxo struct {
 	Outpoint struct {
@@ -51,6 +51,23 @@
 //     single P2WPKH input to pay redeemer scripts (P2PKH, P2WPKH) without a change.
 //     For reference see:
 //     https://live.blockcypher.com/btc-testnet/tx/afcdf8f91273b73abc40018873978c22bbb7c3d8d669ef2faffa0c4b0898c8eb
+//
+//   - moving_funds_scenario_0.json: Bitcoin moving funds transaction that uses
+//     a single P2WPKH input to move all funds to a single target wallet's
+//     P2WPKH output.
+//
+//   - moving_funds_scenario_1.json: Bitcoin moving funds transaction that uses
+//     a single P2WPKH input to move all funds to three target wallets' P2WPKH
+//     outputs. The funds cannot be split evenly so the last output holds
+//     the remainder.
+//
+//   - moved_funds_sweep_scenario_0.json: Bitcoin moved funds sweep transaction
+//     that uses a single P2WPKH moved funds input and produces a single P2WPKH
+//     output. The sweeping wallet has no main UTXO.
+//
+//   - moved_funds_sweep_scenario_1.json: Bitcoin moved funds sweep transaction
+//     that uses a P2WPKH moved funds input and a P2WPKH wallet main UTXO input
+//     and produces a single P2WPKH output.
 package test
 
 import (
@@ -70,9 +87,11 @@ import (
 )
 
 const (
-	testDataDirFormat              = "%s/testdata"
-	depositSweepTestDataFilePrefix = "deposit_sweep_scenario"
-	redemptionTestDataFilePrefix   = "redemption_scenario"
+	testDataDirFormat                 = "%s/testdata"
+	depositSweepTestDataFilePrefix    = "deposit_sweep_scenario"
+	redemptionTestDataFilePrefix      = "redemption_scenario"
+	movingFundsTestDataFilePrefix     = "moving_funds_scenario"
+	movedFundsSweepTestDataFilePrefix = "moved_funds_sweep_scenario"
 )
 
 // Deposit holds the deposit data in the given test scenario.
@@ -140,6 +159,51 @@ func LoadRedemptionTestScenarios() ([]*RedemptionTestScenario, error) {
 	return loadTestScenarios[*RedemptionTestScenario](redemptionTestDataFilePrefix)
 }
 
+// MovingFundsTestScenario represents a moving funds test scenario.
+type MovingFundsTestScenario struct {
+	Title            string
+	WalletPublicKey  *ecdsa.PublicKey
+	WalletPrivateKey *big.Int
+	WalletMainUtxo   *bitcoin.UnspentTransactionOutput
+	TargetWallets    [][20]byte
+	InputTransaction *bitcoin.Transaction
+	Fee              int64
+	Signature        *bitcoin.SignatureContainer
+
+	ExpectedSigHash                           *big.Int
+	ExpectedMovingFundsTransaction            *bitcoin.Transaction
+	ExpectedMovingFundsTransactionHash        bitcoin.Hash
+	ExpectedMovingFundsTransactionWitnessHash bitcoin.Hash
+}
+
+// LoadMovingFundsTestScenarios loads all scenarios related with moving funds.
+func LoadMovingFundsTestScenarios() ([]*MovingFundsTestScenario, error) {
+	return loadTestScenarios[*MovingFundsTestScenario](movingFundsTestDataFilePrefix)
+}
+
+// MovedFundsSweepTestScenario represents a moved funds sweep test scenario.
+type MovedFundsSweepTestScenario struct {
+	Title             string
+	WalletPublicKey   *ecdsa.PublicKey
+	WalletPrivateKey  *big.Int
+	WalletMainUtxo    *bitcoin.UnspentTransactionOutput
+	MovedFundsUtxo    *bitcoin.UnspentTransactionOutput
+	InputTransactions []*bitcoin.Transaction
+	Fee               int64
+	Signatures        []*bitcoin.SignatureContainer
+
+	ExpectedSigHashes                   []*big.Int
+	ExpectedSweepTransaction            *bitcoin.Transaction
+	ExpectedSweepTransactionHash        bitcoin.Hash
+	ExpectedSweepTransactionWitnessHash bitcoin.Hash
+}
+
+// LoadMovedFundsSweepTestScenarios loads all scenarios related with moved
+// funds sweep.
+func LoadMovedFundsSweepTestScenarios() ([]*MovedFundsSweepTestScenario, error) {
+	return loadTestScenarios[*MovedFundsSweepTestScenario](movedFundsSweepTestDataFilePrefix)
+}
+
 func loadTestScenarios[T json.Unmarshaler](testDataFilePrefix string) ([]T, error) {
 	filePaths, err := detectTestDataFiles(testDataFilePrefix)
 	if err != nil {
@@ -7,6 +7,7 @@ import (
 	"github.com/keep-network/keep-core/pkg/bitcoin"
 	"math"
 	"math/big"
+	"time"
 
 	"google.golang.org/protobuf/proto"
 
@@ -86,6 +87,343 @@ func (s *signer) Unmarshal(bytes []byte) error {
 	return nil
 }
 
+// Marshal converts the coordinationFaultCounter to a byte array.
+func (cfc *coordinationFaultCounter) Marshal() ([]byte, error) {
+	return proto.Marshal(&pb.CoordinationFaultCounter{
+		Culprit:         cfc.culprit.String(),
+		FaultType:       uint32(cfc.faultType),
+		Count:           cfc.count,
+		LastWindowIndex: cfc.lastWindowIndex,
+		LastWalletPublicKeyHash: append(
+			[]byte{},
+			cfc.lastWalletPublicKeyHash[:]...,
+		),
+	})
+}
+
+// Unmarshal converts a byte array back to the coordinationFaultCounter.
+func (cfc *coordinationFaultCounter) Unmarshal(bytes []byte) error {
+	pbCounter := pb.CoordinationFaultCounter{}
+	if err := proto.Unmarshal(bytes, &pbCounter); err != nil {
+		return fmt.Errorf(
+			"cannot unmarshal coordination fault counter: [%v]",
+			err,
+		)
+	}
+
+	if pbCounter.FaultType > math.MaxUint8 {
+		return fmt.Errorf(
+			"invalid fault type value: [%v]",
+			pbCounter.FaultType,
+		)
+	}
+
+	lastWalletPublicKeyHash, err := unmarshalWalletPublicKeyHash(
+		pbCounter.LastWalletPublicKeyHash,
+	)
+	if err != nil {
+		return fmt.Errorf(
+			"cannot unmarshal last wallet public key hash: [%v]",
+			err,
+		)
+	}
+
+	cfc.culprit = chain.Address(pbCounter.Culprit)
+	cfc.faultType = CoordinationFaultType(pbCounter.FaultType)
+	cfc.count = pbCounter.Count
+	cfc.lastWindowIndex = pbCounter.LastWindowIndex
+	cfc.lastWalletPublicKeyHash = lastWalletPublicKeyHash
+
+	return nil
+}
+
+// Marshal converts the SigningAuditEntry to a byte array.
+func (sae *SigningAuditEntry) Marshal() ([]byte, error) {
+	signatureBytes, err := sae.Signature.Marshal()
+	if err != nil {
+		return nil, err
+	}
+
+	participatingMembersIndexes := make(
+		[]uint32,
+		len(sae.ParticipatingMembersIndexes),
+	)
+	for i, memberIndex := range sae.ParticipatingMembersIndexes {
+		participatingMembersIndexes[i] = uint32(memberIndex)
+	}
+
+	return proto.Marshal(&pb.SigningAuditEntry{
+		Sequence:                    sae.Sequence,
+		PreviousHash:                append([]byte{}, sae.PreviousHash[:]...),
+		Timestamp:                   sae.Timestamp.Unix(),
+		WalletPublicKeyHash:         append([]byte{}, sae.WalletPublicKeyHash[:]...),
+		Message:                     sae.Message.Bytes(),
+		ActionType:                  uint32(sae.ActionType),
+		TransactionHash:             sae.TransactionHash[:],
+		ParticipatingMembersIndexes: participatingMembersIndexes,
+		Signature:                   signatureBytes,
+	})
+}
+
+// Unmarshal converts a byte array back to the SigningAuditEntry.
+func (sae *SigningAuditEntry) Unmarshal(bytes []byte) error {
+	pbEntry := pb.SigningAuditEntry{}
+	if err := proto.Unmarshal(bytes, &pbEntry); err != nil {
+		return fmt.Errorf("cannot unmarshal signing audit entry: [%v]", err)
+	}
+
+	if len(pbEntry.PreviousHash) != len(sae.PreviousHash) {
+		return fmt.Errorf(
+			"invalid previous hash length: [%v]",
+			len(pbEntry.PreviousHash),
+		)
+	}
+
+	walletPublicKeyHash, err := unmarshalWalletPublicKeyHash(
+		pbEntry.WalletPublicKeyHash,
+	)
+	if err != nil {
+		return fmt.Errorf(
+			"cannot unmarshal wallet public key hash: [%v]",
+			err,
+		)
+	}
+
+	if pbEntry.ActionType > math.MaxUint8 {
+		return fmt.Errorf(
+			"invalid action type value: [%v]",
+			pbEntry.ActionType,
+		)
+	}
+
+	actionType, err := ParseWalletActionType(uint8(pbEntry.ActionType))
+	if err != nil {
+		return fmt.Errorf("cannot parse action type: [%v]", err)
+	}
+
+	transactionHash, err := bitcoin.NewHash(
+		pbEntry.TransactionHash,
+		bitcoin.InternalByteOrder,
+	)
+	if err != nil {
+		return fmt.Errorf("cannot unmarshal transaction hash: [%v]", err)
+	}
+
+	participatingMembersIndexes := make(
+		[]group.MemberIndex,
+		len(pbEntry.ParticipatingMembersIndexes),
+	)
+	for i, memberIndex := range pbEntry.ParticipatingMembersIndexes {
+		if err := validateMemberIndex(memberIndex); err != nil {
+			return err
+		}
+
+		participatingMembersIndexes[i] = group.MemberIndex(memberIndex)
+	}
+
+	signature := &tecdsa.Signature{}
+	if err := signature.Unmarshal(pbEntry.Signature); err != nil {
+		return fmt.Errorf("cannot unmarshal signature: [%v]", err)
+	}
+
+	sae.Sequence = pbEntry.Sequence
+	copy(sae.PreviousHash[:], pbEntry.PreviousHash)
+	sae.Timestamp = time.Unix(pbEntry.Timestamp, 0)
+	sae.WalletPublicKeyHash = walletPublicKeyHash
+	sae.Message = new(big.Int).SetBytes(pbEntry.Message)
+	sae.ActionType = actionType
+	sae.TransactionHash = transactionHash
+	sae.ParticipatingMembersIndexes = participatingMembersIndexes
+	sae.Signature = signature
+
+	return nil
+}
+
+// Marshal converts the QuarantinedDeposit to a byte array.
+func (qd *QuarantinedDeposit) Marshal() ([]byte, error) {
+	return proto.Marshal(&pb.QuarantinedDeposit{
+		WalletPublicKeyHash: append([]byte{}, qd.WalletPublicKeyHash[:]...),
+		FundingTxHash:       append([]byte{}, qd.FundingTxHash[:]...),
+		FundingOutputIndex:  qd.FundingOutputIndex,
+		RevealBlock:         qd.RevealBlock,
+		Reason:              uint32(qd.Reason),
+		QuarantinedAt:       qd.QuarantinedAt.Unix(),
+	})
+}
+
+// Unmarshal converts a byte array back to the QuarantinedDeposit.
+func (qd *QuarantinedDeposit) Unmarshal(bytes []byte) error {
+	pbDeposit := pb.QuarantinedDeposit{}
+	if err := proto.Unmarshal(bytes, &pbDeposit); err != nil {
+		return fmt.Errorf("cannot unmarshal quarantined deposit: [%v]", err)
+	}
+
+	walletPublicKeyHash, err := unmarshalWalletPublicKeyHash(
+		pbDeposit.WalletPublicKeyHash,
+	)
+	if err != nil {
+		return fmt.Errorf(
+			"cannot unmarshal wallet public key hash: [%v]",
+			err,
+		)
+	}
+
+	fundingTxHash, err := bitcoin.NewHash(
+		pbDeposit.FundingTxHash,
+		bitcoin.InternalByteOrder,
+	)
+	if err != nil {
+		return fmt.Errorf("cannot unmarshal funding tx hash: [%v]", err)
+	}
+
+	if pbDeposit.Reason > math.MaxUint8 {
+		return fmt.Errorf(
+			"invalid quarantine reason value: [%v]",
+			pbDeposit.Reason,
+		)
+	}
+
+	qd.WalletPublicKeyHash = walletPublicKeyHash
+	qd.FundingTxHash = fundingTxHash
+	qd.FundingOutputIndex = pbDeposit.FundingOutputIndex
+	qd.RevealBlock = pbDeposit.RevealBlock
+	qd.Reason = DepositQuarantineReason(pbDeposit.Reason)
+	qd.QuarantinedAt = time.Unix(pbDeposit.QuarantinedAt, 0)
+
+	return nil
+}
+
+// Marshal converts the pendingWalletTransaction to a byte array.
+func (pwt *pendingWalletTransaction) Marshal() ([]byte, error) {
+	walletPublicKey, err := marshalPublicKey(pwt.walletPublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("cannot marshal wallet public key: [%v]", err)
+	}
+
+	replacedTransactionsHashes := make(
+		[][]byte,
+		len(pwt.replacedTransactionsHashes),
+	)
+	for i, hash := range pwt.replacedTransactionsHashes {
+		replacedTransactionsHashes[i] = append([]byte{}, hash[:]...)
+	}
+
+	proposalPayload, err := pwt.proposal.Marshal()
+	if err != nil {
+		return nil, fmt.Errorf("cannot marshal proposal: [%v]", err)
+	}
+
+	pbTransaction := &pb.PendingWalletTransaction{
+		WalletPublicKey:            walletPublicKey,
+		Transaction:                pwt.Transaction.Serialize(bitcoin.Witness),
+		Fee:                        pwt.Fee,
+		SignedAtBlockHeight:        uint64(pwt.SignedAtBlockHeight),
+		ReplacedTransactionsHashes: replacedTransactionsHashes,
+		ProposalActionType:         uint32(pwt.proposal.actionType()),
+		ProposalPayload:            proposalPayload,
+	}
+
+	if pwt.walletMainUtxo != nil {
+		pbTransaction.WalletMainUtxoTransactionHash = append(
+			[]byte{},
+			pwt.walletMainUtxo.Outpoint.TransactionHash[:]...,
+		)
+		pbTransaction.WalletMainUtxoOutputIndex =
+			pwt.walletMainUtxo.Outpoint.OutputIndex
+		pbTransaction.WalletMainUtxoValue = pwt.walletMainUtxo.Value
+	}
+
+	return proto.Marshal(pbTransaction)
+}
+
+// Unmarshal converts a byte array back to the pendingWalletTransaction.
+func (pwt *pendingWalletTransaction) Unmarshal(bytes []byte) error {
+	pbTransaction := pb.PendingWalletTransaction{}
+	if err := proto.Unmarshal(bytes, &pbTransaction); err != nil {
+		return fmt.Errorf(
+			"cannot unmarshal pending wallet transaction: [%v]",
+			err,
+		)
+	}
+
+	walletPublicKey := unmarshalPublicKey(pbTransaction.WalletPublicKey)
+	if walletPublicKey.X == nil {
+		return fmt.Errorf("cannot unmarshal wallet public key")
+	}
+
+	transaction := &bitcoin.Transaction{}
+	if err := transaction.Deserialize(pbTransaction.Transaction); err != nil {
+		return fmt.Errorf("cannot deserialize transaction: [%v]", err)
+	}
+
+	replacedTransactionsHashes := make(
+		[]bitcoin.Hash,
+		len(pbTransaction.ReplacedTransactionsHashes),
+	)
+	for i, hashBytes := range pbTransaction.ReplacedTransactionsHashes {
+		hash, err := bitcoin.NewHash(hashBytes, bitcoin.InternalByteOrder)
+		if err != nil {
+			return fmt.Errorf(
+				"cannot unmarshal replaced transaction hash: [%v]",
+				err,
+			)
+		}
+		replacedTransactionsHashes[i] = hash
+	}
+
+	var walletMainUtxo *bitcoin.UnspentTransactionOutput
+	if len(pbTransaction.WalletMainUtxoTransactionHash) > 0 {
+		walletMainUtxoTransactionHash, err := bitcoin.NewHash(
+			pbTransaction.WalletMainUtxoTransactionHash,
+			bitcoin.InternalByteOrder,
+		)
+		if err != nil {
+			return fmt.Errorf(
+				"cannot unmarshal wallet main UTXO transaction hash: [%v]",
+				err,
+			)
+		}
+
+		walletMainUtxo = &bitcoin.UnspentTransactionOutput{
+			Outpoint: &bitcoin.TransactionOutpoint{
+				TransactionHash: walletMainUtxoTransactionHash,
+				OutputIndex:     pbTransaction.WalletMainUtxoOutputIndex,
+			},
+			Value: pbTransaction.WalletMainUtxoValue,
+		}
+	}
+
+	proposal, err := unmarshalCoordinationProposal(
+		pbTransaction.ProposalActionType,
+		pbTransaction.ProposalPayload,
+	)
+	if err != nil {
+		return fmt.Errorf("cannot unmarshal proposal: [%v]", err)
+	}
+
+	transactionProposal, ok := proposal.(walletTransactionProposal)
+	if !ok {
+		return fmt.Errorf(
+			"[%s] proposal does not submit a wallet transaction",
+			proposal.actionType(),
+		)
+	}
+	// The wallet public key hash of the proposal is not marshaled.
+	transactionProposal.setWalletPublicKeyHash(
+		bitcoin.PublicKeyHash(walletPublicKey),
+	)
+
+	pwt.Transaction = transaction
+	pwt.Fee = pbTransaction.Fee
+	pwt.SignedAtBlockHeight = uint(pbTransaction.SignedAtBlockHeight)
+	pwt.replacedTransactionsHashes = replacedTransactionsHashes
+	pwt.walletPublicKey = walletPublicKey
+	pwt.walletMainUtxo = walletMainUtxo
+	pwt.proposal = transactionProposal
+
+	return nil
+}
+
 // Marshal converts the signingDoneMessage to a byte array.
 func (sdm *signingDoneMessage) Marshal() ([]byte, error) {
 	signatureBytes, err := sdm.signature.Marshal()
@@ -127,6 +465,43 @@ func (sdm *signingDoneMessage) Unmarshal(bytes []byte) error {
 	return nil
 }
 
+// Marshal converts the inactivityClaimSignatureMessage to a byte array.
+func (icsm *inactivityClaimSignatureMessage) Marshal() ([]byte, error) {
+	return proto.Marshal(&pb.InactivityClaimSignatureMessage{
+		SenderID:  uint32(icsm.senderID),
+		ClaimHash: icsm.claimHash[:],
+		Signature: icsm.signature,
+	})
+}
+
+// Unmarshal converts a byte array back to the inactivityClaimSignatureMessage.
+func (icsm *inactivityClaimSignatureMessage) Unmarshal(bytes []byte) error {
+	pbMsg := pb.InactivityClaimSignatureMessage{}
+	if err := proto.Unmarshal(bytes, &pbMsg); err != nil {
+		return fmt.Errorf(
+			"failed to unmarshal InactivityClaimSignatureMessage: [%v]",
+			err,
+		)
+	}
+
+	if err := validateMemberIndex(pbMsg.SenderID); err != nil {
+		return err
+	}
+
+	if len(pbMsg.ClaimHash) != len(icsm.claimHash) {
+		return fmt.Errorf(
+			"invalid claim hash length: [%v]",
+			len(pbMsg.ClaimHash),
+		)
+	}
+
+	icsm.senderID = group.MemberIndex(pbMsg.SenderID)
+	copy(icsm.claimHash[:], pbMsg.ClaimHash)
+	icsm.signature = pbMsg.Signature
+
+	return nil
+}
+
 // Marshal converts the coordinationMessage to a byte array.
 func (cm *coordinationMessage) Marshal() ([]byte, error) {
 	proposalBytes, err := cm.proposal.Marshal()
@@ -206,7 +581,7 @@ func unmarshalWalletPublicKeyHash(bytes []byte) ([20]byte, error) {
 // unmarshalCoordinationProposal converts a byte array back to the coordination
 // proposal.
 func unmarshalCoordinationProposal(actionType uint32, payload []byte) (
-	coordinationProposal,
+	CoordinationProposal,
 	error,
 ) {
 	if actionType > math.MaxUint8 {
@@ -224,14 +599,14 @@ func unmarshalCoordinationProposal(actionType uint32, payload []byte) (
 		)
 	}
 
-	proposal, ok := map[WalletActionType]coordinationProposal{
-		ActionNoop:         &noopProposal{},
-		ActionHeartbeat:    &HeartbeatProposal{},
-		ActionDepositSweep: &DepositSweepProposal{},
-		ActionRedemption:   &RedemptionProposal{},
-		// TODO: Uncomment when moving funds support is implemented.
-		// ActionMovingFunds:     &MovingFundsProposal{},
-		// ActionMovedFundsSweep: &MovedFundsSweepProposal{},
+	proposal, ok := map[WalletActionType]CoordinationProposal{
+		ActionNoop:            &NoopProposal{},
+		ActionHeartbeat:       &HeartbeatProposal{},
+		ActionDepositSweep:    &DepositSweepProposal{},
+		ActionRedemption:      &RedemptionProposal{},
+		ActionMovingFunds:     &MovingFundsProposal{},
+		ActionMovedFundsSweep: &MovedFundsSweepProposal{},
+		ActionFeeBump:         &FeeBumpProposal{},
 	}[parsedActionType]
 	if !ok {
 		return nil, fmt.Errorf(
@@ -247,13 +622,13 @@ func unmarshalCoordinationProposal(actionType uint32, payload []byte) (
 	return proposal, nil
 }
 
-// Marshal converts the noopProposal to a byte array.
-func (np *noopProposal) Marshal() ([]byte, error) {
+// Marshal converts the NoopProposal to a byte array.
+func (np *NoopProposal) Marshal() ([]byte, error) {
 	return []byte{}, nil
 }
 
-// Unmarshal converts a byte array back to the noopProposal.
-func (np *noopProposal) Unmarshal([]byte) error {
+// Unmarshal converts a byte array back to the NoopProposal.
+func (np *NoopProposal) Unmarshal([]byte) error {
 	return nil
 }
 
@@ -385,6 +760,124 @@ func (rp *RedemptionProposal) Unmarshal(bytes []byte) error {
 	return nil
 }
 
+// Marshal converts the movingFundsProposal to a byte array.
+func (mfp *MovingFundsProposal) Marshal() ([]byte, error) {
+	targetWallets := make([][]byte, len(mfp.TargetWallets))
+	for i, targetWallet := range mfp.TargetWallets {
+		targetWallets[i] = append([]byte{}, targetWallet[:]...)
+	}
+
+	return proto.Marshal(
+		&pb.MovingFundsProposal{
+			TargetWallets:    targetWallets,
+			MovingFundsTxFee: mfp.MovingFundsTxFee.Bytes(),
+		},
+	)
+}
+
+// Unmarshal converts a byte array back to the movingFundsProposal.
+func (mfp *MovingFundsProposal) Unmarshal(bytes []byte) error {
+	pbMsg := pb.MovingFundsProposal{}
+	if err := proto.Unmarshal(bytes, &pbMsg); err != nil {
+		return fmt.Errorf("failed to unmarshal MovingFundsProposal: [%v]", err)
+	}
+
+	targetWallets := make([][20]byte, len(pbMsg.TargetWallets))
+	for i, targetWallet := range pbMsg.TargetWallets {
+		unmarshaled, err := unmarshalWalletPublicKeyHash(targetWallet)
+		if err != nil {
+			return fmt.Errorf(
+				"failed to unmarshal target wallet [%v]: [%v]",
+				i,
+				err,
+			)
+		}
+
+		targetWallets[i] = unmarshaled
+	}
+
+	mfp.TargetWallets = targetWallets
+	mfp.MovingFundsTxFee = new(big.Int).SetBytes(pbMsg.MovingFundsTxFee)
+
+	return nil
+}
+
+// Marshal converts the movedFundsSweepProposal to a byte array.
+func (mfsp *MovedFundsSweepProposal) Marshal() ([]byte, error) {
+	return proto.Marshal(
+		&pb.MovedFundsSweepProposal{
+			MovingFundsTxHash:        mfsp.MovingFundsTxHash[:],
+			MovingFundsTxOutputIndex: mfsp.MovingFundsTxOutputIndex,
+			SweepTxFee:               mfsp.SweepTxFee.Bytes(),
+		},
+	)
+}
+
+// Unmarshal converts a byte array back to the movedFundsSweepProposal.
+func (mfsp *MovedFundsSweepProposal) Unmarshal(bytes []byte) error {
+	pbMsg := pb.MovedFundsSweepProposal{}
+	if err := proto.Unmarshal(bytes, &pbMsg); err != nil {
+		return fmt.Errorf(
+			"failed to unmarshal MovedFundsSweepProposal: [%v]",
+			err,
+		)
+	}
+
+	movingFundsTxHash, err := bitcoin.NewHash(
+		pbMsg.MovingFundsTxHash,
+		bitcoin.InternalByteOrder,
+	)
+	if err != nil {
+		return fmt.Errorf(
+			"failed to unmarshal moving funds tx hash: [%v]",
+			err,
+		)
+	}
+
+	mfsp.MovingFundsTxHash = movingFundsTxHash
+	mfsp.MovingFundsTxOutputIndex = pbMsg.MovingFundsTxOutputIndex
+	mfsp.SweepTxFee = new(big.Int).SetBytes(pbMsg.SweepTxFee)
+
+	return nil
+}
+
+// Marshal converts the feeBumpProposal to a byte array.
+func (fbp *FeeBumpProposal) Marshal() ([]byte, error) {
+	return proto.Marshal(
+		&pb.FeeBumpProposal{
+			TransactionHash: fbp.TransactionHash[:],
+			TransactionFee:  fbp.TransactionFee.Bytes(),
+		},
+	)
+}
+
+// Unmarshal converts a byte array back to the feeBumpProposal.
+func (fbp *FeeBumpProposal) Unmarshal(bytes []byte) error {
+	pbMsg := pb.FeeBumpProposal{}
+	if err := proto.Unmarshal(bytes, &pbMsg); err != nil {
+		return fmt.Errorf(
+			"failed to unmarshal FeeBumpProposal: [%v]",
+			err,
+		)
+	}
+
+	transactionHash, err := bitcoin.NewHash(
+		pbMsg.TransactionHash,
+		bitcoin.InternalByteOrder,
+	)
+	if err != nil {
+		return fmt.Errorf(
+			"failed to unmarshal transaction hash: [%v]",
+			err,
+		)
+	}
+
+	fbp.TransactionHash = transactionHash
+	fbp.TransactionFee = new(big.Int).SetBytes(pbMsg.TransactionFee)
+
+	return nil
+}
+
 // marshalPublicKey converts an ECDSA public key to a byte
 // array (uncompressed).
 func marshalPublicKey(publicKey *ecdsa.PublicKey) ([]byte, error) {
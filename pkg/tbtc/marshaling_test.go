@@ -8,6 +8,7 @@ import (
 	"math/big"
 	"reflect"
 	"testing"
+	"time"
 
 	fuzz "github.com/google/gofuzz"
 
@@ -47,6 +48,158 @@ func TestSignerMarshalling_NonTECDSAKey(t *testing.T) {
 	testutils.AssertErrorsSame(t, errIncompatiblePublicKey, err)
 }
 
+func TestCoordinationFaultCounter_MarshalingRoundtrip(t *testing.T) {
+	walletPublicKeyHashBytes, err := hex.DecodeString(
+		"aa768412ceed10bd423c025542ca90071f9fb62d",
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var walletPublicKeyHash [20]byte
+	copy(walletPublicKeyHash[:], walletPublicKeyHashBytes)
+
+	counter := &coordinationFaultCounter{
+		culprit:                 "0xAA768412cEed10BD423c025542CA90071F9fb62d",
+		faultType:               FaultLeaderImpersonation,
+		count:                   12,
+		lastWindowIndex:         5,
+		lastWalletPublicKeyHash: walletPublicKeyHash,
+	}
+	unmarshaled := &coordinationFaultCounter{}
+
+	err = pbutils.RoundTrip(counter, unmarshaled)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(counter, unmarshaled) {
+		t.Fatalf("unexpected content of unmarshaled counter")
+	}
+}
+
+func TestFuzzCoordinationFaultCounter_Unmarshaler(t *testing.T) {
+	pbutils.FuzzUnmarshaler(&coordinationFaultCounter{})
+}
+
+func TestSigningAuditEntry_MarshalingRoundtrip(t *testing.T) {
+	entry := &SigningAuditEntry{
+		Sequence:            7,
+		PreviousHash:        [32]byte{1, 2, 3},
+		Timestamp:           time.Unix(1700000000, 0),
+		WalletPublicKeyHash: [20]byte{4, 5, 6},
+		Message:             big.NewInt(100),
+		ActionType:          ActionRedemption,
+		TransactionHash:     bitcoin.Hash{7, 8, 9},
+		ParticipatingMembersIndexes: []group.MemberIndex{
+			1, 3, 5,
+		},
+		Signature: &tecdsa.Signature{
+			R:          big.NewInt(200),
+			S:          big.NewInt(300),
+			RecoveryID: 1,
+		},
+	}
+	unmarshaled := &SigningAuditEntry{}
+
+	err := pbutils.RoundTrip(entry, unmarshaled)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(entry, unmarshaled) {
+		t.Fatalf("unexpected content of unmarshaled entry")
+	}
+}
+
+func TestFuzzSigningAuditEntry_Unmarshaler(t *testing.T) {
+	pbutils.FuzzUnmarshaler(&SigningAuditEntry{})
+}
+
+func TestQuarantinedDeposit_MarshalingRoundtrip(t *testing.T) {
+	deposit := &QuarantinedDeposit{
+		WalletPublicKeyHash: [20]byte{1, 2, 3},
+		FundingTxHash:       bitcoin.Hash{4, 5, 6},
+		FundingOutputIndex:  2,
+		RevealBlock:         1000,
+		Reason:              DepositFundingOutputMismatch,
+		QuarantinedAt:       time.Unix(1700000000, 0),
+	}
+	unmarshaled := &QuarantinedDeposit{}
+
+	err := pbutils.RoundTrip(deposit, unmarshaled)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(deposit, unmarshaled) {
+		t.Fatalf("unexpected content of unmarshaled deposit")
+	}
+}
+
+func TestFuzzQuarantinedDeposit_Unmarshaler(t *testing.T) {
+	pbutils.FuzzUnmarshaler(&QuarantinedDeposit{})
+}
+
+func TestPendingWalletTransaction_MarshalingRoundtrip(t *testing.T) {
+	walletPublicKey := generateWallet(big.NewInt(1)).publicKey
+
+	pending := &pendingWalletTransaction{
+		PendingWalletTransaction: PendingWalletTransaction{
+			Transaction: &bitcoin.Transaction{
+				Version: 1,
+				Inputs: []*bitcoin.TransactionInput{
+					{
+						Outpoint: &bitcoin.TransactionOutpoint{
+							TransactionHash: bitcoin.Hash{1, 2, 3},
+							OutputIndex:     1,
+						},
+						SignatureScript: []byte{},
+						Sequence:        0xfffffffd,
+					},
+				},
+				Outputs: []*bitcoin.TransactionOutput{
+					{
+						Value:           90000,
+						PublicKeyScript: []byte{0x00, 0x14, 0x01},
+					},
+				},
+			},
+			Fee:                 1500,
+			SignedAtBlockHeight: 800000,
+		},
+		replacedTransactionsHashes: []bitcoin.Hash{{4, 5, 6}},
+		walletPublicKey:            walletPublicKey,
+		walletMainUtxo: &bitcoin.UnspentTransactionOutput{
+			Outpoint: &bitcoin.TransactionOutpoint{
+				TransactionHash: bitcoin.Hash{7, 8, 9},
+				OutputIndex:     2,
+			},
+			Value: 10000,
+		},
+		proposal: &MovedFundsSweepProposal{
+			WalletPublicKeyHash:      bitcoin.PublicKeyHash(walletPublicKey),
+			MovingFundsTxHash:        bitcoin.Hash{1, 2, 3},
+			MovingFundsTxOutputIndex: 1,
+			SweepTxFee:               big.NewInt(1000),
+		},
+	}
+	unmarshaled := &pendingWalletTransaction{}
+
+	err := pbutils.RoundTrip(pending, unmarshaled)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(pending, unmarshaled) {
+		t.Fatalf("unexpected content of unmarshaled pending transaction")
+	}
+}
+
+func TestFuzzPendingWalletTransaction_Unmarshaler(t *testing.T) {
+	pbutils.FuzzUnmarshaler(&pendingWalletTransaction{})
+}
+
 func TestSigningDoneMessage_MarshalingRoundtrip(t *testing.T) {
 	msg := &signingDoneMessage{
 		senderID:      group.MemberIndex(10),
@@ -107,6 +260,57 @@ func TestFuzzSigningDoneMessage_Unmarshaler(t *testing.T) {
 	pbutils.FuzzUnmarshaler(&signingDoneMessage{})
 }
 
+func TestInactivityClaimSignatureMessage_MarshalingRoundtrip(t *testing.T) {
+	msg := &inactivityClaimSignatureMessage{
+		senderID:  group.MemberIndex(10),
+		claimHash: InactivityClaimHash{1, 2, 3},
+		signature: []byte{4, 5, 6},
+	}
+	unmarshaled := &inactivityClaimSignatureMessage{}
+
+	err := pbutils.RoundTrip(msg, unmarshaled)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(msg, unmarshaled) {
+		t.Fatalf("unexpected content of unmarshaled message")
+	}
+}
+
+func TestFuzzInactivityClaimSignatureMessage_MarshalingRoundtrip(t *testing.T) {
+	for i := 0; i < 10; i++ {
+		var (
+			senderID  group.MemberIndex
+			claimHash InactivityClaimHash
+			signature []byte
+		)
+
+		f := fuzz.New().NilChance(0.1).
+			NumElements(0, 512).
+			Funcs(pbutils.FuzzFuncs()...)
+
+		f.Fuzz(&senderID)
+		f.Fuzz(&claimHash)
+		f.Fuzz(&signature)
+
+		signatureMessage := &inactivityClaimSignatureMessage{
+			senderID:  senderID,
+			claimHash: claimHash,
+			signature: signature,
+		}
+
+		_ = pbutils.RoundTrip(
+			signatureMessage,
+			&inactivityClaimSignatureMessage{},
+		)
+	}
+}
+
+func TestFuzzInactivityClaimSignatureMessage_Unmarshaler(t *testing.T) {
+	pbutils.FuzzUnmarshaler(&inactivityClaimSignatureMessage{})
+}
+
 func TestCoordinationMessage_MarshalingRoundtrip(t *testing.T) {
 	parseHash := func(hash string) bitcoin.Hash {
 		parsed, err := bitcoin.NewHashFromString(hash, bitcoin.InternalByteOrder)
@@ -126,11 +330,23 @@ func TestCoordinationMessage_MarshalingRoundtrip(t *testing.T) {
 		return parsed
 	}
 
+	parseWalletPublicKeyHash := func(walletPublicKeyHash string) [20]byte {
+		parsed, err := hex.DecodeString(walletPublicKeyHash)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var result [20]byte
+		copy(result[:], parsed)
+
+		return result
+	}
+
 	tests := map[string]struct {
-		proposal coordinationProposal
+		proposal CoordinationProposal
 	}{
 		"with noop proposal": {
-			proposal: &noopProposal{},
+			proposal: &NoopProposal{},
 		},
 		"with heartbeat proposal": {
 			proposal: &HeartbeatProposal{
@@ -168,13 +384,28 @@ func TestCoordinationMessage_MarshalingRoundtrip(t *testing.T) {
 				RedemptionTxFee: big.NewInt(10000),
 			},
 		},
-		// TODO: Uncomment when moving funds support is implemented.
-		// "with moving funds proposal": {
-		//     proposal: &MovingFundsProposal{},
-		// },
-		// "with moved funds sweep proposal": {
-		//     proposal: &MovedFundsSweepProposal{},
-		// },
+		"with moving funds proposal": {
+			proposal: &MovingFundsProposal{
+				TargetWallets: [][20]byte{
+					parseWalletPublicKeyHash("8db50eb52063ea9d98b3eac91489a90f738986f6"),
+					parseWalletPublicKeyHash("2cd680318747b720d67bf4246eb7403b476adb34"),
+				},
+				MovingFundsTxFee: big.NewInt(10000),
+			},
+		},
+		"with moved funds sweep proposal": {
+			proposal: &MovedFundsSweepProposal{
+				MovingFundsTxHash:        parseHash("709b55bd3da0f5a838125bd0ee20c5bfdd7caba173912d4281cae816b79a201b"),
+				MovingFundsTxOutputIndex: 1,
+				SweepTxFee:               big.NewInt(10000),
+			},
+		},
+		"with fee bump proposal": {
+			proposal: &FeeBumpProposal{
+				TransactionHash: parseHash("709b55bd3da0f5a838125bd0ee20c5bfdd7caba173912d4281cae816b79a201b"),
+				TransactionFee:  big.NewInt(15000),
+			},
+		},
 	}
 
 	walletPublicKeyHashBytes, err := hex.DecodeString(
@@ -295,13 +526,100 @@ func TestFuzzCoordinationMessage_MarshalingRoundtrip_WithRedemptionProposal(t *t
 	}
 }
 
+func TestFuzzCoordinationMessage_MarshalingRoundtrip_WithMovingFundsProposal(t *testing.T) {
+	for i := 0; i < 10; i++ {
+		var (
+			senderID            group.MemberIndex
+			coordinationBlock   uint64
+			walletPublicKeyHash [20]byte
+			proposal            MovingFundsProposal
+		)
+
+		f := fuzz.New().NilChance(0.1).
+			NumElements(0, 512).
+			Funcs(pbutils.FuzzFuncs()...)
+
+		f.Fuzz(&senderID)
+		f.Fuzz(&coordinationBlock)
+		f.Fuzz(&walletPublicKeyHash)
+		f.Fuzz(&proposal)
+
+		doneMessage := &coordinationMessage{
+			senderID:            senderID,
+			coordinationBlock:   coordinationBlock,
+			walletPublicKeyHash: walletPublicKeyHash,
+			proposal:            &proposal,
+		}
+
+		_ = pbutils.RoundTrip(doneMessage, &coordinationMessage{})
+	}
+}
+
+func TestFuzzCoordinationMessage_MarshalingRoundtrip_WithMovedFundsSweepProposal(t *testing.T) {
+	for i := 0; i < 10; i++ {
+		var (
+			senderID            group.MemberIndex
+			coordinationBlock   uint64
+			walletPublicKeyHash [20]byte
+			proposal            MovedFundsSweepProposal
+		)
+
+		f := fuzz.New().NilChance(0.1).
+			NumElements(0, 512).
+			Funcs(pbutils.FuzzFuncs()...)
+
+		f.Fuzz(&senderID)
+		f.Fuzz(&coordinationBlock)
+		f.Fuzz(&walletPublicKeyHash)
+		f.Fuzz(&proposal)
+
+		doneMessage := &coordinationMessage{
+			senderID:            senderID,
+			coordinationBlock:   coordinationBlock,
+			walletPublicKeyHash: walletPublicKeyHash,
+			proposal:            &proposal,
+		}
+
+		_ = pbutils.RoundTrip(doneMessage, &coordinationMessage{})
+	}
+}
+
+func TestFuzzCoordinationMessage_MarshalingRoundtrip_WithFeeBumpProposal(t *testing.T) {
+	for i := 0; i < 10; i++ {
+		var (
+			senderID            group.MemberIndex
+			coordinationBlock   uint64
+			walletPublicKeyHash [20]byte
+			proposal            FeeBumpProposal
+		)
+
+		f := fuzz.New().NilChance(0.1).
+			NumElements(0, 512).
+			Funcs(pbutils.FuzzFuncs()...)
+
+		f.Fuzz(&senderID)
+		f.Fuzz(&coordinationBlock)
+		f.Fuzz(&walletPublicKeyHash)
+		f.Fuzz(&proposal)
+
+		doneMessage := &coordinationMessage{
+			senderID:            senderID,
+			coordinationBlock:   coordinationBlock,
+			walletPublicKeyHash: walletPublicKeyHash,
+			proposal:            &proposal,
+		}
+
+		_ = pbutils.RoundTrip(doneMessage, &coordinationMessage{})
+	}
+}
+
 func TestFuzzCoordinationMessage_MarshalingRoundtrip_WithNoopProposal(t *testing.T) {
 	for i := 0; i < 10; i++ {
 		var (
 			senderID            group.MemberIndex
 			coordinationBlock   uint64
 			walletPublicKeyHash [20]byte
-			proposal            noopProposal
+			proposal            NoopProposal
 		)
 
 		f := fuzz.New().NilChance(0.1).
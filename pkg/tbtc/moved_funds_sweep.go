@@ -0,0 +1,436 @@
+package tbtc
+
+import (
+	"crypto/ecdsa"
+	"fmt"
+	"math/big"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ipfs/go-log/v2"
+
+	"github.com/keep-network/keep-core/pkg/bitcoin"
+)
+
+const (
+	// movedFundsSweepProposalValidityBlocks determines the moved funds sweep
+	// proposal validity time expressed in blocks. In other words, this is the
+	// worst-case time for a moved funds sweep during which the wallet is busy
+	// and cannot take another actions. The value of 600 blocks is roughly
+	// 2 hours, assuming 12 seconds per block.
+	movedFundsSweepProposalValidityBlocks = 600
+	// movedFundsSweepSigningTimeoutSafetyMargin determines the duration of the
+	// safety margin that must be preserved between the signing timeout
+	// and the timeout of the entire moved funds sweep action. This safety
+	// margin prevents against the case where signing completes late and there
+	// is not enough time to broadcast the moved funds sweep transaction
+	// properly. In such a case, wallet signatures may leak and make the wallet
+	// subject of fraud accusations. Usage of the safety margin ensures there
+	// is enough time to perform post-signing steps of the moved funds sweep
+	// action.
+	movedFundsSweepSigningTimeoutSafetyMargin = 1 * time.Hour
+	// movedFundsSweepBroadcastTimeout determines the time window for moved
+	// funds sweep transaction broadcast. It is guaranteed that at least
+	// movedFundsSweepSigningTimeoutSafetyMargin is preserved for the broadcast
+	// step. However, the happy path for the broadcast step is usually quick
+	// and few retries are needed to recover from temporary problems. That
+	// said, if the broadcast step does not succeed in a tight timeframe,
+	// there is no point to retry for the entire possible time window.
+	// Hence, the timeout for broadcast step is set as 25% of the entire
+	// time widow determined by movedFundsSweepSigningTimeoutSafetyMargin.
+	movedFundsSweepBroadcastTimeout = movedFundsSweepSigningTimeoutSafetyMargin / 4
+	// movedFundsSweepBroadcastCheckDelay determines the delay that must
+	// be preserved between transaction broadcast and the check that ensures
+	// the transaction is known on the Bitcoin chain. This delay is needed
+	// as spreading the transaction over the Bitcoin network takes time.
+	movedFundsSweepBroadcastCheckDelay = 1 * time.Minute
+)
+
+// MovedFundsSweepProposal represents a moved funds sweep proposal issued by
+// a wallet's coordination leader.
+type MovedFundsSweepProposal struct {
+	// TODO: Remove WalletPublicKeyHash field.
+	WalletPublicKeyHash      [20]byte
+	MovingFundsTxHash        bitcoin.Hash
+	MovingFundsTxOutputIndex uint32
+	SweepTxFee               *big.Int
+}
+
+func (mfsp *MovedFundsSweepProposal) actionType() WalletActionType {
+	return ActionMovedFundsSweep
+}
+
+func (mfsp *MovedFundsSweepProposal) validityBlocks() uint64 {
+	return movedFundsSweepProposalValidityBlocks
+}
+
+func (mfsp *MovedFundsSweepProposal) setWalletPublicKeyHash(walletPublicKeyHash [20]byte) {
+	mfsp.WalletPublicKeyHash = walletPublicKeyHash
+}
+
+func (mfsp *MovedFundsSweepProposal) transactionFee() int64 {
+	return mfsp.SweepTxFee.Int64()
+}
+
+func (mfsp *MovedFundsSweepProposal) withTransactionFee(fee int64) walletTransactionProposal {
+	proposal := *mfsp
+	proposal.SweepTxFee = big.NewInt(fee)
+	return &proposal
+}
+
+// MovedFundsSweepRequestState represents the state of a moved funds sweep
+// request recorded on-chain.
+type MovedFundsSweepRequestState uint8
+
+const (
+	MovedFundsSweepRequestStateUnknown MovedFundsSweepRequestState = iota
+	MovedFundsSweepRequestStatePending
+	MovedFundsSweepRequestStateProcessed
+	MovedFundsSweepRequestStateTimedOut
+)
+
+func (mfsrs MovedFundsSweepRequestState) String() string {
+	switch mfsrs {
+	case MovedFundsSweepRequestStateUnknown:
+		return "Unknown"
+	case MovedFundsSweepRequestStatePending:
+		return "Pending"
+	case MovedFundsSweepRequestStateProcessed:
+		return "Processed"
+	case MovedFundsSweepRequestStateTimedOut:
+		return "TimedOut"
+	default:
+		panic("unknown moved funds sweep request state")
+	}
+}
+
+// MovedFundsSweepRequest represents a tBTC moved funds sweep request. Such
+// a request is created on-chain for each output of a proven moving funds
+// transaction and must be swept by the target wallet.
+type MovedFundsSweepRequest struct {
+	// WalletPublicKeyHash is the 20-byte public key hash of the target wallet
+	// that must sweep the moved funds.
+	WalletPublicKeyHash [20]byte
+	// Value is the value of the moved funds UTXO, in satoshi.
+	Value uint64
+	// CreatedAt is the time the request was created at.
+	CreatedAt time.Time
+	// State is the current state of the request.
+	State MovedFundsSweepRequestState
+}
+
+// movedFundsSweepAction is a moved funds sweep walletAction.
+type movedFundsSweepAction struct {
+	logger   *zap.SugaredLogger
+	chain    Chain
+	btcChain bitcoin.Chain
+
+	sweepingWallet      wallet
+	transactionExecutor *walletTransactionExecutor
+
+	proposal                     *MovedFundsSweepProposal
+	proposalProcessingStartBlock uint64
+	proposalExpiresAt            time.Time
+
+	signingTimeoutSafetyMargin time.Duration
+	broadcastTimeout           time.Duration
+	broadcastCheckDelay        time.Duration
+}
+
+func newMovedFundsSweepAction(
+	logger *zap.SugaredLogger,
+	chain Chain,
+	btcChain bitcoin.Chain,
+	sweepingWallet wallet,
+	signingExecutor walletSigningExecutor,
+	pendingTransactions *pendingWalletTransactions,
+	proposal *MovedFundsSweepProposal,
+	proposalProcessingStartBlock uint64,
+	proposalExpiresAt time.Time,
+) *movedFundsSweepAction {
+	transactionExecutor := newWalletTransactionExecutor(
+		btcChain,
+		sweepingWallet,
+		signingExecutor,
+		ActionMovedFundsSweep,
+		pendingTransactions,
+	)
+
+	return &movedFundsSweepAction{
+		logger:                       logger,
+		chain:                        chain,
+		btcChain:                     btcChain,
+		sweepingWallet:               sweepingWallet,
+		transactionExecutor:          transactionExecutor,
+		proposal:                     proposal,
+		proposalProcessingStartBlock: proposalProcessingStartBlock,
+		proposalExpiresAt:            proposalExpiresAt,
+		signingTimeoutSafetyMargin:   movedFundsSweepSigningTimeoutSafetyMargin,
+		broadcastTimeout:             movedFundsSweepBroadcastTimeout,
+		broadcastCheckDelay:          movedFundsSweepBroadcastCheckDelay,
+	}
+}
+
+func (mfsa *movedFundsSweepAction) execute() error {
+	walletPublicKeyHash := bitcoin.PublicKeyHash(mfsa.wallet().publicKey)
+
+	// The main UTXO is optional for moved funds sweep. However, it is needed
+	// for the proposal validation so, we must determine it upfront.
+	walletMainUtxo, err := DetermineWalletMainUtxo(
+		walletPublicKeyHash,
+		mfsa.chain,
+		mfsa.btcChain,
+	)
+	if err != nil {
+		return fmt.Errorf(
+			"error while determining wallet's main UTXO: [%v]",
+			err,
+		)
+	}
+
+	validateProposalLogger := mfsa.logger.With(
+		zap.String("step", "validateProposal"),
+	)
+
+	movedFundsSweepRequest, err := ValidateMovedFundsSweepProposal(
+		validateProposalLogger,
+		walletMainUtxo,
+		mfsa.proposal,
+		mfsa.chain,
+	)
+	if err != nil {
+		return fmt.Errorf("validate proposal step failed: [%v]", err)
+	}
+
+	err = EnsureWalletSyncedBetweenChains(
+		walletPublicKeyHash,
+		walletMainUtxo,
+		mfsa.chain,
+		mfsa.btcChain,
+	)
+	if err != nil {
+		return fmt.Errorf(
+			"error while ensuring wallet state is synced between "+
+				"BTC and host chain: [%v]",
+			err,
+		)
+	}
+
+	movedFundsUtxo := &bitcoin.UnspentTransactionOutput{
+		Outpoint: &bitcoin.TransactionOutpoint{
+			TransactionHash: mfsa.proposal.MovingFundsTxHash,
+			OutputIndex:     mfsa.proposal.MovingFundsTxOutputIndex,
+		},
+		Value: int64(movedFundsSweepRequest.Value),
+	}
+
+	unsignedSweepTx, err := assembleMovedFundsSweepTransaction(
+		mfsa.btcChain,
+		mfsa.wallet().publicKey,
+		walletMainUtxo,
+		movedFundsUtxo,
+		mfsa.proposal.SweepTxFee.Int64(),
+	)
+	if err != nil {
+		return fmt.Errorf(
+			"error while assembling moved funds sweep transaction: [%v]",
+			err,
+		)
+	}
+
+	signTxLogger := mfsa.logger.With(
+		zap.String("step", "signTransaction"),
+	)
+
+	signingTimesOutAt := mfsa.proposalExpiresAt.Add(-mfsa.signingTimeoutSafetyMargin)
+
+	sweepTx, err := mfsa.transactionExecutor.signTransaction(
+		signTxLogger,
+		unsignedSweepTx,
+		mfsa.proposalProcessingStartBlock,
+		signingTimesOutAt,
+	)
+	if err != nil {
+		return fmt.Errorf("sign transaction step failed: [%v]", err)
+	}
+
+	mfsa.transactionExecutor.recordPendingTransaction(
+		sweepTx,
+		walletMainUtxo,
+		mfsa.proposal,
+	)
+
+	broadcastTxLogger := mfsa.logger.With(
+		zap.String("step", "broadcastTransaction"),
+		zap.String("sweepTxHash", sweepTx.Hash().Hex(bitcoin.ReversedByteOrder)),
+	)
+
+	err = mfsa.transactionExecutor.broadcastTransaction(
+		broadcastTxLogger,
+		sweepTx,
+		mfsa.broadcastTimeout,
+		mfsa.broadcastCheckDelay,
+	)
+	if err != nil {
+		return fmt.Errorf("broadcast transaction step failed: [%v]", err)
+	}
+
+	return nil
+}
+
+// ValidateMovedFundsSweepProposal checks the moved funds sweep proposal with
+// on-chain validation rules. Returns the on-chain moved funds sweep request
+// the proposal refers to.
+func ValidateMovedFundsSweepProposal(
+	validateProposalLogger log.StandardLogger,
+	walletMainUtxo *bitcoin.UnspentTransactionOutput,
+	proposal *MovedFundsSweepProposal,
+	chain interface {
+		// GetMovedFundsSweepRequest gets the on-chain moved funds sweep request
+		// for the given moving funds transaction hash and output index.
+		// The returned bool value indicates whether the request was found or not.
+		GetMovedFundsSweepRequest(
+			movingFundsTxHash bitcoin.Hash,
+			movingFundsTxOutputIndex uint32,
+		) (*MovedFundsSweepRequest, bool, error)
+
+		// ValidateMovedFundsSweepProposal validates the given moved funds sweep
+		// proposal against the chain. The wallet's main UTXO must be provided
+		// if the wallet has one as it becomes an input of the moved funds sweep
+		// transaction. Returns an error if the proposal is not valid or nil
+		// otherwise.
+		ValidateMovedFundsSweepProposal(
+			walletMainUtxo *bitcoin.UnspentTransactionOutput,
+			proposal *MovedFundsSweepProposal,
+		) error
+	},
+) (*MovedFundsSweepRequest, error) {
+	validateProposalLogger.Infof("calling chain for proposal validation")
+
+	err := chain.ValidateMovedFundsSweepProposal(walletMainUtxo, proposal)
+	if err != nil {
+		return nil, fmt.Errorf("moved funds sweep proposal is invalid: [%v]", err)
+	}
+
+	validateProposalLogger.Infof(
+		"moved funds sweep proposal is valid",
+	)
+
+	request, found, err := chain.GetMovedFundsSweepRequest(
+		proposal.MovingFundsTxHash,
+		proposal.MovingFundsTxOutputIndex,
+	)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"cannot get moved funds sweep request data: [%v]",
+			err,
+		)
+	}
+	if !found {
+		return nil, fmt.Errorf("moved funds sweep request not found")
+	}
+
+	// The chain validation should detect the below cases. However, let's
+	// double-check them as the request data are needed anyway.
+	if request.WalletPublicKeyHash != proposal.WalletPublicKeyHash {
+		return nil, fmt.Errorf(
+			"moved funds sweep request belongs to another wallet",
+		)
+	}
+
+	if request.State != MovedFundsSweepRequestStatePending {
+		return nil, fmt.Errorf(
+			"moved funds sweep request is in state [%v] instead of [%v]",
+			request.State,
+			MovedFundsSweepRequestStatePending,
+		)
+	}
+
+	return request, nil
+}
+
+func (mfsa *movedFundsSweepAction) wallet() wallet {
+	return mfsa.sweepingWallet
+}
+
+func (mfsa *movedFundsSweepAction) actionType() WalletActionType {
+	return ActionMovedFundsSweep
+}
+
+func (mfsa *movedFundsSweepAction) expiresAt() time.Time {
+	return mfsa.proposalExpiresAt
+}
+
+func (mfsa *movedFundsSweepAction) signingTimeoutBlock() uint64 {
+	return signingTimeoutBlock(mfsa.proposalProcessingStartBlock)
+}
+
+// assembleMovedFundsSweepTransaction constructs an unsigned moved funds sweep
+// Bitcoin transaction.
+//
+// Regarding input arguments, the walletMainUtxo parameter is optional and
+// can be set as nil if the wallet does not have a main UTXO at the moment.
+// The movedFundsUtxo parameter is required and must point to the moving funds
+// transaction output locked on the sweeping wallet. The fee is not validated
+// in any way so must be chosen with respect to the system limitations.
+//
+// The resulting transaction's input vector starts with the moved funds UTXO
+// input and ends with the optional main UTXO input. The only output is the
+// new wallet's main UTXO locked using a P2WPKH script.
+//
+// The resulting bitcoin.TransactionBuilder instance holds all the data
+// necessary to sign the transaction and obtain a bitcoin.Transaction instance
+// ready to be spread across the Bitcoin network.
+func assembleMovedFundsSweepTransaction(
+	bitcoinChain bitcoin.Chain,
+	walletPublicKey *ecdsa.PublicKey,
+	walletMainUtxo *bitcoin.UnspentTransactionOutput,
+	movedFundsUtxo *bitcoin.UnspentTransactionOutput,
+	fee int64,
+) (*bitcoin.TransactionBuilder, error) {
+	if movedFundsUtxo == nil {
+		return nil, fmt.Errorf("moved funds UTXO is required")
+	}
+
+	builder := bitcoin.NewTransactionBuilder(bitcoinChain)
+
+	err := builder.AddPublicKeyHashInput(movedFundsUtxo)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"cannot add input pointing to moved funds UTXO: [%v]",
+			err,
+		)
+	}
+
+	if walletMainUtxo != nil {
+		err := builder.AddPublicKeyHashInput(walletMainUtxo)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"cannot add input pointing to wallet main UTXO: [%v]",
+				err,
+			)
+		}
+	}
+
+	outputValue := builder.TotalInputsValue() - fee
+	if outputValue <= 0 {
+		return nil, fmt.Errorf(
+			"transaction fee exceeds the value of the swept inputs",
+		)
+	}
+
+	outputScript, err := bitcoin.PayToWitnessPublicKeyHash(
+		bitcoin.PublicKeyHash(walletPublicKey),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("cannot compute output script: [%v]", err)
+	}
+
+	builder.AddOutput(&bitcoin.TransactionOutput{
+		Value:           outputValue,
+		PublicKeyScript: outputScript,
+	})
+
+	return builder, nil
+}
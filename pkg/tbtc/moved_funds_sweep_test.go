@@ -0,0 +1,232 @@
+package tbtc
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/keep-network/keep-core/internal/testutils"
+	"github.com/keep-network/keep-core/pkg/bitcoin"
+	"github.com/keep-network/keep-core/pkg/tbtc/internal/test"
+	"github.com/keep-network/keep-core/pkg/tecdsa"
+)
+
+// TODO: Think about covering unhappy paths for specific steps of the moved funds sweep action.
+func TestMovedFundsSweepAction_Execute(t *testing.T) {
+	scenarios, err := test.LoadMovedFundsSweepTestScenarios()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, scenario := range scenarios {
+		t.Run(scenario.Title, func(t *testing.T) {
+			now := time.Now()
+
+			hostChain := Connect()
+			bitcoinChain := newLocalBitcoinChain()
+
+			wallet := wallet{
+				// Set only relevant fields.
+				publicKey: scenario.WalletPublicKey,
+			}
+			walletPublicKeyHash := bitcoin.PublicKeyHash(wallet.publicKey)
+
+			// Record all transactions whose outputs are used as moved funds
+			// sweep transaction's inputs in the Bitcoin local chain.
+			for _, inputTransaction := range scenario.InputTransactions {
+				err := bitcoinChain.BroadcastTransaction(inputTransaction)
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			// Record the pending moved funds sweep request in the local
+			// host chain.
+			hostChain.setMovedFundsSweepRequest(
+				scenario.MovedFundsUtxo.Outpoint.TransactionHash,
+				scenario.MovedFundsUtxo.Outpoint.OutputIndex,
+				&MovedFundsSweepRequest{
+					WalletPublicKeyHash: walletPublicKeyHash,
+					Value:               uint64(scenario.MovedFundsUtxo.Value),
+					CreatedAt:           now,
+					State:               MovedFundsSweepRequestStatePending,
+				},
+			)
+
+			// Build the moved funds sweep proposal based on the scenario data.
+			proposal := &MovedFundsSweepProposal{
+				WalletPublicKeyHash:      walletPublicKeyHash,
+				MovingFundsTxHash:        scenario.MovedFundsUtxo.Outpoint.TransactionHash,
+				MovingFundsTxOutputIndex: scenario.MovedFundsUtxo.Outpoint.OutputIndex,
+				SweepTxFee:               big.NewInt(scenario.Fee),
+			}
+
+			// Choose an arbitrary start block and expiration time.
+			proposalProcessingStartBlock := uint64(100)
+			proposalExpiresAt := now.Add(4 * time.Hour)
+
+			// Simulate the on-chain proposal validation passes with success.
+			err = hostChain.setMovedFundsSweepProposalValidationResult(
+				scenario.WalletMainUtxo,
+				proposal,
+				true,
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// Record the wallet main UTXO hash in the local host chain so
+			// the moved funds sweep action can detect it.
+			var walletMainUtxoHash [32]byte
+			if scenario.WalletMainUtxo != nil {
+				walletMainUtxoHash = hostChain.ComputeMainUtxoHash(
+					scenario.WalletMainUtxo,
+				)
+			}
+			hostChain.setWallet(walletPublicKeyHash, &WalletChainData{
+				MainUtxoHash: walletMainUtxoHash,
+				State:        StateLive,
+			})
+
+			// Create a signing executor mock instance.
+			signingExecutor := newMockWalletSigningExecutor()
+
+			// The signatures within the scenario fixture are in the format
+			// suitable for applying them directly to a Bitcoin transaction.
+			// However, the signing executor operates on raw tECDSA signatures
+			// so, we need to unpack them first.
+			rawSignatures := make([]*tecdsa.Signature, len(scenario.Signatures))
+			for i, signature := range scenario.Signatures {
+				rawSignatures[i] = &tecdsa.Signature{
+					R: signature.R,
+					S: signature.S,
+				}
+			}
+
+			// Set up the signing executor mock to return the signatures from
+			// the test fixture when called with the expected parameters.
+			// Note that the start block is set based on the proposal
+			// processing start block as done within the action.
+			signingExecutor.setSignatures(
+				scenario.ExpectedSigHashes,
+				proposalProcessingStartBlock,
+				rawSignatures,
+			)
+
+			action := newMovedFundsSweepAction(
+				logger.With(),
+				hostChain,
+				bitcoinChain,
+				wallet,
+				signingExecutor,
+				// Scenario transactions do not signal replace-by-fee so,
+				// they must not be recorded for fee bumping.
+				nil,
+				proposal,
+				proposalProcessingStartBlock,
+				proposalExpiresAt,
+			)
+
+			// Modify the default parameters of the action to make
+			// it possible to execute in the current test environment.
+			action.broadcastCheckDelay = 1 * time.Second
+
+			err = action.execute()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// Action execution that completes without an error is a sign of
+			// success. However, just in case, make an additional check that
+			// the expected moved funds sweep transaction was actually
+			// broadcasted on the local Bitcoin chain.
+			broadcastedSweepTransaction, err := bitcoinChain.GetTransaction(
+				scenario.ExpectedSweepTransactionHash,
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			testutils.AssertBytesEqual(
+				t,
+				scenario.ExpectedSweepTransaction.Serialize(),
+				broadcastedSweepTransaction.Serialize(),
+			)
+		})
+	}
+}
+
+func TestAssembleMovedFundsSweepTransaction(t *testing.T) {
+	scenarios, err := test.LoadMovedFundsSweepTestScenarios()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, scenario := range scenarios {
+		t.Run(scenario.Title, func(t *testing.T) {
+			bitcoinChain := newLocalBitcoinChain()
+
+			for _, inputTransaction := range scenario.InputTransactions {
+				err := bitcoinChain.BroadcastTransaction(inputTransaction)
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			builder, err := assembleMovedFundsSweepTransaction(
+				bitcoinChain,
+				scenario.WalletPublicKey,
+				scenario.WalletMainUtxo,
+				scenario.MovedFundsUtxo,
+				scenario.Fee,
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			sigHashes, err := builder.ComputeSignatureHashes()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			testutils.AssertIntsEqual(
+				t,
+				"sighash count",
+				len(scenario.ExpectedSigHashes),
+				len(sigHashes),
+			)
+
+			for i, expectedSigHash := range scenario.ExpectedSigHashes {
+				testutils.AssertBigIntsEqual(
+					t,
+					"sighash",
+					expectedSigHash,
+					sigHashes[i],
+				)
+			}
+
+			transaction, err := builder.AddSignatures(scenario.Signatures)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			testutils.AssertBytesEqual(
+				t,
+				scenario.ExpectedSweepTransaction.Serialize(),
+				transaction.Serialize(),
+			)
+			testutils.AssertStringsEqual(
+				t,
+				"moved funds sweep transaction hash",
+				scenario.ExpectedSweepTransactionHash.Hex(bitcoin.InternalByteOrder),
+				transaction.Hash().Hex(bitcoin.InternalByteOrder),
+			)
+			testutils.AssertStringsEqual(
+				t,
+				"moved funds sweep transaction witness hash",
+				scenario.ExpectedSweepTransactionWitnessHash.Hex(bitcoin.InternalByteOrder),
+				transaction.WitnessHash().Hex(bitcoin.InternalByteOrder),
+			)
+		})
+	}
+}
@@ -0,0 +1,356 @@
+package tbtc
+
+import (
+	"crypto/ecdsa"
+	"fmt"
+	"math/big"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ipfs/go-log/v2"
+
+	"github.com/keep-network/keep-core/pkg/bitcoin"
+)
+
+const (
+	// movingFundsProposalValidityBlocks determines the moving funds proposal
+	// validity time expressed in blocks. In other words, this is the worst-case
+	// time for moving funds during which the wallet is busy and cannot take
+	// another actions. The value of 650 blocks is roughly 2 hours and 10
+	// minutes, assuming 12 seconds per block.
+	movingFundsProposalValidityBlocks = 650
+	// movingFundsSigningTimeoutSafetyMargin determines the duration of the
+	// safety margin that must be preserved between the signing timeout
+	// and the timeout of the entire moving funds action. This safety
+	// margin prevents against the case where signing completes late and there
+	// is not enough time to broadcast the moving funds transaction properly.
+	// In such a case, wallet signatures may leak and make the wallet subject
+	// of fraud accusations. Usage of the safety margin ensures there is enough
+	// time to perform post-signing steps of the moving funds action.
+	movingFundsSigningTimeoutSafetyMargin = 1 * time.Hour
+	// movingFundsBroadcastTimeout determines the time window for moving
+	// funds transaction broadcast. It is guaranteed that at least
+	// movingFundsSigningTimeoutSafetyMargin is preserved for the broadcast
+	// step. However, the happy path for the broadcast step is usually quick
+	// and few retries are needed to recover from temporary problems. That
+	// said, if the broadcast step does not succeed in a tight timeframe,
+	// there is no point to retry for the entire possible time window.
+	// Hence, the timeout for broadcast step is set as 25% of the entire
+	// time widow determined by movingFundsSigningTimeoutSafetyMargin.
+	movingFundsBroadcastTimeout = movingFundsSigningTimeoutSafetyMargin / 4
+	// movingFundsBroadcastCheckDelay determines the delay that must
+	// be preserved between transaction broadcast and the check that ensures
+	// the transaction is known on the Bitcoin chain. This delay is needed
+	// as spreading the transaction over the Bitcoin network takes time.
+	movingFundsBroadcastCheckDelay = 1 * time.Minute
+)
+
+// MovingFundsProposal represents a moving funds proposal issued by a wallet's
+// coordination leader.
+type MovingFundsProposal struct {
+	// TODO: Remove WalletPublicKeyHash field.
+	WalletPublicKeyHash [20]byte
+	TargetWallets       [][20]byte
+	MovingFundsTxFee    *big.Int
+}
+
+func (mfp *MovingFundsProposal) actionType() WalletActionType {
+	return ActionMovingFunds
+}
+
+func (mfp *MovingFundsProposal) validityBlocks() uint64 {
+	return movingFundsProposalValidityBlocks
+}
+
+func (mfp *MovingFundsProposal) setWalletPublicKeyHash(walletPublicKeyHash [20]byte) {
+	mfp.WalletPublicKeyHash = walletPublicKeyHash
+}
+
+func (mfp *MovingFundsProposal) transactionFee() int64 {
+	return mfp.MovingFundsTxFee.Int64()
+}
+
+func (mfp *MovingFundsProposal) withTransactionFee(fee int64) walletTransactionProposal {
+	proposal := *mfp
+	proposal.MovingFundsTxFee = big.NewInt(fee)
+	return &proposal
+}
+
+// movingFundsAction is a moving funds walletAction.
+type movingFundsAction struct {
+	logger   *zap.SugaredLogger
+	chain    Chain
+	btcChain bitcoin.Chain
+
+	movingFundsWallet   wallet
+	transactionExecutor *walletTransactionExecutor
+
+	proposal                     *MovingFundsProposal
+	proposalProcessingStartBlock uint64
+	proposalExpiresAt            time.Time
+
+	signingTimeoutSafetyMargin time.Duration
+	broadcastTimeout           time.Duration
+	broadcastCheckDelay        time.Duration
+}
+
+func newMovingFundsAction(
+	logger *zap.SugaredLogger,
+	chain Chain,
+	btcChain bitcoin.Chain,
+	movingFundsWallet wallet,
+	signingExecutor walletSigningExecutor,
+	pendingTransactions *pendingWalletTransactions,
+	proposal *MovingFundsProposal,
+	proposalProcessingStartBlock uint64,
+	proposalExpiresAt time.Time,
+) *movingFundsAction {
+	transactionExecutor := newWalletTransactionExecutor(
+		btcChain,
+		movingFundsWallet,
+		signingExecutor,
+		ActionMovingFunds,
+		pendingTransactions,
+	)
+
+	return &movingFundsAction{
+		logger:                       logger,
+		chain:                        chain,
+		btcChain:                     btcChain,
+		movingFundsWallet:            movingFundsWallet,
+		transactionExecutor:          transactionExecutor,
+		proposal:                     proposal,
+		proposalProcessingStartBlock: proposalProcessingStartBlock,
+		proposalExpiresAt:            proposalExpiresAt,
+		signingTimeoutSafetyMargin:   movingFundsSigningTimeoutSafetyMargin,
+		broadcastTimeout:             movingFundsBroadcastTimeout,
+		broadcastCheckDelay:          movingFundsBroadcastCheckDelay,
+	}
+}
+
+func (mfa *movingFundsAction) execute() error {
+	walletPublicKeyHash := bitcoin.PublicKeyHash(mfa.wallet().publicKey)
+
+	walletMainUtxo, err := DetermineWalletMainUtxo(
+		walletPublicKeyHash,
+		mfa.chain,
+		mfa.btcChain,
+	)
+	if err != nil {
+		return fmt.Errorf(
+			"error while determining wallet's main UTXO: [%v]",
+			err,
+		)
+	}
+
+	// The wallet must have funds to move. The main UTXO is also needed
+	// for the proposal validation so, we must check it upfront.
+	if walletMainUtxo == nil {
+		return fmt.Errorf("moving funds wallet has no main UTXO")
+	}
+
+	validateProposalLogger := mfa.logger.With(
+		zap.String("step", "validateProposal"),
+	)
+
+	err = ValidateMovingFundsProposal(
+		validateProposalLogger,
+		walletMainUtxo,
+		mfa.proposal,
+		mfa.chain,
+	)
+	if err != nil {
+		return fmt.Errorf("validate proposal step failed: [%v]", err)
+	}
+
+	err = EnsureWalletSyncedBetweenChains(
+		walletPublicKeyHash,
+		walletMainUtxo,
+		mfa.chain,
+		mfa.btcChain,
+	)
+	if err != nil {
+		return fmt.Errorf(
+			"error while ensuring wallet state is synced between "+
+				"BTC and host chain: [%v]",
+			err,
+		)
+	}
+
+	unsignedMovingFundsTx, err := assembleMovingFundsTransaction(
+		mfa.btcChain,
+		mfa.wallet().publicKey,
+		walletMainUtxo,
+		mfa.proposal.TargetWallets,
+		mfa.proposal.MovingFundsTxFee.Int64(),
+	)
+	if err != nil {
+		return fmt.Errorf(
+			"error while assembling moving funds transaction: [%v]",
+			err,
+		)
+	}
+
+	signTxLogger := mfa.logger.With(
+		zap.String("step", "signTransaction"),
+	)
+
+	signingTimesOutAt := mfa.proposalExpiresAt.Add(-mfa.signingTimeoutSafetyMargin)
+
+	movingFundsTx, err := mfa.transactionExecutor.signTransaction(
+		signTxLogger,
+		unsignedMovingFundsTx,
+		mfa.proposalProcessingStartBlock,
+		signingTimesOutAt,
+	)
+	if err != nil {
+		return fmt.Errorf("sign transaction step failed: [%v]", err)
+	}
+
+	mfa.transactionExecutor.recordPendingTransaction(
+		movingFundsTx,
+		walletMainUtxo,
+		mfa.proposal,
+	)
+
+	broadcastTxLogger := mfa.logger.With(
+		zap.String("step", "broadcastTransaction"),
+		zap.String("movingFundsTxHash", movingFundsTx.Hash().Hex(bitcoin.ReversedByteOrder)),
+	)
+
+	err = mfa.transactionExecutor.broadcastTransaction(
+		broadcastTxLogger,
+		movingFundsTx,
+		mfa.broadcastTimeout,
+		mfa.broadcastCheckDelay,
+	)
+	if err != nil {
+		return fmt.Errorf("broadcast transaction step failed: [%v]", err)
+	}
+
+	return nil
+}
+
+// ValidateMovingFundsProposal checks the moving funds proposal with on-chain
+// validation rules.
+func ValidateMovingFundsProposal(
+	validateProposalLogger log.StandardLogger,
+	walletMainUtxo *bitcoin.UnspentTransactionOutput,
+	proposal *MovingFundsProposal,
+	chain interface {
+		// ValidateMovingFundsProposal validates the given moving funds proposal
+		// against the chain. The wallet's main UTXO must be provided as it
+		// is the only input of the moving funds transaction. Returns an error
+		// if the proposal is not valid or nil otherwise.
+		ValidateMovingFundsProposal(
+			walletMainUtxo *bitcoin.UnspentTransactionOutput,
+			proposal *MovingFundsProposal,
+		) error
+	},
+) error {
+	validateProposalLogger.Infof("calling chain for proposal validation")
+
+	err := chain.ValidateMovingFundsProposal(walletMainUtxo, proposal)
+	if err != nil {
+		return fmt.Errorf("moving funds proposal is invalid: [%v]", err)
+	}
+
+	validateProposalLogger.Infof(
+		"moving funds proposal is valid",
+	)
+
+	return nil
+}
+
+func (mfa *movingFundsAction) wallet() wallet {
+	return mfa.movingFundsWallet
+}
+
+func (mfa *movingFundsAction) actionType() WalletActionType {
+	return ActionMovingFunds
+}
+
+func (mfa *movingFundsAction) expiresAt() time.Time {
+	return mfa.proposalExpiresAt
+}
+
+func (mfa *movingFundsAction) signingTimeoutBlock() uint64 {
+	return signingTimeoutBlock(mfa.proposalProcessingStartBlock)
+}
+
+// assembleMovingFundsTransaction constructs an unsigned moving funds Bitcoin
+// transaction.
+//
+// Regarding input arguments, the targetWallets slice must contain at least
+// one element. The transaction spends the wallet's main UTXO and splits its
+// value, reduced by the given fee, evenly across P2WPKH outputs locked on
+// the target wallets, preserving the order of the targetWallets slice. If
+// the value cannot be divided evenly, the last output receives the remainder.
+// The fee is not validated in any way so must be chosen with respect to the
+// system limitations.
+//
+// The resulting bitcoin.TransactionBuilder instance holds all the data
+// necessary to sign the transaction and obtain a bitcoin.Transaction instance
+// ready to be spread across the Bitcoin network.
+func assembleMovingFundsTransaction(
+	bitcoinChain bitcoin.Chain,
+	walletPublicKey *ecdsa.PublicKey,
+	walletMainUtxo *bitcoin.UnspentTransactionOutput,
+	targetWallets [][20]byte,
+	fee int64,
+) (*bitcoin.TransactionBuilder, error) {
+	if walletMainUtxo == nil {
+		return nil, fmt.Errorf("wallet main UTXO is required")
+	}
+
+	if len(targetWallets) < 1 {
+		return nil, fmt.Errorf("at least one target wallet is required")
+	}
+
+	builder := bitcoin.NewTransactionBuilder(bitcoinChain)
+
+	err := builder.AddPublicKeyHashInput(walletMainUtxo)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"cannot add input pointing to wallet main UTXO: [%v]",
+			err,
+		)
+	}
+
+	// The value that can be moved to target wallets is the difference
+	// between the main UTXO value and the transaction fee.
+	totalOutputsValue := builder.TotalInputsValue() - fee
+	if totalOutputsValue <= 0 {
+		return nil, fmt.Errorf(
+			"transaction fee exceeds the value of the wallet main UTXO",
+		)
+	}
+
+	targetWalletsCount := int64(len(targetWallets))
+	remainder := totalOutputsValue % targetWalletsCount
+	outputValue := (totalOutputsValue - remainder) / targetWalletsCount
+
+	for i, targetWallet := range targetWallets {
+		outputScript, err := bitcoin.PayToWitnessPublicKeyHash(targetWallet)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"cannot compute output script for target wallet [0x%x]: [%v]",
+				targetWallet,
+				err,
+			)
+		}
+
+		value := outputValue
+		if i == len(targetWallets)-1 {
+			value += remainder
+		}
+
+		builder.AddOutput(&bitcoin.TransactionOutput{
+			Value:           value,
+			PublicKeyScript: outputScript,
+		})
+	}
+
+	return builder, nil
+}
@@ -0,0 +1,244 @@
+package tbtc
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/keep-network/keep-core/internal/testutils"
+	"github.com/keep-network/keep-core/pkg/bitcoin"
+	"github.com/keep-network/keep-core/pkg/tbtc/internal/test"
+	"github.com/keep-network/keep-core/pkg/tecdsa"
+)
+
+// TODO: Think about covering unhappy paths for specific steps of the moving funds action.
+func TestMovingFundsAction_Execute(t *testing.T) {
+	scenarios, err := test.LoadMovingFundsTestScenarios()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, scenario := range scenarios {
+		t.Run(scenario.Title, func(t *testing.T) {
+			now := time.Now()
+
+			hostChain := Connect()
+			bitcoinChain := newLocalBitcoinChain()
+
+			wallet := wallet{
+				// Set only relevant fields.
+				publicKey: scenario.WalletPublicKey,
+			}
+			walletPublicKeyHash := bitcoin.PublicKeyHash(wallet.publicKey)
+
+			// Record the transaction that will serve as moving funds
+			// transaction's input in the Bitcoin local chain.
+			err := bitcoinChain.BroadcastTransaction(scenario.InputTransaction)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// Build the moving funds proposal based on the scenario data.
+			proposal := &MovingFundsProposal{
+				WalletPublicKeyHash: walletPublicKeyHash,
+				TargetWallets:       scenario.TargetWallets,
+				MovingFundsTxFee:    big.NewInt(scenario.Fee),
+			}
+
+			// Choose an arbitrary start block and expiration time.
+			proposalProcessingStartBlock := uint64(100)
+			proposalExpiresAt := now.Add(4 * time.Hour)
+
+			// Simulate the on-chain proposal validation passes with success.
+			err = hostChain.setMovingFundsProposalValidationResult(
+				scenario.WalletMainUtxo,
+				proposal,
+				true,
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// Record the wallet main UTXO hash in the local host chain so
+			// the moving funds action can detect it.
+			hostChain.setWallet(walletPublicKeyHash, &WalletChainData{
+				MainUtxoHash: hostChain.ComputeMainUtxoHash(scenario.WalletMainUtxo),
+				State:        StateMovingFunds,
+			})
+
+			// Create a signing executor mock instance.
+			signingExecutor := newMockWalletSigningExecutor()
+
+			// The signature within the scenario fixture is in the format
+			// suitable for applying them directly to a Bitcoin transaction.
+			// However, the signing executor operates on raw tECDSA signatures
+			// so, we need to unpack it first.
+			rawSignature := &tecdsa.Signature{
+				R: scenario.Signature.R,
+				S: scenario.Signature.S,
+			}
+
+			// Set up the signing executor mock to return the signature from
+			// the test fixture when called with the expected parameters.
+			// Note that the start block is set based on the proposal
+			// processing start block as done within the action.
+			signingExecutor.setSignatures(
+				[]*big.Int{scenario.ExpectedSigHash},
+				proposalProcessingStartBlock,
+				[]*tecdsa.Signature{rawSignature},
+			)
+
+			action := newMovingFundsAction(
+				logger.With(),
+				hostChain,
+				bitcoinChain,
+				wallet,
+				signingExecutor,
+				// Scenario transactions do not signal replace-by-fee so,
+				// they must not be recorded for fee bumping.
+				nil,
+				proposal,
+				proposalProcessingStartBlock,
+				proposalExpiresAt,
+			)
+
+			// Modify the default parameters of the action to make
+			// it possible to execute in the current test environment.
+			action.broadcastCheckDelay = 1 * time.Second
+
+			err = action.execute()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// Action execution that completes without an error is a sign of
+			// success. However, just in case, make an additional check that
+			// the expected moving funds transaction was actually broadcasted
+			// on the local Bitcoin chain.
+			broadcastedMovingFundsTransaction, err := bitcoinChain.GetTransaction(
+				scenario.ExpectedMovingFundsTransactionHash,
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			testutils.AssertBytesEqual(
+				t,
+				scenario.ExpectedMovingFundsTransaction.Serialize(),
+				broadcastedMovingFundsTransaction.Serialize(),
+			)
+		})
+	}
+}
+
+func TestAssembleMovingFundsTransaction(t *testing.T) {
+	scenarios, err := test.LoadMovingFundsTestScenarios()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, scenario := range scenarios {
+		t.Run(scenario.Title, func(t *testing.T) {
+			bitcoinChain := newLocalBitcoinChain()
+
+			err := bitcoinChain.BroadcastTransaction(scenario.InputTransaction)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			builder, err := assembleMovingFundsTransaction(
+				bitcoinChain,
+				scenario.WalletPublicKey,
+				scenario.WalletMainUtxo,
+				scenario.TargetWallets,
+				scenario.Fee,
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			sigHashes, err := builder.ComputeSignatureHashes()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			testutils.AssertIntsEqual(
+				t,
+				"sighash count",
+				1,
+				len(sigHashes),
+			)
+
+			testutils.AssertBigIntsEqual(
+				t,
+				"sighash",
+				scenario.ExpectedSigHash,
+				sigHashes[0],
+			)
+
+			transaction, err := builder.AddSignatures(
+				[]*bitcoin.SignatureContainer{scenario.Signature},
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			testutils.AssertBytesEqual(
+				t,
+				scenario.ExpectedMovingFundsTransaction.Serialize(),
+				transaction.Serialize(),
+			)
+			testutils.AssertStringsEqual(
+				t,
+				"moving funds transaction hash",
+				scenario.ExpectedMovingFundsTransactionHash.Hex(bitcoin.InternalByteOrder),
+				transaction.Hash().Hex(bitcoin.InternalByteOrder),
+			)
+			testutils.AssertStringsEqual(
+				t,
+				"moving funds transaction witness hash",
+				scenario.ExpectedMovingFundsTransactionWitnessHash.Hex(bitcoin.InternalByteOrder),
+				transaction.WitnessHash().Hex(bitcoin.InternalByteOrder),
+			)
+		})
+	}
+}
+
+func TestAssembleMovingFundsTransaction_FeeTooHigh(t *testing.T) {
+	scenarios, err := test.LoadMovingFundsTestScenarios()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	scenario := scenarios[0]
+
+	bitcoinChain := newLocalBitcoinChain()
+
+	err = bitcoinChain.BroadcastTransaction(scenario.InputTransaction)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = assembleMovingFundsTransaction(
+		bitcoinChain,
+		scenario.WalletPublicKey,
+		scenario.WalletMainUtxo,
+		scenario.TargetWallets,
+		scenario.WalletMainUtxo.Value,
+	)
+
+	expectedErr := fmt.Errorf(
+		"transaction fee exceeds the value of the wallet main UTXO",
+	)
+	if !reflect.DeepEqual(expectedErr, err) {
+		t.Errorf(
+			"unexpected error\n"+
+				"expected: [%v]\n"+
+				"actual:   [%v]",
+			expectedErr,
+			err,
+		)
+	}
+}
@@ -85,6 +85,52 @@ type node struct {
 	//
 	// coordinationExecutors MUST NOT be used outside this struct.
 	coordinationExecutors map[string]*coordinationExecutor
+
+	inactivityClaimExecutorsMutex sync.Mutex
+	// inactivityClaimExecutors is the cache holding inactivity claim executors
+	// for specific wallets. The cache key is the uncompressed public key
+	// (with 04 prefix) of the wallet. The inactivityClaimExecutor encapsulates
+	// the logic of the inactivity claim protocol.
+	//
+	// inactivityClaimExecutors MUST NOT be used outside this struct.
+	inactivityClaimExecutors map[string]*inactivityClaimExecutor
+
+	// heartbeatFailureCounter holds the numbers of consecutive heartbeat
+	// failures of wallets controlled by the node.
+	heartbeatFailureCounter *heartbeatFailureCounter
+
+	// coordinationFaultRegistry records coordination faults observed
+	// by the node and keeps aggregated per-operator fault counters.
+	coordinationFaultRegistry *coordinationFaultRegistry
+
+	// signingAuditLog records signatures produced by all signing executors
+	// of the node in a tamper-evident way.
+	signingAuditLog *signingAuditLog
+
+	// signingPolicy is the operator-defined policy evaluated by all signing
+	// executors of the node before signing wallet transactions.
+	signingPolicy *signingPolicy
+
+	// depositQuarantine holds revealed deposits that failed validation
+	// against the Bitcoin chain.
+	depositQuarantine *depositQuarantine
+
+	// depositRevealValidator validates revealed deposits and maintains
+	// the deposit quarantine.
+	depositRevealValidator *depositRevealValidator
+
+	// pendingWalletTransactions holds the latest transactions signed by
+	// wallets controlled by the node so their fees can be bumped if they
+	// get stuck.
+	pendingWalletTransactions *pendingWalletTransactions
+
+	// redemptionTimeoutMonitor tracks how close pending redemption requests
+	// of wallets controlled by the node are to their timeout.
+	redemptionTimeoutMonitor *redemptionTimeoutMonitor
+
+	// proposalGenerator is used by the node to generate coordination
+	// proposals when acting as the coordination leader.
+	proposalGenerator CoordinationProposalGenerator
 }
 
 func newNode(
@@ -95,25 +141,51 @@ func newNode(
 	keyStorePersistance persistence.ProtectedHandle,
 	workPersistence persistence.BasicHandle,
 	scheduler *generator.Scheduler,
+	proposalGenerator CoordinationProposalGenerator,
 	config Config,
 ) (*node, error) {
 	walletRegistry := newWalletRegistry(keyStorePersistance)
 
+	signingPolicy, err := newSigningPolicy(config.SigningPolicy)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create signing policy: [%v]", err)
+	}
+
+	blockCounter, err := chain.BlockCounter()
+	if err != nil {
+		return nil, fmt.Errorf("cannot get block counter: [%v]", err)
+	}
+
 	latch := generator.NewProtocolLatch()
 	scheduler.RegisterProtocol(latch)
 
 	node := &node{
-		groupParameters:       groupParameters,
-		chain:                 chain,
-		btcChain:              btcChain,
-		netProvider:           netProvider,
-		walletRegistry:        walletRegistry,
-		walletDispatcher:      newWalletDispatcher(),
-		protocolLatch:         latch,
-		signingExecutors:      make(map[string]*signingExecutor),
-		coordinationExecutors: make(map[string]*coordinationExecutor),
+		groupParameters:           groupParameters,
+		chain:                     chain,
+		btcChain:                  btcChain,
+		netProvider:               netProvider,
+		walletRegistry:            walletRegistry,
+		walletDispatcher:          newWalletDispatcher(blockCounter.CurrentBlock),
+		protocolLatch:             latch,
+		signingExecutors:          make(map[string]*signingExecutor),
+		coordinationExecutors:     make(map[string]*coordinationExecutor),
+		inactivityClaimExecutors:  make(map[string]*inactivityClaimExecutor),
+		heartbeatFailureCounter:   newHeartbeatFailureCounter(),
+		coordinationFaultRegistry: newCoordinationFaultRegistry(workPersistence),
+		signingAuditLog:           newSigningAuditLog(workPersistence),
+		signingPolicy:             signingPolicy,
+		pendingWalletTransactions: newPendingWalletTransactions(workPersistence),
+		proposalGenerator:         proposalGenerator,
 	}
 
+	node.depositQuarantine = newDepositQuarantine(workPersistence)
+	node.depositRevealValidator = newDepositRevealValidator(
+		chain,
+		btcChain,
+		node.depositQuarantine,
+	)
+	node.redemptionTimeoutMonitor = newRedemptionTimeoutMonitor(chain)
+
 	// Only the operator address is known at this point and can be pre-fetched.
 	// The operator ID must be determined later as the operator may not be in
 	// the sortition pool yet.
@@ -240,11 +312,7 @@ func (n *node) getSigningExecutor(
 	// first signer.
 	wallet := signers[0].wallet
 
-	channelName := fmt.Sprintf(
-		"%s-%s",
-		ProtocolName,
-		hex.EncodeToString(walletPublicKeyBytes),
-	)
+	channelName := signingChannelName(walletPublicKeyBytes)
 
 	broadcastChannel, err := n.netProvider.BroadcastChannelFor(channelName)
 	if err != nil {
@@ -294,6 +362,8 @@ func (n *node) getSigningExecutor(
 		blockCounter.CurrentBlock,
 		n.waitForBlockHeight,
 		signingAttemptsLimit,
+		n.signingAuditLog,
+		n.signingPolicy,
 	)
 
 	n.signingExecutors[executorKey] = executor
@@ -337,11 +407,7 @@ func (n *node) getCoordinationExecutor(
 	// first signer.
 	wallet := signers[0].wallet
 
-	channelName := fmt.Sprintf(
-		"%s-%s-coordination",
-		ProtocolName,
-		hex.EncodeToString(walletPublicKeyBytes),
-	)
+	channelName := coordinationChannelName(walletPublicKeyBytes)
 
 	broadcastChannel, err := n.netProvider.BroadcastChannelFor(channelName)
 	if err != nil {
@@ -379,12 +445,12 @@ func (n *node) getCoordinationExecutor(
 		return nil, false, fmt.Errorf("failed to get operator address: [%v]", err)
 	}
 
-	proposalGenerator := func(
-		walletPublicKeyHash [20]byte,
-		actionsChecklist []WalletActionType,
-	) (coordinationProposal, error) {
-		// TODO: Implement proposal generation.
-		return &noopProposal{}, nil
+	blockCounter, err := n.chain.BlockCounter()
+	if err != nil {
+		return nil, false, fmt.Errorf(
+			"could not get block counter: [%v]",
+			err,
+		)
 	}
 
 	executor := newCoordinationExecutor(
@@ -392,10 +458,11 @@ func (n *node) getCoordinationExecutor(
 		wallet,
 		membersIndexes,
 		operatorAddress,
-		proposalGenerator,
+		n.generateCoordinationProposal,
 		broadcastChannel,
 		membershipValidator,
 		n.protocolLatch,
+		blockCounter.CurrentBlock,
 		n.waitForBlockHeight,
 	)
 
@@ -409,6 +476,205 @@ func (n *node) getCoordinationExecutor(
 	return executor, true, nil
 }
 
+// generateCoordinationProposal generates a coordination proposal for the
+// given wallet using the node's proposal generator. Deposits of the wallet
+// that are currently quarantined are passed to the generator so they are
+// not included in the proposal. The wallet's pending transaction that is not
+// confirmed yet is passed to the generator so its fee can be bumped. If the
+// wallet has pending redemption requests at risk of timing out, redemption
+// is moved to the front of the actions checklist so it takes precedence over
+// other actions.
+func (n *node) generateCoordinationProposal(
+	walletPublicKeyHash [20]byte,
+	actionsChecklist []WalletActionType,
+) (CoordinationProposal, error) {
+	if n.redemptionTimeoutMonitor.isAtRisk(walletPublicKeyHash) {
+		prioritizedChecklist, moved := prioritizeRedemption(actionsChecklist)
+		if moved {
+			logger.Warnf(
+				"wallet PKH [0x%x] has redemption requests at risk of "+
+					"timing out; prioritizing redemption in the actions "+
+					"checklist",
+				walletPublicKeyHash,
+			)
+		}
+		actionsChecklist = prioritizedChecklist
+	}
+
+	var pendingTransaction *PendingWalletTransaction
+	if pending, ok := n.pendingWalletTransactions.unconfirmed(
+		walletPublicKeyHash,
+		n.btcChain,
+	); ok {
+		pendingTransaction = &pending.PendingWalletTransaction
+	}
+
+	return n.proposalGenerator.Generate(&CoordinationProposalRequest{
+		WalletPublicKeyHash: walletPublicKeyHash,
+		ActionsChecklist:    actionsChecklist,
+		QuarantinedDeposits: n.depositQuarantine.walletDeposits(
+			walletPublicKeyHash,
+		),
+		PendingTransaction: pendingTransaction,
+	})
+}
+
+// getInactivityClaimExecutor gets the inactivity claim executor responsible
+// for executing the inactivity claim protocol related to a specific wallet
+// whose part is controlled by this node. The second boolean return value
+// indicates whether the node controls at least one signer for the given
+// wallet.
+func (n *node) getInactivityClaimExecutor(
+	walletPublicKey *ecdsa.PublicKey,
+) (*inactivityClaimExecutor, bool, error) {
+	n.inactivityClaimExecutorsMutex.Lock()
+	defer n.inactivityClaimExecutorsMutex.Unlock()
+
+	walletPublicKeyBytes, err := marshalPublicKey(walletPublicKey)
+	if err != nil {
+		return nil, false, fmt.Errorf("cannot marshal wallet public key: [%v]", err)
+	}
+
+	executorKey := hex.EncodeToString(walletPublicKeyBytes)
+
+	if executor, exists := n.inactivityClaimExecutors[executorKey]; exists {
+		return executor, true, nil
+	}
+
+	executorLogger := logger.With(
+		zap.String("wallet", fmt.Sprintf("0x%x", walletPublicKeyBytes)),
+	)
+
+	signers := n.walletRegistry.getSigners(walletPublicKey)
+	if len(signers) == 0 {
+		// This is not an error because the node simply does not control
+		// the given wallet.
+		return nil, false, nil
+	}
+
+	// All signers belong to one wallet. Take that wallet from the
+	// first signer.
+	wallet := signers[0].wallet
+
+	channelName := inactivityClaimChannelName(walletPublicKeyBytes)
+
+	broadcastChannel, err := n.netProvider.BroadcastChannelFor(channelName)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to get broadcast channel: [%v]", err)
+	}
+
+	announcer.RegisterUnmarshaller(broadcastChannel)
+	broadcastChannel.SetUnmarshaler(func() net.TaggedUnmarshaler {
+		return &inactivityClaimSignatureMessage{}
+	})
+
+	membershipValidator := group.NewMembershipValidator(
+		executorLogger,
+		wallet.signingGroupOperators,
+		n.chain.Signing(),
+	)
+
+	err = broadcastChannel.SetFilter(membershipValidator.IsInGroup)
+	if err != nil {
+		return nil, false, fmt.Errorf(
+			"could not set filter for channel [%v]: [%v]",
+			broadcastChannel.Name(),
+			err,
+		)
+	}
+
+	executor := newInactivityClaimExecutor(
+		n.chain,
+		signers,
+		broadcastChannel,
+		membershipValidator,
+		n.groupParameters,
+		n.waitForBlockHeight,
+	)
+
+	n.inactivityClaimExecutors[executorKey] = executor
+
+	executorLogger.Infof(
+		"inactivity claim executor created; controlling [%v] signers",
+		len(signers),
+	)
+
+	return executor, true, nil
+}
+
+// removeWalletExecutors removes signing, coordination, and inactivity claim
+// executors of the given wallet from the node's caches and closes their
+// broadcast channels. It should be called only for wallets the node no longer
+// controls, e.g. after they were archived.
+func (n *node) removeWalletExecutors(walletPublicKey *ecdsa.PublicKey) error {
+	walletPublicKeyBytes, err := marshalPublicKey(walletPublicKey)
+	if err != nil {
+		return fmt.Errorf("cannot marshal wallet public key: [%v]", err)
+	}
+
+	executorKey := hex.EncodeToString(walletPublicKeyBytes)
+
+	n.signingExecutorsMutex.Lock()
+	delete(n.signingExecutors, executorKey)
+	n.signingExecutorsMutex.Unlock()
+
+	n.coordinationExecutorsMutex.Lock()
+	delete(n.coordinationExecutors, executorKey)
+	n.coordinationExecutorsMutex.Unlock()
+
+	n.inactivityClaimExecutorsMutex.Lock()
+	delete(n.inactivityClaimExecutors, executorKey)
+	n.inactivityClaimExecutorsMutex.Unlock()
+
+	channelsNames := []string{
+		signingChannelName(walletPublicKeyBytes),
+		coordinationChannelName(walletPublicKeyBytes),
+		inactivityClaimChannelName(walletPublicKeyBytes),
+	}
+
+	for _, channelName := range channelsNames {
+		if err := n.netProvider.CloseBroadcastChannel(channelName); err != nil {
+			return fmt.Errorf(
+				"cannot close broadcast channel [%v]: [%v]",
+				channelName,
+				err,
+			)
+		}
+	}
+
+	return nil
+}
+
+// signingChannelName returns the name of the broadcast channel used by
+// the signing executor of the given wallet.
+func signingChannelName(walletPublicKeyBytes []byte) string {
+	return fmt.Sprintf(
+		"%s-%s",
+		ProtocolName,
+		hex.EncodeToString(walletPublicKeyBytes),
+	)
+}
+
+// coordinationChannelName returns the name of the broadcast channel used by
+// the coordination executor of the given wallet.
+func coordinationChannelName(walletPublicKeyBytes []byte) string {
+	return fmt.Sprintf(
+		"%s-%s-coordination",
+		ProtocolName,
+		hex.EncodeToString(walletPublicKeyBytes),
+	)
+}
+
+// inactivityClaimChannelName returns the name of the broadcast channel used
+// by the inactivity claim executor of the given wallet.
+func inactivityClaimChannelName(walletPublicKeyBytes []byte) string {
+	return fmt.Sprintf(
+		"%s-%s-inactivity",
+		ProtocolName,
+		hex.EncodeToString(walletPublicKeyBytes),
+	)
+}
+
 // handleHeartbeatRequest handles an incoming wallet heartbeat request.
 // First, it determines whether the node is supposed to do an action by checking
 // whether any of the request's target wallet signers are under the node's control.
@@ -452,6 +718,14 @@ func (n *node) handleHeartbeatRequest(
 		return
 	}
 
+	inactivityClaimExecutor, _, err := n.getInactivityClaimExecutor(
+		wallet.publicKey,
+	)
+	if err != nil {
+		logger.Errorf("cannot get inactivity claim executor: [%v]", err)
+		return
+	}
+
 	walletPublicKeyBytes, err := marshalPublicKey(wallet.publicKey)
 	if err != nil {
 		logger.Errorf("cannot marshal wallet public key: [%v]", err)
@@ -485,6 +759,8 @@ func (n *node) handleHeartbeatRequest(
 		message,
 		heartbeatRequestProcessingStartBlock,
 		requestExpiresAt,
+		n.heartbeatFailureCounter,
+		inactivityClaimExecutor,
 	)
 
 	err = n.walletDispatcher.dispatch(action)
@@ -496,6 +772,91 @@ func (n *node) handleHeartbeatRequest(
 	walletActionLogger.Infof("wallet action dispatched successfully")
 }
 
+// handleDepositRevealed validates the deposit revealed to a wallet against
+// the Bitcoin chain. Only deposits revealed to wallets controlled by the node
+// are validated as only those can be included in proposals generated by
+// the node.
+func (n *node) handleDepositRevealed(event *DepositRevealedEvent) {
+	if _, ok := n.walletRegistry.getWalletByPublicKeyHash(
+		event.WalletPublicKeyHash,
+	); !ok {
+		return
+	}
+
+	if err := n.depositRevealValidator.validate(event); err != nil {
+		logger.Errorf(
+			"cannot validate deposit [%s:%d] revealed to wallet PKH [0x%x]: [%v]",
+			event.FundingTxHash.Hex(bitcoin.ReversedByteOrder),
+			event.FundingOutputIndex,
+			event.WalletPublicKeyHash,
+			err,
+		)
+	}
+}
+
+// handleFraudChallengeSubmitted handles a fraud challenge submitted against
+// a wallet. If the node controls signers of the challenged wallet, it
+// attempts to defeat the challenge by revealing the preimage of the
+// challenged signature hash.
+func (n *node) handleFraudChallengeSubmitted(
+	ctx context.Context,
+	event *FraudChallengeSubmittedEvent,
+) {
+	wallet, ok := n.walletRegistry.getWalletByPublicKeyHash(
+		event.WalletPublicKeyHash,
+	)
+	if !ok {
+		logger.Infof(
+			"node does not control signers of wallet PKH [0x%x]; "+
+				"ignoring the fraud challenge",
+			event.WalletPublicKeyHash,
+		)
+		return
+	}
+
+	signers := n.walletRegistry.getSigners(wallet.publicKey)
+	if len(signers) == 0 {
+		logger.Infof(
+			"node does not control signers of wallet PKH [0x%x]; "+
+				"ignoring the fraud challenge",
+			event.WalletPublicKeyHash,
+		)
+		return
+	}
+
+	submitterMemberIndex := signers[0].signingGroupMemberIndex
+	for _, signer := range signers {
+		if signer.signingGroupMemberIndex < submitterMemberIndex {
+			submitterMemberIndex = signer.signingGroupMemberIndex
+		}
+	}
+
+	logger.Infof(
+		"node controls signers of wallet PKH [0x%x]; "+
+			"defending fraud challenge against sighash [0x%x]",
+		event.WalletPublicKeyHash,
+		event.Sighash,
+	)
+
+	defender := newFraudChallengeDefender(
+		n.chain,
+		n.btcChain,
+		n.signingAuditLog,
+		n.waitForBlockHeight,
+	)
+
+	err := defender.defend(ctx, wallet.publicKey, submitterMemberIndex, event)
+	if err != nil {
+		logger.Errorf(
+			"cannot defend fraud challenge against sighash [0x%x] "+
+				"of wallet PKH [0x%x]: [%v]",
+			event.Sighash,
+			event.WalletPublicKeyHash,
+			err,
+		)
+	}
+}
+
 // handleDepositSweepProposal handles an incoming deposit sweep proposal.
 // First, it determines whether the node is supposed to do an action by checking
 // whether any of the proposal's target wallet signers are under node's control.
@@ -569,6 +930,7 @@ func (n *node) handleDepositSweepProposal(
 		n.btcChain,
 		wallet,
 		signingExecutor,
+		n.pendingWalletTransactions,
 		proposal,
 		proposalProcessingStartBlock,
 		proposalExpiresAt,
@@ -656,6 +1018,272 @@ func (n *node) handleRedemptionProposal(
 		n.btcChain,
 		wallet,
 		signingExecutor,
+		n.pendingWalletTransactions,
+		proposal,
+		proposalProcessingStartBlock,
+		proposalExpiresAt,
+	)
+
+	err = n.walletDispatcher.dispatch(action)
+	if err != nil {
+		walletActionLogger.Errorf("cannot dispatch wallet action: [%v]", err)
+		return
+	}
+
+	walletActionLogger.Infof("wallet action dispatched successfully")
+}
+
+// handleMovingFundsProposal handles an incoming moving funds proposal.
+// First, it determines whether the node is supposed to do an action by checking
+// whether any of the proposal's target wallet signers are under node's control.
+// If so, this function orchestrates and dispatches an appropriate wallet action.
+func (n *node) handleMovingFundsProposal(
+	proposal *MovingFundsProposal,
+	proposalExpiresAt time.Time,
+	startBlock uint64,
+	delayBlocks uint64,
+) {
+	wallet, ok := n.walletRegistry.getWalletByPublicKeyHash(
+		proposal.WalletPublicKeyHash,
+	)
+	if !ok {
+		logger.Infof(
+			"node does not control signers of wallet PKH [0x%x]; "+
+				"ignoring the received moving funds proposal",
+			proposal.WalletPublicKeyHash,
+		)
+		return
+	}
+
+	signingExecutor, ok, err := n.getSigningExecutor(wallet.publicKey)
+	if err != nil {
+		logger.Errorf("cannot get signing executor: [%v]", err)
+		return
+	}
+	// This check is actually redundant. We know the node controls some
+	// wallet signers as we just got the wallet from the registry using their
+	// public key hash. However, we are doing it just in case. The API
+	// contract of getWalletByPublicKeyHash and/or getSigningExecutor may
+	// change one day.
+	if !ok {
+		logger.Infof(
+			"node does not control signers of wallet PKH [0x%x]; "+
+				"ignoring the received moving funds proposal",
+			proposal.WalletPublicKeyHash,
+		)
+		return
+	}
+
+	walletPublicKeyBytes, err := marshalPublicKey(wallet.publicKey)
+	if err != nil {
+		logger.Errorf("cannot marshal wallet public key: [%v]", err)
+		return
+	}
+
+	logger.Infof(
+		"node controls signers of wallet PKH [0x%x]; "+
+			"plain-text uncompressed public key of that wallet is [0x%x]; "+
+			"starting orchestration of the moving funds action",
+		proposal.WalletPublicKeyHash,
+		walletPublicKeyBytes,
+	)
+
+	// The proposal's processing started after a confirmation period represented
+	// by the delayBlocks parameter. Hence, we must add it to the original
+	// startBlock.
+	proposalProcessingStartBlock := startBlock + delayBlocks
+
+	walletActionLogger := logger.With(
+		zap.String("wallet", fmt.Sprintf("0x%x", walletPublicKeyBytes)),
+		zap.String("action", ActionMovingFunds.String()),
+		zap.Uint64("startBlock", proposalProcessingStartBlock),
+	)
+	walletActionLogger.Infof("dispatching wallet action")
+
+	action := newMovingFundsAction(
+		walletActionLogger,
+		n.chain,
+		n.btcChain,
+		wallet,
+		signingExecutor,
+		n.pendingWalletTransactions,
+		proposal,
+		proposalProcessingStartBlock,
+		proposalExpiresAt,
+	)
+
+	err = n.walletDispatcher.dispatch(action)
+	if err != nil {
+		walletActionLogger.Errorf("cannot dispatch wallet action: [%v]", err)
+		return
+	}
+
+	walletActionLogger.Infof("wallet action dispatched successfully")
+}
+
+// handleMovedFundsSweepProposal handles an incoming moved funds sweep proposal.
+// First, it determines whether the node is supposed to do an action by checking
+// whether any of the proposal's target wallet signers are under node's control.
+// If so, this function orchestrates and dispatches an appropriate wallet action.
+func (n *node) handleMovedFundsSweepProposal(
+	proposal *MovedFundsSweepProposal,
+	proposalExpiresAt time.Time,
+	startBlock uint64,
+	delayBlocks uint64,
+) {
+	wallet, ok := n.walletRegistry.getWalletByPublicKeyHash(
+		proposal.WalletPublicKeyHash,
+	)
+	if !ok {
+		logger.Infof(
+			"node does not control signers of wallet PKH [0x%x]; "+
+				"ignoring the received moved funds sweep proposal",
+			proposal.WalletPublicKeyHash,
+		)
+		return
+	}
+
+	signingExecutor, ok, err := n.getSigningExecutor(wallet.publicKey)
+	if err != nil {
+		logger.Errorf("cannot get signing executor: [%v]", err)
+		return
+	}
+	// This check is actually redundant. We know the node controls some
+	// wallet signers as we just got the wallet from the registry using their
+	// public key hash. However, we are doing it just in case. The API
+	// contract of getWalletByPublicKeyHash and/or getSigningExecutor may
+	// change one day.
+	if !ok {
+		logger.Infof(
+			"node does not control signers of wallet PKH [0x%x]; "+
+				"ignoring the received moved funds sweep proposal",
+			proposal.WalletPublicKeyHash,
+		)
+		return
+	}
+
+	walletPublicKeyBytes, err := marshalPublicKey(wallet.publicKey)
+	if err != nil {
+		logger.Errorf("cannot marshal wallet public key: [%v]", err)
+		return
+	}
+
+	logger.Infof(
+		"node controls signers of wallet PKH [0x%x]; "+
+			"plain-text uncompressed public key of that wallet is [0x%x]; "+
+			"starting orchestration of the moved funds sweep action",
+		proposal.WalletPublicKeyHash,
+		walletPublicKeyBytes,
+	)
+
+	// The proposal's processing started after a confirmation period represented
+	// by the delayBlocks parameter. Hence, we must add it to the original
+	// startBlock.
+	proposalProcessingStartBlock := startBlock + delayBlocks
+
+	walletActionLogger := logger.With(
+		zap.String("wallet", fmt.Sprintf("0x%x", walletPublicKeyBytes)),
+		zap.String("action", ActionMovedFundsSweep.String()),
+		zap.Uint64("startBlock", proposalProcessingStartBlock),
+	)
+	walletActionLogger.Infof("dispatching wallet action")
+
+	action := newMovedFundsSweepAction(
+		walletActionLogger,
+		n.chain,
+		n.btcChain,
+		wallet,
+		signingExecutor,
+		n.pendingWalletTransactions,
+		proposal,
+		proposalProcessingStartBlock,
+		proposalExpiresAt,
+	)
+
+	err = n.walletDispatcher.dispatch(action)
+	if err != nil {
+		walletActionLogger.Errorf("cannot dispatch wallet action: [%v]", err)
+		return
+	}
+
+	walletActionLogger.Infof("wallet action dispatched successfully")
+}
+
+// handleFeeBumpProposal handles an incoming fee bump proposal. First, it
+// determines whether the node is supposed to do an action by checking whether
+// any of the proposal's target wallet signers are under node's control. If so,
+// this function orchestrates and dispatches an appropriate wallet action.
+func (n *node) handleFeeBumpProposal(
+	walletPublicKeyHash [20]byte,
+	proposal *FeeBumpProposal,
+	proposalExpiresAt time.Time,
+	startBlock uint64,
+	delayBlocks uint64,
+) {
+	wallet, ok := n.walletRegistry.getWalletByPublicKeyHash(
+		walletPublicKeyHash,
+	)
+	if !ok {
+		logger.Infof(
+			"node does not control signers of wallet PKH [0x%x]; "+
+				"ignoring the received fee bump proposal",
+			walletPublicKeyHash,
+		)
+		return
+	}
+
+	signingExecutor, ok, err := n.getSigningExecutor(wallet.publicKey)
+	if err != nil {
+		logger.Errorf("cannot get signing executor: [%v]", err)
+		return
+	}
+	// This check is actually redundant. We know the node controls some
+	// wallet signers as we just got the wallet from the registry using their
+	// public key hash. However, we are doing it just in case. The API
+	// contract of getWalletByPublicKeyHash and/or getSigningExecutor may
+	// change one day.
+	if !ok {
+		logger.Infof(
+			"node does not control signers of wallet PKH [0x%x]; "+
+				"ignoring the received fee bump proposal",
+			walletPublicKeyHash,
+		)
+		return
+	}
+
+	walletPublicKeyBytes, err := marshalPublicKey(wallet.publicKey)
+	if err != nil {
+		logger.Errorf("cannot marshal wallet public key: [%v]", err)
+		return
+	}
+
+	logger.Infof(
+		"node controls signers of wallet PKH [0x%x]; "+
+			"plain-text uncompressed public key of that wallet is [0x%x]; "+
+			"starting orchestration of the fee bump action",
+		walletPublicKeyHash,
+		walletPublicKeyBytes,
+	)
+
+	// The proposal's processing started after a confirmation period represented
+	// by the delayBlocks parameter. Hence, we must add it to the original
+	// startBlock.
+	proposalProcessingStartBlock := startBlock + delayBlocks
+
+	walletActionLogger := logger.With(
+		zap.String("wallet", fmt.Sprintf("0x%x", walletPublicKeyBytes)),
+		zap.String("action", ActionFeeBump.String()),
+		zap.Uint64("startBlock", proposalProcessingStartBlock),
+	)
+	walletActionLogger.Infof("dispatching wallet action")
+
+	action := newFeeBumpAction(
+		walletActionLogger,
+		n.chain,
+		n.btcChain,
+		wallet,
+		signingExecutor,
+		n.pendingWalletTransactions,
 		proposal,
 		proposalProcessingStartBlock,
 		proposalExpiresAt,
@@ -814,21 +1442,150 @@ func executeCoordinationProcedure(
 func processCoordinationResult(node *node, result *coordinationResult) {
 	logger.Infof("processing coordination result [%s]", result)
 
-	// TODO: Record coordination faults.
+	walletPublicKeyHash := bitcoin.PublicKeyHash(result.wallet.publicKey)
+
+	// Record coordination faults first. Faults may be present even if
+	// no action is going to be executed, e.g. when the leader was idle.
+	if len(result.faults) > 0 {
+		err := node.coordinationFaultRegistry.recordFaults(
+			result.window.index(),
+			walletPublicKeyHash,
+			result.faults,
+		)
+		if err != nil {
+			logger.Errorf(
+				"cannot record faults of coordination result [%s]: [%v]",
+				result,
+				err,
+			)
+		}
+	}
+
+	// Just in case, make sure the proposal is set.
+	if result.proposal == nil {
+		logger.Errorf("no proposal in coordination result [%s]", result)
+		return
+	}
+
+	// There is nothing to execute if the leader did not propose any action.
+	if result.proposal.actionType() == ActionNoop {
+		logger.Infof("no action proposed in coordination result [%s]", result)
+		return
+	}
+
+	// The proposed wallet action starts right after the end of the
+	// coordination window's passive phase, i.e. at the window's end block.
+	// The handlers add delayBlocks to the start block so, we pass zero as
+	// no additional confirmation period is needed here.
+	startBlock := result.window.endBlock()
+	delayBlocks := uint64(0)
+
+	expiresAt, err := node.estimateProposalExpiresAt(
+		startBlock,
+		result.proposal.validityBlocks(),
+	)
+	if err != nil {
+		logger.Errorf(
+			"cannot estimate proposal expiration time for "+
+				"coordination result [%s]: [%v]",
+			result,
+			err,
+		)
+		return
+	}
+
+	// TODO: Remove once the WalletPublicKeyHash field is removed from
+	//       proposals. Proposals received from the coordination leader
+	//       do not carry it.
+	if proposal, ok := result.proposal.(walletPublicKeyHashSetter); ok {
+		proposal.setWalletPublicKeyHash(walletPublicKeyHash)
+	}
 
-	// TODO: Detect proposal type and run the appropriate handler.
-	switch result.proposal.actionType() {
-	case ActionHeartbeat:
-		// node.handleHeartbeatRequest()
-	case ActionDepositSweep:
-		// node.handleDepositSweepProposal()
-	case ActionRedemption:
-		// node.handleRedemptionProposal()
+	switch proposal := result.proposal.(type) {
+	case *HeartbeatProposal:
+		node.handleHeartbeatRequest(
+			walletPublicKeyHash,
+			proposal.Message,
+			expiresAt,
+			startBlock,
+			delayBlocks,
+		)
+	case *DepositSweepProposal:
+		node.handleDepositSweepProposal(
+			proposal,
+			expiresAt,
+			startBlock,
+			delayBlocks,
+		)
+	case *RedemptionProposal:
+		node.handleRedemptionProposal(
+			proposal,
+			expiresAt,
+			startBlock,
+			delayBlocks,
+		)
+	case *MovingFundsProposal:
+		node.handleMovingFundsProposal(
+			proposal,
+			expiresAt,
+			startBlock,
+			delayBlocks,
+		)
+	case *MovedFundsSweepProposal:
+		node.handleMovedFundsSweepProposal(
+			proposal,
+			expiresAt,
+			startBlock,
+			delayBlocks,
+		)
+	case *FeeBumpProposal:
+		node.handleFeeBumpProposal(
+			walletPublicKeyHash,
+			proposal,
+			expiresAt,
+			startBlock,
+			delayBlocks,
+		)
 	default:
 		logger.Errorf("no handler for coordination result [%s]", result)
 	}
 }
 
+// estimateProposalExpiresAt estimates the point in time the proposal that
+// starts at the given block and is valid for the given number of blocks
+// expires at. The estimation is based on the current block and the
+// coordinationAverageBlockTime. Wallet actions operate on time-based
+// deadlines so, the proposal validity expressed in blocks must be
+// converted to a point in time.
+func (n *node) estimateProposalExpiresAt(
+	startBlock uint64,
+	validityBlocks uint64,
+) (time.Time, error) {
+	blockCounter, err := n.chain.BlockCounter()
+	if err != nil {
+		return time.Time{}, fmt.Errorf("cannot get block counter: [%v]", err)
+	}
+
+	currentBlock, err := blockCounter.CurrentBlock()
+	if err != nil {
+		return time.Time{}, fmt.Errorf("cannot get current block: [%v]", err)
+	}
+
+	expiryBlock := startBlock + validityBlocks
+
+	// The proposal may have already expired if the result is processed
+	// with a significant delay.
+	if currentBlock >= expiryBlock {
+		return time.Now(), nil
+	}
+
+	remainingBlocks := expiryBlock - currentBlock
+
+	return time.Now().Add(
+		time.Duration(remainingBlocks) * coordinationAverageBlockTime,
+	), nil
+}
+
 // waitForBlockFn represents a function blocking the execution until the given
 // block height.
 type waitForBlockFn func(context.Context, uint64) error
@@ -5,6 +5,7 @@ import (
 	"crypto/ecdsa"
 	"encoding/hex"
 	"fmt"
+	"math/big"
 	"reflect"
 	"testing"
 	"time"
@@ -43,6 +44,7 @@ func TestNode_GetSigningExecutor(t *testing.T) {
 		keyStorePersistence,
 		&mockPersistenceHandle{},
 		generator.StartScheduler(),
+		&mockCoordinationProposalGenerator{},
 		Config{},
 	)
 	if err != nil {
@@ -160,6 +162,7 @@ func TestNode_GetCoordinationExecutor(t *testing.T) {
 		keyStorePersistence,
 		&mockPersistenceHandle{},
 		generator.StartScheduler(),
+		&mockCoordinationProposalGenerator{},
 		Config{},
 	)
 	if err != nil {
@@ -282,6 +285,7 @@ func TestNode_RunCoordinationLayer(t *testing.T) {
 		keyStorePersistence,
 		&mockPersistenceHandle{},
 		generator.StartScheduler(),
+		&mockCoordinationProposalGenerator{},
 		Config{},
 	)
 	if err != nil {
@@ -399,6 +403,225 @@ loop:
 	)
 }
 
+func TestNode_EstimateProposalExpiresAt(t *testing.T) {
+	// Use a long block time to make sure the current block does not
+	// change during the test. The local chain starts at block 0.
+	localChain := Connect(1 * time.Hour)
+
+	n := &node{
+		chain: localChain,
+	}
+
+	var tests = map[string]struct {
+		startBlock        uint64
+		validityBlocks    uint64
+		expectedRemaining time.Duration
+	}{
+		"proposal not expired yet": {
+			startBlock:        100,
+			validityBlocks:    300,
+			expectedRemaining: 400 * coordinationAverageBlockTime,
+		},
+		"proposal already expired": {
+			startBlock:        0,
+			validityBlocks:    0,
+			expectedRemaining: 0,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			before := time.Now()
+
+			expiresAt, err := n.estimateProposalExpiresAt(
+				test.startBlock,
+				test.validityBlocks,
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			after := time.Now()
+
+			if expiresAt.Before(before.Add(test.expectedRemaining)) ||
+				expiresAt.After(after.Add(test.expectedRemaining)) {
+				t.Errorf(
+					"unexpected expiration time\n"+
+						"expected: between [%v] and [%v]\n"+
+						"actual:   [%v]",
+					before.Add(test.expectedRemaining),
+					after.Add(test.expectedRemaining),
+					expiresAt,
+				)
+			}
+		})
+	}
+}
+
+func TestProcessCoordinationResult(t *testing.T) {
+	groupParameters := &GroupParameters{
+		GroupSize:       5,
+		GroupQuorum:     4,
+		HonestThreshold: 3,
+	}
+
+	signer := createMockSigner(t)
+
+	window := newCoordinationWindow(900)
+
+	var tests = map[string]struct {
+		proposal           CoordinationProposal
+		expectedActionType WalletActionType
+		expectedDispatch   bool
+	}{
+		"heartbeat proposal": {
+			proposal: &HeartbeatProposal{
+				Message: []byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff},
+			},
+			expectedActionType: ActionHeartbeat,
+			expectedDispatch:   true,
+		},
+		"deposit sweep proposal": {
+			proposal: &DepositSweepProposal{
+				SweepTxFee: big.NewInt(10000),
+			},
+			expectedActionType: ActionDepositSweep,
+			expectedDispatch:   true,
+		},
+		"redemption proposal": {
+			proposal: &RedemptionProposal{
+				RedemptionTxFee: big.NewInt(10000),
+			},
+			expectedActionType: ActionRedemption,
+			expectedDispatch:   true,
+		},
+		"moving funds proposal": {
+			proposal: &MovingFundsProposal{
+				MovingFundsTxFee: big.NewInt(10000),
+			},
+			expectedActionType: ActionMovingFunds,
+			expectedDispatch:   true,
+		},
+		"moved funds sweep proposal": {
+			proposal: &MovedFundsSweepProposal{
+				SweepTxFee: big.NewInt(10000),
+			},
+			expectedActionType: ActionMovedFundsSweep,
+			expectedDispatch:   true,
+		},
+		"fee bump proposal": {
+			proposal: &FeeBumpProposal{
+				TransactionFee: big.NewInt(10000),
+			},
+			expectedActionType: ActionFeeBump,
+			expectedDispatch:   true,
+		},
+		"noop proposal": {
+			proposal:         &NoopProposal{},
+			expectedDispatch: false,
+		},
+		"unknown proposal": {
+			proposal:         &mockCoordinationProposal{ActionHeartbeat},
+			expectedDispatch: false,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			// Use a long block time to make sure the current block does not
+			// change during the test. The local chain starts at block 0.
+			localChain := Connect(1 * time.Hour)
+
+			// Populate the mock keystore with the mock signer's data. This is
+			// required to make the node controlling the signer's wallet.
+			keyStorePersistence := createMockKeyStorePersistence(t, signer)
+
+			node, err := newNode(
+				groupParameters,
+				localChain,
+				newLocalBitcoinChain(),
+				local.Connect(),
+				keyStorePersistence,
+				&mockPersistenceHandle{},
+				generator.StartScheduler(),
+				&mockCoordinationProposalGenerator{},
+				Config{},
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			walletPublicKeyBytes, err := marshalPublicKey(signer.wallet.publicKey)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// Mark the wallet as busy so the dispatched action is queued
+			// instead of being executed. This way, the action can be
+			// inspected.
+			key := hex.EncodeToString(walletPublicKeyBytes)
+			node.walletDispatcher.actions[key] = ActionNoop
+
+			before := time.Now()
+
+			processCoordinationResult(node, &coordinationResult{
+				wallet:   signer.wallet,
+				window:   window,
+				leader:   "leader",
+				proposal: test.proposal,
+			})
+
+			after := time.Now()
+
+			queue := node.walletDispatcher.queues[key]
+
+			if !test.expectedDispatch {
+				testutils.AssertIntsEqual(t, "queued actions", 0, len(queue))
+				return
+			}
+
+			testutils.AssertIntsEqual(t, "queued actions", 1, len(queue))
+
+			action := queue[0].action
+
+			if test.expectedActionType != action.actionType() {
+				t.Errorf(
+					"unexpected action type\n"+
+						"expected: [%v]\n"+
+						"actual:   [%v]",
+					test.expectedActionType,
+					action.actionType(),
+				)
+			}
+
+			testutils.AssertIntsEqual(
+				t,
+				"signing timeout block",
+				int(signingTimeoutBlock(window.endBlock())),
+				int(action.signingTimeoutBlock()),
+			)
+
+			// The current block is 0 so, the proposal expires after all
+			// blocks up to the end of its validity period are mined.
+			expectedRemaining := time.Duration(
+				window.endBlock()+test.proposal.validityBlocks(),
+			) * coordinationAverageBlockTime
+
+			if action.expiresAt().Before(before.Add(expectedRemaining)) ||
+				action.expiresAt().After(after.Add(expectedRemaining)) {
+				t.Errorf(
+					"unexpected expiration time\n"+
+						"expected: between [%v] and [%v]\n"+
+						"actual:   [%v]",
+					before.Add(expectedRemaining),
+					after.Add(expectedRemaining),
+					action.expiresAt(),
+				)
+			}
+		})
+	}
+}
+
 type mockCoordinationProposal struct {
 	action WalletActionType
 }
@@ -408,7 +631,7 @@ func (mcp *mockCoordinationProposal) actionType() WalletActionType {
 }
 
 func (mcp *mockCoordinationProposal) validityBlocks() uint64 {
-	panic("unsupported")
+	return 0
 }
 
 func (mcp *mockCoordinationProposal) Marshal() ([]byte, error) {
@@ -492,3 +715,11 @@ func createMockKeyStorePersistence(
 		saved: descriptors,
 	}
 }
+
+type mockCoordinationProposalGenerator struct{}
+
+func (mcpg *mockCoordinationProposalGenerator) Generate(
+	request *CoordinationProposalRequest,
+) (CoordinationProposal, error) {
+	return &NoopProposal{}, nil
+}
@@ -21,10 +21,6 @@ const (
 	// another actions. The value of 600 blocks is roughly 2 hours, assuming
 	// 12 seconds per block.
 	redemptionProposalValidityBlocks = 600
-	// redemptionProposalConfirmationBlocks determines the block length of the
-	// confirmation period on the host chain that is preserved after a
-	// redemption proposal submission.
-	redemptionProposalConfirmationBlocks = 20
 	// redemptionSigningTimeoutSafetyMargin determines the duration of the
 	// safety margin that must be preserved between the signing timeout
 	// and the timeout of the entire redemption action. This safety
@@ -68,6 +64,20 @@ func (rp *RedemptionProposal) validityBlocks() uint64 {
 	return redemptionProposalValidityBlocks
 }
 
+func (rp *RedemptionProposal) setWalletPublicKeyHash(walletPublicKeyHash [20]byte) {
+	rp.WalletPublicKeyHash = walletPublicKeyHash
+}
+
+func (rp *RedemptionProposal) transactionFee() int64 {
+	return rp.RedemptionTxFee.Int64()
+}
+
+func (rp *RedemptionProposal) withTransactionFee(fee int64) walletTransactionProposal {
+	proposal := *rp
+	proposal.RedemptionTxFee = big.NewInt(fee)
+	return &proposal
+}
+
 // RedemptionTransactionShape is an enum describing the shape of
 // a Bitcoin redemption transaction.
 type RedemptionTransactionShape uint8
@@ -131,6 +141,7 @@ func newRedemptionAction(
 	btcChain bitcoin.Chain,
 	redeemingWallet wallet,
 	signingExecutor walletSigningExecutor,
+	pendingTransactions *pendingWalletTransactions,
 	proposal *RedemptionProposal,
 	proposalProcessingStartBlock uint64,
 	proposalExpiresAt time.Time,
@@ -139,6 +150,8 @@ func newRedemptionAction(
 		btcChain,
 		redeemingWallet,
 		signingExecutor,
+		ActionRedemption,
+		pendingTransactions,
 	)
 
 	feeDistribution := withRedemptionTotalFee(proposal.RedemptionTxFee.Int64())
@@ -227,16 +240,24 @@ func (ra *redemptionAction) execute() error {
 		zap.String("step", "signTransaction"),
 	)
 
+	signingTimesOutAt := ra.proposalExpiresAt.Add(-ra.signingTimeoutSafetyMargin)
+
 	redemptionTx, err := ra.transactionExecutor.signTransaction(
 		signTxLogger,
 		unsignedRedemptionTx,
 		ra.proposalProcessingStartBlock,
-		ra.proposalExpiresAt.Add(-ra.signingTimeoutSafetyMargin),
+		signingTimesOutAt,
 	)
 	if err != nil {
 		return fmt.Errorf("sign transaction step failed: [%v]", err)
 	}
 
+	ra.transactionExecutor.recordPendingTransaction(
+		redemptionTx,
+		walletMainUtxo,
+		ra.proposal,
+	)
+
 	broadcastTxLogger := ra.logger.With(
 		zap.String("step", "broadcastTransaction"),
 		zap.String("redemptionTxHash", redemptionTx.Hash().Hex(bitcoin.ReversedByteOrder)),
@@ -326,6 +347,14 @@ func (ra *redemptionAction) actionType() WalletActionType {
 	return ActionRedemption
 }
 
+func (ra *redemptionAction) expiresAt() time.Time {
+	return ra.proposalExpiresAt
+}
+
+func (ra *redemptionAction) signingTimeoutBlock() uint64 {
+	return signingTimeoutBlock(ra.proposalProcessingStartBlock)
+}
+
 // redemptionFeeDistributionFn calculates the redemption transaction fee
 // distribution for the given redemption requests. The resulting list
 // contains the fee shares ordered in the same way as the input requests, i.e.
@@ -0,0 +1,352 @@
+package tbtc
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/keep-network/keep-core/pkg/bitcoin"
+)
+
+const (
+	// redemptionMonitorInterval determines how often pending redemption
+	// requests of wallets controlled by the node are checked.
+	redemptionMonitorInterval = 10 * time.Minute
+	// redemptionAtRiskTimeoutDivisor determines when a pending redemption
+	// request is considered at risk of timing out. A request is at risk once
+	// the time left until its timeout drops to 1/redemptionAtRiskTimeoutDivisor
+	// of the redemption timeout.
+	redemptionAtRiskTimeoutDivisor = 2
+	// redemptionCriticalTimeoutDivisor determines when a pending redemption
+	// request is considered critical. A request is critical once the time
+	// left until its timeout drops to 1/redemptionCriticalTimeoutDivisor of
+	// the redemption timeout.
+	redemptionCriticalTimeoutDivisor = 4
+	// redemptionMonitorLookbackMarginBlocks is a margin added to the
+	// redemption timeout estimated in blocks, in order to not omit requests
+	// on the edge of the lookback block range if the actual average block
+	// time is lesser than the assumed one.
+	redemptionMonitorLookbackMarginBlocks = 1000
+)
+
+// redemptionRequestTimeLeft holds the time left until the timeout of
+// a single pending redemption request.
+type redemptionRequestTimeLeft struct {
+	walletPublicKeyHash  [20]byte
+	redeemerOutputScript bitcoin.Script
+	requestedAt          time.Time
+	timeLeft             time.Duration
+}
+
+// redemptionWalletRisk holds the numbers of pending redemption requests
+// of a single wallet that are at risk of timing out.
+type redemptionWalletRisk struct {
+	// atRisk is the number of requests whose time left dropped below the
+	// at-risk threshold, including the critical ones.
+	atRisk int
+	// critical is the number of requests whose time left dropped below the
+	// critical threshold, including the already timed out ones.
+	critical int
+}
+
+// redemptionTimeoutMonitor is the component that tracks how close pending
+// redemption requests of wallets controlled by the node are to their
+// timeout. Unhandled requests cause slashing of the wallet's operators once
+// they time out so, wallets with requests at risk get their redemptions
+// prioritized during coordination. All functions of the monitor are safe
+// for concurrent use.
+type redemptionTimeoutMonitor struct {
+	chain Chain
+
+	mutex sync.Mutex
+	// walletsRisks holds the outcome of the latest check, keyed by the
+	// wallet public key hash. Only wallets having at least one request at
+	// risk are present.
+	walletsRisks map[[20]byte]*redemptionWalletRisk
+}
+
+func newRedemptionTimeoutMonitor(chain Chain) *redemptionTimeoutMonitor {
+	return &redemptionTimeoutMonitor{
+		chain:        chain,
+		walletsRisks: make(map[[20]byte]*redemptionWalletRisk),
+	}
+}
+
+// check computes the time left for all pending redemption requests of the
+// given wallets and updates the monitor's state. Requests at risk are
+// logged as warnings and critical ones as errors.
+func (rtm *redemptionTimeoutMonitor) check(
+	walletsPublicKeyHashes [][20]byte,
+	now time.Time,
+) error {
+	if len(walletsPublicKeyHashes) == 0 {
+		rtm.update(make(map[[20]byte]*redemptionWalletRisk))
+		return nil
+	}
+
+	_, _, _, _, timeoutSeconds, _, _, err := rtm.chain.GetRedemptionParameters()
+	if err != nil {
+		return fmt.Errorf("cannot get redemption parameters: [%v]", err)
+	}
+
+	timeout := time.Duration(timeoutSeconds) * time.Second
+
+	requests, err := rtm.pendingRequestsTimeLeft(
+		walletsPublicKeyHashes,
+		timeout,
+		now,
+	)
+	if err != nil {
+		return err
+	}
+
+	atRiskThreshold := timeout / redemptionAtRiskTimeoutDivisor
+	criticalThreshold := timeout / redemptionCriticalTimeoutDivisor
+
+	walletsRisks := make(map[[20]byte]*redemptionWalletRisk)
+	for _, request := range requests {
+		if request.timeLeft > atRiskThreshold {
+			continue
+		}
+
+		risk, ok := walletsRisks[request.walletPublicKeyHash]
+		if !ok {
+			risk = &redemptionWalletRisk{}
+			walletsRisks[request.walletPublicKeyHash] = risk
+		}
+
+		risk.atRisk++
+
+		if request.timeLeft > criticalThreshold {
+			logger.Warnf(
+				"redemption request of wallet PKH [0x%x] for output "+
+					"script [0x%x] requested at [%s] is at risk; "+
+					"it times out in [%v]",
+				request.walletPublicKeyHash,
+				request.redeemerOutputScript,
+				request.requestedAt.UTC().Format(time.RFC3339),
+				request.timeLeft.Round(time.Second),
+			)
+			continue
+		}
+
+		risk.critical++
+
+		logger.Errorf(
+			"redemption request of wallet PKH [0x%x] for output "+
+				"script [0x%x] requested at [%s] is critical; "+
+				"it times out in [%v] and wallet operators will be "+
+				"slashed if it is not handled",
+			request.walletPublicKeyHash,
+			request.redeemerOutputScript,
+			request.requestedAt.UTC().Format(time.RFC3339),
+			request.timeLeft.Round(time.Second),
+		)
+	}
+
+	rtm.update(walletsRisks)
+
+	return nil
+}
+
+// pendingRequestsTimeLeft determines the time left until the timeout for
+// all pending redemption requests of the given wallets.
+func (rtm *redemptionTimeoutMonitor) pendingRequestsTimeLeft(
+	walletsPublicKeyHashes [][20]byte,
+	timeout time.Duration,
+	now time.Time,
+) ([]*redemptionRequestTimeLeft, error) {
+	blockCounter, err := rtm.chain.BlockCounter()
+	if err != nil {
+		return nil, fmt.Errorf("cannot get block counter: [%v]", err)
+	}
+
+	currentBlock, err := blockCounter.CurrentBlock()
+	if err != nil {
+		return nil, fmt.Errorf("cannot get current block: [%v]", err)
+	}
+
+	// Requests older than the redemption timeout are timed out and no
+	// longer pending so, there is no need to look further back. The lookback
+	// is estimated in blocks using the assumed average block time.
+	lookbackBlocks := uint64(timeout/coordinationAverageBlockTime) +
+		redemptionMonitorLookbackMarginBlocks
+
+	startBlock := uint64(0)
+	if currentBlock > lookbackBlocks {
+		startBlock = currentBlock - lookbackBlocks
+	}
+
+	events, err := rtm.chain.PastRedemptionRequestedEvents(
+		&RedemptionRequestedEventFilter{
+			StartBlock:          startBlock,
+			WalletPublicKeyHash: walletsPublicKeyHashes,
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"cannot get past redemption requested events: [%v]",
+			err,
+		)
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].BlockNumber < events[j].BlockNumber
+	})
+
+	// There may be multiple events for the same wallet and redeemer output
+	// script pair but only one request can be pending at the same time.
+	// Events are sorted from the oldest to the newest so, the latest event
+	// for the given pair wins.
+	latestEvents := make(map[string]*RedemptionRequestedEvent)
+	for _, event := range events {
+		key := hex.EncodeToString(event.WalletPublicKeyHash[:]) +
+			hex.EncodeToString(event.RedeemerOutputScript)
+		latestEvents[key] = event
+	}
+
+	result := make([]*redemptionRequestTimeLeft, 0)
+	for _, event := range latestEvents {
+		request, found, err := rtm.chain.GetPendingRedemptionRequest(
+			event.WalletPublicKeyHash,
+			event.RedeemerOutputScript,
+		)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"cannot get pending redemption request: [%v]",
+				err,
+			)
+		}
+
+		if !found {
+			continue
+		}
+
+		result = append(result, &redemptionRequestTimeLeft{
+			walletPublicKeyHash:  event.WalletPublicKeyHash,
+			redeemerOutputScript: event.RedeemerOutputScript,
+			requestedAt:          request.RequestedAt,
+			timeLeft:             request.RequestedAt.Add(timeout).Sub(now),
+		})
+	}
+
+	return result, nil
+}
+
+// update replaces the monitor's state with the given wallets risks.
+func (rtm *redemptionTimeoutMonitor) update(
+	walletsRisks map[[20]byte]*redemptionWalletRisk,
+) {
+	rtm.mutex.Lock()
+	defer rtm.mutex.Unlock()
+
+	rtm.walletsRisks = walletsRisks
+}
+
+// isAtRisk returns true if the given wallet had at least one pending
+// redemption request at risk of timing out during the latest check.
+func (rtm *redemptionTimeoutMonitor) isAtRisk(walletPublicKeyHash [20]byte) bool {
+	rtm.mutex.Lock()
+	defer rtm.mutex.Unlock()
+
+	_, ok := rtm.walletsRisks[walletPublicKeyHash]
+	return ok
+}
+
+// atRiskCount returns the total number of pending redemption requests
+// at risk of timing out, including the critical ones.
+func (rtm *redemptionTimeoutMonitor) atRiskCount() int {
+	rtm.mutex.Lock()
+	defer rtm.mutex.Unlock()
+
+	count := 0
+	for _, risk := range rtm.walletsRisks {
+		count += risk.atRisk
+	}
+
+	return count
+}
+
+// criticalCount returns the total number of critical pending redemption
+// requests.
+func (rtm *redemptionTimeoutMonitor) criticalCount() int {
+	rtm.mutex.Lock()
+	defer rtm.mutex.Unlock()
+
+	count := 0
+	for _, risk := range rtm.walletsRisks {
+		count += risk.critical
+	}
+
+	return count
+}
+
+// walletsAtRisk returns a snapshot of at-risk and critical request counters
+// for all wallets having requests at risk, keyed by the wallet public key
+// hash hex string.
+func (rtm *redemptionTimeoutMonitor) walletsAtRisk() map[string]map[string]int {
+	rtm.mutex.Lock()
+	defer rtm.mutex.Unlock()
+
+	result := make(map[string]map[string]int)
+	for walletPublicKeyHash, risk := range rtm.walletsRisks {
+		result[fmt.Sprintf("0x%x", walletPublicKeyHash)] = map[string]int{
+			"at_risk":  risk.atRisk,
+			"critical": risk.critical,
+		}
+	}
+
+	return result
+}
+
+// run periodically checks pending redemption requests of wallets returned
+// by the given function, until the given context is done.
+func (rtm *redemptionTimeoutMonitor) run(
+	ctx context.Context,
+	walletsPublicKeyHashesFn func() [][20]byte,
+) {
+	ticker := time.NewTicker(redemptionMonitorInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := rtm.check(walletsPublicKeyHashesFn(), time.Now()); err != nil {
+			logger.Errorf(
+				"cannot check pending redemption requests: [%v]",
+				err,
+			)
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// prioritizeRedemption returns a copy of the given actions checklist with
+// the redemption action moved to the front. The relative order of other
+// actions is preserved. The returned bool is true if the redemption action
+// was actually moved, i.e. it was present in the checklist but was not its
+// first element.
+func prioritizeRedemption(
+	actionsChecklist []WalletActionType,
+) ([]WalletActionType, bool) {
+	prioritized := make([]WalletActionType, 0, len(actionsChecklist))
+	others := make([]WalletActionType, 0, len(actionsChecklist))
+
+	for _, action := range actionsChecklist {
+		if action == ActionRedemption {
+			prioritized = append(prioritized, action)
+		} else {
+			others = append(others, action)
+		}
+	}
+
+	moved := len(prioritized) > 0 && actionsChecklist[0] != ActionRedemption
+
+	return append(prioritized, others...), moved
+}
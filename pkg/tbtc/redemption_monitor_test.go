@@ -0,0 +1,188 @@
+package tbtc
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/keep-network/keep-core/internal/testutils"
+	"github.com/keep-network/keep-core/pkg/bitcoin"
+)
+
+func TestRedemptionTimeoutMonitor_Check(t *testing.T) {
+	localChain := Connect()
+
+	// 4 days, just like on mainnet.
+	timeout := 4 * 24 * time.Hour
+	localChain.setRedemptionTimeout(uint32(timeout.Seconds()))
+
+	now := time.Unix(1700000000, 0)
+
+	walletPublicKeyHash1 := [20]byte{1}
+	walletPublicKeyHash2 := [20]byte{2}
+	walletPublicKeyHash3 := [20]byte{3}
+
+	addRequest := func(
+		walletPublicKeyHash [20]byte,
+		redeemerOutputScript bitcoin.Script,
+		age time.Duration,
+		pending bool,
+	) {
+		localChain.addPastRedemptionRequestedEvent(
+			&RedemptionRequestedEvent{
+				WalletPublicKeyHash:  walletPublicKeyHash,
+				RedeemerOutputScript: redeemerOutputScript,
+			},
+		)
+
+		if pending {
+			localChain.setPendingRedemptionRequest(
+				walletPublicKeyHash,
+				&RedemptionRequest{
+					RedeemerOutputScript: redeemerOutputScript,
+					RequestedAt:          now.Add(-age),
+				},
+			)
+		}
+	}
+
+	// Wallet 1 has one fresh request, one at risk, and one critical.
+	addRequest(walletPublicKeyHash1, bitcoin.Script{0x01}, 1*time.Hour, true)
+	addRequest(walletPublicKeyHash1, bitcoin.Script{0x02}, 60*time.Hour, true)
+	addRequest(walletPublicKeyHash1, bitcoin.Script{0x03}, 80*time.Hour, true)
+	// Wallet 2 has one request that already timed out but is still pending
+	// and one old request that was already handled.
+	addRequest(walletPublicKeyHash2, bitcoin.Script{0x04}, 100*time.Hour, true)
+	addRequest(walletPublicKeyHash2, bitcoin.Script{0x05}, 90*time.Hour, false)
+	// Wallet 3 has a request at risk but is not controlled by the node.
+	addRequest(walletPublicKeyHash3, bitcoin.Script{0x06}, 60*time.Hour, true)
+
+	monitor := newRedemptionTimeoutMonitor(localChain)
+
+	err := monitor.check(
+		[][20]byte{walletPublicKeyHash1, walletPublicKeyHash2},
+		now,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertIntsEqual(t, "at-risk count", 3, monitor.atRiskCount())
+	testutils.AssertIntsEqual(t, "critical count", 2, monitor.criticalCount())
+
+	testutils.AssertBoolsEqual(
+		t,
+		"wallet 1 at risk",
+		true,
+		monitor.isAtRisk(walletPublicKeyHash1),
+	)
+	testutils.AssertBoolsEqual(
+		t,
+		"wallet 2 at risk",
+		true,
+		monitor.isAtRisk(walletPublicKeyHash2),
+	)
+	testutils.AssertBoolsEqual(
+		t,
+		"wallet 3 at risk",
+		false,
+		monitor.isAtRisk(walletPublicKeyHash3),
+	)
+
+	expectedWalletsAtRisk := map[string]map[string]int{
+		"0x0100000000000000000000000000000000000000": {
+			"at_risk":  2,
+			"critical": 1,
+		},
+		"0x0200000000000000000000000000000000000000": {
+			"at_risk":  1,
+			"critical": 1,
+		},
+	}
+	if !reflect.DeepEqual(expectedWalletsAtRisk, monitor.walletsAtRisk()) {
+		t.Errorf(
+			"unexpected wallets at risk\nexpected: %v\nactual:   %v",
+			expectedWalletsAtRisk,
+			monitor.walletsAtRisk(),
+		)
+	}
+
+	// Subsequent check without controlled wallets should reset the state.
+	if err := monitor.check([][20]byte{}, now); err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertIntsEqual(t, "at-risk count", 0, monitor.atRiskCount())
+	testutils.AssertIntsEqual(t, "critical count", 0, monitor.criticalCount())
+	testutils.AssertBoolsEqual(
+		t,
+		"wallet 1 at risk",
+		false,
+		monitor.isAtRisk(walletPublicKeyHash1),
+	)
+}
+
+func TestPrioritizeRedemption(t *testing.T) {
+	var tests = map[string]struct {
+		checklist         []WalletActionType
+		expectedChecklist []WalletActionType
+		expectedMoved     bool
+	}{
+		"redemption not first": {
+			checklist: []WalletActionType{
+				ActionDepositSweep,
+				ActionRedemption,
+				ActionMovingFunds,
+			},
+			expectedChecklist: []WalletActionType{
+				ActionRedemption,
+				ActionDepositSweep,
+				ActionMovingFunds,
+			},
+			expectedMoved: true,
+		},
+		"redemption already first": {
+			checklist: []WalletActionType{
+				ActionRedemption,
+				ActionDepositSweep,
+			},
+			expectedChecklist: []WalletActionType{
+				ActionRedemption,
+				ActionDepositSweep,
+			},
+			expectedMoved: false,
+		},
+		"no redemption": {
+			checklist: []WalletActionType{
+				ActionDepositSweep,
+				ActionMovedFundsSweep,
+			},
+			expectedChecklist: []WalletActionType{
+				ActionDepositSweep,
+				ActionMovedFundsSweep,
+			},
+			expectedMoved: false,
+		},
+		"empty checklist": {
+			checklist:         []WalletActionType{},
+			expectedChecklist: []WalletActionType{},
+			expectedMoved:     false,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			checklist, moved := prioritizeRedemption(test.checklist)
+
+			if !reflect.DeepEqual(test.expectedChecklist, checklist) {
+				t.Errorf(
+					"unexpected checklist\nexpected: %v\nactual:   %v",
+					test.expectedChecklist,
+					checklist,
+				)
+			}
+
+			testutils.AssertBoolsEqual(t, "moved", test.expectedMoved, moved)
+		})
+	}
+}
@@ -128,6 +128,9 @@ func TestRedemptionAction_Execute(t *testing.T) {
 				bitcoinChain,
 				wallet,
 				signingExecutor,
+				// Scenario transactions do not signal replace-by-fee so,
+				// they must not be recorded for fee bumping.
+				nil,
 				proposal,
 				proposalProcessingStartBlock,
 				proposalExpiresAt,
@@ -156,6 +156,37 @@ func (wr *walletRegistry) getWalletByPublicKeyHash(
 	return wallet{}, false
 }
 
+// archiveWallet removes the given wallet from the registry and archives its
+// signers in the underlying storage. Archived signers are no longer loaded
+// by the registry. Returns an error if the wallet is not registered.
+func (wr *walletRegistry) archiveWallet(walletPublicKeyHash [20]byte) error {
+	wr.mutex.Lock()
+	defer wr.mutex.Unlock()
+
+	for walletStorageKey, value := range wr.walletCache {
+		if value.walletPublicKeyHash != walletPublicKeyHash {
+			continue
+		}
+
+		err := wr.walletStorage.archiveWallet(walletStorageKey)
+		if err != nil {
+			return fmt.Errorf(
+				"cannot archive wallet in the storage: [%w]",
+				err,
+			)
+		}
+
+		delete(wr.walletCache, walletStorageKey)
+
+		return nil
+	}
+
+	return fmt.Errorf(
+		"wallet with public key hash [0x%x] is not registered",
+		walletPublicKeyHash,
+	)
+}
+
 // walletStorage is the component that persists data of the wallets managed
 // by the given node using the underlying persistence layer. It should be
 // used directly only by the walletRegistry.
@@ -194,6 +225,23 @@ func (ws *walletStorage) saveSigner(signer *signer) error {
 	return nil
 }
 
+// archiveWallet archives all signers of the wallet with the given storage key
+// using the underlying persistence layer. It does not remove the wallet from
+// any in-memory cache and should not be called from any other place than
+// walletRegistry.
+func (ws *walletStorage) archiveWallet(walletStorageKey string) error {
+	err := ws.persistence.Archive(walletStorageKey)
+	if err != nil {
+		return fmt.Errorf(
+			"could not archive wallet using the "+
+				"underlying persistence layer: [%w]",
+			err,
+		)
+	}
+
+	return nil
+}
+
 // loadSigners loads all signers stored using the underlying persistence layer.
 // This function should not be called from any other place than walletRegistry.
 func (ws *walletStorage) loadSigners() map[string][]*signer {
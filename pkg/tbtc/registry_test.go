@@ -2,6 +2,7 @@ package tbtc
 
 import (
 	"crypto/ecdsa"
+	"fmt"
 	"math/big"
 	"reflect"
 	"testing"
@@ -265,8 +266,68 @@ func TestWalletStorage_LoadSigners(t *testing.T) {
 	}
 }
 
+func TestWalletRegistry_ArchiveWallet(t *testing.T) {
+	persistenceHandle := &mockPersistenceHandle{}
+
+	walletRegistry := newWalletRegistry(persistenceHandle)
+
+	signer := createMockSigner(t)
+
+	err := walletRegistry.registerSigner(signer)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	walletPublicKeyHash := bitcoin.PublicKeyHash(signer.wallet.publicKey)
+
+	err = walletRegistry.archiveWallet(walletPublicKeyHash)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertIntsEqual(
+		t,
+		"registered wallets count",
+		0,
+		len(walletRegistry.walletCache),
+	)
+	testutils.AssertIntsEqual(
+		t,
+		"persisted wallet signers count",
+		0,
+		len(persistenceHandle.saved),
+	)
+	testutils.AssertIntsEqual(
+		t,
+		"archived wallet signers count",
+		1,
+		len(persistenceHandle.archived),
+	)
+	testutils.AssertStringsEqual(
+		t,
+		"archived wallet directory",
+		getWalletStorageKey(signer.wallet.publicKey),
+		persistenceHandle.archived[0].Directory(),
+	)
+
+	// Archived wallets should not be loaded by a new registry instance.
+	restoredWalletRegistry := newWalletRegistry(persistenceHandle)
+	if _, ok := restoredWalletRegistry.getWalletByPublicKeyHash(
+		walletPublicKeyHash,
+	); ok {
+		t.Errorf("archived wallet should not be loaded")
+	}
+
+	// Archiving a wallet that is not registered should fail.
+	err = walletRegistry.archiveWallet(walletPublicKeyHash)
+	if err == nil {
+		t.Errorf("expected error when archiving unregistered wallet")
+	}
+}
+
 type mockPersistenceHandle struct {
-	saved []persistence.DataDescriptor
+	saved    []persistence.DataDescriptor
+	archived []persistence.DataDescriptor
 }
 
 func (mph *mockPersistenceHandle) Save(
@@ -274,11 +335,21 @@ func (mph *mockPersistenceHandle) Save(
 	directory string,
 	name string,
 ) error {
-	mph.saved = append(mph.saved, &mockDescriptor{
+	descriptor := &mockDescriptor{
 		name:      name,
 		directory: directory,
 		content:   data,
-	})
+	}
+
+	// Overwrite the existing file, just as the disk persistence does.
+	for i, saved := range mph.saved {
+		if saved.Directory() == directory && saved.Name() == name {
+			mph.saved[i] = descriptor
+			return nil
+		}
+	}
+
+	mph.saved = append(mph.saved, descriptor)
 
 	return nil
 }
@@ -309,11 +380,29 @@ func (mph *mockPersistenceHandle) ReadAll() (
 }
 
 func (mph *mockPersistenceHandle) Archive(directory string) error {
-	panic("not implemented")
+	remaining := make([]persistence.DataDescriptor, 0)
+	for _, descriptor := range mph.saved {
+		if descriptor.Directory() == directory {
+			mph.archived = append(mph.archived, descriptor)
+		} else {
+			remaining = append(remaining, descriptor)
+		}
+	}
+
+	mph.saved = remaining
+
+	return nil
 }
 
 func (mph *mockPersistenceHandle) Delete(directory string, name string) error {
-	panic("not implemented")
+	for i, descriptor := range mph.saved {
+		if descriptor.Directory() == directory && descriptor.Name() == name {
+			mph.saved = append(mph.saved[:i], mph.saved[i+1:]...)
+			return nil
+		}
+	}
+
+	return fmt.Errorf("file [%v] not found in directory [%v]", name, directory)
 }
 
 type mockDescriptor struct {
@@ -7,6 +7,7 @@ import (
 	"strings"
 	"sync"
 
+	"github.com/keep-network/keep-core/pkg/bitcoin"
 	"github.com/keep-network/keep-core/pkg/generator"
 	"github.com/keep-network/keep-core/pkg/net"
 	"github.com/keep-network/keep-core/pkg/protocol/announcer"
@@ -32,12 +33,33 @@ const (
 	// completed by the slowest signing group member (the one who sends the
 	// signingDoneMessage as the last one).
 	signingBatchInterludeBlocks = 2
+
+	// signingBatchConcurrency determines the maximum number of messages from
+	// a single signing batch that are signed at the same time, each one by
+	// a separate signing protocol instance. All wallet signers must split the
+	// batch into the same signing rounds as the start block of each round is
+	// determined by the end blocks of the previous one. That's why this value
+	// is a protocol constant and cannot be configured by the operator.
+	signingBatchConcurrency = 2
 )
 
 // errSigningExecutorBusy is an error returned when the signing executor
 // cannot execute the requested signature due to an ongoing signing.
 var errSigningExecutorBusy = fmt.Errorf("signing executor is busy")
 
+// signingResult represents the result of signing a single message.
+type signingResult struct {
+	// signature is the produced signature.
+	signature *tecdsa.Signature
+	// participatingMembersIndexes holds indexes of signing group members
+	// that participated in the signing attempt that produced the signature.
+	participatingMembersIndexes []group.MemberIndex
+	// endBlock is the block at which the signature was calculated. This end
+	// block is common for all wallet signers so can be used as
+	// a synchronization point.
+	endBlock uint64
+}
+
 // signingExecutor is a component responsible for executing signing related to
 // a specific wallet whose part is controlled by this node.
 type signingExecutor struct {
@@ -58,6 +80,14 @@ type signingExecutor struct {
 	// be made by a single signer for the given message. Once the attempts
 	// limit is hit the signer gives up.
 	signingAttemptsLimit uint
+
+	// auditLog is the signing audit log the signatures produced by the
+	// executor are recorded in.
+	auditLog *signingAuditLog
+
+	// signingPolicy is the operator-defined policy wallet transactions must
+	// satisfy in order to be signed by the executor.
+	signingPolicy *signingPolicy
 }
 
 func newSigningExecutor(
@@ -69,6 +99,8 @@ func newSigningExecutor(
 	getCurrentBlockFn getCurrentBlockFn,
 	waitForBlockFn waitForBlockFn,
 	signingAttemptsLimit uint,
+	auditLog *signingAuditLog,
+	signingPolicy *signingPolicy,
 ) *signingExecutor {
 	return &signingExecutor{
 		lock:                 semaphore.NewWeighted(1),
@@ -80,20 +112,30 @@ func newSigningExecutor(
 		getCurrentBlockFn:    getCurrentBlockFn,
 		waitForBlockFn:       waitForBlockFn,
 		signingAttemptsLimit: signingAttemptsLimit,
+		auditLog:             auditLog,
+		signingPolicy:        signingPolicy,
 	}
 }
 
 // signBatch performs the signing process for each message from the given
-// messages batch, one after another. If at least one message cannot be signed,
-// this function returns an error. If all messages were signed successfully,
-// a slice of signatures is returned. Order of the returned signatures matches
-// the order of the messages in the batch, i.e. the first signature corresponds
-// to the first message, and so on.
+// messages batch. Messages are signed in rounds. Each round consists of up to
+// signingBatchConcurrency messages signed at the same time, each one by a separate
+// signing protocol instance. All signings of the given round start at the
+// same block. If at least one message cannot be signed, this function returns
+// an error. If all messages were signed successfully, a slice of signing
+// results is returned. Order of the returned results matches the order of the
+// messages in the batch, i.e. the first result corresponds to the first
+// message, and so on.
 func (se *signingExecutor) signBatch(
 	ctx context.Context,
 	messages []*big.Int,
 	startBlock uint64,
-) ([]*tecdsa.Signature, error) {
+) ([]*signingResult, error) {
+	if lockAcquired := se.lock.TryAcquire(1); !lockAcquired {
+		return nil, errSigningExecutorBusy
+	}
+	defer se.lock.Release(1)
+
 	wallet := se.wallet()
 
 	walletPublicKeyBytes, err := marshalPublicKey(wallet.publicKey)
@@ -129,61 +171,151 @@ func (se *signingExecutor) signBatch(
 		zap.String("signedMessages", strings.Join(messagesDigests, ", ")),
 	)
 
-	signingStartBlock := startBlock // start block for the first signing
-	signatures := make([]*tecdsa.Signature, len(messages))
-	endBlocks := make([]uint64, len(messages))
+	concurrency := batchConcurrency(messages, signingBatchConcurrency)
 
-	for i, message := range messages {
-		signingBatchMessageLogger := signingBatchLogger.With(
-			zap.String("signedMessage", fmt.Sprintf("0x%x", message)),
-			zap.String("index", fmt.Sprintf("%v/%v", i+1, len(messages))),
-		)
+	signingBatchLogger.Infof(
+		"signing [%v] messages with concurrency [%v]",
+		len(messages),
+		concurrency,
+	)
+
+	signingStartBlock := startBlock // start block for the first round
+	results := make([]*signingResult, len(messages))
 
-		signingBatchMessageLogger.Infof("generating signature for message")
+	type signingOutcome struct {
+		index  int
+		result *signingResult
+		err    error
+	}
 
-		if i > 0 {
-			signingStartBlock = endBlocks[i-1] + signingBatchInterludeBlocks
+	for roundStart := 0; roundStart < len(messages); roundStart += concurrency {
+		roundEnd := roundStart + concurrency
+		if roundEnd > len(messages) {
+			roundEnd = len(messages)
 		}
 
-		signature, endBlock, err := se.sign(ctx, message, signingStartBlock)
-		if err != nil {
-			return nil, err
+		signingOutcomeChan := make(chan *signingOutcome, roundEnd-roundStart)
+
+		for i := roundStart; i < roundEnd; i++ {
+			go func(index int, message *big.Int) {
+				signingBatchMessageLogger := signingBatchLogger.With(
+					zap.String("signedMessage", fmt.Sprintf("0x%x", message)),
+					zap.String("index", fmt.Sprintf("%v/%v", index+1, len(messages))),
+				)
+
+				signingBatchMessageLogger.Infof("generating signature for message")
+
+				result, err := se.signMessage(
+					ctx,
+					message,
+					signingStartBlock,
+				)
+				if err == nil {
+					signingBatchMessageLogger.Infof(
+						"generated signature [%v] for message at block [%v]",
+						result.signature,
+						result.endBlock,
+					)
+				}
+
+				signingOutcomeChan <- &signingOutcome{
+					index:  index,
+					result: result,
+					err:    err,
+				}
+			}(i, messages[i])
 		}
 
-		signingBatchMessageLogger.Infof(
-			"generated signature [%v] for message at block [%v]",
-			signature,
-			endBlock,
-		)
+		// Wait until all signings of the current round complete. The end
+		// blocks returned by all signings are common for all wallet signers
+		// so, the latest of them can be used to determine the start block of
+		// the next round.
+		var roundErr error
+		latestEndBlock := uint64(0)
+		for i := roundStart; i < roundEnd; i++ {
+			outcome := <-signingOutcomeChan
+
+			if outcome.err != nil {
+				if roundErr == nil {
+					roundErr = outcome.err
+				}
+				continue
+			}
+
+			results[outcome.index] = outcome.result
+
+			if outcome.result.endBlock > latestEndBlock {
+				latestEndBlock = outcome.result.endBlock
+			}
+		}
+
+		if roundErr != nil {
+			return nil, roundErr
+		}
 
-		signatures[i] = signature
-		endBlocks[i] = endBlock
+		signingStartBlock = latestEndBlock + signingBatchInterludeBlocks
 	}
 
-	return signatures, nil
+	return results, nil
+}
+
+// batchConcurrency determines the number of messages from the given batch
+// that can be signed at the same time, up to the given maximum. Signing
+// protocol instances running at the same time are distinguished by the signed
+// message so, the batch must not contain duplicated messages in order to be
+// signed concurrently. If that's not the case, messages are signed one after
+// another. The result depends only on the batch so, it is the same for all
+// wallet signers.
+func batchConcurrency(messages []*big.Int, maxConcurrency uint) int {
+	if maxConcurrency <= 1 {
+		return 1
+	}
+
+	uniqueMessages := make(map[string]bool)
+	for _, message := range messages {
+		key := message.Text(16)
+
+		if uniqueMessages[key] {
+			return 1
+		}
+
+		uniqueMessages[key] = true
+	}
+
+	return int(maxConcurrency)
 }
 
 // sign performs the signing process for the given message. The process is
 // triggered according to the given start block. If the message cannot be signed
 // within a limited time window, an error is returned. If the message was
-// signed successfully, this function returns the signature along with the
-// block at which the signature was calculated. This end block is common for
-// all wallet signers so can be used as a synchronization point.
+// signed successfully, this function returns the signing result holding the
+// signature along with the block at which the signature was calculated.
 func (se *signingExecutor) sign(
 	ctx context.Context,
 	message *big.Int,
 	startBlock uint64,
-) (*tecdsa.Signature, uint64, error) {
+) (*signingResult, error) {
 	if lockAcquired := se.lock.TryAcquire(1); !lockAcquired {
-		return nil, 0, errSigningExecutorBusy
+		return nil, errSigningExecutorBusy
 	}
 	defer se.lock.Release(1)
 
+	return se.signMessage(ctx, message, startBlock)
+}
+
+// signMessage performs the signing process for the given message, exactly as
+// described in the sign function documentation. This function does not
+// acquire the executor lock so, it is the caller's responsibility to do so.
+func (se *signingExecutor) signMessage(
+	ctx context.Context,
+	message *big.Int,
+	startBlock uint64,
+) (*signingResult, error) {
 	wallet := se.wallet()
 
 	walletPublicKeyBytes, err := marshalPublicKey(wallet.publicKey)
 	if err != nil {
-		return nil, 0, fmt.Errorf("cannot marshal wallet public key: [%v]", err)
+		return nil, fmt.Errorf("cannot marshal wallet public key: [%v]", err)
 	}
 
 	loopTimeoutBlock := startBlock +
@@ -196,14 +328,9 @@ func (se *signingExecutor) sign(
 		zap.Uint64("signingTimeoutBlock", loopTimeoutBlock),
 	)
 
-	type signingOutcome struct {
-		signature *tecdsa.Signature
-		endBlock  uint64
-	}
-
 	wg := sync.WaitGroup{}
 	wg.Add(len(se.signers))
-	signingOutcomeChan := make(chan *signingOutcome, len(se.signers))
+	signingResultChan := make(chan *signingResult, len(se.signers))
 
 	for _, currentSigner := range se.signers {
 		go func(signer *signer) {
@@ -364,9 +491,10 @@ func (se *signingExecutor) sign(
 				loopResult.latestEndBlock,
 			)
 
-			signingOutcomeChan <- &signingOutcome{
-				signature: loopResult.result.Signature,
-				endBlock:  loopResult.latestEndBlock,
+			signingResultChan <- &signingResult{
+				signature:                   loopResult.result.Signature,
+				participatingMembersIndexes: loopResult.participatingMembersIndexes,
+				endBlock:                    loopResult.latestEndBlock,
 			}
 		}(currentSigner)
 	}
@@ -375,20 +503,73 @@ func (se *signingExecutor) sign(
 	// regardless of their result.
 	wg.Wait()
 
-	// Take the first outcome from the channel as the outcome of all members.
+	// Take the first result from the channel as the result of all members.
 	// This assumption is totally valid because the signing loop produces a
 	// result only if all signers who participated in signing confirmed they
 	// are done by sending a valid `signingDoneMessage` during the signing done
 	// check phase. If the result was not inserted to the channel by any
 	// signer, that means all signers failed and have not produced a signature.
 	select {
-	case outcome := <-signingOutcomeChan:
-		return outcome.signature, outcome.endBlock, nil
+	case result := <-signingResultChan:
+		return result, nil
 	default:
-		return nil, 0, fmt.Errorf("all signers failed")
+		return nil, fmt.Errorf("all signers failed")
 	}
 }
 
+// recordSignatures records the given signing results of the given messages
+// in the signing audit log. Messages and results must be in the same order.
+// The signatures must be produced in the course of an action of the given
+// type and belong to the Bitcoin transaction with the given hash. The
+// transaction hash should be zero if the action does not produce
+// a transaction.
+func (se *signingExecutor) recordSignatures(
+	actionType WalletActionType,
+	transactionHash bitcoin.Hash,
+	messages []*big.Int,
+	results []*signingResult,
+) error {
+	return se.auditLog.record(
+		bitcoin.PublicKeyHash(se.wallet().publicKey),
+		actionType,
+		transactionHash,
+		messages,
+		results,
+	)
+}
+
+// evaluateSigningPolicy evaluates the given unsigned transaction against
+// the signing policy. The transaction must be created in the course of an
+// action of the given type. An error is returned if the transaction must not
+// be signed. Otherwise, a slot of the wallet's rate limit is reserved for the
+// transaction until it is counted using countSignedTransaction or released
+// using releaseSigningPolicyReservation.
+func (se *signingExecutor) evaluateSigningPolicy(
+	actionType WalletActionType,
+	unsignedTx *bitcoin.TransactionBuilder,
+) error {
+	return se.signingPolicy.evaluate(
+		bitcoin.PublicKeyHash(se.wallet().publicKey),
+		actionType,
+		unsignedTx,
+	)
+}
+
+// countSignedTransaction counts the signed transaction with the given hash
+// against the signing policy rate limit of the wallet.
+func (se *signingExecutor) countSignedTransaction(transactionHash bitcoin.Hash) {
+	se.signingPolicy.countSigned(
+		bitcoin.PublicKeyHash(se.wallet().publicKey),
+		transactionHash,
+	)
+}
+
+// releaseSigningPolicyReservation releases the signing policy rate limit
+// slot reserved for a transaction of the wallet whose signing failed.
+func (se *signingExecutor) releaseSigningPolicyReservation() {
+	se.signingPolicy.release(bitcoin.PublicKeyHash(se.wallet().publicKey))
+}
+
 func (se *signingExecutor) wallet() wallet {
 	// All signers belong to one wallet. Take that wallet from the
 	// first signer.
@@ -0,0 +1,361 @@
+package tbtc
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/keep-network/keep-common/pkg/persistence"
+
+	"github.com/keep-network/keep-core/pkg/bitcoin"
+	"github.com/keep-network/keep-core/pkg/protocol/group"
+	"github.com/keep-network/keep-core/pkg/tecdsa"
+)
+
+// signingAuditLogDirectory is the name of the work persistence directory
+// holding entries of the signing audit log.
+const signingAuditLogDirectory = "signing_audit_log"
+
+// SigningAuditEntry represents a single entry of the signing audit log. Each
+// entry describes a message signed by the given wallet and points to the
+// hash of the preceding entry. This way, entries form a hash chain and any
+// modification, removal, or reordering of an entry that is not the last one
+// breaks the chain.
+type SigningAuditEntry struct {
+	// Sequence is the position of the entry in the log, starting from 0.
+	Sequence uint64
+	// PreviousHash is the hash of the preceding entry. It is zero for the
+	// first entry of the log.
+	PreviousHash [32]byte
+	// Timestamp is the time the entry was recorded at.
+	Timestamp time.Time
+	// WalletPublicKeyHash is the 20-byte public key hash of the signing wallet.
+	WalletPublicKeyHash [20]byte
+	// Message is the digest that was signed, e.g. a transaction's sighash.
+	Message *big.Int
+	// ActionType is the type of the wallet action the signature was
+	// produced for.
+	ActionType WalletActionType
+	// TransactionHash is the hash of the Bitcoin transaction the signature
+	// belongs to. It is zero if the action does not produce a transaction.
+	TransactionHash bitcoin.Hash
+	// ParticipatingMembersIndexes holds indexes of signing group members
+	// that participated in the signing attempt that produced the signature.
+	ParticipatingMembersIndexes []group.MemberIndex
+	// Signature is the produced signature.
+	Signature *tecdsa.Signature
+}
+
+// Hash computes the hash of the entry. The hash of the entry is referenced
+// by the next entry of the log.
+func (sae *SigningAuditEntry) Hash() ([32]byte, error) {
+	bytes, err := sae.Marshal()
+	if err != nil {
+		return [32]byte{}, fmt.Errorf("cannot marshal entry: [%v]", err)
+	}
+
+	return sha256.Sum256(bytes), nil
+}
+
+// name returns a unique name of the entry that can be used as the name of
+// the file in the persistence layer. The name starts with the zero-padded
+// sequence number so, files are ordered the same way as entries. The name
+// also contains a part of the entry's hash so, two entries with the same
+// sequence number never overwrite each other.
+func (sae *SigningAuditEntry) name(hash [32]byte) string {
+	return fmt.Sprintf("%020d_%x", sae.Sequence, hash[:8])
+}
+
+// LoadSigningAuditLog loads all entries of the signing audit log stored using
+// the given work persistence handle. Returned entries are sorted by their
+// sequence numbers. An error is returned if any of the stored entries cannot
+// be read. The integrity of the loaded log is not checked by this function;
+// use VerifySigningAuditLog for that purpose.
+func LoadSigningAuditLog(
+	handle persistence.BasicHandle,
+) ([]*SigningAuditEntry, error) {
+	entries := make([]*SigningAuditEntry, 0)
+	errs := make([]error, 0)
+
+	descriptorsChan, errorsChan := handle.ReadAll()
+
+	// Two goroutines read from descriptors and errors channels and either
+	// add the entry to the result slice or record an error.
+	// The reason for using two goroutines at the same time - one for
+	// descriptors and one for errors - is that channels do not have to be
+	// buffered, and we do not know in what order the information is written to
+	// channels.
+	var wg sync.WaitGroup
+	wg.Add(2)
+
+	var errsMutex sync.Mutex
+	addErr := func(err error) {
+		errsMutex.Lock()
+		defer errsMutex.Unlock()
+
+		errs = append(errs, err)
+	}
+
+	go func() {
+		for descriptor := range descriptorsChan {
+			// Read only the files located in the signing audit log
+			// directory as the work persistence is shared.
+			if descriptor.Directory() != signingAuditLogDirectory {
+				continue
+			}
+
+			content, err := descriptor.Content()
+			if err != nil {
+				addErr(fmt.Errorf(
+					"cannot get content of entry [%v]: [%v]",
+					descriptor.Name(),
+					err,
+				))
+				continue
+			}
+
+			entry := &SigningAuditEntry{}
+			if err := entry.Unmarshal(content); err != nil {
+				addErr(fmt.Errorf(
+					"cannot unmarshal entry [%v]: [%v]",
+					descriptor.Name(),
+					err,
+				))
+				continue
+			}
+
+			entries = append(entries, entry)
+		}
+
+		wg.Done()
+	}()
+
+	go func() {
+		for err := range errorsChan {
+			addErr(fmt.Errorf("cannot read entry: [%v]", err))
+		}
+
+		wg.Done()
+	}()
+
+	wg.Wait()
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Sequence < entries[j].Sequence
+	})
+
+	if len(errs) > 0 {
+		return entries, fmt.Errorf(
+			"[%v] entries of the signing audit log cannot be loaded; "+
+				"first error: [%v]",
+			len(errs),
+			errs[0],
+		)
+	}
+
+	return entries, nil
+}
+
+// VerifySigningAuditLog verifies the integrity of the given signing audit
+// log. Entries must be sorted by their sequence numbers, just as returned by
+// LoadSigningAuditLog. The log is considered intact if sequence numbers start
+// from 0 and have no gaps nor duplicates, and if each entry points to the
+// hash of the preceding one. An error describing the first found violation
+// is returned if the log is not intact.
+//
+// Note that removal of the last entries of the log cannot be detected this way.
+func VerifySigningAuditLog(entries []*SigningAuditEntry) error {
+	var previousHash [32]byte
+
+	for i, entry := range entries {
+		if entry.Sequence != uint64(i) {
+			return fmt.Errorf(
+				"unexpected sequence number of entry at position [%v]; "+
+					"expected [%v] but got [%v]",
+				i,
+				i,
+				entry.Sequence,
+			)
+		}
+
+		if entry.PreviousHash != previousHash {
+			return fmt.Errorf(
+				"entry [%v] does not point to the hash of the preceding entry; "+
+					"expected [0x%x] but got [0x%x]",
+				entry.Sequence,
+				previousHash,
+				entry.PreviousHash,
+			)
+		}
+
+		hash, err := entry.Hash()
+		if err != nil {
+			return fmt.Errorf(
+				"cannot compute hash of entry [%v]: [%v]",
+				entry.Sequence,
+				err,
+			)
+		}
+
+		previousHash = hash
+	}
+
+	return nil
+}
+
+// signingAuditLog is the component that appends entries to the signing audit
+// log stored using the underlying persistence layer. All functions of the
+// log are safe for concurrent use.
+type signingAuditLog struct {
+	// mutex is a single struct-wide lock that ensures all functions
+	// of the log are thread-safe.
+	mutex sync.Mutex
+
+	// persistence is the handle to the underlying work persistence layer.
+	persistence persistence.BasicHandle
+
+	// nextSequence is the sequence number of the next appended entry.
+	nextSequence uint64
+	// lastHash is the hash of the last entry of the log.
+	lastHash [32]byte
+}
+
+// newSigningAuditLog creates a new instance of the signingAuditLog. The log
+// continues from the last entry already recorded in the persistence layer.
+// Problems with the integrity of the existing log are reported but do not
+// prevent new entries from being appended.
+func newSigningAuditLog(
+	persistence persistence.BasicHandle,
+) *signingAuditLog {
+	sal := &signingAuditLog{
+		persistence: persistence,
+	}
+
+	entries, err := LoadSigningAuditLog(persistence)
+	if err != nil {
+		logger.Errorf("cannot load signing audit log: [%v]", err)
+	}
+
+	if err := VerifySigningAuditLog(entries); err != nil {
+		logger.Errorf("signing audit log integrity is broken: [%v]", err)
+	}
+
+	if len(entries) > 0 {
+		lastEntry := entries[len(entries)-1]
+
+		lastHash, err := lastEntry.Hash()
+		if err != nil {
+			logger.Errorf(
+				"cannot compute hash of the last signing audit log entry: [%v]",
+				err,
+			)
+		}
+
+		sal.nextSequence = lastEntry.Sequence + 1
+		sal.lastHash = lastHash
+
+		logger.Infof(
+			"[%v] signing audit log entries loaded from storage",
+			len(entries),
+		)
+	}
+
+	return sal
+}
+
+// record appends entries for the given messages and their signing results
+// to the log. Messages and results must be in the same order. All messages
+// must be signed by the given wallet, in the course of an action of the
+// given type, and belong to the given Bitcoin transaction. The transaction
+// hash should be zero if the action does not produce a transaction.
+func (sal *signingAuditLog) record(
+	walletPublicKeyHash [20]byte,
+	actionType WalletActionType,
+	transactionHash bitcoin.Hash,
+	messages []*big.Int,
+	results []*signingResult,
+) error {
+	if len(messages) != len(results) {
+		return fmt.Errorf(
+			"messages count [%v] does not match results count [%v]",
+			len(messages),
+			len(results),
+		)
+	}
+
+	sal.mutex.Lock()
+	defer sal.mutex.Unlock()
+
+	for i, message := range messages {
+		entry := &SigningAuditEntry{
+			Sequence:                    sal.nextSequence,
+			PreviousHash:                sal.lastHash,
+			Timestamp:                   time.Unix(time.Now().Unix(), 0),
+			WalletPublicKeyHash:         walletPublicKeyHash,
+			Message:                     message,
+			ActionType:                  actionType,
+			TransactionHash:             transactionHash,
+			ParticipatingMembersIndexes: results[i].participatingMembersIndexes,
+			Signature:                   results[i].signature,
+		}
+
+		bytes, err := entry.Marshal()
+		if err != nil {
+			return fmt.Errorf(
+				"cannot marshal entry [%v]: [%v]",
+				entry.Sequence,
+				err,
+			)
+		}
+
+		hash := sha256.Sum256(bytes)
+
+		err = sal.persistence.Save(
+			bytes,
+			signingAuditLogDirectory,
+			entry.name(hash),
+		)
+		if err != nil {
+			return fmt.Errorf(
+				"cannot save entry [%v]: [%v]",
+				entry.Sequence,
+				err,
+			)
+		}
+
+		sal.nextSequence++
+		sal.lastHash = hash
+	}
+
+	return nil
+}
+
+// find looks up the most recent entry of the log that holds the given
+// message signed by the given wallet. The returned bool value indicates
+// whether the entry was found.
+func (sal *signingAuditLog) find(
+	walletPublicKeyHash [20]byte,
+	message *big.Int,
+) (*SigningAuditEntry, bool, error) {
+	sal.mutex.Lock()
+	defer sal.mutex.Unlock()
+
+	entries, err := LoadSigningAuditLog(sal.persistence)
+	if err != nil {
+		return nil, false, fmt.Errorf("cannot load entries: [%v]", err)
+	}
+
+	for i := len(entries) - 1; i >= 0; i-- {
+		entry := entries[i]
+
+		if entry.WalletPublicKeyHash == walletPublicKeyHash &&
+			entry.Message.Cmp(message) == 0 {
+			return entry, true, nil
+		}
+	}
+
+	return nil, false, nil
+}
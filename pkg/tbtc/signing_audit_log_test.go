@@ -0,0 +1,199 @@
+package tbtc
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/keep-network/keep-core/internal/testutils"
+	"github.com/keep-network/keep-core/pkg/bitcoin"
+	"github.com/keep-network/keep-core/pkg/protocol/group"
+	"github.com/keep-network/keep-core/pkg/tecdsa"
+)
+
+func TestSigningAuditLog(t *testing.T) {
+	persistenceHandle := &mockPersistenceHandle{}
+
+	auditLog := newSigningAuditLog(persistenceHandle)
+
+	walletPublicKeyHash := [20]byte{1}
+	transactionHash := bitcoin.Hash{2}
+
+	err := auditLog.record(
+		walletPublicKeyHash,
+		ActionDepositSweep,
+		transactionHash,
+		[]*big.Int{big.NewInt(100), big.NewInt(200)},
+		[]*signingResult{
+			newTestSigningResult(1, []group.MemberIndex{1, 2, 3}),
+			newTestSigningResult(2, []group.MemberIndex{2, 3, 4}),
+		},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Create a new log instance using the same persistence handle and make
+	// sure it continues the existing hash chain.
+	restoredAuditLog := newSigningAuditLog(persistenceHandle)
+
+	err = restoredAuditLog.record(
+		walletPublicKeyHash,
+		ActionHeartbeat,
+		bitcoin.Hash{},
+		[]*big.Int{big.NewInt(300)},
+		[]*signingResult{
+			newTestSigningResult(3, []group.MemberIndex{1, 3, 5}),
+		},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, descriptor := range persistenceHandle.saved {
+		testutils.AssertStringsEqual(
+			t,
+			"persisted entry directory",
+			signingAuditLogDirectory,
+			descriptor.Directory(),
+		)
+	}
+
+	entries, err := LoadSigningAuditLog(persistenceHandle)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertIntsEqual(t, "entries count", 3, len(entries))
+
+	for i, entry := range entries {
+		testutils.AssertUintsEqual(
+			t,
+			"entry sequence",
+			uint64(i),
+			entry.Sequence,
+		)
+		testutils.AssertBytesEqual(
+			t,
+			walletPublicKeyHash[:],
+			entry.WalletPublicKeyHash[:],
+		)
+	}
+
+	testutils.AssertStringsEqual(
+		t,
+		"action type of the first entry",
+		ActionDepositSweep.String(),
+		entries[0].ActionType.String(),
+	)
+	testutils.AssertBytesEqual(
+		t,
+		transactionHash[:],
+		entries[1].TransactionHash[:],
+	)
+	testutils.AssertStringsEqual(
+		t,
+		"action type of the last entry",
+		ActionHeartbeat.String(),
+		entries[2].ActionType.String(),
+	)
+	testutils.AssertBigIntsEqual(
+		t,
+		"message of the last entry",
+		big.NewInt(300),
+		entries[2].Message,
+	)
+
+	err = VerifySigningAuditLog(entries)
+	if err != nil {
+		t.Errorf("unexpected verification error: [%v]", err)
+	}
+}
+
+func TestVerifySigningAuditLog(t *testing.T) {
+	var tests = map[string]struct {
+		tamperFn      func(entries []*SigningAuditEntry) []*SigningAuditEntry
+		expectedError bool
+	}{
+		"intact log": {
+			tamperFn: func(entries []*SigningAuditEntry) []*SigningAuditEntry {
+				return entries
+			},
+			expectedError: false,
+		},
+		"modified entry": {
+			tamperFn: func(entries []*SigningAuditEntry) []*SigningAuditEntry {
+				entries[1].TransactionHash = bitcoin.Hash{9}
+				return entries
+			},
+			expectedError: true,
+		},
+		"removed entry": {
+			tamperFn: func(entries []*SigningAuditEntry) []*SigningAuditEntry {
+				return append(entries[:1], entries[2:]...)
+			},
+			expectedError: true,
+		},
+		"removed and renumbered entry": {
+			tamperFn: func(entries []*SigningAuditEntry) []*SigningAuditEntry {
+				entries[2].Sequence = 1
+				return append(entries[:1], entries[2:]...)
+			},
+			expectedError: true,
+		},
+		// Removal of the last entries cannot be detected by the hash chain.
+		"removed last entry": {
+			tamperFn: func(entries []*SigningAuditEntry) []*SigningAuditEntry {
+				return entries[:2]
+			},
+			expectedError: false,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			persistenceHandle := &mockPersistenceHandle{}
+
+			auditLog := newSigningAuditLog(persistenceHandle)
+
+			err := auditLog.record(
+				[20]byte{1},
+				ActionRedemption,
+				bitcoin.Hash{2},
+				[]*big.Int{big.NewInt(100), big.NewInt(200), big.NewInt(300)},
+				[]*signingResult{
+					newTestSigningResult(1, []group.MemberIndex{1, 2, 3}),
+					newTestSigningResult(2, []group.MemberIndex{1, 2, 3}),
+					newTestSigningResult(3, []group.MemberIndex{1, 2, 3}),
+				},
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			entries, err := LoadSigningAuditLog(persistenceHandle)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = VerifySigningAuditLog(test.tamperFn(entries))
+
+			if test.expectedError != (err != nil) {
+				t.Errorf("unexpected verification error: [%v]", err)
+			}
+		})
+	}
+}
+
+func newTestSigningResult(
+	seed int64,
+	participatingMembersIndexes []group.MemberIndex,
+) *signingResult {
+	return &signingResult{
+		signature: &tecdsa.Signature{
+			R:          big.NewInt(seed * 1000),
+			S:          big.NewInt(seed * 2000),
+			RecoveryID: 1,
+		},
+		participatingMembersIndexes: participatingMembersIndexes,
+	}
+}
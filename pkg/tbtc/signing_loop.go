@@ -149,6 +149,9 @@ type signingRetryLoopResult struct {
 	// attemptTimeoutBlock is the block at which the successful attempt times
 	// out.
 	attemptTimeoutBlock uint64
+	// participatingMembersIndexes holds indexes of signing group members
+	// that participated in the successful signing attempt.
+	participatingMembersIndexes []group.MemberIndex
 }
 
 // start begins the signing retry loop using the given signing attempt function.
@@ -396,9 +399,10 @@ func (srl *signingRetryLoop) start(
 		}
 
 		return &signingRetryLoopResult{
-			result:              result,
-			latestEndBlock:      latestEndBlock,
-			attemptTimeoutBlock: timeoutBlock,
+			result:                      result,
+			latestEndBlock:              latestEndBlock,
+			attemptTimeoutBlock:         timeoutBlock,
+			participatingMembersIndexes: includedMembersIndexes,
 		}, nil
 	}
 }
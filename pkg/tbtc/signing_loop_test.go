@@ -100,9 +100,10 @@ func TestSigningRetryLoop(t *testing.T) {
 			},
 			expectedErr: nil,
 			expectedResult: &signingRetryLoopResult{
-				result:              testResult,
-				latestEndBlock:      215, // the end block resolved by the done check phase
-				attemptTimeoutBlock: 236, // start block of the first attempt + 30
+				result:                      testResult,
+				latestEndBlock:              215, // the end block resolved by the done check phase
+				attemptTimeoutBlock:         236, // start block of the first attempt + 30
+				participatingMembersIndexes: []group.MemberIndex{1, 2, 4, 5, 6, 9},
 			},
 			// The signing random retry algorithm invoked with the test seed
 			// excludes 4 members (6 is the honest threshold) from the first
@@ -150,9 +151,10 @@ func TestSigningRetryLoop(t *testing.T) {
 			},
 			expectedErr: nil,
 			expectedResult: &signingRetryLoopResult{
-				result:              testResult,
-				latestEndBlock:      215, // the end block resolved by the done check phase
-				attemptTimeoutBlock: 236, // start block of the first attempt + 30
+				result:                      testResult,
+				latestEndBlock:              215, // the end block resolved by the done check phase
+				attemptTimeoutBlock:         236, // start block of the first attempt + 30
+				participatingMembersIndexes: []group.MemberIndex{1, 2, 3, 6, 7, 9},
 			},
 			// As only 6 members (honest threshold) announced their readiness,
 			// we don't have any other option than select them for the attempt.
@@ -204,9 +206,10 @@ func TestSigningRetryLoop(t *testing.T) {
 			},
 			expectedErr: nil,
 			expectedResult: &signingRetryLoopResult{
-				result:              testResult,
-				latestEndBlock:      260, // the end block resolved by the done check phase
-				attemptTimeoutBlock: 277, // start block of the second attempt + 30
+				result:                      testResult,
+				latestEndBlock:              260, // the end block resolved by the done check phase
+				attemptTimeoutBlock:         277, // start block of the second attempt + 30
+				participatingMembersIndexes: []group.MemberIndex{3, 4, 6, 7, 8, 10},
 			},
 			// Member 3 is the executing one. The first attempt's announcement
 			// fails and the signing random retry algorithm invoked with the
@@ -260,9 +263,10 @@ func TestSigningRetryLoop(t *testing.T) {
 			},
 			expectedErr: nil,
 			expectedResult: &signingRetryLoopResult{
-				result:              testResult,
-				latestEndBlock:      260, // the end block resolved by the done check phase
-				attemptTimeoutBlock: 277, // start block of the second attempt + 30
+				result:                      testResult,
+				latestEndBlock:              260, // the end block resolved by the done check phase
+				attemptTimeoutBlock:         277, // start block of the second attempt + 30
+				participatingMembersIndexes: []group.MemberIndex{3, 4, 6, 7, 8, 10},
 			},
 			// Member 4 is the executing one. The first attempt fails and
 			// the signing random retry algorithm invoked with the test seed
@@ -316,9 +320,10 @@ func TestSigningRetryLoop(t *testing.T) {
 			},
 			expectedErr: nil,
 			expectedResult: &signingRetryLoopResult{
-				result:              testResult,
-				latestEndBlock:      260, // the end block resolved by the done check phase
-				attemptTimeoutBlock: 277, // start block of the second attempt + 30
+				result:                      testResult,
+				latestEndBlock:              260, // the end block resolved by the done check phase
+				attemptTimeoutBlock:         277, // start block of the second attempt + 30
+				participatingMembersIndexes: []group.MemberIndex{3, 4, 6, 7, 8, 10},
 			},
 			// Member 4 is the executing one. The first attempt fails and
 			// the signing random retry algorithm invoked with the test seed
@@ -367,6 +372,9 @@ func TestSigningRetryLoop(t *testing.T) {
 				result:              testResult,
 				latestEndBlock:      260, // the end block resolved by the done check phase
 				attemptTimeoutBlock: 277, // start block of the second attempt + 30
+				// Member 2 is excluded from the second attempt hence, it is
+				// not one of the participating members.
+				participatingMembersIndexes: []group.MemberIndex{3, 4, 6, 7, 8, 10},
 			},
 			// Member 2 is the executing one. The first attempt fails
 			// and is the last attempt executed by this member because member
@@ -435,9 +443,10 @@ func TestSigningRetryLoop(t *testing.T) {
 			},
 			expectedErr: nil,
 			expectedResult: &signingRetryLoopResult{
-				result:              testResult,
-				latestEndBlock:      260, // the end block resolved by the done check phase
-				attemptTimeoutBlock: 277, // start block of the second attempt + 30
+				result:                      testResult,
+				latestEndBlock:              260, // the end block resolved by the done check phase
+				attemptTimeoutBlock:         277, // start block of the second attempt + 30
+				participatingMembersIndexes: []group.MemberIndex{3, 4, 6, 7, 8, 10},
 			},
 			// Member 4 is the executing one. The first attempt done check
 			// exchange fails and the signing random retry algorithm invoked
@@ -540,9 +549,10 @@ func TestSigningRetryLoop(t *testing.T) {
 			},
 			expectedErr: nil,
 			expectedResult: &signingRetryLoopResult{
-				result:              testResult,
-				latestEndBlock:      260, // the end block resolved by the done check phase
-				attemptTimeoutBlock: 277, // start block of the second attempt + 30
+				result:                      testResult,
+				latestEndBlock:              260, // the end block resolved by the done check phase
+				attemptTimeoutBlock:         277, // start block of the second attempt + 30
+				participatingMembersIndexes: []group.MemberIndex{3, 4, 6, 7, 8, 10},
 			},
 			// Member 3 is the executing one. The first attempt's announcement
 			// is skipped and the signing random retry algorithm invoked with the
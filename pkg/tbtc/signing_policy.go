@@ -0,0 +1,423 @@
+package tbtc
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/keep-network/keep-core/pkg/bitcoin"
+)
+
+// signingPolicyRateLimitWindow is the duration of the sliding window the
+// per-wallet rate limit of the signing policy is applied to.
+const signingPolicyRateLimitWindow = 24 * time.Hour
+
+// SigningPolicyConfig is the operator-defined policy wallet transactions must
+// satisfy in order to be signed by the client. Zero or empty values of the
+// respective fields mean the given rule is not enforced.
+type SigningPolicyConfig struct {
+	// MaxTransactionValue is the maximum total value of transaction outputs,
+	// in satoshi. Change outputs paying back to the wallet's own P2PKH or
+	// P2WPKH script do not count against this value.
+	MaxTransactionValue int64
+	// MaxFeeRate is the maximum transaction fee rate, in satoshi per vbyte.
+	MaxFeeRate int64
+	// AllowedOutputScriptTypes is the list of script types transaction
+	// outputs are allowed to use, e.g. P2WPKH or P2SH.
+	AllowedOutputScriptTypes []string
+	// MaxTransactionsPerWalletPerDay is the maximum number of transactions
+	// a single wallet can sign within 24 hours. Only successfully signed
+	// transactions are counted.
+	MaxTransactionsPerWalletPerDay int
+	// DeniedRedeemerOutputScripts is the list of hex-encoded output scripts
+	// transactions must never pay to.
+	DeniedRedeemerOutputScripts []string
+}
+
+// signingPolicyRule represents a single rule of the signing policy.
+type signingPolicyRule string
+
+const (
+	ruleMaxTransactionValue            signingPolicyRule = "max_transaction_value"
+	ruleMaxFeeRate                     signingPolicyRule = "max_fee_rate"
+	ruleAllowedOutputScriptTypes       signingPolicyRule = "allowed_output_script_types"
+	ruleMaxTransactionsPerWalletPerDay signingPolicyRule = "max_transactions_per_wallet_per_day"
+	ruleDeniedRedeemerOutputScripts    signingPolicyRule = "denied_redeemer_output_scripts"
+)
+
+// signingPolicyRules holds all rules of the signing policy.
+var signingPolicyRules = []signingPolicyRule{
+	ruleMaxTransactionValue,
+	ruleMaxFeeRate,
+	ruleAllowedOutputScriptTypes,
+	ruleMaxTransactionsPerWalletPerDay,
+	ruleDeniedRedeemerOutputScripts,
+}
+
+// signingPolicyViolation is an error returned when a transaction violates
+// a rule of the signing policy.
+type signingPolicyViolation struct {
+	rule   signingPolicyRule
+	reason string
+}
+
+func (spv *signingPolicyViolation) Error() string {
+	return fmt.Sprintf(
+		"signing policy rule [%v] violated: %v",
+		spv.rule,
+		spv.reason,
+	)
+}
+
+// signingPolicy is the component evaluating wallet transactions against the
+// operator-defined signing policy before they are signed. All functions of
+// the policy are safe for concurrent use.
+type signingPolicy struct {
+	maxTransactionValue            int64
+	maxFeeRate                     int64
+	allowedOutputScriptTypes       map[bitcoin.ScriptType]bool
+	maxTransactionsPerWalletPerDay int
+	deniedRedeemerOutputScripts    map[string]bool
+
+	// mutex is a single struct-wide lock that ensures all functions
+	// of the policy are thread-safe.
+	mutex sync.Mutex
+	// walletsSignedTransactions holds, for each wallet, signing times of
+	// transactions signed within the rate limit window, by transaction hash.
+	// The map key is the 20-byte wallet public key hash.
+	walletsSignedTransactions map[[20]byte]map[bitcoin.Hash]time.Time
+	// walletsReservations holds, for each wallet, the number of transactions
+	// that were approved by the policy and are being signed. They count
+	// against the wallet's rate limit until they are signed or their signing
+	// fails. The map key is the 20-byte wallet public key hash.
+	walletsReservations map[[20]byte]int
+	// refusalsCounts holds the number of refusals of each rule.
+	refusalsCounts map[signingPolicyRule]uint64
+}
+
+// newSigningPolicy creates a new instance of the signingPolicy based on the
+// given config. An error is returned if the config is invalid.
+func newSigningPolicy(config SigningPolicyConfig) (*signingPolicy, error) {
+	if config.MaxTransactionValue < 0 {
+		return nil, fmt.Errorf("max transaction value cannot be negative")
+	}
+
+	if config.MaxFeeRate < 0 {
+		return nil, fmt.Errorf("max fee rate cannot be negative")
+	}
+
+	if config.MaxTransactionsPerWalletPerDay < 0 {
+		return nil, fmt.Errorf(
+			"max transactions per wallet per day cannot be negative",
+		)
+	}
+
+	allowedOutputScriptTypes := make(map[bitcoin.ScriptType]bool)
+	for _, name := range config.AllowedOutputScriptTypes {
+		scriptType, err := parseOutputScriptType(name)
+		if err != nil {
+			return nil, err
+		}
+
+		allowedOutputScriptTypes[scriptType] = true
+	}
+
+	deniedRedeemerOutputScripts := make(map[string]bool)
+	for _, scriptHex := range config.DeniedRedeemerOutputScripts {
+		script, err := hex.DecodeString(strings.TrimPrefix(scriptHex, "0x"))
+		if err != nil {
+			return nil, fmt.Errorf(
+				"cannot decode denied redeemer output script [%v]: [%v]",
+				scriptHex,
+				err,
+			)
+		}
+
+		deniedRedeemerOutputScripts[hex.EncodeToString(script)] = true
+	}
+
+	return &signingPolicy{
+		maxTransactionValue:            config.MaxTransactionValue,
+		maxFeeRate:                     config.MaxFeeRate,
+		allowedOutputScriptTypes:       allowedOutputScriptTypes,
+		maxTransactionsPerWalletPerDay: config.MaxTransactionsPerWalletPerDay,
+		deniedRedeemerOutputScripts:    deniedRedeemerOutputScripts,
+		walletsSignedTransactions:      make(map[[20]byte]map[bitcoin.Hash]time.Time),
+		walletsReservations:            make(map[[20]byte]int),
+		refusalsCounts:                 make(map[signingPolicyRule]uint64),
+	}, nil
+}
+
+// parseOutputScriptType parses the given name of an output script type.
+// The name is case-insensitive. Non-standard scripts cannot be allowed.
+func parseOutputScriptType(name string) (bitcoin.ScriptType, error) {
+	for _, scriptType := range []bitcoin.ScriptType{
+		bitcoin.P2PKHScript,
+		bitcoin.P2WPKHScript,
+		bitcoin.P2SHScript,
+		bitcoin.P2WSHScript,
+		bitcoin.P2TRScript,
+	} {
+		if strings.EqualFold(scriptType.String(), name) {
+			return scriptType, nil
+		}
+	}
+
+	return bitcoin.NonStandardScript, fmt.Errorf(
+		"unknown output script type [%v]",
+		name,
+	)
+}
+
+// evaluate evaluates the given unsigned transaction of the given wallet
+// against the signing policy. The transaction must have all its inputs and
+// outputs added. If the transaction violates any of the rules, the refusal
+// is logged and counted, and a signingPolicyViolation error is returned.
+// Otherwise, nil is returned and a slot of the wallet's rate limit is
+// reserved for the transaction in the same step so, concurrently evaluated
+// transactions cannot exceed the limit together. The reservation must be
+// turned into a signed transaction using countSigned or released using
+// release if the signing fails.
+func (sp *signingPolicy) evaluate(
+	walletPublicKeyHash [20]byte,
+	actionType WalletActionType,
+	unsignedTx *bitcoin.TransactionBuilder,
+) error {
+	sp.mutex.Lock()
+	defer sp.mutex.Unlock()
+
+	violation, err := sp.findViolation(walletPublicKeyHash, unsignedTx)
+	if err != nil {
+		return fmt.Errorf("cannot evaluate signing policy: [%v]", err)
+	}
+
+	if violation != nil {
+		sp.refusalsCounts[violation.rule]++
+
+		logger.Warnf(
+			"signing policy refused to sign [%v] transaction of wallet "+
+				"with public key hash [0x%x]: [%v]",
+			actionType,
+			walletPublicKeyHash,
+			violation,
+		)
+
+		return violation
+	}
+
+	if sp.maxTransactionsPerWalletPerDay > 0 {
+		sp.walletsReservations[walletPublicKeyHash]++
+	}
+
+	return nil
+}
+
+// countSigned counts the transaction with the given hash, signed by the given
+// wallet, against the wallet's rate limit in place of the reservation made
+// by evaluate. A transaction that is already counted, e.g. because it was
+// signed again, is not counted twice.
+func (sp *signingPolicy) countSigned(
+	walletPublicKeyHash [20]byte,
+	transactionHash bitcoin.Hash,
+) {
+	if sp.maxTransactionsPerWalletPerDay == 0 {
+		return
+	}
+
+	sp.mutex.Lock()
+	defer sp.mutex.Unlock()
+
+	sp.releaseReservation(walletPublicKeyHash)
+
+	signedTransactions, ok := sp.walletsSignedTransactions[walletPublicKeyHash]
+	if !ok {
+		signedTransactions = make(map[bitcoin.Hash]time.Time)
+		sp.walletsSignedTransactions[walletPublicKeyHash] = signedTransactions
+	}
+
+	if _, counted := signedTransactions[transactionHash]; !counted {
+		signedTransactions[transactionHash] = time.Now()
+	}
+}
+
+// release releases the reservation of the wallet's rate limit slot made by
+// evaluate for a transaction whose signing failed.
+func (sp *signingPolicy) release(walletPublicKeyHash [20]byte) {
+	if sp.maxTransactionsPerWalletPerDay == 0 {
+		return
+	}
+
+	sp.mutex.Lock()
+	defer sp.mutex.Unlock()
+
+	sp.releaseReservation(walletPublicKeyHash)
+}
+
+// releaseReservation releases a single reservation of the given wallet.
+// Must be called with the mutex held.
+func (sp *signingPolicy) releaseReservation(walletPublicKeyHash [20]byte) {
+	if sp.walletsReservations[walletPublicKeyHash] <= 1 {
+		delete(sp.walletsReservations, walletPublicKeyHash)
+		return
+	}
+
+	sp.walletsReservations[walletPublicKeyHash]--
+}
+
+// findViolation returns the violation of the first rule the given transaction
+// does not satisfy or nil if all rules are satisfied. Must be called with the
+// mutex held.
+func (sp *signingPolicy) findViolation(
+	walletPublicKeyHash [20]byte,
+	unsignedTx *bitcoin.TransactionBuilder,
+) (*signingPolicyViolation, error) {
+	totalOutputsValue := unsignedTx.TotalOutputsValue()
+
+	if sp.maxTransactionValue > 0 {
+		transactionValue, err := transferredValue(
+			walletPublicKeyHash,
+			unsignedTx,
+		)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"cannot determine transaction value: [%v]",
+				err,
+			)
+		}
+
+		if transactionValue > sp.maxTransactionValue {
+			return &signingPolicyViolation{
+				rule: ruleMaxTransactionValue,
+				reason: fmt.Sprintf(
+					"transaction value [%v] exceeds the maximum of "+
+						"[%v] satoshi",
+					transactionValue,
+					sp.maxTransactionValue,
+				),
+			}, nil
+		}
+	}
+
+	if sp.maxFeeRate > 0 {
+		virtualSize, err := unsignedTx.VirtualSize()
+		if err != nil {
+			return nil, fmt.Errorf(
+				"cannot estimate transaction virtual size: [%v]",
+				err,
+			)
+		}
+
+		fee := unsignedTx.TotalInputsValue() - totalOutputsValue
+		if fee > sp.maxFeeRate*virtualSize {
+			return &signingPolicyViolation{
+				rule: ruleMaxFeeRate,
+				reason: fmt.Sprintf(
+					"transaction fee [%v] satoshi for [%v] vbytes exceeds "+
+						"the maximum fee rate of [%v] satoshi/vbyte",
+					fee,
+					virtualSize,
+					sp.maxFeeRate,
+				),
+			}, nil
+		}
+	}
+
+	for i, output := range unsignedTx.Outputs() {
+		if len(sp.allowedOutputScriptTypes) > 0 {
+			scriptType := bitcoin.GetScriptType(output.PublicKeyScript)
+			if !sp.allowedOutputScriptTypes[scriptType] {
+				return &signingPolicyViolation{
+					rule: ruleAllowedOutputScriptTypes,
+					reason: fmt.Sprintf(
+						"output [%v] uses script type [%v] "+
+							"which is not allowed",
+						i,
+						scriptType,
+					),
+				}, nil
+			}
+		}
+
+		scriptHex := hex.EncodeToString(output.PublicKeyScript)
+		if sp.deniedRedeemerOutputScripts[scriptHex] {
+			return &signingPolicyViolation{
+				rule: ruleDeniedRedeemerOutputScripts,
+				reason: fmt.Sprintf(
+					"output [%v] pays to denied script [0x%v]",
+					i,
+					scriptHex,
+				),
+			}, nil
+		}
+	}
+
+	if sp.maxTransactionsPerWalletPerDay > 0 {
+		// Drop transactions signed outside the rate limit window.
+		windowStart := time.Now().Add(-signingPolicyRateLimitWindow)
+		signedTransactions := sp.walletsSignedTransactions[walletPublicKeyHash]
+		for transactionHash, signingTime := range signedTransactions {
+			if !signingTime.After(windowStart) {
+				delete(signedTransactions, transactionHash)
+			}
+		}
+
+		reservations := sp.walletsReservations[walletPublicKeyHash]
+
+		if len(signedTransactions)+reservations >=
+			sp.maxTransactionsPerWalletPerDay {
+			return &signingPolicyViolation{
+				rule: ruleMaxTransactionsPerWalletPerDay,
+				reason: fmt.Sprintf(
+					"wallet already signed [%v] transactions within "+
+						"the last [%v] and is signing [%v] more",
+					len(signedTransactions),
+					signingPolicyRateLimitWindow,
+					reservations,
+				),
+			}, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// transferredValue returns the total value of the given transaction outputs
+// that do not pay back to the P2PKH or P2WPKH script of the wallet with the
+// given public key hash.
+func transferredValue(
+	walletPublicKeyHash [20]byte,
+	unsignedTx *bitcoin.TransactionBuilder,
+) (int64, error) {
+	walletP2PKH, err := bitcoin.PayToPublicKeyHash(walletPublicKeyHash)
+	if err != nil {
+		return 0, fmt.Errorf("cannot compute wallet P2PKH: [%v]", err)
+	}
+
+	walletP2WPKH, err := bitcoin.PayToWitnessPublicKeyHash(walletPublicKeyHash)
+	if err != nil {
+		return 0, fmt.Errorf("cannot compute wallet P2WPKH: [%v]", err)
+	}
+
+	value := int64(0)
+	for _, output := range unsignedTx.Outputs() {
+		if bytes.Equal(output.PublicKeyScript, walletP2PKH) ||
+			bytes.Equal(output.PublicKeyScript, walletP2WPKH) {
+			continue
+		}
+
+		value += output.Value
+	}
+
+	return value, nil
+}
+
+// refusalsCount returns the number of refusals of the given rule.
+func (sp *signingPolicy) refusalsCount(rule signingPolicyRule) uint64 {
+	sp.mutex.Lock()
+	defer sp.mutex.Unlock()
+
+	return sp.refusalsCounts[rule]
+}
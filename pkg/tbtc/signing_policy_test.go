@@ -0,0 +1,394 @@
+package tbtc
+
+import (
+	"encoding/hex"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/keep-network/keep-core/internal/testutils"
+	"github.com/keep-network/keep-core/pkg/bitcoin"
+)
+
+const (
+	testP2WPKHScriptHex = "00148db50eb52063ea9d98b3eac91489a90f738986f6"
+	testP2PKHScriptHex  = "76a9148db50eb52063ea9d98b3eac91489a90f738986f688ac"
+	// Scripts paying to the wallet with public key hash 0x01000...000 the
+	// policy is evaluated for.
+	testWalletP2WPKHScriptHex = "00140100000000000000000000000000000000000000"
+	testWalletP2PKHScriptHex  = "76a914010000000000000000000000000000000000000088ac"
+)
+
+func TestNewSigningPolicy_InvalidConfig(t *testing.T) {
+	var tests = map[string]SigningPolicyConfig{
+		"negative max transaction value": {
+			MaxTransactionValue: -1,
+		},
+		"negative max fee rate": {
+			MaxFeeRate: -1,
+		},
+		"negative max transactions per wallet per day": {
+			MaxTransactionsPerWalletPerDay: -1,
+		},
+		"unknown output script type": {
+			AllowedOutputScriptTypes: []string{"P2WPKH", "P2MS"},
+		},
+		"malformed denied redeemer output script": {
+			DeniedRedeemerOutputScripts: []string{"0x0014zz"},
+		},
+	}
+
+	for testName, config := range tests {
+		t.Run(testName, func(t *testing.T) {
+			_, err := newSigningPolicy(config)
+			if err == nil {
+				t.Errorf("expected error")
+			}
+		})
+	}
+}
+
+func TestSigningPolicy_Evaluate(t *testing.T) {
+	var tests = map[string]struct {
+		config       SigningPolicyConfig
+		inputValue   int64
+		outputs      map[string]int64
+		expectedRule signingPolicyRule
+	}{
+		"empty policy": {
+			config:     SigningPolicyConfig{},
+			inputValue: 100000,
+			outputs: map[string]int64{
+				testP2PKHScriptHex: 10000,
+			},
+		},
+		"all rules satisfied": {
+			config: SigningPolicyConfig{
+				MaxTransactionValue:      90000,
+				MaxFeeRate:               100,
+				AllowedOutputScriptTypes: []string{"p2wpkh", "P2PKH"},
+				DeniedRedeemerOutputScripts: []string{
+					"0x0014e257eccafbc07c381642ce6e7e55120fb077fbed",
+				},
+			},
+			inputValue: 100000,
+			outputs: map[string]int64{
+				testP2WPKHScriptHex: 50000,
+				testP2PKHScriptHex:  40000,
+			},
+		},
+		"max transaction value exceeded": {
+			config: SigningPolicyConfig{
+				MaxTransactionValue: 89999,
+			},
+			inputValue: 100000,
+			outputs: map[string]int64{
+				testP2WPKHScriptHex: 50000,
+				testP2PKHScriptHex:  40000,
+			},
+			expectedRule: ruleMaxTransactionValue,
+		},
+		"max transaction value not exceeded due to change outputs": {
+			config: SigningPolicyConfig{
+				MaxTransactionValue: 50000,
+			},
+			inputValue: 100000,
+			outputs: map[string]int64{
+				testP2WPKHScriptHex:       50000,
+				testWalletP2WPKHScriptHex: 30000,
+				testWalletP2PKHScriptHex:  10000,
+			},
+		},
+		"max fee rate exceeded": {
+			config: SigningPolicyConfig{
+				MaxFeeRate: 50,
+			},
+			inputValue: 100000,
+			outputs: map[string]int64{
+				testP2WPKHScriptHex: 90000,
+			},
+			expectedRule: ruleMaxFeeRate,
+		},
+		"output script type not allowed": {
+			config: SigningPolicyConfig{
+				AllowedOutputScriptTypes: []string{"P2WPKH", "P2WSH"},
+			},
+			inputValue: 100000,
+			outputs: map[string]int64{
+				testP2WPKHScriptHex: 50000,
+				testP2PKHScriptHex:  40000,
+			},
+			expectedRule: ruleAllowedOutputScriptTypes,
+		},
+		"denied redeemer output script": {
+			config: SigningPolicyConfig{
+				DeniedRedeemerOutputScripts: []string{testP2PKHScriptHex},
+			},
+			inputValue: 100000,
+			outputs: map[string]int64{
+				testP2WPKHScriptHex: 50000,
+				testP2PKHScriptHex:  40000,
+			},
+			expectedRule: ruleDeniedRedeemerOutputScripts,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			policy, err := newSigningPolicy(test.config)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			unsignedTx := newTestPolicyTransaction(
+				t,
+				test.inputValue,
+				test.outputs,
+			)
+
+			err = policy.evaluate([20]byte{1}, ActionRedemption, unsignedTx)
+
+			assertSigningPolicyRefusal(t, test.expectedRule, err)
+
+			for _, rule := range signingPolicyRules {
+				expectedRefusalsCount := 0
+				if rule == test.expectedRule {
+					expectedRefusalsCount = 1
+				}
+
+				testutils.AssertIntsEqual(
+					t,
+					"refusals count of rule "+string(rule),
+					expectedRefusalsCount,
+					int(policy.refusalsCount(rule)),
+				)
+			}
+		})
+	}
+}
+
+func TestSigningPolicy_Evaluate_MaxTransactionsPerWalletPerDay(t *testing.T) {
+	policy, err := newSigningPolicy(SigningPolicyConfig{
+		MaxTransactionsPerWalletPerDay: 2,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	walletPublicKeyHash := [20]byte{1}
+	otherWalletPublicKeyHash := [20]byte{2}
+
+	unsignedTx := newTestPolicyTransaction(
+		t,
+		100000,
+		map[string]int64{testP2WPKHScriptHex: 90000},
+	)
+
+	evaluate := func(walletPublicKeyHash [20]byte) error {
+		return policy.evaluate(walletPublicKeyHash, ActionDepositSweep, unsignedTx)
+	}
+
+	// Transactions approved by the policy count against the limit while
+	// being signed.
+	assertSigningPolicyRefusal(t, "", evaluate(walletPublicKeyHash))
+	assertSigningPolicyRefusal(t, "", evaluate(walletPublicKeyHash))
+	assertSigningPolicyRefusal(
+		t,
+		ruleMaxTransactionsPerWalletPerDay,
+		evaluate(walletPublicKeyHash),
+	)
+
+	// Transactions whose signing failed are not counted.
+	policy.release(walletPublicKeyHash)
+	policy.release(walletPublicKeyHash)
+
+	assertSigningPolicyRefusal(t, "", evaluate(walletPublicKeyHash))
+	policy.countSigned(walletPublicKeyHash, bitcoin.Hash{1})
+
+	// The same transaction signed again is counted once.
+	assertSigningPolicyRefusal(t, "", evaluate(walletPublicKeyHash))
+	policy.countSigned(walletPublicKeyHash, bitcoin.Hash{1})
+
+	assertSigningPolicyRefusal(t, "", evaluate(walletPublicKeyHash))
+	policy.countSigned(walletPublicKeyHash, bitcoin.Hash{2})
+
+	assertSigningPolicyRefusal(
+		t,
+		ruleMaxTransactionsPerWalletPerDay,
+		evaluate(walletPublicKeyHash),
+	)
+
+	// The limit is applied to each wallet separately.
+	assertSigningPolicyRefusal(t, "", evaluate(otherWalletPublicKeyHash))
+
+	// Move the first signing outside the rate limit window. This should
+	// release one slot for the wallet.
+	policy.walletsSignedTransactions[walletPublicKeyHash][bitcoin.Hash{1}] =
+		time.Now().Add(-signingPolicyRateLimitWindow - time.Minute)
+
+	assertSigningPolicyRefusal(t, "", evaluate(walletPublicKeyHash))
+	policy.countSigned(walletPublicKeyHash, bitcoin.Hash{3})
+
+	assertSigningPolicyRefusal(
+		t,
+		ruleMaxTransactionsPerWalletPerDay,
+		evaluate(walletPublicKeyHash),
+	)
+
+	testutils.AssertIntsEqual(
+		t,
+		"refusals count",
+		3,
+		int(policy.refusalsCount(ruleMaxTransactionsPerWalletPerDay)),
+	)
+}
+
+func TestSigningPolicy_Evaluate_MaxTransactionsPerWalletPerDay_Concurrent(
+	t *testing.T,
+) {
+	maxTransactions := 3
+
+	policy, err := newSigningPolicy(SigningPolicyConfig{
+		MaxTransactionsPerWalletPerDay: maxTransactions,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	walletPublicKeyHash := [20]byte{1}
+
+	unsignedTx := newTestPolicyTransaction(
+		t,
+		100000,
+		map[string]int64{testP2WPKHScriptHex: 90000},
+	)
+
+	evaluations := 20
+
+	var approved int32
+	var wg sync.WaitGroup
+	wg.Add(evaluations)
+
+	for i := 0; i < evaluations; i++ {
+		go func() {
+			defer wg.Done()
+
+			err := policy.evaluate(
+				walletPublicKeyHash,
+				ActionDepositSweep,
+				unsignedTx,
+			)
+			if err == nil {
+				atomic.AddInt32(&approved, 1)
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	testutils.AssertIntsEqual(
+		t,
+		"approved transactions",
+		maxTransactions,
+		int(approved),
+	)
+	testutils.AssertIntsEqual(
+		t,
+		"refusals count",
+		evaluations-maxTransactions,
+		int(policy.refusalsCount(ruleMaxTransactionsPerWalletPerDay)),
+	)
+}
+
+// newTestPolicyTransaction builds an unsigned transaction with one P2WPKH
+// input of the given value and the given outputs, keyed by hex-encoded
+// output scripts.
+func newTestPolicyTransaction(
+	t *testing.T,
+	inputValue int64,
+	outputs map[string]int64,
+) *bitcoin.TransactionBuilder {
+	btcChain := newLocalBitcoinChain()
+
+	fundingTx := &bitcoin.Transaction{
+		Version: 1,
+		Inputs: []*bitcoin.TransactionInput{
+			{
+				Outpoint: &bitcoin.TransactionOutpoint{
+					TransactionHash: bitcoin.Hash{1},
+					OutputIndex:     0,
+				},
+				Sequence: 0xffffffff,
+			},
+		},
+		Outputs: []*bitcoin.TransactionOutput{
+			{
+				Value:           inputValue,
+				PublicKeyScript: decodeTestScript(t, testP2WPKHScriptHex),
+			},
+		},
+	}
+
+	err := btcChain.BroadcastTransaction(fundingTx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	builder := bitcoin.NewTransactionBuilder(btcChain)
+
+	err = builder.AddPublicKeyHashInput(&bitcoin.UnspentTransactionOutput{
+		Outpoint: &bitcoin.TransactionOutpoint{
+			TransactionHash: fundingTx.Hash(),
+			OutputIndex:     0,
+		},
+		Value: inputValue,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for scriptHex, value := range outputs {
+		builder.AddOutput(&bitcoin.TransactionOutput{
+			Value:           value,
+			PublicKeyScript: decodeTestScript(t, scriptHex),
+		})
+	}
+
+	return builder
+}
+
+func decodeTestScript(t *testing.T, scriptHex string) bitcoin.Script {
+	script, err := hex.DecodeString(scriptHex)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return script
+}
+
+func assertSigningPolicyRefusal(
+	t *testing.T,
+	expectedRule signingPolicyRule,
+	err error,
+) {
+	if expectedRule == "" {
+		if err != nil {
+			t.Errorf("unexpected error: [%v]", err)
+		}
+		return
+	}
+
+	var violation *signingPolicyViolation
+	if !errors.As(err, &violation) {
+		t.Fatalf("expected signing policy violation; got [%v]", err)
+	}
+
+	testutils.AssertStringsEqual(
+		t,
+		"violated rule",
+		string(expectedRule),
+		string(violation.rule),
+	)
+}
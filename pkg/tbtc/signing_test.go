@@ -27,7 +27,7 @@ func TestSigningExecutor_Sign(t *testing.T) {
 	message := big.NewInt(100)
 	startBlock := uint64(0)
 
-	signature, endBlock, err := executor.sign(ctx, message, startBlock)
+	result, err := executor.sign(ctx, message, startBlock)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -37,15 +37,24 @@ func TestSigningExecutor_Sign(t *testing.T) {
 	if !ecdsa.Verify(
 		walletPublicKey,
 		message.Bytes(),
-		signature.R,
-		signature.S,
+		result.signature.R,
+		result.signature.S,
 	) {
-		t.Errorf("invalid signature: [%+v]", signature)
+		t.Errorf("invalid signature: [%+v]", result.signature)
 	}
 
-	if endBlock <= startBlock {
+	if result.endBlock <= startBlock {
 		t.Errorf("wrong end block")
 	}
+
+	// At least the honest threshold of members must participate in signing.
+	if len(result.participatingMembersIndexes) <
+		executor.groupParameters.HonestThreshold {
+		t.Errorf(
+			"unexpected participating members: [%v]",
+			result.participatingMembersIndexes,
+		)
+	}
 }
 
 func TestSigningExecutor_Sign_Busy(t *testing.T) {
@@ -59,13 +68,13 @@ func TestSigningExecutor_Sign_Busy(t *testing.T) {
 
 	errChan := make(chan error, 1)
 	go func() {
-		_, _, err := executor.sign(ctx, message, startBlock)
+		_, err := executor.sign(ctx, message, startBlock)
 		errChan <- err
 	}()
 
 	time.Sleep(100 * time.Millisecond)
 
-	_, _, err := executor.sign(ctx, message, startBlock)
+	_, err := executor.sign(ctx, message, startBlock)
 	testutils.AssertErrorsSame(t, errSigningExecutorBusy, err)
 
 	err = <-errChan
@@ -87,25 +96,60 @@ func TestSigningExecutor_SignBatch(t *testing.T) {
 	}
 	startBlock := uint64(0)
 
-	signatures, err := executor.signBatch(ctx, messages, startBlock)
+	results, err := executor.signBatch(ctx, messages, startBlock)
 	if err != nil {
 		t.Fatal(err)
 	}
 
 	walletPublicKey := executor.wallet().publicKey
 
-	for i, signature := range signatures {
+	for i, result := range results {
 		if !ecdsa.Verify(
 			walletPublicKey,
 			messages[i].Bytes(),
-			signature.R,
-			signature.S,
+			result.signature.R,
+			result.signature.S,
 		) {
-			t.Errorf("invalid signature [%v]: [%+v]", i, signature)
+			t.Errorf("invalid signature [%v]: [%+v]", i, result.signature)
 		}
 	}
 }
 
+func TestBatchConcurrency(t *testing.T) {
+	var tests = map[string]struct {
+		maxConcurrency      uint
+		messages            []*big.Int
+		expectedConcurrency int
+	}{
+		"sequential signing": {
+			maxConcurrency:      1,
+			messages:            []*big.Int{big.NewInt(1), big.NewInt(2)},
+			expectedConcurrency: 1,
+		},
+		"concurrent signing": {
+			maxConcurrency:      4,
+			messages:            []*big.Int{big.NewInt(1), big.NewInt(2)},
+			expectedConcurrency: 4,
+		},
+		"concurrent signing with duplicated messages": {
+			maxConcurrency:      4,
+			messages:            []*big.Int{big.NewInt(1), big.NewInt(1)},
+			expectedConcurrency: 1,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			testutils.AssertIntsEqual(
+				t,
+				"batch concurrency",
+				test.expectedConcurrency,
+				batchConcurrency(test.messages, test.maxConcurrency),
+			)
+		})
+	}
+}
+
 // setupSigningExecutor sets up an instance of the signing executor ready
 // to perform test signing.
 func setupSigningExecutor(t *testing.T) *signingExecutor {
@@ -169,6 +213,7 @@ func setupSigningExecutor(t *testing.T) *signingExecutor {
 		keyStorePersistence,
 		&mockPersistenceHandle{},
 		generator.StartScheduler(),
+		&mockCoordinationProposalGenerator{},
 		Config{},
 	)
 	if err != nil {
@@ -65,6 +65,14 @@ type Config struct {
 	PreParamsGenerationConcurrency int
 	// Concurrency level for key-generation for tECDSA.
 	KeyGenerationConcurrency int
+	// Operator-defined policy wallet transactions must satisfy in order
+	// to be signed.
+	SigningPolicy SigningPolicyConfig
+	// Minimum time that must remain until the refund locktime of deposits
+	// proposed for sweeping by the node acting as the coordination leader.
+	// Must not be lower than DepositSweepRefundLocktimeSafetyMargin which
+	// is used by all signers to validate deposit sweep proposals.
+	DepositSweepRefundLocktimeSafetyMargin time.Duration
 }
 
 // Initialize kicks off the TBTC by initializing internal state, ensuring
@@ -78,6 +86,7 @@ func Initialize(
 	keyStorePersistence persistence.ProtectedHandle,
 	workPersistence persistence.BasicHandle,
 	scheduler *generator.Scheduler,
+	proposalGenerator CoordinationProposalGenerator,
 	config Config,
 	clientInfo *clientinfo.Registry,
 ) error {
@@ -95,6 +104,7 @@ func Initialize(
 		keyStorePersistence,
 		workPersistence,
 		scheduler,
+		proposalGenerator,
 		config,
 	)
 	if err != nil {
@@ -110,12 +120,50 @@ func Initialize(
 
 	if clientInfo != nil {
 		// only if client info endpoint is configured
-		clientInfo.ObserveApplicationSource(
+		sources := map[string]clientinfo.Source{
+			"pre_params_count": func() float64 {
+				return float64(node.dkgExecutor.preParamsCount())
+			},
+			"quarantined_deposits_count": func() float64 {
+				return float64(node.depositQuarantine.count())
+			},
+			"wallet_dispatcher_queue_depth": func() float64 {
+				return float64(node.walletDispatcher.queueDepth())
+			},
+			"wallet_dispatcher_queue_max_wait_time_seconds": func() float64 {
+				return node.walletDispatcher.queueMaxWaitTime().Seconds()
+			},
+			"wallet_dispatcher_queue_last_wait_time_seconds": func() float64 {
+				return node.walletDispatcher.queueLastWaitTime().Seconds()
+			},
+			"redemption_requests_at_risk_count": func() float64 {
+				return float64(node.redemptionTimeoutMonitor.atRiskCount())
+			},
+			"redemption_requests_critical_count": func() float64 {
+				return float64(node.redemptionTimeoutMonitor.criticalCount())
+			},
+		}
+		for _, rule := range signingPolicyRules {
+			rule := rule
+			sources[fmt.Sprintf("signing_policy_refusals_%v", rule)] =
+				func() float64 {
+					return float64(node.signingPolicy.refusalsCount(rule))
+				}
+		}
+
+		clientInfo.ObserveApplicationSource("tbtc", sources)
+
+		clientInfo.RegisterApplicationSource(
 			"tbtc",
-			map[string]clientinfo.Source{
-				"pre_params_count": func() float64 {
-					return float64(node.dkgExecutor.preParamsCount())
-				},
+			func() clientinfo.ApplicationInfo {
+				return clientinfo.ApplicationInfo{
+					"coordination_faults": node.coordinationFaultRegistry.
+						operatorsFaultsCounts(),
+					"quarantined_deposits": node.depositQuarantine.
+						diagnostics(),
+					"redemption_wallets_at_risk": node.redemptionTimeoutMonitor.
+						walletsAtRisk(),
+				}
 			},
 		)
 	}
@@ -262,308 +310,70 @@ func Initialize(
 		}()
 	})
 
-	// Set up a handler of a wallet heartbeat request coming from the
-	// WalletCoordinator on-chain contract. Once an event is seen, a handler
-	// goroutine makes sure that the observed event is not duplicate, waits
-	// a fixed confirmation period, and ensures the on-chain state justifies
-	// the occurrence of the event. Once done, the original event is used
-	// to trigger the heartbeat request action. The handler does not care about
-	// possible subsequent events being a result of chain reorgs. This is because
-	// the WalletCoordinator contract is just a coordination point based on
-	// the chain consensus. If enough clients received the event, they should
-	// follow it and execute a heartbeat signing. The message for that heartbeat
-	// request was validated in the contract, so even if there was a reorg and
-	// another event landed on the canonical chain later, the signature
-	// will still be valid and not lead to fraud. The only reason the handler
-	// waits a fixed confirmation period after receiving the coordination event
-	// is to make sure the right type of action is executed given different types
-	// of actions may have different lock times. We do not want to run into a
-	// situation when the majority of clients execute heartbeat with N blocks
-	// wallet lock time and the chain has M < N blocks wallet lock time because
-	// the canonical chain - as a result of a reorg - is supposed to execute
-	// e.g. redemption.
-	_ = chain.OnHeartbeatRequestSubmitted(func(event *HeartbeatRequestSubmittedEvent) {
-		go func() {
-			walletPublicKeyHash := event.WalletPublicKeyHash
-			message := event.Message
-
-			if ok := deduplicator.notifyHeartbeatRequestSubmitted(
-				walletPublicKeyHash,
-				message,
-			); !ok {
-				logger.Infof(
-					"heartbeat request for wallet PKH [0x%x] and message [0x%x] "+
-						"has been already processed",
-					walletPublicKeyHash,
-					message,
-				)
-				return
-			}
-
-			confirmationBlock := event.BlockNumber +
-				heartbeatRequestConfirmationBlocks
-
+	handleFraudChallengeSubmitted := func(event *FraudChallengeSubmittedEvent) {
+		if ok := deduplicator.notifyFraudChallengeSubmitted(
+			event.WalletPublicKeyHash,
+			event.Sighash,
+		); !ok {
 			logger.Infof(
-				"observed heartbeat request for wallet PKH [0x%x] "+
-					"at block [%v]; waiting for block [%v] to confirm",
-				walletPublicKeyHash,
-				event.BlockNumber,
-				confirmationBlock,
-			)
-
-			err := node.waitForBlockHeight(ctx, confirmationBlock)
-			if err != nil {
-				logger.Errorf(
-					"failed to confirm heartbeat request for "+
-						"wallet PKH [0x%x]: [%v]",
-					walletPublicKeyHash,
-					err,
-				)
-				return
-			}
-
-			expiresAt, cause, err := chain.GetWalletLock(
-				walletPublicKeyHash,
+				"fraud challenge against sighash [0x%x] of wallet "+
+					"PKH [0x%x] has been already processed",
+				event.Sighash,
+				event.WalletPublicKeyHash,
 			)
-			if err != nil {
-				logger.Errorf(
-					"failed to get lock for wallet PKH [0x%x]: [%v]",
-					walletPublicKeyHash,
-					err,
-				)
-				return
-			}
+			return
+		}
+
+		logger.Infof(
+			"fraud challenge against sighash [0x%x] of wallet "+
+				"PKH [0x%x] submitted at block [%v]",
+			event.Sighash,
+			event.WalletPublicKeyHash,
+			event.BlockNumber,
+		)
 
-			// The event is confirmed if the wallet is locked due to a heartbeat
-			// action.
-			if time.Now().Before(expiresAt) && cause == ActionHeartbeat {
-				logger.Infof(
-					"heartbeat request submitted for "+
-						"wallet PKH [0x%x] at block [%v] by [%v]",
-					walletPublicKeyHash,
-					event.BlockNumber,
-					event.Coordinator,
-				)
+		node.handleFraudChallengeSubmitted(ctx, event)
+	}
 
-				node.handleHeartbeatRequest(
-					walletPublicKeyHash,
-					message,
-					expiresAt,
-					event.BlockNumber,
-					heartbeatRequestConfirmationBlocks,
-				)
-			} else {
-				logger.Infof(
-					"heartbeat request for wallet PKH [0x%x] "+
-						"at block [%v] was not confirmed; existing wallet lock "+
-						"has unexpected expiration time [%s] and/or cause [%v]",
-					walletPublicKeyHash,
-					event.BlockNumber,
-					expiresAt,
-					cause,
-				)
-			}
-		}()
+	_ = chain.OnFraudChallengeSubmitted(func(event *FraudChallengeSubmittedEvent) {
+		go handleFraudChallengeSubmitted(event)
 	})
 
-	// Set up a handler of deposit sweep proposals coming from the
-	// WalletCoordinator on-chain contract. Once an event is seen, a handler
-	// goroutine makes sure that the observed event is not a duplicate, waits
-	// a fixed confirmation period, and ensures the on-chain state justifies
-	// the occurrence of the event. Once done, the original event is used
-	// to trigger the deposit sweep action. The handler does not care about
-	// possible subsequent events being result of chain reorgs. This is because
-	// the WalletCoordinator contract is just a coordination point based on
-	// the chain consensus. If enough clients received the event, they should
-	// follow it and execute a signature. All input parameters for that
-	// signature are validated, so even if there was a reorg and another event
-	// landed on the canonical chain later, the first signature will still be
-	// valid and approved by Bitcoin. The only reason the handler waits a
-	// fixed confirmation period after receiving the coordination event is to
-	// make sure the right type of action is executed given different types of
-	// actions may have different lock times. We do not want to run into a
-	// situation when the majority of clients execute sweep with N blocks wallet
-	// lock time and the chain has M < N blocks wallet lock time because the
-	// canonical chain - as a result of a reorg - is supposed to execute
-	// e.g. redemption.
-	_ = chain.OnDepositSweepProposalSubmitted(func(event *DepositSweepProposalSubmittedEvent) {
-		go func() {
-			walletPublicKeyHash := event.Proposal.WalletPublicKeyHash
-
-			if ok := deduplicator.notifyDepositSweepProposalSubmitted(
-				event.Proposal,
-			); !ok {
-				logger.Infof(
-					"deposit sweep proposal for wallet PKH [0x%x] "+
-						"has been already processed",
-					walletPublicKeyHash,
-				)
-				return
-			}
-
-			confirmationBlock := event.BlockNumber +
-				depositSweepProposalConfirmationBlocks
-
-			logger.Infof(
-				"observed deposit sweep proposal for wallet PKH [0x%x] "+
-					"at block [%v]; waiting for block [%v] to confirm",
-				walletPublicKeyHash,
-				event.BlockNumber,
-				confirmationBlock,
-			)
-
-			err := node.waitForBlockHeight(ctx, confirmationBlock)
-			if err != nil {
-				logger.Errorf(
-					"failed to confirm deposit sweep proposal for "+
-						"wallet PKH [0x%x]: [%v]",
-					walletPublicKeyHash,
-					err,
-				)
-				return
-			}
-
-			expiresAt, cause, err := chain.GetWalletLock(
-				walletPublicKeyHash,
-			)
-			if err != nil {
-				logger.Errorf(
-					"failed to get lock for wallet PKH [0x%x]: [%v]",
-					walletPublicKeyHash,
-					err,
-				)
-				return
-			}
-
-			// The event is confirmed if the wallet is locked due to a deposit
-			// sweep action.
-			if time.Now().Before(expiresAt) && cause == ActionDepositSweep {
-				logger.Infof(
-					"deposit sweep proposal submitted for "+
-						"wallet PKH [0x%x] at block [%v] by [%v]",
-					walletPublicKeyHash,
-					event.BlockNumber,
-					event.Coordinator,
-				)
-
-				node.handleDepositSweepProposal(
-					event.Proposal,
-					expiresAt,
-					event.BlockNumber,
-					depositSweepProposalConfirmationBlocks,
-				)
-			} else {
-				logger.Infof(
-					"deposit sweep proposal for wallet PKH [0x%x] "+
-						"at block [%v] was not confirmed; existing wallet lock "+
-						"has unexpected expiration time [%s] and/or cause [%v]",
-					walletPublicKeyHash,
-					event.BlockNumber,
-					expiresAt,
-					cause,
-				)
-			}
-		}()
+	// Fraud challenges submitted while the node was offline are not
+	// delivered by the subscription so, look for them in past events.
+	go func() {
+		events, err := findPendingFraudChallenges(
+			chain,
+			node.walletRegistry.getWalletsPublicKeys(),
+			time.Now(),
+		)
+		if err != nil {
+			logger.Errorf("cannot find pending fraud challenges: [%v]", err)
+			return
+		}
+
+		for _, event := range events {
+			go handleFraudChallengeSubmitted(event)
+		}
+	}()
+
+	_ = chain.OnDepositRevealed(func(event *DepositRevealedEvent) {
+		go node.handleDepositRevealed(event)
 	})
 
-	// Set up a handler of redemption proposals coming from the
-	// WalletCoordinator on-chain contract. Once an event is seen, a handler
-	// goroutine makes sure that the observed event is not a duplicate, waits
-	// a fixed confirmation period, and ensures the on-chain state justifies
-	// the occurrence of the event. Once done, the original event is used
-	// to trigger the redemption action. The handler does not care about
-	// possible subsequent events being result of chain reorgs. This is because
-	// the WalletCoordinator contract is just a coordination point based on
-	// the chain consensus. If enough clients received the event, they should
-	// follow it and execute a signature. All input parameters for that
-	// signature are validated, so even if there was a reorg and another event
-	// landed on the canonical chain later, the first signature will still be
-	// valid and approved by Bitcoin. The only reason the handler waits a
-	// fixed confirmation period after receiving the coordination event is to
-	// make sure the right type of action is executed given different types of
-	// actions may have different lock times. We do not want to run into a
-	// situation when the majority of clients execute redemption with N blocks
-	// wallet lock time and the chain has M < N blocks wallet lock time because
-	// the canonical chain - as a result of a reorg - is supposed to execute
-	// e.g. wallet heartbeat.
-	_ = chain.OnRedemptionProposalSubmitted(func(event *RedemptionProposalSubmittedEvent) {
-		go func() {
-			walletPublicKeyHash := event.Proposal.WalletPublicKeyHash
-
-			if ok := deduplicator.notifyRedemptionProposalSubmitted(
-				event.Proposal,
-			); !ok {
-				logger.Infof(
-					"redemption proposal for wallet PKH [0x%x] "+
-						"has been already processed",
-					walletPublicKeyHash,
-				)
-				return
-			}
-
-			confirmationBlock := event.BlockNumber +
-				redemptionProposalConfirmationBlocks
+	go node.depositRevealValidator.runRecheckLoop(ctx)
 
-			logger.Infof(
-				"observed redemption proposal for wallet PKH [0x%x] "+
-					"at block [%v]; waiting for block [%v] to confirm",
-				walletPublicKeyHash,
-				event.BlockNumber,
-				confirmationBlock,
-			)
+	go node.runWalletArchiveReconciliation(ctx)
 
-			err := node.waitForBlockHeight(ctx, confirmationBlock)
-			if err != nil {
-				logger.Errorf(
-					"failed to confirm redemption proposal for "+
-						"wallet PKH [0x%x]: [%v]",
-					walletPublicKeyHash,
-					err,
-				)
-				return
-			}
-
-			expiresAt, cause, err := chain.GetWalletLock(
-				walletPublicKeyHash,
-			)
-			if err != nil {
-				logger.Errorf(
-					"failed to get lock for wallet PKH [0x%x]: [%v]",
-					walletPublicKeyHash,
-					err,
-				)
-				return
-			}
+	go node.redemptionTimeoutMonitor.run(ctx, func() [][20]byte {
+		walletsPublicKeys := node.walletRegistry.getWalletsPublicKeys()
 
-			// The event is confirmed if the wallet is locked due to a
-			// redemption action.
-			if time.Now().Before(expiresAt) && cause == ActionRedemption {
-				logger.Infof(
-					"redemption proposal submitted for "+
-						"wallet PKH [0x%x] at block [%v] by [%v]",
-					walletPublicKeyHash,
-					event.BlockNumber,
-					event.Coordinator,
-				)
+		walletsPublicKeyHashes := make([][20]byte, len(walletsPublicKeys))
+		for i, walletPublicKey := range walletsPublicKeys {
+			walletsPublicKeyHashes[i] = bitcoin.PublicKeyHash(walletPublicKey)
+		}
 
-				node.handleRedemptionProposal(
-					event.Proposal,
-					expiresAt,
-					event.BlockNumber,
-					redemptionProposalConfirmationBlocks,
-				)
-			} else {
-				logger.Infof(
-					"redemption proposal for wallet PKH [0x%x] "+
-						"at block [%v] was not confirmed; existing wallet lock "+
-						"has unexpected expiration time [%s] and/or cause [%v]",
-					walletPublicKeyHash,
-					event.BlockNumber,
-					expiresAt,
-					cause,
-				)
-			}
-		}()
+		return walletsPublicKeyHashes
 	})
 
 	return nil
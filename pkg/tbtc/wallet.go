@@ -30,6 +30,7 @@ const (
 	ActionRedemption
 	ActionMovingFunds
 	ActionMovedFundsSweep
+	ActionFeeBump
 )
 
 // ParseWalletActionType parses the given value into a WalletActionType.
@@ -47,6 +48,8 @@ func ParseWalletActionType(value uint8) (WalletActionType, error) {
 		return ActionMovingFunds, nil
 	case 5:
 		return ActionMovedFundsSweep, nil
+	case 6:
+		return ActionFeeBump, nil
 	default:
 		return 0, fmt.Errorf("unknown wallet action type [%v]", value)
 	}
@@ -66,6 +69,8 @@ func (wat WalletActionType) String() string {
 		return "MovingFunds"
 	case ActionMovedFundsSweep:
 		return "MovedFundsSweep"
+	case ActionFeeBump:
+		return "FeeBump"
 	default:
 		panic("unknown wallet action type")
 	}
@@ -81,6 +86,23 @@ type walletAction interface {
 
 	// actionType returns the specific type of the walletAction.
 	actionType() WalletActionType
+
+	// expiresAt returns the point in time the walletAction expires at.
+	// An expired walletAction must not be started.
+	expiresAt() time.Time
+
+	// signingTimeoutBlock returns the block at which signing done by the
+	// walletAction times out. The walletAction cannot produce signatures
+	// once this block is reached so, it must not be started.
+	signingTimeoutBlock() uint64
+}
+
+// signingTimeoutBlock returns the block at which signing started at the
+// given block times out, i.e. the block at which all signing attempts
+// are exhausted.
+func signingTimeoutBlock(signingStartBlock uint64) uint64 {
+	return signingStartBlock +
+		uint64(signingAttemptsLimit*signingAttemptMaximumBlocks())
 }
 
 // WalletState represents the state of a wallet.
@@ -114,55 +136,147 @@ func (ws WalletState) String() string {
 	}
 }
 
+// walletDispatcherQueueCapacity determines the maximum number of actions
+// that can wait for execution in the queue of a single wallet.
+const walletDispatcherQueueCapacity = 10
+
 // errWalletBusy is an error returned when the waller cannot execute the
-// requested walletAction due to an ongoing work.
+// requested walletAction due to an ongoing work and the queue of actions
+// waiting for execution is full.
 var errWalletBusy = fmt.Errorf("wallet is busy")
 
+// dispatchPriority returns the priority of the given action type used
+// to order actions waiting for execution. Actions with a higher priority are
+// executed first. Fee bumps go first as no other transaction of the wallet
+// can be made until the wallet's pending transaction is confirmed.
+// Redemptions are time-sensitive for redeemers so, they go next. They are
+// followed by moving funds and moved funds sweeps, then by deposit sweeps
+// and finally by heartbeats.
+func (wat WalletActionType) dispatchPriority() int {
+	switch wat {
+	case ActionFeeBump:
+		return 6
+	case ActionRedemption:
+		return 5
+	case ActionMovingFunds:
+		return 4
+	case ActionMovedFundsSweep:
+		return 3
+	case ActionDepositSweep:
+		return 2
+	case ActionHeartbeat:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// queuedWalletAction represents a walletAction waiting for execution.
+type queuedWalletAction struct {
+	action   walletAction
+	queuedAt time.Time
+}
+
 // walletDispatcher is a component responsible for dispatching wallet actions
-// to specific wallets.
+// to specific wallets. Only one action is executed by a wallet at a time.
+// Actions dispatched while the wallet is busy are queued and executed
+// in the order of their priority once the wallet becomes idle. Actions
+// that expire or whose signing times out while waiting in the queue
+// are dropped.
 type walletDispatcher struct {
 	actionsMutex sync.Mutex
 	// actions is the mapping holding the currently executed action of the
 	// given wallet. The mapping key is the uncompressed public key
 	// (with 04 prefix) of the wallet.
 	actions map[string]WalletActionType
+	// queues is the mapping holding actions waiting for execution by the
+	// given wallet, in the order they were dispatched. The mapping key is
+	// the same as for the actions mapping.
+	queues map[string][]*queuedWalletAction
+	// lastQueueWaitTime is the time the most recently started action spent
+	// waiting in the queue.
+	lastQueueWaitTime time.Duration
+	// getCurrentBlockFn is a function used to get the current block.
+	getCurrentBlockFn getCurrentBlockFn
 }
 
-func newWalletDispatcher() *walletDispatcher {
+func newWalletDispatcher(getCurrentBlockFn getCurrentBlockFn) *walletDispatcher {
 	return &walletDispatcher{
-		actions: make(map[string]WalletActionType),
+		actions:           make(map[string]WalletActionType),
+		queues:            make(map[string][]*queuedWalletAction),
+		getCurrentBlockFn: getCurrentBlockFn,
 	}
 }
 
 // dispatch sends the given walletAction for execution. If the wallet is
-// already busy, an errWalletBusy error is returned and the action is ignored.
+// already busy, the action is queued and executed once the wallet becomes
+// idle, unless it expires or its signing times out in the meantime. If the wallet's queue is full,
+// an errWalletBusy error is returned and the action is ignored.
 func (wd *walletDispatcher) dispatch(action walletAction) error {
 	wd.actionsMutex.Lock()
 	defer wd.actionsMutex.Unlock()
 
-	walletPublicKeyBytes, err := marshalPublicKey(action.wallet().publicKey)
+	key, walletActionLogger, err := wd.actionContext(action)
 	if err != nil {
-		return fmt.Errorf("cannot marshal wallet public key: [%v]", err)
+		return err
 	}
 
-	walletActionLogger := logger.With(
-		zap.String("wallet", fmt.Sprintf("0x%x", walletPublicKeyBytes)),
-		zap.String("action", action.actionType().String()),
-	)
+	if _, ok := wd.actions[key]; ok {
+		if len(wd.queues[key]) >= walletDispatcherQueueCapacity {
+			return errWalletBusy
+		}
 
-	key := hex.EncodeToString(walletPublicKeyBytes)
+		wd.queues[key] = append(wd.queues[key], &queuedWalletAction{
+			action:   action,
+			queuedAt: time.Now(),
+		})
 
-	if _, ok := wd.actions[key]; ok {
-		return errWalletBusy
+		walletActionLogger.Infof(
+			"wallet is busy with action [%s]; action queued; "+
+				"[%v] action(s) waiting in the queue",
+			wd.actions[key],
+			len(wd.queues[key]),
+		)
+
+		return nil
 	}
 
+	wd.start(key, action, walletActionLogger)
+
+	return nil
+}
+
+// start starts the execution of the given walletAction. Once the action
+// completes, the next queued action of the wallet is started, if any. This
+// function must be called with the actionsMutex held.
+func (wd *walletDispatcher) start(
+	key string,
+	action walletAction,
+	walletActionLogger log.StandardLogger,
+) {
 	wd.actions[key] = action.actionType()
 
 	go func() {
 		defer func() {
 			wd.actionsMutex.Lock()
+			defer wd.actionsMutex.Unlock()
+
 			delete(wd.actions, key)
-			wd.actionsMutex.Unlock()
+
+			next, ok := wd.dequeue(key)
+			if !ok {
+				return
+			}
+
+			_, nextActionLogger, err := wd.actionContext(next)
+			if err != nil {
+				logger.Errorf("cannot start queued action: [%v]", err)
+				return
+			}
+
+			nextActionLogger.Infof("starting queued action")
+
+			wd.start(key, next, nextActionLogger)
 		}()
 
 		walletActionLogger.Infof("starting action execution")
@@ -178,8 +292,140 @@ func (wd *walletDispatcher) dispatch(action walletAction) error {
 
 		walletActionLogger.Infof("action execution terminated with success")
 	}()
+}
 
-	return nil
+// dequeue removes the action with the highest priority from the queue of
+// the given wallet and returns it. Actions with the same priority are
+// dequeued in the order they were dispatched. Expired actions are dropped
+// from the queue. Actions whose signing timed out are dropped as well. The
+// signing start block of an action is set when the action is dispatched and
+// is the same for all signing group members so, an action that waited in the
+// queue for too long would fail signing right away. The returned bool value
+// is false if there is no action that can be started. This function must be
+// called with the actionsMutex held.
+func (wd *walletDispatcher) dequeue(key string) (walletAction, bool) {
+	now := time.Now()
+
+	currentBlock, err := wd.getCurrentBlockFn()
+	if err != nil {
+		// Do not drop actions based on the signing timeout if the current
+		// block is not known. Signing of such actions fails on its own.
+		logger.Warnf("cannot get current block: [%v]", err)
+		currentBlock = 0
+	}
+
+	queue := make([]*queuedWalletAction, 0, len(wd.queues[key]))
+	for _, queued := range wd.queues[key] {
+		if !now.Before(queued.action.expiresAt()) {
+			logger.Warnf(
+				"dropping queued action [%s] of wallet [0x%s]; "+
+					"action expired at [%s] after waiting [%v] in the queue",
+				queued.action.actionType(),
+				key,
+				queued.action.expiresAt().UTC().Format(time.RFC3339),
+				now.Sub(queued.queuedAt).Round(time.Second),
+			)
+			continue
+		}
+
+		if currentBlock >= queued.action.signingTimeoutBlock() {
+			logger.Warnf(
+				"dropping queued action [%s] of wallet [0x%s]; "+
+					"action signing timed out at block [%v] after "+
+					"waiting [%v] in the queue",
+				queued.action.actionType(),
+				key,
+				queued.action.signingTimeoutBlock(),
+				now.Sub(queued.queuedAt).Round(time.Second),
+			)
+			continue
+		}
+
+		queue = append(queue, queued)
+	}
+
+	if len(queue) == 0 {
+		delete(wd.queues, key)
+		return nil, false
+	}
+
+	nextIndex := 0
+	for i, queued := range queue {
+		if queued.action.actionType().dispatchPriority() >
+			queue[nextIndex].action.actionType().dispatchPriority() {
+			nextIndex = i
+		}
+	}
+
+	next := queue[nextIndex]
+	wd.queues[key] = append(queue[:nextIndex], queue[nextIndex+1:]...)
+	wd.lastQueueWaitTime = now.Sub(next.queuedAt)
+
+	return next.action, true
+}
+
+// actionContext returns the key of the wallet the given walletAction is bound
+// to and the logger that should be used for the action.
+func (wd *walletDispatcher) actionContext(
+	action walletAction,
+) (string, log.StandardLogger, error) {
+	walletPublicKeyBytes, err := marshalPublicKey(action.wallet().publicKey)
+	if err != nil {
+		return "", nil, fmt.Errorf(
+			"cannot marshal wallet public key: [%v]",
+			err,
+		)
+	}
+
+	walletActionLogger := logger.With(
+		zap.String("wallet", fmt.Sprintf("0x%x", walletPublicKeyBytes)),
+		zap.String("action", action.actionType().String()),
+	)
+
+	return hex.EncodeToString(walletPublicKeyBytes), walletActionLogger, nil
+}
+
+// queueDepth returns the total number of actions waiting for execution
+// across all wallets.
+func (wd *walletDispatcher) queueDepth() int {
+	wd.actionsMutex.Lock()
+	defer wd.actionsMutex.Unlock()
+
+	depth := 0
+	for _, queue := range wd.queues {
+		depth += len(queue)
+	}
+
+	return depth
+}
+
+// queueMaxWaitTime returns the time the longest waiting action has been
+// waiting in the queue so far, across all wallets.
+func (wd *walletDispatcher) queueMaxWaitTime() time.Duration {
+	wd.actionsMutex.Lock()
+	defer wd.actionsMutex.Unlock()
+
+	now := time.Now()
+
+	maxWaitTime := time.Duration(0)
+	for _, queue := range wd.queues {
+		for _, queued := range queue {
+			if waitTime := now.Sub(queued.queuedAt); waitTime > maxWaitTime {
+				maxWaitTime = waitTime
+			}
+		}
+	}
+
+	return maxWaitTime
+}
+
+// queueLastWaitTime returns the time the most recently started queued action
+// spent waiting in the queue.
+func (wd *walletDispatcher) queueLastWaitTime() time.Duration {
+	wd.actionsMutex.Lock()
+	defer wd.actionsMutex.Unlock()
+
+	return wd.lastQueueWaitTime
 }
 
 // walletSigningExecutor is an interface meant to decouple the specific
@@ -189,7 +435,23 @@ type walletSigningExecutor interface {
 		ctx context.Context,
 		messages []*big.Int,
 		startBlock uint64,
-	) ([]*tecdsa.Signature, error)
+	) ([]*signingResult, error)
+
+	recordSignatures(
+		actionType WalletActionType,
+		transactionHash bitcoin.Hash,
+		messages []*big.Int,
+		results []*signingResult,
+	) error
+
+	evaluateSigningPolicy(
+		actionType WalletActionType,
+		unsignedTx *bitcoin.TransactionBuilder,
+	) error
+
+	countSignedTransaction(transactionHash bitcoin.Hash)
+
+	releaseSigningPolicyReservation()
 }
 
 // walletTransactionExecutor is a component allowing to sign and broadcast
@@ -199,29 +461,66 @@ type walletTransactionExecutor struct {
 
 	executingWallet wallet
 	signingExecutor walletSigningExecutor
+
+	// actionType is the type of the wallet action the signed transactions
+	// belong to. It is recorded in the signing audit log.
+	actionType WalletActionType
+
+	// pendingTransactions is the registry signed transactions are recorded
+	// in so their fees can be bumped by the fee bump action. If nil, signed
+	// transactions are not recorded and do not signal replace-by-fee.
+	pendingTransactions *pendingWalletTransactions
 }
 
 func newWalletTransactionExecutor(
 	btcChain bitcoin.Chain,
 	executingWallet wallet,
 	signingExecutor walletSigningExecutor,
+	actionType WalletActionType,
+	pendingTransactions *pendingWalletTransactions,
 ) *walletTransactionExecutor {
 	return &walletTransactionExecutor{
-		btcChain:        btcChain,
-		executingWallet: executingWallet,
-		signingExecutor: signingExecutor,
+		btcChain:            btcChain,
+		executingWallet:     executingWallet,
+		signingExecutor:     signingExecutor,
+		actionType:          actionType,
+		pendingTransactions: pendingTransactions,
 	}
 }
 
 // signTransaction performs signing of an unsigned Bitcoin transaction
 // and returns a signed transaction ready to be broadcasted over the
-// Bitcoin network.
+// Bitcoin network. If signed transactions are recorded for fee bumping,
+// the transaction is made replaceable before signing.
 func (wte *walletTransactionExecutor) signTransaction(
 	signTxLogger log.StandardLogger,
 	unsignedTx *bitcoin.TransactionBuilder,
 	signingStartBlock uint64,
 	signingTimesOutAt time.Time,
 ) (*bitcoin.Transaction, error) {
+	if wte.pendingTransactions != nil {
+		unsignedTx.SignalReplaceByFee()
+	}
+
+	signTxLogger.Infof("evaluating transaction against signing policy")
+
+	err := wte.signingExecutor.evaluateSigningPolicy(wte.actionType, unsignedTx)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"transaction refused by signing policy: [%v]",
+			err,
+		)
+	}
+
+	// The transaction holds a slot of the signing policy rate limit from
+	// now on. Release it if the transaction does not get signed.
+	signed := false
+	defer func() {
+		if !signed {
+			wte.signingExecutor.releaseSigningPolicyReservation()
+		}
+	}()
+
 	signTxLogger.Infof("computing transaction's sig hashes")
 
 	sigHashes, err := unsignedTx.ComputeSignatureHashes()
@@ -240,7 +539,7 @@ func (wte *walletTransactionExecutor) signTransaction(
 	)
 	defer cancelSigningCtx()
 
-	signatures, err := wte.signingExecutor.signBatch(
+	results, err := wte.signingExecutor.signBatch(
 		signingCtx,
 		sigHashes,
 		signingStartBlock,
@@ -254,11 +553,11 @@ func (wte *walletTransactionExecutor) signTransaction(
 
 	signTxLogger.Infof("applying transaction's signatures")
 
-	containers := make([]*bitcoin.SignatureContainer, len(signatures))
-	for i, signature := range signatures {
+	containers := make([]*bitcoin.SignatureContainer, len(results))
+	for i, result := range results {
 		containers[i] = &bitcoin.SignatureContainer{
-			R:         signature.R,
-			S:         signature.S,
+			R:         result.signature.R,
+			S:         result.signature.S,
 			PublicKey: wte.executingWallet.publicKey,
 		}
 	}
@@ -271,11 +570,73 @@ func (wte *walletTransactionExecutor) signTransaction(
 		)
 	}
 
+	// Count the transaction against the signing policy rate limit only
+	// once it is actually signed.
+	wte.signingExecutor.countSignedTransaction(tx.Hash())
+	signed = true
+
+	signTxLogger.Infof("recording transaction's signatures in audit log")
+
+	// The transaction is already signed at this point so, failing to record
+	// its signatures should not prevent the transaction from being used.
+	err = wte.signingExecutor.recordSignatures(
+		wte.actionType,
+		tx.Hash(),
+		sigHashes,
+		results,
+	)
+	if err != nil {
+		signTxLogger.Errorf(
+			"cannot record transaction's signatures in audit log: [%v]",
+			err,
+		)
+	}
+
 	signTxLogger.Infof("transaction created successfully")
 
 	return tx, nil
 }
 
+// recordPendingTransaction records the given signed transaction as the
+// wallet's pending transaction so its fee can be bumped later. It must be
+// called before the transaction is broadcast. All signers have the transaction
+// at this point, regardless of whether their broadcast succeeds, so its fee
+// can be bumped if it gets stuck. Replacement transactions are assembled from
+// the given proposal the transaction was assembled from, with the replacement
+// fee, and the given wallet main UTXO. This function does nothing if signed
+// transactions are not recorded for fee bumping. The transaction is not
+// recorded if the current Bitcoin chain height cannot be determined as it is
+// not possible to tell when the transaction gets stuck then.
+func (wte *walletTransactionExecutor) recordPendingTransaction(
+	tx *bitcoin.Transaction,
+	walletMainUtxo *bitcoin.UnspentTransactionOutput,
+	proposal walletTransactionProposal,
+) {
+	if wte.pendingTransactions == nil {
+		return
+	}
+
+	signedAtBlockHeight, err := wte.btcChain.GetLatestBlockHeight()
+	if err != nil {
+		logger.Warnf(
+			"cannot record pending transaction [%s] of wallet PKH [0x%x]; "+
+				"cannot get latest Bitcoin block height: [%v]",
+			tx.Hash().Hex(bitcoin.ReversedByteOrder),
+			bitcoin.PublicKeyHash(wte.executingWallet.publicKey),
+			err,
+		)
+		return
+	}
+
+	wte.pendingTransactions.record(
+		wte.executingWallet.publicKey,
+		tx,
+		signedAtBlockHeight,
+		walletMainUtxo,
+		proposal,
+	)
+}
+
 // broadcastTransaction broadcasts a signed Bitcoin transaction until
 // the transaction lands in the Bitcoin mempool or the provided timeout
 // is hit, whichever comes first.
@@ -0,0 +1,294 @@
+package tbtc
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/keep-network/keep-common/pkg/persistence"
+
+	"github.com/keep-network/keep-core/pkg/bitcoin"
+	"github.com/keep-network/keep-core/pkg/protocol/group"
+)
+
+// walletArchiveReconciliationInterval determines how often the node checks
+// the on-chain state of wallets it controls in order to archive finished
+// ones.
+const walletArchiveReconciliationInterval = 6 * time.Hour
+
+// archiveFinishedWallets checks the on-chain state of all wallets registered
+// in the wallet registry and archives those that are either closed or
+// terminated. Signers of archived wallets are moved to the archive of the
+// key store persistence and removed from the node's caches along with their
+// broadcast channel subscriptions. Errors related to specific wallets are
+// logged and do not prevent processing of other wallets.
+func (n *node) archiveFinishedWallets() {
+	for _, walletPublicKey := range n.walletRegistry.getWalletsPublicKeys() {
+		walletPublicKeyHash := bitcoin.PublicKeyHash(walletPublicKey)
+
+		walletChainData, err := n.chain.GetWallet(walletPublicKeyHash)
+		if err != nil {
+			logger.Errorf(
+				"cannot get on-chain data of wallet PKH [0x%x]: [%v]",
+				walletPublicKeyHash,
+				err,
+			)
+			continue
+		}
+
+		if walletChainData.State != StateClosed &&
+			walletChainData.State != StateTerminated {
+			continue
+		}
+
+		logger.Infof(
+			"wallet PKH [0x%x] is in the [%s] state; archiving its signers",
+			walletPublicKeyHash,
+			walletChainData.State,
+		)
+
+		if err := n.walletRegistry.archiveWallet(walletPublicKeyHash); err != nil {
+			logger.Errorf(
+				"cannot archive wallet PKH [0x%x]: [%v]",
+				walletPublicKeyHash,
+				err,
+			)
+			continue
+		}
+
+		if err := n.removeWalletExecutors(walletPublicKey); err != nil {
+			logger.Errorf(
+				"cannot remove executors of archived wallet PKH [0x%x]: [%v]",
+				walletPublicKeyHash,
+				err,
+			)
+			continue
+		}
+
+		logger.Infof("wallet PKH [0x%x] archived", walletPublicKeyHash)
+	}
+}
+
+// runWalletArchiveReconciliation periodically archives wallets that are
+// closed or terminated on-chain, until the given context is done.
+func (n *node) runWalletArchiveReconciliation(ctx context.Context) {
+	ticker := time.NewTicker(walletArchiveReconciliationInterval)
+	defer ticker.Stop()
+
+	for {
+		n.archiveFinishedWallets()
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// ArchivedWallet represents a wallet whose signers were archived by the node.
+type ArchivedWallet struct {
+	WalletPublicKeyHash [20]byte
+	// MembersIndexes holds signing group member indexes of all archived
+	// signers of the wallet, in ascending order.
+	MembersIndexes []group.MemberIndex
+}
+
+// archivedSigner is a signer read from the archive along with the location
+// it was read from.
+type archivedSigner struct {
+	directory string
+	name      string
+	content   []byte
+	signer    *signer
+}
+
+// LoadArchivedWallets loads wallets whose signers were archived in the given
+// key store archive persistence. Archived wallets are sorted by their public
+// key hashes. The returned error, if any, denotes that some archived signers
+// cannot be loaded. In that case, wallets of signers that could be loaded are
+// still returned.
+func LoadArchivedWallets(
+	archive persistence.BasicHandle,
+) ([]*ArchivedWallet, error) {
+	signers, err := readArchivedSigners(archive)
+
+	walletsByKey := make(map[[20]byte]*ArchivedWallet)
+	for _, archived := range signers {
+		walletPublicKeyHash := bitcoin.PublicKeyHash(
+			archived.signer.wallet.publicKey,
+		)
+
+		wallet, ok := walletsByKey[walletPublicKeyHash]
+		if !ok {
+			wallet = &ArchivedWallet{WalletPublicKeyHash: walletPublicKeyHash}
+			walletsByKey[walletPublicKeyHash] = wallet
+		}
+
+		wallet.MembersIndexes = append(
+			wallet.MembersIndexes,
+			archived.signer.signingGroupMemberIndex,
+		)
+	}
+
+	wallets := make([]*ArchivedWallet, 0, len(walletsByKey))
+	for _, wallet := range walletsByKey {
+		sort.Slice(wallet.MembersIndexes, func(i, j int) bool {
+			return wallet.MembersIndexes[i] < wallet.MembersIndexes[j]
+		})
+		wallets = append(wallets, wallet)
+	}
+
+	sort.Slice(wallets, func(i, j int) bool {
+		return bytes.Compare(
+			wallets[i].WalletPublicKeyHash[:],
+			wallets[j].WalletPublicKeyHash[:],
+		) < 0
+	})
+
+	return wallets, err
+}
+
+// RestoreArchivedWallet moves all archived signers of the given wallet from
+// the key store archive persistence back to the key store persistence so they
+// are loaded by the wallet registry on the next client start. The client must
+// not be running while the wallet is restored. Returns the number of restored
+// signers.
+func RestoreArchivedWallet(
+	archive persistence.BasicHandle,
+	keyStore persistence.ProtectedHandle,
+	walletPublicKeyHash [20]byte,
+) (int, error) {
+	signers, err := readArchivedSigners(archive)
+	if err != nil {
+		return 0, fmt.Errorf("cannot read archived signers: [%v]", err)
+	}
+
+	restored := 0
+	for _, archived := range signers {
+		if bitcoin.PublicKeyHash(archived.signer.wallet.publicKey) !=
+			walletPublicKeyHash {
+			continue
+		}
+
+		// The signer is saved first and removed from the archive afterward
+		// so it is never lost, even if the restoration is interrupted.
+		err := keyStore.Save(archived.content, archived.directory, archived.name)
+		if err != nil {
+			return restored, fmt.Errorf(
+				"cannot save signer [%v/%v]: [%v]",
+				archived.directory,
+				archived.name,
+				err,
+			)
+		}
+
+		err = archive.Delete(archived.directory, archived.name)
+		if err != nil {
+			return restored, fmt.Errorf(
+				"cannot remove signer [%v/%v] from the archive: [%v]",
+				archived.directory,
+				archived.name,
+				err,
+			)
+		}
+
+		restored++
+	}
+
+	if restored == 0 {
+		return 0, fmt.Errorf(
+			"no archived signers found for wallet PKH [0x%x]",
+			walletPublicKeyHash,
+		)
+	}
+
+	return restored, nil
+}
+
+// readArchivedSigners reads all signers stored in the given key store archive
+// persistence.
+func readArchivedSigners(
+	archive persistence.BasicHandle,
+) ([]*archivedSigner, error) {
+	signers := make([]*archivedSigner, 0)
+	errs := make([]error, 0)
+
+	descriptorsChan, errorsChan := archive.ReadAll()
+
+	// Two goroutines read from descriptors and errors channels and either
+	// add the signer to the result slice or record an error.
+	// The reason for using two goroutines at the same time - one for
+	// descriptors and one for errors - is that channels do not have to be
+	// buffered, and we do not know in what order the information is written to
+	// channels.
+	var wg sync.WaitGroup
+	wg.Add(2)
+
+	var errsMutex sync.Mutex
+	addErr := func(err error) {
+		errsMutex.Lock()
+		defer errsMutex.Unlock()
+
+		errs = append(errs, err)
+	}
+
+	go func() {
+		for descriptor := range descriptorsChan {
+			content, err := descriptor.Content()
+			if err != nil {
+				addErr(fmt.Errorf(
+					"cannot get content from file [%v] in directory [%v]: [%v]",
+					descriptor.Name(),
+					descriptor.Directory(),
+					err,
+				))
+				continue
+			}
+
+			signer := &signer{}
+			if err := signer.Unmarshal(content); err != nil {
+				addErr(fmt.Errorf(
+					"cannot unmarshal signer from file [%v] "+
+						"in directory [%v]: [%v]",
+					descriptor.Name(),
+					descriptor.Directory(),
+					err,
+				))
+				continue
+			}
+
+			signers = append(signers, &archivedSigner{
+				directory: descriptor.Directory(),
+				name:      descriptor.Name(),
+				content:   content,
+				signer:    signer,
+			})
+		}
+
+		wg.Done()
+	}()
+
+	go func() {
+		for err := range errorsChan {
+			addErr(fmt.Errorf("cannot read archived signer: [%v]", err))
+		}
+
+		wg.Done()
+	}()
+
+	wg.Wait()
+
+	if len(errs) > 0 {
+		return signers, fmt.Errorf(
+			"[%v] archived signers cannot be loaded; first error: [%v]",
+			len(errs),
+			errs[0],
+		)
+	}
+
+	return signers, nil
+}
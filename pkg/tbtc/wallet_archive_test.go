@@ -0,0 +1,196 @@
+package tbtc
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/keep-network/keep-core/internal/testutils"
+	"github.com/keep-network/keep-core/pkg/bitcoin"
+	"github.com/keep-network/keep-core/pkg/generator"
+	"github.com/keep-network/keep-core/pkg/net/local"
+	"github.com/keep-network/keep-core/pkg/protocol/group"
+)
+
+func TestNode_ArchiveFinishedWallets(t *testing.T) {
+	groupParameters := &GroupParameters{
+		GroupSize:       5,
+		GroupQuorum:     4,
+		HonestThreshold: 3,
+	}
+
+	localChain := Connect()
+	localProvider := local.Connect()
+
+	signer := createMockSigner(t)
+
+	// Populate the mock keystore with the mock signer's data. This is
+	// required to make the node controlling the signer's wallet.
+	keyStorePersistence := createMockKeyStorePersistence(t, signer)
+
+	node, err := newNode(
+		groupParameters,
+		localChain,
+		newLocalBitcoinChain(),
+		localProvider,
+		keyStorePersistence,
+		&mockPersistenceHandle{},
+		generator.StartScheduler(),
+		&mockCoordinationProposalGenerator{},
+		Config{},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	walletPublicKey := signer.wallet.publicKey
+	walletPublicKeyHash := bitcoin.PublicKeyHash(walletPublicKey)
+
+	_, ok, err := node.getSigningExecutor(walletPublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ok {
+		t.Fatal("node is supposed to control wallet signers")
+	}
+
+	_, ok, err = node.getCoordinationExecutor(walletPublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ok {
+		t.Fatal("node is supposed to control wallet signers")
+	}
+
+	// A live wallet must not be archived.
+	localChain.setWallet(walletPublicKeyHash, &WalletChainData{
+		State: StateLive,
+	})
+
+	node.archiveFinishedWallets()
+
+	testutils.AssertIntsEqual(
+		t,
+		"registered wallets count",
+		1,
+		len(node.walletRegistry.getWalletsPublicKeys()),
+	)
+	testutils.AssertIntsEqual(
+		t,
+		"signing executors count",
+		1,
+		len(node.signingExecutors),
+	)
+
+	// A closed wallet must be archived.
+	localChain.setWallet(walletPublicKeyHash, &WalletChainData{
+		State: StateClosed,
+	})
+
+	node.archiveFinishedWallets()
+
+	testutils.AssertIntsEqual(
+		t,
+		"registered wallets count",
+		0,
+		len(node.walletRegistry.getWalletsPublicKeys()),
+	)
+	testutils.AssertIntsEqual(
+		t,
+		"signing executors count",
+		0,
+		len(node.signingExecutors),
+	)
+	testutils.AssertIntsEqual(
+		t,
+		"coordination executors count",
+		0,
+		len(node.coordinationExecutors),
+	)
+	testutils.AssertIntsEqual(
+		t,
+		"persisted wallet signers count",
+		0,
+		len(keyStorePersistence.saved),
+	)
+	testutils.AssertIntsEqual(
+		t,
+		"archived wallet signers count",
+		1,
+		len(keyStorePersistence.archived),
+	)
+
+	_, ok, err = node.getSigningExecutor(walletPublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ok {
+		t.Fatal("node is not supposed to control archived wallet signers")
+	}
+}
+
+func TestRestoreArchivedWallet(t *testing.T) {
+	signer := createMockSigner(t)
+
+	walletPublicKeyHash := bitcoin.PublicKeyHash(signer.wallet.publicKey)
+
+	// The archive persistence holds data in the same layout as the key
+	// store persistence so, it can be populated in the same way.
+	archivePersistence := createMockKeyStorePersistence(t, signer)
+	keyStorePersistence := &mockPersistenceHandle{}
+
+	archivedWallets, err := LoadArchivedWallets(archivePersistence)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedArchivedWallets := []*ArchivedWallet{
+		{
+			WalletPublicKeyHash: walletPublicKeyHash,
+			MembersIndexes: []group.MemberIndex{
+				signer.signingGroupMemberIndex,
+			},
+		},
+	}
+	if !reflect.DeepEqual(expectedArchivedWallets, archivedWallets) {
+		t.Errorf(
+			"unexpected archived wallets\nexpected: %v\nactual:   %v",
+			expectedArchivedWallets,
+			archivedWallets,
+		)
+	}
+
+	// Restoring a wallet that is not archived should fail.
+	_, err = RestoreArchivedWallet(
+		archivePersistence,
+		keyStorePersistence,
+		[20]byte{1},
+	)
+	if err == nil {
+		t.Errorf("expected error when restoring non-archived wallet")
+	}
+
+	restored, err := RestoreArchivedWallet(
+		archivePersistence,
+		keyStorePersistence,
+		walletPublicKeyHash,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertIntsEqual(t, "restored signers count", 1, restored)
+	testutils.AssertIntsEqual(
+		t,
+		"archived wallet signers count",
+		0,
+		len(archivePersistence.saved),
+	)
+
+	// The restored wallet should be loaded by the wallet registry.
+	walletRegistry := newWalletRegistry(keyStorePersistence)
+
+	signers := walletRegistry.getSigners(signer.wallet.publicKey)
+	if len(signers) != 1 || !reflect.DeepEqual(signer, signers[0]) {
+		t.Errorf("restored wallet signer differs from the original one")
+	}
+}
@@ -13,6 +13,7 @@ import (
 	"math/big"
 	"reflect"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -51,9 +52,13 @@ func TestParseWalletActionType(t *testing.T) {
 			value:          5,
 			expectedAction: ActionMovedFundsSweep,
 		},
+		"fee bump": {
+			value:          6,
+			expectedAction: ActionFeeBump,
+		},
 		"unknown": {
-			value:       6,
-			expectedErr: fmt.Errorf("unknown wallet action type [6]"),
+			value:       7,
+			expectedErr: fmt.Errorf("unknown wallet action type [7]"),
 		},
 	}
 
@@ -81,11 +86,17 @@ func TestParseWalletActionType(t *testing.T) {
 }
 
 func TestWalletDispatcher_Dispatch(t *testing.T) {
-	walletDispatcher := newWalletDispatcher()
+	currentBlock := uint64(100)
+	walletDispatcher := newWalletDispatcher(func() (uint64, error) {
+		return currentBlock, nil
+	})
 
 	wallet1 := generateWallet(big.NewInt(100))
 	wallet2 := generateWallet(big.NewInt(101))
 
+	expiresAt := time.Now().Add(time.Hour)
+	signingTimesOutAt := signingTimeoutBlock(currentBlock)
+
 	// Ctx for first actions of both wallets.
 	ctxActions1, cancelCtxActions1 := context.WithCancel(context.Background())
 	defer cancelCtxActions1()
@@ -93,33 +104,46 @@ func TestWalletDispatcher_Dispatch(t *testing.T) {
 	ctxActions2, cancelCtxActions2 := context.WithCancel(context.Background())
 	defer cancelCtxActions2()
 
+	wallet1Action2Started := make(chan struct{})
+	wallet2Action2Started := make(chan struct{})
+
 	wallet1Action1 := &mockWalletAction{
 		executeFn: func() error {
 			<-ctxActions1.Done()
 			return nil // complete with success
 		},
-		actionWallet: wallet1,
+		actionWallet:              wallet1,
+		actionExpiresAt:           expiresAt,
+		actionSigningTimeoutBlock: signingTimesOutAt,
 	}
 	wallet1Action2 := &mockWalletAction{
 		executeFn: func() error {
+			close(wallet1Action2Started)
 			<-ctxActions2.Done()
 			return nil // complete with success
 		},
-		actionWallet: wallet1,
+		actionWallet:              wallet1,
+		actionExpiresAt:           expiresAt,
+		actionSigningTimeoutBlock: signingTimesOutAt,
 	}
 	wallet2Action1 := &mockWalletAction{
 		executeFn: func() error {
 			<-ctxActions1.Done()
 			return fmt.Errorf("unexpected error") // complete with error
 		},
-		actionWallet: wallet2,
+		actionWallet:              wallet2,
+		actionExpiresAt:           expiresAt,
+		actionSigningTimeoutBlock: signingTimesOutAt,
 	}
 	wallet2Action2 := &mockWalletAction{
 		executeFn: func() error {
+			close(wallet2Action2Started)
 			<-ctxActions2.Done()
 			return nil // complete with success
 		},
-		actionWallet: wallet2,
+		actionWallet:              wallet2,
+		actionExpiresAt:           expiresAt,
+		actionSigningTimeoutBlock: signingTimesOutAt,
 	}
 
 	// Dispatch Action 1 for Wallet 1.
@@ -134,40 +158,244 @@ func TestWalletDispatcher_Dispatch(t *testing.T) {
 		t.Errorf("unexpected error: [%v]", err)
 	}
 
-	// Try to dispatch Action 1 for Wallet 1 again.
+	// Dispatch Action 2 for Wallet 1. It should be queued.
+	err = walletDispatcher.dispatch(wallet1Action2)
+	if err != nil {
+		t.Errorf("unexpected error: [%v]", err)
+	}
+
+	// Dispatch Action 2 for Wallet 2. It should be queued.
+	err = walletDispatcher.dispatch(wallet2Action2)
+	if err != nil {
+		t.Errorf("unexpected error: [%v]", err)
+	}
+
+	testutils.AssertIntsEqual(
+		t,
+		"queue depth",
+		2,
+		walletDispatcher.queueDepth(),
+	)
+
+	// Complete dispatched actions. Queued actions should be started.
+	cancelCtxActions1()
+
+	for _, started := range []chan struct{}{
+		wallet1Action2Started,
+		wallet2Action2Started,
+	} {
+		select {
+		case <-started:
+		case <-time.After(5 * time.Second):
+			t.Fatal("queued action was not started")
+		}
+	}
+
+	testutils.AssertIntsEqual(
+		t,
+		"queue depth",
+		0,
+		walletDispatcher.queueDepth(),
+	)
+
+	// Fill the queue of Wallet 1. The next action should be rejected.
+	for i := 0; i < walletDispatcherQueueCapacity; i++ {
+		err = walletDispatcher.dispatch(wallet1Action1)
+		if err != nil {
+			t.Errorf("unexpected error: [%v]", err)
+		}
+	}
+
 	err = walletDispatcher.dispatch(wallet1Action1)
 	testutils.AssertErrorsSame(t, errWalletBusy, err)
+}
 
-	// Try to dispatch Action 1 for Wallet 2 again.
-	err = walletDispatcher.dispatch(wallet2Action1)
-	testutils.AssertErrorsSame(t, errWalletBusy, err)
+func TestWalletDispatcher_Dispatch_Priority(t *testing.T) {
+	currentBlock := uint64(100)
+	walletDispatcher := newWalletDispatcher(func() (uint64, error) {
+		return currentBlock, nil
+	})
+	signingTimesOutAt := signingTimeoutBlock(currentBlock)
+
+	wallet := generateWallet(big.NewInt(100))
+
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	defer cancelCtx()
+
+	executedActions := make(chan WalletActionType, 10)
+
+	newAction := func(
+		actionType WalletActionType,
+		expiresAt time.Time,
+	) *mockWalletAction {
+		return &mockWalletAction{
+			executeFn: func() error {
+				executedActions <- actionType
+				return nil
+			},
+			actionWallet:              wallet,
+			actionTypeValue:           actionType,
+			actionExpiresAt:           expiresAt,
+			actionSigningTimeoutBlock: signingTimesOutAt,
+		}
+	}
 
-	// Try to dispatch Action 2 for Wallet 1.
-	err = walletDispatcher.dispatch(wallet1Action2)
-	testutils.AssertErrorsSame(t, errWalletBusy, err)
+	notExpired := time.Now().Add(time.Hour)
+	expired := time.Now().Add(-time.Hour)
 
-	// Try to dispatch Action 2 for Wallet 2.
-	err = walletDispatcher.dispatch(wallet2Action2)
-	testutils.AssertErrorsSame(t, errWalletBusy, err)
+	blockingAction := &mockWalletAction{
+		executeFn: func() error {
+			<-ctx.Done()
+			return nil
+		},
+		actionWallet:              wallet,
+		actionTypeValue:           ActionHeartbeat,
+		actionExpiresAt:           notExpired,
+		actionSigningTimeoutBlock: signingTimesOutAt,
+	}
 
-	// Complete dispatched actions.
-	cancelCtxActions1()
-	<-ctxActions1.Done()
+	err := walletDispatcher.dispatch(blockingAction)
+	if err != nil {
+		t.Fatal(err)
+	}
 
-	// Give some time to release the lock.
-	time.Sleep(1 * time.Second)
+	// Queue actions in the reverse order of their priority. The expired
+	// moving funds action should be dropped.
+	queuedActions := []*mockWalletAction{
+		newAction(ActionHeartbeat, notExpired),
+		newAction(ActionDepositSweep, notExpired),
+		newAction(ActionMovingFunds, expired),
+		newAction(ActionRedemption, notExpired),
+	}
 
-	// Dispatch Action 2 for Wallet 1.
-	err = walletDispatcher.dispatch(wallet1Action2)
+	for _, action := range queuedActions {
+		err := walletDispatcher.dispatch(action)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	testutils.AssertIntsEqual(
+		t,
+		"queue depth",
+		len(queuedActions),
+		walletDispatcher.queueDepth(),
+	)
+
+	if walletDispatcher.queueMaxWaitTime() <= 0 {
+		t.Errorf("queue max wait time should be positive")
+	}
+
+	// Complete the blocking action.
+	cancelCtx()
+
+	expectedOrder := []WalletActionType{
+		ActionRedemption,
+		ActionDepositSweep,
+		ActionHeartbeat,
+	}
+
+	for i, expectedAction := range expectedOrder {
+		select {
+		case action := <-executedActions:
+			if action != expectedAction {
+				t.Errorf(
+					"unexpected action [%v]\nexpected: [%v]\nactual:   [%v]",
+					i,
+					expectedAction,
+					action,
+				)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("action [%v] was not executed", i)
+		}
+	}
+
+	select {
+	case action := <-executedActions:
+		t.Errorf("unexpected action executed: [%v]", action)
+	case <-time.After(1 * time.Second):
+	}
+
+	testutils.AssertIntsEqual(
+		t,
+		"queue depth",
+		0,
+		walletDispatcher.queueDepth(),
+	)
+
+	if walletDispatcher.queueLastWaitTime() <= 0 {
+		t.Errorf("queue last wait time should be positive")
+	}
+}
+
+func TestWalletDispatcher_Dispatch_SigningTimedOut(t *testing.T) {
+	currentBlock := uint64(1000)
+
+	walletDispatcher := newWalletDispatcher(func() (uint64, error) {
+		return atomic.LoadUint64(&currentBlock), nil
+	})
+
+	wallet := generateWallet(big.NewInt(100))
+
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	defer cancelCtx()
+
+	executedActions := make(chan WalletActionType, 10)
+
+	// All actions are dispatched at the same block and their time-based
+	// expiration is far in the future.
+	expiresAt := time.Now().Add(time.Hour)
+	signingTimesOutAt := signingTimeoutBlock(currentBlock)
+
+	blockingAction := &mockWalletAction{
+		executeFn: func() error {
+			<-ctx.Done()
+			return nil
+		},
+		actionWallet:              wallet,
+		actionTypeValue:           ActionDepositSweep,
+		actionExpiresAt:           expiresAt,
+		actionSigningTimeoutBlock: signingTimesOutAt,
+	}
+
+	err := walletDispatcher.dispatch(blockingAction)
 	if err != nil {
-		t.Errorf("unexpected error: [%v]", err)
+		t.Fatal(err)
 	}
 
-	// Dispatch Action 2 for Wallet 2.
-	err = walletDispatcher.dispatch(wallet2Action2)
+	queuedAction := &mockWalletAction{
+		executeFn: func() error {
+			executedActions <- ActionRedemption
+			return nil
+		},
+		actionWallet:              wallet,
+		actionTypeValue:           ActionRedemption,
+		actionExpiresAt:           expiresAt,
+		actionSigningTimeoutBlock: signingTimesOutAt,
+	}
+
+	err = walletDispatcher.dispatch(queuedAction)
 	if err != nil {
-		t.Errorf("unexpected error: [%v]", err)
+		t.Fatal(err)
 	}
+
+	// The blocking action outlives the signing window of the queued action.
+	atomic.StoreUint64(&currentBlock, signingTimesOutAt)
+	cancelCtx()
+
+	select {
+	case action := <-executedActions:
+		t.Errorf("unexpected action executed: [%v]", action)
+	case <-time.After(1 * time.Second):
+	}
+
+	testutils.AssertIntsEqual(
+		t,
+		"queue depth",
+		0,
+		walletDispatcher.queueDepth(),
+	)
 }
 
 func TestDetermineWalletMainUtxo(t *testing.T) {
@@ -387,8 +615,11 @@ func TestWallet_MembersByOperator(t *testing.T) {
 }
 
 type mockWalletAction struct {
-	executeFn    func() error
-	actionWallet wallet
+	executeFn                 func() error
+	actionWallet              wallet
+	actionTypeValue           WalletActionType
+	actionExpiresAt           time.Time
+	actionSigningTimeoutBlock uint64
 }
 
 func (mwa *mockWalletAction) execute() error {
@@ -400,7 +631,15 @@ func (mwa *mockWalletAction) wallet() wallet {
 }
 
 func (mwa *mockWalletAction) actionType() WalletActionType {
-	return ActionNoop
+	return mwa.actionTypeValue
+}
+
+func (mwa *mockWalletAction) expiresAt() time.Time {
+	return mwa.actionExpiresAt
+}
+
+func (mwa *mockWalletAction) signingTimeoutBlock() uint64 {
+	return mwa.actionSigningTimeoutBlock
 }
 
 func generateWallet(privateKey *big.Int) wallet {
@@ -431,7 +670,7 @@ func (mwse *mockWalletSigningExecutor) signBatch(
 	ctx context.Context,
 	messages []*big.Int,
 	startBlock uint64,
-) ([]*tecdsa.Signature, error) {
+) ([]*signingResult, error) {
 	mwse.signaturesMutex.Lock()
 	defer mwse.signaturesMutex.Unlock()
 
@@ -442,7 +681,39 @@ func (mwse *mockWalletSigningExecutor) signBatch(
 		return nil, fmt.Errorf("signing error")
 	}
 
-	return signatures, nil
+	results := make([]*signingResult, len(signatures))
+	for i, signature := range signatures {
+		results[i] = &signingResult{
+			signature: signature,
+			endBlock:  startBlock,
+		}
+	}
+
+	return results, nil
+}
+
+func (mwse *mockWalletSigningExecutor) recordSignatures(
+	actionType WalletActionType,
+	transactionHash bitcoin.Hash,
+	messages []*big.Int,
+	results []*signingResult,
+) error {
+	return nil
+}
+
+func (mwse *mockWalletSigningExecutor) evaluateSigningPolicy(
+	actionType WalletActionType,
+	unsignedTx *bitcoin.TransactionBuilder,
+) error {
+	return nil
+}
+
+func (mwse *mockWalletSigningExecutor) countSignedTransaction(
+	transactionHash bitcoin.Hash,
+) {
+}
+
+func (mwse *mockWalletSigningExecutor) releaseSigningPolicyReservation() {
 }
 
 func (mwse *mockWalletSigningExecutor) setSignatures(
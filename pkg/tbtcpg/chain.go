@@ -0,0 +1,33 @@
+package tbtcpg
+
+import (
+	"github.com/keep-network/keep-core/pkg/bitcoin"
+	walletmtr "github.com/keep-network/keep-core/pkg/maintainer/wallet"
+	"github.com/keep-network/keep-core/pkg/tbtc"
+)
+
+// Chain represents the interface that the tBTC proposal generator expects
+// to interact with the anchoring blockchain on.
+type Chain interface {
+	walletmtr.Chain
+	tbtc.BridgeChain
+
+	// ValidateMovingFundsProposal validates the given moving funds proposal
+	// against the chain. The wallet's main UTXO must be provided as it
+	// is the only input of the moving funds transaction. Returns an error
+	// if the proposal is not valid or nil otherwise.
+	ValidateMovingFundsProposal(
+		walletMainUtxo *bitcoin.UnspentTransactionOutput,
+		proposal *tbtc.MovingFundsProposal,
+	) error
+
+	// ValidateMovedFundsSweepProposal validates the given moved funds sweep
+	// proposal against the chain. The wallet's main UTXO must be provided
+	// if the wallet has one as it becomes an input of the moved funds sweep
+	// transaction. Returns an error if the proposal is not valid or nil
+	// otherwise.
+	ValidateMovedFundsSweepProposal(
+		walletMainUtxo *bitcoin.UnspentTransactionOutput,
+		proposal *tbtc.MovedFundsSweepProposal,
+	) error
+}
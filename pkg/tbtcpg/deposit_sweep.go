@@ -0,0 +1,119 @@
+package tbtcpg
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/keep-network/keep-core/pkg/bitcoin"
+	walletmtr "github.com/keep-network/keep-core/pkg/maintainer/wallet"
+	"github.com/keep-network/keep-core/pkg/tbtc"
+)
+
+// generateDepositSweepProposal generates a deposit sweep proposal for the
+// given wallet. Quarantined deposits are not included in the proposal.
+// The returned boolean flag is false if there are no deposits that can be
+// swept by the wallet.
+func (pg *ProposalGenerator) generateDepositSweepProposal(
+	walletPublicKeyHash [20]byte,
+	quarantinedDeposits []*tbtc.QuarantinedDeposit,
+) (tbtc.CoordinationProposal, bool, error) {
+	depositSweepMaxSize, err := pg.chain.GetDepositSweepMaxSize()
+	if err != nil {
+		return nil, false, fmt.Errorf(
+			"failed to get deposit sweep max size: [%v]",
+			err,
+		)
+	}
+
+	excludedDeposits := make(
+		[]*walletmtr.DepositReference,
+		len(quarantinedDeposits),
+	)
+	for i, quarantinedDeposit := range quarantinedDeposits {
+		excludedDeposits[i] = &walletmtr.DepositReference{
+			FundingTxHash:      quarantinedDeposit.FundingTxHash,
+			FundingOutputIndex: quarantinedDeposit.FundingOutputIndex,
+			RevealBlock:        quarantinedDeposit.RevealBlock,
+		}
+	}
+
+	_, deposits, err := walletmtr.FindDepositsToSweep(
+		pg.chain,
+		pg.btcChain,
+		walletPublicKeyHash,
+		depositSweepMaxSize,
+		pg.depositSweepRefundLocktimeSafetyMargin,
+		excludedDeposits,
+	)
+	if err != nil {
+		return nil, false, fmt.Errorf(
+			"failed to find deposits to sweep: [%v]",
+			err,
+		)
+	}
+
+	if len(deposits) == 0 {
+		return nil, false, nil
+	}
+
+	fees, err := walletmtr.EstimateDepositsSweepFee(
+		pg.chain,
+		pg.btcChain,
+		len(deposits),
+	)
+	if err != nil {
+		return nil, false, fmt.Errorf(
+			"cannot estimate sweep transaction fee: [%v]",
+			err,
+		)
+	}
+
+	fee, ok := fees[len(deposits)]
+	if !ok {
+		return nil, false, fmt.Errorf(
+			"missing sweep transaction fee estimation for [%v] deposits",
+			len(deposits),
+		)
+	}
+
+	depositsKeys := make([]struct {
+		FundingTxHash      bitcoin.Hash
+		FundingOutputIndex uint32
+	}, len(deposits))
+
+	depositsRevealBlocks := make([]*big.Int, len(deposits))
+
+	for i, deposit := range deposits {
+		depositsKeys[i] = struct {
+			FundingTxHash      bitcoin.Hash
+			FundingOutputIndex uint32
+		}{
+			FundingTxHash:      deposit.FundingTxHash,
+			FundingOutputIndex: deposit.FundingOutputIndex,
+		}
+		depositsRevealBlocks[i] = big.NewInt(int64(deposit.RevealBlock))
+	}
+
+	proposal := &tbtc.DepositSweepProposal{
+		WalletPublicKeyHash:  walletPublicKeyHash,
+		DepositsKeys:         depositsKeys,
+		SweepTxFee:           big.NewInt(fee.TotalFee),
+		DepositsRevealBlocks: depositsRevealBlocks,
+	}
+
+	if _, err := tbtc.ValidateDepositSweepProposal(
+		logger,
+		proposal,
+		tbtc.DepositSweepRequiredFundingTxConfirmations,
+		pg.depositSweepRefundLocktimeSafetyMargin,
+		pg.chain,
+		pg.btcChain,
+	); err != nil {
+		return nil, false, fmt.Errorf(
+			"failed to verify deposit sweep proposal: [%v]",
+			err,
+		)
+	}
+
+	return proposal, true, nil
+}
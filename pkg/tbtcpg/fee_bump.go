@@ -0,0 +1,80 @@
+package tbtcpg
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/keep-network/keep-core/pkg/bitcoin"
+	"github.com/keep-network/keep-core/pkg/tbtc"
+)
+
+// feeBumpStuckTransactionBlocks is the number of Bitcoin blocks that must be
+// mined since the pending wallet transaction was signed for the transaction
+// to be considered stuck. A transaction is given some time to get confirmed
+// before its fee is bumped, as fee estimates change over time and a fresh
+// transaction paying a fee slightly lower than the current estimate can
+// still be mined soon.
+const feeBumpStuckTransactionBlocks = 6
+
+// generateFeeBumpProposal generates a fee bump proposal for the given
+// pending wallet transaction. The pending transaction is replaced only if
+// it is stuck, i.e. it has not been confirmed for at least
+// feeBumpStuckTransactionBlocks, and the fee estimated for it is higher
+// than the fee it pays. The replacement fee is the estimated fee but not
+// lower than the minimum fee required to replace the transaction.
+// The returned boolean flag is false if there is no pending transaction or
+// its fee does not need to be bumped.
+func (pg *ProposalGenerator) generateFeeBumpProposal(
+	pendingTransaction *tbtc.PendingWalletTransaction,
+) (tbtc.CoordinationProposal, bool, error) {
+	if pendingTransaction == nil {
+		return nil, false, nil
+	}
+
+	latestBlockHeight, err := pg.btcChain.GetLatestBlockHeight()
+	if err != nil {
+		return nil, false, fmt.Errorf(
+			"cannot get latest Bitcoin block height: [%v]",
+			err,
+		)
+	}
+
+	if latestBlockHeight < pendingTransaction.SignedAtBlockHeight+
+		feeBumpStuckTransactionBlocks {
+		logger.Infof(
+			"pending transaction [%s] was signed at block [%v] and is "+
+				"not considered stuck at block [%v] yet",
+			pendingTransaction.Transaction.Hash().Hex(bitcoin.ReversedByteOrder),
+			pendingTransaction.SignedAtBlockHeight,
+			latestBlockHeight,
+		)
+		return nil, false, nil
+	}
+
+	estimatedFee, err := bitcoin.NewTransactionFeeEstimator(
+		pg.btcChain,
+	).EstimateFee(pendingTransaction.Transaction.VirtualSize())
+	if err != nil {
+		return nil, false, fmt.Errorf(
+			"cannot estimate pending transaction fee: [%v]",
+			err,
+		)
+	}
+
+	if estimatedFee <= pendingTransaction.Fee {
+		return nil, false, nil
+	}
+
+	fee := tbtc.MinimumReplacementFee(
+		pendingTransaction.Transaction,
+		pendingTransaction.Fee,
+	)
+	if estimatedFee > fee {
+		fee = estimatedFee
+	}
+
+	return &tbtc.FeeBumpProposal{
+		TransactionHash: pendingTransaction.Transaction.Hash(),
+		TransactionFee:  big.NewInt(fee),
+	}, true, nil
+}
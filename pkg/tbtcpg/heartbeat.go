@@ -0,0 +1,35 @@
+package tbtcpg
+
+import (
+	"fmt"
+
+	"github.com/keep-network/keep-core/pkg/tbtc"
+)
+
+// generateHeartbeatProposal generates a heartbeat proposal for the given
+// wallet. The heartbeat message is built for the current block number. A
+// heartbeat can always be proposed so, the returned boolean flag is false
+// only if an error occurred.
+func (pg *ProposalGenerator) generateHeartbeatProposal(
+	walletPublicKeyHash [20]byte,
+) (tbtc.CoordinationProposal, bool, error) {
+	blockCounter, err := pg.chain.BlockCounter()
+	if err != nil {
+		return nil, false, fmt.Errorf(
+			"failed to get block counter: [%v]",
+			err,
+		)
+	}
+
+	currentBlock, err := blockCounter.CurrentBlock()
+	if err != nil {
+		return nil, false, fmt.Errorf(
+			"failed to get current block: [%v]",
+			err,
+		)
+	}
+
+	return &tbtc.HeartbeatProposal{
+		Message: tbtc.NewHeartbeatMessage(currentBlock),
+	}, true, nil
+}
@@ -0,0 +1,150 @@
+package tbtcpg
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/keep-network/keep-core/pkg/bitcoin"
+	"github.com/keep-network/keep-core/pkg/tbtc"
+)
+
+// generateMovedFundsSweepProposal generates a moved funds sweep proposal for
+// the given wallet. Pending moved funds sweep requests are found among the
+// wallet's confirmed unspent outputs, as each request points to a moving
+// funds transaction output locked on the sweeping wallet. The oldest pending
+// request is proposed. The returned boolean flag is false if the wallet has
+// no pending moved funds sweep requests.
+func (pg *ProposalGenerator) generateMovedFundsSweepProposal(
+	walletPublicKeyHash [20]byte,
+) (tbtc.CoordinationProposal, bool, error) {
+	walletChainData, err := pg.chain.GetWallet(walletPublicKeyHash)
+	if err != nil {
+		return nil, false, fmt.Errorf(
+			"cannot get on-chain data for wallet: [%v]",
+			err,
+		)
+	}
+
+	if walletChainData.State != tbtc.StateLive &&
+		walletChainData.State != tbtc.StateMovingFunds {
+		return nil, false, nil
+	}
+
+	if walletChainData.PendingMovedFundsSweepRequestsCount == 0 {
+		return nil, false, nil
+	}
+
+	utxos, err := pg.btcChain.GetUtxosForPublicKeyHash(walletPublicKeyHash)
+	if err != nil {
+		return nil, false, fmt.Errorf(
+			"cannot get wallet's unspent outputs: [%v]",
+			err,
+		)
+	}
+
+	var movedFundsUtxo *bitcoin.UnspentTransactionOutput
+	var movedFundsRequest *tbtc.MovedFundsSweepRequest
+
+	for _, utxo := range utxos {
+		request, found, err := pg.chain.GetMovedFundsSweepRequest(
+			utxo.Outpoint.TransactionHash,
+			utxo.Outpoint.OutputIndex,
+		)
+		if err != nil {
+			return nil, false, fmt.Errorf(
+				"cannot get moved funds sweep request for output [%s:%d]: [%v]",
+				utxo.Outpoint.TransactionHash.Hex(bitcoin.ReversedByteOrder),
+				utxo.Outpoint.OutputIndex,
+				err,
+			)
+		}
+
+		if !found ||
+			request.State != tbtc.MovedFundsSweepRequestStatePending ||
+			request.WalletPublicKeyHash != walletPublicKeyHash {
+			continue
+		}
+
+		if movedFundsRequest == nil ||
+			request.CreatedAt.Before(movedFundsRequest.CreatedAt) {
+			movedFundsUtxo = utxo
+			movedFundsRequest = request
+		}
+	}
+
+	if movedFundsRequest == nil {
+		return nil, false, nil
+	}
+
+	walletMainUtxo, err := tbtc.DetermineWalletMainUtxo(
+		walletPublicKeyHash,
+		pg.chain,
+		pg.btcChain,
+	)
+	if err != nil {
+		return nil, false, fmt.Errorf(
+			"cannot determine wallet's main UTXO: [%v]",
+			err,
+		)
+	}
+
+	fee, err := estimateMovedFundsSweepFee(pg.btcChain, walletMainUtxo != nil)
+	if err != nil {
+		return nil, false, fmt.Errorf(
+			"cannot estimate moved funds sweep transaction fee: [%v]",
+			err,
+		)
+	}
+
+	proposal := &tbtc.MovedFundsSweepProposal{
+		WalletPublicKeyHash:      walletPublicKeyHash,
+		MovingFundsTxHash:        movedFundsUtxo.Outpoint.TransactionHash,
+		MovingFundsTxOutputIndex: movedFundsUtxo.Outpoint.OutputIndex,
+		SweepTxFee:               big.NewInt(fee),
+	}
+
+	if _, err := tbtc.ValidateMovedFundsSweepProposal(
+		logger,
+		walletMainUtxo,
+		proposal,
+		pg.chain,
+	); err != nil {
+		return nil, false, fmt.Errorf(
+			"failed to verify moved funds sweep proposal: [%v]",
+			err,
+		)
+	}
+
+	return proposal, true, nil
+}
+
+// estimateMovedFundsSweepFee estimates the fee of the moved funds sweep
+// transaction. The transaction spends the moved funds UTXO and the wallet's
+// main UTXO, if the wallet has one.
+func estimateMovedFundsSweepFee(
+	btcChain bitcoin.Chain,
+	hasMainUtxo bool,
+) (int64, error) {
+	inputsCount := 1
+	if hasMainUtxo {
+		inputsCount++
+	}
+
+	transactionSize, err := bitcoin.NewTransactionSizeEstimator().
+		// P2WPKH moved funds UTXO input and optional main UTXO input.
+		AddPublicKeyHashInputs(inputsCount, true).
+		// 1 P2WPKH output being the wallet's new main UTXO.
+		AddPublicKeyHashOutputs(1, true).
+		VirtualSize()
+	if err != nil {
+		return 0, fmt.Errorf("cannot estimate transaction virtual size: [%v]", err)
+	}
+
+	totalFee, err := bitcoin.NewTransactionFeeEstimator(btcChain).
+		EstimateFee(transactionSize)
+	if err != nil {
+		return 0, fmt.Errorf("cannot estimate transaction fee: [%v]", err)
+	}
+
+	return totalFee, nil
+}
@@ -0,0 +1,181 @@
+package tbtcpg
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/keep-network/keep-core/pkg/bitcoin"
+	"github.com/keep-network/keep-core/pkg/tbtc"
+)
+
+// movingFundsCommitmentLookBackMarginBlocks is the number of blocks added
+// to the estimated number of blocks that passed since the wallet entered
+// the MovingFunds state, when looking for the target wallets commitment.
+// This margin accounts for the block time being different from the
+// assumed average.
+const movingFundsCommitmentLookBackMarginBlocks = 1000
+
+// generateMovingFundsProposal generates a moving funds proposal for the
+// given wallet. The wallet must be in the MovingFunds state and must have
+// its target wallets commitment submitted on-chain. Funds are moved to the
+// target wallets from the latest commitment submitted for the wallet.
+// The returned boolean flag is false if the wallet is not supposed to move
+// its funds at the moment.
+func (pg *ProposalGenerator) generateMovingFundsProposal(
+	walletPublicKeyHash [20]byte,
+) (tbtc.CoordinationProposal, bool, error) {
+	walletChainData, err := pg.chain.GetWallet(walletPublicKeyHash)
+	if err != nil {
+		return nil, false, fmt.Errorf(
+			"cannot get on-chain data for wallet: [%v]",
+			err,
+		)
+	}
+
+	if walletChainData.State != tbtc.StateMovingFunds {
+		return nil, false, nil
+	}
+
+	if walletChainData.MovingFundsTargetWalletsCommitmentHash == [32]byte{} {
+		logger.Infof(
+			"wallet [0x%x] has no target wallets commitment submitted yet",
+			walletPublicKeyHash,
+		)
+		return nil, false, nil
+	}
+
+	walletMainUtxo, err := tbtc.DetermineWalletMainUtxo(
+		walletPublicKeyHash,
+		pg.chain,
+		pg.btcChain,
+	)
+	if err != nil {
+		return nil, false, fmt.Errorf(
+			"cannot determine wallet's main UTXO: [%v]",
+			err,
+		)
+	}
+
+	// A wallet without the main UTXO has nothing to move.
+	if walletMainUtxo == nil {
+		return nil, false, nil
+	}
+
+	targetWallets, err := pg.findMovingFundsTargetWallets(
+		walletPublicKeyHash,
+		walletChainData.MovingFundsRequestedAt,
+	)
+	if err != nil {
+		return nil, false, fmt.Errorf(
+			"cannot find moving funds target wallets: [%v]",
+			err,
+		)
+	}
+
+	fee, err := estimateMovingFundsFee(pg.btcChain, len(targetWallets))
+	if err != nil {
+		return nil, false, fmt.Errorf(
+			"cannot estimate moving funds transaction fee: [%v]",
+			err,
+		)
+	}
+
+	proposal := &tbtc.MovingFundsProposal{
+		WalletPublicKeyHash: walletPublicKeyHash,
+		TargetWallets:       targetWallets,
+		MovingFundsTxFee:    big.NewInt(fee),
+	}
+
+	if err := tbtc.ValidateMovingFundsProposal(
+		logger,
+		walletMainUtxo,
+		proposal,
+		pg.chain,
+	); err != nil {
+		return nil, false, fmt.Errorf(
+			"failed to verify moving funds proposal: [%v]",
+			err,
+		)
+	}
+
+	return proposal, true, nil
+}
+
+// findMovingFundsTargetWallets finds the target wallets of the given moving
+// funds wallet using the latest target wallets commitment submitted for it.
+// The commitment can be submitted only after the wallet entered the
+// MovingFunds state so, there is no need to look for it before that point.
+// The returned target wallets are not validated against the on-chain
+// commitment hash; this is done by the moving funds proposal validation.
+func (pg *ProposalGenerator) findMovingFundsTargetWallets(
+	walletPublicKeyHash [20]byte,
+	movingFundsRequestedAt time.Time,
+) ([][20]byte, error) {
+	blockCounter, err := pg.chain.BlockCounter()
+	if err != nil {
+		return nil, fmt.Errorf("cannot get block counter: [%v]", err)
+	}
+
+	currentBlock, err := blockCounter.CurrentBlock()
+	if err != nil {
+		return nil, fmt.Errorf("cannot get current block: [%v]", err)
+	}
+
+	lookBackBlocks := uint64(time.Since(movingFundsRequestedAt)/
+		pg.chain.AverageBlockTime()) + movingFundsCommitmentLookBackMarginBlocks
+
+	startBlock := uint64(0)
+	if currentBlock > lookBackBlocks {
+		startBlock = currentBlock - lookBackBlocks
+	}
+
+	events, err := pg.chain.PastMovingFundsCommitmentSubmittedEvents(
+		&tbtc.MovingFundsCommitmentSubmittedEventFilter{
+			StartBlock:          startBlock,
+			WalletPublicKeyHash: [][20]byte{walletPublicKeyHash},
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"cannot get past moving funds commitment submitted events: [%v]",
+			err,
+		)
+	}
+
+	if len(events) == 0 {
+		return nil, fmt.Errorf("target wallets commitment event not found")
+	}
+
+	targetWallets := events[len(events)-1].TargetWallets
+	if len(targetWallets) == 0 {
+		return nil, fmt.Errorf("target wallets commitment is empty")
+	}
+
+	return targetWallets, nil
+}
+
+// estimateMovingFundsFee estimates the fee of the moving funds transaction
+// transferring the wallet's main UTXO to the given number of target wallets.
+func estimateMovingFundsFee(
+	btcChain bitcoin.Chain,
+	targetWalletsCount int,
+) (int64, error) {
+	transactionSize, err := bitcoin.NewTransactionSizeEstimator().
+		// 1 P2WPKH main UTXO input.
+		AddPublicKeyHashInputs(1, true).
+		// P2WPKH outputs locked on target wallets.
+		AddPublicKeyHashOutputs(targetWalletsCount, true).
+		VirtualSize()
+	if err != nil {
+		return 0, fmt.Errorf("cannot estimate transaction virtual size: [%v]", err)
+	}
+
+	totalFee, err := bitcoin.NewTransactionFeeEstimator(btcChain).
+		EstimateFee(transactionSize)
+	if err != nil {
+		return 0, fmt.Errorf("cannot estimate transaction fee: [%v]", err)
+	}
+
+	return totalFee, nil
+}
@@ -0,0 +1,75 @@
+package tbtcpg
+
+import (
+	"fmt"
+	"math/big"
+
+	walletmtr "github.com/keep-network/keep-core/pkg/maintainer/wallet"
+	"github.com/keep-network/keep-core/pkg/tbtc"
+)
+
+// generateRedemptionProposal generates a redemption proposal for the given
+// wallet. The returned boolean flag is false if there are no pending
+// redemption requests that can be handled by the wallet.
+func (pg *ProposalGenerator) generateRedemptionProposal(
+	walletPublicKeyHash [20]byte,
+) (tbtc.CoordinationProposal, bool, error) {
+	redemptionMaxSize, err := pg.chain.GetRedemptionMaxSize()
+	if err != nil {
+		return nil, false, fmt.Errorf(
+			"failed to get redemption max size: [%v]",
+			err,
+		)
+	}
+
+	walletsPendingRedemptions, err := walletmtr.FindPendingRedemptions(
+		pg.chain,
+		walletmtr.PendingRedemptionsFilter{
+			WalletPublicKeyHashes: [][20]byte{walletPublicKeyHash},
+			WalletsLimit:          1,
+			RequestsLimit:         redemptionMaxSize,
+			RequestAmountLimit:    walletmtr.DefaultRedemptionRequestAmountLimit,
+		},
+	)
+	if err != nil {
+		return nil, false, fmt.Errorf(
+			"failed to find pending redemption requests: [%v]",
+			err,
+		)
+	}
+
+	redeemersOutputScripts, ok := walletsPendingRedemptions[walletPublicKeyHash]
+	if !ok || len(redeemersOutputScripts) == 0 {
+		return nil, false, nil
+	}
+
+	fee, err := walletmtr.EstimateRedemptionFee(
+		pg.btcChain,
+		redeemersOutputScripts,
+	)
+	if err != nil {
+		return nil, false, fmt.Errorf(
+			"cannot estimate redemption transaction fee: [%v]",
+			err,
+		)
+	}
+
+	proposal := &tbtc.RedemptionProposal{
+		WalletPublicKeyHash:    walletPublicKeyHash,
+		RedeemersOutputScripts: redeemersOutputScripts,
+		RedemptionTxFee:        big.NewInt(fee),
+	}
+
+	if _, err := tbtc.ValidateRedemptionProposal(
+		logger,
+		proposal,
+		pg.chain,
+	); err != nil {
+		return nil, false, fmt.Errorf(
+			"failed to verify redemption proposal: [%v]",
+			err,
+		)
+	}
+
+	return proposal, true, nil
+}
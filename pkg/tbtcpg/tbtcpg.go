@@ -0,0 +1,158 @@
+// Package tbtcpg contains the tBTC coordination proposal generator used by
+// the node's coordination leader. The generator relies on the wallet
+// maintainer's logic to determine what actions can be proposed for the
+// given wallet.
+package tbtcpg
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ipfs/go-log/v2"
+
+	"github.com/keep-network/keep-core/internal/hexutils"
+	"github.com/keep-network/keep-core/pkg/bitcoin"
+	"github.com/keep-network/keep-core/pkg/tbtc"
+)
+
+var logger = log.Logger("keep-tbtcpg")
+
+// ProposalGenerator is a component responsible for generating coordination
+// proposals for tBTC wallets. It implements the
+// tbtc.CoordinationProposalGenerator interface.
+type ProposalGenerator struct {
+	chain    Chain
+	btcChain bitcoin.Chain
+
+	// depositSweepRefundLocktimeSafetyMargin determines the minimum time
+	// that must remain until the refund locktime of proposed deposits.
+	// It is never lower than tbtc.DepositSweepRefundLocktimeSafetyMargin
+	// used by signers to validate deposit sweep proposals.
+	depositSweepRefundLocktimeSafetyMargin time.Duration
+}
+
+// NewProposalGenerator returns a new proposal generator. If the given
+// deposit sweep refund locktime safety margin is zero, the default
+// tbtc.DepositSweepRefundLocktimeSafetyMargin is used. A non-zero margin
+// lower than tbtc.DepositSweepRefundLocktimeSafetyMargin is rejected as
+// signers would refuse proposals built with it.
+func NewProposalGenerator(
+	chain Chain,
+	btcChain bitcoin.Chain,
+	depositSweepRefundLocktimeSafetyMargin time.Duration,
+) (*ProposalGenerator, error) {
+	if depositSweepRefundLocktimeSafetyMargin == 0 {
+		depositSweepRefundLocktimeSafetyMargin =
+			tbtc.DepositSweepRefundLocktimeSafetyMargin
+	}
+
+	if depositSweepRefundLocktimeSafetyMargin <
+		tbtc.DepositSweepRefundLocktimeSafetyMargin {
+		return nil, fmt.Errorf(
+			"deposit sweep refund locktime safety margin [%v] is lower "+
+				"than the protocol minimum [%v]",
+			depositSweepRefundLocktimeSafetyMargin,
+			tbtc.DepositSweepRefundLocktimeSafetyMargin,
+		)
+	}
+
+	return &ProposalGenerator{
+		chain:                                  chain,
+		btcChain:                               btcChain,
+		depositSweepRefundLocktimeSafetyMargin: depositSweepRefundLocktimeSafetyMargin,
+	}, nil
+}
+
+// Generate generates a coordination proposal based on the checklist of
+// possible wallet actions held by the given request. Actions from the
+// checklist are checked in order and the proposal for the first action that
+// can be executed by the wallet is returned. If none of the actions can be
+// executed, a tbtc.NoopProposal is returned. An action whose proposal cannot
+// be generated due to an error is skipped. Quarantined deposits listed by
+// the request are never included in a deposit sweep proposal. A fee bump can
+// be proposed only for the pending transaction listed by the request.
+func (pg *ProposalGenerator) Generate(
+	request *tbtc.CoordinationProposalRequest,
+) (tbtc.CoordinationProposal, error) {
+	walletPublicKeyHash := request.WalletPublicKeyHash
+	actionsChecklist := request.ActionsChecklist
+
+	generatorLogger := logger.With(
+		"wallet", fmt.Sprintf("0x%s", hexutils.Encode(walletPublicKeyHash[:])),
+	)
+
+	generatorLogger.Infof(
+		"starting proposal generation using actions checklist [%v]",
+		actionsChecklist,
+	)
+
+	for _, action := range actionsChecklist {
+		generatorLogger.Infof("checking action [%s]", action)
+
+		var proposal tbtc.CoordinationProposal
+		var ok bool
+		var err error
+
+		switch action {
+		case tbtc.ActionFeeBump:
+			proposal, ok, err = pg.generateFeeBumpProposal(
+				request.PendingTransaction,
+			)
+		case tbtc.ActionRedemption:
+			proposal, ok, err = pg.generateRedemptionProposal(
+				walletPublicKeyHash,
+			)
+		case tbtc.ActionMovingFunds:
+			proposal, ok, err = pg.generateMovingFundsProposal(
+				walletPublicKeyHash,
+			)
+		case tbtc.ActionMovedFundsSweep:
+			proposal, ok, err = pg.generateMovedFundsSweepProposal(
+				walletPublicKeyHash,
+			)
+		case tbtc.ActionDepositSweep:
+			proposal, ok, err = pg.generateDepositSweepProposal(
+				walletPublicKeyHash,
+				request.QuarantinedDeposits,
+			)
+		case tbtc.ActionHeartbeat:
+			proposal, ok, err = pg.generateHeartbeatProposal(
+				walletPublicKeyHash,
+			)
+		default:
+			generatorLogger.Infof(
+				"proposal generation for action [%s] is not supported",
+				action,
+			)
+			continue
+		}
+
+		if err != nil {
+			// Do not let a single failing action, e.g. due to a transient
+			// chain error, prevent the remaining actions from being proposed.
+			generatorLogger.Errorf(
+				"error while generating proposal for action [%s]; "+
+					"checking the next action: [%v]",
+				action,
+				err,
+			)
+			continue
+		}
+
+		if !ok {
+			generatorLogger.Infof(
+				"action [%s] cannot be proposed at the moment",
+				action,
+			)
+			continue
+		}
+
+		generatorLogger.Infof("generated proposal for action [%s]", action)
+
+		return proposal, nil
+	}
+
+	generatorLogger.Infof("none of the actions can be proposed; proposing noop")
+
+	return &tbtc.NoopProposal{}, nil
+}
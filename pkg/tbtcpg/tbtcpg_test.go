@@ -0,0 +1,823 @@
+package tbtcpg
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/keep-network/keep-core/internal/testutils"
+	"github.com/keep-network/keep-core/pkg/bitcoin"
+	"github.com/keep-network/keep-core/pkg/chain"
+	"github.com/keep-network/keep-core/pkg/tbtc"
+)
+
+func TestProposalGenerator_Generate(t *testing.T) {
+	localChain := &localChain{
+		blockCounter: &localBlockCounter{currentBlock: 0x1020304},
+		wallets: map[[20]byte]*tbtc.WalletChainData{
+			{1}: {State: tbtc.StateLive},
+		},
+	}
+
+	generator, err := NewProposalGenerator(localChain, nil, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var tests = map[string]struct {
+		actionsChecklist []tbtc.WalletActionType
+		expectedProposal tbtc.CoordinationProposal
+	}{
+		"heartbeat action": {
+			actionsChecklist: []tbtc.WalletActionType{
+				tbtc.ActionHeartbeat,
+			},
+			expectedProposal: &tbtc.HeartbeatProposal{
+				Message: []byte{
+					0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+					0x00, 0x00, 0x00, 0x00, 0x01, 0x02, 0x03, 0x04,
+				},
+			},
+		},
+		"moving funds actions not possible followed by heartbeat action": {
+			actionsChecklist: []tbtc.WalletActionType{
+				tbtc.ActionMovingFunds,
+				tbtc.ActionMovedFundsSweep,
+				tbtc.ActionHeartbeat,
+			},
+			expectedProposal: &tbtc.HeartbeatProposal{
+				Message: []byte{
+					0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+					0x00, 0x00, 0x00, 0x00, 0x01, 0x02, 0x03, 0x04,
+				},
+			},
+		},
+		"failing redemption action followed by heartbeat action": {
+			actionsChecklist: []tbtc.WalletActionType{
+				tbtc.ActionRedemption,
+				tbtc.ActionHeartbeat,
+			},
+			expectedProposal: &tbtc.HeartbeatProposal{
+				Message: []byte{
+					0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+					0x00, 0x00, 0x00, 0x00, 0x01, 0x02, 0x03, 0x04,
+				},
+			},
+		},
+		"failing redemption action only": {
+			actionsChecklist: []tbtc.WalletActionType{
+				tbtc.ActionRedemption,
+			},
+			expectedProposal: &tbtc.NoopProposal{},
+		},
+		"moving funds actions not possible only": {
+			actionsChecklist: []tbtc.WalletActionType{
+				tbtc.ActionMovingFunds,
+				tbtc.ActionMovedFundsSweep,
+			},
+			expectedProposal: &tbtc.NoopProposal{},
+		},
+		"empty checklist": {
+			actionsChecklist: []tbtc.WalletActionType{},
+			expectedProposal: &tbtc.NoopProposal{},
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			proposal, err := generator.Generate(
+				&tbtc.CoordinationProposalRequest{
+					WalletPublicKeyHash: [20]byte{1},
+					ActionsChecklist:    test.actionsChecklist,
+				},
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(test.expectedProposal, proposal) {
+				t.Errorf(
+					"unexpected proposal\n"+
+						"expected: [%+v]\n"+
+						"actual:   [%+v]",
+					test.expectedProposal,
+					proposal,
+				)
+			}
+		})
+	}
+}
+
+func TestNewProposalGenerator_DepositSweepRefundLocktimeSafetyMargin(t *testing.T) {
+	var tests = map[string]struct {
+		margin         time.Duration
+		expectedMargin time.Duration
+		expectedErr    error
+	}{
+		"zero margin": {
+			margin:         0,
+			expectedMargin: tbtc.DepositSweepRefundLocktimeSafetyMargin,
+		},
+		"margin equal to the protocol minimum": {
+			margin:         tbtc.DepositSweepRefundLocktimeSafetyMargin,
+			expectedMargin: tbtc.DepositSweepRefundLocktimeSafetyMargin,
+		},
+		"margin greater than the protocol minimum": {
+			margin:         2 * tbtc.DepositSweepRefundLocktimeSafetyMargin,
+			expectedMargin: 2 * tbtc.DepositSweepRefundLocktimeSafetyMargin,
+		},
+		"margin lower than the protocol minimum": {
+			margin: tbtc.DepositSweepRefundLocktimeSafetyMargin - time.Second,
+			expectedErr: fmt.Errorf(
+				"deposit sweep refund locktime safety margin [%v] is lower "+
+					"than the protocol minimum [%v]",
+				tbtc.DepositSweepRefundLocktimeSafetyMargin-time.Second,
+				tbtc.DepositSweepRefundLocktimeSafetyMargin,
+			),
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			generator, err := NewProposalGenerator(
+				&localChain{},
+				&localBitcoinChain{},
+				test.margin,
+			)
+
+			if !reflect.DeepEqual(test.expectedErr, err) {
+				t.Fatalf(
+					"unexpected error\n"+
+						"expected: [%v]\n"+
+						"actual:   [%v]",
+					test.expectedErr,
+					err,
+				)
+			}
+
+			if test.expectedErr != nil {
+				return
+			}
+
+			if test.expectedMargin != generator.depositSweepRefundLocktimeSafetyMargin {
+				t.Errorf(
+					"unexpected margin\n"+
+						"expected: [%v]\n"+
+						"actual:   [%v]",
+					test.expectedMargin,
+					generator.depositSweepRefundLocktimeSafetyMargin,
+				)
+			}
+		})
+	}
+}
+
+func TestProposalGenerator_GenerateHeartbeatProposal(t *testing.T) {
+	localChain := &localChain{
+		blockCounter: &localBlockCounter{currentBlock: 100},
+	}
+
+	generator, err := NewProposalGenerator(localChain, nil, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	proposal, ok, err := generator.generateHeartbeatProposal([20]byte{1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !ok {
+		t.Fatal("heartbeat proposal should be generated")
+	}
+
+	heartbeatProposal, isHeartbeat := proposal.(*tbtc.HeartbeatProposal)
+	if !isHeartbeat {
+		t.Fatalf("unexpected proposal type: [%T]", proposal)
+	}
+
+	testutils.AssertBytesEqual(
+		t,
+		[]byte{
+			0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x64,
+		},
+		heartbeatProposal.Message,
+	)
+}
+
+func TestProposalGenerator_GenerateFeeBumpProposal(t *testing.T) {
+	pendingTransaction := &tbtc.PendingWalletTransaction{
+		Transaction: &bitcoin.Transaction{
+			Version: 1,
+			Inputs: []*bitcoin.TransactionInput{
+				{
+					Outpoint: &bitcoin.TransactionOutpoint{
+						TransactionHash: bitcoin.Hash{1},
+						OutputIndex:     0,
+					},
+					Sequence: 0xfffffffd,
+				},
+			},
+			Outputs: []*bitcoin.TransactionOutput{
+				{
+					Value:           90000,
+					PublicKeyScript: make([]byte, 22),
+				},
+			},
+		},
+		Fee:                 10000,
+		SignedAtBlockHeight: 100,
+	}
+
+	virtualSize := pendingTransaction.Transaction.VirtualSize()
+	minimumFee := tbtc.MinimumReplacementFee(
+		pendingTransaction.Transaction,
+		pendingTransaction.Fee,
+	)
+
+	stuckBlockHeight := pendingTransaction.SignedAtBlockHeight +
+		feeBumpStuckTransactionBlocks
+
+	var tests = map[string]struct {
+		pendingTransaction *tbtc.PendingWalletTransaction
+		latestBlockHeight  uint
+		satPerVByteFee     int64
+		expectedProposal   tbtc.CoordinationProposal
+	}{
+		"no pending transaction": {
+			pendingTransaction: nil,
+			latestBlockHeight:  stuckBlockHeight,
+			satPerVByteFee:     1000,
+			expectedProposal:   nil,
+		},
+		"pending transaction not stuck yet": {
+			pendingTransaction: pendingTransaction,
+			latestBlockHeight:  stuckBlockHeight - 1,
+			satPerVByteFee:     1000,
+			expectedProposal:   nil,
+		},
+		"estimated fee not higher than pending transaction fee": {
+			pendingTransaction: pendingTransaction,
+			latestBlockHeight:  stuckBlockHeight,
+			satPerVByteFee:     pendingTransaction.Fee / virtualSize,
+			expectedProposal:   nil,
+		},
+		"estimated fee higher than minimum replacement fee": {
+			pendingTransaction: pendingTransaction,
+			latestBlockHeight:  stuckBlockHeight,
+			satPerVByteFee:     1000,
+			expectedProposal: &tbtc.FeeBumpProposal{
+				TransactionHash: pendingTransaction.Transaction.Hash(),
+				TransactionFee:  big.NewInt(1000 * virtualSize),
+			},
+		},
+		"estimated fee lower than minimum replacement fee": {
+			pendingTransaction: pendingTransaction,
+			latestBlockHeight:  stuckBlockHeight,
+			satPerVByteFee:     pendingTransaction.Fee/virtualSize + 1,
+			expectedProposal: &tbtc.FeeBumpProposal{
+				TransactionHash: pendingTransaction.Transaction.Hash(),
+				TransactionFee:  big.NewInt(minimumFee),
+			},
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			generator, err := NewProposalGenerator(
+				&localChain{},
+				&localBitcoinChain{
+					satPerVByteFee:    test.satPerVByteFee,
+					latestBlockHeight: test.latestBlockHeight,
+				},
+				0,
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			proposal, ok, err := generator.generateFeeBumpProposal(
+				test.pendingTransaction,
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			testutils.AssertBoolsEqual(
+				t,
+				"proposal generated",
+				test.expectedProposal != nil,
+				ok,
+			)
+
+			if test.expectedProposal == nil {
+				return
+			}
+
+			if !reflect.DeepEqual(test.expectedProposal, proposal) {
+				t.Errorf(
+					"unexpected proposal\n"+
+						"expected: [%+v]\n"+
+						"actual:   [%+v]",
+					test.expectedProposal,
+					proposal,
+				)
+			}
+		})
+	}
+}
+
+func TestProposalGenerator_GenerateMovingFundsProposal(t *testing.T) {
+	walletPublicKeyHash := [20]byte{1}
+	targetWallets := [][20]byte{{2}, {3}}
+	satPerVByteFee := int64(10)
+
+	walletScript, err := bitcoin.PayToWitnessPublicKeyHash(walletPublicKeyHash)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mainUtxoTransaction := &bitcoin.Transaction{
+		Version: 1,
+		Inputs: []*bitcoin.TransactionInput{
+			{
+				Outpoint: &bitcoin.TransactionOutpoint{
+					TransactionHash: bitcoin.Hash{0xff},
+					OutputIndex:     0,
+				},
+				Sequence: 0xffffffff,
+			},
+		},
+		Outputs: []*bitcoin.TransactionOutput{
+			{
+				Value:           100000,
+				PublicKeyScript: walletScript,
+			},
+		},
+	}
+
+	mainUtxo := &bitcoin.UnspentTransactionOutput{
+		Outpoint: &bitcoin.TransactionOutpoint{
+			TransactionHash: mainUtxoTransaction.Hash(),
+			OutputIndex:     0,
+		},
+		Value: 100000,
+	}
+
+	transactionSize, err := bitcoin.NewTransactionSizeEstimator().
+		AddPublicKeyHashInputs(1, true).
+		AddPublicKeyHashOutputs(len(targetWallets), true).
+		VirtualSize()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var tests = map[string]struct {
+		walletState      tbtc.WalletState
+		commitmentHash   [32]byte
+		hasMainUtxo      bool
+		expectedProposal tbtc.CoordinationProposal
+	}{
+		"live wallet": {
+			walletState:      tbtc.StateLive,
+			commitmentHash:   [32]byte{1},
+			hasMainUtxo:      true,
+			expectedProposal: nil,
+		},
+		"target wallets commitment not submitted": {
+			walletState:      tbtc.StateMovingFunds,
+			commitmentHash:   [32]byte{},
+			hasMainUtxo:      true,
+			expectedProposal: nil,
+		},
+		"no main UTXO": {
+			walletState:      tbtc.StateMovingFunds,
+			commitmentHash:   [32]byte{1},
+			hasMainUtxo:      false,
+			expectedProposal: nil,
+		},
+		"funds can be moved": {
+			walletState:    tbtc.StateMovingFunds,
+			commitmentHash: [32]byte{1},
+			hasMainUtxo:    true,
+			expectedProposal: &tbtc.MovingFundsProposal{
+				WalletPublicKeyHash: walletPublicKeyHash,
+				TargetWallets:       targetWallets,
+				MovingFundsTxFee:    big.NewInt(satPerVByteFee * transactionSize),
+			},
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			walletChainData := &tbtc.WalletChainData{
+				State:                                  test.walletState,
+				MovingFundsRequestedAt:                 time.Now().Add(-time.Hour),
+				MovingFundsTargetWalletsCommitmentHash: test.commitmentHash,
+			}
+
+			localChain := &localChain{
+				blockCounter: &localBlockCounter{currentBlock: 10000},
+				wallets: map[[20]byte]*tbtc.WalletChainData{
+					walletPublicKeyHash: walletChainData,
+				},
+				movingFundsCommitments: []*tbtc.MovingFundsCommitmentSubmittedEvent{
+					{
+						WalletPublicKeyHash: [20]byte{9},
+						TargetWallets:       [][20]byte{{8}},
+					},
+					{
+						WalletPublicKeyHash: walletPublicKeyHash,
+						TargetWallets:       targetWallets,
+					},
+				},
+			}
+
+			if test.hasMainUtxo {
+				walletChainData.MainUtxoHash = localChain.ComputeMainUtxoHash(
+					mainUtxo,
+				)
+			}
+
+			generator, err := NewProposalGenerator(
+				localChain,
+				&localBitcoinChain{
+					satPerVByteFee: satPerVByteFee,
+					transactions:   []*bitcoin.Transaction{mainUtxoTransaction},
+				},
+				0,
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			proposal, ok, err := generator.generateMovingFundsProposal(
+				walletPublicKeyHash,
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			testutils.AssertBoolsEqual(
+				t,
+				"proposal generated",
+				test.expectedProposal != nil,
+				ok,
+			)
+
+			if test.expectedProposal == nil {
+				return
+			}
+
+			testutils.AssertIntsEqual(
+				t,
+				"validated proposals count",
+				1,
+				localChain.validatedProposalsCount,
+			)
+
+			if !reflect.DeepEqual(test.expectedProposal, proposal) {
+				t.Errorf(
+					"unexpected proposal\n"+
+						"expected: [%+v]\n"+
+						"actual:   [%+v]",
+					test.expectedProposal,
+					proposal,
+				)
+			}
+		})
+	}
+}
+
+func TestProposalGenerator_GenerateMovedFundsSweepProposal(t *testing.T) {
+	walletPublicKeyHash := [20]byte{1}
+	satPerVByteFee := int64(10)
+
+	utxos := []*bitcoin.UnspentTransactionOutput{
+		{
+			Outpoint: &bitcoin.TransactionOutpoint{
+				TransactionHash: bitcoin.Hash{1},
+				OutputIndex:     0,
+			},
+			Value: 10000,
+		},
+		{
+			Outpoint: &bitcoin.TransactionOutpoint{
+				TransactionHash: bitcoin.Hash{2},
+				OutputIndex:     1,
+			},
+			Value: 20000,
+		},
+		{
+			Outpoint: &bitcoin.TransactionOutpoint{
+				TransactionHash: bitcoin.Hash{3},
+				OutputIndex:     2,
+			},
+			Value: 30000,
+		},
+		{
+			Outpoint: &bitcoin.TransactionOutpoint{
+				TransactionHash: bitcoin.Hash{4},
+				OutputIndex:     0,
+			},
+			Value: 40000,
+		},
+	}
+
+	now := time.Now()
+
+	movedFundsSweepRequests := map[bitcoin.TransactionOutpoint]*tbtc.MovedFundsSweepRequest{
+		// Oldest request but already processed.
+		*utxos[0].Outpoint: {
+			WalletPublicKeyHash: walletPublicKeyHash,
+			Value:               10000,
+			CreatedAt:           now.Add(-3 * time.Hour),
+			State:               tbtc.MovedFundsSweepRequestStateProcessed,
+		},
+		*utxos[1].Outpoint: {
+			WalletPublicKeyHash: walletPublicKeyHash,
+			Value:               20000,
+			CreatedAt:           now.Add(-time.Hour),
+			State:               tbtc.MovedFundsSweepRequestStatePending,
+		},
+		// Oldest pending request that should be proposed.
+		*utxos[2].Outpoint: {
+			WalletPublicKeyHash: walletPublicKeyHash,
+			Value:               30000,
+			CreatedAt:           now.Add(-2 * time.Hour),
+			State:               tbtc.MovedFundsSweepRequestStatePending,
+		},
+		// The last UTXO has no moved funds sweep request.
+	}
+
+	transactionSize, err := bitcoin.NewTransactionSizeEstimator().
+		AddPublicKeyHashInputs(1, true).
+		AddPublicKeyHashOutputs(1, true).
+		VirtualSize()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var tests = map[string]struct {
+		walletState             tbtc.WalletState
+		pendingRequestsCount    uint32
+		movedFundsSweepRequests map[bitcoin.TransactionOutpoint]*tbtc.MovedFundsSweepRequest
+		expectedProposal        tbtc.CoordinationProposal
+	}{
+		"closed wallet": {
+			walletState:             tbtc.StateClosed,
+			pendingRequestsCount:    2,
+			movedFundsSweepRequests: movedFundsSweepRequests,
+			expectedProposal:        nil,
+		},
+		"no pending requests": {
+			walletState:             tbtc.StateLive,
+			pendingRequestsCount:    0,
+			movedFundsSweepRequests: movedFundsSweepRequests,
+			expectedProposal:        nil,
+		},
+		"pending requests not found among wallet outputs": {
+			walletState:             tbtc.StateLive,
+			pendingRequestsCount:    2,
+			movedFundsSweepRequests: nil,
+			expectedProposal:        nil,
+		},
+		"pending requests": {
+			walletState:             tbtc.StateMovingFunds,
+			pendingRequestsCount:    2,
+			movedFundsSweepRequests: movedFundsSweepRequests,
+			expectedProposal: &tbtc.MovedFundsSweepProposal{
+				WalletPublicKeyHash:      walletPublicKeyHash,
+				MovingFundsTxHash:        bitcoin.Hash{3},
+				MovingFundsTxOutputIndex: 2,
+				SweepTxFee:               big.NewInt(satPerVByteFee * transactionSize),
+			},
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			localChain := &localChain{
+				wallets: map[[20]byte]*tbtc.WalletChainData{
+					walletPublicKeyHash: {
+						State:                               test.walletState,
+						PendingMovedFundsSweepRequestsCount: test.pendingRequestsCount,
+					},
+				},
+				movedFundsSweepRequests: test.movedFundsSweepRequests,
+			}
+
+			generator, err := NewProposalGenerator(
+				localChain,
+				&localBitcoinChain{
+					satPerVByteFee: satPerVByteFee,
+					utxos:          utxos,
+				},
+				0,
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			proposal, ok, err := generator.generateMovedFundsSweepProposal(
+				walletPublicKeyHash,
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			testutils.AssertBoolsEqual(
+				t,
+				"proposal generated",
+				test.expectedProposal != nil,
+				ok,
+			)
+
+			if test.expectedProposal == nil {
+				return
+			}
+
+			testutils.AssertIntsEqual(
+				t,
+				"validated proposals count",
+				1,
+				localChain.validatedProposalsCount,
+			)
+
+			if !reflect.DeepEqual(test.expectedProposal, proposal) {
+				t.Errorf(
+					"unexpected proposal\n"+
+						"expected: [%+v]\n"+
+						"actual:   [%+v]",
+					test.expectedProposal,
+					proposal,
+				)
+			}
+		})
+	}
+}
+
+// localChain is a stub implementation of the proposal generator chain.
+// Only functions used by the tested code paths are implemented.
+type localChain struct {
+	Chain
+
+	blockCounter chain.BlockCounter
+
+	wallets                 map[[20]byte]*tbtc.WalletChainData
+	movedFundsSweepRequests map[bitcoin.TransactionOutpoint]*tbtc.MovedFundsSweepRequest
+	movingFundsCommitments  []*tbtc.MovingFundsCommitmentSubmittedEvent
+	validatedProposalsCount int
+}
+
+func (lc *localChain) BlockCounter() (chain.BlockCounter, error) {
+	return lc.blockCounter, nil
+}
+
+func (lc *localChain) AverageBlockTime() time.Duration {
+	return 12 * time.Second
+}
+
+func (lc *localChain) GetWallet(
+	walletPublicKeyHash [20]byte,
+) (*tbtc.WalletChainData, error) {
+	walletChainData, ok := lc.wallets[walletPublicKeyHash]
+	if !ok {
+		return nil, fmt.Errorf("wallet not found")
+	}
+
+	return walletChainData, nil
+}
+
+func (lc *localChain) GetRedemptionMaxSize() (uint16, error) {
+	return 0, fmt.Errorf("redemption max size not available")
+}
+
+func (lc *localChain) ComputeMainUtxoHash(
+	mainUtxo *bitcoin.UnspentTransactionOutput,
+) [32]byte {
+	outputIndexBytes := make([]byte, 4)
+	binary.BigEndian.PutUint32(outputIndexBytes, mainUtxo.Outpoint.OutputIndex)
+
+	valueBytes := make([]byte, 8)
+	binary.BigEndian.PutUint64(valueBytes, uint64(mainUtxo.Value))
+
+	return sha256.Sum256(
+		append(
+			append(
+				mainUtxo.Outpoint.TransactionHash[:],
+				outputIndexBytes...,
+			), valueBytes...,
+		),
+	)
+}
+
+func (lc *localChain) GetMovedFundsSweepRequest(
+	movingFundsTxHash bitcoin.Hash,
+	movingFundsTxOutputIndex uint32,
+) (*tbtc.MovedFundsSweepRequest, bool, error) {
+	request, ok := lc.movedFundsSweepRequests[bitcoin.TransactionOutpoint{
+		TransactionHash: movingFundsTxHash,
+		OutputIndex:     movingFundsTxOutputIndex,
+	}]
+
+	return request, ok, nil
+}
+
+func (lc *localChain) PastMovingFundsCommitmentSubmittedEvents(
+	filter *tbtc.MovingFundsCommitmentSubmittedEventFilter,
+) ([]*tbtc.MovingFundsCommitmentSubmittedEvent, error) {
+	result := make([]*tbtc.MovingFundsCommitmentSubmittedEvent, 0)
+	for _, event := range lc.movingFundsCommitments {
+		if filter != nil && len(filter.WalletPublicKeyHash) > 0 &&
+			filter.WalletPublicKeyHash[0] != event.WalletPublicKeyHash {
+			continue
+		}
+
+		result = append(result, event)
+	}
+
+	return result, nil
+}
+
+func (lc *localChain) ValidateMovingFundsProposal(
+	walletMainUtxo *bitcoin.UnspentTransactionOutput,
+	proposal *tbtc.MovingFundsProposal,
+) error {
+	lc.validatedProposalsCount++
+	return nil
+}
+
+func (lc *localChain) ValidateMovedFundsSweepProposal(
+	walletMainUtxo *bitcoin.UnspentTransactionOutput,
+	proposal *tbtc.MovedFundsSweepProposal,
+) error {
+	lc.validatedProposalsCount++
+	return nil
+}
+
+// localBlockCounter is a stub block counter returning a fixed current block.
+type localBlockCounter struct {
+	chain.BlockCounter
+
+	currentBlock uint64
+}
+
+func (lbc *localBlockCounter) CurrentBlock() (uint64, error) {
+	return lbc.currentBlock, nil
+}
+
+// localBitcoinChain is a stub implementation of the Bitcoin chain.
+// Only functions used by the tested code paths are implemented.
+type localBitcoinChain struct {
+	bitcoin.Chain
+
+	satPerVByteFee    int64
+	latestBlockHeight uint
+	transactions      []*bitcoin.Transaction
+	utxos             []*bitcoin.UnspentTransactionOutput
+}
+
+func (lbc *localBitcoinChain) GetLatestBlockHeight() (uint, error) {
+	return lbc.latestBlockHeight, nil
+}
+
+func (lbc *localBitcoinChain) EstimateSatPerVByteFee(
+	blocks uint32,
+) (int64, error) {
+	return lbc.satPerVByteFee, nil
+}
+
+func (lbc *localBitcoinChain) GetTransaction(
+	transactionHash bitcoin.Hash,
+) (*bitcoin.Transaction, error) {
+	for _, transaction := range lbc.transactions {
+		if transaction.Hash() == transactionHash {
+			return transaction, nil
+		}
+	}
+
+	return nil, fmt.Errorf("transaction not found")
+}
+
+func (lbc *localBitcoinChain) GetTxHashesForPublicKeyHash(
+	publicKeyHash [20]byte,
+) ([]bitcoin.Hash, error) {
+	hashes := make([]bitcoin.Hash, len(lbc.transactions))
+	for i, transaction := range lbc.transactions {
+		hashes[i] = transaction.Hash()
+	}
+
+	return hashes, nil
+}
+
+func (lbc *localBitcoinChain) GetUtxosForPublicKeyHash(
+	publicKeyHash [20]byte,
+) ([]*bitcoin.UnspentTransactionOutput, error) {
+	return lbc.utxos, nil
+}